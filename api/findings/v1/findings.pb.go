@@ -0,0 +1,463 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: api/findings/v1/findings.proto
+
+package findingsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Type describes how the finding changed.
+type FindingEvent_Type int32
+
+const (
+	FindingEvent_TYPE_UNSPECIFIED FindingEvent_Type = 0
+	// The finding was first detected by the scan at scan_time.
+	FindingEvent_TYPE_NEW FindingEvent_Type = 1
+	// The finding was no longer present in the scan at scan_time.
+	FindingEvent_TYPE_RESOLVED FindingEvent_Type = 2
+	// The finding was present when the watch started.
+	FindingEvent_TYPE_EXISTING FindingEvent_Type = 3
+)
+
+// Enum value maps for FindingEvent_Type.
+var (
+	FindingEvent_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "TYPE_NEW",
+		2: "TYPE_RESOLVED",
+		3: "TYPE_EXISTING",
+	}
+	FindingEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"TYPE_NEW":         1,
+		"TYPE_RESOLVED":    2,
+		"TYPE_EXISTING":    3,
+	}
+)
+
+func (x FindingEvent_Type) Enum() *FindingEvent_Type {
+	p := new(FindingEvent_Type)
+	*p = x
+	return p
+}
+
+func (x FindingEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FindingEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_findings_v1_findings_proto_enumTypes[0].Descriptor()
+}
+
+func (FindingEvent_Type) Type() protoreflect.EnumType {
+	return &file_api_findings_v1_findings_proto_enumTypes[0]
+}
+
+func (x FindingEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FindingEvent_Type.Descriptor instead.
+func (FindingEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_findings_v1_findings_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// FindingsWatchRequest selects which findings a watch receives.
+type FindingsWatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only stream findings in this namespace. Empty matches all namespaces.
+	Namespace string `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Only stream findings with this severity (error, warning, info). Empty matches all.
+	Severity string `protobuf:"bytes,2,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Send every finding from the most recent scan as EXISTING before streaming changes.
+	SendInitialState bool `protobuf:"varint,3,opt,name=send_initial_state,json=sendInitialState,proto3" json:"send_initial_state,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FindingsWatchRequest) Reset() {
+	*x = FindingsWatchRequest{}
+	mi := &file_api_findings_v1_findings_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingsWatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingsWatchRequest) ProtoMessage() {}
+
+func (x *FindingsWatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_findings_v1_findings_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingsWatchRequest.ProtoReflect.Descriptor instead.
+func (*FindingsWatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_findings_v1_findings_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FindingsWatchRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *FindingsWatchRequest) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *FindingsWatchRequest) GetSendInitialState() bool {
+	if x != nil {
+		return x.SendInitialState
+	}
+	return false
+}
+
+// Finding is a single validation finding.
+type Finding struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ResourceType     string                 `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceName     string                 `protobuf:"bytes,2,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	Namespace        string                 `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ValidationType   string                 `protobuf:"bytes,4,opt,name=validation_type,json=validationType,proto3" json:"validation_type,omitempty"`
+	ErrorCode        string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	Message          string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Severity         string                 `protobuf:"bytes,7,opt,name=severity,proto3" json:"severity,omitempty"`
+	RemediationHint  string                 `protobuf:"bytes,8,opt,name=remediation_hint,json=remediationHint,proto3" json:"remediation_hint,omitempty"`
+	RelatedResources []string               `protobuf:"bytes,9,rep,name=related_resources,json=relatedResources,proto3" json:"related_resources,omitempty"`
+	Details          map[string]string      `protobuf:"bytes,10,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Finding) Reset() {
+	*x = Finding{}
+	mi := &file_api_findings_v1_findings_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Finding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Finding) ProtoMessage() {}
+
+func (x *Finding) ProtoReflect() protoreflect.Message {
+	mi := &file_api_findings_v1_findings_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Finding.ProtoReflect.Descriptor instead.
+func (*Finding) Descriptor() ([]byte, []int) {
+	return file_api_findings_v1_findings_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Finding) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *Finding) GetResourceName() string {
+	if x != nil {
+		return x.ResourceName
+	}
+	return ""
+}
+
+func (x *Finding) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Finding) GetValidationType() string {
+	if x != nil {
+		return x.ValidationType
+	}
+	return ""
+}
+
+func (x *Finding) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *Finding) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Finding) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
+func (x *Finding) GetRemediationHint() string {
+	if x != nil {
+		return x.RemediationHint
+	}
+	return ""
+}
+
+func (x *Finding) GetRelatedResources() []string {
+	if x != nil {
+		return x.RelatedResources
+	}
+	return nil
+}
+
+func (x *Finding) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// FindingEvent reports a change to the set of findings.
+type FindingEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          FindingEvent_Type      `protobuf:"varint,1,opt,name=type,proto3,enum=kogaro.findings.v1.FindingEvent_Type" json:"type,omitempty"`
+	Finding       *Finding               `protobuf:"bytes,2,opt,name=finding,proto3" json:"finding,omitempty"`
+	ScanTime      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=scan_time,json=scanTime,proto3" json:"scan_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindingEvent) Reset() {
+	*x = FindingEvent{}
+	mi := &file_api_findings_v1_findings_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindingEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindingEvent) ProtoMessage() {}
+
+func (x *FindingEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_findings_v1_findings_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindingEvent.ProtoReflect.Descriptor instead.
+func (*FindingEvent) Descriptor() ([]byte, []int) {
+	return file_api_findings_v1_findings_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FindingEvent) GetType() FindingEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return FindingEvent_TYPE_UNSPECIFIED
+}
+
+func (x *FindingEvent) GetFinding() *Finding {
+	if x != nil {
+		return x.Finding
+	}
+	return nil
+}
+
+func (x *FindingEvent) GetScanTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScanTime
+	}
+	return nil
+}
+
+var File_api_findings_v1_findings_proto protoreflect.FileDescriptor
+
+var file_api_findings_v1_findings_proto_rawDesc = string([]byte{
+	0x0a, 0x1e, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x76,
+	0x31, 0x2f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x12, 0x6b, 0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x7e, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67,
+	0x73, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x6e, 0x64, 0x5f,
+	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x65, 0x6e, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x22, 0xc7, 0x03, 0x0a, 0x07, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72,
+	0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x65, 0x64,
+	0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x69, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x72, 0x65, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x69,
+	0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x65, 0x64, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x42, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x28, 0x2e, 0x6b, 0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e,
+	0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x44, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x1a, 0x3a, 0x0a, 0x0c, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x8b, 0x02, 0x0a, 0x0c, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x39, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25,
+	0x2e, 0x6b, 0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x66,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6b,
+	0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x66, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x50, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x4e, 0x45, 0x57, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x32, 0x70, 0x0a,
+	0x0f, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x5d, 0x0a, 0x0d, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x28, 0x2e, 0x6b, 0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x57,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x6b, 0x6f,
+	0x67, 0x61, 0x72, 0x6f, 0x2e, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x46, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x42,
+	0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x6f,
+	0x70, 0x69, 0x61, 0x72, 0x75, 0x73, 0x73, 0x2f, 0x6b, 0x6f, 0x67, 0x61, 0x72, 0x6f, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x66, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x66,
+	0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+})
+
+var (
+	file_api_findings_v1_findings_proto_rawDescOnce sync.Once
+	file_api_findings_v1_findings_proto_rawDescData []byte
+)
+
+func file_api_findings_v1_findings_proto_rawDescGZIP() []byte {
+	file_api_findings_v1_findings_proto_rawDescOnce.Do(func() {
+		file_api_findings_v1_findings_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_findings_v1_findings_proto_rawDesc), len(file_api_findings_v1_findings_proto_rawDesc)))
+	})
+	return file_api_findings_v1_findings_proto_rawDescData
+}
+
+var file_api_findings_v1_findings_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_api_findings_v1_findings_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_api_findings_v1_findings_proto_goTypes = []any{
+	(FindingEvent_Type)(0),        // 0: kogaro.findings.v1.FindingEvent.Type
+	(*FindingsWatchRequest)(nil),  // 1: kogaro.findings.v1.FindingsWatchRequest
+	(*Finding)(nil),               // 2: kogaro.findings.v1.Finding
+	(*FindingEvent)(nil),          // 3: kogaro.findings.v1.FindingEvent
+	nil,                           // 4: kogaro.findings.v1.Finding.DetailsEntry
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_api_findings_v1_findings_proto_depIdxs = []int32{
+	4, // 0: kogaro.findings.v1.Finding.details:type_name -> kogaro.findings.v1.Finding.DetailsEntry
+	0, // 1: kogaro.findings.v1.FindingEvent.type:type_name -> kogaro.findings.v1.FindingEvent.Type
+	2, // 2: kogaro.findings.v1.FindingEvent.finding:type_name -> kogaro.findings.v1.Finding
+	5, // 3: kogaro.findings.v1.FindingEvent.scan_time:type_name -> google.protobuf.Timestamp
+	1, // 4: kogaro.findings.v1.FindingsService.FindingsWatch:input_type -> kogaro.findings.v1.FindingsWatchRequest
+	3, // 5: kogaro.findings.v1.FindingsService.FindingsWatch:output_type -> kogaro.findings.v1.FindingEvent
+	5, // [5:6] is the sub-list for method output_type
+	4, // [4:5] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_findings_v1_findings_proto_init() }
+func file_api_findings_v1_findings_proto_init() {
+	if File_api_findings_v1_findings_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_findings_v1_findings_proto_rawDesc), len(file_api_findings_v1_findings_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_findings_v1_findings_proto_goTypes,
+		DependencyIndexes: file_api_findings_v1_findings_proto_depIdxs,
+		EnumInfos:         file_api_findings_v1_findings_proto_enumTypes,
+		MessageInfos:      file_api_findings_v1_findings_proto_msgTypes,
+	}.Build()
+	File_api_findings_v1_findings_proto = out.File
+	file_api_findings_v1_findings_proto_goTypes = nil
+	file_api_findings_v1_findings_proto_depIdxs = nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: api/findings/v1/findings.proto
+
+package findingsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FindingsService_FindingsWatch_FullMethodName = "/kogaro.findings.v1.FindingsService/FindingsWatch"
+)
+
+// FindingsServiceClient is the client API for FindingsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FindingsService streams validation findings to downstream automation.
+type FindingsServiceClient interface {
+	// FindingsWatch streams findings as they are detected and resolved by
+	// cluster scans. Clients that fall behind are disconnected and should
+	// reconnect with send_initial_state set to resynchronise.
+	FindingsWatch(ctx context.Context, in *FindingsWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FindingEvent], error)
+}
+
+type findingsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFindingsServiceClient(cc grpc.ClientConnInterface) FindingsServiceClient {
+	return &findingsServiceClient{cc}
+}
+
+func (c *findingsServiceClient) FindingsWatch(ctx context.Context, in *FindingsWatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FindingEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FindingsService_ServiceDesc.Streams[0], FindingsService_FindingsWatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FindingsWatchRequest, FindingEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FindingsService_FindingsWatchClient = grpc.ServerStreamingClient[FindingEvent]
+
+// FindingsServiceServer is the server API for FindingsService service.
+// All implementations must embed UnimplementedFindingsServiceServer
+// for forward compatibility.
+//
+// FindingsService streams validation findings to downstream automation.
+type FindingsServiceServer interface {
+	// FindingsWatch streams findings as they are detected and resolved by
+	// cluster scans. Clients that fall behind are disconnected and should
+	// reconnect with send_initial_state set to resynchronise.
+	FindingsWatch(*FindingsWatchRequest, grpc.ServerStreamingServer[FindingEvent]) error
+	mustEmbedUnimplementedFindingsServiceServer()
+}
+
+// UnimplementedFindingsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFindingsServiceServer struct{}
+
+func (UnimplementedFindingsServiceServer) FindingsWatch(*FindingsWatchRequest, grpc.ServerStreamingServer[FindingEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method FindingsWatch not implemented")
+}
+func (UnimplementedFindingsServiceServer) mustEmbedUnimplementedFindingsServiceServer() {}
+func (UnimplementedFindingsServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeFindingsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FindingsServiceServer will
+// result in compilation errors.
+type UnsafeFindingsServiceServer interface {
+	mustEmbedUnimplementedFindingsServiceServer()
+}
+
+func RegisterFindingsServiceServer(s grpc.ServiceRegistrar, srv FindingsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFindingsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FindingsService_ServiceDesc, srv)
+}
+
+func _FindingsService_FindingsWatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FindingsWatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FindingsServiceServer).FindingsWatch(m, &grpc.GenericServerStream[FindingsWatchRequest, FindingEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FindingsService_FindingsWatchServer = grpc.ServerStreamingServer[FindingEvent]
+
+// FindingsService_ServiceDesc is the grpc.ServiceDesc for FindingsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FindingsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kogaro.findings.v1.FindingsService",
+	HandlerType: (*FindingsServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FindingsWatch",
+			Handler:       _FindingsService_FindingsWatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/findings/v1/findings.proto",
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// managerCacheOptions returns the cache and client options of a manager: the
+// namespaces and objects the informer cache holds, whether it holds only the
+// metadata of Secrets, and the objects read from the API server instead
+func managerCacheOptions(config *FlagConfig) (cache.Options, client.Options, error) {
+	var cacheOptions cache.Options
+	var clientOptions client.Options
+
+	for _, namespace := range strings.Split(config.CacheNamespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); namespace == "" {
+			continue
+		}
+		if cacheOptions.DefaultNamespaces == nil {
+			cacheOptions.DefaultNamespaces = make(map[string]cache.Config)
+		}
+		cacheOptions.DefaultNamespaces[namespace] = cache.Config{}
+	}
+
+	byObject := make(map[schema.GroupVersionKind]*cache.ByObject)
+	err := parseCacheSelectors(config.CacheLabelSelectors, "--cache-label-selectors", byObject, func(selector string, object *cache.ByObject) error {
+		parsed, err := labels.Parse(selector)
+		object.Label = parsed
+		return err
+	})
+	if err != nil {
+		return cache.Options{}, client.Options{}, err
+	}
+	err = parseCacheSelectors(config.CacheFieldSelectors, "--cache-field-selectors", byObject, func(selector string, object *cache.ByObject) error {
+		parsed, err := fields.ParseSelector(selector)
+		object.Field = parsed
+		return err
+	})
+	if err != nil {
+		return cache.Options{}, client.Options{}, err
+	}
+	for gvk, selection := range byObject {
+		object, err := scheme.New(gvk)
+		if err != nil {
+			return cache.Options{}, client.Options{}, fmt.Errorf("failed to create %s: %w", gvk, err)
+		}
+		if cacheOptions.ByObject == nil {
+			cacheOptions.ByObject = make(map[client.Object]cache.ByObject)
+		}
+		cacheOptions.ByObject[object.(client.Object)] = *selection
+	}
+
+	// Watch only the metadata of Secrets, so that their data is never cached
+	if config.SecretMetadataOnly && config.EnableSecretHygieneValidation {
+		return cache.Options{}, client.Options{}, fmt.Errorf("--secret-metadata-only cannot be combined with --enable-secret-hygiene-validation, which reads Secret data")
+	}
+	validators.SetSecretMetadataOnly(config.SecretMetadataOnly)
+
+	// Page through Pods read from the API server rather than caching all of them, in
+	// the controller only: CLI validation overlays manifests on whole lists
+	if config.PodPageSize > 0 && config.ValidateMode == "" {
+		clientOptions.Cache = &client.CacheOptions{DisableFor: []client.Object{&corev1.Pod{}}}
+		validators.SetPodPageSize(config.PodPageSize)
+	}
+
+	return cacheOptions, clientOptions, nil
+}
+
+// parseCacheSelectors parses semicolon-separated kind=selector entries, such as
+// "Secret=kogaro.io/validate=true;Ingress.networking.k8s.io=app=web", calling set
+// with the selector of each object the kind names
+func parseCacheSelectors(value, flagName string, byObject map[schema.GroupVersionKind]*cache.ByObject, set func(selector string, object *cache.ByObject) error) error {
+	for _, entry := range strings.Split(value, ";") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		kind, selector, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(kind) == "" || strings.TrimSpace(selector) == "" {
+			return fmt.Errorf("%s entry %q must be kind=selector", flagName, entry)
+		}
+		gvks, err := resolveCacheKind(scheme, strings.TrimSpace(kind))
+		if err != nil {
+			return fmt.Errorf("%s: %w", flagName, err)
+		}
+		for _, gvk := range gvks {
+			object, ok := byObject[gvk]
+			if !ok {
+				object = &cache.ByObject{}
+				byObject[gvk] = object
+			}
+			if err := set(strings.TrimSpace(selector), object); err != nil {
+				return fmt.Errorf("%s: invalid selector for %s: %w", flagName, kind, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resolveCacheKind returns the versions of the kind a selector names, as Kind or
+// Kind.group, such as Secret or Ingress.networking.k8s.io. A kind without a group
+// names that kind in every group.
+func resolveCacheKind(scheme *runtime.Scheme, name string) ([]schema.GroupVersionKind, error) {
+	kind, group, grouped := strings.Cut(name, ".")
+	var gvks []schema.GroupVersionKind
+	for gvk := range scheme.AllKnownTypes() {
+		if gvk.Version == runtime.APIVersionInternal || !strings.EqualFold(gvk.Kind, kind) {
+			continue
+		}
+		if grouped && gvk.Group != group {
+			continue
+		}
+		gvks = append(gvks, gvk)
+	}
+	if len(gvks) == 0 {
+		return nil, fmt.Errorf("unknown kind %q", name)
+	}
+	sort.Slice(gvks, func(i, j int) bool { return gvks[i].String() < gvks[j].String() })
+	return gvks, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestManagerCacheOptions(t *testing.T) {
+	config := &FlagConfig{
+		CacheNamespaces:     "shop, blog,",
+		CacheLabelSelectors: "Secret=kogaro.io/validate=true; Ingress.networking.k8s.io=app=web",
+		CacheFieldSelectors: "secret=type!=helm.sh/release.v1",
+	}
+	cacheOptions, clientOptions, err := managerCacheOptions(config)
+	if err != nil {
+		t.Fatalf("managerCacheOptions() error = %v", err)
+	}
+	if len(cacheOptions.DefaultNamespaces) != 2 {
+		t.Errorf("DefaultNamespaces = %v, want shop and blog", cacheOptions.DefaultNamespaces)
+	}
+	if clientOptions.Cache != nil {
+		t.Errorf("client cache options = %+v, want Pods cached without a page size", clientOptions.Cache)
+	}
+
+	selection := func(kind string) cache.ByObject {
+		t.Helper()
+		for object, selection := range cacheOptions.ByObject {
+			gvks, _, err := scheme.ObjectKinds(object)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gvks[0].Kind == kind {
+				return selection
+			}
+		}
+		t.Fatalf("no selectors for %s", kind)
+		return cache.ByObject{}
+	}
+	secrets := selection("Secret")
+	if !secrets.Label.Matches(labels.Set{"kogaro.io/validate": "true"}) || secrets.Label.Matches(labels.Set{}) {
+		t.Errorf("Secret label selector = %v", secrets.Label)
+	}
+	if secrets.Field.Matches(fields.Set{"type": "helm.sh/release.v1"}) || !secrets.Field.Matches(fields.Set{"type": "Opaque"}) {
+		t.Errorf("Secret field selector = %v", secrets.Field)
+	}
+	if ingresses := selection("Ingress"); !ingresses.Label.Matches(labels.Set{"app": "web"}) || ingresses.Field != nil {
+		t.Errorf("Ingress selection = %+v", ingresses)
+	}
+	// Only the kinds named are selected, in every version of their group
+	for object := range cacheOptions.ByObject {
+		gvks, _, err := scheme.ObjectKinds(object)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gvk := gvks[0]; gvk.Kind != "Secret" && gvk.GroupKind() != networkingv1.SchemeGroupVersion.WithKind("Ingress").GroupKind() {
+			t.Errorf("%s selected without being named", gvk)
+		}
+	}
+
+	// Pods read from the API server are paged, in the controller only
+	_, clientOptions, err = managerCacheOptions(&FlagConfig{PodPageSize: 500})
+	t.Cleanup(func() { validators.SetPodPageSize(0) })
+	if err != nil || clientOptions.Cache == nil || len(clientOptions.Cache.DisableFor) != 1 {
+		t.Errorf("client options = %+v, %v, want Pods read from the API server", clientOptions, err)
+	} else if _, ok := clientOptions.Cache.DisableFor[0].(*corev1.Pod); !ok {
+		t.Errorf("DisableFor = %T, want Pods", clientOptions.Cache.DisableFor[0])
+	}
+}
+
+func TestManagerCacheOptions_Invalid(t *testing.T) {
+	tests := []FlagConfig{
+		{CacheLabelSelectors: "Secret"},
+		{CacheLabelSelectors: "Widget=app=web"},
+		{CacheLabelSelectors: "Secret=app in (web"},
+		{CacheFieldSelectors: "Ingress.apps=metadata.name=web"},
+		{CacheFieldSelectors: "=metadata.name=web"},
+		{SecretMetadataOnly: true, EnableSecretHygieneValidation: true},
+	}
+	for _, config := range tests {
+		if _, _, err := managerCacheOptions(&config); err == nil {
+			t.Errorf("managerCacheOptions(%+v) succeeded", config)
+		}
+	}
+}
@@ -0,0 +1,343 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/controllers"
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// clusterTarget is a cluster validated in multi-cluster mode
+type clusterTarget struct {
+	// Name labels the cluster's findings and metrics; defaults to the context
+	Name string `json:"name,omitempty"`
+	// Context is the kubeconfig context of the cluster
+	Context string `json:"context,omitempty"`
+	// Kubeconfig is the kubeconfig file holding the context; defaults to the standard locations
+	Kubeconfig string `json:"kubeconfig,omitempty"`
+}
+
+// clustersFile is the format of the file given by --clusters-file
+type clustersFile struct {
+	Clusters []clusterTarget `json:"clusters"`
+}
+
+// clusterRuntime holds the manager and validator registry of one validated cluster
+type clusterRuntime struct {
+	target   clusterTarget
+	mgr      ctrl.Manager
+	registry *validators.ValidatorRegistry
+}
+
+// loadClusterTargets returns the clusters given by --kubeconfig-contexts and
+// --clusters-file. No targets means Kogaro validates the single cluster of --context.
+func loadClusterTargets(config *FlagConfig) ([]clusterTarget, error) {
+	var targets []clusterTarget
+
+	for _, kubeContext := range strings.Split(config.KubeconfigContexts, ",") {
+		if kubeContext = strings.TrimSpace(kubeContext); kubeContext != "" {
+			targets = append(targets, clusterTarget{Name: kubeContext, Context: kubeContext})
+		}
+	}
+
+	if config.ClustersFile != "" {
+		data, err := os.ReadFile(config.ClustersFile) // nolint:gosec // Clusters file path is user-provided
+		if err != nil {
+			return nil, fmt.Errorf("failed to read clusters file: %w", err)
+		}
+		fileTargets, err := parseClustersFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", config.ClustersFile, err)
+		}
+		targets = append(targets, fileTargets...)
+	}
+
+	seen := make(map[string]bool)
+	for _, target := range targets {
+		if seen[target.Name] {
+			return nil, fmt.Errorf("cluster %q is configured more than once", target.Name)
+		}
+		seen[target.Name] = true
+	}
+
+	return targets, nil
+}
+
+// parseClustersFile parses a clusters file, defaulting each cluster's name to its context
+func parseClustersFile(data []byte) ([]clusterTarget, error) {
+	var file clustersFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid clusters file: %w", err)
+	}
+
+	for i := range file.Clusters {
+		target := &file.Clusters[i]
+		if target.Name == "" {
+			target.Name = target.Context
+		}
+		if target.Name == "" {
+			return nil, fmt.Errorf("cluster %d needs a name or a context", i+1)
+		}
+	}
+	return file.Clusters, nil
+}
+
+// restConfigFor loads the client configuration of a cluster from its kubeconfig
+func restConfigFor(target clusterTarget) (*rest.Config, error) {
+	if target.Kubeconfig == "" {
+		return ctrlconfig.GetConfigWithContext(target.Context)
+	}
+	return clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: target.Kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: target.Context},
+	).ClientConfig()
+}
+
+// setupClusters builds a manager and validator registry for each cluster. The first
+// cluster's manager serves metrics and health probes and holds the leader election
+// lease; the other managers only read from their clusters.
+func setupClusters(targets []clusterTarget, config *FlagConfig) ([]clusterRuntime, error) {
+	clusters := make([]clusterRuntime, 0, len(targets))
+
+	for i, target := range targets {
+		restConfig, err := restConfigFor(target)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kubeconfig for cluster %s: %w", target.Name, err)
+		}
+		configureAPIClient(restConfig, config)
+
+		cacheOptions, clientOptions, err := managerCacheOptions(config)
+		if err != nil {
+			return nil, err
+		}
+		options := ctrl.Options{
+			Scheme:  scheme,
+			Cache:   cacheOptions,
+			Client:  clientOptions,
+			Metrics: server.Options{BindAddress: "0"},
+		}
+		if i == 0 {
+			options.Metrics.BindAddress = config.MetricsAddr
+			options.HealthProbeBindAddress = config.ProbeAddr
+			options.LeaderElection = config.EnableLeaderElection
+			options.LeaderElectionID = "kogaro.io"
+			options.GracefulShutdownTimeout = gracefulShutdownTimeout(config)
+		}
+
+		mgr, err := ctrl.NewManager(restConfig, options)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create manager for cluster %s: %w", target.Name, err)
+		}
+		if err := validators.RegisterFieldIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+			return nil, fmt.Errorf("unable to index the cache of cluster %s: %w", target.Name, err)
+		}
+
+		registry := setupValidators(mgr, config)
+		registry.SetCluster(target.Name)
+		clusters = append(clusters, clusterRuntime{target: target, mgr: mgr, registry: registry})
+	}
+
+	return clusters, nil
+}
+
+// runMultiCluster validates several clusters from one process. In controller mode each
+// cluster has its own validation controller; the managers of the other clusters run
+// inside the first cluster's manager, so they only run while it holds the leader
+// election lease. The findings APIs serve the first cluster.
+func runMultiCluster(targets []clusterTarget, config *FlagConfig) {
+	clusters, err := setupClusters(targets, config)
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(failureExitCode(config))
+	}
+
+	// Register metrics
+	metrics.RegisterMetrics()
+
+	// Handle validate command
+	if config.ValidateMode != "" {
+		runMultiClusterValidationMode(clusters, config)
+		return
+	}
+
+	// The first cluster holds the leader election lease and the state of every
+	// cluster's findings for the next leader
+	primary := clusters[0]
+	store, err := setupHandoff(primary.mgr, config)
+	if err != nil {
+		setupLog.Error(err, "failed to setup finding state hand-off")
+		os.Exit(1)
+	}
+	var primaryController *controllers.ValidationController
+	validationControllers := make([]*controllers.ValidationController, 0, len(clusters))
+	for i, cluster := range clusters {
+		validationController, err := setupController(cluster.mgr, cluster.registry, config)
+		if err != nil {
+			setupLog.Error(err, "failed to setup controller", "cluster", cluster.target.Name)
+			os.Exit(1)
+		}
+		if store != nil {
+			validationController.State = store
+		}
+		setupScanListeners(cluster.mgr, cluster.registry, config, store)
+		validationControllers = append(validationControllers, validationController)
+		if i == 0 {
+			primaryController = validationController
+		}
+	}
+	for _, cluster := range clusters[1:] {
+		if err := primary.mgr.Add(cluster.mgr); err != nil {
+			setupLog.Error(err, "failed to add manager", "cluster", cluster.target.Name)
+			os.Exit(1)
+		}
+	}
+
+	// Only the first cluster's manager serves probes and metrics
+	if err := setupScanStatus(primary.mgr, validationControllers...); err != nil {
+		setupLog.Error(err, "failed to setup scan status")
+		os.Exit(1)
+	}
+
+	if err := setupAPIServers(primary.mgr, primary.registry, primaryController, config); err != nil {
+		setupLog.Error(err, "failed to setup API servers")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting managers", "clusters", len(clusters))
+	if err := primary.mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
+
+// runMultiClusterValidationMode handles one-off and monitor validation modes across
+// several clusters, merging their findings into one result
+func runMultiClusterValidationMode(clusters []clusterRuntime, config *FlagConfig) {
+	var duration time.Duration
+	if config.ValidateDuration != "" {
+		var err error
+		duration, err = time.ParseDuration(config.ValidateDuration)
+		if err != nil {
+			setupLog.Error(err, "invalid duration format")
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+	}
+
+	interval, err := time.ParseDuration(config.ValidateInterval)
+	if err != nil {
+		setupLog.Error(err, "invalid interval format")
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+
+	// Start each manager's cache briefly to allow cluster object retrieval
+	cacheCtx, cacheCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cacheCancel()
+	for _, cluster := range clusters {
+		setupLog.Info("starting manager cache for CLI validation", "cluster", cluster.target.Name)
+		go func(mgr ctrl.Manager) {
+			if err := mgr.Start(cacheCtx); err != nil && err != context.Canceled {
+				setupLog.Error(err, "failed to start manager for cache warmup")
+			}
+		}(cluster.mgr)
+	}
+	for _, cluster := range clusters {
+		if !cluster.mgr.GetCache().WaitForCacheSync(cacheCtx) {
+			setupLog.Error(nil, "failed to sync cache", "cluster", cluster.target.Name)
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+	}
+	setupLog.Info("caches synced successfully", "clusters", len(clusters))
+
+	if !slices.Contains(validOutputFormats, config.ValidateOutput) {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", strings.Join(validOutputFormats, ", "))
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+
+	ctx := context.Background()
+	if duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, duration)
+		defer cancel()
+	}
+
+	switch config.ValidateMode {
+	case "one-off":
+		result, err := validateClusters(ctx, clusters, config.ParallelClusters)
+		if err != nil {
+			setupLog.Error(err, "validation failed")
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+		emitValidationResult(clusters[0].registry, config, result)
+	case "monitor":
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := validateClusters(ctx, clusters, config.ParallelClusters); err != nil {
+					setupLog.Error(err, "validation failed")
+				}
+			}
+		}
+	default:
+		setupLog.Error(nil, "invalid validation mode", "mode", config.ValidateMode)
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+}
+
+// validateClusters runs each cluster's registry, one cluster after another or all at
+// once, and merges the findings. Every cluster is validated even when another fails.
+func validateClusters(ctx context.Context, clusters []clusterRuntime, parallel bool) (validators.ValidationResult, error) {
+	results := make([]validators.ValidationResult, len(clusters))
+	errs := make([]error, len(clusters))
+
+	validate := func(i int) {
+		cluster := clusters[i]
+		if err := cluster.registry.ValidateCluster(ctx); err != nil {
+			errs[i] = fmt.Errorf("cluster %s: %w", cluster.target.Name, err)
+			return
+		}
+		results[i] = cluster.registry.LastValidationResult()
+	}
+
+	if parallel {
+		var wg sync.WaitGroup
+		for i := range clusters {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				validate(i)
+			}(i)
+		}
+		wg.Wait()
+	} else {
+		for i := range clusters {
+			validate(i)
+		}
+	}
+
+	return validators.MergeResults(results...), errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadClusterTargets(t *testing.T) {
+	clustersFile := filepath.Join(t.TempDir(), "clusters.yaml")
+	content := `clusters:
+- context: prod-eu
+- name: prod-us
+  context: admin@prod-us
+  kubeconfig: /etc/kogaro/prod-us.kubeconfig
+`
+	if err := os.WriteFile(clustersFile, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		config  FlagConfig
+		want    []clusterTarget
+		wantErr bool
+	}{
+		{
+			name:   "single cluster",
+			config: FlagConfig{},
+		},
+		{
+			name:   "contexts flag",
+			config: FlagConfig{KubeconfigContexts: "staging, prod,"},
+			want: []clusterTarget{
+				{Name: "staging", Context: "staging"},
+				{Name: "prod", Context: "prod"},
+			},
+		},
+		{
+			name:   "contexts flag and clusters file",
+			config: FlagConfig{KubeconfigContexts: "staging", ClustersFile: clustersFile},
+			want: []clusterTarget{
+				{Name: "staging", Context: "staging"},
+				{Name: "prod-eu", Context: "prod-eu"},
+				{Name: "prod-us", Context: "admin@prod-us", Kubeconfig: "/etc/kogaro/prod-us.kubeconfig"},
+			},
+		},
+		{
+			name:    "duplicate cluster",
+			config:  FlagConfig{KubeconfigContexts: "prod-eu", ClustersFile: clustersFile},
+			wantErr: true,
+		},
+		{
+			name:    "missing clusters file",
+			config:  FlagConfig{ClustersFile: filepath.Join(t.TempDir(), "missing.yaml")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadClusterTargets(&tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadClusterTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("loadClusterTargets() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseClustersFile_RequiresName(t *testing.T) {
+	if _, err := parseClustersFile([]byte("clusters:\n- kubeconfig: /tmp/config\n")); err == nil {
+		t.Error("expected an error for a cluster without name or context")
+	}
+	if _, err := parseClustersFile([]byte("clusters:\n- contxt: prod\n")); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
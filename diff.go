@@ -0,0 +1,184 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/topiaruss/kogaro/internal/drift"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// diffCommand is the subcommand that compares the workloads of two clusters, or of a
+// directory of manifests and a cluster
+const diffCommand = "diff"
+
+// diffConfig holds the flag values of the diff subcommand
+type diffConfig struct {
+	Source      clusterTarget
+	SourceDir   string
+	Target      clusterTarget
+	Namespace   string
+	Output      string
+	OutputFile  string
+	Timeout     time.Duration
+	FailOnDrift bool
+}
+
+// runDiff compares equivalent workloads in two clusters and reports drift in images,
+// resource settings, securityContext and replica counts. With --source-dir the source
+// is a directory of expected manifests, env is compared too and live workloads missing
+// from the manifests are reported. It returns the exit code: 1 when drift is found and
+// 3 when the comparison could not run.
+func runDiff(args []string) int {
+	config := diffConfig{}
+	flags := flag.NewFlagSet(diffCommand, flag.ExitOnError)
+	flags.StringVar(&config.Source.Context, "source-context", "", "Kubeconfig context of the cluster to compare against")
+	flags.StringVar(&config.Source.Kubeconfig, "source-kubeconfig", "", "Kubeconfig file of the source cluster (defaults to the standard locations)")
+	flags.StringVar(&config.SourceDir, "source-dir", "", "Directory or file of expected manifests, such as rendered GitOps output, to compare the target cluster against")
+	flags.StringVar(&config.Target.Context, "target-context", "", "Kubeconfig context of the cluster checked for drift")
+	flags.StringVar(&config.Target.Kubeconfig, "target-kubeconfig", "", "Kubeconfig file of the target cluster (defaults to the standard locations)")
+	flags.StringVar(&config.Namespace, "namespace", "", "Only compare workloads in this namespace (defaults to all namespaces except system namespaces)")
+	flags.StringVar(&config.Output, "output", "text", "Output format: text, ci, json, yaml or markdown")
+	flags.StringVar(&config.OutputFile, "output-file", "", "Write formatted output to this file instead of stdout/stderr")
+	flags.DurationVar(&config.Timeout, "timeout", 2*time.Minute, "Maximum time to read both clusters")
+	flags.BoolVar(&config.FailOnDrift, "fail-on-drift", true, "Exit with status 1 when drift is found")
+
+	opts := zap.Options{Development: true}
+	opts.BindFlags(flags)
+	_ = flags.Parse(args)
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if (config.Source.Context == "") == (config.SourceDir == "") || config.Target.Context == "" {
+		setupLog.Error(nil, "kogaro diff requires --target-context and one of --source-context or --source-dir")
+		return validators.ExitCodeInternalFailure
+	}
+	if config.Output == "github" || !slices.Contains(validOutputFormats, config.Output) {
+		setupLog.Error(nil, "invalid output format", "output", config.Output, "valid", "text, ci, json, yaml, markdown")
+		return validators.ExitCodeInternalFailure
+	}
+	config.Source.Name = config.Source.Context
+	config.Target.Name = config.Target.Context
+
+	target, err := newDirectClient(config.Target)
+	if err != nil {
+		setupLog.Error(err, "unable to connect to target cluster", "context", config.Target.Context)
+		return validators.ExitCodeInternalFailure
+	}
+
+	var comparer *drift.Comparer
+	if config.SourceDir != "" {
+		config.Source.Name = config.SourceDir
+		files, err := validators.LoadManifestDirectory(config.SourceDir)
+		if err != nil {
+			setupLog.Error(err, "unable to load manifests", "dir", config.SourceDir)
+			return validators.ExitCodeInternalFailure
+		}
+		manifests, err := validators.NewManifestClient(files)
+		if err != nil {
+			setupLog.Error(err, "unable to parse manifests", "dir", config.SourceDir)
+			return validators.ExitCodeInternalFailure
+		}
+		comparer = drift.NewManifestComparer(manifests, target, config.Source.Name, config.Target.Name, config.Namespace, ctrl.Log)
+	} else {
+		source, err := newDirectClient(config.Source)
+		if err != nil {
+			setupLog.Error(err, "unable to connect to source cluster", "context", config.Source.Context)
+			return validators.ExitCodeInternalFailure
+		}
+		comparer = drift.NewComparer(source, target, config.Source.Name, config.Target.Name, config.Namespace, ctrl.Log)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	findings, err := comparer.Compare(ctx)
+	if err != nil {
+		setupLog.Error(err, "drift comparison failed")
+		return validators.ExitCodeInternalFailure
+	}
+
+	result := validators.ValidationResult{Errors: findings}
+	result.Summary.TotalErrors = len(findings)
+	if len(findings) > 0 && config.FailOnDrift {
+		result.ExitCode = validators.ExitCodeErrors
+	}
+
+	if err := writeDiffResult(config, result); err != nil {
+		setupLog.Error(err, "failed to write drift report")
+		return validators.ExitCodeInternalFailure
+	}
+	return result.ExitCode
+}
+
+// newDirectClient creates a client that reads a cluster without a cache
+func newDirectClient(target clusterTarget) (client.Client, error) {
+	restConfig, err := restConfigFor(target)
+	if err != nil {
+		return nil, err
+	}
+	return client.New(restConfig, client.Options{Scheme: scheme})
+}
+
+// writeDiffResult writes drift findings in the configured output format
+func writeDiffResult(config diffConfig, result validators.ValidationResult) error {
+	registry := validators.NewValidatorRegistry(setupLog, nil)
+
+	var output string
+	var err error
+	switch config.Output {
+	case "ci":
+		output, err = registry.FormatCIOutput(result)
+	case "json":
+		output, err = registry.FormatJSONOutput(result)
+	case "yaml":
+		output, err = registry.FormatYAMLOutput(result)
+	case "markdown":
+		output, err = registry.FormatMarkdownOutput(result, nil)
+	default:
+		output = formatDiffText(config, result)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+
+	if config.OutputFile != "" {
+		return os.WriteFile(config.OutputFile, []byte(strings.TrimRight(output, "\n")+"\n"), 0o600)
+	}
+	if config.Output == "ci" || config.Output == "markdown" {
+		fmt.Fprintf(os.Stderr, "%s\n", output)
+		return nil
+	}
+	fmt.Fprintf(os.Stdout, "%s\n", strings.TrimRight(output, "\n"))
+	return nil
+}
+
+// formatDiffText renders drift findings as one line per difference
+func formatDiffText(config diffConfig, result validators.ValidationResult) string {
+	if len(result.Errors) == 0 {
+		return fmt.Sprintf("No drift found between %s and %s", config.Source.Name, config.Target.Name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d differences between %s and %s:\n", len(result.Errors), config.Source.Name, config.Target.Name)
+	for _, finding := range result.Errors {
+		fmt.Fprintf(&b, "  [%s] %s/%s: %s\n", finding.ErrorCode, finding.Namespace, finding.ResourceName, finding.Message)
+		if finding.Details["source_value"] != finding.Details["target_value"] {
+			fmt.Fprintf(&b, "      %s: %s\n      %s: %s\n", config.Source.Name, finding.Details["source_value"], config.Target.Name, finding.Details["target_value"])
+		}
+	}
+	return b.String()
+}
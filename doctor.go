@@ -0,0 +1,459 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// doctorCommand is the subcommand that checks whether a cluster is ready for the
+// validators and features selected by the same flags as the controller
+const doctorCommand = "doctor"
+
+const (
+	// maxDoctorRegistries bounds the image registries whose reachability is checked
+	maxDoctorRegistries = 20
+	// maxDoctorPods bounds the pods listed to find the registries images come from
+	maxDoctorPods = 500
+)
+
+// doctorStatus is the outcome of a doctor check
+type doctorStatus string
+
+const (
+	// doctorOK means the check passed
+	doctorOK doctorStatus = "ok"
+	// doctorInfo reports something worth knowing that needs no action
+	doctorInfo doctorStatus = "info"
+	// doctorWarn means an enabled feature will work only partly
+	doctorWarn doctorStatus = "warn"
+	// doctorFail means an enabled validator or feature will not work
+	doctorFail doctorStatus = "fail"
+)
+
+// doctorCheck is one row of the readiness matrix
+type doctorCheck struct {
+	Area   string       `json:"area"`
+	Name   string       `json:"name"`
+	Status doctorStatus `json:"status"`
+	Detail string       `json:"detail,omitempty"`
+}
+
+// optionalAPI is a custom resource that a Kogaro feature or a related integration
+// relies on, and which clusters may not serve
+type optionalAPI struct {
+	name         string
+	groupVersion string
+	resource     string
+	// neededBy names the flag of the feature that cannot work without the API, if any
+	neededBy func(config *FlagConfig) string
+	// present and absent describe what the API's availability means for Kogaro
+	present, absent string
+}
+
+// optionalAPIs are the custom resources checked by kogaro doctor
+var optionalAPIs = []optionalAPI{
+	{
+		name:         "ValidationPolicy",
+		groupVersion: validators.ValidationPolicyGVK.GroupVersion().String(),
+		resource:     "validationpolicies",
+		neededBy: func(config *FlagConfig) string {
+			if config.EnableValidationPolicies {
+				return "--enable-validation-policies"
+			}
+			return ""
+		},
+		present: "validation policies can be read from the cluster",
+		absent:  "install the CRDs from charts/kogaro/crds to read validation policies from the cluster",
+	},
+	{
+		name:         "ValidationReport",
+		groupVersion: "kogaro.io/v1alpha1",
+		resource:     "validationreports",
+		neededBy: func(config *FlagConfig) string {
+			if config.EnableValidationReports {
+				return "--enable-validation-reports"
+			}
+			return ""
+		},
+		present: "scan results can be published for GitOps health checks",
+		absent:  "install the CRDs from charts/kogaro/crds to publish scan results as ValidationReports",
+	},
+	{
+		name:         "SecretProviderClass (Secrets Store CSI)",
+		groupVersion: "secrets-store.csi.x-k8s.io/v1",
+		resource:     "secretproviderclasses",
+		present:      "CSI secret volumes are checked by reference validation",
+		absent:       "not installed; CSI secret volumes are not used",
+	},
+	{
+		name:         "PolicyReport (Kyverno and other policy engines)",
+		groupVersion: validators.PolicyReportGVK.GroupVersion().String(),
+		resource:     "policyreports",
+		neededBy: func(config *FlagConfig) string {
+			if config.EnablePolicyReports {
+				return "--enable-policy-reports"
+			}
+			return ""
+		},
+		present: "the results of policy engines are merged into the findings with --enable-policy-report-validation, and findings can be published with --enable-policy-reports",
+		absent:  "install the wgpolicyk8s.io CRDs, e.g. with Kyverno or Policy Reporter, to read or publish PolicyReports",
+	},
+	{
+		name:         "Gateway API",
+		groupVersion: "gateway.networking.k8s.io/v1",
+		resource:     "httproutes",
+		present:      "HTTPRoutes are not validated; networking and reference validation cover Ingresses and Services",
+		absent:       "not installed",
+	},
+	{
+		name:         "cert-manager",
+		groupVersion: "cert-manager.io/v1",
+		resource:     "certificates",
+		present:      "Certificates managed by cert-manager renew the TLS Secrets whose expiry secret validation reports",
+		absent:       "not installed; renew TLS Secrets reported as expiring (KOGARO-SCR-005) by other means",
+	},
+	{
+		name:         "VerticalPodAutoscaler",
+		groupVersion: "autoscaling.k8s.io/v1",
+		resource:     "verticalpodautoscalers",
+		present:      "requests are compared with the recommendations of VPAs in Off or Initial mode",
+		absent:       "not installed; requests are not compared with VPA recommendations",
+	},
+	{
+		name:         "metrics-server",
+		groupVersion: "metrics.k8s.io/v1beta1",
+		resource:     "pods",
+		neededBy: func(config *FlagConfig) string {
+			if config.EnableResourceLimitsValidation && config.EnableUsageValidation {
+				return "--enable-usage-validation"
+			}
+			return ""
+		},
+		present: "requests and limits can be compared with pod usage",
+		absent:  "install metrics-server to compare requests and limits with pod usage",
+	},
+}
+
+// doctor runs the checks of kogaro doctor against one cluster
+type doctor struct {
+	config    *FlagConfig
+	client    client.Client
+	discovery discovery.DiscoveryInterface
+	// pingRegistry checks that an image registry answers the registry API
+	pingRegistry func(ctx context.Context, registry name.Registry) error
+}
+
+// runDoctor checks the cluster for the validators and features enabled by the given
+// flags and prints a readiness matrix. It returns 1 when any check fails.
+func runDoctor() int {
+	var serviceAccount string
+	var timeout time.Duration
+	flag.StringVar(&serviceAccount, "service-account", "", "Check permissions as this ServiceAccount, given as namespace/name, by impersonating it")
+	flag.DurationVar(&timeout, "check-timeout", 10*time.Second, "Maximum time each image registry may take to answer")
+	config := registerFlags()
+	if config.ValidateOutput != "text" && config.ValidateOutput != "json" {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", "text, json")
+		return 1
+	}
+
+	restConfig, err := ctrlconfig.GetConfigWithContext(config.KubeContext)
+	if err != nil {
+		setupLog.Error(err, "unable to load kubeconfig", "context", config.KubeContext)
+		return 1
+	}
+	if serviceAccount != "" {
+		namespace, name, ok := strings.Cut(serviceAccount, "/")
+		if !ok || namespace == "" || name == "" {
+			setupLog.Error(nil, "--service-account must be given as namespace/name", "service_account", serviceAccount)
+			return 1
+		}
+		restConfig.Impersonate.UserName = fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name)
+	}
+	restConfig.Timeout = timeout
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		return 1
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		return 1
+	}
+
+	d := &doctor{config: config, client: c, discovery: discoveryClient, pingRegistry: registryPinger(timeout)}
+	checks := d.run(context.Background())
+
+	var output strings.Builder
+	if config.ValidateOutput == "json" {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			setupLog.Error(err, "failed to format output")
+			return 1
+		}
+		output.Write(data)
+		output.WriteString("\n")
+	} else {
+		writeDoctorMatrix(&output, checks)
+	}
+	if config.OutputFile != "" {
+		if err := os.WriteFile(config.OutputFile, []byte(output.String()), 0o644); err != nil {
+			setupLog.Error(err, "unable to write file", "path", config.OutputFile)
+			return 1
+		}
+	} else {
+		fmt.Print(output.String())
+	}
+
+	for _, check := range checks {
+		if check.Status == doctorFail {
+			return 1
+		}
+	}
+	return 0
+}
+
+// run returns the readiness matrix of the cluster. Cluster checks are skipped when
+// the API server cannot be reached.
+func (d *doctor) run(ctx context.Context) []doctorCheck {
+	checks := []doctorCheck{d.checkAPIServer()}
+	if checks[0].Status == doctorFail {
+		return checks
+	}
+	checks = append(checks, d.checkPermissions(ctx)...)
+	checks = append(checks, d.checkAPIs()...)
+	checks = append(checks, d.checkRegistries(ctx)...)
+	return checks
+}
+
+// checkAPIServer checks that the API server answers
+func (d *doctor) checkAPIServer() doctorCheck {
+	check := doctorCheck{Area: "API server", Name: "connectivity"}
+	info, err := d.discovery.ServerVersion()
+	if err != nil {
+		check.Status = doctorFail
+		check.Detail = err.Error()
+		return check
+	}
+	check.Status = doctorOK
+	check.Detail = "Kubernetes " + info.GitVersion
+	return check
+}
+
+// checkPermissions checks that the permissions each enabled validator and feature needs
+// are granted cluster-wide
+func (d *doctor) checkPermissions(ctx context.Context) []doctorCheck {
+	checker := validators.NewPermissionChecker(d.client)
+	var checks []doctorCheck
+
+	check := func(name string, options validators.PermissionOptions) {
+		rules, unknown := validators.RequiredPermissions(options)
+		if len(unknown) > 0 {
+			checks = append(checks, doctorCheck{Area: "Permissions", Name: name, Status: doctorWarn,
+				Detail: "depends on its configuration; grant read access to the resources it inspects"})
+			return
+		}
+		missing, err := checker.Missing(ctx, rules)
+		switch {
+		case err != nil:
+			checks = append(checks, doctorCheck{Area: "Permissions", Name: name, Status: doctorFail, Detail: err.Error()})
+		case len(missing) > 0:
+			checks = append(checks, doctorCheck{Area: "Permissions", Name: name, Status: doctorFail, Detail: "missing " + strings.Join(missing, ", ")})
+		default:
+			checks = append(checks, doctorCheck{Area: "Permissions", Name: name, Status: doctorOK, Detail: "all required permissions granted"})
+		}
+	}
+
+	for _, validationType := range enabledValidationTypes(d.config) {
+		check(validationType, validators.PermissionOptions{ValidationTypes: []string{validationType}})
+	}
+	if d.config.EnableValidationPolicies {
+		check("--enable-validation-policies", validators.PermissionOptions{ValidationPolicies: true})
+	}
+	if d.config.EnableWorkloadAnnotations {
+		check("--enable-workload-annotations", validators.PermissionOptions{WorkloadAnnotations: true})
+	}
+	if d.config.EnableValidationReports {
+		check("--enable-validation-reports", validators.PermissionOptions{ValidationReports: true})
+	}
+	if d.config.EnablePolicyReports {
+		check("--enable-policy-reports", validators.PermissionOptions{PolicyReports: true})
+	}
+	if d.config.EnableAutoRemediation {
+		check("--enable-auto-remediation", validators.PermissionOptions{AutoRemediation: true})
+	}
+	if d.config.EnableResourceLimitsValidation && d.config.EnableUsageValidation {
+		check("--enable-usage-validation", validators.PermissionOptions{UsageMetrics: true})
+	}
+	if d.config.PluginDir != "" {
+		checks = append(checks, doctorCheck{Area: "Permissions", Name: "plugins", Status: doctorWarn,
+			Detail: "depend on the plugins; grant read access to the resources they inspect"})
+	}
+	return checks
+}
+
+// checkAPIs checks which of the optional custom resources the cluster serves
+func (d *doctor) checkAPIs() []doctorCheck {
+	checks := make([]doctorCheck, 0, len(optionalAPIs))
+	for _, api := range optionalAPIs {
+		check := doctorCheck{Area: "APIs", Name: api.name}
+		neededBy := ""
+		if api.neededBy != nil {
+			neededBy = api.neededBy(d.config)
+		}
+
+		served, err := d.serves(api.groupVersion, api.resource)
+		switch {
+		case err != nil:
+			check.Status = doctorWarn
+			check.Detail = err.Error()
+		case served:
+			check.Status = doctorOK
+			check.Detail = api.present
+		case neededBy != "":
+			check.Status = doctorFail
+			check.Detail = fmt.Sprintf("%s is not served but %s is set; %s", api.groupVersion, neededBy, api.absent)
+		default:
+			check.Status = doctorInfo
+			check.Detail = api.absent
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// serves reports whether the API server serves resource in groupVersion
+func (d *doctor) serves(groupVersion, resource string) (bool, error) {
+	resources, err := d.discovery.ServerResourcesForGroupVersion(groupVersion)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to discover %s: %w", groupVersion, err)
+	}
+	for _, r := range resources.APIResources {
+		if r.Name == resource {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkRegistries checks that the registries serving the images of running pods answer,
+// since image validation looks up every image in its registry
+func (d *doctor) checkRegistries(ctx context.Context) []doctorCheck {
+	if !d.config.EnableImageValidation {
+		return nil
+	}
+
+	pods := &corev1.PodList{}
+	if err := d.client.List(ctx, pods, client.Limit(maxDoctorPods)); err != nil {
+		return []doctorCheck{{Area: "Registries", Name: "discovery", Status: doctorWarn, Detail: fmt.Sprintf("unable to list pods to find image registries: %v", err)}}
+	}
+	registries := imageRegistries(pods.Items)
+	if len(registries) == 0 {
+		return []doctorCheck{{Area: "Registries", Name: "discovery", Status: doctorInfo, Detail: "no running pods reference images"}}
+	}
+
+	var checks []doctorCheck
+	for i, registry := range registries {
+		if i == maxDoctorRegistries {
+			checks = append(checks, doctorCheck{Area: "Registries", Name: "others", Status: doctorInfo,
+				Detail: fmt.Sprintf("%d more registries not checked", len(registries)-maxDoctorRegistries)})
+			break
+		}
+		check := doctorCheck{Area: "Registries", Name: registry.RegistryStr(), Status: doctorOK, Detail: "reachable"}
+		if err := d.pingRegistry(ctx, registry); err != nil {
+			check.Status = doctorFail
+			check.Detail = err.Error()
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// imageRegistries returns the registries serving the images of pods, sorted by name
+func imageRegistries(pods []corev1.Pod) []name.Registry {
+	byName := make(map[string]name.Registry)
+	for _, pod := range pods {
+		containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+		for _, container := range containers {
+			ref, err := name.ParseReference(container.Image)
+			if err != nil {
+				continue
+			}
+			registry := ref.Context().Registry
+			byName[registry.RegistryStr()] = registry
+		}
+	}
+
+	registries := make([]name.Registry, 0, len(byName))
+	for _, registry := range byName {
+		registries = append(registries, registry)
+	}
+	sort.Slice(registries, func(i, j int) bool { return registries[i].RegistryStr() < registries[j].RegistryStr() })
+	return registries
+}
+
+// registryPinger returns a check that a registry answers the /v2/ endpoint of the
+// registry API within timeout. An authentication challenge counts as an answer.
+func registryPinger(timeout time.Duration) func(ctx context.Context, registry name.Registry) error {
+	httpClient := &http.Client{Timeout: timeout}
+	return func(ctx context.Context, registry name.Registry) error {
+		url := fmt.Sprintf("%s://%s/v2/", registry.Scheme(), registry.RegistryStr())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("unreachable: %w", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("answered %s", resp.Status)
+		}
+		return nil
+	}
+}
+
+// writeDoctorMatrix writes the checks as an aligned table followed by a summary
+func writeDoctorMatrix(w io.Writer, checks []doctorCheck) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "AREA\tCHECK\tSTATUS\tDETAIL")
+	counts := make(map[doctorStatus]int)
+	for _, check := range checks {
+		counts[check.Status]++
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", check.Area, check.Name, strings.ToUpper(string(check.Status)), check.Detail)
+	}
+	_ = table.Flush()
+
+	_, _ = fmt.Fprintf(w, "\n%d ok, %d info, %d warnings, %d failures\n", counts[doctorOK], counts[doctorInfo], counts[doctorWarn], counts[doctorFail])
+	if counts[doctorFail] > 0 {
+		_, _ = fmt.Fprintln(w, "Fix the failures before enabling these features in production.")
+	}
+}
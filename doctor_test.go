@@ -0,0 +1,127 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestDoctor_Run(t *testing.T) {
+	pods := []client.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "init", Image: "busybox:1.36"}},
+				Containers:     []corev1.Container{{Name: "web", Image: "ghcr.io/acme/web:1.0"}},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "registry.internal:5000/worker:2"}}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pods...).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectAccessReview)
+			// Everything but reading Secrets is granted
+			review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "secrets"
+			return nil
+		},
+	}).Build()
+
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: []*metav1.APIResourceList{
+		{GroupVersion: "cert-manager.io/v1", APIResources: []metav1.APIResource{{Name: "certificates"}}},
+	}}}
+
+	d := &doctor{
+		config: &FlagConfig{
+			EnableResourceLimitsValidation: true,
+			EnableSecretHygieneValidation:  true,
+			EnableImageValidation:          true,
+			EnableValidationReports:        true,
+		},
+		client:    fakeClient,
+		discovery: discoveryClient,
+		pingRegistry: func(ctx context.Context, registry name.Registry) error {
+			if registry.RegistryStr() == "registry.internal:5000" {
+				return errors.New("unreachable: connection refused")
+			}
+			return nil
+		},
+	}
+
+	got := make(map[string]doctorCheck)
+	for _, check := range d.run(context.Background()) {
+		got[check.Area+"/"+check.Name] = check
+	}
+	want := map[string]doctorStatus{
+		"API server/connectivity":                 doctorOK,
+		"Permissions/resource_limits_validation":  doctorOK,
+		"Permissions/image_validation":            doctorOK,
+		"Permissions/--enable-validation-reports": doctorOK,
+		"APIs/ValidationPolicy":                   doctorInfo,
+		"APIs/ValidationReport":                   doctorFail,
+		"APIs/Gateway API":                        doctorInfo,
+		"APIs/cert-manager":                       doctorOK,
+		"Registries/index.docker.io":              doctorOK,
+		"Registries/ghcr.io":                      doctorOK,
+		"Registries/registry.internal:5000":       doctorFail,
+	}
+	for key, status := range want {
+		if check, ok := got[key]; !ok {
+			t.Errorf("missing check %s", key)
+		} else if check.Status != status {
+			t.Errorf("check %s = %s (%s), want %s", key, check.Status, check.Detail, status)
+		}
+	}
+
+	secrets := got["Permissions/secret_validation"]
+	if secrets.Status != doctorFail || !strings.Contains(secrets.Detail, "list secrets") {
+		t.Errorf("secret_validation check = %s %q, want a failure naming list secrets", secrets.Status, secrets.Detail)
+	}
+}
+
+func TestDoctor_RunUnreachableAPIServer(t *testing.T) {
+	discoveryClient := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{}}
+	discoveryClient.AddReactor("get", "version", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	d := &doctor{config: &FlagConfig{EnableImageValidation: true}, discovery: discoveryClient}
+
+	checks := d.run(context.Background())
+	if len(checks) != 1 || checks[0].Status != doctorFail {
+		t.Errorf("run() = %+v, want only a failed connectivity check", checks)
+	}
+}
+
+func TestWriteDoctorMatrix(t *testing.T) {
+	var out strings.Builder
+	writeDoctorMatrix(&out, []doctorCheck{
+		{Area: "API server", Name: "connectivity", Status: doctorOK, Detail: "Kubernetes v1.33.0"},
+		{Area: "Permissions", Name: "secrets_validation", Status: doctorFail, Detail: "missing list secrets"},
+	})
+
+	output := out.String()
+	for _, want := range []string{"AREA", "connectivity", "FAIL", "missing list secrets", "1 ok, 0 info, 0 warnings, 1 failures", "Fix the failures"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("matrix missing %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// explainCommand is the subcommand that prints the documentation of error codes
+const explainCommand = "explain"
+
+// explainedCode is the documentation of one error code in JSON output
+type explainedCode struct {
+	validators.ErrorCodeInfo
+	CategoryTitle       string `json:"categoryTitle"`
+	CategoryDescription string `json:"categoryDescription"`
+}
+
+// runExplain prints the documentation of the error codes given as arguments, such as
+// kogaro explain KOGARO-NET-003. It returns 1 when a code is unknown.
+func runExplain(args []string) int {
+	var output string
+	flags := flag.NewFlagSet(explainCommand, flag.ExitOnError)
+	flags.StringVar(&output, "output", "text", "Output format: text or json")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: kogaro %s [--output text|json] CODE...\n", explainCommand)
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 1
+	}
+	if output != "text" && output != "json" {
+		fmt.Fprintf(os.Stderr, "invalid output format %q: valid formats are text, json\n", output)
+		return 1
+	}
+
+	var codes []explainedCode
+	for _, code := range flags.Args() {
+		info, ok := validators.LookupErrorCode(code)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "unknown error code %q; see %s for all codes\n", code, validators.ErrorCodesDocURL)
+			return 1
+		}
+		explained := explainedCode{ErrorCodeInfo: info}
+		if category, ok := validators.ErrorCodeCategoryOf(info.Code); ok {
+			explained.CategoryTitle = category.Title
+			explained.CategoryDescription = category.Description
+		}
+		codes = append(codes, explained)
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(codes); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to encode error codes: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	for i, code := range codes {
+		if i > 0 {
+			_, _ = fmt.Fprintln(os.Stdout)
+		}
+		writeExplainedCode(os.Stdout, code)
+	}
+	return 0
+}
+
+// writeExplainedCode writes the documentation of an error code as text
+func writeExplainedCode(w io.Writer, code explainedCode) {
+	_, _ = fmt.Fprintf(w, "%s: %s\n\n", code.Code, code.Title)
+	_, _ = fmt.Fprintf(w, "  Validation type:  %s\n", code.ValidationType)
+	_, _ = fmt.Fprintf(w, "  Resource:         %s\n", code.ResourceType)
+	_, _ = fmt.Fprintf(w, "  Default severity: %s\n", code.Severity)
+	_, _ = fmt.Fprintf(w, "  Checks:           %s\n", code.Checks)
+	_, _ = fmt.Fprintf(w, "  Example:          %s\n", code.Example)
+	if len(code.Controls) > 0 {
+		_, _ = fmt.Fprintf(w, "  Controls:         %s\n", strings.Join(code.Controls, ", "))
+	}
+	if code.CategoryTitle != "" {
+		_, _ = fmt.Fprintf(w, "\n%s: %s\n", code.CategoryTitle, code.CategoryDescription)
+	}
+	_, _ = fmt.Fprintf(w, "\nDocumentation: %s\n", code.DocURL)
+}
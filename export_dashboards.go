@@ -0,0 +1,99 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/topiaruss/kogaro/internal/dashboards"
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// exportDashboardsCommand is the subcommand that writes a Grafana dashboard and
+// PrometheusRule generated from the metrics and error codes of this binary
+const exportDashboardsCommand = "export-dashboards"
+
+const (
+	dashboardFileName      = "kogaro-dashboard.json"
+	prometheusRuleFileName = "kogaro-prometheusrule.yaml"
+)
+
+// runExportDashboards writes the generated dashboard and alerting rules to a directory.
+// It returns the exit code.
+func runExportDashboards(args []string) int {
+	options := dashboards.Options{}
+	var outputDir, ruleLabels string
+	flags := flag.NewFlagSet(exportDashboardsCommand, flag.ExitOnError)
+	flags.StringVar(&outputDir, "output-dir", ".", "Directory to write "+dashboardFileName+" and "+prometheusRuleFileName+" to")
+	flags.StringVar(&options.DatasourceUID, "datasource-uid", "prometheus", "UID of the Grafana Prometheus datasource the dashboard queries")
+	flags.StringVar(&options.NamespaceLabel, "namespace-label", "exported_namespace", "Label holding the namespace of a finding once scraped (namespace when the scrape honours labels)")
+	flags.StringVar(&options.RuleNamespace, "rule-namespace", "kogaro-system", "Namespace of the generated PrometheusRule")
+	flags.StringVar(&ruleLabels, "rule-labels", "", "Comma-separated key=value labels added to the PrometheusRule, such as release=prometheus, so that the Prometheus Operator selects it")
+	flags.StringVar(&options.AlertFor, "alert-for", "30m", "How long an alert condition must hold before the alert fires")
+	flags.StringVar(&options.StallWindow, "stall-window", "1h", "How long Kogaro may go without a validation run before its scans are reported stalled; raise it above the longest gap of --scan-schedule")
+
+	opts := zap.Options{Development: true}
+	opts.BindFlags(flags)
+	_ = flags.Parse(args)
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	labels, err := parseRuleLabels(ruleLabels)
+	if err != nil {
+		setupLog.Error(err, "invalid --rule-labels")
+		return 1
+	}
+	options.RuleLabels = labels
+
+	generator := dashboards.NewGenerator(metrics.Catalog(), validators.ErrorCodes(), options)
+	dashboard, err := generator.Dashboard()
+	if err != nil {
+		setupLog.Error(err, "failed to generate dashboard")
+		return 1
+	}
+	rule, err := generator.PrometheusRule()
+	if err != nil {
+		setupLog.Error(err, "failed to generate PrometheusRule")
+		return 1
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		setupLog.Error(err, "unable to create output directory", "dir", outputDir)
+		return 1
+	}
+	for name, content := range map[string][]byte{dashboardFileName: dashboard, prometheusRuleFileName: rule} {
+		path := filepath.Join(outputDir, name)
+		if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+			setupLog.Error(err, "unable to write file", "path", path)
+			return 1
+		}
+		setupLog.Info("wrote file", "path", path)
+	}
+	return 0
+}
+
+// parseRuleLabels parses comma-separated key=value pairs
+func parseRuleLabels(value string) (map[string]string, error) {
+	labels := map[string]string{}
+	if value == "" {
+		return labels, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		key, labelValue, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("label %q is not of the form key=value", pair)
+		}
+		labels[key] = labelValue
+	}
+	return labels, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/handoff"
+)
+
+// setupHandoff returns the store in which the leader persists its finding state for
+// the next leader, or nil when --handoff-configmap is not set
+func setupHandoff(mgr ctrl.Manager, config *FlagConfig) (*handoff.Store, error) {
+	if config.HandoffConfigMap == "" {
+		return nil, nil
+	}
+	key, err := handoffConfigMapKey(config.HandoffConfigMap, podNamespace)
+	if err != nil {
+		return nil, err
+	}
+	setupLog.Info("persisting finding state for the next leader", "configmap", key.String())
+	return handoff.NewStore(mgr.GetAPIReader(), mgr.GetClient(), key, ctrl.Log), nil
+}
+
+// handoffConfigMapKey parses the ConfigMap of --handoff-configmap, given as name or
+// namespace/name. A bare name is in the namespace ownNamespace returns.
+func handoffConfigMapKey(value string, ownNamespace func() (string, error)) (client.ObjectKey, error) {
+	if namespace, name, ok := strings.Cut(value, "/"); ok {
+		if namespace == "" || name == "" || strings.Contains(name, "/") {
+			return client.ObjectKey{}, fmt.Errorf("--handoff-configmap %q must be name or namespace/name", value)
+		}
+		return client.ObjectKey{Namespace: namespace, Name: name}, nil
+	}
+	namespace, err := ownNamespace()
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("--handoff-configmap %q names no namespace and Kogaro's own is unknown, use namespace/name: %w", value, err)
+	}
+	return client.ObjectKey{Namespace: namespace, Name: value}, nil
+}
+
+// podNamespace returns the namespace Kubernetes mounted the pod's ServiceAccount
+// credentials from
+func podNamespace() (string, error) {
+	data, err := os.ReadFile(filepath.Join(serviceAccountDir, "namespace"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
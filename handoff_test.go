@@ -0,0 +1,41 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestHandoffConfigMapKey(t *testing.T) {
+	ownNamespace := func() (string, error) { return "kogaro-system", nil }
+	outsideCluster := func() (string, error) { return "", errors.New("not running in a pod") }
+
+	tests := []struct {
+		value        string
+		ownNamespace func() (string, error)
+		want         client.ObjectKey
+		wantErr      bool
+	}{
+		{value: "kogaro-handoff", ownNamespace: ownNamespace, want: client.ObjectKey{Namespace: "kogaro-system", Name: "kogaro-handoff"}},
+		{value: "monitoring/kogaro-handoff", ownNamespace: outsideCluster, want: client.ObjectKey{Namespace: "monitoring", Name: "kogaro-handoff"}},
+		{value: "kogaro-handoff", ownNamespace: outsideCluster, wantErr: true},
+		{value: "/kogaro-handoff", ownNamespace: ownNamespace, wantErr: true},
+		{value: "a/b/c", ownNamespace: ownNamespace, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := handoffConfigMapKey(tt.value, tt.ownNamespace)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("handoffConfigMapKey(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("handoffConfigMapKey(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
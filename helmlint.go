@@ -0,0 +1,319 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// helmLintCommand is the subcommand that renders a chart with each of several values
+// files and validates every rendering, reporting the findings by values profile
+const helmLintCommand = "helm-lint"
+
+// offlineClusterHost is the API server of the manager helm-lint and --offline build
+// their validators with. Configs are validated on their own, so it is never contacted.
+const offlineClusterHost = "https://kogaro.invalid"
+
+// valuesProfile is a values file a chart is rendered with, named after the
+// environment it configures
+type valuesProfile struct {
+	Name string
+	// File is the values file, or empty to render the chart's defaults
+	File string
+}
+
+// renderChart renders a chart with the values of a profile
+type renderChart func(ctx context.Context, profile valuesProfile) ([]byte, error)
+
+// runHelmLint runs the helm-lint subcommand and returns its exit code
+func runHelmLint() int {
+	var chart, values, releaseName, namespace, helmBinary string
+	flag.StringVar(&chart, "chart", "", "Chart to lint: a chart directory, packaged chart or repo/chart reference")
+	flag.StringVar(&values, "values", "", "Comma-separated values files to render the chart with, each as file or profile=file (profiles default to the file name without extension and 'values-' prefix)")
+	flag.StringVar(&releaseName, "release-name", "release-name", "Release name the chart is rendered with")
+	flag.StringVar(&namespace, "namespace", "default", "Namespace the chart is rendered for")
+	flag.StringVar(&helmBinary, "helm-binary", "helm", "Helm executable that renders the chart")
+	config := registerFlags()
+
+	if chart == "" {
+		setupLog.Error(nil, "kogaro helm-lint needs --chart")
+		return validators.ExitCodeInternalFailure
+	}
+	if !slices.Contains(validOutputFormats, config.ValidateOutput) {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", strings.Join(validOutputFormats, ", "))
+		return validators.ExitCodeInternalFailure
+	}
+	if config.EnableValidationPolicies {
+		setupLog.Error(nil, "kogaro helm-lint does not contact a cluster, so it can't read ValidationPolicy resources; use --policy-file")
+		return validators.ExitCodeInternalFailure
+	}
+	profiles, err := parseValuesProfiles(values)
+	if err != nil {
+		setupLog.Error(err, "invalid --values")
+		return validators.ExitCodeInternalFailure
+	}
+
+	mgr, err := ctrl.NewManager(&rest.Config{Host: offlineClusterHost}, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                server.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create manager")
+		return validators.ExitCodeInternalFailure
+	}
+	registry := setupValidators(mgr, config)
+
+	chartDir := ""
+	if info, err := os.Stat(chart); err == nil && info.IsDir() {
+		chartDir = chart
+	}
+	result, err := lintProfiles(context.Background(), registry, helmTemplate(helmBinary, chart, releaseName, namespace), chartDir, profiles)
+	if err != nil {
+		setupLog.Error(err, "failed to lint chart", "chart", chart)
+		return validators.ExitCodeInternalFailure
+	}
+
+	if config.ValidateOutput != "text" {
+		emitValidationResult(registry, config, result)
+		return result.ExitCode
+	}
+	var report strings.Builder
+	writeHelmLintReport(&report, chart, profiles, result)
+	if config.OutputFile != "" {
+		if err := os.WriteFile(config.OutputFile, []byte(report.String()), 0o600); err != nil {
+			setupLog.Error(err, "failed to write output file", "path", config.OutputFile)
+			return validators.ExitCodeInternalFailure
+		}
+	} else {
+		fmt.Print(report.String())
+	}
+	return result.ExitCode
+}
+
+// parseValuesProfiles parses --values. Without values files, the chart is rendered
+// once with its defaults.
+func parseValuesProfiles(value string) ([]valuesProfile, error) {
+	var profiles []valuesProfile
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, file, named := strings.Cut(entry, "=")
+		if !named {
+			file = entry
+			name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			if trimmed := strings.TrimPrefix(name, "values-"); trimmed != "" {
+				name = trimmed
+			}
+		}
+		name, file = strings.TrimSpace(name), strings.TrimSpace(file)
+		if name == "" || file == "" {
+			return nil, fmt.Errorf("%q must be given as file or profile=file", entry)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("values profile %q is given twice; name the profiles as profile=file", name)
+		}
+		seen[name] = true
+		profiles = append(profiles, valuesProfile{Name: name, File: file})
+	}
+	if len(profiles) == 0 {
+		profiles = []valuesProfile{{Name: "default"}}
+	}
+	return profiles, nil
+}
+
+// helmTemplate renders a chart with helm template
+func helmTemplate(binary, chart, releaseName, namespace string) renderChart {
+	return func(ctx context.Context, profile valuesProfile) ([]byte, error) {
+		args := []string{"template", releaseName, chart, "--namespace", namespace}
+		if profile.File != "" {
+			args = append(args, "--values", profile.File)
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, binary, args...) // nolint:gosec // Helm binary and chart are user-provided
+		cmd.Stderr = &stderr
+		rendered, err := cmd.Output()
+		if err != nil {
+			if message := strings.TrimSpace(stderr.String()); message != "" {
+				return nil, fmt.Errorf("helm template failed: %w: %s", err, message)
+			}
+			return nil, fmt.Errorf("helm template failed: %w", err)
+		}
+		return rendered, nil
+	}
+}
+
+// lintProfiles renders the chart with each values profile and validates the rendered
+// manifests on their own. Each finding records its profile and values file in its
+// details, and the template that defines its resource in its source file. Profiles
+// that fail to render or validate are reported together in the error.
+func lintProfiles(ctx context.Context, registry *validators.ValidatorRegistry, render renderChart, chartDir string, profiles []valuesProfile) (validators.ValidationResult, error) {
+	var results []validators.ValidationResult
+	var errs []error
+	for _, profile := range profiles {
+		rendered, err := render(ctx, profile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("values profile %s: %w", profile.Name, err))
+			continue
+		}
+		result, err := registry.ValidateFileOnlyData(ctx, profile.Name, rendered)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("values profile %s: %w", profile.Name, err))
+			continue
+		}
+
+		sources := templateSources(rendered)
+		for i := range result.Errors {
+			finding := &result.Errors[i]
+			// Details maps may be shared with cached findings, so the profile goes into a copy
+			finding.Details = maps.Clone(finding.Details)
+			*finding = finding.WithDetail("values_profile", profile.Name)
+			if profile.File != "" {
+				*finding = finding.WithDetail("values_file", profile.File)
+			}
+			finding.SourceFile = chartFile(chartDir, sources.at(finding.SourceLine))
+			// Lines of the rendered output don't correspond to lines of the template
+			finding.SourceLine = 0
+		}
+		results = append(results, *result)
+	}
+	if len(errs) > 0 {
+		return validators.ValidationResult{}, errors.Join(errs...)
+	}
+	return validators.MergeResults(results...), nil
+}
+
+// templateSource marks the line from which helm template output was rendered from a
+// template
+type templateSource struct {
+	line     int
+	template string
+}
+
+// templateSourceList lists the templates of helm template output in line order
+type templateSourceList []templateSource
+
+// templateSources reads the "# Source:" comments helm template writes at the start of
+// each document
+func templateSources(rendered []byte) templateSourceList {
+	var sources templateSourceList
+	for i, line := range strings.Split(string(rendered), "\n") {
+		if template, ok := strings.CutPrefix(strings.TrimSpace(line), "# Source: "); ok {
+			sources = append(sources, templateSource{line: i + 1, template: strings.TrimSpace(template)})
+		}
+	}
+	return sources
+}
+
+// at returns the template the given line of the output was rendered from, or empty
+// when it is unknown
+func (s templateSourceList) at(line int) string {
+	if line <= 0 {
+		return ""
+	}
+	i := sort.Search(len(s), func(i int) bool { return s[i].line >= line })
+	if i == 0 {
+		return ""
+	}
+	return s[i-1].template
+}
+
+// chartFile returns the path of a template helm names as chart/templates/file. The
+// chart name needn't match the directory, so it is replaced with the chart directory
+// when the chart was given as one.
+func chartFile(chartDir, template string) string {
+	if chartDir == "" || template == "" {
+		return template
+	}
+	_, path, ok := strings.Cut(template, "/")
+	if !ok {
+		return template
+	}
+	return filepath.Join(chartDir, path)
+}
+
+// profileFinding is a finding and the values profiles that report it
+type profileFinding struct {
+	finding  validators.ValidationError
+	profiles []string
+}
+
+// writeHelmLintReport writes the findings of a chart once, grouped by the values
+// profiles that report them: first those of every profile, then those that only
+// appear with some values
+func writeHelmLintReport(w io.Writer, chart string, profiles []valuesProfile, result validators.ValidationResult) {
+	counts := make(map[string]int)
+	byKey := make(map[string]*profileFinding)
+	var findings []*profileFinding
+	for _, finding := range result.Errors {
+		profile := finding.Details["values_profile"]
+		counts[profile]++
+		key := strings.Join([]string{finding.ErrorCode, finding.ValidationType, finding.ResourceType, finding.GetResourceKey(), finding.Message}, "|")
+		if existing, ok := byKey[key]; ok {
+			if !slices.Contains(existing.profiles, profile) {
+				existing.profiles = append(existing.profiles, profile)
+			}
+			continue
+		}
+		byKey[key] = &profileFinding{finding: finding, profiles: []string{profile}}
+		findings = append(findings, byKey[key])
+	}
+
+	_, _ = fmt.Fprintf(w, "Chart %s: %d findings across %d values profiles\n", chart, len(findings), len(profiles))
+	for _, profile := range profiles {
+		_, _ = fmt.Fprintf(w, "  %-12s %d findings\n", profile.Name, counts[profile.Name])
+	}
+
+	// Group the findings by the profiles reporting them, most widely reported first
+	groups := make(map[string][]*profileFinding)
+	var labels []string
+	widths := make(map[string]int)
+	for _, finding := range findings {
+		label := "Only in " + strings.Join(finding.profiles, ", ")
+		if len(finding.profiles) == len(profiles) {
+			label = "In every profile"
+		}
+		if _, ok := groups[label]; !ok {
+			labels = append(labels, label)
+			widths[label] = len(finding.profiles)
+		}
+		groups[label] = append(groups[label], finding)
+	}
+	sort.SliceStable(labels, func(i, j int) bool { return widths[labels[i]] > widths[labels[j]] })
+
+	for _, label := range labels {
+		_, _ = fmt.Fprintf(w, "\n%s:\n", label)
+		for _, entry := range groups[label] {
+			finding := entry.finding
+			_, _ = fmt.Fprintf(w, "  [%s] %s %s/%s: %s", finding.Severity, finding.ErrorCode, finding.ResourceType, finding.GetResourceKey(), finding.Message)
+			if finding.SourceFile != "" {
+				_, _ = fmt.Fprintf(w, " (%s)", finding.SourceFile)
+			}
+			_, _ = fmt.Fprintln(w)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestParseValuesProfiles(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    []valuesProfile
+		wantErr bool
+	}{
+		{value: "", want: []valuesProfile{{Name: "default"}}},
+		{value: "env/dev.yaml, values-prod.yaml", want: []valuesProfile{{Name: "dev", File: "env/dev.yaml"}, {Name: "prod", File: "values-prod.yaml"}}},
+		{value: "stage=env/values.yaml", want: []valuesProfile{{Name: "stage", File: "env/values.yaml"}}},
+		{value: "dev/values.yaml,prod/values.yaml", wantErr: true},
+		{value: "prod=", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseValuesProfiles(tt.value)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseValuesProfiles(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseValuesProfiles(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestTemplateSources(t *testing.T) {
+	rendered := []byte(`---
+# Source: shop/templates/service.yaml
+apiVersion: v1
+kind: Service
+---
+# Source: shop/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+`)
+	sources := templateSources(rendered)
+	for line, want := range map[int]string{0: "", 1: "", 3: "shop/templates/service.yaml", 7: "shop/templates/deployment.yaml"} {
+		if got := sources.at(line); got != want {
+			t.Errorf("at(%d) = %q, want %q", line, got, want)
+		}
+	}
+
+	if got := chartFile("charts/shop", "shop/templates/deployment.yaml"); got != filepath.Join("charts", "shop", "templates", "deployment.yaml") {
+		t.Errorf("chartFile() = %q, want the template in the chart directory", got)
+	}
+	if got := chartFile("", "shop/templates/deployment.yaml"); got != "shop/templates/deployment.yaml" {
+		t.Errorf("chartFile() of a packaged chart = %q, want the template as helm names it", got)
+	}
+}
+
+func TestLintProfiles(t *testing.T) {
+	deployment := `---
+# Source: shop/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.27
+        envFrom:
+        - secretRef:
+            name: creds
+`
+	worker := `---
+# Source: shop/templates/worker.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx:1.27
+        envFrom:
+        - configMapRef:
+            name: settings
+`
+	// The worker is only enabled in prod
+	render := func(_ context.Context, profile valuesProfile) ([]byte, error) {
+		if profile.Name == "prod" {
+			return []byte(deployment + worker), nil
+		}
+		return []byte(deployment), nil
+	}
+
+	registry := validators.NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(validators.NewReferenceValidator(fake.NewClientBuilder().Build(), logr.Discard(),
+		validators.ValidationConfig{EnableConfigMapValidation: true, EnableSecretValidation: true}))
+	profiles := []valuesProfile{{Name: "dev", File: "values-dev.yaml"}, {Name: "prod", File: "values-prod.yaml"}}
+
+	result, err := lintProfiles(context.Background(), registry, render, "charts/shop", profiles)
+	if err != nil {
+		t.Fatalf("lintProfiles() error = %v", err)
+	}
+	byProfile := make(map[string][]validators.ValidationError)
+	for _, finding := range result.Errors {
+		byProfile[finding.Details["values_profile"]] = append(byProfile[finding.Details["values_profile"]], finding)
+	}
+	if len(byProfile["dev"]) != 1 || len(byProfile["prod"]) != 2 {
+		t.Fatalf("findings by profile = %v, want the missing Secret in both and the missing ConfigMap in prod", byProfile)
+	}
+	dev := byProfile["dev"][0]
+	if dev.Details["values_file"] != "values-dev.yaml" || dev.SourceFile != filepath.Join("charts", "shop", "templates", "deployment.yaml") || dev.SourceLine != 0 {
+		t.Errorf("dev finding = %+v, want its values file and template", dev)
+	}
+
+	var report strings.Builder
+	writeHelmLintReport(&report, "charts/shop", profiles, result)
+	everyProfile, onlyProd, ok := strings.Cut(report.String(), "Only in prod:")
+	if !ok || !strings.Contains(everyProfile, "Secret 'creds'") || !strings.Contains(onlyProd, "ConfigMap 'settings'") {
+		t.Errorf("report = %q, want the missing Secret in every profile and the missing ConfigMap only in prod", report.String())
+	}
+
+	// A profile that fails to render fails the lint
+	failing := func(_ context.Context, profile valuesProfile) ([]byte, error) {
+		if profile.Name == "prod" {
+			return nil, errors.New("required value replicas is missing")
+		}
+		return render(context.Background(), profile)
+	}
+	if _, err := lintProfiles(context.Background(), registry, failing, "charts/shop", profiles); err == nil || !strings.Contains(err.Error(), "values profile prod") {
+		t.Errorf("lintProfiles() error = %v, want the profile that failed to render", err)
+	}
+}
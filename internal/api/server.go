@@ -0,0 +1,370 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package api implements the optional HTTP API for validation findings.
+//
+// The API serves the findings recorded by the most recent cluster scan so that
+// dashboards, chatbots and other internal tooling can query Kogaro directly
+// instead of scraping logs or metrics, and lets them request an immediate scan.
+// Findings are also served by component, such as a Backstage component, with a hygiene
+// score, so that developer portals can show them on each component's page. It
+// implements the manager.Runnable interface so it shares the lifecycle of the
+// controller manager.
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/reporting"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// FindingsSource provides the findings of the most recent cluster scan
+type FindingsSource interface {
+	LastScanResult() (validators.ValidationResult, time.Time, bool)
+}
+
+// ScanTrigger starts a full cluster scan on demand
+type ScanTrigger interface {
+	TriggerScan(trigger string) error
+}
+
+// ScanResponse is the body returned by /api/v1/scan when a scan is started
+type ScanResponse struct {
+	Status string `json:"status"`
+}
+
+// FindingsResponse is the body returned by /api/v1/findings
+type FindingsResponse struct {
+	SchemaVersion string                       `json:"schema_version"`
+	ScanTime      time.Time                    `json:"scan_time"`
+	Count         int                          `json:"count"`
+	Findings      []validators.ValidationError `json:"findings"`
+}
+
+// SummaryResponse is the body returned by /api/v1/summary
+type SummaryResponse struct {
+	SchemaVersion    string         `json:"schema_version"`
+	ScanTime         time.Time      `json:"scan_time"`
+	TotalFindings    int            `json:"total_findings"`
+	BySeverity       map[string]int `json:"by_severity"`
+	ByNamespace      map[string]int `json:"by_namespace"`
+	ByValidationType map[string]int `json:"by_validation_type"`
+	ByErrorCode      map[string]int `json:"by_error_code"`
+	HygieneScore     int            `json:"hygiene_score"`
+	NamespaceScores  map[string]int `json:"namespace_scores"`
+}
+
+// ComponentSummary is the hygiene of one component's resources
+type ComponentSummary struct {
+	Component      string `json:"component"`
+	Score          int    `json:"score"`
+	Errors         int    `json:"errors"`
+	Warnings       int    `json:"warnings"`
+	Info           int    `json:"info"`
+	WorstErrorCode string `json:"worst_error_code,omitempty"`
+}
+
+// ComponentsResponse is the body returned by /api/v1/components
+type ComponentsResponse struct {
+	SchemaVersion string             `json:"schema_version"`
+	ScanTime      time.Time          `json:"scan_time"`
+	Components    []ComponentSummary `json:"components"`
+}
+
+// ComponentResponse is the body returned by /api/v1/components/{component}
+type ComponentResponse struct {
+	SchemaVersion string    `json:"schema_version"`
+	ScanTime      time.Time `json:"scan_time"`
+	ComponentSummary
+	Count    int                          `json:"count"`
+	Findings []validators.ValidationError `json:"findings"`
+}
+
+// errorResponse is the body returned for failed requests
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves validation findings over HTTP
+type Server struct {
+	BindAddress string
+	Source      FindingsSource
+	Log         logr.Logger
+	// Trigger starts scans requested with POST /api/v1/scan; nil disables the endpoint
+	Trigger ScanTrigger
+}
+
+// NewServer creates a new API server bound to the given address
+func NewServer(bindAddress string, source FindingsSource, log logr.Logger) *Server {
+	return &Server{
+		BindAddress: bindAddress,
+		Source:      source,
+		Log:         log.WithName("api-server"),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Returns false so every replica serves the API; replicas that have not completed
+// a scan respond with 503 until findings are available.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the HTTP handler serving the API endpoints
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v1/findings", s.handleFindings)
+	mux.HandleFunc("/api/v1/summary", s.handleSummary)
+	mux.HandleFunc("/api/v1/components", s.handleComponents)
+	mux.HandleFunc("/api/v1/components/{component}", s.handleComponent)
+	mux.HandleFunc("/api/v1/scan", s.handleScan)
+	return mux
+}
+
+// Start runs the HTTP server until the context is cancelled.
+// This method implements the manager.Runnable interface.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.Log.Info("starting API server", "address", s.BindAddress)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		s.Log.Info("stopping API server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
+
+// handleFindings serves the findings of the last scan, optionally filtered by the
+// namespace, severity, validation_type and error_code query parameters
+func (s *Server) handleFindings(w http.ResponseWriter, req *http.Request) {
+	result, scanTime, ok := s.lastScan(w, req)
+	if !ok {
+		return
+	}
+
+	query := req.URL.Query()
+	filters := map[string]func(validators.ValidationError) string{
+		"namespace":       func(ve validators.ValidationError) string { return ve.Namespace },
+		"severity":        func(ve validators.ValidationError) string { return string(ve.Severity) },
+		"validation_type": func(ve validators.ValidationError) string { return ve.ValidationType },
+		"error_code":      func(ve validators.ValidationError) string { return ve.ErrorCode },
+	}
+
+	findings := make([]validators.ValidationError, 0, len(result.Errors))
+	for _, ve := range result.Errors {
+		matches := true
+		for param, field := range filters {
+			if want := query.Get(param); want != "" && field(ve) != want {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			findings = append(findings, ve)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, FindingsResponse{
+		SchemaVersion: result.SchemaVersion,
+		ScanTime:      scanTime,
+		Count:         len(findings),
+		Findings:      findings,
+	})
+}
+
+// handleSummary serves aggregated counts for the findings of the last scan
+func (s *Server) handleSummary(w http.ResponseWriter, req *http.Request) {
+	result, scanTime, ok := s.lastScan(w, req)
+	if !ok {
+		return
+	}
+
+	summary := SummaryResponse{
+		SchemaVersion:    result.SchemaVersion,
+		ScanTime:         scanTime,
+		TotalFindings:    len(result.Errors),
+		BySeverity:       make(map[string]int),
+		ByNamespace:      make(map[string]int),
+		ByValidationType: make(map[string]int),
+		ByErrorCode:      make(map[string]int),
+		HygieneScore:     result.Summary.HygieneScore,
+		NamespaceScores:  result.Summary.NamespaceScores,
+	}
+	if summary.NamespaceScores == nil {
+		summary.NamespaceScores = make(map[string]int)
+	}
+	for _, ve := range result.Errors {
+		summary.BySeverity[string(ve.Severity)]++
+		if ve.Namespace != "" {
+			summary.ByNamespace[ve.Namespace]++
+		}
+		summary.ByValidationType[ve.ValidationType]++
+		if ve.ErrorCode != "" {
+			summary.ByErrorCode[ve.ErrorCode]++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleComponents serves the hygiene of every component with findings in the last
+// scan, lowest score first
+func (s *Server) handleComponents(w http.ResponseWriter, req *http.Request) {
+	result, scanTime, ok := s.lastScan(w, req)
+	if !ok {
+		return
+	}
+
+	byComponent := make(map[string][]validators.ValidationError)
+	for _, ve := range result.Errors {
+		if component := ve.Details[validators.ComponentDetail]; component != "" {
+			byComponent[component] = append(byComponent[component], ve)
+		}
+	}
+	components := make([]ComponentSummary, 0, len(byComponent))
+	for component, findings := range byComponent {
+		components = append(components, summarizeComponent(component, findings, result.ScorePolicy()))
+	}
+	sort.Slice(components, func(i, j int) bool {
+		if components[i].Score != components[j].Score {
+			return components[i].Score < components[j].Score
+		}
+		return components[i].Component < components[j].Component
+	})
+
+	writeJSON(w, http.StatusOK, ComponentsResponse{
+		SchemaVersion: result.SchemaVersion,
+		ScanTime:      scanTime,
+		Components:    components,
+	})
+}
+
+// handleComponent serves the hygiene and open findings of one component. A component
+// without findings has the maximum score.
+func (s *Server) handleComponent(w http.ResponseWriter, req *http.Request) {
+	result, scanTime, ok := s.lastScan(w, req)
+	if !ok {
+		return
+	}
+
+	component := req.PathValue("component")
+	findings := make([]validators.ValidationError, 0)
+	for _, ve := range result.Errors {
+		if ve.Details[validators.ComponentDetail] == component {
+			findings = append(findings, ve)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, ComponentResponse{
+		SchemaVersion:    result.SchemaVersion,
+		ScanTime:         scanTime,
+		ComponentSummary: summarizeComponent(component, findings, result.ScorePolicy()),
+		Count:            len(findings),
+		Findings:         findings,
+	})
+}
+
+// summarizeComponent scores a component's findings with the score policy of their scan
+// and counts them by severity
+func summarizeComponent(component string, findings []validators.ValidationError, policy *validators.ScorePolicy) ComponentSummary {
+	summary := reporting.Summarize(findings)
+	return ComponentSummary{
+		Component:      component,
+		Score:          policy.HygieneScore(findings),
+		Errors:         summary.Errors,
+		Warnings:       summary.Warnings,
+		Info:           summary.Info,
+		WorstErrorCode: summary.WorstErrorCode,
+	}
+}
+
+// handleScan starts a full cluster scan outside the scan interval. It responds 202
+// when the scan starts and 409 while another scan is running.
+func (s *Server) handleScan(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return
+	}
+	if s.Trigger == nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Error: "on-demand scans are not enabled"})
+		return
+	}
+
+	if err := s.Trigger.TriggerScan("api"); err != nil {
+		status := http.StatusServiceUnavailable
+		if errors.Is(err, validators.ErrScanInProgress) {
+			status = http.StatusConflict
+		}
+		writeJSON(w, status, errorResponse{Error: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusAccepted, ScanResponse{Status: "scan started"})
+}
+
+// lastScan validates the request method and returns the last scan result, writing
+// an error response when the request cannot be served
+func (s *Server) lastScan(w http.ResponseWriter, req *http.Request) (validators.ValidationResult, time.Time, bool) {
+	if req.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSON(w, http.StatusMethodNotAllowed, errorResponse{Error: "method not allowed"})
+		return validators.ValidationResult{}, time.Time{}, false
+	}
+
+	result, scanTime, ok := s.Source.LastScanResult()
+	if !ok {
+		writeJSON(w, http.StatusServiceUnavailable, errorResponse{Error: "no cluster scan has completed yet"})
+		return validators.ValidationResult{}, time.Time{}, false
+	}
+
+	// Sort a copy so the registry's snapshot is never modified, keeping the
+	// response order stable between requests
+	result.Errors = append([]validators.ValidationError(nil), result.Errors...)
+	sort.SliceStable(result.Errors, func(i, j int) bool {
+		a, b := result.Errors[i], result.Errors[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ResourceType != b.ResourceType {
+			return a.ResourceType < b.ResourceType
+		}
+		if a.ResourceName != b.ResourceName {
+			return a.ResourceName < b.ResourceName
+		}
+		return a.ValidationType < b.ValidationType
+	})
+	return result, scanTime, true
+}
+
+// writeJSON writes a JSON response with the given status code
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// staticSource is a FindingsSource returning a fixed result
+type staticSource struct {
+	result   validators.ValidationResult
+	scanTime time.Time
+	ok       bool
+}
+
+func (s *staticSource) LastScanResult() (validators.ValidationResult, time.Time, bool) {
+	return s.result, s.scanTime, s.ok
+}
+
+func testSource() *staticSource {
+	return &staticSource{
+		result: validators.ValidationResult{
+			SchemaVersion: validators.ResultSchemaVersion,
+			Errors: []validators.ValidationError{
+				validators.NewValidationErrorWithCode("Pod", "web", "team-a", "dangling_configmap_volume", "KOGARO-REF-003", "missing configmap"),
+				validators.NewValidationErrorWithCode("Deployment", "api", "team-a", "missing_resource_limits", "KOGARO-RES-003", "no limits").
+					WithSeverity(validators.SeverityWarning),
+				validators.NewValidationErrorWithCode("Service", "db", "team-b", "service_no_matching_pods", "KOGARO-NET-001", "no pods"),
+			},
+		},
+		scanTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		ok:       true,
+	}
+}
+
+func TestServer_Findings(t *testing.T) {
+	server := NewServer(":0", testSource(), logr.Discard())
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedCount int
+	}{
+		{name: "all findings", query: "", expectedCount: 3},
+		{name: "filter by namespace", query: "?namespace=team-a", expectedCount: 2},
+		{name: "filter by namespace and severity", query: "?namespace=team-a&severity=error", expectedCount: 1},
+		{name: "filter by error code", query: "?error_code=KOGARO-NET-001", expectedCount: 1},
+		{name: "no matches", query: "?namespace=missing", expectedCount: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/findings"+tt.query, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+
+			var response FindingsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("invalid JSON response: %v", err)
+			}
+			if response.Count != tt.expectedCount || len(response.Findings) != tt.expectedCount {
+				t.Errorf("count = %d (%d findings), want %d", response.Count, len(response.Findings), tt.expectedCount)
+			}
+			if response.SchemaVersion != validators.ResultSchemaVersion {
+				t.Errorf("schema_version = %q", response.SchemaVersion)
+			}
+		})
+	}
+}
+
+func TestServer_Summary(t *testing.T) {
+	source := testSource()
+	source.result.Summary.HygieneScore = 76
+	source.result.Summary.NamespaceScores = map[string]int{"team-a": 82, "team-b": 85, "team-c": 100}
+	server := NewServer(":0", source, logr.Discard())
+
+	rec := httptest.NewRecorder()
+	server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/summary", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	var summary SummaryResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if summary.TotalFindings != 3 {
+		t.Errorf("total_findings = %d, want 3", summary.TotalFindings)
+	}
+	if summary.BySeverity["error"] != 2 || summary.BySeverity["warning"] != 1 {
+		t.Errorf("by_severity = %v", summary.BySeverity)
+	}
+	if summary.ByNamespace["team-a"] != 2 || summary.ByNamespace["team-b"] != 1 {
+		t.Errorf("by_namespace = %v", summary.ByNamespace)
+	}
+	if summary.ByErrorCode["KOGARO-REF-003"] != 1 {
+		t.Errorf("by_error_code = %v", summary.ByErrorCode)
+	}
+	if summary.HygieneScore != 76 || summary.NamespaceScores["team-a"] != 82 || summary.NamespaceScores["team-c"] != 100 {
+		t.Errorf("hygiene_score = %d, namespace_scores = %v", summary.HygieneScore, summary.NamespaceScores)
+	}
+	if !summary.ScanTime.Equal(testSource().scanTime) {
+		t.Errorf("scan_time = %v", summary.ScanTime)
+	}
+}
+
+func TestServer_Components(t *testing.T) {
+	source := testSource()
+	for i, component := range []string{"storefront", "storefront", "billing"} {
+		source.result.Errors[i] = source.result.Errors[i].WithDetail(validators.ComponentDetail, component)
+	}
+	source.result.Errors = append(source.result.Errors,
+		validators.NewValidationErrorWithCode("Pod", "debug", "team-c", "missing_resource_requests", "KOGARO-RES-001", "no requests"))
+	server := NewServer(":0", source, logr.Discard())
+
+	get := func(path string, body interface{}) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		server.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s status = %d, want %d", path, rec.Code, http.StatusOK)
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), body); err != nil {
+			t.Fatalf("invalid JSON response: %v", err)
+		}
+	}
+
+	var components ComponentsResponse
+	get("/api/v1/components", &components)
+	want := []ComponentSummary{
+		{Component: "storefront", Score: 82, Errors: 1, Warnings: 1, WorstErrorCode: "KOGARO-REF-003"},
+		{Component: "billing", Score: 85, Errors: 1, WorstErrorCode: "KOGARO-NET-001"},
+	}
+	if len(components.Components) != len(want) {
+		t.Fatalf("components = %+v, want %+v", components.Components, want)
+	}
+	for i := range want {
+		if components.Components[i] != want[i] {
+			t.Errorf("components[%d] = %+v, want %+v", i, components.Components[i], want[i])
+		}
+	}
+
+	var storefront ComponentResponse
+	get("/api/v1/components/storefront", &storefront)
+	if storefront.Component != "storefront" || storefront.Score != 82 || storefront.Count != 2 || len(storefront.Findings) != 2 {
+		t.Errorf("storefront = %+v, want its 2 findings scored 82", storefront)
+	}
+
+	var healthy ComponentResponse
+	get("/api/v1/components/checkout", &healthy)
+	if healthy.Score != 100 || healthy.Count != 0 || healthy.Findings == nil {
+		t.Errorf("checkout = %+v, want a score of 100 and no findings", healthy)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		source         *staticSource
+		method         string
+		path           string
+		expectedStatus int
+	}{
+		{
+			name:           "no scan completed",
+			source:         &staticSource{},
+			method:         http.MethodGet,
+			path:           "/api/v1/findings",
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "method not allowed",
+			source:         testSource(),
+			method:         http.MethodPost,
+			path:           "/api/v1/summary",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "unknown path",
+			source:         testSource(),
+			method:         http.MethodGet,
+			path:           "/api/v1/unknown",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(":0", tt.source, logr.Discard())
+
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+		})
+	}
+}
+
+// stubTrigger is a ScanTrigger returning a fixed error
+type stubTrigger struct {
+	err      error
+	triggers []string
+}
+
+func (s *stubTrigger) TriggerScan(trigger string) error {
+	s.triggers = append(s.triggers, trigger)
+	return s.err
+}
+
+func TestServer_Scan(t *testing.T) {
+	tests := []struct {
+		name           string
+		trigger        ScanTrigger
+		method         string
+		expectedStatus int
+	}{
+		{name: "scan started", trigger: &stubTrigger{}, method: http.MethodPost, expectedStatus: http.StatusAccepted},
+		{name: "scan in progress", trigger: &stubTrigger{err: validators.ErrScanInProgress}, method: http.MethodPost, expectedStatus: http.StatusConflict},
+		{name: "controller not running", trigger: &stubTrigger{err: errors.New("not running")}, method: http.MethodPost, expectedStatus: http.StatusServiceUnavailable},
+		{name: "not enabled", method: http.MethodPost, expectedStatus: http.StatusNotFound},
+		{name: "method not allowed", trigger: &stubTrigger{}, method: http.MethodGet, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(":0", testSource(), logr.Discard())
+			server.Trigger = tt.trigger
+
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, httptest.NewRequest(tt.method, "/api/v1/scan", nil))
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.expectedStatus)
+			}
+			if stub, ok := tt.trigger.(*stubTrigger); ok && tt.method == http.MethodPost && len(stub.triggers) != 1 {
+				t.Errorf("triggered %d scans, want 1", len(stub.triggers))
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ScanStatus reports the most recent successful scan of a validation controller
+type ScanStatus struct {
+	Cluster string `json:"cluster,omitempty"`
+	// Running is false on replicas that do not hold the leader election lease
+	Running bool `json:"running"`
+	// Healthy is false once no scan has succeeded within the allowed number of intervals
+	Healthy                  bool           `json:"healthy"`
+	Message                  string         `json:"message,omitempty"`
+	LastScanTime             *time.Time     `json:"last_scan_time,omitempty"`
+	LastScanDurationSeconds  float64        `json:"last_scan_duration_seconds,omitempty"`
+	TotalFindings            int            `json:"total_findings"`
+	BySeverity               map[string]int `json:"by_severity,omitempty"`
+	ScanIntervalSeconds      float64        `json:"scan_interval_seconds,omitempty"`
+	ScanSchedule             string         `json:"scan_schedule,omitempty"`
+	NextScheduledScanTime    *time.Time     `json:"next_scheduled_scan_time,omitempty"`
+	QuietHours               bool           `json:"quiet_hours,omitempty"`
+	ScanInProgress           bool           `json:"scan_in_progress"`
+	MaxSecondsWithoutSuccess float64        `json:"max_seconds_without_success,omitempty"`
+}
+
+// ValidationzResponse is the body returned by /validationz
+type ValidationzResponse struct {
+	Ready bool         `json:"ready"`
+	Scans []ScanStatus `json:"scans"`
+}
+
+// staleAfter returns how long the controller may go without a successful scan since
+// the given time before it is reported unhealthy, or zero when staleness is not checked.
+// With a schedule, the allowance runs until the scans scheduled in that time are due.
+func (r *ValidationController) staleAfter(since time.Time) time.Duration {
+	if r.StaleScanIntervals <= 0 {
+		return 0
+	}
+	if r.Schedule != nil {
+		deadline := since
+		for i := 0; i < r.StaleScanIntervals; i++ {
+			deadline = r.Schedule.Next(deadline)
+		}
+		return deadline.Sub(since)
+	}
+	// Jitter delays every scan, so it extends the allowance as well
+	interval := time.Duration(float64(r.ScanInterval) * (1 + r.ScanJitter))
+	return time.Duration(r.StaleScanIntervals) * interval
+}
+
+// Status reports the last successful scan and whether the controller is healthy at now.
+// A controller that is not running, such as on a replica that is not the leader, is
+// healthy, as is one that has been running for less than the allowed time.
+func (r *ValidationController) Status(now time.Time) ScanStatus {
+	r.mu.Lock()
+	startedAt := r.startedAt
+	running := r.ctx != nil
+	r.mu.Unlock()
+
+	status := ScanStatus{
+		Cluster:        r.Registry.Cluster(),
+		Running:        running,
+		Healthy:        true,
+		ScanInProgress: r.Registry.ScanInProgress(),
+		QuietHours:     r.Registry.QuietHours() != nil && r.Registry.QuietHours().Contains(now),
+	}
+	if r.Schedule != nil {
+		next := r.Schedule.Next(now)
+		status.ScanSchedule = r.Schedule.String()
+		status.NextScheduledScanTime = &next
+	} else {
+		status.ScanIntervalSeconds = r.ScanInterval.Seconds()
+	}
+
+	result, scanTime, scanned := r.Registry.LastScanResult()
+	if scanned {
+		status.LastScanTime = &scanTime
+		status.LastScanDurationSeconds = r.Registry.LastScanDuration().Seconds()
+		status.TotalFindings = len(result.Errors)
+		status.BySeverity = make(map[string]int)
+		for _, finding := range result.Errors {
+			status.BySeverity[string(finding.Severity)]++
+		}
+	}
+
+	// Measure from the start of the controller until the first scan succeeds
+	since := startedAt
+	if scanned && scanTime.After(since) {
+		since = scanTime
+	}
+	if since.IsZero() {
+		since = now
+	}
+
+	staleAfter := r.staleAfter(since)
+	status.MaxSecondsWithoutSuccess = staleAfter.Seconds()
+	if !running || staleAfter == 0 {
+		return status
+	}
+
+	if elapsed := now.Sub(since); elapsed > staleAfter {
+		status.Healthy = false
+		if scanned {
+			status.Message = fmt.Sprintf("no scan has succeeded for %s, the last one completed at %s", elapsed.Round(time.Second), scanTime.Format(time.RFC3339))
+		} else {
+			status.Message = fmt.Sprintf("no scan has succeeded since the controller started %s ago", elapsed.Round(time.Second))
+		}
+	}
+	return status
+}
+
+// ReadyCheck implements healthz.Checker. It fails once no scan has succeeded within
+// StaleScanIntervals scan intervals, so that a wedged scanner is reported not ready.
+func (r *ValidationController) ReadyCheck(_ *http.Request) error {
+	if status := r.Status(time.Now()); !status.Healthy {
+		return fmt.Errorf("%s", status.Message)
+	}
+	return nil
+}
+
+// NewValidationzHandler serves the scan status of the given controllers as JSON. It
+// responds 503 when any controller is unhealthy.
+func NewValidationzHandler(controllers ...*ValidationController) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		now := time.Now()
+		response := ValidationzResponse{Ready: true, Scans: make([]ScanStatus, 0, len(controllers))}
+		for _, controller := range controllers {
+			status := controller.Status(now)
+			response.Ready = response.Ready && status.Healthy
+			response.Scans = append(response.Scans, status)
+		}
+
+		statusCode := http.StatusOK
+		if !response.Ready {
+			statusCode = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(statusCode)
+		_ = json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/schedule"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestValidationController_Status(t *testing.T) {
+	registry := validators.NewValidatorRegistry(logr.Discard(), fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build())
+	registry.Register(&findingsValidator{errors: []validators.ValidationError{
+		validators.NewValidationError("ConfigMap", "app", "shop", "unused_configmap", "unused").WithSeverity(validators.SeverityInfo),
+	}})
+
+	controller := &ValidationController{
+		Log:                logr.Discard(),
+		Registry:           registry,
+		ScanInterval:       time.Minute,
+		StaleScanIntervals: 3,
+	}
+
+	// A controller that is not running, such as on a follower replica, is healthy
+	if status := controller.Status(time.Now()); !status.Healthy || status.Running {
+		t.Errorf("Status() of stopped controller = %+v, want healthy and not running", status)
+	}
+
+	// A running controller without a successful scan is healthy during the allowance
+	started := time.Now()
+	controller.mu.Lock()
+	controller.ctx = context.Background()
+	controller.startedAt = started
+	controller.mu.Unlock()
+
+	if status := controller.Status(started.Add(2 * time.Minute)); !status.Healthy {
+		t.Errorf("Status() within allowance = %+v, want healthy", status)
+	}
+	if err := controller.ReadyCheck(nil); err != nil {
+		t.Errorf("ReadyCheck() within allowance error = %v", err)
+	}
+	if status := controller.Status(started.Add(4 * time.Minute)); status.Healthy || status.Message == "" {
+		t.Errorf("Status() without scan after allowance = %+v, want unhealthy with message", status)
+	}
+
+	// A successful scan restarts the allowance and is summarised
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	_, scanTime, _ := registry.LastScanResult()
+	status := controller.Status(scanTime.Add(2 * time.Minute))
+	if !status.Healthy || status.LastScanTime == nil || status.TotalFindings != 1 || status.BySeverity["info"] != 1 {
+		t.Errorf("Status() after scan = %+v, want healthy with one info finding", status)
+	}
+	if status := controller.Status(scanTime.Add(4 * time.Minute)); status.Healthy {
+		t.Errorf("Status() long after last scan = %+v, want unhealthy", status)
+	}
+
+	// Staleness checks can be disabled
+	controller.StaleScanIntervals = 0
+	if status := controller.Status(scanTime.Add(time.Hour)); !status.Healthy {
+		t.Errorf("Status() with check disabled = %+v, want healthy", status)
+	}
+}
+
+func TestValidationController_StatusWithSchedule(t *testing.T) {
+	registry := validators.NewValidatorRegistry(logr.Discard(), fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build())
+	scanSchedule, err := schedule.ParseCron("0 */4 * * *", nil)
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	controller := &ValidationController{
+		Log:                logr.Discard(),
+		Registry:           registry,
+		ScanInterval:       time.Minute,
+		Schedule:           scanSchedule,
+		StaleScanIntervals: 2,
+	}
+
+	// Started at 10:30, the scans at 12:00 and 16:00 may be missed until 16:00
+	started := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+	controller.mu.Lock()
+	controller.ctx = context.Background()
+	controller.startedAt = started
+	controller.mu.Unlock()
+
+	status := controller.Status(started.Add(5 * time.Hour))
+	if !status.Healthy || status.ScanSchedule != "0 */4 * * *" || status.ScanIntervalSeconds != 0 {
+		t.Errorf("Status() before second scheduled scan = %+v, want healthy with schedule", status)
+	}
+	if want := time.Date(2025, time.January, 15, 16, 0, 0, 0, time.UTC); status.NextScheduledScanTime == nil || !status.NextScheduledScanTime.Equal(want) {
+		t.Errorf("NextScheduledScanTime = %v, want %v", status.NextScheduledScanTime, want)
+	}
+	if status.MaxSecondsWithoutSuccess != (5*time.Hour + 30*time.Minute).Seconds() {
+		t.Errorf("MaxSecondsWithoutSuccess = %v, want 5h30m", status.MaxSecondsWithoutSuccess)
+	}
+	if status := controller.Status(started.Add(6 * time.Hour)); status.Healthy {
+		t.Errorf("Status() after two missed scheduled scans = %+v, want unhealthy", status)
+	}
+}
+
+func TestValidationzHandler(t *testing.T) {
+	registry := validators.NewValidatorRegistry(logr.Discard(), fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build())
+	registry.Register(&findingsValidator{})
+	controller := &ValidationController{
+		Log:                logr.Discard(),
+		Registry:           registry,
+		ScanInterval:       time.Millisecond,
+		StaleScanIntervals: 1,
+	}
+	controller.mu.Lock()
+	controller.ctx = context.Background()
+	controller.startedAt = time.Now().Add(-time.Hour)
+	controller.mu.Unlock()
+
+	handler := NewValidationzHandler(controller)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/validationz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Errorf("status code of stalled controller = %d, want %d", recorder.Code, http.StatusServiceUnavailable)
+	}
+
+	var response ValidationzResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if response.Ready || len(response.Scans) != 1 || response.Scans[0].Healthy {
+		t.Errorf("response = %+v, want not ready with one unhealthy scan", response)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/validationz", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status code of POST = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+// findingsValidator reports a fixed set of findings
+type findingsValidator struct {
+	errors []validators.ValidationError
+}
+
+func (f *findingsValidator) ValidateCluster(context.Context) error { return nil }
+
+func (f *findingsValidator) GetValidationType() string { return "findings_test" }
+
+func (f *findingsValidator) GetLastValidationErrors() []validators.ValidationError { return f.errors }
+
+func (f *findingsValidator) SetClient(client.Client) {}
+
+func (f *findingsValidator) SetLogReceiver(validators.LogReceiver) {}
@@ -14,6 +14,10 @@ package controllers
 
 import (
 	"context"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -21,9 +25,20 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/schedule"
 	"github.com/topiaruss/kogaro/internal/validators"
 )
 
+// ErrNotRunning is returned when a scan is triggered on a replica whose controller is
+// not running, such as a replica that does not hold the leader election lease
+var ErrNotRunning = errors.New("the validation controller is not running on this replica")
+
+// StateLoader restores the finding state a previous leader persisted
+type StateLoader interface {
+	Load(ctx context.Context) error
+}
+
 // ValidationController manages periodic validation of Kubernetes resource references.
 // It implements the manager.Runnable interface to run as a timer-based background process.
 type ValidationController struct {
@@ -32,6 +47,30 @@ type ValidationController struct {
 	Log          logr.Logger
 	Registry     *validators.ValidatorRegistry
 	ScanInterval time.Duration
+	// ScanJitter delays each scan by up to this fraction of ScanInterval, so that
+	// several installations sharing an apiserver don't scan in lockstep
+	ScanJitter float64
+	// Schedule runs periodic scans at the times of a cron expression instead of every
+	// ScanInterval, without jitter
+	Schedule *schedule.Cron
+	// ResyncSignals triggers an immediate scan for each signal received, such as SIGUSR1
+	ResyncSignals <-chan os.Signal
+	// StaleScanIntervals is the number of scan intervals that may pass without a
+	// successful scan before the controller is reported not ready; zero disables the check
+	StaleScanIntervals int
+	// State is loaded before the first scan, so that a replica taking over the leader
+	// election lease resumes the findings and incidents of the previous leader
+	State StateLoader
+	// DrainTimeout is the time the scan in flight at shutdown may take to finish its
+	// running validator before it is cut off; its findings are published either way
+	DrainTimeout time.Duration
+
+	mu  sync.Mutex
+	ctx context.Context
+	// scans tracks the on-demand scans running in the background
+	scans sync.WaitGroup
+	// startedAt is when the controller started, zero while it is not running
+	startedAt time.Time
 }
 
 // SetupWithManager registers the ValidationController with the manager as a runnable
@@ -50,27 +89,139 @@ func (r *ValidationController) NeedLeaderElection() bool {
 // This method implements the manager.Runnable interface.
 func (r *ValidationController) Start(ctx context.Context) error {
 	log := r.Log.WithName("periodic-validator")
-	log.Info("starting periodic validation controller", "scan_interval", r.ScanInterval)
+	if r.Schedule != nil {
+		log.Info("starting periodic validation controller", "scan_schedule", r.Schedule.String(), "time_zone", r.Schedule.Location().String())
+	} else {
+		log.Info("starting periodic validation controller", "scan_interval", r.ScanInterval, "scan_jitter", r.ScanJitter)
+	}
+
+	timer := time.NewTimer(r.nextInterval(time.Now()))
+	defer timer.Stop()
+
+	// Keep the quiet hours metric current between scans, so that alerts can be held back
+	var quietHoursTicks <-chan time.Time
+	if quietHours := r.Registry.QuietHours(); quietHours != nil {
+		log.Info("holding back notifications during quiet hours", "quiet_hours", quietHours.String())
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		quietHoursTicks = ticker.C
+		r.Registry.InQuietHours(time.Now())
+	}
 
-	ticker := time.NewTicker(r.ScanInterval)
-	defer ticker.Stop()
+	// Scans outlive the manager's context: on shutdown the scan in flight finishes its
+	// running validator, bounded by DrainTimeout, and publishes its findings
+	scanCtx, cancelScans := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelScans()
+	stopDrain := context.AfterFunc(ctx, func() {
+		r.Registry.Drain()
+		time.AfterFunc(r.DrainTimeout, cancelScans)
+	})
+	defer stopDrain()
+
+	// Accept on-demand scans while the controller runs
+	r.mu.Lock()
+	r.ctx = scanCtx
+	r.startedAt = time.Now()
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.ctx = nil
+		r.startedAt = time.Time{}
+		r.mu.Unlock()
+	}()
+
+	// Resume the previous leader's findings; without them the scan starts from scratch
+	if r.State != nil {
+		if err := r.State.Load(ctx); err != nil {
+			log.Error(err, "failed to resume finding state, starting from scratch")
+		}
+	}
 
 	// Run initial validation
 	log.Info("running initial cluster validation")
-	if err := r.Registry.ValidateCluster(ctx); err != nil {
-		log.Error(err, "initial validation failed")
-	}
+	r.scan(scanCtx, log, "initial validation failed")
 
 	for {
 		select {
 		case <-ctx.Done():
-			log.Info("stopping periodic validation controller")
+			log.Info("stopping periodic validation controller", "drain_timeout", r.DrainTimeout)
+			r.shutdown(log)
 			return nil
-		case <-ticker.C:
+		case <-timer.C:
 			log.Info("running periodic cluster validation")
-			if err := r.Registry.ValidateCluster(ctx); err != nil {
-				log.Error(err, "periodic validation failed")
-			}
+			r.scan(scanCtx, log, "periodic validation failed")
+			timer.Reset(r.nextInterval(time.Now()))
+		case now := <-quietHoursTicks:
+			r.Registry.InQuietHours(now)
+		case sig := <-r.ResyncSignals:
+			log.Info("received resync signal", "signal", sig.String())
+			_ = r.TriggerScan("signal")
 		}
 	}
 }
+
+// TriggerScan starts a full cluster scan immediately, outside the scan interval, and
+// returns without waiting for it. It returns validators.ErrScanInProgress while
+// another scan is running, and ErrNotRunning when the controller is not running.
+func (r *ValidationController) TriggerScan(trigger string) error {
+	log := r.Log.WithName("periodic-validator")
+	cluster := r.Registry.Cluster()
+
+	// Register the scan while the controller accepts scans, so that shutdown waits for it
+	r.mu.Lock()
+	ctx := r.ctx
+	inProgress := ctx != nil && r.Registry.ScanInProgress()
+	if ctx != nil && !inProgress {
+		r.scans.Add(1)
+	}
+	r.mu.Unlock()
+	if ctx == nil {
+		metrics.ScansTriggered.WithLabelValues(trigger, "not_running", cluster).Inc()
+		return ErrNotRunning
+	}
+	if inProgress {
+		log.Info("rejecting on-demand cluster scan, a scan is already in progress", "trigger", trigger)
+		metrics.ScansTriggered.WithLabelValues(trigger, "rejected", cluster).Inc()
+		return validators.ErrScanInProgress
+	}
+
+	metrics.ScansTriggered.WithLabelValues(trigger, "accepted", cluster).Inc()
+	log.Info("running on-demand cluster validation", "trigger", trigger)
+	go func() {
+		defer r.scans.Done()
+		r.scan(ctx, log, "on-demand validation failed")
+	}()
+	return nil
+}
+
+// shutdown stops accepting on-demand scans, waits for those in flight to drain and
+// flushes the notifications that wait to be retried after the next scan
+func (r *ValidationController) shutdown(log logr.Logger) {
+	r.mu.Lock()
+	r.ctx = nil
+	r.mu.Unlock()
+
+	r.scans.Wait()
+	r.Registry.Flush()
+	log.Info("flushed notifications of the last scan")
+}
+
+// scan runs a cluster scan and logs its failure. Scans rejected because another scan
+// is running are logged by the registry.
+func (r *ValidationController) scan(ctx context.Context, log logr.Logger, failure string) {
+	if err := r.Registry.ValidateCluster(ctx); err != nil && !errors.Is(err, validators.ErrScanInProgress) {
+		log.Error(err, failure)
+	}
+}
+
+// nextInterval returns the time from now until the next scheduled scan, or the scan
+// interval with a random delay of up to ScanJitter of it
+func (r *ValidationController) nextInterval(now time.Time) time.Duration {
+	if r.Schedule != nil {
+		return r.Schedule.Next(now).Sub(now)
+	}
+	if r.ScanJitter <= 0 {
+		return r.ScanInterval
+	}
+	return r.ScanInterval + time.Duration(rand.Float64()*r.ScanJitter*float64(r.ScanInterval)) // nolint:gosec // Jitter needs no cryptographic randomness
+}
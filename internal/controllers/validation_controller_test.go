@@ -7,6 +7,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -15,8 +16,10 @@ import (
 	networkingv1 "k8s.io/api/networking/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	"github.com/topiaruss/kogaro/internal/schedule"
 	"github.com/topiaruss/kogaro/internal/validators"
 )
 
@@ -65,3 +68,177 @@ func TestValidationController_Start(t *testing.T) {
 		t.Fatalf("Start() error = %v", err)
 	}
 }
+
+// stateLoader records whether the state was loaded before the first scan
+type stateLoader struct {
+	registry        *validators.ValidatorRegistry
+	loadedFirstScan bool
+}
+
+func (l *stateLoader) Load(context.Context) error {
+	_, _, scanned := l.registry.LastScanResult()
+	l.loadedFirstScan = !scanned
+	return errors.New("state unavailable")
+}
+
+func TestValidationController_StartLoadsState(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = networkingv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	registry := validators.NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.Register(validators.NewReferenceValidator(fakeClient, logr.Discard(), validators.ValidationConfig{EnableIngressValidation: true}))
+	loader := &stateLoader{registry: registry}
+	controller := &ValidationController{Log: logr.Discard(), Registry: registry, ScanInterval: time.Hour, State: loader}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// A state that fails to load does not stop the scan
+	if _, _, scanned := registry.LastScanResult(); !loader.loadedFirstScan || !scanned {
+		t.Errorf("state loaded before the first scan = %v, scanned = %v; want both", loader.loadedFirstScan, scanned)
+	}
+}
+
+// slowValidator takes a while to validate, and reports whether its context was cancelled
+type slowValidator struct {
+	started   chan struct{}
+	cancelled bool
+}
+
+func (v *slowValidator) ValidateCluster(ctx context.Context) error {
+	close(v.started)
+	select {
+	case <-ctx.Done():
+		v.cancelled = true
+		return ctx.Err()
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func (v *slowValidator) GetValidationType() string                             { return "slow_validation" }
+func (v *slowValidator) SetClient(client.Client)                               {}
+func (v *slowValidator) SetLogReceiver(validators.LogReceiver)                 {}
+func (v *slowValidator) GetLastValidationErrors() []validators.ValidationError { return nil }
+
+func TestValidationController_StartDrainsScanOnShutdown(t *testing.T) {
+	registry := validators.NewValidatorRegistry(logr.Discard(), nil)
+	validator := &slowValidator{started: make(chan struct{})}
+	registry.Register(validator)
+	var published, flushed int
+	registry.AddScanListener(func(validators.ValidationResult, time.Time) { published++ })
+	registry.AddCompletionListener(func(validators.ValidationResult, time.Time) { flushed++ })
+	controller := &ValidationController{Log: logr.Discard(), Registry: registry, ScanInterval: time.Hour, DrainTimeout: time.Second}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-validator.started
+		cancel()
+	}()
+	if err := controller.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	// The scan in flight finished within the drain timeout and was published, then flushed
+	if validator.cancelled || published != 1 || flushed != 2 {
+		t.Errorf("validator cancelled = %v, scans published %d and completed %d times; want false, 1 and 2", validator.cancelled, published, flushed)
+	}
+	if err := controller.TriggerScan("test"); !errors.Is(err, ErrNotRunning) {
+		t.Errorf("TriggerScan() after shutdown error = %v, want %v", err, ErrNotRunning)
+	}
+}
+
+func TestValidationController_NextInterval(t *testing.T) {
+	controller := &ValidationController{ScanInterval: time.Minute}
+	if got := controller.nextInterval(time.Now()); got != time.Minute {
+		t.Errorf("nextInterval() without jitter = %v, want %v", got, time.Minute)
+	}
+
+	controller.ScanJitter = 0.1
+	for i := 0; i < 100; i++ {
+		if got := controller.nextInterval(time.Now()); got < time.Minute || got > time.Minute+6*time.Second {
+			t.Fatalf("nextInterval() with 10%% jitter = %v, want between 1m and 1m6s", got)
+		}
+	}
+}
+
+func TestValidationController_NextIntervalWithSchedule(t *testing.T) {
+	scanSchedule, err := schedule.ParseCron("0 */4 * * *", nil)
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	controller := &ValidationController{ScanInterval: time.Minute, ScanJitter: 0.5, Schedule: scanSchedule}
+
+	now := time.Date(2025, time.January, 15, 10, 30, 0, 0, time.UTC)
+	if got := controller.nextInterval(now); got != 90*time.Minute {
+		t.Errorf("nextInterval() with schedule = %v, want 1h30m until 12:00", got)
+	}
+}
+
+// blockingValidator signals the start of each scan and blocks it until released
+type blockingValidator struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingValidator) ValidateCluster(ctx context.Context) error {
+	b.started <- struct{}{}
+	select {
+	case <-b.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (b *blockingValidator) GetValidationType() string { return "blocking" }
+
+func (b *blockingValidator) GetLastValidationErrors() []validators.ValidationError { return nil }
+
+func (b *blockingValidator) SetClient(client.Client) {}
+
+func (b *blockingValidator) SetLogReceiver(validators.LogReceiver) {}
+
+func TestValidationController_TriggerScan(t *testing.T) {
+	validator := &blockingValidator{started: make(chan struct{}, 1), release: make(chan struct{})}
+	registry := validators.NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(validator)
+
+	controller := &ValidationController{Log: logr.Discard(), Registry: registry, ScanInterval: time.Hour}
+	if err := controller.TriggerScan("api"); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("TriggerScan() before Start error = %v, want ErrNotRunning", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- controller.Start(ctx) }()
+
+	// The initial scan is still running, so an on-demand scan is rejected
+	<-validator.started
+	if err := controller.TriggerScan("api"); !errors.Is(err, validators.ErrScanInProgress) {
+		t.Errorf("TriggerScan() during a scan error = %v, want ErrScanInProgress", err)
+	}
+	validator.release <- struct{}{}
+
+	// Once the initial scan has finished, an on-demand scan starts immediately
+	deadline := time.Now().Add(5 * time.Second)
+	for registry.ScanInProgress() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if err := controller.TriggerScan("api"); err != nil {
+		t.Fatalf("TriggerScan() error = %v", err)
+	}
+	select {
+	case <-validator.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("on-demand scan did not start")
+	}
+	validator.release <- struct{}{}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
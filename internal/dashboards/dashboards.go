@@ -0,0 +1,424 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package dashboards generates a Grafana dashboard and Prometheus alerting rules from
+// the metrics and error codes compiled into Kogaro, so that they never refer to metric
+// names, labels or error codes the running binary does not export.
+package dashboards
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// docsURL is where the error codes referenced by alerts are documented
+const docsURL = validators.ErrorCodesDocURL
+
+// Options configures the generated dashboard and rules
+type Options struct {
+	// DatasourceUID is the UID of the Prometheus datasource queried by the dashboard
+	DatasourceUID string
+	// NamespaceLabel is the label holding the namespace of a finding once scraped, which
+	// Prometheus renames to exported_namespace unless the scrape honours labels
+	NamespaceLabel string
+	// RuleNamespace is the namespace of the PrometheusRule
+	RuleNamespace string
+	// RuleLabels are added to the PrometheusRule so that the Prometheus Operator selects it
+	RuleLabels map[string]string
+	// AlertFor is how long a condition must hold before an alert fires, such as 30m
+	AlertFor string
+	// StallWindow is how long Kogaro may go without completing a validation run before
+	// its scans are reported stalled, such as 1h; set it above the longest gap between
+	// scans of a cron schedule
+	StallWindow string
+}
+
+// category groups the error codes of one validator, such as KOGARO-REF-*
+type category struct {
+	prefix string
+	title  string
+	codes  []validators.ErrorCodeInfo
+}
+
+// Generator renders dashboards and rules for a set of metrics and error codes
+type Generator struct {
+	metrics    map[string]metrics.MetricInfo
+	categories []category
+	options    Options
+}
+
+// NewGenerator creates a Generator for the given metric catalog and error codes
+func NewGenerator(catalog []metrics.MetricInfo, codes []validators.ErrorCodeInfo, options Options) *Generator {
+	byName := make(map[string]metrics.MetricInfo, len(catalog))
+	for _, info := range catalog {
+		byName[info.Name] = info
+	}
+	return &Generator{
+		metrics:    byName,
+		categories: groupCodes(codes),
+		options:    options,
+	}
+}
+
+// groupCodes groups error codes by their KOGARO-<PREFIX>- prefix, in order of first code
+func groupCodes(codes []validators.ErrorCodeInfo) []category {
+	var categories []category
+	index := make(map[string]int)
+	for _, code := range codes {
+		parts := strings.Split(code.Code, "-")
+		if len(parts) < 3 {
+			continue
+		}
+		prefix := parts[0] + "-" + parts[1] + "-"
+		i, ok := index[prefix]
+		if !ok {
+			i = len(categories)
+			index[prefix] = i
+			categories = append(categories, category{prefix: prefix, title: categoryTitle(code.Category)})
+		}
+		categories[i].codes = append(categories[i].codes, code)
+	}
+	sort.SliceStable(categories, func(i, j int) bool { return categories[i].prefix < categories[j].prefix })
+	return categories
+}
+
+// categoryTitle turns a registry category such as resource_limits into "Resource limits"
+func categoryTitle(name string) string {
+	title := strings.ReplaceAll(name, "_", " ")
+	if title == "" {
+		return title
+	}
+	return strings.ToUpper(title[:1]) + title[1:]
+}
+
+// alertName turns a category title such as "Resource limits" into ResourceLimits
+func alertName(title string) string {
+	var name strings.Builder
+	for _, word := range strings.Fields(title) {
+		name.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return name.String()
+}
+
+// metric returns the name of a metric, failing when the catalog does not export it with
+// the labels a query relies on
+func (g *Generator) metric(info metrics.MetricInfo, labels ...string) (string, error) {
+	exported, ok := g.metrics[info.Name]
+	if !ok {
+		return "", fmt.Errorf("metric %s is not exported", info.Name)
+	}
+	for _, label := range labels {
+		found := false
+		for _, exportedLabel := range exported.Labels {
+			if exportedLabel == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", fmt.Errorf("metric %s has no label %s", info.Name, label)
+		}
+	}
+	return exported.Name, nil
+}
+
+// queryNames are the metric names the dashboard and rules query
+type queryNames struct {
+	active, resolved, runs, scanDuration, validatorDuration, failures, rejected, clientReads, quietHours string
+}
+
+// names resolves the metrics queried by the dashboard and rules
+func (g *Generator) names() (queryNames, error) {
+	var names queryNames
+	var err error
+	lookups := []struct {
+		target *string
+		info   metrics.MetricInfo
+		labels []string
+	}{
+		{&names.active, metrics.Describe(metrics.FindingsActive), []string{"namespace", "severity", "error_code", "phase"}},
+		{&names.resolved, metrics.Describe(metrics.FindingsResolved), []string{"namespace", "severity", "error_code"}},
+		{&names.runs, metrics.Describe(metrics.ValidationRuns), nil},
+		{&names.scanDuration, metrics.Describe(metrics.ScanDuration), nil},
+		{&names.validatorDuration, metrics.Describe(metrics.ValidatorScanDuration), []string{"validator_type"}},
+		{&names.failures, metrics.Describe(metrics.ValidatorFailures), []string{"validator_type", "reason"}},
+		{&names.rejected, metrics.Describe(metrics.ScansRejected), nil},
+		{&names.clientReads, metrics.Describe(metrics.ClientReads), []string{"validator_type", "verb"}},
+		{&names.quietHours, metrics.Describe(metrics.QuietHours), nil},
+	}
+	for _, lookup := range lookups {
+		if *lookup.target, err = g.metric(lookup.info, lookup.labels...); err != nil {
+			return queryNames{}, err
+		}
+	}
+	return names, nil
+}
+
+// Dashboard renders the Grafana dashboard as JSON
+func (g *Generator) Dashboard() ([]byte, error) {
+	names, err := g.names()
+	if err != nil {
+		return nil, err
+	}
+	ns := g.options.NamespaceLabel
+	scope := fmt.Sprintf(`%s=~"$namespace"`, ns)
+
+	layout := &panelLayout{datasource: map[string]string{"type": "prometheus", "uid": g.options.DatasourceUID}}
+
+	layout.row("Findings")
+	layout.stat("Open errors", fmt.Sprintf(`sum(%s{severity="error", %s}) or vector(0)`, names.active, scope), "red")
+	layout.stat("Open warnings", fmt.Sprintf(`sum(%s{severity="warning", %s}) or vector(0)`, names.active, scope), "orange")
+	layout.stat("New since last scan", fmt.Sprintf(`sum(%s{phase="new", %s}) or vector(0)`, names.active, scope), "yellow")
+	layout.stat("Resolved in 24h", fmt.Sprintf(`sum(increase(%s{%s}[24h])) or vector(0)`, names.resolved, scope), "green")
+	layout.timeseries("Open findings by severity", 12, fmt.Sprintf(`sum by (severity) (%s{%s})`, names.active, scope), "{{severity}}", "")
+	layout.timeseries("Findings resolved per day", 12, fmt.Sprintf(`sum by (severity) (increase(%s{%s}[1d]))`, names.resolved, scope), "{{severity}}",
+		"Remediation velocity: findings that stopped being reported")
+	layout.table("Open findings by namespace and error code", fmt.Sprintf(`sort_desc(sum by (%s, error_code) (%s{%s, error_code=~"$error_code"}))`, ns, names.active, scope))
+
+	layout.row("Findings by error code")
+	for _, category := range g.categories {
+		var description strings.Builder
+		for _, code := range category.codes {
+			fmt.Fprintf(&description, "%s: %s\n", code.Code, code.ValidationType)
+		}
+		layout.timeseries(category.title, 8, fmt.Sprintf(`sum by (error_code) (%s{error_code=~"%s.*", %s})`, names.active, category.prefix, scope), "{{error_code}}",
+			strings.TrimSpace(description.String()))
+	}
+
+	layout.row("Scans")
+	layout.timeseries("Scan duration (p95)", 8, fmt.Sprintf(`histogram_quantile(0.95, sum by (le) (rate(%s_bucket[$__rate_interval])))`, names.scanDuration), "p95", "")
+	layout.timeseries("Validator duration (p95)", 8, fmt.Sprintf(`histogram_quantile(0.95, sum by (le, validator_type) (rate(%s_bucket[$__rate_interval])))`, names.validatorDuration), "{{validator_type}}", "")
+	layout.timeseries("Client reads", 8, fmt.Sprintf(`sum by (validator_type, verb) (rate(%s[$__rate_interval]))`, names.clientReads), "{{validator_type}} {{verb}}", "")
+	layout.timeseries("Validator failures", 12, fmt.Sprintf(`sum by (validator_type, reason) (increase(%s[$__rate_interval]))`, names.failures), "{{validator_type}} {{reason}}", "")
+	layout.timeseries("Scans rejected while another was running", 12, fmt.Sprintf(`sum(increase(%s[$__rate_interval]))`, names.rejected), "rejected", "")
+
+	var codeOptions []string
+	for _, category := range g.categories {
+		for _, code := range category.codes {
+			codeOptions = append(codeOptions, code.Code)
+		}
+	}
+
+	dashboard := map[string]interface{}{
+		"title":         "Kogaro Findings",
+		"uid":           "kogaro-findings",
+		"tags":          []string{"kogaro"},
+		"editable":      true,
+		"schemaVersion": 39,
+		"refresh":       "1m",
+		"time":          map[string]string{"from": "now-7d", "to": "now"},
+		"panels":        layout.panels,
+		"templating": map[string]interface{}{
+			"list": []interface{}{
+				map[string]interface{}{
+					"name":       "namespace",
+					"label":      "Namespace",
+					"type":       "query",
+					"datasource": layout.datasource,
+					"query":      fmt.Sprintf("label_values(%s, %s)", names.active, ns),
+					"refresh":    2,
+					"includeAll": true,
+					"multi":      true,
+					"allValue":   ".*",
+					"current":    map[string]interface{}{"text": "All", "value": "$__all"},
+				},
+				map[string]interface{}{
+					"name":       "error_code",
+					"label":      "Error code",
+					"type":       "custom",
+					"query":      strings.Join(codeOptions, ","),
+					"includeAll": true,
+					"multi":      true,
+					"allValue":   "KOGARO-.*",
+					"current":    map[string]interface{}{"text": "All", "value": "$__all"},
+				},
+			},
+		},
+	}
+	return json.MarshalIndent(dashboard, "", "  ")
+}
+
+// stallWindow returns the range without validation runs after which scans are stalled
+func (g *Generator) stallWindow() string {
+	if g.options.StallWindow == "" {
+		return "1h"
+	}
+	return g.options.StallWindow
+}
+
+// PrometheusRule renders the alerting rules as a PrometheusRule manifest
+func (g *Generator) PrometheusRule() ([]byte, error) {
+	names, err := g.names()
+	if err != nil {
+		return nil, err
+	}
+	ns := g.options.NamespaceLabel
+
+	// Alerts about findings are held back while Kogaro reports quiet hours
+	var findingRules []interface{}
+	for _, category := range g.categories {
+		findingRules = append(findingRules, map[string]interface{}{
+			"alert": "Kogaro" + alertName(category.title) + "Errors",
+			"expr":  fmt.Sprintf(`sum by (%s, error_code) (%s{severity="error", error_code=~"%s.*"}) > 0 unless on() (max(%s) == 1)`, ns, names.active, category.prefix, names.quietHours),
+			"for":   g.options.AlertFor,
+			"labels": map[string]string{
+				"severity": "warning",
+			},
+			"annotations": map[string]string{
+				"summary":     fmt.Sprintf("%s validation reports {{ $value }} {{ $labels.error_code }} errors in namespace {{ $labels.%s }}", category.title, ns),
+				"description": fmt.Sprintf("Kogaro reports open %s errors (%s*). Run kogaro or query its findings API for the affected resources.", strings.ToLower(category.title), category.prefix),
+				"runbook_url": docsURL,
+			},
+		})
+	}
+
+	healthRules := []interface{}{
+		map[string]interface{}{
+			"alert":       "KogaroValidatorFailing",
+			"expr":        fmt.Sprintf(`sum by (validator_type, reason) (increase(%s[1h])) > 0`, names.failures),
+			"for":         g.options.AlertFor,
+			"labels":      map[string]string{"severity": "warning"},
+			"annotations": map[string]string{"summary": "Kogaro validator {{ $labels.validator_type }} is failing ({{ $labels.reason }}), so its findings are stale"},
+		},
+		map[string]interface{}{
+			"alert":       "KogaroScansStalled",
+			"expr":        fmt.Sprintf(`sum(increase(%s[%s])) == 0`, names.runs, g.stallWindow()),
+			"for":         g.options.AlertFor,
+			"labels":      map[string]string{"severity": "warning"},
+			"annotations": map[string]string{"summary": fmt.Sprintf("Kogaro has not completed a validation run for %s", g.stallWindow())},
+		},
+		map[string]interface{}{
+			"alert":       "KogaroScansOverlapping",
+			"expr":        fmt.Sprintf(`sum(increase(%s[1h])) > 0`, names.rejected),
+			"for":         g.options.AlertFor,
+			"labels":      map[string]string{"severity": "info"},
+			"annotations": map[string]string{"summary": "Kogaro scans take longer than the scan interval and are being rejected"},
+		},
+	}
+
+	rule := map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "PrometheusRule",
+		"metadata": map[string]interface{}{
+			"name":      "kogaro",
+			"namespace": g.options.RuleNamespace,
+			"labels":    g.ruleLabels(),
+		},
+		"spec": map[string]interface{}{
+			"groups": []interface{}{
+				map[string]interface{}{"name": "kogaro.findings", "rules": findingRules},
+				map[string]interface{}{"name": "kogaro.health", "rules": healthRules},
+			},
+		},
+	}
+	return yaml.Marshal(rule)
+}
+
+// ruleLabels returns the labels of the PrometheusRule
+func (g *Generator) ruleLabels() map[string]string {
+	labels := map[string]string{"app.kubernetes.io/name": "kogaro"}
+	for key, value := range g.options.RuleLabels {
+		labels[key] = value
+	}
+	return labels
+}
+
+// panelLayout places panels left to right in rows of 24 grid units
+type panelLayout struct {
+	datasource map[string]string
+	panels     []interface{}
+	x, y       int
+	rowHeight  int
+	nextID     int
+}
+
+// place returns the grid position of a panel of the given size, wrapping to a new line
+func (l *panelLayout) place(width, height int) map[string]int {
+	if l.x+width > 24 {
+		l.x = 0
+		l.y += l.rowHeight
+		l.rowHeight = 0
+	}
+	position := map[string]int{"x": l.x, "y": l.y, "w": width, "h": height}
+	l.x += width
+	if height > l.rowHeight {
+		l.rowHeight = height
+	}
+	return position
+}
+
+func (l *panelLayout) add(panel map[string]interface{}) {
+	l.nextID++
+	panel["id"] = l.nextID
+	l.panels = append(l.panels, panel)
+}
+
+func (l *panelLayout) row(title string) {
+	if l.x > 0 {
+		l.x = 0
+		l.y += l.rowHeight
+		l.rowHeight = 0
+	}
+	l.add(map[string]interface{}{"type": "row", "title": title, "collapsed": false, "panels": []interface{}{}, "gridPos": l.place(24, 1)})
+	l.x = 0
+	l.y++
+	l.rowHeight = 0
+}
+
+func (l *panelLayout) target(expr, legend string) []interface{} {
+	return []interface{}{map[string]interface{}{"refId": "A", "datasource": l.datasource, "expr": expr, "legendFormat": legend}}
+}
+
+func (l *panelLayout) stat(title, expr, color string) {
+	l.add(map[string]interface{}{
+		"type":       "stat",
+		"title":      title,
+		"datasource": l.datasource,
+		"gridPos":    l.place(6, 4),
+		"targets":    l.target(expr, ""),
+		"fieldConfig": map[string]interface{}{
+			"defaults": map[string]interface{}{
+				"color": map[string]string{"mode": "fixed", "fixedColor": color},
+				"unit":  "short",
+			},
+		},
+		"options": map[string]interface{}{
+			"colorMode":     "value",
+			"reduceOptions": map[string]interface{}{"calcs": []string{"lastNotNull"}, "fields": "", "values": false},
+		},
+	})
+}
+
+func (l *panelLayout) timeseries(title string, width int, expr, legend, description string) {
+	l.add(map[string]interface{}{
+		"type":        "timeseries",
+		"title":       title,
+		"description": description,
+		"datasource":  l.datasource,
+		"gridPos":     l.place(width, 8),
+		"targets":     l.target(expr, legend),
+		"fieldConfig": map[string]interface{}{"defaults": map[string]interface{}{"unit": "short"}},
+	})
+}
+
+func (l *panelLayout) table(title, expr string) {
+	targets := l.target(expr, "")
+	targets[0].(map[string]interface{})["instant"] = true
+	targets[0].(map[string]interface{})["format"] = "table"
+	l.add(map[string]interface{}{
+		"type":       "table",
+		"title":      title,
+		"datasource": l.datasource,
+		"gridPos":    l.place(24, 8),
+		"targets":    targets,
+	})
+}
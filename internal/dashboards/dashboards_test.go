@@ -0,0 +1,157 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package dashboards
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func testOptions() Options {
+	return Options{
+		DatasourceUID:  "prometheus",
+		NamespaceLabel: "exported_namespace",
+		RuleNamespace:  "monitoring",
+		RuleLabels:     map[string]string{"release": "prometheus"},
+		AlertFor:       "30m",
+		StallWindow:    "6h",
+	}
+}
+
+func TestGenerator_Dashboard(t *testing.T) {
+	codes := validators.ErrorCodes()
+	generator := NewGenerator(metrics.Catalog(), codes, testOptions())
+
+	output, err := generator.Dashboard()
+	if err != nil {
+		t.Fatalf("Dashboard() error = %v", err)
+	}
+
+	var dashboard struct {
+		Panels []struct {
+			Type    string `json:"type"`
+			Title   string `json:"title"`
+			Targets []struct {
+				Expr string `json:"expr"`
+			} `json:"targets"`
+		} `json:"panels"`
+		Templating struct {
+			List []struct {
+				Name  string `json:"name"`
+				Query string `json:"query"`
+			} `json:"list"`
+		} `json:"templating"`
+	}
+	if err := json.Unmarshal(output, &dashboard); err != nil {
+		t.Fatalf("dashboard is not valid JSON: %v", err)
+	}
+
+	// Every error code category has a panel querying its codes
+	var exprs strings.Builder
+	for _, panel := range dashboard.Panels {
+		for _, target := range panel.Targets {
+			exprs.WriteString(target.Expr + "\n")
+		}
+	}
+	for _, category := range groupCodes(codes) {
+		if !strings.Contains(exprs.String(), `error_code=~"`+category.prefix+`.*"`) {
+			t.Errorf("no panel queries error codes %s*", category.prefix)
+		}
+	}
+	if !strings.Contains(exprs.String(), "kogaro_findings_active{") || !strings.Contains(exprs.String(), "exported_namespace=~\"$namespace\"") {
+		t.Errorf("dashboard does not query active findings by namespace:\n%s", exprs.String())
+	}
+
+	// The error code variable offers every compiled error code
+	for _, variable := range dashboard.Templating.List {
+		if variable.Name != "error_code" {
+			continue
+		}
+		if got := len(strings.Split(variable.Query, ",")); got != len(codes) {
+			t.Errorf("error_code variable offers %d codes, want %d", got, len(codes))
+		}
+	}
+}
+
+func TestGenerator_PrometheusRule(t *testing.T) {
+	codes := validators.ErrorCodes()
+	generator := NewGenerator(metrics.Catalog(), codes, testOptions())
+
+	output, err := generator.PrometheusRule()
+	if err != nil {
+		t.Fatalf("PrometheusRule() error = %v", err)
+	}
+
+	var rule struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Namespace string            `json:"namespace"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Spec struct {
+			Groups []struct {
+				Name  string `json:"name"`
+				Rules []struct {
+					Alert string `json:"alert"`
+					Expr  string `json:"expr"`
+					For   string `json:"for"`
+				} `json:"rules"`
+			} `json:"groups"`
+		} `json:"spec"`
+	}
+	if err := yaml.Unmarshal(output, &rule); err != nil {
+		t.Fatalf("PrometheusRule is not valid YAML: %v", err)
+	}
+
+	if rule.Kind != "PrometheusRule" || rule.Metadata.Namespace != "monitoring" || rule.Metadata.Labels["release"] != "prometheus" {
+		t.Errorf("unexpected PrometheusRule metadata: %+v", rule)
+	}
+
+	alerts := map[string]string{}
+	for _, group := range rule.Spec.Groups {
+		for _, r := range group.Rules {
+			if r.For != "30m" {
+				t.Errorf("alert %s for = %q, want 30m", r.Alert, r.For)
+			}
+			alerts[r.Alert] = r.Expr
+		}
+	}
+	if expr := alerts["KogaroResourceLimitsErrors"]; !strings.Contains(expr, `kogaro_findings_active{severity="error", error_code=~"KOGARO-RES-.*"}`) {
+		t.Errorf("KogaroResourceLimitsErrors expr = %q", expr)
+	}
+	if expr := alerts["KogaroResourceLimitsErrors"]; !strings.HasSuffix(expr, "unless on() (max(kogaro_quiet_hours) == 1)") {
+		t.Errorf("KogaroResourceLimitsErrors expr = %q, want it held back during quiet hours", expr)
+	}
+	if _, ok := alerts["KogaroValidatorFailing"]; !ok {
+		t.Error("KogaroValidatorFailing alert missing")
+	}
+	if expr := alerts["KogaroScansStalled"]; !strings.Contains(expr, "[6h]") {
+		t.Errorf("KogaroScansStalled expr = %q, want the 6h stall window", expr)
+	}
+}
+
+func TestGenerator_FailsOnMissingMetric(t *testing.T) {
+	var catalog []metrics.MetricInfo
+	for _, info := range metrics.Catalog() {
+		if info.Name != "kogaro_findings_active" {
+			catalog = append(catalog, info)
+		}
+	}
+
+	generator := NewGenerator(catalog, validators.ErrorCodes(), testOptions())
+	if _, err := generator.Dashboard(); err == nil {
+		t.Error("Dashboard() succeeded although kogaro_findings_active is not exported")
+	}
+	if _, err := generator.PrometheusRule(); err == nil {
+		t.Error("PrometheusRule() succeeded although kogaro_findings_active is not exported")
+	}
+}
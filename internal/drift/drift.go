@@ -0,0 +1,321 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package drift compares equivalent workloads in two clusters, or in a directory of
+// expected manifests and a live cluster, and reports how their configuration has
+// drifted apart.
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// workload is the part of a Deployment, StatefulSet or DaemonSet that is compared
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+	// replicas is nil for kinds without a replica count and for manifests that
+	// leave the replica count to an autoscaler
+	replicas *int32
+	podSpec  corev1.PodSpec
+	// owned is set for workloads created by another object, such as an operator's resource
+	owned bool
+}
+
+func (w workload) key() string {
+	return w.kind + "/" + w.namespace + "/" + w.name
+}
+
+// Comparer compares the workloads of a source and a target cluster
+type Comparer struct {
+	source       client.Reader
+	target       client.Reader
+	sourceName   string
+	targetName   string
+	namespace    string
+	log          logr.Logger
+	sharedConfig validators.SharedConfig
+	// manifests is set when the source holds expected manifests rather than a cluster
+	manifests bool
+}
+
+// NewComparer creates a Comparer for two clusters. The names label the clusters in
+// findings. An empty namespace compares all namespaces except system namespaces.
+func NewComparer(source, target client.Reader, sourceName, targetName, namespace string, log logr.Logger) *Comparer {
+	return &Comparer{
+		source:       source,
+		target:       target,
+		sourceName:   sourceName,
+		targetName:   targetName,
+		namespace:    namespace,
+		log:          log.WithName("drift"),
+		sharedConfig: validators.ActiveSharedConfig(),
+	}
+}
+
+// NewManifestComparer creates a Comparer that checks a live cluster against the expected
+// manifests served by the manifests reader, usually a client built with
+// validators.NewManifestClient. Besides the cluster comparison it also compares container
+// env and reports live workloads that the manifests do not define, in the namespaces the
+// manifests deploy workloads to. Manifest objects without a namespace are taken to be in
+// the default namespace.
+func NewManifestComparer(manifests, live client.Reader, manifestsName, clusterName, namespace string, log logr.Logger) *Comparer {
+	comparer := NewComparer(manifests, live, manifestsName, clusterName, namespace, log)
+	comparer.manifests = true
+	return comparer
+}
+
+// Compare returns a finding for each difference in images, resource settings,
+// securityContext and replica counts between workloads of the same kind, namespace
+// and name in both clusters. Findings are reported on the target cluster's workload.
+// Workloads that exist in only one cluster are not compared, except that a Comparer
+// created by NewManifestComparer reports live workloads missing from the manifests.
+func (c *Comparer) Compare(ctx context.Context) ([]validators.ValidationError, error) {
+	sourceWorkloads, err := c.listWorkloads(ctx, c.source, c.manifests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads in %s: %w", c.sourceName, err)
+	}
+	targetWorkloads, err := c.listWorkloads(ctx, c.target, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workloads in %s: %w", c.targetName, err)
+	}
+
+	keys := make([]string, 0, len(targetWorkloads))
+	for key := range targetWorkloads {
+		if _, exists := sourceWorkloads[key]; exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var findings []validators.ValidationError
+	for _, key := range keys {
+		findings = append(findings, c.compareWorkload(sourceWorkloads[key], targetWorkloads[key])...)
+	}
+	if c.manifests {
+		findings = append(findings, c.findUnmanagedWorkloads(sourceWorkloads, targetWorkloads)...)
+	}
+
+	c.log.Info("drift comparison completed", "source", c.sourceName, "target", c.targetName,
+		"compared_workloads", len(keys), "findings", len(findings))
+	return findings, nil
+}
+
+// listWorkloads lists the Deployments, StatefulSets and DaemonSets of a cluster by key.
+// For manifests, unset replica counts are left unset and unset namespaces are defaulted.
+func (c *Comparer) listWorkloads(ctx context.Context, reader client.Reader, manifests bool) (map[string]workload, error) {
+	// Manifests are filtered after their namespaces are defaulted
+	var opts []client.ListOption
+	if c.namespace != "" && !manifests {
+		opts = append(opts, client.InNamespace(c.namespace))
+	}
+
+	var workloads []workload
+
+	var deployments appsv1.DeploymentList
+	if err := reader.List(ctx, &deployments, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, workload{kind: "Deployment", namespace: d.Namespace, name: d.Name, replicas: d.Spec.Replicas, podSpec: d.Spec.Template.Spec, owned: len(d.OwnerReferences) > 0})
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := reader.List(ctx, &statefulSets, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, workload{kind: "StatefulSet", namespace: s.Namespace, name: s.Name, replicas: s.Spec.Replicas, podSpec: s.Spec.Template.Spec, owned: len(s.OwnerReferences) > 0})
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := reader.List(ctx, &daemonSets, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, d := range daemonSets.Items {
+		workloads = append(workloads, workload{kind: "DaemonSet", namespace: d.Namespace, name: d.Name, podSpec: d.Spec.Template.Spec, owned: len(d.OwnerReferences) > 0})
+	}
+
+	byKey := make(map[string]workload, len(workloads))
+	for _, w := range workloads {
+		if manifests {
+			if w.namespace == "" {
+				w.namespace = metav1.NamespaceDefault
+			}
+			if c.namespace != "" && w.namespace != c.namespace {
+				continue
+			}
+		} else if w.kind != "DaemonSet" {
+			w.replicas = defaultReplicas(w.replicas)
+		}
+		if c.namespace == "" && c.sharedConfig.IsSystemNamespace(w.namespace) {
+			continue
+		}
+		// The API server stores an unset pod securityContext as an empty one
+		if w.podSpec.SecurityContext != nil && equality.Semantic.DeepEqual(*w.podSpec.SecurityContext, corev1.PodSecurityContext{}) {
+			w.podSpec.SecurityContext = nil
+		}
+		byKey[w.key()] = w
+	}
+	return byKey, nil
+}
+
+// defaultReplicas applies the API default of one replica
+func defaultReplicas(replicas *int32) *int32 {
+	if replicas == nil {
+		one := int32(1)
+		return &one
+	}
+	return replicas
+}
+
+// compareWorkload returns the drift between two versions of a workload
+func (c *Comparer) compareWorkload(source, target workload) []validators.ValidationError {
+	var findings []validators.ValidationError
+
+	if source.replicas != nil && target.replicas != nil && *source.replicas != *target.replicas {
+		findings = append(findings, c.newFinding(target, "replica_count_drift", validators.SeverityInfo, "",
+			fmt.Sprintf("%s '%s' runs %d replicas in %s but %d in %s", target.kind, target.name, *target.replicas, c.targetName, *source.replicas, c.sourceName),
+			fmt.Sprint(*source.replicas), fmt.Sprint(*target.replicas)))
+	}
+
+	if !equality.Semantic.DeepEqual(source.podSpec.SecurityContext, target.podSpec.SecurityContext) {
+		findings = append(findings, c.newFinding(target, "security_context_drift", validators.SeverityWarning, "",
+			fmt.Sprintf("%s '%s' has a different pod securityContext in %s than in %s", target.kind, target.name, c.targetName, c.sourceName),
+			compactJSON(source.podSpec.SecurityContext), compactJSON(target.podSpec.SecurityContext)))
+	}
+
+	sourceContainers := containersByName(source.podSpec)
+	for _, container := range append(append([]corev1.Container(nil), target.podSpec.InitContainers...), target.podSpec.Containers...) {
+		sourceContainer, exists := sourceContainers[container.Name]
+		if !exists {
+			continue
+		}
+
+		if sourceContainer.Image != container.Image {
+			findings = append(findings, c.newFinding(target, "image_drift", validators.SeverityWarning, container.Name,
+				fmt.Sprintf("%s '%s' container '%s' runs image '%s' in %s but '%s' in %s", target.kind, target.name, container.Name, container.Image, c.targetName, sourceContainer.Image, c.sourceName),
+				sourceContainer.Image, container.Image))
+		}
+
+		if !equality.Semantic.DeepEqual(sourceContainer.Resources, container.Resources) {
+			findings = append(findings, c.newFinding(target, "resource_settings_drift", validators.SeverityInfo, container.Name,
+				fmt.Sprintf("%s '%s' container '%s' has different resource requests or limits in %s than in %s", target.kind, target.name, container.Name, c.targetName, c.sourceName),
+				compactJSON(sourceContainer.Resources), compactJSON(container.Resources)))
+		}
+
+		if !equality.Semantic.DeepEqual(sourceContainer.SecurityContext, container.SecurityContext) {
+			findings = append(findings, c.newFinding(target, "security_context_drift", validators.SeverityWarning, container.Name,
+				fmt.Sprintf("%s '%s' container '%s' has a different securityContext in %s than in %s", target.kind, target.name, container.Name, c.targetName, c.sourceName),
+				compactJSON(sourceContainer.SecurityContext), compactJSON(container.SecurityContext)))
+		}
+
+		// Env legitimately differs between clusters, so it is only compared with manifests
+		if c.manifests && !equality.Semantic.DeepEqual(containerEnv(sourceContainer), containerEnv(container)) {
+			findings = append(findings, c.newFinding(target, "env_drift", validators.SeverityWarning, container.Name,
+				fmt.Sprintf("%s '%s' container '%s' has different environment variables in %s than in %s", target.kind, target.name, container.Name, c.targetName, c.sourceName),
+				compactJSON(containerEnv(sourceContainer)), compactJSON(containerEnv(container))))
+		}
+	}
+
+	return findings
+}
+
+// findUnmanagedWorkloads reports live workloads that the manifests do not define. Only
+// namespaces the manifests deploy workloads to are checked, and workloads owned by
+// another object are skipped because they are not expected to be in the manifests.
+func (c *Comparer) findUnmanagedWorkloads(manifestWorkloads, liveWorkloads map[string]workload) []validators.ValidationError {
+	namespaces := make(map[string]bool)
+	for _, w := range manifestWorkloads {
+		namespaces[w.namespace] = true
+	}
+
+	keys := make([]string, 0, len(liveWorkloads))
+	for key, w := range liveWorkloads {
+		if _, exists := manifestWorkloads[key]; !exists && namespaces[w.namespace] && !w.owned {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	findings := make([]validators.ValidationError, 0, len(keys))
+	for _, key := range keys {
+		w := liveWorkloads[key]
+		finding := c.newFinding(w, "resource_not_in_git", validators.SeverityWarning, "",
+			fmt.Sprintf("%s '%s' exists in %s but is not defined in %s", w.kind, w.name, c.targetName, c.sourceName), "", "")
+		findings = append(findings, finding.WithRemediationHint(fmt.Sprintf("Add %s '%s' to %s, or delete it from %s if it is no longer needed", w.kind, w.name, c.sourceName, c.targetName)))
+	}
+	return findings
+}
+
+// envSettings is the part of a container's environment that is compared
+type envSettings struct {
+	Env     []corev1.EnvVar        `json:"env,omitempty"`
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+}
+
+// containerEnv returns a container's environment with API defaults applied, so that
+// manifests which omit a defaulted field match the live object
+func containerEnv(container corev1.Container) envSettings {
+	env := make([]corev1.EnvVar, 0, len(container.Env))
+	for _, envVar := range container.Env {
+		if envVar.ValueFrom != nil && envVar.ValueFrom.FieldRef != nil && envVar.ValueFrom.FieldRef.APIVersion == "" {
+			envVar.ValueFrom = envVar.ValueFrom.DeepCopy()
+			envVar.ValueFrom.FieldRef.APIVersion = "v1"
+		}
+		env = append(env, envVar)
+	}
+	return envSettings{Env: env, EnvFrom: append([]corev1.EnvFromSource{}, container.EnvFrom...)}
+}
+
+// newFinding builds a drift finding on the target cluster's workload
+func (c *Comparer) newFinding(target workload, driftType string, severity validators.Severity, container, message, sourceValue, targetValue string) validators.ValidationError {
+	finding := validators.NewValidationErrorWithCode(target.kind, target.name, target.namespace, driftType, validators.GetDriftErrorCode(driftType), message).
+		WithSeverity(severity).
+		WithRemediationHint(fmt.Sprintf("Align %s '%s' in %s with %s, or record why the clusters differ", target.kind, target.name, c.targetName, c.sourceName)).
+		WithDetail("source_cluster", c.sourceName).
+		WithDetail("target_cluster", c.targetName).
+		WithDetail("source_value", sourceValue).
+		WithDetail("target_value", targetValue)
+	if container != "" {
+		finding = finding.WithDetail("container", container)
+	}
+	finding.Cluster = c.targetName
+	return finding
+}
+
+// containersByName indexes the init and regular containers of a pod spec
+func containersByName(spec corev1.PodSpec) map[string]corev1.Container {
+	containers := make(map[string]corev1.Container)
+	for _, container := range spec.InitContainers {
+		containers[container.Name] = container
+	}
+	for _, container := range spec.Containers {
+		containers[container.Name] = container
+	}
+	return containers
+}
+
+// compactJSON renders a value for a finding detail; unset values render as "{}"
+func compactJSON(value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil || string(data) == "null" {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,217 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package drift
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestComparer_Compare(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := func(namespace, name string, replicas *int32, containers ...corev1.Container) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: containers}},
+			},
+		}
+	}
+	container := func(image, memory string) corev1.Container {
+		return corev1.Container{
+			Name:  "app",
+			Image: image,
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memory)},
+			},
+		}
+	}
+
+	hardened := container("web:1.0", "256Mi")
+	hardened.SecurityContext = &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)}
+
+	source := []client.Object{
+		deployment("shop", "web", nil, hardened),
+		deployment("shop", "api", ptr.To(int32(2)), container("api:2.1", "512Mi")),
+		deployment("shop", "staging-only", nil, container("tool:1", "64Mi")),
+		deployment("kube-system", "coredns", nil, container("coredns:1.10", "170Mi")),
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop"},
+			Spec:       appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container("agent:1", "64Mi")}}}},
+		},
+	}
+	target := []client.Object{
+		// Same settings, with the memory limit written differently and the default replica count explicit
+		deployment("shop", "web", ptr.To(int32(1)), func() corev1.Container {
+			c := container("web:1.0", "0.25Gi")
+			c.SecurityContext = &corev1.SecurityContext{RunAsNonRoot: ptr.To(true)}
+			return c
+		}()),
+		deployment("shop", "api", ptr.To(int32(6)), container("api:2.0", "1Gi")),
+		deployment("kube-system", "coredns", nil, container("coredns:1.11", "170Mi")),
+		&appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "shop"},
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				SecurityContext: &corev1.PodSecurityContext{RunAsUser: ptr.To(int64(0))},
+				Containers:      []corev1.Container{container("agent:1", "64Mi")},
+			}}},
+		},
+	}
+
+	sourceClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(source...).Build()
+	targetClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(target...).Build()
+
+	findings, err := NewComparer(sourceClient, targetClient, "staging", "prod", "", logr.Discard()).Compare(context.Background())
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.ErrorCode+" "+f.ResourceType+"/"+f.ResourceName+" "+f.Details["container"])
+		if f.Cluster != "prod" || f.Details["source_cluster"] != "staging" {
+			t.Errorf("%s: cluster = %q, source_cluster = %q", f.ValidationType, f.Cluster, f.Details["source_cluster"])
+		}
+	}
+	want := []string{
+		"KOGARO-DRF-003 DaemonSet/agent ",
+		"KOGARO-DRF-004 Deployment/api ",
+		"KOGARO-DRF-001 Deployment/api app",
+		"KOGARO-DRF-002 Deployment/api app",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Compare() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestComparer_CompareNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	objects := func(image string) []client.Object {
+		return []client.Object{
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "coredns", Image: image}}}}},
+			},
+		}
+	}
+	sourceClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects("coredns:1.10")...).Build()
+	targetClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects("coredns:1.11")...).Build()
+
+	// An explicit namespace is compared even when it is a system namespace
+	findings, err := NewComparer(sourceClient, targetClient, "staging", "prod", "kube-system", logr.Discard()).Compare(context.Background())
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+	if len(findings) != 1 || findings[0].ValidationType != "image_drift" {
+		t.Errorf("Compare() = %+v, want one image_drift finding", findings)
+	}
+}
+
+func TestManifestComparer_Compare(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	manifests, err := validators.NewManifestClient([]validators.ManifestFile{{Path: "apps.yaml", Data: []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: web:1.0
+        env:
+        - name: LOG_LEVEL
+          value: info
+        - name: POD_NAME
+          valueFrom:
+            fieldRef:
+              fieldPath: metadata.name
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: worker
+        image: worker:3
+`)}})
+	if err != nil {
+		t.Fatalf("NewManifestClient() error = %v", err)
+	}
+
+	deployment := func(namespace, name string, replicas int32, env []corev1.EnvVar, image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					SecurityContext: &corev1.PodSecurityContext{},
+					Containers:      []corev1.Container{{Name: "app", Image: image, Env: env}},
+				}},
+			},
+		}
+	}
+	podName := corev1.EnvVar{Name: "POD_NAME", ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{APIVersion: "v1", FieldPath: "metadata.name"}}}
+
+	worker := deployment("default", "worker", 2, nil, "worker:3")
+	worker.Spec.Template.Spec.Containers[0].Name = "worker"
+	operated := deployment("shop", "operated", 1, nil, "db:1")
+	operated.OwnerReferences = []metav1.OwnerReference{{APIVersion: "example.com/v1", Kind: "Database", Name: "db", UID: "uid-1"}}
+
+	live := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		// The replica count is left to an autoscaler and the manifest's fieldRef is defaulted
+		deployment("shop", "web", 4, []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}, podName}, "web:1.1"),
+		worker,
+		deployment("shop", "debug", 1, nil, "busybox"),
+		operated,
+		deployment("other", "unrelated", 1, nil, "tool:1"),
+	).Build()
+
+	findings, err := NewManifestComparer(manifests, live, "deploy/prod", "prod", "", logr.Discard()).Compare(context.Background())
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.ErrorCode+" "+f.Namespace+"/"+f.ResourceName+" "+f.Details["container"])
+	}
+	want := []string{
+		"KOGARO-DRF-001 shop/web app",
+		"KOGARO-DRF-005 shop/web app",
+		"KOGARO-DRF-006 shop/debug ",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Compare() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
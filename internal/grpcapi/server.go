@@ -0,0 +1,245 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package grpcapi implements the optional gRPC streaming API for validation findings.
+//
+// The FindingsWatch RPC pushes findings to subscribers as cluster scans detect
+// and resolve them, so downstream automation such as ticket creation or
+// remediation bots can react to changes without polling. The server implements
+// the manager.Runnable interface so it shares the lifecycle of the controller manager.
+package grpcapi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	findingsv1 "github.com/topiaruss/kogaro/api/findings/v1"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// subscriberBufferSize is the number of events buffered per watch before the
+// subscriber is considered to have fallen behind and is disconnected
+const subscriberBufferSize = 1024
+
+// ScanSource provides scan results and notifies listeners when scans complete
+type ScanSource interface {
+	LastScanResult() (validators.ValidationResult, time.Time, bool)
+	AddScanListener(listener validators.ScanListener)
+}
+
+// subscriber is a single FindingsWatch stream
+type subscriber struct {
+	events chan *findingsv1.FindingEvent
+}
+
+// Server streams validation findings over gRPC
+type Server struct {
+	findingsv1.UnimplementedFindingsServiceServer
+
+	BindAddress string
+	Log         logr.Logger
+
+	mu          sync.Mutex
+	current     []validators.ValidationError
+	scanTime    time.Time
+	subscribers map[*subscriber]struct{}
+}
+
+// NewServer creates a gRPC server bound to the given address and registers it for
+// notifications of completed scans
+func NewServer(bindAddress string, source ScanSource, log logr.Logger) *Server {
+	s := &Server{
+		BindAddress: bindAddress,
+		Log:         log.WithName("grpc-server"),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+	if result, scanTime, ok := source.LastScanResult(); ok {
+		s.current = result.Errors
+		s.scanTime = scanTime
+	}
+	source.AddScanListener(s.handleScan)
+	return s
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable.
+// Returns false so every replica serves the API; only the leader runs scans, so
+// watches on other replicas receive events once they become leader.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the gRPC server until the context is cancelled.
+// This method implements the manager.Runnable interface.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.BindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.BindAddress, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	findingsv1.RegisterFindingsServiceServer(grpcServer, s)
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.Log.Info("starting gRPC server", "address", s.BindAddress)
+		errCh <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		s.Log.Info("stopping gRPC server")
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-time.After(5 * time.Second):
+			grpcServer.Stop()
+		}
+		return nil
+	}
+}
+
+// FindingsWatch streams finding changes to the client until it disconnects
+func (s *Server) FindingsWatch(req *findingsv1.FindingsWatchRequest, stream grpc.ServerStreamingServer[findingsv1.FindingEvent]) error {
+	sub := &subscriber{events: make(chan *findingsv1.FindingEvent, subscriberBufferSize)}
+
+	// Capture the initial state and subscribe atomically so no scan is missed
+	s.mu.Lock()
+	var initial []*findingsv1.FindingEvent
+	if req.GetSendInitialState() {
+		for _, ve := range s.current {
+			initial = append(initial, newFindingEvent(findingsv1.FindingEvent_TYPE_EXISTING, ve, s.scanTime))
+		}
+	}
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+
+	defer s.unsubscribe(sub)
+
+	for _, event := range initial {
+		if matchesRequest(req, event.GetFinding()) {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-sub.events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watch fell behind; reconnect with send_initial_state to resynchronise")
+			}
+			if !matchesRequest(req, event.GetFinding()) {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleScan computes the changes introduced by a scan and fans them out to subscribers
+func (s *Server) handleScan(result validators.ValidationResult, scanTime time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	newFindings, resolvedFindings, _ := validators.DiffFindings(s.current, result.Errors)
+	s.current = result.Errors
+	s.scanTime = scanTime
+
+	var events []*findingsv1.FindingEvent
+	for _, ve := range newFindings {
+		events = append(events, newFindingEvent(findingsv1.FindingEvent_TYPE_NEW, ve, scanTime))
+	}
+	for _, ve := range resolvedFindings {
+		events = append(events, newFindingEvent(findingsv1.FindingEvent_TYPE_RESOLVED, ve, scanTime))
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	s.Log.V(1).Info("publishing finding changes",
+		"new", len(newFindings), "resolved", len(resolvedFindings), "subscribers", len(s.subscribers))
+
+	for sub := range s.subscribers {
+		if !publish(sub, events) {
+			s.Log.Info("disconnecting slow findings watch", "buffer_size", subscriberBufferSize)
+			close(sub.events)
+			delete(s.subscribers, sub)
+		}
+	}
+}
+
+// unsubscribe removes a subscriber if it is still registered
+func (s *Server) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// publish queues events for a subscriber without blocking, returning false if its buffer is full
+func publish(sub *subscriber, events []*findingsv1.FindingEvent) bool {
+	for _, event := range events {
+		select {
+		case sub.events <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// matchesRequest reports whether a finding satisfies the watch filters
+func matchesRequest(req *findingsv1.FindingsWatchRequest, finding *findingsv1.Finding) bool {
+	if req.GetNamespace() != "" && finding.GetNamespace() != req.GetNamespace() {
+		return false
+	}
+	if req.GetSeverity() != "" && finding.GetSeverity() != req.GetSeverity() {
+		return false
+	}
+	return true
+}
+
+// newFindingEvent converts a validation error into a FindingEvent
+func newFindingEvent(eventType findingsv1.FindingEvent_Type, ve validators.ValidationError, scanTime time.Time) *findingsv1.FindingEvent {
+	return &findingsv1.FindingEvent{
+		Type: eventType,
+		Finding: &findingsv1.Finding{
+			ResourceType:     ve.ResourceType,
+			ResourceName:     ve.ResourceName,
+			Namespace:        ve.Namespace,
+			ValidationType:   ve.ValidationType,
+			ErrorCode:        ve.ErrorCode,
+			Message:          ve.Message,
+			Severity:         string(ve.Severity),
+			RemediationHint:  ve.RemediationHint,
+			RelatedResources: ve.RelatedResources,
+			Details:          ve.Details,
+		},
+		ScanTime: timestamppb.New(scanTime),
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	findingsv1 "github.com/topiaruss/kogaro/api/findings/v1"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// fakeScanSource records the registered listener so tests can simulate scans
+type fakeScanSource struct {
+	result   validators.ValidationResult
+	scanTime time.Time
+	ok       bool
+	listener validators.ScanListener
+}
+
+func (f *fakeScanSource) LastScanResult() (validators.ValidationResult, time.Time, bool) {
+	return f.result, f.scanTime, f.ok
+}
+
+func (f *fakeScanSource) AddScanListener(listener validators.ScanListener) {
+	f.listener = listener
+}
+
+func (f *fakeScanSource) scan(findings ...validators.ValidationError) {
+	f.listener(validators.ValidationResult{Errors: findings}, time.Now())
+}
+
+func finding(name, namespace string, severity validators.Severity) validators.ValidationError {
+	return validators.NewValidationErrorWithCode("Pod", name, namespace, "dangling_configmap_volume", "KOGARO-REF-003", "missing configmap").
+		WithSeverity(severity)
+}
+
+// startWatch serves the server over an in-memory connection and opens a FindingsWatch stream
+func startWatch(t *testing.T, server *Server, req *findingsv1.FindingsWatchRequest) findingsv1.FindingsService_FindingsWatchClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	findingsv1.RegisterFindingsServiceServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+
+	stream, err := findingsv1.NewFindingsServiceClient(conn).FindingsWatch(ctx, req)
+	if err != nil {
+		t.Fatalf("FindingsWatch() error = %v", err)
+	}
+	return stream
+}
+
+// waitForSubscribers blocks until the server has registered the expected number of watches
+func waitForSubscribers(t *testing.T, server *Server, count int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		server.mu.Lock()
+		n := len(server.subscribers)
+		server.mu.Unlock()
+		if n == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d subscribers", count)
+}
+
+func TestFindingsWatch_StreamsChanges(t *testing.T) {
+	source := &fakeScanSource{
+		result: validators.ValidationResult{Errors: []validators.ValidationError{finding("existing", "team-a", validators.SeverityError)}},
+		ok:     true,
+	}
+	server := NewServer(":0", source, logr.Discard())
+
+	stream := startWatch(t, server, &findingsv1.FindingsWatchRequest{SendInitialState: true})
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if event.GetType() != findingsv1.FindingEvent_TYPE_EXISTING || event.GetFinding().GetResourceName() != "existing" {
+		t.Errorf("initial event = %v", event)
+	}
+
+	waitForSubscribers(t, server, 1)
+	source.scan(finding("added", "team-a", validators.SeverityWarning))
+
+	expected := map[string]findingsv1.FindingEvent_Type{
+		"added":    findingsv1.FindingEvent_TYPE_NEW,
+		"existing": findingsv1.FindingEvent_TYPE_RESOLVED,
+	}
+	for range expected {
+		event, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		name := event.GetFinding().GetResourceName()
+		if want, ok := expected[name]; !ok || event.GetType() != want {
+			t.Errorf("unexpected event %v for %s", event.GetType(), name)
+		}
+		if event.GetFinding().GetErrorCode() != "KOGARO-REF-003" || event.GetScanTime() == nil {
+			t.Errorf("event missing finding details: %v", event)
+		}
+	}
+}
+
+func TestFindingsWatch_Filters(t *testing.T) {
+	source := &fakeScanSource{}
+	server := NewServer(":0", source, logr.Discard())
+
+	stream := startWatch(t, server, &findingsv1.FindingsWatchRequest{Namespace: "team-b", Severity: "error"})
+	waitForSubscribers(t, server, 1)
+
+	source.scan(
+		finding("other-namespace", "team-a", validators.SeverityError),
+		finding("warning", "team-b", validators.SeverityWarning),
+		finding("match", "team-b", validators.SeverityError),
+	)
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	if event.GetFinding().GetResourceName() != "match" {
+		t.Errorf("expected only the matching finding, got %s", event.GetFinding().GetResourceName())
+	}
+}
+
+func TestHandleScan_DisconnectsSlowSubscribers(t *testing.T) {
+	source := &fakeScanSource{}
+	server := NewServer(":0", source, logr.Discard())
+
+	sub := &subscriber{events: make(chan *findingsv1.FindingEvent, 1)}
+	server.subscribers[sub] = struct{}{}
+
+	source.scan(finding("a", "ns", validators.SeverityError), finding("b", "ns", validators.SeverityError))
+
+	if _, ok := server.subscribers[sub]; ok {
+		t.Error("expected slow subscriber to be removed")
+	}
+	<-sub.events
+	if _, ok := <-sub.events; ok {
+		t.Error("expected slow subscriber channel to be closed")
+	}
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package handoff persists the finding state of the leader, so that a replica taking
+// over the leader election lease resumes it instead of starting from scratch.
+//
+// The leader saves the open findings of the state tracker and the open incidents of
+// its notifiers to a ConfigMap after each scan. A new leader loads them before its
+// first scan, so the age of findings carries over and incidents are not opened again.
+package handoff
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/notify"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// stateKey is the binaryData key of the ConfigMap holding the gzipped state
+	stateKey = "state.json.gz"
+	// stateVersion is the version of the persisted state format
+	stateVersion = 1
+	// saveTimeout bounds the time spent saving state after a scan
+	saveTimeout = 30 * time.Second
+)
+
+// state is the persisted finding state of a leader
+type state struct {
+	Version int       `json:"version"`
+	SavedAt time.Time `json:"savedAt"`
+	// Findings are the open findings of the state tracker
+	Findings []metrics.FindingRecord `json:"findings,omitempty"`
+	// Incidents are the states of the incident notifiers, by notifier name
+	Incidents map[string]notify.IncidentState `json:"incidents,omitempty"`
+}
+
+// Store saves and loads the finding state of the leader in a ConfigMap
+type Store struct {
+	reader  client.Reader
+	writer  client.Writer
+	key     client.ObjectKey
+	tracker *metrics.StateTracker
+	log     logr.Logger
+
+	mu sync.Mutex
+	// Incident notifiers whose state is persisted, by name
+	notifiers map[string]*notify.IncidentNotifier
+	// loaded is set once the state has been loaded, so that the controllers of
+	// several clusters load it once
+	loaded bool
+}
+
+// NewStore creates a Store that keeps the state of the global state tracker in the
+// ConfigMap with the given key. Reads go through reader, which should bypass the
+// informer cache, so that the ConfigMap is read even when the cache does not hold it.
+func NewStore(reader client.Reader, writer client.Writer, key client.ObjectKey, log logr.Logger) *Store {
+	return &Store{
+		reader:    reader,
+		writer:    writer,
+		key:       key,
+		tracker:   metrics.GetGlobalStateTracker(),
+		log:       log.WithName("handoff"),
+		notifiers: make(map[string]*notify.IncidentNotifier),
+	}
+}
+
+// AddNotifier persists the state of an incident notifier under its cluster and
+// channel, which must identify it among the store's notifiers
+func (s *Store) AddNotifier(cluster string, notifier *notify.IncidentNotifier) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := notifier.Channel()
+	if cluster != "" {
+		name = cluster + "/" + name
+	}
+	s.notifiers[name] = notifier
+}
+
+// Load restores the state the previous leader saved. Only the first call loads it;
+// a missing ConfigMap leaves the state as it is.
+func (s *Store) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.loaded {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := s.reader.Get(ctx, s.key, configMap); err != nil {
+		if apierrors.IsNotFound(err) {
+			s.log.Info("no finding state to resume", "configmap", s.key.String())
+			s.loaded = true
+			return nil
+		}
+		return fmt.Errorf("failed to read finding state from ConfigMap %s: %w", s.key, err)
+	}
+	saved, err := decodeState(configMap.BinaryData[stateKey])
+	if err != nil {
+		return fmt.Errorf("ConfigMap %s: %w", s.key, err)
+	}
+
+	s.tracker.Restore(saved.Findings, time.Now())
+	restored := 0
+	for name, incidents := range saved.Incidents {
+		if notifier, ok := s.notifiers[name]; ok {
+			notifier.Restore(incidents)
+			restored += len(incidents.Open)
+		}
+	}
+	s.loaded = true
+	s.log.Info("resumed finding state of the previous leader", "configmap", s.key.String(),
+		"saved_at", saved.SavedAt, "findings", len(saved.Findings), "incidents", restored)
+	return nil
+}
+
+// Save writes the current state to the ConfigMap, creating it when it is missing
+func (s *Store) Save(ctx context.Context, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := state{
+		Version:   stateVersion,
+		SavedAt:   at.UTC(),
+		Findings:  s.tracker.Snapshot(),
+		Incidents: make(map[string]notify.IncidentState, len(s.notifiers)),
+	}
+	for name, notifier := range s.notifiers {
+		current.Incidents[name] = notifier.State()
+	}
+	data, err := encodeState(current)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMap.Namespace = s.key.Namespace
+	configMap.Name = s.key.Name
+	configMap.Labels = map[string]string{"app.kubernetes.io/managed-by": "kogaro"}
+	configMap.BinaryData = map[string][]byte{stateKey: data}
+	if err := s.writer.Update(ctx, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to save finding state to ConfigMap %s: %w", s.key, err)
+		}
+		if err := s.writer.Create(ctx, configMap); err != nil {
+			return fmt.Errorf("failed to create ConfigMap %s for finding state: %w", s.key, err)
+		}
+	}
+	s.log.V(1).Info("saved finding state", "configmap", s.key.String(), "findings", len(current.Findings), "bytes", len(data))
+	return nil
+}
+
+// HandleScan saves the state once a scan's listeners and notifiers have handled it.
+// Its signature matches validators.ScanListener.
+func (s *Store) HandleScan(_ validators.ValidationResult, scanTime time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), saveTimeout)
+	defer cancel()
+
+	if err := s.Save(ctx, scanTime); err != nil {
+		s.log.Error(err, "failed to save finding state")
+	}
+}
+
+// encodeState marshals and gzips a state, which keeps the ConfigMap of a cluster with
+// many findings well below the size limit of Kubernetes objects
+func encodeState(current state) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(writer).Encode(current); err != nil {
+		return nil, fmt.Errorf("failed to encode finding state: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress finding state: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeState reads a state written by encodeState
+func decodeState(data []byte) (state, error) {
+	var saved state
+	if len(data) == 0 {
+		return saved, fmt.Errorf("holds no %s", stateKey)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return saved, fmt.Errorf("failed to decompress finding state: %w", err)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return saved, fmt.Errorf("failed to decompress finding state: %w", err)
+	}
+	if err := json.Unmarshal(decoded, &saved); err != nil {
+		return saved, fmt.Errorf("failed to decode finding state: %w", err)
+	}
+	if saved.Version != stateVersion {
+		return saved, fmt.Errorf("finding state has unsupported version %d", saved.Version)
+	}
+	return saved, nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package handoff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/notify"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// countingBackend counts the incidents it is asked to open
+type countingBackend struct {
+	triggered int
+}
+
+func (b *countingBackend) Channel() string {
+	return notify.PagerDutyChannel
+}
+
+func (b *countingBackend) Trigger(context.Context, notify.Incident) error {
+	b.triggered++
+	return nil
+}
+
+func (b *countingBackend) Resolve(context.Context, notify.Incident) error {
+	return nil
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kogaro-system", Name: "kogaro-handoff"}
+	fakeClient := fake.NewClientBuilder().Build()
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist")
+
+	// The leader opens an incident and saves twice, creating and then updating the ConfigMap
+	leaderBackend := &countingBackend{}
+	leaderNotifier := notify.NewIncidentNotifier(leaderBackend, nil, nil, logr.Discard())
+	if err := leaderNotifier.Sync(ctx, []validators.ValidationError{finding}, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	metrics.RecordValidatorFinding("", "reference", "Deployment", "web", "shop", "dangling_configmap_volume", "error", "KOGARO-REF-003", false)
+	leader := NewStore(fakeClient, fakeClient, key, logr.Discard())
+	leader.AddNotifier("", leaderNotifier)
+	for range 2 {
+		if err := leader.Save(ctx, time.Now()); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	// The next leader resumes the incident instead of opening it again
+	backend := &countingBackend{}
+	notifier := notify.NewIncidentNotifier(backend, nil, nil, logr.Discard())
+	successor := NewStore(fakeClient, fakeClient, key, logr.Discard())
+	successor.AddNotifier("", notifier)
+	if err := successor.Load(ctx); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if err := notifier.Sync(ctx, []validators.ValidationError{finding}, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if backend.triggered != 0 {
+		t.Errorf("triggered %d incidents, want the resumed incident left alone", backend.triggered)
+	}
+	if len(notifier.State().Open) != 1 {
+		t.Errorf("open incidents = %v, want the resumed one", notifier.State().Open)
+	}
+}
+
+func TestStore_Load(t *testing.T) {
+	ctx := context.Background()
+	key := client.ObjectKey{Namespace: "kogaro-system", Name: "kogaro-handoff"}
+
+	// Nothing to resume before the first leader saved its state
+	store := NewStore(fake.NewClientBuilder().Build(), nil, key, logr.Discard())
+	if err := store.Load(ctx); err != nil {
+		t.Errorf("Load() without a ConfigMap error = %v, want nil", err)
+	}
+
+	corrupt := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		BinaryData: map[string][]byte{stateKey: []byte("not gzip")},
+	}
+	store = NewStore(fake.NewClientBuilder().WithObjects(corrupt).Build(), nil, key, logr.Discard())
+	if err := store.Load(ctx); err == nil {
+		t.Error("Load() of a corrupt state succeeded, want an error")
+	}
+}
@@ -12,8 +12,11 @@ package metrics
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
@@ -26,7 +29,7 @@ var (
 			Name: "kogaro_validation_errors_total",
 			Help: "Total number of validation errors found",
 		},
-		[]string{"resource_type", "validation_type", "namespace", "resource_name", "severity", "workload_category", "expected_pattern", "error_code"},
+		[]string{"resource_type", "validation_type", "namespace", "resource_name", "severity", "workload_category", "expected_pattern", "error_code", "cluster"},
 	)
 
 	// ValidationFirstSeen tracks when validation errors were first detected
@@ -35,7 +38,7 @@ var (
 			Name: "kogaro_validation_first_seen_timestamp",
 			Help: "Timestamp when validation error was first detected",
 		},
-		[]string{"namespace", "resource_type", "resource_name", "validation_type", "severity", "workload_category", "expected_pattern", "error_code"},
+		[]string{"namespace", "resource_type", "resource_name", "validation_type", "severity", "workload_category", "expected_pattern", "error_code", "cluster"},
 	)
 
 	// ValidationLastSeen tracks when validation errors were last seen
@@ -44,7 +47,7 @@ var (
 			Name: "kogaro_validation_last_seen_timestamp",
 			Help: "Timestamp when validation error was last detected",
 		},
-		[]string{"namespace", "resource_type", "resource_name", "validation_type"},
+		[]string{"namespace", "resource_type", "resource_name", "validation_type", "cluster"},
 	)
 
 	// ValidationAge tracks the age of validation errors in hours
@@ -53,7 +56,7 @@ var (
 			Name: "kogaro_validation_age_hours",
 			Help: "Age of validation error in hours",
 		},
-		[]string{"namespace", "resource_type", "resource_name", "validation_type", "temporal_state"},
+		[]string{"namespace", "resource_type", "resource_name", "validation_type", "temporal_state", "cluster"},
 	)
 
 	// ValidationStateChanges tracks the number of validation state changes
@@ -62,7 +65,7 @@ var (
 			Name: "kogaro_validation_state_changes_total",
 			Help: "Number of validation state changes",
 		},
-		[]string{"namespace", "resource_type", "resource_name", "validation_type", "change_type"},
+		[]string{"namespace", "resource_type", "resource_name", "validation_type", "change_type", "cluster"},
 	)
 
 	// ValidationResolved tracks the number of validation errors resolved
@@ -71,7 +74,61 @@ var (
 			Name: "kogaro_validation_resolved_total",
 			Help: "Number of validation errors resolved",
 		},
-		[]string{"namespace", "resource_type", "resource_name", "validation_type", "resolution_duration_hours"},
+		[]string{"namespace", "resource_type", "resource_name", "validation_type", "resolution_duration_hours", "cluster"},
+	)
+
+	// FindingsActive tracks the findings that are currently reported, by lifecycle phase
+	FindingsActive = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_findings_active",
+			Help: "Number of findings reported by the latest run of their validator, by phase (new or active)",
+		},
+		[]string{"namespace", "validation_type", "severity", "error_code", "phase", "cluster"},
+	)
+
+	// FindingsResolved tracks the findings that stopped being reported
+	FindingsResolved = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_findings_resolved_total",
+			Help: "Total number of findings resolved, that is no longer reported by their validator",
+		},
+		[]string{"namespace", "validation_type", "severity", "error_code", "cluster"},
+	)
+
+	// TeamFindings tracks the findings of the latest cluster scan by the team that owns them
+	TeamFindings = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_team_findings",
+			Help: "Number of findings reported by the latest cluster scan, by owning team; findings without a team have an empty team",
+		},
+		[]string{"team", "severity", "cluster"},
+	)
+
+	// HygieneScore tracks the hygiene score of the latest cluster scan
+	HygieneScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_hygiene_score",
+			Help: "Hygiene score of the cluster from 0 to 100 in the latest cluster scan, the mean of its namespace scores",
+		},
+		[]string{"cluster"},
+	)
+
+	// NamespaceHygieneScore tracks the hygiene score of each namespace in the latest scan
+	NamespaceHygieneScore = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_namespace_hygiene_score",
+			Help: "Hygiene score of a namespace from 0 to 100 in the latest cluster scan, lowered by its findings weighted by severity and category",
+		},
+		[]string{"namespace", "cluster"},
+	)
+
+	// PolicyReportResults tracks the results of the PolicyReports read by the latest scan
+	PolicyReportResults = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_policy_report_results",
+			Help: "Number of results in the PolicyReports read by the latest cluster scan, by the policy engine that produced them and result",
+		},
+		[]string{"source", "result", "cluster"},
 	)
 
 	// ValidationRuns tracks the total number of validation runs performed
@@ -82,9 +139,165 @@ var (
 		},
 	)
 
+	// ScanDuration tracks the duration of complete cluster scans across all validators
+	ScanDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "kogaro_scan_duration_seconds",
+			Help:    "Duration of complete cluster validation scans in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 14),
+		},
+	)
+
+	// ValidatorScanDuration tracks the duration of each validator's scan
+	ValidatorScanDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kogaro_validator_scan_duration_seconds",
+			Help:    "Duration of individual validator scans in seconds",
+			Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+		},
+		[]string{"validator_type", "result", "cluster"},
+	)
+
+	// ValidatorResourcesListed tracks the number of resources listed by each validator per scan
+	ValidatorResourcesListed = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kogaro_validator_resources_listed",
+			Help:    "Number of resources listed by a validator during a single scan",
+			Buckets: prometheus.ExponentialBuckets(1, 4, 10),
+		},
+		[]string{"validator_type", "cluster"},
+	)
+
+	// ClientReads tracks the reads validators make through the Kubernetes client;
+	// most are served from the informer cache and never reach the API server
+	ClientReads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_client_reads_total",
+			Help: "Total number of reads validators make through the Kubernetes client, including informer cache hits",
+		},
+		[]string{"validator_type", "verb", "kind"},
+	)
+
+	// ValidationCacheLookups tracks the objects whose findings were reused from an
+	// earlier scan, or validated again because they changed
+	ValidationCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_validation_cache_lookups_total",
+			Help: "Total number of objects looked up in the validation result cache, by whether their unchanged findings were reused",
+		},
+		[]string{"validator_type", "result"},
+	)
+
+	// ScansRejected tracks the cluster scans rejected because another scan was running
+	ScansRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_scans_rejected_total",
+			Help: "Total number of cluster scans rejected because the previous scan was still in progress",
+		},
+		[]string{"cluster"},
+	)
+
+	// ScansTriggered tracks the cluster scans requested outside the scan interval
+	ScansTriggered = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_scans_triggered_total",
+			Help: "Total number of cluster scans triggered on demand, by trigger and result",
+		},
+		[]string{"trigger", "result", "cluster"},
+	)
+
+	// ValidatorFailures tracks the validators that timed out or panicked during a scan
+	ValidatorFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_validator_failures_total",
+			Help: "Total number of validator runs abandoned because the validator timed out or panicked",
+		},
+		[]string{"validator_type", "reason", "cluster"},
+	)
+
+	// ValidatorsSkipped tracks the low-priority validators skipped because a scan ran out
+	// of API request budget
+	ValidatorsSkipped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_validators_skipped_total",
+			Help: "Total number of low-priority validator runs skipped because a scan exhausted its API request budget",
+		},
+		[]string{"validator_type", "cluster"},
+	)
+
+	// QuietHours tracks whether notifications about findings are held back for quiet hours
+	QuietHours = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kogaro_quiet_hours",
+			Help: "Whether notifications about findings are held back because it is quiet hours (1) or not (0)",
+		},
+		[]string{"cluster"},
+	)
+
+	// NotificationsSuppressed tracks the scans whose notifications were held back for quiet hours
+	NotificationsSuppressed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_notifications_suppressed_total",
+			Help: "Total number of cluster scans whose notifications were held back during quiet hours",
+		},
+		[]string{"cluster"},
+	)
+
+	// WebhookDeadLetters tracks the webhook deliveries dropped after their retries failed
+	WebhookDeadLetters = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kogaro_webhook_dead_letters_total",
+			Help: "Total number of webhook deliveries of new or resolved findings dropped after every attempt failed",
+		},
+		[]string{"webhook", "event"},
+	)
+
+	// ShardNamespaces tracks the number of namespaces assigned to the replica's shard
+	ShardNamespaces = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kogaro_shard_namespaces",
+			Help: "Number of namespaces assigned to this replica's shard",
+		},
+	)
+
 	once sync.Once
+
+	// descPattern extracts the name, help and labels of a metric from its descriptor
+	descPattern = regexp.MustCompile(`^Desc\{fqName: (".*"), help: (".*"), constLabels: \{.*\}, variableLabels: \{(.*)\}\}$`)
 )
 
+// collectors returns every Kogaro metric, in registration order
+func collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		ValidationErrors,
+		ValidationFirstSeen,
+		ValidationLastSeen,
+		ValidationAge,
+		ValidationStateChanges,
+		ValidationResolved,
+		FindingsActive,
+		FindingsResolved,
+		TeamFindings,
+		HygieneScore,
+		NamespaceHygieneScore,
+		PolicyReportResults,
+		ValidationRuns,
+		ScanDuration,
+		ValidatorScanDuration,
+		ValidatorResourcesListed,
+		ClientReads,
+		ValidationCacheLookups,
+		ValidatorFailures,
+		ValidatorsSkipped,
+		ScansRejected,
+		ScansTriggered,
+		QuietHours,
+		NotificationsSuppressed,
+		WebhookDeadLetters,
+		ShardNamespaces,
+	}
+}
+
 // TemporalState represents the temporal classification of a validation error
 type TemporalState string
 
@@ -112,17 +325,77 @@ const (
 // RegisterMetrics registers all Kogaro metrics with the controller-runtime metrics registry.
 // This function is safe to call multiple times due to sync.Once protection.
 func RegisterMetrics() {
+	register(metrics.Registry)
+}
+
+// RegisterShardedMetrics registers all Kogaro metrics with the controller-runtime metrics
+// registry, adding a constant shard label so that the metrics of replicas which split a
+// cluster between them can be aggregated by shard. Only the first registration applies.
+func RegisterShardedMetrics(shard string) {
+	register(prometheus.WrapRegistererWith(prometheus.Labels{"shard": shard}, metrics.Registry))
+}
+
+// register registers all Kogaro metrics once
+func register(registerer prometheus.Registerer) {
 	once.Do(func() {
-		metrics.Registry.MustRegister(ValidationErrors)
-		metrics.Registry.MustRegister(ValidationFirstSeen)
-		metrics.Registry.MustRegister(ValidationLastSeen)
-		metrics.Registry.MustRegister(ValidationAge)
-		metrics.Registry.MustRegister(ValidationStateChanges)
-		metrics.Registry.MustRegister(ValidationResolved)
-		metrics.Registry.MustRegister(ValidationRuns)
+		registerer.MustRegister(collectors()...)
 	})
 }
 
+// MetricInfo describes a metric that Kogaro exports
+type MetricInfo struct {
+	Name   string
+	Help   string
+	Type   string
+	Labels []string
+}
+
+// Catalog returns every metric Kogaro exports, in registration order
+func Catalog() []MetricInfo {
+	all := collectors()
+	catalog := make([]MetricInfo, 0, len(all))
+	for _, collector := range all {
+		catalog = append(catalog, Describe(collector))
+	}
+	return catalog
+}
+
+// Describe returns the name, help, type and variable labels of one of Kogaro's metrics,
+// as registered with Prometheus
+func Describe(collector prometheus.Collector) MetricInfo {
+	descs := make(chan *prometheus.Desc, 1)
+	go func() {
+		collector.Describe(descs)
+		close(descs)
+	}()
+	var desc *prometheus.Desc
+	for d := range descs {
+		if desc == nil {
+			desc = d
+		}
+	}
+
+	info := MetricInfo{}
+	if match := descPattern.FindStringSubmatch(desc.String()); match != nil {
+		info.Name, _ = strconv.Unquote(match[1])
+		info.Help, _ = strconv.Unquote(match[2])
+		if match[3] != "" {
+			info.Labels = strings.Split(match[3], ",")
+		}
+	}
+
+	// Gauges also implement Counter, so they are matched first
+	switch collector.(type) {
+	case *prometheus.GaugeVec, prometheus.Gauge:
+		info.Type = "gauge"
+	case *prometheus.HistogramVec, prometheus.Histogram:
+		info.Type = "histogram"
+	case *prometheus.CounterVec, prometheus.Counter:
+		info.Type = "counter"
+	}
+	return info
+}
+
 // ClassifyWorkload determines the workload category based on namespace and resource type
 func ClassifyWorkload(namespace, resourceType string) WorkloadCategory {
 	// System namespaces are always infrastructure
@@ -173,23 +446,75 @@ func RecordValidationError(
 
 // RecordValidationResolved records when a validation error is resolved
 func RecordValidationResolved(
-	namespace, resourceType, resourceName, validationType, severity, errorCode string,
+	cluster, namespace, resourceType, resourceName, validationType, severity, errorCode string,
 	resolutionDurationHours float64,
 ) {
 	// Record resolution
 	ValidationResolved.WithLabelValues(
 		namespace, resourceType, resourceName, validationType,
-		fmt.Sprintf("%.1f", resolutionDurationHours),
+		fmt.Sprintf("%.1f", resolutionDurationHours), cluster,
 	).Inc()
+	FindingsResolved.WithLabelValues(namespace, validationType, severity, errorCode, cluster).Inc()
 
 	// Record state change
 	ValidationStateChanges.WithLabelValues(
-		namespace, resourceType, resourceName, validationType, "resolved",
+		namespace, resourceType, resourceName, validationType, "resolved", cluster,
 	).Inc()
 
-	// Remove temporal metrics for resolved errors
-	// Note: We need to provide all required labels, but we'll use empty strings for missing ones
-	ValidationFirstSeen.WithLabelValues(namespace, resourceType, resourceName, validationType, severity, "", "", errorCode).Set(0)
-	ValidationLastSeen.WithLabelValues(namespace, resourceType, resourceName, validationType).Set(0)
-	ValidationAge.WithLabelValues(namespace, resourceType, resourceName, validationType, "resolved").Set(0)
+	// Remove the temporal series of the resolved error, whatever its severity and
+	// temporal state were, so that dashboards stop showing it
+	finding := prometheus.Labels{
+		"namespace":       namespace,
+		"resource_type":   resourceType,
+		"resource_name":   resourceName,
+		"validation_type": validationType,
+		"cluster":         cluster,
+	}
+	ValidationFirstSeen.DeletePartialMatch(finding)
+	ValidationLastSeen.DeletePartialMatch(finding)
+	ValidationAge.DeletePartialMatch(finding)
+}
+
+// RecordValidatorScan records the duration and listed resource count of a single validator
+// scan. The cluster is empty unless Kogaro validates several clusters.
+func RecordValidatorScan(cluster, validatorType string, duration time.Duration, resourcesListed int, err error) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	ValidatorScanDuration.WithLabelValues(validatorType, result, cluster).Observe(duration.Seconds())
+	ValidatorResourcesListed.WithLabelValues(validatorType, cluster).Observe(float64(resourcesListed))
+}
+
+// RecordTeamFindings replaces the per-team finding counts of a cluster with the counts
+// of its latest scan, by team and then severity, so that teams whose findings were
+// all resolved drop to no series
+func RecordTeamFindings(cluster string, counts map[string]map[string]int) {
+	TeamFindings.DeletePartialMatch(prometheus.Labels{"cluster": cluster})
+	for team, severities := range counts {
+		for severity, count := range severities {
+			TeamFindings.WithLabelValues(team, severity, cluster).Set(float64(count))
+		}
+	}
+}
+
+// RecordHygieneScores replaces the hygiene scores of a cluster and its namespaces with
+// those of its latest scan, so that deleted namespaces drop to no series
+func RecordHygieneScores(cluster string, score int, namespaces map[string]int) {
+	HygieneScore.WithLabelValues(cluster).Set(float64(score))
+	NamespaceHygieneScore.DeletePartialMatch(prometheus.Labels{"cluster": cluster})
+	for namespace, namespaceScore := range namespaces {
+		NamespaceHygieneScore.WithLabelValues(namespace, cluster).Set(float64(namespaceScore))
+	}
+}
+
+// RecordPolicyReportResults replaces the PolicyReport result counts of a cluster with
+// the counts of its latest scan, by policy engine and then result
+func RecordPolicyReportResults(cluster string, counts map[string]map[string]int) {
+	PolicyReportResults.DeletePartialMatch(prometheus.Labels{"cluster": cluster})
+	for source, results := range counts {
+		for result, count := range results {
+			PolicyReportResults.WithLabelValues(source, result, cluster).Set(float64(count))
+		}
+	}
 }
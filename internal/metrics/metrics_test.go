@@ -6,8 +6,11 @@
 package metrics
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func TestClassifyWorkload(t *testing.T) {
@@ -173,3 +176,119 @@ func TestRecordValidationErrorWithState(t *testing.T) {
 		t.Errorf("Expected state to be NEW, got %v", state.State)
 	}
 }
+
+func TestResolveValidatorFindings(t *testing.T) {
+	globalStateTracker = NewStateTracker()
+
+	record := func(resourceName string) string {
+		return RecordValidatorFinding("lifecycle-test", "reference", "Pod", resourceName, "shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", false)
+	}
+	active := func(phase FindingPhase) float64 {
+		return testutil.ToFloat64(FindingsActive.WithLabelValues("shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", string(phase), "lifecycle-test"))
+	}
+	resolvedBefore := testutil.ToFloat64(FindingsResolved.WithLabelValues("shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", "lifecycle-test"))
+
+	// First run reports two findings, which are new
+	ResolveValidatorFindings("lifecycle-test", "reference", map[string]bool{record("web"): true, record("worker"): true})
+	if got := active(FindingPhaseNew); got != 2 {
+		t.Errorf("new findings after first run = %v, want 2", got)
+	}
+
+	// Second run still reports one of them, which becomes active
+	webKey := record("web")
+	ResolveValidatorFindings("lifecycle-test", "reference", map[string]bool{webKey: true})
+	if got := active(FindingPhaseNew); got != 0 {
+		t.Errorf("new findings after second run = %v, want 0", got)
+	}
+	if got := active(FindingPhaseActive); got != 1 {
+		t.Errorf("active findings after second run = %v, want 1", got)
+	}
+	if state := globalStateTracker.GetState(webKey); state.Phase != FindingPhaseActive {
+		t.Errorf("web finding phase = %v, want %v", state.Phase, FindingPhaseActive)
+	}
+
+	workerKey := GetClusterStateKey("lifecycle-test", "shop", "Pod", "worker", "dangling_configmap_volume")
+	if state := globalStateTracker.GetState(workerKey); !state.Resolved || state.Phase != FindingPhaseResolved {
+		t.Errorf("worker finding = %+v, want resolved", state)
+	}
+	if got := testutil.ToFloat64(FindingsResolved.WithLabelValues("shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", "lifecycle-test")) - resolvedBefore; got != 1 {
+		t.Errorf("resolved findings = %v, want 1", got)
+	}
+
+	// Findings of other validators are left alone
+	ResolveValidatorFindings("lifecycle-test", "security", map[string]bool{})
+	if got := active(FindingPhaseActive); got != 1 {
+		t.Errorf("active findings after another validator's run = %v, want 1", got)
+	}
+
+	// A resolved finding reported again is new once more
+	ResolveValidatorFindings("lifecycle-test", "reference", map[string]bool{webKey: true, record("worker"): true})
+	if state := globalStateTracker.GetState(workerKey); state.Resolved || state.Phase != FindingPhaseNew {
+		t.Errorf("reopened worker finding = %+v, want new", state)
+	}
+}
+
+func TestCatalog(t *testing.T) {
+	catalog := Catalog()
+	if len(catalog) != len(collectors()) {
+		t.Fatalf("Catalog() returned %d metrics, want %d", len(catalog), len(collectors()))
+	}
+
+	byName := make(map[string]MetricInfo, len(catalog))
+	for _, info := range catalog {
+		if info.Name == "" || info.Help == "" || info.Type == "" {
+			t.Errorf("incomplete metric info %+v", info)
+		}
+		byName[info.Name] = info
+	}
+
+	active, ok := byName["kogaro_findings_active"]
+	if !ok {
+		t.Fatal("kogaro_findings_active missing from catalog")
+	}
+	if active.Type != "gauge" {
+		t.Errorf("kogaro_findings_active type = %s, want gauge", active.Type)
+	}
+	wantLabels := []string{"namespace", "validation_type", "severity", "error_code", "phase", "cluster"}
+	if strings.Join(active.Labels, ",") != strings.Join(wantLabels, ",") {
+		t.Errorf("kogaro_findings_active labels = %v, want %v", active.Labels, wantLabels)
+	}
+
+	if got := byName["kogaro_validation_runs_total"].Type; got != "counter" {
+		t.Errorf("kogaro_validation_runs_total type = %s, want counter", got)
+	}
+	if got := byName["kogaro_scan_duration_seconds"].Type; got != "histogram" {
+		t.Errorf("kogaro_scan_duration_seconds type = %s, want histogram", got)
+	}
+	if got := byName["kogaro_shard_namespaces"]; got.Type != "gauge" || len(got.Labels) != 0 {
+		t.Errorf("kogaro_shard_namespaces = %+v, want an unlabelled gauge", got)
+	}
+}
+
+func TestStateTracker_SnapshotRestore(t *testing.T) {
+	globalStateTracker = NewStateTracker()
+
+	firstSeen := time.Now().Add(-48 * time.Hour)
+	key := RecordValidatorFinding("handoff-test", "reference", "Pod", "web", "shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", false)
+	ResolveValidatorFindings("handoff-test", "reference", map[string]bool{key: true})
+	globalStateTracker.states[key].FirstSeen = firstSeen
+	resolved := RecordValidatorFinding("handoff-test", "reference", "Pod", "worker", "shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", false)
+	globalStateTracker.MarkResolved(resolved, time.Now())
+
+	records := globalStateTracker.Snapshot()
+	if len(records) != 1 || records[0].Key != key || records[0].Validator != "reference" || records[0].Runs != 1 {
+		t.Fatalf("Snapshot() = %+v, want only the open web finding", records)
+	}
+
+	// A new leader resumes the finding with its age, and its next run makes it active
+	globalStateTracker = NewStateTracker()
+	globalStateTracker.Restore(records, time.Now())
+	if state := globalStateTracker.GetState(key); state == nil || !state.FirstSeen.Equal(firstSeen) || state.State != TemporalStateStable {
+		t.Fatalf("restored state = %+v, want first seen two days ago", state)
+	}
+	RecordValidatorFinding("handoff-test", "reference", "Pod", "web", "shop", "dangling_configmap_volume", "error", "KOGARO-REF-002", false)
+	ResolveValidatorFindings("handoff-test", "reference", map[string]bool{key: true})
+	if state := globalStateTracker.GetState(key); state.Phase != FindingPhaseActive || !state.FirstSeen.Equal(firstSeen) {
+		t.Errorf("state after the new leader's run = %+v, want active since two days ago", state)
+	}
+}
@@ -8,18 +8,47 @@ package metrics
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 )
 
+// FindingPhase is the lifecycle phase of a finding across validator runs
+type FindingPhase string
+
+const (
+	// FindingPhaseNew indicates a finding reported for the first time, or again after it was resolved
+	FindingPhaseNew FindingPhase = "new"
+	// FindingPhaseActive indicates a finding reported by consecutive validator runs
+	FindingPhaseActive FindingPhase = "active"
+	// FindingPhaseResolved indicates a finding that its validator no longer reports
+	FindingPhaseResolved FindingPhase = "resolved"
+)
+
 // ValidationState represents the state of a validation error over time
 type ValidationState struct {
 	FirstSeen   time.Time
 	LastSeen    time.Time
 	State       TemporalState
+	Phase       FindingPhase
 	ChangeCount int
 	Resolved    bool
 	ErrorCode   string
+
+	finding findingLabels
+	// runs counts the validator runs that reported the finding since it was last opened
+	runs int
+}
+
+// findingLabels identifies the finding a state belongs to in the findings metrics
+type findingLabels struct {
+	cluster        string
+	validator      string
+	namespace      string
+	resourceType   string
+	resourceName   string
+	validationType string
+	severity       string
 }
 
 // StateTracker manages validation state persistence across runs
@@ -40,6 +69,16 @@ func GetStateKey(namespace, resourceType, resourceName, validationType string) s
 	return namespace + "/" + resourceType + "/" + resourceName + "/" + validationType
 }
 
+// GetClusterStateKey generates a unique key for a validation error in a named cluster,
+// so that the same resource in different clusters is tracked separately
+func GetClusterStateKey(cluster, namespace, resourceType, resourceName, validationType string) string {
+	key := GetStateKey(namespace, resourceType, resourceName, validationType)
+	if cluster == "" {
+		return key
+	}
+	return cluster + ":" + key
+}
+
 // UpdateState updates the state of a validation error and returns the updated state
 func (st *StateTracker) UpdateState(key string, currentTime time.Time, errorCode string) *ValidationState {
 	st.mu.Lock()
@@ -52,11 +91,22 @@ func (st *StateTracker) UpdateState(key string, currentTime time.Time, errorCode
 			FirstSeen:   currentTime,
 			LastSeen:    currentTime,
 			State:       TemporalStateNew,
+			Phase:       FindingPhaseNew,
 			ChangeCount: 1,
 			Resolved:    false,
 			ErrorCode:   errorCode,
 		}
 		st.states[key] = state
+	} else if state.Resolved {
+		// Reopened issue, whose age starts over
+		state.FirstSeen = currentTime
+		state.LastSeen = currentTime
+		state.State = TemporalStateNew
+		state.Phase = FindingPhaseNew
+		state.ChangeCount++
+		state.Resolved = false
+		state.ErrorCode = errorCode
+		state.runs = 0
 	} else {
 		// Existing issue
 		state.LastSeen = currentTime
@@ -82,21 +132,77 @@ func (st *StateTracker) MarkResolved(key string, resolutionTime time.Time) *Vali
 		return nil
 	}
 
+	st.resolve(state, resolutionTime)
+	st.updateActiveFindings()
+
+	return state
+}
+
+// ResolveUnreported completes a run of a validator in a cluster: findings it reported
+// in the run that were already open become active, and the open findings it did not
+// report are marked resolved. reported holds the state keys of the run's findings.
+func (st *StateTracker) ResolveUnreported(cluster, validator string, reported map[string]bool, resolutionTime time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for key, state := range st.states {
+		if state.Resolved || state.finding.cluster != cluster || state.finding.validator != validator {
+			continue
+		}
+		if !reported[key] {
+			st.resolve(state, resolutionTime)
+			continue
+		}
+		if state.runs > 0 {
+			state.Phase = FindingPhaseActive
+		}
+		state.runs++
+	}
+
+	st.updateActiveFindings()
+}
+
+// resolve marks a state resolved and records the resolution metrics. The caller must
+// hold the lock.
+func (st *StateTracker) resolve(state *ValidationState, resolutionTime time.Time) {
 	state.Resolved = true
 	state.LastSeen = resolutionTime
 	state.State = TemporalStateResolved
+	state.Phase = FindingPhaseResolved
 
 	// Calculate resolution duration
 	resolutionDuration := resolutionTime.Sub(state.FirstSeen)
 
 	// Record resolution metrics
-	namespace, resourceType, resourceName, validationType := parseStateKey(key)
+	finding := state.finding
 	RecordValidationResolved(
-		namespace, resourceType, resourceName, validationType, "error", state.ErrorCode, // Default severity for resolved errors
+		finding.cluster, finding.namespace, finding.resourceType, finding.resourceName, finding.validationType, finding.severity, state.ErrorCode,
 		resolutionDuration.Hours(),
 	)
+}
 
-	return state
+// updateActiveFindings rebuilds the active findings gauge from the open states, so that
+// series of resolved findings drop to nothing instead of keeping their last value. The
+// caller must hold the lock.
+func (st *StateTracker) updateActiveFindings() {
+	FindingsActive.Reset()
+	for _, state := range st.states {
+		if state.Resolved {
+			continue
+		}
+		finding := state.finding
+		FindingsActive.WithLabelValues(finding.namespace, finding.validationType, finding.severity, state.ErrorCode, string(state.Phase), finding.cluster).Inc()
+	}
+}
+
+// setFinding records the finding a state belongs to
+func (st *StateTracker) setFinding(key string, finding findingLabels) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if state, exists := st.states[key]; exists {
+		state.finding = finding
+	}
 }
 
 // GetState retrieves the current state of a validation error
@@ -134,12 +240,89 @@ func (st *StateTracker) CleanupResolved(olderThan time.Duration) {
 	}
 }
 
-// parseStateKey parses a state key back into its components
-func parseStateKey(key string) (namespace, resourceType, resourceName, validationType string) {
-	// This is a simplified parser - in production you might want more robust parsing
-	// For now, we'll assume the key format is: namespace/resourceType/resourceName/validationType
-	// This is a basic implementation and might need enhancement for edge cases
-	return "", "", "", ""
+// FindingRecord is the persisted state of an open finding, from which a replica that
+// takes over the leader election lease resumes tracking it
+type FindingRecord struct {
+	Key            string       `json:"key"`
+	Cluster        string       `json:"cluster,omitempty"`
+	Validator      string       `json:"validator,omitempty"`
+	Namespace      string       `json:"namespace,omitempty"`
+	ResourceType   string       `json:"resourceType"`
+	ResourceName   string       `json:"resourceName"`
+	ValidationType string       `json:"validationType"`
+	Severity       string       `json:"severity,omitempty"`
+	ErrorCode      string       `json:"errorCode,omitempty"`
+	FirstSeen      time.Time    `json:"firstSeen"`
+	LastSeen       time.Time    `json:"lastSeen"`
+	Phase          FindingPhase `json:"phase"`
+	ChangeCount    int          `json:"changeCount"`
+	Runs           int          `json:"runs"`
+}
+
+// Snapshot returns the records of the open findings, sorted by key
+func (st *StateTracker) Snapshot() []FindingRecord {
+	st.mu.RLock()
+	defer st.mu.RUnlock()
+
+	records := make([]FindingRecord, 0, len(st.states))
+	for key, state := range st.states {
+		if state.Resolved {
+			continue
+		}
+		finding := state.finding
+		records = append(records, FindingRecord{
+			Key:            key,
+			Cluster:        finding.cluster,
+			Validator:      finding.validator,
+			Namespace:      finding.namespace,
+			ResourceType:   finding.resourceType,
+			ResourceName:   finding.resourceName,
+			ValidationType: finding.validationType,
+			Severity:       finding.severity,
+			ErrorCode:      state.ErrorCode,
+			FirstSeen:      state.FirstSeen,
+			LastSeen:       state.LastSeen,
+			Phase:          state.Phase,
+			ChangeCount:    state.ChangeCount,
+			Runs:           state.runs,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	return records
+}
+
+// Restore resumes tracking the open findings of records, such as those a previous
+// leader persisted, so that their age and phase carry over and the next run resolves
+// them if they are gone. Findings the tracker already holds are kept as they are.
+func (st *StateTracker) Restore(records []FindingRecord, now time.Time) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	for _, record := range records {
+		if _, exists := st.states[record.Key]; exists {
+			continue
+		}
+		st.states[record.Key] = &ValidationState{
+			FirstSeen:   record.FirstSeen,
+			LastSeen:    record.LastSeen,
+			State:       ClassifyTemporalState(now.Sub(record.FirstSeen).Hours()),
+			Phase:       record.Phase,
+			ChangeCount: record.ChangeCount,
+			ErrorCode:   record.ErrorCode,
+			finding: findingLabels{
+				cluster:        record.Cluster,
+				validator:      record.Validator,
+				namespace:      record.Namespace,
+				resourceType:   record.ResourceType,
+				resourceName:   record.ResourceName,
+				validationType: record.ValidationType,
+				severity:       record.Severity,
+			},
+			runs: record.Runs,
+		}
+	}
+
+	st.updateActiveFindings()
 }
 
 // Global state tracker instance
@@ -155,6 +338,25 @@ func RecordValidationErrorWithState(
 	resourceType, resourceName, namespace, validationType, severity, errorCode string,
 	expectedPattern bool,
 ) {
+	RecordClusterValidationError("", resourceType, resourceName, namespace, validationType, severity, errorCode, expectedPattern)
+}
+
+// RecordClusterValidationError records a validation error found in a named cluster with
+// proper state tracking. The cluster is empty unless Kogaro validates several clusters.
+func RecordClusterValidationError(
+	cluster, resourceType, resourceName, namespace, validationType, severity, errorCode string,
+	expectedPattern bool,
+) {
+	RecordValidatorFinding(cluster, "", resourceType, resourceName, namespace, validationType, severity, errorCode, expectedPattern)
+}
+
+// RecordValidatorFinding records a validation error reported by a validator with proper
+// state tracking, and returns its state key. Once the validator's run is complete,
+// ResolveValidatorFindings resolves the findings the run no longer reported.
+func RecordValidatorFinding(
+	cluster, validator, resourceType, resourceName, namespace, validationType, severity, errorCode string,
+	expectedPattern bool,
+) string {
 	// Classify workload
 	workloadCategory := ClassifyWorkload(namespace, resourceType)
 
@@ -168,30 +370,55 @@ func RecordValidationErrorWithState(
 		string(workloadCategory),
 		fmt.Sprintf("%t", expectedPattern),
 		errorCode,
+		cluster,
 	).Inc()
 
 	// Update state tracking
-	key := GetStateKey(namespace, resourceType, resourceName, validationType)
+	key := GetClusterStateKey(cluster, namespace, resourceType, resourceName, validationType)
+	previous := globalStateTracker.GetState(key)
+	previousState := TemporalStateNew
+	if previous != nil {
+		previousState = previous.State
+	}
 	state := globalStateTracker.UpdateState(key, time.Now(), errorCode)
-	state.ErrorCode = errorCode // Set the error code on the state
+	globalStateTracker.setFinding(key, findingLabels{
+		cluster:        cluster,
+		validator:      validator,
+		namespace:      namespace,
+		resourceType:   resourceType,
+		resourceName:   resourceName,
+		validationType: validationType,
+		severity:       severity,
+	})
 
 	// Record temporal metrics
 	now := float64(time.Now().Unix())
-	firstSeenMetric := ValidationFirstSeen.WithLabelValues(namespace, resourceType, resourceName, validationType, severity, string(workloadCategory), fmt.Sprintf("%t", expectedPattern), errorCode)
-	lastSeenMetric := ValidationLastSeen.WithLabelValues(namespace, resourceType, resourceName, validationType)
+	firstSeenMetric := ValidationFirstSeen.WithLabelValues(namespace, resourceType, resourceName, validationType, severity, string(workloadCategory), fmt.Sprintf("%t", expectedPattern), errorCode, cluster)
+	lastSeenMetric := ValidationLastSeen.WithLabelValues(namespace, resourceType, resourceName, validationType, cluster)
 
 	// Set timestamps
 	firstSeenMetric.Set(float64(state.FirstSeen.Unix()))
 	lastSeenMetric.Set(now)
 
-	// Calculate and set age
+	// Calculate and set age, dropping the series of the temporal state the error left
+	if previousState != state.State {
+		ValidationAge.DeleteLabelValues(namespace, resourceType, resourceName, validationType, string(previousState), cluster)
+	}
 	ageHours := time.Since(state.FirstSeen).Hours()
 	ValidationAge.WithLabelValues(
-		namespace, resourceType, resourceName, validationType, string(state.State),
+		namespace, resourceType, resourceName, validationType, string(state.State), cluster,
 	).Set(ageHours)
 
 	// Record state change
 	ValidationStateChanges.WithLabelValues(
-		namespace, resourceType, resourceName, validationType, string(state.State),
+		namespace, resourceType, resourceName, validationType, string(state.State), cluster,
 	).Inc()
+
+	return key
+}
+
+// ResolveValidatorFindings completes a validator's run, marking the findings it reported
+// before but not in this run as resolved and updating the active findings gauge
+func ResolveValidatorFindings(cluster, validator string, reported map[string]bool) {
+	globalStateTracker.ResolveUnreported(cluster, validator, reported, time.Now())
 }
@@ -0,0 +1,241 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/schedule"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// SMTPConfig is the mail server the email digest is sent through
+type SMTPConfig struct {
+	// Address of the server as host:port
+	Address string
+	// Username and Password authenticate with PLAIN auth, which requires TLS unless
+	// the server is on localhost; no authentication when Username is empty
+	Username string
+	Password string
+	// From is the sender address of the digest
+	From string
+}
+
+// DigestRecipient is an address the email digest is sent to
+type DigestRecipient struct {
+	Address string
+	// Namespaces are the names or globs of the namespaces whose findings the recipient
+	// receives. Without namespaces the recipient receives every finding, including
+	// findings on cluster-scoped resources.
+	Namespaces []string
+}
+
+// ParseDigestRecipients parses semicolon-separated recipients, each an address
+// optionally followed by "=" and comma-separated namespace names or globs, such as
+// "sre@example.com;payments@example.com=payments,billing-*"
+func ParseDigestRecipients(value string) ([]DigestRecipient, error) {
+	var recipients []DigestRecipient
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		address, namespaces, _ := strings.Cut(entry, "=")
+		parsed, err := mail.ParseAddress(strings.TrimSpace(address))
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", entry, err)
+		}
+		recipient := DigestRecipient{Address: parsed.Address}
+		for _, namespace := range strings.Split(namespaces, ",") {
+			if namespace = strings.TrimSpace(namespace); namespace == "" {
+				continue
+			}
+			if _, err := path.Match(namespace, ""); err != nil {
+				return nil, fmt.Errorf("invalid namespace %q of recipient %s: %w", namespace, recipient.Address, err)
+			}
+			recipient.Namespaces = append(recipient.Namespaces, namespace)
+		}
+		recipients = append(recipients, recipient)
+	}
+	return recipients, nil
+}
+
+// receives reports whether the recipient receives a finding
+func (r DigestRecipient) receives(finding validators.ValidationError) bool {
+	if len(r.Namespaces) == 0 {
+		return true
+	}
+	for _, namespace := range r.Namespaces {
+		if matched, _ := path.Match(namespace, finding.Namespace); matched && finding.Namespace != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sendMail sends an email; it is smtp.SendMail outside tests
+type sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailDigest emails each recipient a digest of the active findings in its namespaces
+// on a schedule, such as daily or weekly. The digest is sent after the first scan at
+// or after each scheduled time, rendered with the HTML report formatter. Recipients
+// without findings get no digest, and digests that fail to send are retried after the
+// next scan.
+type EmailDigest struct {
+	smtp       SMTPConfig
+	recipients []DigestRecipient
+	schedule   *schedule.Cron
+	log        logr.Logger
+	send       sendMail
+
+	mu sync.Mutex
+	// Time the next digest is due
+	next time.Time
+	// Addresses the due digest has not been sent to yet
+	pending map[string]bool
+}
+
+// NewEmailDigest creates an EmailDigest whose first digest is due at the first
+// scheduled time after now
+func NewEmailDigest(config SMTPConfig, recipients []DigestRecipient, digestSchedule *schedule.Cron, now time.Time, log logr.Logger) *EmailDigest {
+	return &EmailDigest{
+		smtp:       config,
+		recipients: recipients,
+		schedule:   digestSchedule,
+		log:        log.WithName("email-digest"),
+		send:       smtp.SendMail,
+		next:       digestSchedule.Next(now),
+		pending:    make(map[string]bool),
+	}
+}
+
+// HandleScan sends the digest when it is due, from the findings of a completed scan.
+// Its signature matches validators.ScanListener.
+func (d *EmailDigest) HandleScan(result validators.ValidationResult, scanTime time.Time) {
+	if err := d.Deliver(result.Errors, result.ScorePolicy(), scanTime); err != nil {
+		d.log.Error(err, "failed to send email digest")
+	}
+}
+
+// Deliver sends the digest to the recipients it is due for at the given time. The
+// score policy weighs the findings in the digest's hygiene score.
+func (d *EmailDigest) Deliver(findings []validators.ValidationError, policy *validators.ScorePolicy, at time.Time) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !at.Before(d.next) {
+		for _, recipient := range d.recipients {
+			d.pending[recipient.Address] = true
+		}
+		d.next = d.schedule.Next(at)
+	}
+
+	var errs []error
+	sent := 0
+	for _, recipient := range d.recipients {
+		if !d.pending[recipient.Address] {
+			continue
+		}
+		var received []validators.ValidationError
+		for _, finding := range findings {
+			if recipient.receives(finding) {
+				received = append(received, finding)
+			}
+		}
+		if len(received) > 0 {
+			if err := d.sendDigest(recipient.Address, received, policy, at); err != nil {
+				errs = append(errs, fmt.Errorf("failed to send digest to %s: %w", recipient.Address, err))
+				continue
+			}
+			sent++
+		}
+		delete(d.pending, recipient.Address)
+	}
+	if sent > 0 {
+		d.log.Info("sent email digest", "recipients", sent, "next", d.next)
+	}
+	return errors.Join(errs...)
+}
+
+// sendDigest emails a digest of findings to one address
+func (d *EmailDigest) sendDigest(address string, findings []validators.ValidationError, policy *validators.ScorePolicy, at time.Time) error {
+	title := "Kogaro digest for " + at.In(d.schedule.Location()).Format("Mon 2 Jan 2006")
+	if cluster := findings[0].Cluster; cluster != "" {
+		title += " (" + cluster + ")"
+	}
+	body, err := validators.FormatHTMLReport(title, findings, policy)
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+	for _, finding := range findings {
+		if !finding.IsWarning() && !finding.IsInfo() {
+			errorCount++
+		}
+	}
+	subject := fmt.Sprintf("%s: %d findings, %d errors", title, len(findings), errorCount)
+	message, err := digestMessage(d.smtp.From, address, subject, body, at)
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if d.smtp.Username != "" {
+		host, _, err := net.SplitHostPort(d.smtp.Address)
+		if err != nil {
+			return fmt.Errorf("invalid SMTP address %q: %w", d.smtp.Address, err)
+		}
+		auth = smtp.PlainAuth("", d.smtp.Username, d.smtp.Password, host)
+	}
+	return d.send(d.smtp.Address, auth, d.smtp.From, []string{address}, message)
+}
+
+// digestMessage builds a MIME message with a quoted-printable HTML body
+func digestMessage(from, to, subject, htmlBody string, at time.Time) ([]byte, error) {
+	headers := map[string]string{
+		"From":                      from,
+		"To":                        to,
+		"Subject":                   mime.QEncoding.Encode("utf-8", subject),
+		"Date":                      at.Format(time.RFC1123Z),
+		"MIME-Version":              "1.0",
+		"Content-Type":              `text/html; charset="utf-8"`,
+		"Content-Transfer-Encoding": "quoted-printable",
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var message bytes.Buffer
+	for _, name := range names {
+		fmt.Fprintf(&message, "%s: %s\r\n", name, headers[name])
+	}
+	message.WriteString("\r\n")
+	body := quotedprintable.NewWriter(&message)
+	if _, err := body.Write([]byte(htmlBody)); err != nil {
+		return nil, fmt.Errorf("failed to encode digest: %w", err)
+	}
+	if err := body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode digest: %w", err)
+	}
+	return message.Bytes(), nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"errors"
+	"net/smtp"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/schedule"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestParseDigestRecipients(t *testing.T) {
+	recipients, err := ParseDigestRecipients(" SRE <sre@example.com>; payments@example.com = payments, billing-* ;")
+	if err != nil {
+		t.Fatalf("ParseDigestRecipients() error = %v", err)
+	}
+	want := []DigestRecipient{
+		{Address: "sre@example.com"},
+		{Address: "payments@example.com", Namespaces: []string{"payments", "billing-*"}},
+	}
+	if !reflect.DeepEqual(recipients, want) {
+		t.Errorf("ParseDigestRecipients() = %+v, want %+v", recipients, want)
+	}
+
+	for _, value := range []string{"not-an-address", "sre@example.com=billing-["} {
+		if _, err := ParseDigestRecipients(value); err == nil {
+			t.Errorf("ParseDigestRecipients(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+// sentMail is an email captured by a fake sendMail
+type sentMail struct {
+	to      []string
+	message string
+}
+
+func TestEmailDigest_Deliver(t *testing.T) {
+	cron, err := schedule.ParseCron("0 8 * * *", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recipients := []DigestRecipient{
+		{Address: "sre@example.com"},
+		{Address: "payments@example.com", Namespaces: []string{"payments"}},
+		{Address: "billing@example.com", Namespaces: []string{"billing-*"}},
+	}
+	start := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+	digest := NewEmailDigest(SMTPConfig{Address: "smtp.example.com:587", From: "kogaro@example.com"}, recipients, cron, start, logr.Discard())
+
+	var sent []sentMail
+	fail := false
+	digest.send = func(_ string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		if fail {
+			return errors.New("connection refused")
+		}
+		if auth != nil || from != "kogaro@example.com" {
+			t.Errorf("sent from %s with auth %v", from, auth)
+		}
+		sent = append(sent, sentMail{to: to, message: string(msg)})
+		return nil
+	}
+
+	findings := []validators.ValidationError{
+		validators.NewValidationErrorWithCode("Deployment", "api", "payments", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist"),
+		validators.NewValidationErrorWithCode("StorageClass", "fast", "", "missing_storage_class", "KOGARO-REF-009", "unused").
+			WithSeverity(validators.SeverityWarning),
+	}
+
+	// Nothing is sent before the digest is due
+	if err := digest.Deliver(findings, nil, start.Add(30*time.Minute)); err != nil || len(sent) != 0 {
+		t.Fatalf("Deliver() before the schedule sent %d emails, error = %v", len(sent), err)
+	}
+
+	// Failed digests stay pending, while recipients without findings are done
+	fail = true
+	if err := digest.Deliver(findings, nil, start.Add(90*time.Minute)); err == nil {
+		t.Fatal("Deliver() succeeded although sending failed")
+	}
+	fail = false
+	if err := digest.Deliver(findings, nil, start.Add(2*time.Hour)); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	if len(sent) != 2 || sent[0].to[0] != "sre@example.com" || sent[1].to[0] != "payments@example.com" {
+		t.Fatalf("sent = %+v, want digests for sre and payments", sent)
+	}
+	if !strings.Contains(sent[0].message, "KOGARO-REF-009") || strings.Contains(sent[1].message, "KOGARO-REF-009") {
+		t.Error("cluster-scoped finding was not limited to recipients without namespaces")
+	}
+	if !strings.Contains(sent[1].message, "Subject: Kogaro digest for Mon 2 Jun 2025: 1 findings, 1 errors\r\n") {
+		t.Errorf("unexpected message:\n%s", sent[1].message)
+	}
+
+	// Once sent, the digest waits for the next scheduled time
+	sent = nil
+	if err := digest.Deliver(findings, nil, start.Add(3*time.Hour)); err != nil || len(sent) != 0 {
+		t.Fatalf("Deliver() after sending sent %d emails, error = %v", len(sent), err)
+	}
+	if err := digest.Deliver(findings, nil, start.Add(25*time.Hour)); err != nil || len(sent) != 2 {
+		t.Fatalf("Deliver() on the next day sent %d emails, error = %v", len(sent), err)
+	}
+}
+
+func TestDigestMessage(t *testing.T) {
+	at := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+	body := "<p>" + strings.Repeat("é", 60) + "</p>"
+	message, err := digestMessage("kogaro@example.com", "sre@example.com", "Digest: 1 finding", body, at)
+	if err != nil {
+		t.Fatalf("digestMessage() error = %v", err)
+	}
+	headers, encoded, found := strings.Cut(string(message), "\r\n\r\n")
+	if !found {
+		t.Fatalf("message has no body:\n%s", message)
+	}
+	for _, header := range []string{
+		"From: kogaro@example.com",
+		"To: sre@example.com",
+		"Subject: Digest: 1 finding",
+		"Date: Mon, 02 Jun 2025 08:00:00 +0000",
+		`Content-Type: text/html; charset="utf-8"`,
+		"Content-Transfer-Encoding: quoted-printable",
+	} {
+		if !strings.Contains(headers, header+"\r\n") && !strings.HasSuffix(headers, header) {
+			t.Errorf("header %q is missing from:\n%s", header, headers)
+		}
+	}
+	for _, line := range strings.Split(encoded, "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("encoded line is %d characters long", len(line))
+		}
+	}
+	if !strings.Contains(encoded, "=C3=A9") {
+		t.Errorf("body is not quoted-printable:\n%s", encoded)
+	}
+}
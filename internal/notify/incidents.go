@@ -0,0 +1,237 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// notifyTimeout bounds the time spent opening and resolving incidents after a scan
+const notifyTimeout = 2 * time.Minute
+
+// Incident is an incident opened for a finding in a paging service
+type Incident struct {
+	// DedupKey identifies the incident in the paging service; it is the finding's fingerprint
+	DedupKey string
+	// Finding is the finding the incident was opened for
+	Finding validators.ValidationError
+}
+
+// IncidentBackend opens and resolves incidents in a paging service
+type IncidentBackend interface {
+	// Channel is the notification channel name teams use to route findings to the service
+	Channel() string
+	// Trigger opens an incident, or updates the open incident with the same dedup key
+	Trigger(ctx context.Context, incident Incident) error
+	// Resolve resolves the incident with the dedup key of the given incident
+	Resolve(ctx context.Context, incident Incident) error
+}
+
+// IncidentNotifier opens an incident for each error-severity finding its filter
+// selects, and resolves the incident once a scan no longer reports the finding.
+// WithSeverities selects findings of other severities too, and WithMinAge holds
+// incidents back until scans have reported their finding for a while.
+// Findings of a team that names notification channels only open incidents in the
+// backends of those channels. Incidents that fail to open or resolve are retried
+// after the next scan.
+type IncidentNotifier struct {
+	backend IncidentBackend
+	filter  *Filter
+	// Severities of the selected findings; error only when empty
+	severities []validators.Severity
+	// Time scans must report a finding before an incident is opened for it
+	minAge time.Duration
+	// routes returns the notification channels of a team
+	routes func(team string) []string
+	log    logr.Logger
+
+	mu sync.Mutex
+	// Incidents opened by the notifier that are not resolved yet, by dedup key
+	open map[string]Incident
+	// Time each selected finding was first reported without a gap, by dedup key
+	firstSeen map[string]time.Time
+}
+
+// NewIncidentNotifier creates an IncidentNotifier. A nil routes function sends every
+// selected finding to the backend.
+func NewIncidentNotifier(backend IncidentBackend, filter *Filter, routes func(team string) []string, log logr.Logger) *IncidentNotifier {
+	return &IncidentNotifier{
+		backend:   backend,
+		filter:    filter,
+		routes:    routes,
+		log:       log.WithName(backend.Channel()),
+		open:      make(map[string]Incident),
+		firstSeen: make(map[string]time.Time),
+	}
+}
+
+// WithSeverities selects the findings of the given severities instead of the
+// error-severity findings only
+func (n *IncidentNotifier) WithSeverities(severities ...validators.Severity) *IncidentNotifier {
+	n.severities = severities
+	return n
+}
+
+// WithMinAge holds incidents back until scans have reported their finding for at
+// least the given time, so that findings fixed soon after they appear don't open
+// incidents. The time is measured from the first scan of this process that reported
+// the finding, or of the previous leader when its state was restored.
+func (n *IncidentNotifier) WithMinAge(minAge time.Duration) *IncidentNotifier {
+	n.minAge = minAge
+	return n
+}
+
+// HandleScan opens and resolves incidents from the findings of a completed scan.
+// Its signature matches validators.ScanListener.
+func (n *IncidentNotifier) HandleScan(result validators.ValidationResult, scanTime time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+	defer cancel()
+
+	if err := n.Sync(ctx, result.Errors, scanTime); err != nil {
+		n.log.Error(err, "failed to update incidents")
+	}
+}
+
+// Sync opens an incident for each selected finding without one and resolves the
+// incidents of findings that are no longer reported, from the findings of a scan at
+// the given time
+func (n *IncidentNotifier) Sync(ctx context.Context, findings []validators.ValidationError, at time.Time) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	current := make(map[string]Incident)
+	for _, finding := range findings {
+		if n.selects(finding) {
+			incident := Incident{DedupKey: Fingerprint(finding), Finding: finding}
+			current[incident.DedupKey] = incident
+			if _, seen := n.firstSeen[incident.DedupKey]; !seen {
+				n.firstSeen[incident.DedupKey] = at
+			}
+		}
+	}
+	for key := range n.firstSeen {
+		if _, exists := current[key]; !exists {
+			delete(n.firstSeen, key)
+		}
+	}
+
+	var errs []error
+	opened, resolved := 0, 0
+	for key, incident := range current {
+		if _, exists := n.open[key]; exists || at.Sub(n.firstSeen[key]) < n.minAge {
+			continue
+		}
+		if err := n.backend.Trigger(ctx, incident); err != nil {
+			errs = append(errs, fmt.Errorf("failed to open incident for %s: %w", summary(incident.Finding), err))
+			continue
+		}
+		n.open[key] = incident
+		opened++
+	}
+	for key, incident := range n.open {
+		if _, exists := current[key]; exists {
+			continue
+		}
+		if err := n.backend.Resolve(ctx, incident); err != nil {
+			errs = append(errs, fmt.Errorf("failed to resolve incident for %s: %w", summary(incident.Finding), err))
+			continue
+		}
+		delete(n.open, key)
+		resolved++
+	}
+
+	if opened > 0 || resolved > 0 {
+		n.log.Info("updated incidents", "opened", opened, "resolved", resolved, "open", len(n.open))
+	}
+	return errors.Join(errs...)
+}
+
+// IncidentState is the persisted state of an IncidentNotifier, from which a replica
+// that takes over the leader election lease resumes its incidents instead of opening
+// them again
+type IncidentState struct {
+	// Open are the incidents opened and not resolved yet
+	Open []Incident `json:"open,omitempty"`
+	// FirstSeen is the time each selected finding was first reported, by dedup key
+	FirstSeen map[string]time.Time `json:"firstSeen,omitempty"`
+}
+
+// Channel returns the notification channel of the notifier's backend
+func (n *IncidentNotifier) Channel() string {
+	return n.backend.Channel()
+}
+
+// State returns the open incidents and the first sightings of the notifier's findings
+func (n *IncidentNotifier) State() IncidentState {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	state := IncidentState{FirstSeen: make(map[string]time.Time, len(n.firstSeen))}
+	for _, incident := range n.open {
+		state.Open = append(state.Open, incident)
+	}
+	slices.SortFunc(state.Open, func(a, b Incident) int { return strings.Compare(a.DedupKey, b.DedupKey) })
+	for key, at := range n.firstSeen {
+		state.FirstSeen[key] = at
+	}
+	return state
+}
+
+// Restore resumes the incidents and first sightings of a persisted state. The next
+// scan resolves the restored incidents whose findings are gone, and incidents the
+// notifier tracks already are kept.
+func (n *IncidentNotifier) Restore(state IncidentState) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, incident := range state.Open {
+		if _, exists := n.open[incident.DedupKey]; !exists {
+			n.open[incident.DedupKey] = incident
+		}
+	}
+	for key, at := range state.FirstSeen {
+		if _, exists := n.firstSeen[key]; !exists {
+			n.firstSeen[key] = at
+		}
+	}
+}
+
+// selects reports whether a finding opens an incident in the notifier's backend
+func (n *IncidentNotifier) selects(finding validators.ValidationError) bool {
+	severities := n.severities
+	if len(severities) == 0 {
+		severities = []validators.Severity{validators.SeverityError}
+	}
+	if !slices.Contains(severities, severity(finding)) || !n.filter.Matches(finding) {
+		return false
+	}
+	if n.routes == nil || finding.Team == "" {
+		return true
+	}
+	channels := n.routes(finding.Team)
+	return len(channels) == 0 || slices.Contains(channels, n.backend.Channel())
+}
+
+// severity returns the severity of a finding, treating findings without one as errors
+func severity(finding validators.ValidationError) validators.Severity {
+	switch {
+	case finding.IsWarning():
+		return validators.SeverityWarning
+	case finding.IsInfo():
+		return validators.SeverityInfo
+	}
+	return validators.SeverityError
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// recordingBackend records the incidents it is asked to open and resolve
+type recordingBackend struct {
+	triggered []string
+	resolved  []string
+	fail      bool
+}
+
+func (b *recordingBackend) Channel() string {
+	return PagerDutyChannel
+}
+
+func (b *recordingBackend) Trigger(_ context.Context, incident Incident) error {
+	if b.fail {
+		return errors.New("service unavailable")
+	}
+	b.triggered = append(b.triggered, incident.Finding.ResourceName)
+	return nil
+}
+
+func (b *recordingBackend) Resolve(_ context.Context, incident Incident) error {
+	if b.fail {
+		return errors.New("service unavailable")
+	}
+	b.resolved = append(b.resolved, incident.Finding.ResourceName)
+	return nil
+}
+
+func TestIncidentNotifier_Sync(t *testing.T) {
+	finding := func(name, team string, severity validators.Severity) validators.ValidationError {
+		ve := validators.NewValidationErrorWithCode("Ingress", name, "prod", "ingress_no_backend_pods", "KOGARO-NET-005", "no ready backend pods").
+			WithSeverity(severity)
+		ve.Team = team
+		return ve
+	}
+	web := finding("web", "", validators.SeverityError)
+	api := finding("api", "payments", validators.SeverityError)
+	// Teams that name other channels don't page through this backend
+	admin := finding("admin", "platform", validators.SeverityError)
+	docs := finding("docs", "", validators.SeverityWarning)
+
+	routes := func(team string) []string {
+		return map[string][]string{"payments": {"pagerduty", "email"}, "platform": {"opsgenie"}}[team]
+	}
+	backend := &recordingBackend{}
+	notifier := NewIncidentNotifier(backend, nil, routes, logr.Discard())
+	ctx := context.Background()
+
+	if err := notifier.Sync(ctx, []validators.ValidationError{web, api, admin, docs}, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	sort.Strings(backend.triggered)
+	if want := []string{"api", "web"}; !reflect.DeepEqual(backend.triggered, want) {
+		t.Errorf("triggered = %q, want %q", backend.triggered, want)
+	}
+
+	// An open incident is not triggered again, and a fixed finding is resolved
+	reworded := web
+	reworded.Message = "reworded"
+	backend.triggered = nil
+	if err := notifier.Sync(ctx, []validators.ValidationError{reworded}, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(backend.triggered) != 0 || !reflect.DeepEqual(backend.resolved, []string{"api"}) {
+		t.Errorf("triggered = %q, resolved = %q, want only api resolved", backend.triggered, backend.resolved)
+	}
+
+	// Failed requests are retried after the next scan
+	backend.fail = true
+	if err := notifier.Sync(ctx, nil, time.Now()); err == nil {
+		t.Error("Sync() succeeded although the backend failed")
+	}
+	backend.fail = false
+	backend.resolved = nil
+	if err := notifier.Sync(ctx, nil, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !reflect.DeepEqual(backend.resolved, []string{"web"}) {
+		t.Errorf("resolved = %q, want the retried web incident", backend.resolved)
+	}
+}
+
+func TestIncidentNotifier_MinAge(t *testing.T) {
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist")
+	backend := &recordingBackend{}
+	notifier := NewIncidentNotifier(backend, nil, nil, logr.Discard()).WithMinAge(24 * time.Hour)
+	ctx := context.Background()
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+
+	sync := func(at time.Time, findings ...validators.ValidationError) {
+		t.Helper()
+		if err := notifier.Sync(ctx, findings, at); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+	}
+
+	// A finding fixed before it is old enough never opens an incident, and its age
+	// starts over when it is reported again
+	sync(start, finding)
+	sync(start.Add(12 * time.Hour))
+	sync(start.Add(20*time.Hour), finding)
+	sync(start.Add(40*time.Hour), finding)
+	if len(backend.triggered) != 0 {
+		t.Fatalf("triggered = %q before the finding was a day old", backend.triggered)
+	}
+	sync(start.Add(44*time.Hour), finding)
+	if !reflect.DeepEqual(backend.triggered, []string{"web"}) {
+		t.Errorf("triggered = %q, want web once it was a day old", backend.triggered)
+	}
+}
+
+func TestIncidentNotifier_Restore(t *testing.T) {
+	web := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist")
+	api := validators.NewValidationErrorWithCode("Deployment", "api", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'routes' does not exist")
+	worker := validators.NewValidationErrorWithCode("Deployment", "worker", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'jobs' does not exist")
+	ctx := context.Background()
+	start := time.Date(2025, 6, 2, 8, 0, 0, 0, time.UTC)
+
+	// The previous leader opened incidents for web and api, and saw worker first at start
+	leader := NewIncidentNotifier(&recordingBackend{}, nil, nil, logr.Discard()).WithMinAge(time.Hour)
+	if err := leader.Sync(ctx, []validators.ValidationError{web, api}, start.Add(-2*time.Hour)); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if err := leader.Sync(ctx, []validators.ValidationError{web, api, worker}, start); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	state := leader.State()
+	if len(state.Open) != 2 || len(state.FirstSeen) != 3 {
+		t.Fatalf("State() = %+v, want the api and web incidents and three first sightings", state)
+	}
+
+	// The new leader neither opens them again nor restarts the age of worker
+	backend := &recordingBackend{}
+	successor := NewIncidentNotifier(backend, nil, nil, logr.Discard()).WithMinAge(time.Hour)
+	successor.Restore(state)
+	if err := successor.Sync(ctx, []validators.ValidationError{web, worker}, start.Add(90*time.Minute)); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if !reflect.DeepEqual(backend.triggered, []string{"worker"}) || !reflect.DeepEqual(backend.resolved, []string{"api"}) {
+		t.Errorf("triggered = %q, resolved = %q, want worker triggered and api resolved", backend.triggered, backend.resolved)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// JiraChannel is the notification channel name of Jira
+	JiraChannel = "jira"
+
+	// jiraSummaryLimit is the longest summary Jira accepts
+	jiraSummaryLimit = 255
+	// jiraManifestLimit bounds the resource manifest quoted in issue descriptions
+	jiraManifestLimit = 8000
+)
+
+// jiraCustomFieldPattern matches the ID of a Jira custom field, such as customfield_10050
+var jiraCustomFieldPattern = regexp.MustCompile(`^customfield_([0-9]+)$`)
+
+// JiraConfig configures the Jira issues opened for findings
+type JiraConfig struct {
+	// URL of the Jira site, such as https://example.atlassian.net
+	URL string
+	// User is the account email the API token belongs to on Jira Cloud. When empty,
+	// Token is sent as a personal access token of Jira Data Center.
+	User  string
+	Token string
+	// Project is the key of the project issues are created in
+	Project string
+	// IssueType is the name of the type of the issues created, such as Bug
+	IssueType string
+	// FingerprintField is the ID of the text custom field holding the finding
+	// fingerprint, such as customfield_10050, by which issues are deduplicated
+	FingerprintField string
+	// CloseTransition is the name of the workflow transition that closes an issue
+	// whose finding is fixed, such as Done
+	CloseTransition string
+	// Labels are added to every issue, with the finding's error code
+	Labels []string
+}
+
+// Jira creates an issue for each finding and transitions it to closed once the
+// finding is fixed. Issues are found by the fingerprint custom field, so a finding
+// never has two open issues, including across restarts of Kogaro.
+type Jira struct {
+	config     JiraConfig
+	fieldID    string
+	manifest   func(ctx context.Context, finding validators.ValidationError) (string, error)
+	httpClient *http.Client
+}
+
+// NewJira creates a Jira backend. The optional manifest function returns the YAML of
+// a finding's resource to quote in the issue description.
+func NewJira(config JiraConfig, manifest func(ctx context.Context, finding validators.ValidationError) (string, error), httpClient *http.Client) (*Jira, error) {
+	if parsed, err := url.Parse(config.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid Jira URL %q, expected an http or https URL", config.URL)
+	}
+	match := jiraCustomFieldPattern.FindStringSubmatch(config.FingerprintField)
+	if match == nil {
+		return nil, fmt.Errorf("invalid Jira fingerprint field %q, expected a custom field ID such as customfield_10050", config.FingerprintField)
+	}
+	if config.Project == "" || config.IssueType == "" || config.CloseTransition == "" {
+		return nil, errors.New("creating Jira issues requires a project, an issue type and a close transition")
+	}
+	config.URL = strings.TrimSuffix(config.URL, "/")
+	return &Jira{config: config, fieldID: match[1], manifest: manifest, httpClient: httpClient}, nil
+}
+
+// Channel implements IncidentBackend
+func (j *Jira) Channel() string {
+	return JiraChannel
+}
+
+// Trigger implements IncidentBackend by creating an issue, unless the finding has an
+// open issue already
+func (j *Jira) Trigger(ctx context.Context, incident Incident) error {
+	keys, err := j.openIssues(ctx, incident.DedupKey)
+	if err != nil || len(keys) > 0 {
+		return err
+	}
+
+	finding := incident.Finding
+	labels := append([]string{"kogaro"}, j.config.Labels...)
+	if finding.ErrorCode != "" {
+		labels = append(labels, finding.ErrorCode)
+	}
+	fields := map[string]any{
+		"project":                 map[string]string{"key": j.config.Project},
+		"issuetype":               map[string]string{"name": j.config.IssueType},
+		"summary":                 truncate(summary(finding), jiraSummaryLimit),
+		"description":             j.description(ctx, finding),
+		"labels":                  labels,
+		j.config.FingerprintField: incident.DedupKey,
+	}
+	return j.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]any{"fields": fields}, nil)
+}
+
+// Resolve implements IncidentBackend by commenting on and closing the open issues of
+// the finding
+func (j *Jira) Resolve(ctx context.Context, incident Incident) error {
+	keys, err := j.openIssues(ctx, incident.DedupKey)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		var transitions struct {
+			Transitions []struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			} `json:"transitions"`
+		}
+		if err := j.do(ctx, http.MethodGet, "/rest/api/2/issue/"+key+"/transitions", nil, &transitions); err != nil {
+			return err
+		}
+		id := ""
+		for _, transition := range transitions.Transitions {
+			if strings.EqualFold(transition.Name, j.config.CloseTransition) {
+				id = transition.ID
+			}
+		}
+		if id == "" {
+			return fmt.Errorf("issue %s has no %q transition", key, j.config.CloseTransition)
+		}
+
+		comment := map[string]string{"body": "Kogaro no longer reports this finding, so the issue is closed."}
+		if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue/"+key+"/comment", comment, nil); err != nil {
+			return err
+		}
+		transition := map[string]any{"transition": map[string]string{"id": id}}
+		if err := j.do(ctx, http.MethodPost, "/rest/api/2/issue/"+key+"/transitions", transition, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// openIssues returns the keys of the issues of a finding that are not done
+func (j *Jira) openIssues(ctx context.Context, fingerprint string) ([]string, error) {
+	search := map[string]any{
+		"jql": fmt.Sprintf(`project = "%s" AND cf[%s] ~ "\"%s\"" AND statusCategory != Done`,
+			j.config.Project, j.fieldID, fingerprint),
+		"fields":     []string{"summary"},
+		"maxResults": 10,
+	}
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	// Jira Cloud serves enhanced search; Jira Data Center only the original endpoint
+	err := j.do(ctx, http.MethodPost, "/rest/api/2/search/jql", search, &result)
+	var rejected *statusError
+	if errors.As(err, &rejected) && rejected.code == http.StatusNotFound {
+		err = j.do(ctx, http.MethodPost, "/rest/api/2/search", search, &result)
+	}
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		keys = append(keys, issue.Key)
+	}
+	return keys, nil
+}
+
+// description renders a finding in Jira wiki markup
+func (j *Jira) description(ctx context.Context, finding validators.ValidationError) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n\n", finding.Message)
+	if finding.RemediationHint != "" {
+		fmt.Fprintf(&text, "h3. Remediation\n%s\n\n", finding.RemediationHint)
+	}
+
+	fields := details(finding)
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	text.WriteString("h3. Details\n||Field||Value||\n")
+	for _, name := range names {
+		value := strings.NewReplacer("|", "\\|", "\n", " ").Replace(fields[name])
+		fmt.Fprintf(&text, "|%s|%s|\n", name, value)
+	}
+
+	if j.manifest != nil {
+		if manifest, err := j.manifest(ctx, finding); err == nil {
+			fmt.Fprintf(&text, "\nh3. Resource\n{code:yaml}\n%s\n{code}\n", strings.TrimSpace(truncate(manifest, jiraManifestLimit)))
+		}
+	}
+	if info, ok := validators.LookupErrorCode(finding.ErrorCode); ok {
+		fmt.Fprintf(&text, "\n[%s: %s|%s]\n", finding.ErrorCode, info.Title, info.DocURL)
+	}
+	return text.String()
+}
+
+// do sends a request to the Jira REST API and decodes the response into out, unless
+// out is nil
+func (j *Jira) do(ctx context.Context, method, path string, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, method, j.config.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if j.config.User != "" {
+		req.SetBasicAuth(j.config.User, j.config.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.config.Token)
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{host: req.URL.Host, status: resp.Status, code: resp.StatusCode, message: strings.TrimSpace(string(message))}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", path, err)
+	}
+	return nil
+}
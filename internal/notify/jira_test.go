@@ -0,0 +1,153 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// fakeJira serves the parts of the Jira REST API the Jira backend uses, like Jira
+// Data Center without enhanced search
+type fakeJira struct {
+	t        *testing.T
+	issues   map[string]map[string]any
+	closed   map[string]bool
+	comments map[string]int
+	searches []string
+}
+
+func (f *fakeJira) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, token, ok := r.BasicAuth(); !ok || user != "kogaro@example.com" || token != "api-token" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	var body map[string]any
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			f.t.Errorf("failed to decode %s request: %v", r.URL.Path, err)
+		}
+	}
+
+	switch {
+	case r.URL.Path == "/rest/api/2/search/jql":
+		http.NotFound(w, r)
+	case r.URL.Path == "/rest/api/2/search":
+		jql := body["jql"].(string)
+		f.searches = append(f.searches, jql)
+		var issues []map[string]string
+		for key, fields := range f.issues {
+			if !f.closed[key] && strings.Contains(jql, `"\"`+fields["customfield_10050"].(string)+`\""`) {
+				issues = append(issues, map[string]string{"key": key})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"issues": issues})
+	case r.URL.Path == "/rest/api/2/issue":
+		key := "HYG-" + string(rune('1'+len(f.issues)))
+		f.issues[key] = body["fields"].(map[string]any)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]string{"key": key})
+	case strings.HasSuffix(r.URL.Path, "/comment"):
+		f.comments[strings.Split(r.URL.Path, "/")[5]]++
+		w.WriteHeader(http.StatusCreated)
+	case strings.HasSuffix(r.URL.Path, "/transitions") && r.Method == http.MethodGet:
+		_ = json.NewEncoder(w).Encode(map[string]any{"transitions": []map[string]string{
+			{"id": "11", "name": "In Progress"}, {"id": "31", "name": "Done"},
+		}})
+	case strings.HasSuffix(r.URL.Path, "/transitions"):
+		if id := body["transition"].(map[string]any)["id"]; id != "31" {
+			f.t.Errorf("transition = %v, want the Done transition", id)
+		}
+		f.closed[strings.Split(r.URL.Path, "/")[5]] = true
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestJira_TriggerAndResolve(t *testing.T) {
+	jira := &fakeJira{t: t, issues: map[string]map[string]any{}, closed: map[string]bool{}, comments: map[string]int{}}
+	server := httptest.NewServer(jira)
+	defer server.Close()
+
+	config := JiraConfig{
+		URL:              server.URL + "/",
+		User:             "kogaro@example.com",
+		Token:            "api-token",
+		Project:          "HYG",
+		IssueType:        "Bug",
+		FingerprintField: "customfield_10050",
+		CloseTransition:  "done",
+	}
+	manifest := func(_ context.Context, finding validators.ValidationError) (string, error) {
+		return "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: " + finding.ResourceName + "\n", nil
+	}
+	backend, err := NewJira(config, manifest, server.Client())
+	if err != nil {
+		t.Fatalf("NewJira() error = %v", err)
+	}
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist").
+		WithRemediationHint("Create the ConfigMap")
+	incident := Incident{DedupKey: Fingerprint(finding), Finding: finding}
+	ctx := context.Background()
+
+	// An open issue of the finding is reused, for example after a restart
+	for range 2 {
+		if err := backend.Trigger(ctx, incident); err != nil {
+			t.Fatalf("Trigger() error = %v", err)
+		}
+	}
+	if len(jira.issues) != 1 {
+		t.Fatalf("created %d issues, want 1", len(jira.issues))
+	}
+	if !strings.Contains(jira.searches[0], `project = "HYG" AND cf[10050] ~`) {
+		t.Errorf("search = %s", jira.searches[0])
+	}
+
+	fields := jira.issues["HYG-1"]
+	if fields["summary"] != "[KOGARO-REF-003] Deployment/shop/web: ConfigMap 'settings' does not exist" || fields["customfield_10050"] != incident.DedupKey {
+		t.Errorf("fields = %v", fields)
+	}
+	description := fields["description"].(string)
+	for _, want := range []string{
+		"h3. Remediation\nCreate the ConfigMap",
+		"|error_code|KOGARO-REF-003|",
+		"{code:yaml}\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n{code}",
+	} {
+		if !strings.Contains(description, want) {
+			t.Errorf("description lacks %q:\n%s", want, description)
+		}
+	}
+
+	if err := backend.Resolve(ctx, incident); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if !jira.closed["HYG-1"] || jira.comments["HYG-1"] != 1 {
+		t.Errorf("closed = %v, comments = %v, want HYG-1 commented on and closed", jira.closed, jira.comments)
+	}
+}
+
+func TestNewJira_InvalidConfig(t *testing.T) {
+	valid := JiraConfig{URL: "https://example.atlassian.net", Project: "HYG", IssueType: "Bug", FingerprintField: "customfield_10050", CloseTransition: "Done"}
+	for name, change := range map[string]func(*JiraConfig){
+		"url":        func(c *JiraConfig) { c.URL = "example.atlassian.net" },
+		"field":      func(c *JiraConfig) { c.FingerprintField = "Kogaro Fingerprint" },
+		"project":    func(c *JiraConfig) { c.Project = "" },
+		"transition": func(c *JiraConfig) { c.CloseTransition = "" },
+	} {
+		config := valid
+		change(&config)
+		if _, err := NewJira(config, nil, http.DefaultClient); err == nil {
+			t.Errorf("%s: NewJira() succeeded, want an error", name)
+		}
+	}
+}
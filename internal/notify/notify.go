@@ -0,0 +1,195 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package notify alerts people to the findings of cluster scans.
+//
+// It provides notifiers, registered with validators.ValidatorRegistry.AddNotifier,
+// that open incidents in paging services such as PagerDuty and Opsgenie for the
+// findings that need attention and resolve them once the findings are fixed, that
+// post new and resolved findings to webhooks, and that email scheduled digests of
+// the active findings.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// requestTimeout bounds each request to a notification service
+const requestTimeout = 10 * time.Second
+
+// Fingerprint identifies a finding independently of its message wording, so that
+// the incident opened for it is deduplicated across scans and resolved once the
+// finding is no longer reported
+func Fingerprint(finding validators.ValidationError) string {
+	identity := strings.Join([]string{finding.Cluster, finding.ErrorCode, finding.ValidationType,
+		finding.ResourceType, finding.Namespace, finding.ResourceName}, "|")
+	sum := sha256.Sum256([]byte(identity))
+	return "kogaro-" + hex.EncodeToString(sum[:16])
+}
+
+// filterEntry matches findings by error code, code prefix or validation type, and
+// optionally by namespace
+type filterEntry struct {
+	match     string
+	namespace string
+}
+
+// Filter selects the findings a notifier alerts on. Entries have the form
+// match[@namespace], where match is an error code such as KOGARO-NET-002, a code
+// prefix ending in "*" such as KOGARO-SEC-*, or a validation type such as
+// ingress_no_backend_pods, and namespace is a namespace name or glob such as prod-*.
+// A finding is selected when any entry matches it; an empty filter selects every finding.
+type Filter struct {
+	entries []filterEntry
+}
+
+// ParseFilter parses comma-separated filter entries, ignoring blank entries
+func ParseFilter(value string) (*Filter, error) {
+	filter := &Filter{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		match, namespace, _ := strings.Cut(entry, "@")
+		if match == "" {
+			return nil, fmt.Errorf("invalid filter entry %q: missing error code or validation type", entry)
+		}
+		if namespace != "" {
+			if _, err := path.Match(namespace, ""); err != nil {
+				return nil, fmt.Errorf("invalid filter entry %q: %w", entry, err)
+			}
+		}
+		filter.entries = append(filter.entries, filterEntry{match: match, namespace: namespace})
+	}
+	return filter, nil
+}
+
+// Matches reports whether a finding is selected by the filter
+func (f *Filter) Matches(finding validators.ValidationError) bool {
+	if f == nil || len(f.entries) == 0 {
+		return true
+	}
+	for _, entry := range f.entries {
+		if entry.namespace != "" {
+			if matched, _ := path.Match(entry.namespace, finding.Namespace); !matched {
+				continue
+			}
+		}
+		if prefix, ok := strings.CutSuffix(entry.match, "*"); ok {
+			if strings.HasPrefix(finding.ErrorCode, prefix) {
+				return true
+			}
+		} else if entry.match == finding.ErrorCode || entry.match == finding.ValidationType {
+			return true
+		}
+	}
+	return false
+}
+
+// summary describes a finding in one line, such as
+// "[KOGARO-REF-003] Deployment/shop/web: ConfigMap 'settings' does not exist"
+func summary(finding validators.ValidationError) string {
+	return fmt.Sprintf("[%s] %s/%s: %s", finding.ErrorCode, finding.ResourceType, finding.GetResourceKey(), finding.Message)
+}
+
+// details returns the fields of a finding that help responders, including the
+// details recorded by its validator
+func details(finding validators.ValidationError) map[string]string {
+	fields := make(map[string]string, len(finding.Details)+8)
+	for key, value := range finding.Details {
+		fields[key] = value
+	}
+	set := func(key, value string) {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	set("error_code", finding.ErrorCode)
+	set("validation_type", finding.ValidationType)
+	set("resource", finding.ResourceType+"/"+finding.ResourceName)
+	set("namespace", finding.Namespace)
+	set("cluster", finding.Cluster)
+	set("team", finding.Team)
+	set("remediation_hint", finding.RemediationHint)
+	if info, ok := validators.LookupErrorCode(finding.ErrorCode); ok {
+		set("docs", info.DocURL)
+	}
+	return fields
+}
+
+// truncate shortens a value to at most limit bytes, on a rune boundary, marking the cut with "…"
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	cut := limit - len("…")
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return value[:cut] + "…"
+}
+
+// statusError is the answer of a notification service that rejected a request
+type statusError struct {
+	host    string
+	status  string
+	code    int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%s answered %s: %s", e.host, e.status, e.message)
+}
+
+// postJSON sends a JSON request body to a notification service and fails unless it
+// answers with a 2xx status
+func postJSON(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+	return post(ctx, httpClient, url, headers, data)
+}
+
+// post sends an encoded JSON request body to a notification service and fails with a
+// *statusError unless it answers with a 2xx status
+func post(ctx context.Context, httpClient *http.Client, url string, headers map[string]string, data []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return &statusError{host: req.URL.Host, status: resp.Status, code: resp.StatusCode, message: strings.TrimSpace(string(message))}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestFilter_Matches(t *testing.T) {
+	filter, err := ParseFilter("KOGARO-SEC-*, ingress_no_backend_pods@prod-*,KOGARO-REF-003")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		finding validators.ValidationError
+		want    bool
+	}{
+		{"code prefix", validators.ValidationError{ErrorCode: "KOGARO-SEC-004", Namespace: "dev"}, true},
+		{"exact code", validators.ValidationError{ErrorCode: "KOGARO-REF-003", Namespace: "dev"}, true},
+		{"validation type in namespace", validators.ValidationError{ErrorCode: "KOGARO-NET-005", ValidationType: "ingress_no_backend_pods", Namespace: "prod-eu"}, true},
+		{"validation type elsewhere", validators.ValidationError{ErrorCode: "KOGARO-NET-005", ValidationType: "ingress_no_backend_pods", Namespace: "dev"}, false},
+		{"other code", validators.ValidationError{ErrorCode: "KOGARO-RES-001", Namespace: "prod-eu"}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Matches(tt.finding); got != tt.want {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	empty, _ := ParseFilter("")
+	if !empty.Matches(validators.ValidationError{ErrorCode: "KOGARO-RES-001"}) {
+		t.Error("an empty filter does not match every finding")
+	}
+	for _, invalid := range []string{"@prod", "KOGARO-SEC-*@prod-["} {
+		if _, err := ParseFilter(invalid); err == nil {
+			t.Errorf("ParseFilter(%q) succeeded, want an error", invalid)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist")
+	reworded := finding
+	reworded.Message = "ConfigMap settings is missing"
+	elsewhere := finding
+	elsewhere.Cluster = "staging"
+
+	if Fingerprint(finding) != Fingerprint(reworded) {
+		t.Error("Fingerprint() changes with the message")
+	}
+	if Fingerprint(finding) == Fingerprint(elsewhere) {
+		t.Error("Fingerprint() is the same in another cluster")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	if got := truncate("short", 10); got != "short" {
+		t.Errorf("truncate() = %q", got)
+	}
+	// The cut never splits a rune
+	if got := truncate("abcdéfgh", 8); got != "abcd…" {
+		t.Errorf("truncate() = %q, want %q", got, "abcd…")
+	}
+}
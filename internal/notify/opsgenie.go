@@ -0,0 +1,98 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// OpsgenieChannel is the notification channel name of Opsgenie
+	OpsgenieChannel = "opsgenie"
+	// OpsgenieAPIURL is the Opsgenie API endpoint; accounts in the EU use https://api.eu.opsgenie.com
+	OpsgenieAPIURL = "https://api.opsgenie.com"
+
+	// opsgenieMessageLimit is the longest alert message Opsgenie accepts
+	opsgenieMessageLimit = 130
+	// opsgeniePriority is the priority of the alerts opened for error-severity findings
+	opsgeniePriority = "P2"
+)
+
+// Opsgenie opens and closes alerts through the Opsgenie Alert API. Alerts are
+// deduplicated by their alias, the finding fingerprint.
+type Opsgenie struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+// NewOpsgenie creates an Opsgenie backend authenticating with the key of an API
+// integration. An empty apiURL uses OpsgenieAPIURL.
+func NewOpsgenie(apiKey, apiURL string, httpClient *http.Client) *Opsgenie {
+	if apiURL == "" {
+		apiURL = OpsgenieAPIURL
+	}
+	return &Opsgenie{apiKey: apiKey, url: strings.TrimSuffix(apiURL, "/"), httpClient: httpClient}
+}
+
+// opsgenieAlert is an alert of the Opsgenie Alert API
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Entity      string            `json:"entity,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+// opsgenieClose is the body of a request closing an Opsgenie alert
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note"`
+}
+
+// Channel implements IncidentBackend
+func (o *Opsgenie) Channel() string {
+	return OpsgenieChannel
+}
+
+// Trigger implements IncidentBackend
+func (o *Opsgenie) Trigger(ctx context.Context, incident Incident) error {
+	finding := incident.Finding
+	tags := []string{"kogaro", finding.ErrorCode}
+	if finding.Team != "" {
+		tags = append(tags, "team:"+finding.Team)
+	}
+	return postJSON(ctx, o.httpClient, o.url+"/v2/alerts", o.headers(), opsgenieAlert{
+		Message:     truncate(summary(finding), opsgenieMessageLimit),
+		Alias:       incident.DedupKey,
+		Description: finding.Message,
+		Priority:    opsgeniePriority,
+		Source:      source(finding),
+		Entity:      finding.ResourceType + "/" + finding.GetResourceKey(),
+		Tags:        tags,
+		Details:     details(finding),
+	})
+}
+
+// Resolve implements IncidentBackend
+func (o *Opsgenie) Resolve(ctx context.Context, incident Incident) error {
+	endpoint := o.url + "/v2/alerts/" + url.PathEscape(incident.DedupKey) + "/close?identifierType=alias"
+	return postJSON(ctx, o.httpClient, endpoint, o.headers(), opsgenieClose{
+		Source: source(incident.Finding),
+		Note:   "The finding is no longer reported by Kogaro",
+	})
+}
+
+// headers returns the headers authenticating requests to Opsgenie
+func (o *Opsgenie) headers() map[string]string {
+	return map[string]string{"Authorization": "GenieKey " + o.apiKey}
+}
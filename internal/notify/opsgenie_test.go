@@ -0,0 +1,71 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestOpsgenie_TriggerAndResolve(t *testing.T) {
+	type request struct {
+		path string
+		auth string
+		body map[string]any
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		requests = append(requests, request{path: r.URL.RequestURI(), auth: r.Header.Get("Authorization"), body: body})
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	opsgenie := NewOpsgenie("api-key", server.URL+"/", server.Client())
+	finding := validators.NewValidationErrorWithCode("Ingress", "web", "prod", "ingress_no_backend_pods", "KOGARO-NET-005",
+		"Ingress service 'web' has no ready backend pods, so every request it routes fails until the Deployment behind it becomes ready again")
+	finding.Team = "payments"
+	incident := Incident{DedupKey: Fingerprint(finding), Finding: finding}
+
+	ctx := context.Background()
+	if err := opsgenie.Trigger(ctx, incident); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if err := opsgenie.Resolve(ctx, incident); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("received %d requests, want 2", len(requests))
+	}
+
+	create := requests[0]
+	if create.path != "/v2/alerts" || create.auth != "GenieKey api-key" {
+		t.Errorf("create request = %s with %q", create.path, create.auth)
+	}
+	if message := create.body["message"].(string); len(message) > opsgenieMessageLimit {
+		t.Errorf("message is %d bytes long, want at most %d", len(message), opsgenieMessageLimit)
+	}
+	if create.body["alias"] != incident.DedupKey || create.body["description"] != finding.Message || create.body["source"] != "kogaro" {
+		t.Errorf("create body = %v", create.body)
+	}
+	if tags := create.body["tags"]; !reflect.DeepEqual(tags, []any{"kogaro", "KOGARO-NET-005", "team:payments"}) {
+		t.Errorf("tags = %v", tags)
+	}
+
+	closeRequest := requests[1]
+	if want := "/v2/alerts/" + incident.DedupKey + "/close?identifierType=alias"; closeRequest.path != want || closeRequest.auth != "GenieKey api-key" {
+		t.Errorf("close request = %s with %q, want %s", closeRequest.path, closeRequest.auth, want)
+	}
+}
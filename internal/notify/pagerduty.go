@@ -0,0 +1,107 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// PagerDutyChannel is the notification channel name of PagerDuty
+	PagerDutyChannel = "pagerduty"
+	// PagerDutyEventsURL is the endpoint of the PagerDuty Events API v2
+	PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// pagerDutySummaryLimit is the longest summary PagerDuty accepts
+	pagerDutySummaryLimit = 1024
+)
+
+// PagerDuty opens and resolves incidents through the PagerDuty Events API v2. Events
+// are deduplicated by the finding fingerprint, so an incident is opened once per
+// finding however many scans report it.
+type PagerDuty struct {
+	routingKey string
+	url        string
+	httpClient *http.Client
+}
+
+// NewPagerDuty creates a PagerDuty backend sending events with the routing key of a
+// PagerDuty service integration
+func NewPagerDuty(routingKey string, httpClient *http.Client) *PagerDuty {
+	return &PagerDuty{routingKey: routingKey, url: PagerDutyEventsURL, httpClient: httpClient}
+}
+
+// pagerDutyEvent is an event of the PagerDuty Events API v2
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// Channel implements IncidentBackend
+func (p *PagerDuty) Channel() string {
+	return PagerDutyChannel
+}
+
+// Trigger implements IncidentBackend
+func (p *PagerDuty) Trigger(ctx context.Context, incident Incident) error {
+	finding := incident.Finding
+	event := pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "trigger",
+		DedupKey:    incident.DedupKey,
+		Payload: &pagerDutyPayload{
+			Summary:       truncate(summary(finding), pagerDutySummaryLimit),
+			Source:        source(finding),
+			Severity:      "error",
+			Component:     finding.ResourceType + "/" + finding.ResourceName,
+			Group:         finding.Namespace,
+			Class:         finding.ErrorCode,
+			CustomDetails: details(finding),
+		},
+	}
+	if info, ok := validators.LookupErrorCode(finding.ErrorCode); ok {
+		event.Links = []pagerDutyLink{{Href: info.DocURL, Text: finding.ErrorCode + ": " + info.Title}}
+	}
+	return postJSON(ctx, p.httpClient, p.url, nil, event)
+}
+
+// Resolve implements IncidentBackend
+func (p *PagerDuty) Resolve(ctx context.Context, incident Incident) error {
+	return postJSON(ctx, p.httpClient, p.url, nil, pagerDutyEvent{
+		RoutingKey:  p.routingKey,
+		EventAction: "resolve",
+		DedupKey:    incident.DedupKey,
+	})
+}
+
+// source names where a finding was reported: its cluster, or Kogaro for a single cluster
+func source(finding validators.ValidationError) string {
+	if finding.Cluster != "" {
+		return finding.Cluster
+	}
+	return "kogaro"
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestPagerDuty_TriggerAndResolve(t *testing.T) {
+	var events []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("failed to decode event: %v", err)
+		}
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pagerDuty := NewPagerDuty("routing-key", server.Client())
+	pagerDuty.url = server.URL
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist").
+		WithRemediationHint("Create the ConfigMap")
+	finding.Cluster = "prod-eu"
+	incident := Incident{DedupKey: Fingerprint(finding), Finding: finding}
+
+	ctx := context.Background()
+	if err := pagerDuty.Trigger(ctx, incident); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if err := pagerDuty.Resolve(ctx, incident); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("received %d events, want 2", len(events))
+	}
+
+	trigger := events[0]
+	payload, _ := trigger["payload"].(map[string]any)
+	details, _ := payload["custom_details"].(map[string]any)
+	if trigger["event_action"] != "trigger" || trigger["dedup_key"] != incident.DedupKey || trigger["routing_key"] != "routing-key" {
+		t.Errorf("trigger event = %v", trigger)
+	}
+	if payload["summary"] != "[KOGARO-REF-003] Deployment/shop/web: ConfigMap 'settings' does not exist" ||
+		payload["source"] != "prod-eu" || payload["severity"] != "error" {
+		t.Errorf("trigger payload = %v", payload)
+	}
+	if details["remediation_hint"] != "Create the ConfigMap" || !strings.HasPrefix(details["docs"].(string), validators.ErrorCodesDocURL) {
+		t.Errorf("custom details = %v", details)
+	}
+
+	resolve := events[1]
+	if resolve["event_action"] != "resolve" || resolve["dedup_key"] != incident.DedupKey || resolve["payload"] != nil {
+		t.Errorf("resolve event = %v", resolve)
+	}
+}
+
+func TestPagerDuty_RejectedEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"status":"invalid event"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	pagerDuty := NewPagerDuty("routing-key", server.Client())
+	pagerDuty.url = server.URL
+	err := pagerDuty.Resolve(context.Background(), Incident{DedupKey: "kogaro-1"})
+	if err == nil || !strings.Contains(err.Error(), "invalid event") {
+		t.Errorf("Resolve() error = %v, want the rejection", err)
+	}
+}
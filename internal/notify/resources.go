@@ -0,0 +1,76 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// lastAppliedAnnotation holds a copy of the manifest that kubectl apply last applied
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// ResourceManifests reads the manifests of the resources findings were reported for,
+// so that tickets can show the configuration to fix
+type ResourceManifests struct {
+	reader client.Reader
+	scheme *runtime.Scheme
+}
+
+// NewResourceManifests creates ResourceManifests reading resources of the kinds the
+// scheme knows
+func NewResourceManifests(reader client.Reader, scheme *runtime.Scheme) *ResourceManifests {
+	return &ResourceManifests{reader: reader, scheme: scheme}
+}
+
+// YAML returns the manifest of a finding's resource as YAML, without its status,
+// managed fields and last-applied copy. The data of Secrets is left out.
+func (m *ResourceManifests) YAML(ctx context.Context, finding validators.ValidationError) (string, error) {
+	gvk, ok := m.kind(finding.ResourceType)
+	if !ok {
+		return "", fmt.Errorf("unknown kind %s", finding.ResourceType)
+	}
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	if err := m.reader.Get(ctx, client.ObjectKey{Namespace: finding.Namespace, Name: finding.ResourceName}, object); err != nil {
+		return "", fmt.Errorf("failed to get %s %s: %w", finding.ResourceType, finding.GetResourceKey(), err)
+	}
+
+	unstructured.RemoveNestedField(object.Object, "status")
+	unstructured.RemoveNestedField(object.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(object.Object, "metadata", "annotations", lastAppliedAnnotation)
+	if gvk.Group == "" && gvk.Kind == "Secret" {
+		unstructured.RemoveNestedField(object.Object, "data")
+		unstructured.RemoveNestedField(object.Object, "stringData")
+	}
+	if len(object.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(object.Object, "metadata", "annotations")
+	}
+
+	data, err := yaml.Marshal(object.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s %s: %w", finding.ResourceType, finding.GetResourceKey(), err)
+	}
+	return string(data), nil
+}
+
+// kind returns the group and version of a kind, in the scheme's version priority
+func (m *ResourceManifests) kind(kind string) (schema.GroupVersionKind, bool) {
+	for _, groupVersion := range m.scheme.PrioritizedVersionsAllGroups() {
+		if gvk := groupVersion.WithKind(kind); m.scheme.Recognizes(gvk) {
+			return gvk, true
+		}
+	}
+	return schema.GroupVersionKind{}, false
+}
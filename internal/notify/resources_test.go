@@ -0,0 +1,64 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestResourceManifests_YAML(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "web",
+			Namespace:     "shop",
+			Annotations:   map[string]string{lastAppliedAnnotation: `{"kind":"Deployment"}`},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		},
+		Status: appsv1.DeploymentStatus{ReadyReplicas: 2},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "shop"},
+		Data:       map[string][]byte{"tls.key": []byte("private")},
+	}
+	reader := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(deployment, secret).Build()
+	manifests := NewResourceManifests(reader, clientgoscheme.Scheme)
+	ctx := context.Background()
+
+	manifest, err := manifests.YAML(ctx, validators.ValidationError{ResourceType: "Deployment", ResourceName: "web", Namespace: "shop"})
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	if !strings.Contains(manifest, "kind: Deployment") || !strings.Contains(manifest, "name: web") {
+		t.Errorf("unexpected manifest:\n%s", manifest)
+	}
+	for _, omitted := range []string{"status", "managedFields", "annotations", "readyReplicas"} {
+		if strings.Contains(manifest, omitted) {
+			t.Errorf("manifest contains %s:\n%s", omitted, manifest)
+		}
+	}
+
+	manifest, err = manifests.YAML(ctx, validators.ValidationError{ResourceType: "Secret", ResourceName: "tls", Namespace: "shop"})
+	if err != nil {
+		t.Fatalf("YAML() error = %v", err)
+	}
+	if strings.Contains(manifest, "tls.key") {
+		t.Errorf("manifest contains the Secret data:\n%s", manifest)
+	}
+
+	if _, err := manifests.YAML(ctx, validators.ValidationError{ResourceType: "Widget", ResourceName: "web"}); err == nil {
+		t.Error("YAML() of an unknown kind succeeded")
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"text/template"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// WebhookEventNew is the event of a finding a scan reports for the first time
+	WebhookEventNew = "new"
+	// WebhookEventResolved is the event of a finding a scan no longer reports
+	WebhookEventResolved = "resolved"
+
+	// WebhookSignatureHeader carries the HMAC-SHA256 of the request body, keyed with
+	// the webhook secret, as "sha256=" followed by the hex digest
+	WebhookSignatureHeader = "X-Kogaro-Signature-256"
+
+	// defaultWebhookRetries is the number of times a failed delivery is retried
+	defaultWebhookRetries = 3
+	// webhookBackoff is the delay before the first retry; it doubles with each retry
+	webhookBackoff = time.Second
+)
+
+// defaultWebhookTemplate renders the event, the finding fingerprint and summary, and
+// the finding as Kogaro's JSON output encodes it
+const defaultWebhookTemplate = `{"event": {{json .Event}}, "fingerprint": {{json .Fingerprint}}, ` +
+	`"summary": {{json .Summary}}, "docs": {{json .DocURL}}, "timestamp": {{json .Timestamp}}, "finding": {{json .Finding}}}`
+
+// WebhookConfigFile is a document of webhooks, such as
+//
+//	webhooks:
+//	  - name: servicenow
+//	    url: https://example.service-now.com/api/now/table/incident
+//	    secret: signing-key
+//	    filter: KOGARO-SEC-*
+//	    template: |
+//	      {"short_description": {{json .Summary}}, "correlation_id": {{json .Fingerprint}}}
+type WebhookConfigFile struct {
+	Webhooks []WebhookConfig `json:"webhooks"`
+}
+
+// WebhookConfig configures a webhook that findings are posted to
+type WebhookConfig struct {
+	// Name identifies the webhook in logs and metrics, and is the notification channel
+	// teams route findings to it by
+	Name string `json:"name"`
+	// URL the events are posted to
+	URL string `json:"url"`
+	// Secret signs the request body in the X-Kogaro-Signature-256 header; unsigned when empty
+	Secret string `json:"secret,omitempty"`
+	// Headers are added to every request, such as an Authorization header
+	Headers map[string]string `json:"headers,omitempty"`
+	// Filter selects the findings posted, in the syntax of ParseFilter; all when empty
+	Filter string `json:"filter,omitempty"`
+	// Severities of the findings posted; all when empty
+	Severities []validators.Severity `json:"severities,omitempty"`
+	// Template is a Go template of the JSON request body, executed with a WebhookEvent.
+	// The json function encodes a value as JSON.
+	Template string `json:"template,omitempty"`
+	// MaxRetries is the number of times a failed delivery is retried, with
+	// exponential backoff, before it is dropped; 3 when unset
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
+
+// WebhookEvent is the data webhook templates are executed with
+type WebhookEvent struct {
+	// Event is "new" or "resolved"
+	Event string
+	// Fingerprint identifies the finding across scans, so a resolved event can be
+	// correlated with the new event of the same finding
+	Fingerprint string
+	// Summary describes the finding in one line
+	Summary string
+	// DocURL links to the documentation of the finding's error code
+	DocURL string
+	// Timestamp is when the event was sent
+	Timestamp time.Time
+	// Finding is the finding itself
+	Finding validators.ValidationError
+	// Details are the fields of the finding that help responders
+	Details map[string]string
+}
+
+// ParseWebhookConfig parses and checks a document of webhooks
+func ParseWebhookConfig(data []byte) ([]WebhookConfig, error) {
+	var file WebhookConfigFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse webhooks: %w", err)
+	}
+	names := make(map[string]bool)
+	for i, webhook := range file.Webhooks {
+		if webhook.Name == "" {
+			return nil, fmt.Errorf("webhook %d has no name", i+1)
+		}
+		if names[webhook.Name] {
+			return nil, fmt.Errorf("webhook %q is defined twice", webhook.Name)
+		}
+		names[webhook.Name] = true
+		if parsed, err := url.Parse(webhook.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("webhook %q has invalid url %q, expected an http or https URL", webhook.Name, webhook.URL)
+		}
+		for _, severity := range webhook.Severities {
+			switch severity {
+			case validators.SeverityError, validators.SeverityWarning, validators.SeverityInfo:
+			default:
+				return nil, fmt.Errorf("webhook %q has unknown severity %q, expected error, warning or info", webhook.Name, severity)
+			}
+		}
+		if webhook.MaxRetries != nil && *webhook.MaxRetries < 0 {
+			return nil, fmt.Errorf("webhook %q has negative maxRetries", webhook.Name)
+		}
+	}
+	return file.Webhooks, nil
+}
+
+// Webhook posts new and resolved findings to a URL, with a request body rendered from
+// a Go template. Failed deliveries are retried with exponential backoff; deliveries
+// whose retries all fail, or that the webhook rejects with a 4xx status other than
+// 429, are dropped and counted in kogaro_webhook_dead_letters_total.
+type Webhook struct {
+	name       string
+	url        string
+	secret     string
+	headers    map[string]string
+	template   *template.Template
+	maxRetries int
+	backoff    time.Duration
+	httpClient *http.Client
+	log        logr.Logger
+}
+
+// NewWebhook creates a Webhook, checking that its template renders valid JSON
+func NewWebhook(config WebhookConfig, httpClient *http.Client, log logr.Logger) (*Webhook, error) {
+	text := config.Template
+	if text == "" {
+		text = defaultWebhookTemplate
+	}
+	tmpl, err := template.New(config.Name).Option("missingkey=error").Funcs(template.FuncMap{"json": toJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("webhook %q has invalid template: %w", config.Name, err)
+	}
+
+	webhook := &Webhook{
+		name:       config.Name,
+		url:        config.URL,
+		secret:     config.Secret,
+		headers:    config.Headers,
+		template:   tmpl,
+		maxRetries: defaultWebhookRetries,
+		backoff:    webhookBackoff,
+		httpClient: httpClient,
+		log:        log.WithName("webhook").WithValues("webhook", config.Name),
+	}
+	if config.MaxRetries != nil {
+		webhook.maxRetries = *config.MaxRetries
+	}
+
+	sample := validators.NewValidationErrorWithCode("Deployment", "web", "default", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist")
+	if _, err := webhook.render(WebhookEventNew, sample); err != nil {
+		return nil, fmt.Errorf("webhook %q: %w", config.Name, err)
+	}
+	return webhook, nil
+}
+
+// NewWebhookNotifier creates an IncidentNotifier that posts the findings selected by a
+// webhook's filter and severities to the webhook
+func NewWebhookNotifier(config WebhookConfig, httpClient *http.Client, routes func(team string) []string, log logr.Logger) (*IncidentNotifier, error) {
+	filter, err := ParseFilter(config.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("webhook %q has invalid filter: %w", config.Name, err)
+	}
+	webhook, err := NewWebhook(config, httpClient, log)
+	if err != nil {
+		return nil, err
+	}
+	severities := config.Severities
+	if len(severities) == 0 {
+		severities = []validators.Severity{validators.SeverityError, validators.SeverityWarning, validators.SeverityInfo}
+	}
+	return NewIncidentNotifier(webhook, filter, routes, log).WithSeverities(severities...), nil
+}
+
+// Channel implements IncidentBackend
+func (w *Webhook) Channel() string {
+	return w.name
+}
+
+// Trigger implements IncidentBackend by posting a new event
+func (w *Webhook) Trigger(ctx context.Context, incident Incident) error {
+	return w.deliver(ctx, WebhookEventNew, incident)
+}
+
+// Resolve implements IncidentBackend by posting a resolved event
+func (w *Webhook) Resolve(ctx context.Context, incident Incident) error {
+	return w.deliver(ctx, WebhookEventResolved, incident)
+}
+
+// deliver posts an event, retrying failed requests. It only fails when the context
+// ends, so that the event is retried after the next scan; events that can't be
+// delivered otherwise are dropped as dead letters.
+func (w *Webhook) deliver(ctx context.Context, event string, incident Incident) error {
+	body, err := w.render(event, incident.Finding)
+	if err != nil {
+		w.deadLetter(err, event, incident, 0)
+		return nil
+	}
+
+	headers := map[string]string{
+		"X-Kogaro-Event":       event,
+		"X-Kogaro-Fingerprint": incident.DedupKey,
+	}
+	for key, value := range w.headers {
+		headers[key] = value
+	}
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		headers[WebhookSignatureHeader] = "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	delay := w.backoff
+	for attempt := 1; ; attempt++ {
+		err = post(ctx, w.httpClient, w.url, headers, body)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return err
+		}
+		if !retryable(err) || attempt > w.maxRetries {
+			w.deadLetter(err, event, incident, attempt)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// render executes the webhook template for an event of a finding
+func (w *Webhook) render(event string, finding validators.ValidationError) ([]byte, error) {
+	data := WebhookEvent{
+		Event:       event,
+		Fingerprint: Fingerprint(finding),
+		Summary:     summary(finding),
+		Timestamp:   time.Now().UTC(),
+		Finding:     finding,
+		Details:     details(finding),
+	}
+	if info, ok := validators.LookupErrorCode(finding.ErrorCode); ok {
+		data.DocURL = info.DocURL
+	}
+
+	var body bytes.Buffer
+	if err := w.template.Execute(&body, data); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+	if !json.Valid(body.Bytes()) {
+		return nil, fmt.Errorf("template rendered invalid JSON: %s", truncate(body.String(), 200))
+	}
+	return body.Bytes(), nil
+}
+
+// deadLetter records a dropped delivery
+func (w *Webhook) deadLetter(err error, event string, incident Incident, attempts int) {
+	metrics.WebhookDeadLetters.WithLabelValues(w.name, event).Inc()
+	w.log.Error(err, "dropped webhook delivery", "event", event, "finding", summary(incident.Finding), "attempts", attempts)
+}
+
+// retryable reports whether a failed request may succeed when retried: network
+// failures, rate limiting and server errors are retried, other rejections are not
+func retryable(err error) bool {
+	var rejected *statusError
+	if errors.As(err, &rejected) {
+		return rejected.code == http.StatusTooManyRequests || rejected.code >= 500
+	}
+	return true
+}
+
+// toJSON encodes a value as JSON for webhook templates
+func toJSON(value any) (string, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestParseWebhookConfig(t *testing.T) {
+	webhooks, err := ParseWebhookConfig([]byte(`
+webhooks:
+  - name: bot
+    url: https://bot.example.com/kogaro
+    severities: [error, warning]
+    maxRetries: 0
+`))
+	if err != nil {
+		t.Fatalf("ParseWebhookConfig() error = %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].Name != "bot" || len(webhooks[0].Severities) != 2 || *webhooks[0].MaxRetries != 0 {
+		t.Errorf("ParseWebhookConfig() = %+v", webhooks)
+	}
+
+	tests := map[string]string{
+		"unknown field":    "webhooks: [{name: bot, url: 'https://bot.example.com', retries: 2}]",
+		"missing name":     "webhooks: [{url: 'https://bot.example.com'}]",
+		"duplicate name":   "webhooks: [{name: bot, url: 'https://a.example.com'}, {name: bot, url: 'https://b.example.com'}]",
+		"invalid url":      "webhooks: [{name: bot, url: 'bot.example.com/kogaro'}]",
+		"unknown severity": "webhooks: [{name: bot, url: 'https://bot.example.com', severities: [critical]}]",
+		"negative retries": "webhooks: [{name: bot, url: 'https://bot.example.com', maxRetries: -1}]",
+	}
+	for name, document := range tests {
+		if _, err := ParseWebhookConfig([]byte(document)); err == nil {
+			t.Errorf("%s: ParseWebhookConfig() succeeded, want an error", name)
+		}
+	}
+}
+
+func TestWebhook_DeliverWithRetries(t *testing.T) {
+	type request struct {
+		headers http.Header
+		body    []byte
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, request{headers: r.Header, body: body})
+		if len(requests) == 1 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	config := WebhookConfig{
+		Name:     "servicenow",
+		URL:      server.URL,
+		Secret:   "signing-key",
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Template: `{"short_description": {{json .Summary}}, "state": {{if eq .Event "resolved"}}"closed"{{else}}"open"{{end}}, "correlation_id": {{json .Fingerprint}}}`,
+	}
+	webhook, err := NewWebhook(config, server.Client(), logr.Discard())
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	webhook.backoff = time.Millisecond
+	finding := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", `ConfigMap "settings" does not exist`)
+	incident := Incident{DedupKey: Fingerprint(finding), Finding: finding}
+
+	if err := webhook.Resolve(context.Background(), incident); err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("received %d requests, want the failed request and its retry", len(requests))
+	}
+
+	delivered := requests[1]
+	var body map[string]string
+	if err := json.Unmarshal(delivered.body, &body); err != nil {
+		t.Fatalf("body is not JSON: %v\n%s", err, delivered.body)
+	}
+	want := map[string]string{
+		"short_description": `[KOGARO-REF-003] Deployment/shop/web: ConfigMap "settings" does not exist`,
+		"state":             "closed",
+		"correlation_id":    incident.DedupKey,
+	}
+	for key, value := range want {
+		if body[key] != value {
+			t.Errorf("body[%s] = %q, want %q", key, body[key], value)
+		}
+	}
+
+	mac := hmac.New(sha256.New, []byte("signing-key"))
+	mac.Write(delivered.body)
+	if signature := delivered.headers.Get(WebhookSignatureHeader); signature != "sha256="+hex.EncodeToString(mac.Sum(nil)) {
+		t.Errorf("signature = %q does not match the body", signature)
+	}
+	if delivered.headers.Get("Authorization") != "Bearer token" || delivered.headers.Get("X-Kogaro-Event") != WebhookEventResolved {
+		t.Errorf("headers = %v", delivered.headers)
+	}
+}
+
+func TestWebhook_DeadLetter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	webhook, err := NewWebhook(WebhookConfig{Name: "dead-letter-test", URL: server.URL}, server.Client(), logr.Discard())
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	webhook.backoff = time.Millisecond
+	finding := validators.NewValidationErrorWithCode("Pod", "api", "shop", "missing_resource_requests", "KOGARO-RES-002", "no requests")
+
+	// A rejected delivery is not retried, and dropping it lets the notifier move on
+	if err := webhook.Trigger(context.Background(), Incident{DedupKey: Fingerprint(finding), Finding: finding}); err != nil {
+		t.Fatalf("Trigger() error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("received %d requests, want 1", requests)
+	}
+	if got := testutil.ToFloat64(metrics.WebhookDeadLetters.WithLabelValues("dead-letter-test", WebhookEventNew)); got != 1 {
+		t.Errorf("dead letters = %v, want 1", got)
+	}
+}
+
+func TestNewWebhook_InvalidTemplate(t *testing.T) {
+	for name, tmpl := range map[string]string{
+		"syntax":       `{"summary": {{json .Summary}`,
+		"unknown":      `{"summary": {{json .Title}}}`,
+		"invalid JSON": `{"summary": {{.Summary}}}`,
+	} {
+		if _, err := NewWebhook(WebhookConfig{Name: "bot", URL: "https://bot.example.com", Template: tmpl}, http.DefaultClient, logr.Discard()); err == nil {
+			t.Errorf("%s: NewWebhook() succeeded, want an error", name)
+		}
+	}
+}
+
+func TestNewWebhookNotifier_Severities(t *testing.T) {
+	var events []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		events = append(events, r.Header.Get("X-Kogaro-Event"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier, err := NewWebhookNotifier(WebhookConfig{Name: "bot", URL: server.URL}, server.Client(), nil, logr.Discard())
+	if err != nil {
+		t.Fatalf("NewWebhookNotifier() error = %v", err)
+	}
+	warning := validators.NewValidationErrorWithCode("Pod", "api", "shop", "missing_resource_limits", "KOGARO-RES-003", "no limits").
+		WithSeverity(validators.SeverityWarning)
+
+	ctx := context.Background()
+	if err := notifier.Sync(ctx, []validators.ValidationError{warning}, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if err := notifier.Sync(ctx, nil, time.Now()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if got := strings.Join(events, ","); got != "new,resolved" {
+		t.Errorf("events = %s, want new,resolved", got)
+	}
+}
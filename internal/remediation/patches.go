@@ -0,0 +1,408 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package remediation turns fixable findings into changes that resolve them.
+package remediation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// defaultDenyPolicyName names suggested default-deny NetworkPolicies when the
+	// finding does not reference one
+	defaultDenyPolicyName = "default-deny-all"
+
+	// initContainerType is the container_type detail value for init containers
+	initContainerType = "init container"
+)
+
+// podSpecPaths gives the API version of each workload kind and the path to its pod spec
+var podSpecPaths = map[string]struct {
+	apiVersion string
+	path       []string
+}{
+	"Pod":         {"v1", []string{"spec"}},
+	"Deployment":  {"apps/v1", []string{"spec", "template", "spec"}},
+	"StatefulSet": {"apps/v1", []string{"spec", "template", "spec"}},
+	"DaemonSet":   {"apps/v1", []string{"spec", "template", "spec"}},
+	"ReplicaSet":  {"apps/v1", []string{"spec", "template", "spec"}},
+	"Job":         {"batch/v1", []string{"spec", "template", "spec"}},
+	"CronJob":     {"batch/v1", []string{"spec", "jobTemplate", "spec", "template", "spec"}},
+}
+
+// Patch is a suggested change that fixes the findings on one resource. It is either a
+// strategic merge patch for an existing resource or a complete manifest to apply.
+type Patch struct {
+	// Path of the patch relative to the output directory
+	Path      string
+	Kind      string
+	Namespace string
+	Name      string
+	// ErrorCodes of the fixed findings, or validation types for findings without a code
+	ErrorCodes []string
+	// Manifest is true for complete manifests of resources that do not exist yet
+	Manifest bool
+	Data     []byte
+}
+
+// ApplyCommand returns the kubectl command that applies the patch once written to dir
+func (p Patch) ApplyCommand(dir string) string {
+	path := filepath.Join(dir, p.Path)
+	if p.Manifest {
+		return fmt.Sprintf("kubectl apply -f %s", path)
+	}
+	return fmt.Sprintf("kubectl patch %s %s -n %s --type strategic --patch-file %s",
+		strings.ToLower(p.Kind), p.Name, p.Namespace, path)
+}
+
+// workloadFix collects the changes for the findings on one workload
+type workloadFix struct {
+	kind, namespace, name string
+	omitSettings          map[string]bool
+	errorCodes            map[string]bool
+	podSecurityContext    map[string]interface{}
+	containers            map[string]map[string]interface{}
+	initContainers        map[string]map[string]interface{}
+}
+
+// SuggestPatches builds patches for the fixable findings: missing resource requests and
+// limits, missing pod and container SecurityContexts, and missing default-deny
+// NetworkPolicies. Values come from each finding's structured details, so findings
+// without the details they need are skipped. Patches are sorted by path.
+func SuggestPatches(findings []validators.ValidationError) ([]Patch, error) {
+	policies := make(map[string]*Patch)
+	for _, ve := range findings {
+		switch ve.ValidationType {
+		case "missing_network_policy_default_deny", "missing_network_policy_security_sensitive", "missing_network_policy_production":
+			patch, err := defaultDenyPolicy(ve)
+			if err != nil {
+				return nil, err
+			}
+			if existing, ok := policies[patch.Path]; ok {
+				existing.ErrorCodes = appendCode(existing.ErrorCodes, findingCode(ve))
+				continue
+			}
+			policies[patch.Path] = &patch
+		}
+	}
+
+	workloads := collectWorkloadFixes(findings, nil)
+	patches := make([]Patch, 0, len(workloads)+len(policies))
+	for _, fix := range workloads {
+		patch, err := fix.patch()
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
+	}
+	for _, patch := range policies {
+		patches = append(patches, *patch)
+	}
+	sort.Slice(patches, func(i, j int) bool { return patches[i].Path < patches[j].Path })
+
+	return patches, nil
+}
+
+// collectWorkloadFixes combines the fixable findings on each workload into one fix,
+// keyed by kind, namespace and name. Container SecurityContext settings listed in
+// omitSettings are left out of the fixes.
+func collectWorkloadFixes(findings []validators.ValidationError, omitSettings map[string]bool) map[string]*workloadFix {
+	workloads := make(map[string]*workloadFix)
+
+	for _, ve := range findings {
+		if _, ok := podSpecPaths[ve.ResourceType]; !ok {
+			continue
+		}
+		key := workloadKey(ve.ResourceType, ve.Namespace, ve.ResourceName)
+		fix := workloads[key]
+		if fix == nil {
+			fix = &workloadFix{
+				kind:           ve.ResourceType,
+				namespace:      ve.Namespace,
+				name:           ve.ResourceName,
+				omitSettings:   omitSettings,
+				errorCodes:     make(map[string]bool),
+				containers:     make(map[string]map[string]interface{}),
+				initContainers: make(map[string]map[string]interface{}),
+			}
+		}
+		if fix.add(ve) {
+			workloads[key] = fix
+			fix.errorCodes[findingCode(ve)] = true
+		}
+	}
+
+	return workloads
+}
+
+// workloadKey identifies a workload by kind, namespace and name
+func workloadKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// add records the change that fixes a finding, reporting whether the finding is fixable
+func (f *workloadFix) add(ve validators.ValidationError) bool {
+	switch ve.ValidationType {
+	case "missing_resource_requests", "request_deviates_from_vpa_recommendation":
+		return f.setResources(ve, "requests", "recommended_cpu", "recommended_memory")
+	case "missing_resource_limits":
+		return f.setResources(ve, "limits", "recommended_cpu_limit", "recommended_memory_limit")
+	case "missing_pod_security_context":
+		securityContext := map[string]interface{}{"runAsNonRoot": true}
+		for detail, field := range map[string]string{
+			"recommended_user_id":  "runAsUser",
+			"recommended_group_id": "runAsGroup",
+			"recommended_fs_group": "fsGroup",
+		} {
+			if value, err := strconv.ParseInt(ve.Details[detail], 10, 64); err == nil {
+				securityContext[field] = value
+			}
+		}
+		if _, ok := securityContext["runAsUser"]; !ok {
+			return false
+		}
+		f.podSecurityContext = securityContext
+		return true
+	case "missing_container_security_context":
+		securityContext, err := parseRecommendedSettings(ve.Details["recommended_settings"])
+		if err != nil {
+			return false
+		}
+		for setting := range f.omitSettings {
+			delete(securityContext, setting)
+		}
+		if len(securityContext) == 0 {
+			return false
+		}
+		container := f.container(ve)
+		if container == nil {
+			return false
+		}
+		container["securityContext"] = securityContext
+		return true
+	}
+	return false
+}
+
+// setResources sets a container's requests or limits from a finding's recommendations
+func (f *workloadFix) setResources(ve validators.ValidationError, field, cpuDetail, memoryDetail string) bool {
+	quantities := make(map[string]interface{})
+	if cpu := ve.Details[cpuDetail]; cpu != "" {
+		quantities["cpu"] = cpu
+	}
+	if memory := ve.Details[memoryDetail]; memory != "" {
+		quantities["memory"] = memory
+	}
+	if len(quantities) == 0 {
+		return false
+	}
+
+	container := f.container(ve)
+	if container == nil {
+		return false
+	}
+	resources, _ := container["resources"].(map[string]interface{})
+	if resources == nil {
+		resources = make(map[string]interface{})
+		container["resources"] = resources
+	}
+	resources[field] = quantities
+	return true
+}
+
+// container returns the patch of the container a finding refers to
+func (f *workloadFix) container(ve validators.ValidationError) map[string]interface{} {
+	name := ve.Details["container_name"]
+	if name == "" {
+		return nil
+	}
+
+	containers := f.containers
+	if ve.Details["container_type"] == initContainerType {
+		containers = f.initContainers
+	}
+	if containers[name] == nil {
+		containers[name] = map[string]interface{}{"name": name}
+	}
+	return containers[name]
+}
+
+// object returns the partial workload holding only the fixed fields, usable as a
+// strategic merge patch or a server-side apply configuration
+func (f *workloadFix) object() map[string]interface{} {
+	kind := podSpecPaths[f.kind]
+
+	podSpec := make(map[string]interface{})
+	if f.podSecurityContext != nil {
+		podSpec["securityContext"] = f.podSecurityContext
+	}
+	if len(f.containers) > 0 {
+		podSpec["containers"] = sortedContainers(f.containers)
+	}
+	if len(f.initContainers) > 0 {
+		podSpec["initContainers"] = sortedContainers(f.initContainers)
+	}
+
+	object := map[string]interface{}{
+		"apiVersion": kind.apiVersion,
+		"kind":       f.kind,
+		"metadata":   map[string]interface{}{"name": f.name, "namespace": f.namespace},
+	}
+	parent := object
+	for _, field := range kind.path[:len(kind.path)-1] {
+		child := make(map[string]interface{})
+		parent[field] = child
+		parent = child
+	}
+	parent[kind.path[len(kind.path)-1]] = podSpec
+
+	return object
+}
+
+// codes returns the sorted codes of the findings the fix resolves
+func (f *workloadFix) codes() []string {
+	errorCodes := make([]string, 0, len(f.errorCodes))
+	for code := range f.errorCodes {
+		errorCodes = append(errorCodes, code)
+	}
+	sort.Strings(errorCodes)
+	return errorCodes
+}
+
+// patch renders the strategic merge patch for the workload
+func (f *workloadFix) patch() (Patch, error) {
+	data, err := yaml.Marshal(f.object())
+	if err != nil {
+		return Patch{}, fmt.Errorf("failed to marshal patch for %s %s/%s: %w", f.kind, f.namespace, f.name, err)
+	}
+
+	return Patch{
+		Path:       filepath.Join(f.namespace, fmt.Sprintf("%s-%s.patch.yaml", strings.ToLower(f.kind), f.name)),
+		Kind:       f.kind,
+		Namespace:  f.namespace,
+		Name:       f.name,
+		ErrorCodes: f.codes(),
+		Data:       data,
+	}, nil
+}
+
+// sortedContainers returns container patches ordered by name
+func sortedContainers(containers map[string]map[string]interface{}) []interface{} {
+	names := make([]string, 0, len(containers))
+	for name := range containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	sorted := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, containers[name])
+	}
+	return sorted
+}
+
+// parseRecommendedSettings parses a recommended_settings detail, a comma-separated list
+// of "field: value" SecurityContext settings
+func parseRecommendedSettings(settings string) (map[string]interface{}, error) {
+	parsed := make(map[string]interface{})
+	if strings.TrimSpace(settings) == "" {
+		return parsed, nil
+	}
+	if err := yaml.Unmarshal([]byte("{"+settings+"}"), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid recommended settings %q: %w", settings, err)
+	}
+	return parsed, nil
+}
+
+// defaultDenyPolicy builds a NetworkPolicy that denies all ingress and egress traffic
+// in the namespace of a finding
+func defaultDenyPolicy(ve validators.ValidationError) (Patch, error) {
+	namespace := ve.Namespace
+	if namespace == "" && ve.ResourceType == "Namespace" {
+		namespace = ve.ResourceName
+	}
+	name := defaultDenyPolicyName
+	for _, related := range ve.RelatedResources {
+		if policyName, ok := strings.CutPrefix(related, "NetworkPolicy/"); ok && policyName != "" {
+			name = policyName
+			break
+		}
+	}
+
+	data, err := yaml.Marshal(map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata":   map[string]interface{}{"name": name, "namespace": namespace},
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{},
+			"policyTypes": []string{"Ingress", "Egress"},
+		},
+	})
+	if err != nil {
+		return Patch{}, fmt.Errorf("failed to marshal NetworkPolicy for namespace %s: %w", namespace, err)
+	}
+
+	return Patch{
+		Path:       filepath.Join(namespace, fmt.Sprintf("networkpolicy-%s.yaml", name)),
+		Kind:       "NetworkPolicy",
+		Namespace:  namespace,
+		Name:       name,
+		ErrorCodes: []string{findingCode(ve)},
+		Manifest:   true,
+		Data:       data,
+	}, nil
+}
+
+// findingCode identifies a finding by its error code, or by its validation type when
+// it has no code
+func findingCode(ve validators.ValidationError) string {
+	if ve.ErrorCode != "" {
+		return ve.ErrorCode
+	}
+	return ve.ValidationType
+}
+
+// appendCode adds an error code to a sorted list of codes without duplicates
+func appendCode(codes []string, code string) []string {
+	i := sort.SearchStrings(codes, code)
+	if i < len(codes) && codes[i] == code {
+		return codes
+	}
+	return append(codes[:i], append([]string{code}, codes[i:]...)...)
+}
+
+// WritePatches writes patches under dir, each headed by the findings it fixes and the
+// command that applies it
+func WritePatches(dir string, patches []Patch) error {
+	for _, patch := range patches {
+		path := filepath.Join(dir, patch.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return fmt.Errorf("failed to create patch directory: %w", err)
+		}
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "# Suggested by Kogaro for %s\n", strings.Join(patch.ErrorCodes, ", "))
+		if patch.Manifest {
+			content.WriteString("# Denies all ingress and egress traffic: add allow policies, including DNS egress, before applying\n")
+		}
+		fmt.Fprintf(&content, "# Apply with: %s\n", patch.ApplyCommand(dir))
+		content.Write(patch.Data)
+
+		if err := os.WriteFile(path, []byte(content.String()), 0o600); err != nil {
+			return fmt.Errorf("failed to write patch %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package remediation
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func testFindings() []validators.ValidationError {
+	return []validators.ValidationError{
+		validators.NewValidationErrorWithCode("Deployment", "web", "team-a", "missing_resource_requests", "KOGARO-RES-001", "no requests").
+			WithDetail("container_name", "app").
+			WithDetail("container_type", "container").
+			WithDetail("recommended_cpu", "100m").
+			WithDetail("recommended_memory", "128Mi"),
+		validators.NewValidationErrorWithCode("Deployment", "web", "team-a", "missing_resource_limits", "KOGARO-RES-003", "no limits").
+			WithDetail("container_name", "app").
+			WithDetail("container_type", "container").
+			WithDetail("recommended_cpu_limit", "500m").
+			WithDetail("recommended_memory_limit", "512Mi"),
+		validators.NewValidationErrorWithCode("Deployment", "web", "team-a", "missing_container_security_context", "KOGARO-SEC-010", "no security context").
+			WithDetail("container_name", "migrate").
+			WithDetail("container_type", "init container").
+			WithDetail("recommended_settings", "allowPrivilegeEscalation: false, runAsNonRoot: true, readOnlyRootFilesystem: true"),
+		validators.NewValidationErrorWithCode("Deployment", "web", "team-a", "missing_pod_security_context", "KOGARO-SEC-009", "no pod security context").
+			WithDetail("recommended_user_id", "1000").
+			WithDetail("recommended_group_id", "3000").
+			WithDetail("recommended_fs_group", "2000"),
+		*validators.NewValidationErrorWithCode("Namespace", "team-a", "team-a", "missing_network_policy_default_deny", "KOGARO-NET-006", "no default deny").
+			WithRelatedResources("NetworkPolicy/default-deny-all"),
+		// Not fixable from its details
+		validators.NewValidationErrorWithCode("Service", "web", "team-a", "service_selector_mismatch", "KOGARO-NET-001", "no pods"),
+		validators.NewValidationErrorWithCode("Pod", "job", "team-b", "missing_resource_requests", "KOGARO-RES-001", "no requests"),
+	}
+}
+
+func TestSuggestPatches(t *testing.T) {
+	patches, err := SuggestPatches(testFindings())
+	if err != nil {
+		t.Fatalf("SuggestPatches() error = %v", err)
+	}
+	if len(patches) != 2 {
+		t.Fatalf("SuggestPatches() returned %d patches, want 2: %+v", len(patches), patches)
+	}
+
+	deployment := patches[0]
+	if deployment.Path != filepath.Join("team-a", "deployment-web.patch.yaml") || deployment.Manifest {
+		t.Errorf("unexpected deployment patch %+v", deployment)
+	}
+	if got := strings.Join(deployment.ErrorCodes, ","); got != "KOGARO-RES-001,KOGARO-RES-003,KOGARO-SEC-009,KOGARO-SEC-010" {
+		t.Errorf("ErrorCodes = %s", got)
+	}
+	wantDeployment := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team-a
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        resources:
+          limits:
+            cpu: 500m
+            memory: 512Mi
+          requests:
+            cpu: 100m
+            memory: 128Mi
+      initContainers:
+      - name: migrate
+        securityContext:
+          allowPrivilegeEscalation: false
+          readOnlyRootFilesystem: true
+          runAsNonRoot: true
+      securityContext:
+        fsGroup: 2000
+        runAsGroup: 3000
+        runAsNonRoot: true
+        runAsUser: 1000
+`
+	if string(deployment.Data) != wantDeployment {
+		t.Errorf("deployment patch:\n%s\nwant:\n%s", deployment.Data, wantDeployment)
+	}
+
+	policy := patches[1]
+	if policy.Path != filepath.Join("team-a", "networkpolicy-default-deny-all.yaml") || !policy.Manifest {
+		t.Errorf("unexpected policy patch %+v", policy)
+	}
+	wantPolicy := `apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: default-deny-all
+  namespace: team-a
+spec:
+  podSelector: {}
+  policyTypes:
+  - Ingress
+  - Egress
+`
+	if string(policy.Data) != wantPolicy {
+		t.Errorf("policy manifest:\n%s\nwant:\n%s", policy.Data, wantPolicy)
+	}
+}
+
+func TestWritePatches(t *testing.T) {
+	patches, err := SuggestPatches(testFindings())
+	if err != nil {
+		t.Fatalf("SuggestPatches() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := WritePatches(dir, patches); err != nil {
+		t.Fatalf("WritePatches() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "team-a", "deployment-web.patch.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read patch: %v", err)
+	}
+	for _, want := range []string{
+		"# Suggested by Kogaro for KOGARO-RES-001, KOGARO-RES-003, KOGARO-SEC-009, KOGARO-SEC-010\n",
+		"# Apply with: kubectl patch deployment web -n team-a --type strategic --patch-file " + filepath.Join(dir, "team-a", "deployment-web.patch.yaml") + "\n",
+		"kind: Deployment\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("patch does not contain %q:\n%s", want, data)
+		}
+	}
+
+	data, err = os.ReadFile(filepath.Join(dir, "team-a", "networkpolicy-default-deny-all.yaml"))
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	if !strings.Contains(string(data), "# Apply with: kubectl apply -f ") {
+		t.Errorf("manifest has no apply command:\n%s", data)
+	}
+}
+
+func TestSuggestPatchesFromVPARecommendation(t *testing.T) {
+	patches, err := SuggestPatches([]validators.ValidationError{
+		validators.NewValidationErrorWithCode("StatefulSet", "db", "team-a", "request_deviates_from_vpa_recommendation", "KOGARO-RES-017", "overprovisioned").
+			WithDetail("container_name", "postgres").
+			WithDetail("container_type", "container").
+			WithDetail("recommended_cpu", "250m").
+			WithDetail("recommended_memory", "2Gi"),
+	})
+	if err != nil {
+		t.Fatalf("SuggestPatches() error = %v", err)
+	}
+	if len(patches) != 1 {
+		t.Fatalf("SuggestPatches() returned %d patches, want 1: %+v", len(patches), patches)
+	}
+	if !strings.Contains(string(patches[0].Data), "requests:\n            cpu: 250m\n            memory: 2Gi\n") {
+		t.Errorf("patch does not set the recommended requests:\n%s", patches[0].Data)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// AutoRemediateAnnotation opts a workload into automatic remediation. Its value is a
+	// comma-separated list of categories, e.g. "securitycontext,resources".
+	AutoRemediateAnnotation = "kogaro.io/auto-remediate"
+
+	// CategorySecurityContext fixes missing pod and container SecurityContexts
+	CategorySecurityContext = "securitycontext"
+	// CategoryResources fixes missing resource requests and limits
+	CategoryResources = "resources"
+
+	// FieldManager owns the fields set by automatic remediation
+	FieldManager = "kogaro-remediation"
+
+	// Event reasons recorded on remediated workloads
+	ReasonRemediated        = "AutoRemediated"
+	ReasonRemediationDryRun = "AutoRemediationDryRun"
+	ReasonRemediationFailed = "AutoRemediationFailed"
+
+	// remediationTimeout bounds the time spent remediating after a scan
+	remediationTimeout = 2 * time.Minute
+)
+
+// remediationCategories maps the validation types that can be remediated to the
+// category that enables them
+var remediationCategories = map[string]string{
+	"missing_resource_requests":          CategoryResources,
+	"missing_resource_limits":            CategoryResources,
+	"missing_pod_security_context":       CategorySecurityContext,
+	"missing_container_security_context": CategorySecurityContext,
+}
+
+// remediableKinds lists the workload kinds whose pod templates can be changed in place
+var remediableKinds = map[string]schema.GroupVersionKind{
+	"Deployment":  {Group: "apps", Version: "v1", Kind: "Deployment"},
+	"StatefulSet": {Group: "apps", Version: "v1", Kind: "StatefulSet"},
+	"DaemonSet":   {Group: "apps", Version: "v1", Kind: "DaemonSet"},
+}
+
+// unsafeContainerSettings are recommended SecurityContext settings that are left out of
+// automatic remediation because they commonly break running applications
+var unsafeContainerSettings = map[string]bool{
+	"readOnlyRootFilesystem": true,
+}
+
+// Remediator applies safe defaults to workloads that opt in with the
+// kogaro.io/auto-remediate annotation. Changes are made with server-side apply under
+// the kogaro-remediation field manager and recorded as Events on the workload.
+type Remediator struct {
+	client   client.Client
+	recorder record.EventRecorder
+	log      logr.Logger
+	dryRun   bool
+}
+
+// NewRemediator creates a new Remediator. In dry-run mode changes are validated by the
+// API server and recorded as Events, but not persisted.
+func NewRemediator(client client.Client, recorder record.EventRecorder, log logr.Logger, dryRun bool) *Remediator {
+	return &Remediator{
+		client:   client,
+		recorder: recorder,
+		log:      log.WithName("remediator"),
+		dryRun:   dryRun,
+	}
+}
+
+// HandleScan remediates the findings of a completed scan.
+// Its signature matches validators.ScanListener.
+func (r *Remediator) HandleScan(result validators.ValidationResult, _ time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), remediationTimeout)
+	defer cancel()
+
+	if err := r.Apply(ctx, result.Errors); err != nil {
+		r.log.Error(err, "failed to remediate findings")
+	}
+}
+
+// Apply remediates the findings on workloads that opt in to their category
+func (r *Remediator) Apply(ctx context.Context, findings []validators.ValidationError) error {
+	grouped := make(map[string][]validators.ValidationError)
+	for _, ve := range findings {
+		if _, ok := remediableKinds[ve.ResourceType]; !ok {
+			continue
+		}
+		if _, ok := remediationCategories[ve.ValidationType]; !ok {
+			continue
+		}
+		key := workloadKey(ve.ResourceType, ve.Namespace, ve.ResourceName)
+		grouped[key] = append(grouped[key], ve)
+	}
+
+	keys := make([]string, 0, len(grouped))
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	remediated := 0
+	for _, key := range keys {
+		applied, err := r.remediateWorkload(ctx, grouped[key])
+		if err != nil {
+			return err
+		}
+		if applied {
+			remediated++
+		}
+	}
+
+	r.log.V(1).Info("remediation completed", "workloads", remediated, "dry_run", r.dryRun)
+	return nil
+}
+
+// remediateWorkload applies the fixes a workload opts in to, reporting whether a
+// change was made
+func (r *Remediator) remediateWorkload(ctx context.Context, findings []validators.ValidationError) (bool, error) {
+	first := findings[0]
+	workload := &unstructured.Unstructured{}
+	workload.SetGroupVersionKind(remediableKinds[first.ResourceType])
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: first.Namespace, Name: first.ResourceName}, workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s %s/%s: %w", first.ResourceType, first.Namespace, first.ResourceName, err)
+	}
+
+	categories := ParseCategories(workload.GetAnnotations()[AutoRemediateAnnotation])
+	if len(categories) == 0 {
+		return false, nil
+	}
+
+	var enabled []validators.ValidationError
+	for _, ve := range findings {
+		if categories[remediationCategories[ve.ValidationType]] {
+			enabled = append(enabled, ve)
+		}
+	}
+	fix := collectWorkloadFixes(enabled, unsafeContainerSettings)[workloadKey(first.ResourceType, first.Namespace, first.ResourceName)]
+	if fix == nil {
+		return false, nil
+	}
+
+	codes := strings.Join(fix.codes(), ", ")
+	options := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if r.dryRun {
+		options = append(options, client.DryRunAll)
+	}
+
+	desired := &unstructured.Unstructured{Object: fix.object()}
+	if err := r.client.Patch(ctx, desired, client.Apply, options...); err != nil {
+		r.recorder.Eventf(workload, corev1.EventTypeWarning, ReasonRemediationFailed,
+			"Failed to apply safe defaults for %s: %v", codes, err)
+		r.log.Error(err, "failed to remediate workload", "kind", first.ResourceType,
+			"namespace", first.Namespace, "name", first.ResourceName)
+		return false, nil
+	}
+
+	if r.dryRun {
+		r.recorder.Eventf(workload, corev1.EventTypeNormal, ReasonRemediationDryRun,
+			"Would apply safe defaults for %s (dry run)", codes)
+	} else {
+		r.recorder.Eventf(workload, corev1.EventTypeNormal, ReasonRemediated,
+			"Applied safe defaults for %s", codes)
+	}
+	r.log.Info("workload remediated", "kind", first.ResourceType, "namespace", first.Namespace,
+		"name", first.ResourceName, "error_codes", codes, "dry_run", r.dryRun)
+	return true, nil
+}
+
+// ParseCategories parses the value of the kogaro.io/auto-remediate annotation.
+// Unknown categories are ignored.
+func ParseCategories(value string) map[string]bool {
+	categories := make(map[string]bool)
+	for _, category := range strings.Split(value, ",") {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category == CategorySecurityContext || category == CategoryResources {
+			categories[category] = true
+		}
+	}
+	return categories
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package remediation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// appliedPatch records a patch sent through the fake client
+type appliedPatch struct {
+	object  *unstructured.Unstructured
+	options *client.PatchOptions
+}
+
+func newDeployment(name, autoRemediate string) *appsv1.Deployment {
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "team-a"}}
+	if autoRemediate != "" {
+		deployment.Annotations = map[string]string{AutoRemediateAnnotation: autoRemediate}
+	}
+	return deployment
+}
+
+func newRemediator(t *testing.T, dryRun bool, objects ...client.Object) (*Remediator, *record.FakeRecorder, *[]appliedPatch) {
+	t.Helper()
+	var applied []appliedPatch
+	fakeClient := fake.NewClientBuilder().
+		WithObjects(objects...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(_ context.Context, _ client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if patch != client.Apply {
+					t.Errorf("patch type = %v, want server-side apply", patch.Type())
+				}
+				options := &client.PatchOptions{}
+				options.ApplyOptions(opts)
+				applied = append(applied, appliedPatch{object: obj.(*unstructured.Unstructured), options: options})
+				return nil
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	return NewRemediator(fakeClient, recorder, logr.Discard(), dryRun), recorder, &applied
+}
+
+func TestRemediator_Apply(t *testing.T) {
+	remediator, recorder, applied := newRemediator(t, false,
+		newDeployment("web", "resources"),
+		newDeployment("api", "securitycontext, resources"),
+		newDeployment("legacy", ""),
+	)
+
+	if err := remediator.Apply(context.Background(), testRemediationFindings()); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	// "legacy" has not opted in, and "web" has only opted in to resources
+	if len(*applied) != 2 {
+		t.Fatalf("applied %d patches, want 2", len(*applied))
+	}
+	api, web := (*applied)[0], (*applied)[1]
+	if api.object.GetName() != "api" || web.object.GetName() != "web" {
+		t.Fatalf("applied patches to %s and %s, want api and web", api.object.GetName(), web.object.GetName())
+	}
+	if api.options.FieldManager != FieldManager || len(api.options.DryRun) != 0 {
+		t.Errorf("unexpected patch options %+v", api.options)
+	}
+
+	containers, _, _ := unstructured.NestedSlice(api.object.Object, "spec", "template", "spec", "containers")
+	initContainers, _, _ := unstructured.NestedSlice(api.object.Object, "spec", "template", "spec", "initContainers")
+	if len(containers) != 1 || len(initContainers) != 1 {
+		t.Fatalf("api containers = %v, init containers = %v", containers, initContainers)
+	}
+	if cpu, _, _ := unstructured.NestedString(containers[0].(map[string]interface{}), "resources", "requests", "cpu"); cpu != "100m" {
+		t.Errorf("api cpu request = %q, want 100m", cpu)
+	}
+	securityContext, _, _ := unstructured.NestedMap(initContainers[0].(map[string]interface{}), "securityContext")
+	if securityContext["allowPrivilegeEscalation"] != false || securityContext["runAsNonRoot"] != true {
+		t.Errorf("api init container securityContext = %v", securityContext)
+	}
+	if _, ok := securityContext["readOnlyRootFilesystem"]; ok {
+		t.Error("readOnlyRootFilesystem must not be applied automatically")
+	}
+	if user, _, _ := unstructured.NestedInt64(api.object.Object, "spec", "template", "spec", "securityContext", "runAsUser"); user != 1000 {
+		t.Errorf("api runAsUser = %d, want 1000", user)
+	}
+
+	if _, found, _ := unstructured.NestedMap(web.object.Object, "spec", "template", "spec", "securityContext"); found {
+		t.Error("web has not opted in to securitycontext remediation")
+	}
+
+	events := []string{<-recorder.Events, <-recorder.Events}
+	for _, event := range events {
+		if !strings.HasPrefix(event, "Normal "+ReasonRemediated+" Applied safe defaults for ") {
+			t.Errorf("unexpected event %q", event)
+		}
+	}
+	if !strings.Contains(events[0], "KOGARO-RES-001, KOGARO-RES-003, KOGARO-SEC-009, KOGARO-SEC-010") {
+		t.Errorf("api event %q does not list the fixed codes", events[0])
+	}
+}
+
+func TestRemediator_DryRun(t *testing.T) {
+	remediator, recorder, applied := newRemediator(t, true, newDeployment("web", "resources"))
+
+	if err := remediator.Apply(context.Background(), testRemediationFindings()); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if len(*applied) != 1 {
+		t.Fatalf("applied %d patches, want 1", len(*applied))
+	}
+	if dryRun := (*applied)[0].options.DryRun; len(dryRun) != 1 || dryRun[0] != metav1.DryRunAll {
+		t.Errorf("DryRun = %v, want [All]", dryRun)
+	}
+	if event := <-recorder.Events; !strings.HasPrefix(event, "Normal "+ReasonRemediationDryRun+" Would apply") {
+		t.Errorf("unexpected event %q", event)
+	}
+}
+
+func TestParseCategories(t *testing.T) {
+	categories := ParseCategories(" SecurityContext,resources,,unknown")
+	if len(categories) != 2 || !categories[CategorySecurityContext] || !categories[CategoryResources] {
+		t.Errorf("ParseCategories() = %v", categories)
+	}
+}
+
+// testRemediationFindings repeats the Deployment findings of testFindings for each
+// of the test Deployments
+func testRemediationFindings() []validators.ValidationError {
+	var findings []validators.ValidationError
+	for _, name := range []string{"web", "api", "legacy"} {
+		for _, ve := range testFindings() {
+			if ve.ResourceType != "Deployment" {
+				continue
+			}
+			ve.ResourceName = name
+			findings = append(findings, ve)
+		}
+	}
+	return findings
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/utils"
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// SummaryAnnotation records the number of findings by severity on a workload
+	SummaryAnnotation = "kogaro.io/validation-summary"
+	// WorstErrorCodeAnnotation records the error code of a workload's most severe finding
+	WorstErrorCodeAnnotation = "kogaro.io/worst-error-code"
+
+	// publishTimeout bounds the time spent publishing results after a scan
+	publishTimeout = 2 * time.Minute
+)
+
+// WorkloadAnnotator writes a summary of each workload's findings as annotations on
+// the workload. Annotations are removed once a workload no longer has findings.
+type WorkloadAnnotator struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewWorkloadAnnotator creates a new WorkloadAnnotator
+func NewWorkloadAnnotator(client client.Client, log logr.Logger) *WorkloadAnnotator {
+	return &WorkloadAnnotator{
+		client: client,
+		log:    log.WithName("workload-annotator"),
+	}
+}
+
+// HandleScan updates workload annotations from the findings of a completed scan.
+// Its signature matches validators.ScanListener.
+func (a *WorkloadAnnotator) HandleScan(result validators.ValidationResult, _ time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := a.Apply(ctx, result.Errors); err != nil {
+		a.log.Error(err, "failed to update workload annotations")
+	}
+}
+
+// Apply reconciles the summary annotations on all workloads against the given findings
+func (a *WorkloadAnnotator) Apply(ctx context.Context, findings []validators.ValidationError) error {
+	grouped := make(map[string][]validators.ValidationError)
+	for _, ve := range findings {
+		key := workloadKey(ve.ResourceType, ve.Namespace, ve.ResourceName)
+		grouped[key] = append(grouped[key], ve)
+	}
+
+	workloads, err := a.listWorkloads(ctx)
+	if err != nil {
+		return err
+	}
+
+	patched := 0
+	for _, workload := range workloads {
+		desired := map[string]string{}
+		if workloadFindings := grouped[workloadKey(workload.kind, workload.object.GetNamespace(), workload.object.GetName())]; len(workloadFindings) > 0 {
+			summary := Summarize(workloadFindings)
+			desired[SummaryAnnotation] = summary.String()
+			if summary.WorstErrorCode != "" {
+				desired[WorstErrorCodeAnnotation] = summary.WorstErrorCode
+			}
+		}
+
+		patch := annotationPatch(workload.object.GetAnnotations(), desired)
+		if patch == nil {
+			continue
+		}
+		if err := a.client.Patch(ctx, workload.object, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			a.log.Error(err, "failed to annotate workload", "kind", workload.kind,
+				"namespace", workload.object.GetNamespace(), "name", workload.object.GetName())
+			continue
+		}
+		patched++
+	}
+
+	a.log.V(1).Info("workload annotations updated", "workloads", len(workloads), "patched", patched)
+	return nil
+}
+
+// annotatedWorkload is a workload that may carry summary annotations
+type annotatedWorkload struct {
+	kind   string
+	object client.Object
+}
+
+// listWorkloads lists the Deployments, StatefulSets, DaemonSets and standalone Pods
+// outside system namespaces
+func (a *WorkloadAnnotator) listWorkloads(ctx context.Context) ([]annotatedWorkload, error) {
+	var workloads []annotatedWorkload
+
+	var deployments appsv1.DeploymentList
+	if err := a.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for i := range deployments.Items {
+		workloads = append(workloads, annotatedWorkload{kind: "Deployment", object: &deployments.Items[i]})
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := a.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for i := range statefulSets.Items {
+		workloads = append(workloads, annotatedWorkload{kind: "StatefulSet", object: &statefulSets.Items[i]})
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := a.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for i := range daemonSets.Items {
+		workloads = append(workloads, annotatedWorkload{kind: "DaemonSet", object: &daemonSets.Items[i]})
+	}
+
+	var pods corev1.PodList
+	if err := a.client.List(ctx, &pods); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for i := range pods.Items {
+		// Controller-owned pods are summarised on their workload
+		if utils.HasOwnerReferences(pods.Items[i]) {
+			continue
+		}
+		workloads = append(workloads, annotatedWorkload{kind: "Pod", object: &pods.Items[i]})
+	}
+
+	filtered := workloads[:0]
+	for _, workload := range workloads {
+		if !utils.IsSystemNamespace(workload.object.GetNamespace()) {
+			filtered = append(filtered, workload)
+		}
+	}
+	return filtered, nil
+}
+
+// annotationPatch builds a merge patch that brings the summary annotations in line
+// with the desired values, or returns nil when no change is needed
+func annotationPatch(current, desired map[string]string) []byte {
+	changes := make(map[string]interface{})
+	for _, key := range []string{SummaryAnnotation, WorstErrorCodeAnnotation} {
+		value, wanted := desired[key]
+		existing, present := current[key]
+		switch {
+		case wanted && (!present || existing != value):
+			changes[key] = value
+		case !wanted && present:
+			changes[key] = nil
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	patch, _ := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": changes},
+	})
+	return patch
+}
+
+// workloadKey identifies a workload by kind, namespace and name
+func workloadKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestWorkloadAnnotator_Apply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"}},
+		&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "db", Namespace: "app",
+			Annotations: map[string]string{
+				SummaryAnnotation:        "errors=1 warnings=0 info=0",
+				WorstErrorCodeAnnotation: "KOGARO-REF-003",
+				"unrelated":              "kept",
+			},
+		}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "app"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: "web-abc", Namespace: "app",
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-123"}},
+		}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	findings := []validators.ValidationError{
+		finding("Deployment", "web", "app", "KOGARO-RES-002", validators.SeverityWarning),
+		finding("Deployment", "web", "app", "KOGARO-REF-003", validators.SeverityError),
+		finding("Pod", "debug", "app", "KOGARO-SEC-001", validators.SeverityInfo),
+		finding("Pod", "web-abc", "app", "KOGARO-SEC-002", validators.SeverityError),
+		finding("Deployment", "coredns", "kube-system", "KOGARO-RES-001", validators.SeverityError),
+	}
+
+	annotator := NewWorkloadAnnotator(fakeClient, logr.Discard())
+	if err := annotator.Apply(context.Background(), findings); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	annotations := func(obj client.Object) map[string]string {
+		t.Helper()
+		if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		return obj.GetAnnotations()
+	}
+
+	web := annotations(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"}})
+	if web[SummaryAnnotation] != "errors=1 warnings=1 info=0" || web[WorstErrorCodeAnnotation] != "KOGARO-REF-003" {
+		t.Errorf("unexpected deployment annotations: %v", web)
+	}
+
+	db := annotations(&appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "app"}})
+	if _, ok := db[SummaryAnnotation]; ok {
+		t.Errorf("expected resolved statefulset annotations to be removed: %v", db)
+	}
+	if _, ok := db[WorstErrorCodeAnnotation]; ok {
+		t.Errorf("expected resolved statefulset annotations to be removed: %v", db)
+	}
+	if db["unrelated"] != "kept" {
+		t.Errorf("expected unrelated annotation to be kept: %v", db)
+	}
+
+	debug := annotations(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "app"}})
+	if debug[SummaryAnnotation] != "errors=0 warnings=0 info=1" {
+		t.Errorf("unexpected standalone pod annotations: %v", debug)
+	}
+
+	owned := annotations(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-abc", Namespace: "app"}})
+	if _, ok := owned[SummaryAnnotation]; ok {
+		t.Errorf("expected controller-owned pod not to be annotated: %v", owned)
+	}
+
+	system := annotations(&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}})
+	if _, ok := system[SummaryAnnotation]; ok {
+		t.Errorf("expected system namespace workload not to be annotated: %v", system)
+	}
+}
+
+func TestAnnotationPatch(t *testing.T) {
+	desired := map[string]string{SummaryAnnotation: "errors=1 warnings=0 info=0", WorstErrorCodeAnnotation: "KOGARO-REF-003"}
+
+	if patch := annotationPatch(desired, desired); patch != nil {
+		t.Errorf("expected no patch when annotations are current, got %s", patch)
+	}
+	if patch := annotationPatch(nil, map[string]string{}); patch != nil {
+		t.Errorf("expected no patch for a clean workload, got %s", patch)
+	}
+
+	patch := annotationPatch(map[string]string{SummaryAnnotation: "errors=1 warnings=0 info=0"}, map[string]string{})
+	want := `{"metadata":{"annotations":{"kogaro.io/validation-summary":null}}}`
+	if string(patch) != want {
+		t.Errorf("annotationPatch() = %s, want %s", patch, want)
+	}
+}
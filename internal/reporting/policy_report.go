@@ -0,0 +1,267 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+const (
+	// PolicyReportName is the name of the PolicyReport Kogaro maintains in each
+	// namespace with findings, and of its ClusterPolicyReport
+	PolicyReportName = "kogaro"
+	// managedByLabel marks the PolicyReports Kogaro maintains
+	managedByLabel = "app.kubernetes.io/managed-by"
+
+	// maxPolicyReportResults caps the number of results stored in one report, keeping
+	// reports well below the size limit of a Kubernetes object
+	maxPolicyReportResults = 1000
+)
+
+// policyReportResult is a result of a PolicyReport, in the wgpolicyk8s.io/v1alpha2 schema
+type policyReportResult struct {
+	Source     string            `json:"source"`
+	Policy     string            `json:"policy"`
+	Rule       string            `json:"rule,omitempty"`
+	Category   string            `json:"category,omitempty"`
+	Severity   string            `json:"severity"`
+	Result     string            `json:"result"`
+	Scored     bool              `json:"scored"`
+	Message    string            `json:"message"`
+	Timestamp  map[string]int64  `json:"timestamp"`
+	Resources  []policyResource  `json:"resources"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// policyResource is the resource a PolicyReport result applies to
+type policyResource struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PolicyReportWriter publishes findings as wgpolicyk8s.io PolicyReports, so that tools
+// built for policy engines, such as the Policy Reporter UI, show Kogaro's findings
+// next to those of Kyverno. Each namespace with findings gets a PolicyReport named
+// kogaro, and findings on cluster-scoped resources go to a ClusterPolicyReport. Reports
+// of namespaces without findings are deleted.
+type PolicyReportWriter struct {
+	client client.Client
+	log    logr.Logger
+	// ownsClusterScope is whether this replica reports findings on cluster-scoped
+	// resources, which only the first shard does
+	ownsClusterScope bool
+}
+
+// NewPolicyReportWriter creates a new PolicyReportWriter for the namespaces of a shard
+func NewPolicyReportWriter(client client.Client, shard validators.Shard, log logr.Logger) *PolicyReportWriter {
+	return &PolicyReportWriter{
+		client:           client,
+		log:              log.WithName("policy-report-writer"),
+		ownsClusterScope: shard.Owns(""),
+	}
+}
+
+// HandleScan writes the findings of a completed scan to PolicyReports.
+// Its signature matches validators.ScanListener.
+func (w *PolicyReportWriter) HandleScan(result validators.ValidationResult, scanTime time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := w.Apply(ctx, result.Errors, scanTime); err != nil {
+		w.log.Error(err, "failed to update policy reports")
+	}
+}
+
+// Apply reconciles Kogaro's PolicyReports and ClusterPolicyReport against the given
+// findings
+func (w *PolicyReportWriter) Apply(ctx context.Context, findings []validators.ValidationError, scanTime time.Time) error {
+	byNamespace := make(map[string][]validators.ValidationError)
+	for _, ve := range findings {
+		byNamespace[ve.Namespace] = append(byNamespace[ve.Namespace], ve)
+	}
+
+	// Reports of namespaces that no longer have findings are deleted
+	existing := &unstructured.UnstructuredList{}
+	existing.SetGroupVersionKind(validators.PolicyReportGVK.GroupVersion().WithKind(validators.PolicyReportGVK.Kind + "List"))
+	if err := w.client.List(ctx, existing, client.MatchingLabels{managedByLabel: validators.PolicyReportSource}); err != nil {
+		if meta.IsNoMatchError(err) {
+			w.log.V(1).Info("PolicyReport CRD is not installed, skipping policy report updates")
+			return nil
+		}
+		return fmt.Errorf("failed to list policy reports: %w", err)
+	}
+	for i := range existing.Items {
+		report := &existing.Items[i]
+		if report.GetName() != PolicyReportName || len(byNamespace[report.GetNamespace()]) > 0 {
+			continue
+		}
+		if err := w.client.Delete(ctx, report); err != nil && !errors.IsNotFound(err) {
+			w.log.Error(err, "failed to delete policy report", "namespace", report.GetNamespace(), "name", report.GetName())
+		}
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for namespace := range byNamespace {
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		if err := w.write(ctx, validators.PolicyReportGVK, namespace, byNamespace[namespace], scanTime); err != nil {
+			w.log.Error(err, "failed to update policy report", "namespace", namespace)
+		}
+	}
+
+	if w.ownsClusterScope {
+		if err := w.writeClusterReport(ctx, byNamespace[""], scanTime); err != nil {
+			w.log.Error(err, "failed to update cluster policy report")
+		}
+	}
+
+	w.log.V(1).Info("policy reports updated", "reports", len(namespaces))
+	return nil
+}
+
+// writeClusterReport writes the findings on cluster-scoped resources to the
+// ClusterPolicyReport, deleting it when there are none
+func (w *PolicyReportWriter) writeClusterReport(ctx context.Context, findings []validators.ValidationError, scanTime time.Time) error {
+	if len(findings) > 0 {
+		return w.write(ctx, validators.ClusterPolicyReportGVK, "", findings, scanTime)
+	}
+
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(validators.ClusterPolicyReportGVK)
+	report.SetName(PolicyReportName)
+	if err := w.client.Delete(ctx, report); err != nil && !errors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete cluster policy report: %w", err)
+	}
+	return nil
+}
+
+// write creates or replaces the report of a namespace, or the ClusterPolicyReport when
+// the namespace is empty
+func (w *PolicyReportWriter) write(ctx context.Context, gvk schema.GroupVersionKind, namespace string, findings []validators.ValidationError, scanTime time.Time) error {
+	desired, err := buildPolicyReport(gvk, namespace, findings, scanTime)
+	if err != nil {
+		return err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+	err = w.client.Get(ctx, client.ObjectKeyFromObject(desired), current)
+	switch {
+	case errors.IsNotFound(err):
+		return w.client.Create(ctx, desired)
+	case err != nil:
+		return err
+	}
+
+	if current.GetLabels()[managedByLabel] != validators.PolicyReportSource {
+		return fmt.Errorf("%s %s is not managed by Kogaro", gvk.Kind, client.ObjectKeyFromObject(desired))
+	}
+	desired.SetResourceVersion(current.GetResourceVersion())
+	return w.client.Update(ctx, desired)
+}
+
+// buildPolicyReport builds the report of a set of findings. Errors and warnings are
+// reported as failed results of high and medium severity, and info findings as
+// warnings of low severity. Each finding's error code is the policy it fails, and its
+// validation type the rule.
+func buildPolicyReport(gvk schema.GroupVersionKind, namespace string, findings []validators.ValidationError, scanTime time.Time) (*unstructured.Unstructured, error) {
+	sorted := make([]validators.ValidationError, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ri, rj := severityRank(sorted[i].Severity), severityRank(sorted[j].Severity); ri != rj {
+			return ri > rj
+		}
+		return sorted[i].GetResourceKey() < sorted[j].GetResourceKey()
+	})
+
+	summary := map[string]int64{"pass": 0, "fail": 0, "warn": 0, "error": 0, "skip": 0}
+	var results []interface{}
+	for _, ve := range sorted {
+		result := newPolicyReportResult(ve, scanTime)
+		summary[result.Result]++
+		if len(results) == maxPolicyReportResults {
+			continue
+		}
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert policy report result: %w", err)
+		}
+		results = append(results, object)
+	}
+
+	report := &unstructured.Unstructured{Object: map[string]interface{}{
+		"summary": map[string]interface{}{
+			"pass": summary["pass"], "fail": summary["fail"], "warn": summary["warn"], "error": summary["error"], "skip": summary["skip"],
+		},
+		"results": results,
+	}}
+	report.SetGroupVersionKind(gvk)
+	report.SetNamespace(namespace)
+	report.SetName(PolicyReportName)
+	report.SetLabels(map[string]string{managedByLabel: validators.PolicyReportSource})
+	return report, nil
+}
+
+// newPolicyReportResult converts a finding to a PolicyReport result
+func newPolicyReportResult(ve validators.ValidationError, scanTime time.Time) policyReportResult {
+	result := policyReportResult{
+		Source:    validators.PolicyReportSource,
+		Policy:    ve.ErrorCode,
+		Rule:      ve.ValidationType,
+		Result:    "fail",
+		Scored:    true,
+		Message:   ve.Message,
+		Timestamp: map[string]int64{"seconds": scanTime.Unix(), "nanos": 0},
+		Resources: []policyResource{{Kind: ve.ResourceType, Name: ve.ResourceName, Namespace: ve.Namespace}},
+	}
+	switch severityRank(ve.Severity) {
+	case 3:
+		result.Severity = "high"
+	case 2:
+		result.Severity = "medium"
+	default:
+		result.Result = "warn"
+		result.Severity = "low"
+	}
+
+	properties := make(map[string]string)
+	if ve.ErrorCode == "" {
+		result.Policy = ve.ValidationType
+	} else {
+		properties["errorCode"] = ve.ErrorCode
+		if info, ok := validators.LookupErrorCode(ve.ErrorCode); ok {
+			properties["docURL"] = info.DocURL
+		}
+		if category, ok := validators.ErrorCodeCategoryOf(ve.ErrorCode); ok {
+			result.Category = category.Title
+		}
+	}
+	if ve.RemediationHint != "" {
+		properties["remediationHint"] = ve.RemediationHint
+	}
+	if len(properties) > 0 {
+		result.Properties = properties
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func newPolicyReportScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	for _, gvk := range []schema.GroupVersionKind{validators.PolicyReportGVK, validators.ClusterPolicyReportGVK} {
+		scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
+	return scheme
+}
+
+func newPolicyReport(namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(validators.PolicyReportGVK)
+	report.SetNamespace(namespace)
+	report.SetName(name)
+	report.SetLabels(labels)
+	return report
+}
+
+func TestPolicyReportWriter_Apply(t *testing.T) {
+	managed := map[string]string{managedByLabel: validators.PolicyReportSource}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(newPolicyReportScheme()).
+		WithObjects(
+			newPolicyReport("team-a", PolicyReportName, managed),
+			newPolicyReport("fixed", PolicyReportName, managed),
+			newPolicyReport("fixed", "polr-ns-fixed", nil),
+		).
+		Build()
+
+	findings := []validators.ValidationError{
+		finding("Pod", "web", "team-a", "KOGARO-RES-002", validators.SeverityWarning).WithRemediationHint("Set memory requests"),
+		finding("Pod", "web", "team-a", "KOGARO-REF-003", validators.SeverityError),
+		finding("Pod", "web", "team-a", "KOGARO-RES-010", validators.SeverityInfo),
+		finding("Pod", "api", "team-b", "KOGARO-REF-003", validators.SeverityError),
+		finding("ClusterRole", "admin-all", "", "KOGARO-SEC-013", validators.SeverityWarning),
+	}
+	scanTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writer := NewPolicyReportWriter(fakeClient, validators.Shard{}, logr.Discard())
+	if err := writer.Apply(context.Background(), findings, scanTime); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	get := func(gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
+		report := &unstructured.Unstructured{}
+		report.SetGroupVersionKind(gvk)
+		err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, report)
+		return report, err
+	}
+	summary := func(report *unstructured.Unstructured) map[string]int64 {
+		counts := make(map[string]int64)
+		for _, result := range []string{"pass", "fail", "warn", "error", "skip"} {
+			counts[result], _, _ = unstructured.NestedInt64(report.Object, "summary", result)
+		}
+		return counts
+	}
+
+	teamA, err := get(validators.PolicyReportGVK, "team-a", PolicyReportName)
+	if err != nil {
+		t.Fatalf("Get(team-a) error = %v", err)
+	}
+	if counts := summary(teamA); counts["fail"] != 2 || counts["warn"] != 1 || counts["pass"] != 0 {
+		t.Errorf("team-a summary = %v, want 2 fail and 1 warn", counts)
+	}
+	results, _, _ := unstructured.NestedSlice(teamA.Object, "results")
+	if len(results) != 3 {
+		t.Fatalf("team-a has %d results, want 3", len(results))
+	}
+	// Results are ordered by severity, errors first
+	first := results[0].(map[string]interface{})
+	if first["source"] != validators.PolicyReportSource || first["policy"] != "KOGARO-REF-003" || first["rule"] != "test_validation" ||
+		first["result"] != "fail" || first["severity"] != "high" || first["category"] != "Reference Validation" {
+		t.Errorf("first team-a result = %v, want the KOGARO-REF-003 failure", first)
+	}
+	if seconds, _, _ := unstructured.NestedInt64(first, "timestamp", "seconds"); seconds != scanTime.Unix() {
+		t.Errorf("timestamp = %d, want the scan time %d", seconds, scanTime.Unix())
+	}
+	resources, _, _ := unstructured.NestedSlice(first, "resources")
+	if len(resources) != 1 || resources[0].(map[string]interface{})["kind"] != "Pod" || resources[0].(map[string]interface{})["name"] != "web" {
+		t.Errorf("resources = %v, want Pod web", resources)
+	}
+	second := results[1].(map[string]interface{})
+	if hint, _, _ := unstructured.NestedString(second, "properties", "remediationHint"); second["severity"] != "medium" || hint != "Set memory requests" {
+		t.Errorf("second team-a result = %v, want the medium KOGARO-RES-002 failure with its hint", second)
+	}
+	if third := results[2].(map[string]interface{}); third["result"] != "warn" || third["severity"] != "low" {
+		t.Errorf("third team-a result = %v, want a low warning", third)
+	}
+
+	if _, err := get(validators.PolicyReportGVK, "team-b", PolicyReportName); err != nil {
+		t.Errorf("Get(team-b) error = %v, want a created report", err)
+	}
+	clusterReport, err := get(validators.ClusterPolicyReportGVK, "", PolicyReportName)
+	if err != nil {
+		t.Fatalf("Get(cluster report) error = %v", err)
+	}
+	if counts := summary(clusterReport); counts["fail"] != 1 {
+		t.Errorf("cluster report summary = %v, want 1 fail", counts)
+	}
+	if _, err := get(validators.PolicyReportGVK, "fixed", PolicyReportName); !errors.IsNotFound(err) {
+		t.Errorf("Get(fixed) error = %v, want the report of a namespace without findings deleted", err)
+	}
+	if _, err := get(validators.PolicyReportGVK, "fixed", "polr-ns-fixed"); err != nil {
+		t.Errorf("Get(polr-ns-fixed) error = %v, want reports of other sources kept", err)
+	}
+
+	// Reports are deleted once their findings are fixed
+	if err := writer.Apply(context.Background(), findings[3:4], scanTime); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if _, err := get(validators.PolicyReportGVK, "team-a", PolicyReportName); !errors.IsNotFound(err) {
+		t.Errorf("Get(team-a) error = %v, want the report deleted", err)
+	}
+	if _, err := get(validators.ClusterPolicyReportGVK, "", PolicyReportName); !errors.IsNotFound(err) {
+		t.Errorf("Get(cluster report) error = %v, want the report deleted", err)
+	}
+}
+
+func TestPolicyReportWriter_ClusterReportOwnedByFirstShard(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(newPolicyReportScheme()).Build()
+	findings := []validators.ValidationError{finding("ClusterRole", "admin-all", "", "KOGARO-SEC-013", validators.SeverityWarning)}
+
+	writer := NewPolicyReportWriter(fakeClient, validators.Shard{Index: 1, Count: 2}, logr.Discard())
+	if err := writer.Apply(context.Background(), findings, time.Now()); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(validators.ClusterPolicyReportGVK)
+	if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: PolicyReportName}, report); !errors.IsNotFound(err) {
+		t.Errorf("Get(cluster report) error = %v, want no report from the second shard", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package reporting publishes validation results back into the cluster.
+//
+// It provides scan listeners that annotate validated workloads with a summary
+// of their findings, that maintain the status of ValidationReport resources and
+// that publish findings as wgpolicyk8s.io PolicyReports, allowing GitOps tools such
+// as Argo CD and PolicyReport tooling to surface configuration hygiene alongside
+// application health.
+package reporting
+
+import (
+	"fmt"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// Summary aggregates a set of findings by severity
+type Summary struct {
+	Errors         int
+	Warnings       int
+	Info           int
+	WorstErrorCode string
+}
+
+// Total returns the total number of findings in the summary
+func (s Summary) Total() int {
+	return s.Errors + s.Warnings + s.Info
+}
+
+// String renders the counts in the format used for annotations
+func (s Summary) String() string {
+	return fmt.Sprintf("errors=%d warnings=%d info=%d", s.Errors, s.Warnings, s.Info)
+}
+
+// Summarize counts findings by severity and selects the error code of the most
+// severe finding. Ties are broken by the lowest error code so results are stable.
+func Summarize(findings []validators.ValidationError) Summary {
+	var summary Summary
+	worstRank := 0
+
+	for _, ve := range findings {
+		rank := severityRank(ve.Severity)
+		switch rank {
+		case 3:
+			summary.Errors++
+		case 2:
+			summary.Warnings++
+		default:
+			summary.Info++
+		}
+
+		if ve.ErrorCode == "" {
+			continue
+		}
+		if rank > worstRank || (rank == worstRank && ve.ErrorCode < summary.WorstErrorCode) {
+			worstRank = rank
+			summary.WorstErrorCode = ve.ErrorCode
+		}
+	}
+
+	return summary
+}
+
+// severityRank orders severities from least to most severe. Findings without a
+// severity are treated as errors, matching NewValidationErrorWithCode.
+func severityRank(severity validators.Severity) int {
+	switch severity {
+	case validators.SeverityWarning:
+		return 2
+	case validators.SeverityInfo:
+		return 1
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func finding(resourceType, name, namespace, code string, severity validators.Severity) validators.ValidationError {
+	return validators.NewValidationErrorWithCode(resourceType, name, namespace, "test_validation", code, "test finding").
+		WithSeverity(severity)
+}
+
+func TestSummarize(t *testing.T) {
+	tests := []struct {
+		name     string
+		findings []validators.ValidationError
+		want     Summary
+	}{
+		{
+			name: "no findings",
+			want: Summary{},
+		},
+		{
+			name: "most severe finding wins",
+			findings: []validators.ValidationError{
+				finding("Pod", "a", "ns", "KOGARO-RES-002", validators.SeverityWarning),
+				finding("Pod", "a", "ns", "KOGARO-REF-003", validators.SeverityError),
+				finding("Pod", "a", "ns", "KOGARO-SEC-001", validators.SeverityInfo),
+			},
+			want: Summary{Errors: 1, Warnings: 1, Info: 1, WorstErrorCode: "KOGARO-REF-003"},
+		},
+		{
+			name: "ties broken by lowest code",
+			findings: []validators.ValidationError{
+				finding("Pod", "a", "ns", "KOGARO-SEC-002", validators.SeverityWarning),
+				finding("Pod", "a", "ns", "KOGARO-RES-004", validators.SeverityWarning),
+			},
+			want: Summary{Warnings: 2, WorstErrorCode: "KOGARO-RES-004"},
+		},
+		{
+			name: "missing severity counts as error",
+			findings: []validators.ValidationError{
+				{ResourceType: "Pod", ResourceName: "a", Namespace: "ns", ErrorCode: "KOGARO-REF-001"},
+			},
+			want: Summary{Errors: 1, WorstErrorCode: "KOGARO-REF-001"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.findings); got != tt.want {
+				t.Errorf("Summarize() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSummary_String(t *testing.T) {
+	summary := Summary{Errors: 1, Warnings: 2, Info: 3}
+	if got := summary.String(); got != "errors=1 warnings=2 info=3" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := summary.Total(); got != 6 {
+		t.Errorf("Total() = %d, want 6", got)
+	}
+}
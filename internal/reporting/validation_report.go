@@ -0,0 +1,193 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// ValidationReportGVK identifies the ValidationReport custom resource
+var ValidationReportGVK = schema.GroupVersionKind{Group: "kogaro.io", Version: "v1alpha1", Kind: "ValidationReport"}
+
+const (
+	// HealthHealthy indicates no findings at or above the report's failure threshold
+	HealthHealthy = "Healthy"
+	// HealthDegraded indicates findings at or above the report's failure threshold
+	HealthDegraded = "Degraded"
+
+	// maxReportFindings caps the number of findings stored in a report's status
+	maxReportFindings = 50
+)
+
+// reportFinding is a single finding as stored in a ValidationReport status
+type reportFinding struct {
+	ResourceType string `json:"resourceType"`
+	ResourceName string `json:"resourceName"`
+	ErrorCode    string `json:"errorCode,omitempty"`
+	Severity     string `json:"severity"`
+	Message      string `json:"message"`
+}
+
+// reportStatus is the status written to ValidationReport resources. Field names
+// follow Kubernetes conventions, and health matches the shape read by the Argo CD
+// health check in docs/ARGOCD.md. Fields are never omitted so that merge patches
+// clear values left by earlier scans.
+type reportStatus struct {
+	Health struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	} `json:"health"`
+	Summary struct {
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+		Info     int `json:"info"`
+	} `json:"summary"`
+	WorstErrorCode    string          `json:"worstErrorCode"`
+	LastScanTime      string          `json:"lastScanTime"`
+	Findings          []reportFinding `json:"findings"`
+	TruncatedFindings int             `json:"truncatedFindings"`
+}
+
+// ValidationReportWriter maintains the status of ValidationReport resources. Each
+// report summarises the findings in its own namespace, so adding a ValidationReport
+// to an application's manifests lets GitOps tools surface its hygiene status.
+type ValidationReportWriter struct {
+	client client.Client
+	log    logr.Logger
+}
+
+// NewValidationReportWriter creates a new ValidationReportWriter
+func NewValidationReportWriter(client client.Client, log logr.Logger) *ValidationReportWriter {
+	return &ValidationReportWriter{
+		client: client,
+		log:    log.WithName("validation-report-writer"),
+	}
+}
+
+// HandleScan updates ValidationReport statuses from the findings of a completed scan.
+// Its signature matches validators.ScanListener.
+func (w *ValidationReportWriter) HandleScan(result validators.ValidationResult, scanTime time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := w.Apply(ctx, result.Errors, scanTime); err != nil {
+		w.log.Error(err, "failed to update validation reports")
+	}
+}
+
+// Apply writes the status of every ValidationReport from the given findings
+func (w *ValidationReportWriter) Apply(ctx context.Context, findings []validators.ValidationError, scanTime time.Time) error {
+	reports := &unstructured.UnstructuredList{}
+	reports.SetGroupVersionKind(ValidationReportGVK.GroupVersion().WithKind(ValidationReportGVK.Kind + "List"))
+	if err := w.client.List(ctx, reports); err != nil {
+		if meta.IsNoMatchError(err) {
+			w.log.V(1).Info("ValidationReport CRD is not installed, skipping report updates")
+			return nil
+		}
+		return fmt.Errorf("failed to list validation reports: %w", err)
+	}
+
+	byNamespace := make(map[string][]validators.ValidationError)
+	for _, ve := range findings {
+		byNamespace[ve.Namespace] = append(byNamespace[ve.Namespace], ve)
+	}
+
+	for i := range reports.Items {
+		report := &reports.Items[i]
+		failOn, _, _ := unstructured.NestedString(report.Object, "spec", "failOnSeverity")
+
+		status := buildReportStatus(byNamespace[report.GetNamespace()], failOn, scanTime)
+		patch, err := json.Marshal(map[string]interface{}{"status": status})
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation report status: %w", err)
+		}
+
+		if err := w.client.Status().Patch(ctx, report, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			w.log.Error(err, "failed to update validation report", "namespace", report.GetNamespace(), "name", report.GetName())
+		}
+	}
+
+	w.log.V(1).Info("validation reports updated", "reports", len(reports.Items))
+	return nil
+}
+
+// buildReportStatus summarises a namespace's findings. The report is Degraded when any
+// finding is at or above failOnSeverity, which defaults to error.
+func buildReportStatus(findings []validators.ValidationError, failOn string, scanTime time.Time) reportStatus {
+	status := reportStatus{Findings: []reportFinding{}}
+	summary := Summarize(findings)
+	status.Summary.Errors = summary.Errors
+	status.Summary.Warnings = summary.Warnings
+	status.Summary.Info = summary.Info
+	status.WorstErrorCode = summary.WorstErrorCode
+	status.LastScanTime = scanTime.UTC().Format(time.RFC3339)
+
+	threshold := severityRank(validators.Severity(failOn))
+	failing := 0
+	for _, ve := range findings {
+		if severityRank(ve.Severity) >= threshold {
+			failing++
+		}
+	}
+
+	if failing > 0 {
+		status.Health.Status = HealthDegraded
+		status.Health.Message = fmt.Sprintf("%d findings at or above %s severity (worst: %s)",
+			failing, thresholdName(threshold), summary.WorstErrorCode)
+	} else {
+		status.Health.Status = HealthHealthy
+		status.Health.Message = fmt.Sprintf("No findings at or above %s severity (%s)", thresholdName(threshold), summary.String())
+	}
+
+	sorted := make([]validators.ValidationError, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if ri, rj := severityRank(sorted[i].Severity), severityRank(sorted[j].Severity); ri != rj {
+			return ri > rj
+		}
+		return sorted[i].GetResourceKey() < sorted[j].GetResourceKey()
+	})
+	for i, ve := range sorted {
+		if i == maxReportFindings {
+			status.TruncatedFindings = len(sorted) - maxReportFindings
+			break
+		}
+		status.Findings = append(status.Findings, reportFinding{
+			ResourceType: ve.ResourceType,
+			ResourceName: ve.ResourceName,
+			ErrorCode:    ve.ErrorCode,
+			Severity:     string(ve.Severity),
+			Message:      ve.Message,
+		})
+	}
+
+	return status
+}
+
+// thresholdName returns the severity name for a severity rank
+func thresholdName(rank int) string {
+	switch rank {
+	case 1:
+		return string(validators.SeverityInfo)
+	case 2:
+		return string(validators.SeverityWarning)
+	default:
+		return string(validators.SeverityError)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func newValidationReport(namespace, failOn string) *unstructured.Unstructured {
+	report := &unstructured.Unstructured{}
+	report.SetGroupVersionKind(ValidationReportGVK)
+	report.SetNamespace(namespace)
+	report.SetName("hygiene")
+	if failOn != "" {
+		_ = unstructured.SetNestedField(report.Object, failOn, "spec", "failOnSeverity")
+	}
+	return report
+}
+
+func TestValidationReportWriter_Apply(t *testing.T) {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(ValidationReportGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(ValidationReportGVK.GroupVersion().WithKind("ValidationReportList"), &unstructured.UnstructuredList{})
+
+	degraded := newValidationReport("team-a", "")
+	healthy := newValidationReport("team-b", "")
+	strict := newValidationReport("team-c", "warning")
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(degraded, healthy, strict).
+		WithStatusSubresource(degraded, healthy, strict).
+		Build()
+
+	findings := []validators.ValidationError{
+		finding("Pod", "web", "team-a", "KOGARO-RES-002", validators.SeverityWarning),
+		finding("Pod", "web", "team-a", "KOGARO-REF-003", validators.SeverityError),
+		finding("Pod", "web", "team-b", "KOGARO-RES-002", validators.SeverityWarning),
+		finding("Pod", "web", "team-c", "KOGARO-RES-002", validators.SeverityWarning),
+	}
+	scanTime := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writer := NewValidationReportWriter(fakeClient, logr.Discard())
+	if err := writer.Apply(context.Background(), findings, scanTime); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	status := func(namespace string) map[string]interface{} {
+		t.Helper()
+		report := newValidationReport(namespace, "")
+		if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(report), report); err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		status, _, _ := unstructured.NestedMap(report.Object, "status")
+		return status
+	}
+	health := func(status map[string]interface{}) string {
+		value, _, _ := unstructured.NestedString(status, "health", "status")
+		return value
+	}
+
+	teamA := status("team-a")
+	if health(teamA) != HealthDegraded {
+		t.Errorf("team-a health = %q, want %q", health(teamA), HealthDegraded)
+	}
+	if teamA["worstErrorCode"] != "KOGARO-REF-003" || teamA["lastScanTime"] != "2025-01-01T12:00:00Z" {
+		t.Errorf("unexpected team-a status: %v", teamA)
+	}
+	if errs, _, _ := unstructured.NestedInt64(teamA, "summary", "errors"); errs != 1 {
+		t.Errorf("team-a summary errors = %d, want 1", errs)
+	}
+	if items, _, _ := unstructured.NestedSlice(teamA, "findings"); len(items) != 2 {
+		t.Errorf("team-a findings = %d, want 2", len(items))
+	}
+
+	if got := health(status("team-b")); got != HealthHealthy {
+		t.Errorf("team-b health = %q, want %q", got, HealthHealthy)
+	}
+	if got := health(status("team-c")); got != HealthDegraded {
+		t.Errorf("team-c health = %q, want %q with failOnSeverity warning", got, HealthDegraded)
+	}
+
+	// A later scan without findings clears the findings written previously
+	if err := writer.Apply(context.Background(), nil, scanTime.Add(time.Minute)); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	teamA = status("team-a")
+	if health(teamA) != HealthHealthy || teamA["worstErrorCode"] != "" {
+		t.Errorf("expected team-a to recover, got %v", teamA)
+	}
+	if items, _, _ := unstructured.NestedSlice(teamA, "findings"); len(items) != 0 {
+		t.Errorf("expected team-a findings to be cleared, got %d", len(items))
+	}
+}
+
+func TestBuildReportStatus_TruncatesFindings(t *testing.T) {
+	var findings []validators.ValidationError
+	for i := 0; i < maxReportFindings+5; i++ {
+		findings = append(findings, finding("Pod", fmt.Sprintf("pod-%03d", i), "ns", "KOGARO-RES-002", validators.SeverityWarning))
+	}
+	findings = append(findings, finding("Pod", "zz-broken", "ns", "KOGARO-REF-003", validators.SeverityError))
+
+	status := buildReportStatus(findings, "", time.Now())
+
+	if len(status.Findings) != maxReportFindings || status.TruncatedFindings != 6 {
+		t.Errorf("findings = %d, truncated = %d", len(status.Findings), status.TruncatedFindings)
+	}
+	if status.Findings[0].ResourceName != "zz-broken" {
+		t.Errorf("expected the error to be listed first, got %s", status.Findings[0].ResourceName)
+	}
+	if !strings.Contains(status.Health.Message, "KOGARO-REF-003") {
+		t.Errorf("health message = %q", status.Health.Message)
+	}
+}
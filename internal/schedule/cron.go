@@ -0,0 +1,226 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package schedule provides cron-style scan schedules and quiet-hour windows.
+//
+// Cron expressions decide when periodic cluster scans run, and quiet hours decide
+// when notifications about their findings are held back. Both are evaluated in a
+// configurable time zone, so that schedules follow a team's working day.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchYears bounds the search for the next activation, so that expressions which
+// can never match, such as February 30th, are rejected instead of searched forever
+const searchYears = 5
+
+// macros are the named schedules cron implementations commonly accept
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	dayNames   = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// field describes one of the five fields of a cron expression
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: monthNames}
+	// Day 7 is accepted as another name for Sunday
+	dowField = field{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Cron is a schedule given by a standard five-field cron expression: minute, hour, day
+// of month, month and day of week. Fields accept *, values, ranges, lists and steps,
+// such as "0 */4 * * *" or "30 9 * * mon-fri", and the usual @hourly, @daily,
+// @weekly, @monthly and @yearly macros. As in cron, a time matches when the day of
+// month or the day of week matches if both are restricted.
+type Cron struct {
+	expression                    string
+	location                      *time.Location
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are set when the field is *, so that only the other restricts days
+	domAny, dowAny bool
+}
+
+// ParseCron parses a cron expression evaluated in location, or in UTC when location is nil
+func ParseCron(expression string, location *time.Location) (*Cron, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	spec := strings.TrimSpace(expression)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: want 5 fields (minute hour day-of-month month day-of-week), got %d", expression, len(fields))
+	}
+
+	c := &Cron{expression: expression, location: location}
+	targets := []struct {
+		bits  *uint64
+		field field
+	}{
+		{&c.minute, minuteField},
+		{&c.hour, hourField},
+		{&c.dom, domField},
+		{&c.month, monthField},
+		{&c.dow, dowField},
+	}
+	for i, target := range targets {
+		bits, err := parseField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+		}
+		*target.bits = bits
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	c.domAny = fields[2] == "*" || strings.HasPrefix(fields[2], "*/")
+	c.dowAny = fields[4] == "*" || strings.HasPrefix(fields[4], "*/")
+
+	if c.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("invalid cron expression %q: it never matches", expression)
+	}
+	return c, nil
+}
+
+// parseField returns the values a field matches as a bit set
+func parseField(spec string, f field) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(spec, ",") {
+		rangeSpec, step := item, 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			var err error
+			rangeSpec = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangeSpec == "*":
+			low, high = f.min, f.max
+		case strings.Contains(rangeSpec, "-"):
+			bounds := strings.SplitN(rangeSpec, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			if high, err = f.value(bounds[1]); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if low, err = f.value(rangeSpec); err != nil {
+				return 0, err
+			}
+			high = low
+			// A single value with a step, such as 5/15, runs to the end of the field
+			if strings.Contains(item, "/") {
+				high = f.max
+			}
+		}
+		if low > high {
+			return 0, fmt.Errorf("invalid range in %s field %q", f.name, item)
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a number or name of the field and checks its bounds
+func (f field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", f.name, s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d is outside %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String returns the expression the schedule was parsed from
+func (c *Cron) String() string {
+	return c.expression
+}
+
+// Location returns the time zone the schedule is evaluated in
+func (c *Cron) Location() *time.Location {
+	return c.location
+}
+
+// Next returns the first time after t that matches the schedule, in the schedule's
+// time zone, or the zero time when none does within the next few years
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.location)
+	// Start from the next whole minute
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, c.location).Add(time.Minute)
+	yearLimit := t.Year() + searchYears
+
+	// Each field is advanced until it matches; advancing one past its range resets the
+	// smaller fields, and the search restarts so that the larger fields are checked again
+	for t.Year() <= yearLimit {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the day-of-month and day-of-week fields
+func (c *Cron) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
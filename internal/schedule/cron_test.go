@@ -0,0 +1,78 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCron_Next(t *testing.T) {
+	// Wednesday 10:17 UTC
+	from := time.Date(2025, time.January, 15, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expression string
+		want       time.Time
+	}{
+		{"* * * * *", time.Date(2025, time.January, 15, 10, 18, 0, 0, time.UTC)},
+		{"0 */4 * * *", time.Date(2025, time.January, 15, 12, 0, 0, 0, time.UTC)},
+		{"30 9 * * mon-fri", time.Date(2025, time.January, 16, 9, 30, 0, 0, time.UTC)},
+		{"0 9 * * sat,sun", time.Date(2025, time.January, 18, 9, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 feb *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2025, time.January, 15, 10, 25, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either matches
+		{"0 0 20 * mon", time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC)},
+		{"0 0 17 * mon", time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2025, time.January, 16, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2025, time.January, 15, 11, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			c, err := ParseCron(tt.expression, nil)
+			if err != nil {
+				t.Fatalf("ParseCron() error = %v", err)
+			}
+			if got := c.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCron_NextInLocation(t *testing.T) {
+	location := time.FixedZone("UTC+2", 2*60*60)
+	c, err := ParseCron("0 9 * * *", location)
+	if err != nil {
+		t.Fatalf("ParseCron() error = %v", err)
+	}
+	got := c.Next(time.Date(2025, time.January, 15, 8, 0, 0, 0, time.UTC))
+	if want := time.Date(2025, time.January, 16, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next() = %v, want %v", got, want)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"0 * * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"0 0 * 13 *",
+		"0 0 * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"0 0 * * funday",
+		"0 0 30 feb *",
+	} {
+		if _, err := ParseCron(expression, nil); err == nil {
+			t.Errorf("ParseCron(%q) error = nil, want error", expression)
+		}
+	}
+}
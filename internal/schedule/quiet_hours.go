@@ -0,0 +1,176 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minutesPerDay is the end of a window that lasts until midnight, written 24:00
+const minutesPerDay = 24 * 60
+
+// window is a quiet period on some days of the week. A window whose end is before its
+// start covers the early morning and the evening of each of its days.
+type window struct {
+	days       [7]bool
+	start, end int
+}
+
+// QuietHours are the weekly windows during which notifications are held back, such as
+// "Mon-Fri 18:00-09:00,Sat-Sun". Scans still run during quiet hours.
+type QuietHours struct {
+	spec     string
+	location *time.Location
+	windows  []window
+}
+
+// ParseQuietHours parses comma-separated quiet windows evaluated in location, or in UTC
+// when location is nil. Each window is a day or range of days, a time range in 24-hour
+// HH:MM-HH:MM form, or a day range followed by a time range. Days alone are quiet all
+// day, and a time range alone is quiet every day. A time range whose end is before its
+// start wraps around midnight: "Mon-Fri 18:00-09:00" is quiet before 09:00 and from
+// 18:00 on weekdays.
+func ParseQuietHours(spec string, location *time.Location) (*QuietHours, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	q := &QuietHours{spec: spec, location: location}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		w, err := parseWindow(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quiet hours %q: %w", spec, err)
+		}
+		q.windows = append(q.windows, w)
+	}
+	if len(q.windows) == 0 {
+		return nil, fmt.Errorf("invalid quiet hours %q: no windows given", spec)
+	}
+	return q, nil
+}
+
+// parseWindow parses one window such as "Mon-Fri 18:00-09:00", "Sat-Sun" or "22:00-07:00"
+func parseWindow(item string) (window, error) {
+	w := window{start: 0, end: minutesPerDay}
+	parts := strings.Fields(item)
+	if len(parts) > 2 {
+		return window{}, fmt.Errorf("window %q has more than a day range and a time range", item)
+	}
+
+	daySpec, timeSpec := "", ""
+	for _, part := range parts {
+		if strings.Contains(part, ":") {
+			timeSpec = part
+		} else {
+			daySpec = part
+		}
+	}
+	if len(parts) == 2 && (daySpec == "" || timeSpec == "") {
+		return window{}, fmt.Errorf("window %q needs a day range followed by a time range", item)
+	}
+
+	if daySpec == "" {
+		for d := range w.days {
+			w.days[d] = true
+		}
+	} else {
+		bounds := strings.SplitN(daySpec, "-", 2)
+		first, err := parseDay(bounds[0])
+		if err != nil {
+			return window{}, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseDay(bounds[1]); err != nil {
+				return window{}, err
+			}
+		}
+		// Ranges may wrap around the week, such as Fri-Mon
+		for d := first; ; d = (d + 1) % 7 {
+			w.days[d] = true
+			if d == last {
+				break
+			}
+		}
+	}
+
+	if timeSpec != "" {
+		bounds := strings.SplitN(timeSpec, "-", 2)
+		if len(bounds) != 2 {
+			return window{}, fmt.Errorf("time range %q is not HH:MM-HH:MM", timeSpec)
+		}
+		var err error
+		if w.start, err = parseClock(bounds[0]); err != nil {
+			return window{}, err
+		}
+		if w.end, err = parseClock(bounds[1]); err != nil {
+			return window{}, err
+		}
+		if w.start == w.end || w.start == minutesPerDay {
+			return window{}, fmt.Errorf("time range %q is empty", timeSpec)
+		}
+	}
+	return w, nil
+}
+
+// parseDay parses a day name such as mon or Monday
+func parseDay(s string) (int, error) {
+	name := strings.ToLower(s)
+	if len(name) >= 3 {
+		if d, ok := dayNames[name[:3]]; ok {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid day %q", s)
+}
+
+// parseClock parses a time of day in HH:MM form into minutes after midnight
+func parseClock(s string) (int, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil || hours < 0 || minutes < 0 || minutes > 59 || hours*60+minutes > minutesPerDay {
+		return 0, fmt.Errorf("invalid time %q, want HH:MM", s)
+	}
+	return hours*60 + minutes, nil
+}
+
+// String returns the windows the quiet hours were parsed from
+func (q *QuietHours) String() string {
+	return q.spec
+}
+
+// Contains reports whether t falls within quiet hours
+func (q *QuietHours) Contains(t time.Time) bool {
+	t = t.In(q.location)
+	day := int(t.Weekday())
+	minute := t.Hour()*60 + t.Minute()
+
+	for _, w := range q.windows {
+		if !w.days[day] {
+			continue
+		}
+		if w.start < w.end && minute >= w.start && minute < w.end {
+			return true
+		}
+		if w.start > w.end && (minute >= w.start || minute < w.end) {
+			return true
+		}
+	}
+	return false
+}
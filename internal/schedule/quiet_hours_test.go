@@ -0,0 +1,72 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHours_Contains(t *testing.T) {
+	quiet, err := ParseQuietHours("Mon-Fri 18:00-09:00, Sat-Sun", nil)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"weekday working hours", time.Date(2025, time.January, 15, 10, 0, 0, 0, time.UTC), false},
+		{"weekday start of working hours", time.Date(2025, time.January, 15, 9, 0, 0, 0, time.UTC), false},
+		{"weekday evening", time.Date(2025, time.January, 15, 18, 0, 0, 0, time.UTC), true},
+		{"weekday early morning", time.Date(2025, time.January, 15, 8, 59, 0, 0, time.UTC), true},
+		{"friday night runs into saturday", time.Date(2025, time.January, 17, 23, 0, 0, 0, time.UTC), true},
+		{"saturday midday", time.Date(2025, time.January, 18, 12, 0, 0, 0, time.UTC), true},
+		{"monday early morning after the weekend", time.Date(2025, time.January, 20, 7, 0, 0, 0, time.UTC), true},
+		{"monday working hours", time.Date(2025, time.January, 20, 11, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quiet.Contains(tt.at); got != tt.want {
+				t.Errorf("Contains(%v) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHours_ContainsInLocation(t *testing.T) {
+	location := time.FixedZone("UTC-5", -5*60*60)
+	quiet, err := ParseQuietHours("22:00-07:00", location)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	// 04:00 UTC is 23:00 the day before in UTC-5
+	if !quiet.Contains(time.Date(2025, time.January, 15, 4, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(04:00 UTC) = false, want true in UTC-5")
+	}
+	if quiet.Contains(time.Date(2025, time.January, 15, 15, 0, 0, 0, time.UTC)) {
+		t.Error("Contains(15:00 UTC) = true, want false in UTC-5")
+	}
+}
+
+func TestParseQuietHours_Invalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"Funday",
+		"18:00",
+		"18:00-18:00",
+		"25:00-07:00",
+		"18:0-07:00",
+		"Mon-Fri 18:00-09:00 extra",
+		"18:00-07:00 20:00-21:00",
+	} {
+		if _, err := ParseQuietHours(spec, nil); err == nil {
+			t.Errorf("ParseQuietHours(%q) error = nil, want error", spec)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+)
+
+// apiRequestCounterKey is the context key of a scan's API request counter
+type apiRequestCounterKey struct{}
+
+// withAPIRequestCounter returns a context whose API requests are counted by counter
+func withAPIRequestCounter(ctx context.Context, counter *atomic.Int64) context.Context {
+	return context.WithValue(ctx, apiRequestCounterKey{}, counter)
+}
+
+// CountAPIRequests wraps the transport of a Kubernetes API client so that the
+// requests made on behalf of a cluster scan are counted towards its API request
+// budget. Reads served from the informer cache never reach the transport and are
+// not counted. Use it with rest.Config.Wrap.
+func CountAPIRequests(rt http.RoundTripper) http.RoundTripper {
+	return apiRequestCounter{next: rt}
+}
+
+// apiRequestCounter counts the requests of the scan found in each request's context
+type apiRequestCounter struct {
+	next http.RoundTripper
+}
+
+// RoundTrip counts the request and passes it on
+func (c apiRequestCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if counter, ok := req.Context().Value(apiRequestCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(1)
+	}
+	return c.next.RoundTrip(req)
+}
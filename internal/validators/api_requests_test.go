@@ -0,0 +1,56 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// roundTripperFunc adapts a function to an http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// sendAPIRequest sends a request through a counted transport, as the Kubernetes
+// client does for reads that miss the informer cache
+func sendAPIRequest(ctx context.Context) error {
+	transport := CountAPIRequests(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://kubernetes.default.svc/api/v1/pods", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestCountAPIRequests(t *testing.T) {
+	var requests atomic.Int64
+	ctx := withAPIRequestCounter(context.Background(), &requests)
+
+	for i := 0; i < 2; i++ {
+		if err := sendAPIRequest(ctx); err != nil {
+			t.Fatalf("sendAPIRequest() error = %v", err)
+		}
+	}
+	// Requests outside a scan are not counted
+	if err := sendAPIRequest(context.Background()); err != nil {
+		t.Fatalf("sendAPIRequest() error = %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %d, want 2", got)
+	}
+}
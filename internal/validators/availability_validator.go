@@ -0,0 +1,248 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides high availability validation functionality.
+//
+// This package implements checks for workloads that a single node or zone failure
+// takes down: replicated Deployments and StatefulSets whose replicas may all be
+// scheduled onto one node, single-replica workloads in production-like namespaces,
+// and workloads pinned to a single zone.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// zoneLabels are the node labels that name a node's zone
+var zoneLabels = []string{corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone}
+
+// AvailabilityConfig defines which availability validation checks to perform
+type AvailabilityConfig struct {
+	EnableSpreadValidation        bool
+	EnableSingleReplicaValidation bool
+	EnableZonePinningValidation   bool
+}
+
+// AvailabilityValidator validates that workloads survive the loss of a node or zone
+type AvailabilityValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               AvailabilityConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// replicatedWorkload is a Deployment or StatefulSet with its replica count and pod template
+type replicatedWorkload struct {
+	kind      string
+	name      string
+	namespace string
+	replicas  int64
+	template  corev1.PodTemplateSpec
+}
+
+// NewAvailabilityValidator creates a new AvailabilityValidator with the given client, logger and config
+func NewAvailabilityValidator(client client.Client, log logr.Logger, config AvailabilityConfig) *AvailabilityValidator {
+	return &AvailabilityValidator{
+		client:       client,
+		log:          log.WithName("availability-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *AvailabilityValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *AvailabilityValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *AvailabilityValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for availability validation
+func (v *AvailabilityValidator) GetValidationType() string {
+	return "availability_validation"
+}
+
+// ValidateCluster performs high availability validation across the cluster
+func (v *AvailabilityValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	workloads, err := v.listReplicatedWorkloads(ctx)
+	if err != nil {
+		return err
+	}
+
+	var allErrors []ValidationError
+	for _, workload := range workloads {
+		if v.sharedConfig.IsSystemNamespace(workload.namespace) {
+			continue
+		}
+		// Workloads scaled to zero are intentionally unavailable
+		if workload.replicas == 0 {
+			continue
+		}
+
+		if v.config.EnableSpreadValidation {
+			allErrors = append(allErrors, v.validateReplicaSpread(workload)...)
+		}
+		if v.config.EnableSingleReplicaValidation {
+			allErrors = append(allErrors, v.validateSingleReplica(workload)...)
+		}
+		if v.config.EnableZonePinningValidation {
+			allErrors = append(allErrors, v.validateZonePinning(workload)...)
+		}
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "availability", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "availability", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// listReplicatedWorkloads lists the Deployments and StatefulSets of the cluster
+func (v *AvailabilityValidator) listReplicatedWorkloads(ctx context.Context) ([]replicatedWorkload, error) {
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+
+	var workloads []replicatedWorkload
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, replicatedWorkload{
+			kind:      "Deployment",
+			name:      deployment.Name,
+			namespace: deployment.Namespace,
+			replicas:  replicaCount(deployment.Spec.Replicas),
+			template:  deployment.Spec.Template,
+		})
+	}
+	for _, statefulSet := range statefulSets.Items {
+		workloads = append(workloads, replicatedWorkload{
+			kind:      "StatefulSet",
+			name:      statefulSet.Name,
+			namespace: statefulSet.Namespace,
+			replicas:  replicaCount(statefulSet.Spec.Replicas),
+			template:  statefulSet.Spec.Template,
+		})
+	}
+	return workloads, nil
+}
+
+// validateReplicaSpread reports replicated workloads with neither topology spread
+// constraints nor pod anti-affinity, so the scheduler may place every replica on one node
+func (v *AvailabilityValidator) validateReplicaSpread(workload replicatedWorkload) []ValidationError {
+	if workload.replicas < 2 {
+		return nil
+	}
+	spec := workload.template.Spec
+	if len(spec.TopologySpreadConstraints) > 0 {
+		return nil
+	}
+	if affinity := spec.Affinity; affinity != nil && affinity.PodAntiAffinity != nil {
+		antiAffinity := affinity.PodAntiAffinity
+		if len(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution) > 0 || len(antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution) > 0 {
+			return nil
+		}
+	}
+
+	return []ValidationError{NewValidationErrorWithCode(workload.kind, workload.name, workload.namespace, "replicas_not_spread", GetAvailabilityErrorCode("replicas_not_spread"),
+		fmt.Sprintf("%s '%s' runs %d replicas without topologySpreadConstraints or pod anti-affinity, so they may all be scheduled onto one node", workload.kind, workload.name, workload.replicas)).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint(fmt.Sprintf("Add a topologySpreadConstraint with topologyKey '%s' (and '%s' for multi-zone clusters) matching the pod labels, or a preferred podAntiAffinity term, so one node failure cannot take down every replica", corev1.LabelHostname, corev1.LabelTopologyZone)).
+		WithDetail("replicas", strconv.FormatInt(workload.replicas, 10))}
+}
+
+// validateSingleReplica reports workloads in production-like namespaces that run a
+// single replica, which is unavailable during every node drain and rollout
+func (v *AvailabilityValidator) validateSingleReplica(workload replicatedWorkload) []ValidationError {
+	if workload.replicas != 1 || !v.sharedConfig.IsProductionLikeNamespace(workload.namespace) {
+		return nil
+	}
+
+	hint := "Run at least 2 replicas with a PodDisruptionBudget so node drains and rollouts keep one replica serving"
+	if workload.kind == "StatefulSet" {
+		hint = "Run at least 2 replicas if the application supports it, with a PodDisruptionBudget so node drains keep one replica serving"
+	}
+	return []ValidationError{NewValidationErrorWithCode(workload.kind, workload.name, workload.namespace, "single_replica_production", GetAvailabilityErrorCode("single_replica_production"),
+		fmt.Sprintf("%s '%s' runs a single replica in production-like namespace '%s'", workload.kind, workload.name, workload.namespace)).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint(hint).
+		WithDetail("replicas", "1")}
+}
+
+// validateZonePinning reports workloads whose nodeSelector or required node affinity
+// restricts them to a single zone
+func (v *AvailabilityValidator) validateZonePinning(workload replicatedWorkload) []ValidationError {
+	zone, source := pinnedZone(workload.template.Spec)
+	if zone == "" {
+		return nil
+	}
+
+	return []ValidationError{NewValidationErrorWithCode(workload.kind, workload.name, workload.namespace, "workload_pinned_to_single_zone", GetAvailabilityErrorCode("workload_pinned_to_single_zone"),
+		fmt.Sprintf("%s '%s' is pinned to zone '%s' by its %s, so a zone outage takes down every replica", workload.kind, workload.name, zone, source)).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint(fmt.Sprintf("Remove the zone from the %s, or allow several zones and spread replicas across them with a topologySpreadConstraint on '%s'", source, corev1.LabelTopologyZone)).
+		WithDetail("zone", zone).
+		WithDetail("pinned_by", source)}
+}
+
+// pinnedZone returns the zone a pod spec is restricted to and the field restricting it,
+// or an empty zone when the pod may run in several zones
+func pinnedZone(spec corev1.PodSpec) (string, string) {
+	for _, label := range zoneLabels {
+		if zone, ok := spec.NodeSelector[label]; ok {
+			return zone, "nodeSelector"
+		}
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return "", ""
+	}
+	// Terms are ORed, so every term must pin the same single zone
+	zone := ""
+	for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		termZone := ""
+		for _, expression := range term.MatchExpressions {
+			if slices.Contains(zoneLabels, expression.Key) && expression.Operator == corev1.NodeSelectorOpIn && len(expression.Values) == 1 {
+				termZone = expression.Values[0]
+			}
+		}
+		if termZone == "" || (zone != "" && termZone != zone) {
+			return "", ""
+		}
+		zone = termZone
+	}
+	if zone == "" {
+		return "", ""
+	}
+	return zone, "required node affinity"
+}
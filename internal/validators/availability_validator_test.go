@@ -0,0 +1,114 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestAvailabilityValidator_GetValidationType(t *testing.T) {
+	validator := NewAvailabilityValidator(nil, logr.Discard(), AvailabilityConfig{})
+	if got := validator.GetValidationType(); got != "availability_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "availability_validation")
+	}
+}
+
+func TestAvailabilityValidator_ValidateCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := func(namespace, name string, replicas int32, spec corev1.PodSpec) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{Spec: spec},
+			},
+		}
+	}
+	spread := corev1.PodSpec{TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{TopologyKey: corev1.LabelHostname, MaxSkew: 1}}}
+	antiAffinity := corev1.PodSpec{Affinity: &corev1.Affinity{PodAntiAffinity: &corev1.PodAntiAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{{Weight: 100, PodAffinityTerm: corev1.PodAffinityTerm{TopologyKey: corev1.LabelHostname}}},
+	}}}
+	zoneAffinity := func(zones ...string) corev1.PodSpec {
+		var terms []corev1.NodeSelectorTerm
+		for _, zone := range zones {
+			terms = append(terms, corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+				{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{zone}},
+			}})
+		}
+		return corev1.PodSpec{
+			TopologySpreadConstraints: spread.TopologySpreadConstraints,
+			Affinity:                  &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: terms}}},
+		}
+	}
+	zoneSelector := spread
+	zoneSelector.NodeSelector = map[string]string{corev1.LabelTopologyZone: "eu-west-1a"}
+	singleReplicaSet := int32(1)
+
+	objects := []client.Object{
+		deployment("test-ns", "unspread", 3, corev1.PodSpec{}),
+		deployment("test-ns", "spread", 3, spread),
+		deployment("test-ns", "anti-affinity", 3, antiAffinity),
+		deployment("test-ns", "single", 1, corev1.PodSpec{}),
+		deployment("test-ns", "zone-selector", 2, zoneSelector),
+		deployment("test-ns", "zone-affinity", 2, zoneAffinity("eu-west-1a", "eu-west-1a")),
+		deployment("test-ns", "multi-zone", 2, zoneAffinity("eu-west-1a", "eu-west-1b")),
+		deployment("shop-prod", "checkout", 1, corev1.PodSpec{}),
+		deployment("shop-prod", "paused", 0, corev1.PodSpec{}),
+		deployment("kube-system", "coredns", 2, corev1.PodSpec{}),
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop-prod"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &singleReplicaSet},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewAvailabilityValidator(fakeClient, logr.Discard(), AvailabilityConfig{
+		EnableSpreadValidation:        true,
+		EnableSingleReplicaValidation: true,
+		EnableZonePinningValidation:   true,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		got = append(got, finding.ValidationType+" "+finding.ResourceType+"/"+finding.ResourceName)
+		if finding.Severity != SeverityWarning {
+			t.Errorf("%s on %s has severity %q, want %q", finding.ValidationType, finding.ResourceName, finding.Severity, SeverityWarning)
+		}
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"replicas_not_spread Deployment/unspread",
+		"single_replica_production Deployment/checkout",
+		"single_replica_production StatefulSet/db",
+		"workload_pinned_to_single_zone Deployment/zone-affinity",
+		"workload_pinned_to_single_zone Deployment/zone-selector",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"strings"
+)
+
+// ComplianceControl is a control of a compliance framework, with the error codes whose
+// findings show it is not met
+type ComplianceControl struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	ErrorCodes []string `json:"errorCodes"`
+}
+
+// ComplianceFramework is a compliance framework whose controls Kogaro's checks support,
+// such as the CIS Kubernetes Benchmark
+type ComplianceFramework struct {
+	// Name is how the framework is selected, such as cis
+	Name     string              `json:"name"`
+	Title    string              `json:"title"`
+	Controls []ComplianceControl `json:"controls"`
+}
+
+// complianceFrameworks maps the controls of each supported framework to error codes.
+// A control lists only the codes whose checks cover it; controls no check covers, such
+// as those of the control plane's configuration, are left out.
+var complianceFrameworks = []ComplianceFramework{
+	{Name: "cis", Title: "CIS Kubernetes Benchmark v1.9", Controls: []ComplianceControl{
+		{ID: "5.1.1", Title: "Ensure that the cluster-admin role is only used where required", ErrorCodes: []string{"KOGARO-SEC-011"}},
+		{ID: "5.1.2", Title: "Minimize access to secrets", ErrorCodes: []string{"KOGARO-SEC-014"}},
+		{ID: "5.1.3", Title: "Minimize wildcard use in Roles and ClusterRoles", ErrorCodes: []string{"KOGARO-SEC-013"}},
+		{ID: "5.1.4", Title: "Minimize access to create pods", ErrorCodes: []string{"KOGARO-SEC-012", "KOGARO-SEC-016"}},
+		{ID: "5.1.8", Title: "Limit use of the Bind, Impersonate and Escalate permissions", ErrorCodes: []string{"KOGARO-SEC-015"}},
+		{ID: "5.2.2", Title: "Minimize the admission of privileged containers", ErrorCodes: []string{"KOGARO-SEC-006"}},
+		{ID: "5.2.3", Title: "Minimize the admission of containers wishing to share the host process ID namespace", ErrorCodes: []string{"KOGARO-SEC-022"}},
+		{ID: "5.2.4", Title: "Minimize the admission of containers wishing to share the host IPC namespace", ErrorCodes: []string{"KOGARO-SEC-022"}},
+		{ID: "5.2.5", Title: "Minimize the admission of containers wishing to share the host network namespace", ErrorCodes: []string{"KOGARO-SEC-022", "KOGARO-WKL-006"}},
+		{ID: "5.2.6", Title: "Minimize the admission of containers with allowPrivilegeEscalation", ErrorCodes: []string{"KOGARO-SEC-004", "KOGARO-SEC-005"}},
+		{ID: "5.2.7", Title: "Minimize the admission of root containers", ErrorCodes: []string{"KOGARO-SEC-001", "KOGARO-SEC-002", "KOGARO-SEC-003"}},
+		{ID: "5.2.8", Title: "Minimize the admission of containers with the NET_RAW capability", ErrorCodes: []string{"KOGARO-SEC-024"}},
+		{ID: "5.2.9", Title: "Minimize the admission of containers with added capabilities", ErrorCodes: []string{"KOGARO-SEC-008"}},
+		{ID: "5.2.10", Title: "Minimize the admission of containers with capabilities assigned", ErrorCodes: []string{"KOGARO-SEC-008", "KOGARO-SEC-024"}},
+		{ID: "5.2.12", Title: "Minimize the admission of HostPath volumes", ErrorCodes: []string{"KOGARO-SEC-021"}},
+		{ID: "5.3.2", Title: "Ensure that all Namespaces have NetworkPolicies defined", ErrorCodes: []string{"KOGARO-NET-006", "KOGARO-SEC-027", "KOGARO-SEC-028"}},
+		{ID: "5.7.3", Title: "Apply SecurityContext to your Pods and Containers", ErrorCodes: []string{"KOGARO-SEC-009", "KOGARO-SEC-010", "KOGARO-SEC-025", "KOGARO-SEC-026"}},
+	}},
+	{Name: "nsa", Title: "NSA/CISA Kubernetes Hardening Guide v1.2", Controls: []ComplianceControl{
+		{ID: "POD-1", Title: "Use containers built to run applications as non-root users", ErrorCodes: []string{"KOGARO-SEC-001", "KOGARO-SEC-002", "KOGARO-SEC-003"}},
+		{ID: "POD-2", Title: "Run containers with immutable file systems", ErrorCodes: []string{"KOGARO-SEC-007"}},
+		{ID: "POD-3", Title: "Prevent privileged containers and privilege escalation", ErrorCodes: []string{"KOGARO-SEC-004", "KOGARO-SEC-005", "KOGARO-SEC-006", "KOGARO-SEC-008", "KOGARO-SEC-024"}},
+		{ID: "POD-4", Title: "Isolate pods from the host's namespaces, file system and kernel settings", ErrorCodes: []string{"KOGARO-SEC-021", "KOGARO-SEC-022", "KOGARO-SEC-025", "KOGARO-SEC-026", "KOGARO-WKL-006"}},
+		{ID: "POD-5", Title: "Set a security context on every pod and container", ErrorCodes: []string{"KOGARO-SEC-009", "KOGARO-SEC-010"}},
+		{ID: "NET-1", Title: "Deny traffic by default with NetworkPolicies", ErrorCodes: []string{"KOGARO-NET-006", "KOGARO-SEC-027", "KOGARO-SEC-028"}},
+		{ID: "NET-2", Title: "Restrict egress traffic with NetworkPolicies", ErrorCodes: []string{"KOGARO-NET-019"}},
+		{ID: "NET-3", Title: "Encrypt traffic with TLS", ErrorCodes: []string{"KOGARO-NET-026", "KOGARO-SCR-003", "KOGARO-SCR-004"}},
+		{ID: "RES-1", Title: "Limit the resources of containers with requests, limits and LimitRanges", ErrorCodes: []string{
+			"KOGARO-RES-001", "KOGARO-RES-002", "KOGARO-RES-003", "KOGARO-RES-004", "KOGARO-RES-005", "KOGARO-QTA-004"}},
+		{ID: "AUTH-1", Title: "Grant users and ServiceAccounts least privilege with RBAC", ErrorCodes: []string{
+			"KOGARO-SEC-011", "KOGARO-SEC-012", "KOGARO-SEC-013", "KOGARO-SEC-014", "KOGARO-SEC-015", "KOGARO-SEC-016"}},
+	}},
+	{Name: "pci", Title: "PCI DSS v4.0", Controls: []ComplianceControl{
+		{ID: "1.3.1", Title: "Inbound traffic to the cardholder data environment is restricted", ErrorCodes: []string{"KOGARO-NET-006", "KOGARO-SEC-027", "KOGARO-SEC-028"}},
+		{ID: "1.3.2", Title: "Outbound traffic from the cardholder data environment is restricted", ErrorCodes: []string{"KOGARO-NET-019"}},
+		{ID: "2.2.5", Title: "Insecure services, protocols and daemons are justified and secured", ErrorCodes: []string{"KOGARO-SEC-021", "KOGARO-SEC-022", "KOGARO-WKL-006"}},
+		{ID: "2.2.6", Title: "System security parameters are configured to prevent misuse", ErrorCodes: []string{
+			"KOGARO-SEC-001", "KOGARO-SEC-002", "KOGARO-SEC-003", "KOGARO-SEC-004", "KOGARO-SEC-005", "KOGARO-SEC-006", "KOGARO-SEC-007",
+			"KOGARO-SEC-008", "KOGARO-SEC-009", "KOGARO-SEC-010", "KOGARO-SEC-024", "KOGARO-SEC-025", "KOGARO-SEC-026"}},
+		{ID: "4.2.1", Title: "Strong cryptography protects data during transmission over open networks", ErrorCodes: []string{
+			"KOGARO-NET-026", "KOGARO-NET-028", "KOGARO-NET-029", "KOGARO-SCR-003", "KOGARO-SCR-004"}},
+		{ID: "7.2.1", Title: "Access is assigned by least privilege", ErrorCodes: []string{
+			"KOGARO-SEC-011", "KOGARO-SEC-012", "KOGARO-SEC-013", "KOGARO-SEC-014", "KOGARO-SEC-015", "KOGARO-SEC-016"}},
+	}},
+}
+
+// ComplianceFrameworks returns the supported compliance frameworks
+func ComplianceFrameworks() []ComplianceFramework {
+	return complianceFrameworks
+}
+
+// LookupComplianceFramework returns a compliance framework by name, such as cis.
+// Names are matched case-insensitively.
+func LookupComplianceFramework(name string) (ComplianceFramework, bool) {
+	for _, framework := range complianceFrameworks {
+		if strings.EqualFold(framework.Name, strings.TrimSpace(name)) {
+			return framework, true
+		}
+	}
+	return ComplianceFramework{}, false
+}
+
+// complianceControlsByCode indexes the controls of every framework by error code, as
+// framework:control, such as cis:5.2.2
+func complianceControlsByCode() map[string][]string {
+	controls := make(map[string][]string)
+	for _, framework := range complianceFrameworks {
+		for _, control := range framework.Controls {
+			for _, code := range control.ErrorCodes {
+				controls[code] = append(controls[code], framework.Name+":"+control.ID)
+			}
+		}
+	}
+	return controls
+}
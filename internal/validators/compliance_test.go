@@ -0,0 +1,66 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestComplianceFrameworks_MapRegisteredCodes checks that controls map registered codes,
+// which are tagged with them, as documented in docs/ERROR-CODES.md
+func TestComplianceFrameworks_MapRegisteredCodes(t *testing.T) {
+	markdown, err := os.ReadFile("../../docs/ERROR-CODES.md")
+	if err != nil {
+		t.Fatalf("failed to read ERROR-CODES.md: %v", err)
+	}
+
+	for _, framework := range ComplianceFrameworks() {
+		ids := make(map[string]bool)
+		for _, control := range framework.Controls {
+			if ids[control.ID] {
+				t.Errorf("%s control %s is listed twice", framework.Name, control.ID)
+			}
+			ids[control.ID] = true
+			row := "| " + control.ID + " | " + control.Title + " | " + strings.Join(control.ErrorCodes, ", ") + " |"
+			if !strings.Contains(string(markdown), row) {
+				t.Errorf("%s control %s is not documented in ERROR-CODES.md as %q", framework.Name, control.ID, row)
+			}
+			if control.Title == "" || len(control.ErrorCodes) == 0 {
+				t.Errorf("%s control %s has no title or error codes", framework.Name, control.ID)
+			}
+			for _, code := range control.ErrorCodes {
+				info, ok := LookupErrorCode(code)
+				if !ok {
+					t.Errorf("%s control %s maps unregistered code %s", framework.Name, control.ID, code)
+					continue
+				}
+				if !slices.Contains(info.Controls, framework.Name+":"+control.ID) {
+					t.Errorf("%s controls = %v, want them to include %s:%s", code, info.Controls, framework.Name, control.ID)
+				}
+			}
+		}
+	}
+}
+
+func TestLookupComplianceFramework(t *testing.T) {
+	framework, ok := LookupComplianceFramework(" CIS")
+	if !ok || framework.Name != "cis" {
+		t.Errorf("LookupComplianceFramework(CIS) = %+v, %v", framework, ok)
+	}
+	if _, ok := LookupComplianceFramework("sox"); ok {
+		t.Error("LookupComplianceFramework() found an unsupported framework")
+	}
+
+	info, _ := LookupErrorCode("KOGARO-SEC-006")
+	for _, want := range []string{"cis:5.2.2", "nsa:POD-3", "pci:2.2.6"} {
+		if !slices.Contains(info.Controls, want) {
+			t.Errorf("KOGARO-SEC-006 controls = %v, want them to include %s", info.Controls, want)
+		}
+	}
+}
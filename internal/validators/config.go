@@ -7,6 +7,10 @@
 package validators
 
 import (
+	"slices"
+	"strings"
+	"sync/atomic"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -160,6 +164,71 @@ func DefaultSharedConfig() SharedConfig {
 	}
 }
 
+// SharedConfigOverrides adjusts the namespace, role and pod classifications of the
+// SharedConfig. Entries are added to the built-in lists unless ReplaceDefaults is set,
+// in which case every list that has entries replaces the built-in one.
+type SharedConfigOverrides struct {
+	// ReplaceDefaults replaces the built-in lists instead of extending them
+	ReplaceDefaults bool `json:"replaceDefaults,omitempty"`
+	// SystemNamespaces are excluded from most validations
+	SystemNamespaces []string `json:"systemNamespaces,omitempty"`
+	// SecurityExcludedNamespaces are excluded from security validation
+	SecurityExcludedNamespaces []string `json:"securityExcludedNamespaces,omitempty"`
+	// NetworkingExcludedNamespaces are excluded from networking validation
+	NetworkingExcludedNamespaces []string `json:"networkingExcludedNamespaces,omitempty"`
+	// DangerousRoles are role names whose bindings are reported as excessive
+	DangerousRoles []string `json:"dangerousRoles,omitempty"`
+	// ProductionIndicators classify namespaces as production-like by prefix or suffix
+	ProductionIndicators []string `json:"productionIndicators,omitempty"`
+	// UnexposedPodPatterns are pod name prefixes of pods that don't need a Service
+	UnexposedPodPatterns []string `json:"unexposedPodPatterns,omitempty"`
+	// BatchOwnerKinds are owner kinds of pods that don't need a Service
+	BatchOwnerKinds []string `json:"batchOwnerKinds,omitempty"`
+}
+
+// WithOverrides returns a copy of the configuration with the overrides applied
+func (c SharedConfig) WithOverrides(overrides SharedConfigOverrides) SharedConfig {
+	apply := func(current, entries []string) []string {
+		var values []string
+		if !overrides.ReplaceDefaults || len(entries) == 0 {
+			values = append(values, current...)
+		}
+		for _, entry := range entries {
+			if entry = strings.TrimSpace(entry); entry != "" && !slices.Contains(values, entry) {
+				values = append(values, entry)
+			}
+		}
+		return values
+	}
+
+	c.SystemNamespaces = apply(c.SystemNamespaces, overrides.SystemNamespaces)
+	c.SecurityExcludedNamespaces = apply(c.SecurityExcludedNamespaces, overrides.SecurityExcludedNamespaces)
+	c.NetworkingExcludedNamespaces = apply(c.NetworkingExcludedNamespaces, overrides.NetworkingExcludedNamespaces)
+	c.RBACConfig.DangerousRoles = apply(c.RBACConfig.DangerousRoles, overrides.DangerousRoles)
+	c.NamespacePatterns.ProductionIndicators = apply(c.NamespacePatterns.ProductionIndicators, overrides.ProductionIndicators)
+	c.PodPatterns.UnexposedPodPatterns = apply(c.PodPatterns.UnexposedPodPatterns, overrides.UnexposedPodPatterns)
+	c.PodPatterns.BatchOwnerKinds = apply(c.PodPatterns.BatchOwnerKinds, overrides.BatchOwnerKinds)
+	return c
+}
+
+// activeSharedConfig is the configuration given to validators when they are created
+var activeSharedConfig atomic.Pointer[SharedConfig]
+
+// SetSharedConfig installs the shared configuration used by validators created after
+// the call. A nil configuration restores DefaultSharedConfig.
+func SetSharedConfig(config *SharedConfig) {
+	activeSharedConfig.Store(config)
+}
+
+// ActiveSharedConfig returns the shared configuration installed with SetSharedConfig,
+// or DefaultSharedConfig when none is installed
+func ActiveSharedConfig() SharedConfig {
+	if config := activeSharedConfig.Load(); config != nil {
+		return *config
+	}
+	return DefaultSharedConfig()
+}
+
 // IsSystemNamespace checks if a namespace is considered a system namespace
 func (c *SharedConfig) IsSystemNamespace(namespace string) bool {
 	for _, systemNS := range c.SystemNamespaces {
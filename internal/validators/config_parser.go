@@ -0,0 +1,304 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+// configDocument is a single object parsed from a config file together with the
+// line on which its YAML document starts
+type configDocument struct {
+	Object client.Object
+	Line   int
+}
+
+// parseConfigDocuments parses a Kubernetes config file into objects, recording the
+// 1-based line number of the first content line of each object.
+//
+// The file may be a stream of YAML documents, or of JSON values as written by
+// kubectl get -o json or jq. A document or value may be a single object, a List
+// kind such as v1/List or ConfigMapList, or an array of objects; lists and arrays are
+// expanded into their items.
+func parseConfigDocuments(data []byte) ([]configDocument, error) {
+	// Check for Helm template syntax
+	configStr := string(data)
+	if strings.Contains(configStr, "{{") && strings.Contains(configStr, "}}") {
+		return nil, fmt.Errorf("file appears to contain Helm templates. Please render the template first using 'helm template' and validate the resulting YAML")
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		documents, jsonErr := parseJSONDocuments(data)
+		if jsonErr == nil {
+			return documents, nil
+		}
+		// Flow-style YAML looks like JSON too
+		documents, err := parseYAMLDocuments(data)
+		if err != nil {
+			return nil, jsonErr
+		}
+		return documents, nil
+	}
+	return parseYAMLDocuments(data)
+}
+
+// parseYAMLDocuments decodes a stream of YAML documents. Unlike splitting the file on
+// "---", the decoder leaves separators inside strings and block scalars alone.
+func parseYAMLDocuments(data []byte) ([]configDocument, error) {
+	var documents []configDocument
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+		}
+
+		// Documents holding nothing but comments are skipped
+		if len(document.Content) == 0 {
+			continue
+		}
+		root := document.Content[0]
+		switch {
+		case root.Kind == yaml.ScalarNode && root.Tag == "!!null":
+			continue
+		case root.Kind == yaml.SequenceNode:
+			for _, item := range root.Content {
+				itemDocuments, err := yamlNodeDocuments(item)
+				if err != nil {
+					return nil, err
+				}
+				documents = append(documents, itemDocuments...)
+			}
+		default:
+			nodeDocuments, err := yamlNodeDocuments(root)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, nodeDocuments...)
+		}
+	}
+}
+
+// yamlNodeDocuments converts a YAML node holding an object. The node is encoded again
+// and converted like kubectl does, so that scalars keep their Kubernetes meaning.
+func yamlNodeDocuments(node *yaml.Node) ([]configDocument, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("failed to parse YAML: document at line %d is not an object", node.Line)
+	}
+	encoded, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML at line %d: %w", node.Line, err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := sigsyaml.Unmarshal(encoded, obj); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML at line %d: %w", node.Line, err)
+	}
+
+	// Items of a List start on their own lines
+	var itemLines []int
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "items" && node.Content[i+1].Kind == yaml.SequenceNode {
+			for _, item := range node.Content[i+1].Content {
+				itemLines = append(itemLines, item.Line)
+			}
+		}
+	}
+	return objectDocuments(obj, node.Line, itemLines)
+}
+
+// parseJSONDocuments decodes a stream of JSON values
+func parseJSONDocuments(data []byte) ([]configDocument, error) {
+	var documents []configDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		start := jsonValueStart(data, decoder.InputOffset())
+		if start == len(data) {
+			return documents, nil
+		}
+
+		if data[start] != '[' {
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to parse JSON at line %d: %w", lineAt(data, start), err)
+			}
+			valueDocuments, err := jsonObjectDocuments(raw, lineAt(data, start))
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, valueDocuments...)
+			continue
+		}
+
+		// Expand an array of objects
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON at line %d: %w", lineAt(data, start), err)
+		}
+		for decoder.More() {
+			itemStart := jsonValueStart(data, decoder.InputOffset())
+			var raw json.RawMessage
+			if err := decoder.Decode(&raw); err != nil {
+				return nil, fmt.Errorf("failed to parse JSON at line %d: %w", lineAt(data, itemStart), err)
+			}
+			itemDocuments, err := jsonObjectDocuments(raw, lineAt(data, itemStart))
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, itemDocuments...)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON at line %d: %w", lineAt(data, start), err)
+		}
+	}
+}
+
+// jsonObjectDocuments converts a JSON value holding an object
+func jsonObjectDocuments(raw json.RawMessage, line int) ([]configDocument, error) {
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, fmt.Errorf("failed to parse JSON: value at line %d is not an object", line)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON at line %d: %w", line, err)
+	}
+	// JSON items aren't located, so they share the line of their List
+	return objectDocuments(obj, line, nil)
+}
+
+// objectDocuments returns the document of an object starting at line, or the items
+// of a List kind starting at itemLines, or at the line of the List when unknown
+func objectDocuments(obj *unstructured.Unstructured, line int, itemLines []int) ([]configDocument, error) {
+	if !strings.HasSuffix(obj.GetKind(), "List") || !obj.IsList() {
+		return []configDocument{{Object: obj, Line: line}}, nil
+	}
+
+	items, _ := obj.Object["items"].([]interface{})
+	documents := make([]configDocument, 0, len(items))
+	for i, item := range items {
+		itemLine := line
+		if i < len(itemLines) {
+			itemLine = itemLines[i]
+		}
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("item %d of the %s at line %d is not an object", i, obj.GetKind(), line)
+		}
+		itemObj := &unstructured.Unstructured{Object: fields}
+		if itemObj.GetKind() == "" || itemObj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("item %d of the %s at line %d has no apiVersion or kind", i, obj.GetKind(), itemLine)
+		}
+		documents = append(documents, configDocument{Object: itemObj, Line: itemLine})
+	}
+	return documents, nil
+}
+
+// decodeConfigObjects decodes objects of kinds registered in the client-go scheme into
+// their typed form, as the API server would, and leaves other kinds unstructured.
+// Objects whose fields do not match their schema are dropped and reported, and
+// fields the schema does not define are reported and ignored. Custom resources whose
+// CustomResourceDefinition is part of the config are checked against its schema.
+func decodeConfigObjects(documents []configDocument) ([]client.Object, []ValidationError) {
+	customResources := bundledCustomResourceSchemas(documents)
+	objects := make([]client.Object, 0, len(documents))
+	var findings []ValidationError
+	for _, document := range documents {
+		obj, ok := document.Object.(*unstructured.Unstructured)
+		gvk := document.Object.GetObjectKind().GroupVersionKind()
+		if !ok || !scheme.Scheme.Recognizes(gvk) {
+			// Custom resources are checked against a CRD of the same config
+			if ok {
+				findings = append(findings, customResources.validate(obj)...)
+			}
+			objects = append(objects, document.Object)
+			continue
+		}
+		newObj, err := scheme.Scheme.New(gvk)
+		if err != nil {
+			objects = append(objects, document.Object)
+			continue
+		}
+		typed, ok := newObj.(client.Object)
+		if !ok {
+			objects = append(objects, document.Object)
+			continue
+		}
+
+		err = runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj.Object, typed, true)
+		switch {
+		case err == nil:
+			objects = append(objects, typed)
+		case runtime.IsStrictDecodingError(err):
+			var fields []string
+			if strictErr, ok := runtime.AsStrictDecodingError(err); ok {
+				for _, fieldErr := range strictErr.Errors() {
+					fields = append(fields, strings.TrimSuffix(strings.TrimPrefix(fieldErr.Error(), `unknown field "`), `"`))
+				}
+			}
+			findings = append(findings, unknownFieldsFinding(obj, fields))
+			objects = append(objects, typed)
+		default:
+			// The JSON decoder names the offending field, unlike the converter
+			if data, marshalErr := obj.MarshalJSON(); marshalErr == nil {
+				if jsonErr := json.Unmarshal(data, newObj); jsonErr != nil {
+					err = jsonErr
+				}
+			}
+			finding := NewValidationErrorWithCode(gvk.Kind, obj.GetName(), obj.GetNamespace(), "invalid_config_object", GetRegistryErrorCode("invalid_config_object"),
+				fmt.Sprintf("%s '%s' does not match the %s schema and was not validated: %v", gvk.Kind, obj.GetName(), gvk.GroupVersion(), err)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Fix the field types; the API server rejects objects that do not match their schema").
+				WithDetail("api_version", gvk.GroupVersion().String())
+			findings = append(findings, finding)
+		}
+	}
+	return objects, findings
+}
+
+// unknownFieldsFinding reports fields of a config object that its kind does not define
+func unknownFieldsFinding(obj *unstructured.Unstructured, fields []string) ValidationError {
+	gvk := obj.GroupVersionKind()
+	return NewValidationErrorWithCode(gvk.Kind, obj.GetName(), obj.GetNamespace(), "unknown_config_field", GetRegistryErrorCode("unknown_config_field"),
+		fmt.Sprintf("%s '%s' sets fields that %s does not define: %s", gvk.Kind, obj.GetName(), gvk.GroupVersion(), strings.Join(fields, ", "))).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Correct or remove the fields; the API server drops unknown fields, or rejects them under kubectl apply --validate=strict").
+		WithDetail("api_version", gvk.GroupVersion().String()).
+		WithDetail("fields", strings.Join(fields, ","))
+}
+
+// jsonValueStart returns the offset of the next JSON value from offset, skipping
+// whitespace and the commas between array items
+func jsonValueStart(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\r', '\n', ',':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// lineAt returns the 1-based line of an offset
+func lineAt(data []byte, offset int) int {
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
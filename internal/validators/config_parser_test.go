@@ -0,0 +1,282 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestParseConfigDocuments(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		// want lists the parsed objects as kind/name@line
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "separators inside strings",
+			data: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: certs
+data:
+  bundle.pem: |
+    -----BEGIN CERTIFICATE-----
+    ---
+    -----END CERTIFICATE-----
+  divider: "---"
+--- # the next document
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+`,
+			want: []string{"ConfigMap/certs@1", "Service/web@12"},
+		},
+		{
+			name: "empty and comment-only documents",
+			data: `---
+# nothing here
+---
+
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: token
+---
+`,
+			want: []string{"Secret/token@6"},
+		},
+		{
+			name: "List kind",
+			data: `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+`,
+			want: []string{"ConfigMap/a@4", "ConfigMap/b@8"},
+		},
+		{
+			name: "typed List kind",
+			data: `apiVersion: v1
+kind: ServiceList
+items:
+- apiVersion: v1
+  kind: Service
+  metadata:
+    name: web
+`,
+			want: []string{"Service/web@4"},
+		},
+		{
+			name: "List item without kind",
+			data: `apiVersion: v1
+kind: List
+items:
+- metadata:
+    name: a
+`,
+			wantErr: "has no apiVersion or kind",
+		},
+		{
+			name: "YAML sequence of objects",
+			data: `- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: a
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: b
+`,
+			want: []string{"ConfigMap/a@1", "ConfigMap/b@5"},
+		},
+		{
+			name: "JSON object",
+			data: `{
+  "apiVersion": "v1",
+  "kind": "ConfigMap",
+  "metadata": {"name": "a"}
+}`,
+			want: []string{"ConfigMap/a@1"},
+		},
+		{
+			name: "JSON stream",
+			data: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}}
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b", "annotations": {"note": "---"}}}
+`,
+			want: []string{"ConfigMap/a@1", "ConfigMap/b@2"},
+		},
+		{
+			name: "JSON array",
+			data: `[
+	{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "a"}},
+	{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "b"}}
+]`,
+			want: []string{"ConfigMap/a@2", "ConfigMap/b@3"},
+		},
+		{
+			name: "JSON List from kubectl get -o json",
+			data: `{
+  "apiVersion": "v1",
+  "kind": "List",
+  "items": [
+    {"apiVersion": "v1", "kind": "Service", "metadata": {"name": "web"}},
+    {"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "web"}}
+  ]
+}`,
+			want: []string{"Service/web@1", "Deployment/web@1"},
+		},
+		{
+			name: "flow-style YAML",
+			data: `{apiVersion: v1, kind: ConfigMap, metadata: {name: a}}`,
+			want: []string{"ConfigMap/a@1"},
+		},
+		{
+			name:    "malformed JSON",
+			data:    `{"apiVersion": "v1", "kind": "ConfigMap",`,
+			wantErr: "failed to parse JSON",
+		},
+		{
+			name:    "JSON array of scalars",
+			data:    `[1, 2]`,
+			wantErr: "not an object",
+		},
+		{
+			name:    "scalar document",
+			data:    "just a string\n",
+			wantErr: "not an object",
+		},
+		{
+			name:    "object without kind",
+			data:    "apiVersion: v1\nmetadata:\n  name: a\n",
+			wantErr: "failed to parse YAML",
+		},
+		{
+			name:    "Helm template",
+			data:    "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: {{ .Release.Name }}\n",
+			wantErr: "Helm templates",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := parseConfigDocuments([]byte(tt.data))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseConfigDocuments() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseConfigDocuments() error = %v", err)
+			}
+			var got []string
+			for _, document := range documents {
+				got = append(got, fmt.Sprintf("%s/%s@%d", document.Object.GetObjectKind().GroupVersionKind().Kind, document.Object.GetName(), document.Line))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("parseConfigDocuments() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfigDocuments_KeepsKubernetesScalars(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+  labels:
+    version: "1.10"
+data:
+  enabled: "yes"
+`))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+	obj := documents[0].Object.(*unstructured.Unstructured)
+	if got := obj.GetLabels()["version"]; got != "1.10" {
+		t.Errorf("version label = %q, want the quoted string kept", got)
+	}
+	if got, _, _ := unstructured.NestedString(obj.Object, "data", "enabled"); got != "yes" {
+		t.Errorf("data.enabled = %q, want the quoted string kept", got)
+	}
+}
+
+func TestDecodeConfigObjects(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: "three"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+    targetPorts: 8080
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  anything: goes
+`))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+
+	objects, findings := decodeConfigObjects(documents)
+
+	// The Deployment is dropped, the built-in kinds are typed and the custom kind is kept as is
+	var kinds []string
+	for _, obj := range objects {
+		kinds = append(kinds, fmt.Sprintf("%T", obj))
+	}
+	want := []string{"*v1.Service", "*v1.ConfigMap", "*unstructured.Unstructured"}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Errorf("decoded objects = %v, want %v", kinds, want)
+	}
+	if service, ok := objects[0].(*corev1.Service); !ok || service.Kind != "Service" || service.Spec.Ports[0].Port != 80 {
+		t.Errorf("Service = %+v, want its type and ports decoded", objects[0])
+	}
+
+	if len(findings) != 2 {
+		t.Fatalf("findings = %+v, want 2", findings)
+	}
+	if got := findings[0]; got.ErrorCode != "KOGARO-SYS-005" || got.ResourceType != "Deployment" || got.Severity != SeverityError ||
+		!strings.Contains(got.Message, "spec.replicas") {
+		t.Errorf("Deployment finding = %+v, want an invalid_config_object error", got)
+	}
+	if got := findings[1]; got.ErrorCode != "KOGARO-SYS-006" || got.ResourceType != "Service" || got.Severity != SeverityWarning ||
+		got.Details["fields"] != "spec.ports[0].targetPorts" {
+		t.Errorf("Service finding = %+v, want an unknown_config_field warning for spec.ports[0].targetPorts", got)
+	}
+}
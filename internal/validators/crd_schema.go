@@ -0,0 +1,127 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// bundledCRD is a CustomResourceDefinition defined in a config
+type bundledCRD struct {
+	name string
+	// versions maps the served versions of the CRD to their schema, which is nil
+	// when the version has none or its schema is not structural
+	versions map[string]*structuralschema.Structural
+}
+
+// customResourceSchemas holds the CustomResourceDefinitions of a config by the group and
+// kind they define, so that custom resources of the same config can be validated
+// before the CRDs are installed in any cluster
+type customResourceSchemas map[schema.GroupKind]bundledCRD
+
+// bundledCustomResourceSchemas collects the CustomResourceDefinitions of a config.
+// Definitions that cannot be read are left out, so their resources are not checked.
+func bundledCustomResourceSchemas(documents []configDocument) customResourceSchemas {
+	schemas := make(customResourceSchemas)
+	for _, document := range documents {
+		obj, ok := document.Object.(*unstructured.Unstructured)
+		if !ok || obj.GroupVersionKind() != apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition") {
+			continue
+		}
+		var crd apiextensionsv1.CustomResourceDefinition
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+			continue
+		}
+
+		definition := bundledCRD{name: crd.Name, versions: make(map[string]*structuralschema.Structural)}
+		for _, version := range crd.Spec.Versions {
+			if !version.Served {
+				continue
+			}
+			definition.versions[version.Name] = structuralSchema(version.Schema)
+		}
+		schemas[schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}] = definition
+	}
+	return schemas
+}
+
+// structuralSchema converts the schema of a CRD version, returning nil when it has
+// none or it is not structural, as the API server requires of v1 CRDs
+func structuralSchema(validation *apiextensionsv1.CustomResourceValidation) *structuralschema.Structural {
+	if validation == nil || validation.OpenAPIV3Schema == nil {
+		return nil
+	}
+	var internal apiextensions.JSONSchemaProps
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(validation.OpenAPIV3Schema, &internal, nil); err != nil {
+		return nil
+	}
+	structural, err := structuralschema.NewStructural(&internal)
+	if err != nil {
+		return nil
+	}
+	return structural
+}
+
+// validate checks a custom resource against its CustomResourceDefinition the way the API
+// server would on create: the version must be served, fields the schema does not
+// define are pruned, and what remains must satisfy the schema. Resources without a CRD
+// in the config are not checked.
+func (s customResourceSchemas) validate(obj *unstructured.Unstructured) []ValidationError {
+	gvk := obj.GroupVersionKind()
+	definition, ok := s[gvk.GroupKind()]
+	if !ok {
+		return nil
+	}
+
+	structural, served := definition.versions[gvk.Version]
+	if !served {
+		finding := NewValidationErrorWithCode(gvk.Kind, obj.GetName(), obj.GetNamespace(), "invalid_custom_resource", GetRegistryErrorCode("invalid_custom_resource"),
+			fmt.Sprintf("%s '%s' uses version %s, which CustomResourceDefinition '%s' does not serve", gvk.Kind, obj.GetName(), gvk.Version, definition.name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Use a version the CustomResourceDefinition serves, or add the version to it").
+			WithDetail("api_version", gvk.GroupVersion().String()).
+			WithDetail("custom_resource_definition", definition.name)
+		return []ValidationError{finding}
+	}
+	if structural == nil {
+		return nil
+	}
+
+	var findings []ValidationError
+	pruned := obj.DeepCopy()
+	unknownFields := pruning.PruneWithOptions(pruned.Object, structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+	if len(unknownFields) > 0 {
+		findings = append(findings, unknownFieldsFinding(obj, unknownFields).
+			WithDetail("custom_resource_definition", definition.name))
+	}
+
+	result := validate.NewSchemaValidator(structural.ToKubeOpenAPI(), nil, "", strfmt.Default).Validate(pruned.Object)
+	if result.IsValid() {
+		return findings
+	}
+	var problems []string
+	for _, err := range result.Errors {
+		problems = append(problems, err.Error())
+	}
+	finding := NewValidationErrorWithCode(gvk.Kind, obj.GetName(), obj.GetNamespace(), "invalid_custom_resource", GetRegistryErrorCode("invalid_custom_resource"),
+		fmt.Sprintf("%s '%s' does not match the schema of CustomResourceDefinition '%s': %s", gvk.Kind, obj.GetName(), definition.name, strings.Join(problems, "; "))).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Fix the resource to match the schema, or update the schema if the resource is right; the API server rejects the resource as it is").
+		WithDetail("api_version", gvk.GroupVersion().String()).
+		WithDetail("custom_resource_definition", definition.name)
+	return append(findings, finding)
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+const widgetCRD = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+spec:
+  group: example.com
+  scope: Namespaced
+  names:
+    kind: Widget
+    plural: widgets
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [size]
+            properties:
+              size:
+                type: integer
+                minimum: 1
+              color:
+                type: string
+                enum: [red, blue]
+              labels:
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+  - name: v1alpha1
+    served: false
+    storage: false
+    schema:
+      openAPIV3Schema:
+        type: object
+---
+`
+
+func TestCustomResourceSchemas_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		// want lists the findings as validation type: message fragment
+		want []string
+	}{
+		{
+			name: "valid resource",
+			resource: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  size: 3
+  color: red
+  labels:
+    anything: goes
+`,
+		},
+		{
+			name: "wrong type and enum",
+			resource: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  size: "three"
+  color: green
+`,
+			want: []string{"invalid_custom_resource: spec.size in body must be of type integer"},
+		},
+		{
+			name: "missing required field",
+			resource: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  color: blue
+`,
+			want: []string{"invalid_custom_resource: spec.size in body is required"},
+		},
+		{
+			name: "unknown fields",
+			resource: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+spec:
+  size: 2
+  colour: red
+`,
+			want: []string{"unknown_config_field: sets fields that example.com/v1 does not define: spec.colour"},
+		},
+		{
+			name: "version not served",
+			resource: `apiVersion: example.com/v1alpha1
+kind: Widget
+metadata:
+  name: gadget
+`,
+			want: []string{"invalid_custom_resource: uses version v1alpha1, which CustomResourceDefinition 'widgets.example.com' does not serve"},
+		},
+		{
+			name: "kind without a bundled CRD",
+			resource: `apiVersion: example.com/v1
+kind: Gizmo
+metadata:
+  name: gadget
+spec:
+  size: "three"
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := parseConfigDocuments([]byte(widgetCRD + tt.resource))
+			if err != nil {
+				t.Fatalf("parseConfigDocuments() error = %v", err)
+			}
+
+			objects, findings := decodeConfigObjects(documents)
+			if len(objects) != 2 {
+				t.Errorf("decoded %d objects, want the CRD and the resource", len(objects))
+			}
+			if len(findings) != len(tt.want) {
+				t.Fatalf("findings = %+v, want %v", findings, tt.want)
+			}
+			for i, want := range tt.want {
+				validationType, fragment, _ := strings.Cut(want, ": ")
+				if findings[i].ValidationType != validationType || !strings.Contains(findings[i].Message, fragment) {
+					t.Errorf("finding %d = %s: %s, want %s", i, findings[i].ValidationType, findings[i].Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCustomResourceSchemas_EnumAndMinimum(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(widgetCRD + `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: gadget
+  namespace: shop
+spec:
+  size: 0
+  color: green
+`))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+
+	_, findings := decodeConfigObjects(documents)
+	if len(findings) != 1 {
+		t.Fatalf("findings = %+v, want a single invalid_custom_resource", findings)
+	}
+	got := findings[0]
+	if got.ErrorCode != "KOGARO-SYS-007" || got.Severity != SeverityError || got.Namespace != "shop" ||
+		got.Details["custom_resource_definition"] != "widgets.example.com" {
+		t.Errorf("finding = %+v, want a KOGARO-SYS-007 error naming the CRD", got)
+	}
+	for _, fragment := range []string{"spec.size in body should be greater than or equal to 1", "spec.color in body should be one of [red blue]"} {
+		if !strings.Contains(got.Message, fragment) {
+			t.Errorf("message = %q, want it to contain %q", got.Message, fragment)
+		}
+	}
+}
@@ -0,0 +1,367 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides policy-as-code validation with CEL expressions.
+//
+// This package implements user-defined rules written in the Common Expression
+// Language (CEL). Each rule selects resources by apiVersion and kind, optionally
+// narrows them with namespaces and a match expression, and reports a finding
+// with the rule's own error code and severity whenever its expression does not
+// evaluate to true. Rules are compiled once when loaded and evaluated against
+// every scan of the cluster.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// customRuleCostLimit bounds the evaluation cost of a single rule expression so a
+// pathological rule cannot stall a scan
+const customRuleCostLimit = 1000000
+
+// CustomRuleFile is the document format for custom rules, read from a file or the
+// rules.yaml key of a ConfigMap
+type CustomRuleFile struct {
+	Rules []CustomRule `json:"rules"`
+}
+
+// CustomRule is a user-defined validation rule written in CEL. The expression is
+// evaluated with the resource bound to the variable `object` and must return true
+// for compliant resources.
+type CustomRule struct {
+	// Name uniquely identifies the rule
+	Name string `json:"name"`
+	// Description documents the intent of the rule
+	Description string `json:"description,omitempty"`
+	// Resources selects the kinds the rule applies to
+	Resources []CustomRuleResource `json:"resources"`
+	// Namespaces restricts the rule to the listed namespaces; all namespaces when empty
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Match is an optional CEL expression selecting the resources to check
+	Match string `json:"match,omitempty"`
+	// Expression is the CEL expression that must be true for compliant resources
+	Expression string `json:"expression"`
+	// Message describes a violation of the rule
+	Message string `json:"message"`
+	// ErrorCode is reported with every violation of the rule
+	ErrorCode string `json:"errorCode"`
+	// Severity of violations: error (default), warning or info
+	Severity Severity `json:"severity,omitempty"`
+	// RemediationHint describes how to fix a violation
+	RemediationHint string `json:"remediationHint,omitempty"`
+}
+
+// CustomRuleResource identifies a kind of resource by apiVersion and kind
+type CustomRuleResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// compiledCustomRule is a custom rule with its expressions compiled to programs
+type compiledCustomRule struct {
+	rule       CustomRule
+	kinds      []schema.GroupVersionKind
+	namespaces map[string]bool
+	match      cel.Program
+	expression cel.Program
+}
+
+// CustomRuleSet is a set of compiled custom rules ready for evaluation
+type CustomRuleSet struct {
+	rules []compiledCustomRule
+}
+
+// Len returns the number of rules in the set
+func (s *CustomRuleSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.rules)
+}
+
+// ParseCustomRules parses a custom rules document
+func ParseCustomRules(data []byte) ([]CustomRule, error) {
+	var file CustomRuleFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse custom rules: %w", err)
+	}
+	return file.Rules, nil
+}
+
+// CompileCustomRules validates and compiles custom rules. All problems are reported
+// together so a rules file can be fixed in one pass.
+func CompileCustomRules(rules []CustomRule) (*CustomRuleSet, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	set := &CustomRuleSet{}
+	var problems []string
+	names := make(map[string]bool)
+
+	for i, rule := range rules {
+		label := fmt.Sprintf("rule %d", i+1)
+		if rule.Name != "" {
+			label = fmt.Sprintf("rule %q", rule.Name)
+		}
+
+		compiled, ruleProblems := compileCustomRule(env, rule)
+		if rule.Name != "" && names[rule.Name] {
+			ruleProblems = append(ruleProblems, "duplicate rule name")
+		}
+		names[rule.Name] = true
+
+		for _, problem := range ruleProblems {
+			problems = append(problems, fmt.Sprintf("%s: %s", label, problem))
+		}
+		if len(ruleProblems) == 0 {
+			set.rules = append(set.rules, compiled)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid custom rules:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return set, nil
+}
+
+// compileCustomRule checks the fields of a rule and compiles its expressions
+func compileCustomRule(env *cel.Env, rule CustomRule) (compiledCustomRule, []string) {
+	var problems []string
+	compiled := compiledCustomRule{rule: rule}
+
+	if rule.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if rule.ErrorCode == "" {
+		problems = append(problems, "errorCode is required")
+	}
+	if rule.Message == "" {
+		problems = append(problems, "message is required")
+	}
+	switch rule.Severity {
+	case "":
+		compiled.rule.Severity = SeverityError
+	case SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		problems = append(problems, fmt.Sprintf("unknown severity %q", rule.Severity))
+	}
+
+	if len(rule.Resources) == 0 {
+		problems = append(problems, "at least one resource is required")
+	}
+	for _, resource := range rule.Resources {
+		gv, err := schema.ParseGroupVersion(resource.APIVersion)
+		if err != nil || resource.APIVersion == "" || resource.Kind == "" {
+			problems = append(problems, fmt.Sprintf("invalid resource %s/%s", resource.APIVersion, resource.Kind))
+			continue
+		}
+		compiled.kinds = append(compiled.kinds, gv.WithKind(resource.Kind))
+	}
+
+	if len(rule.Namespaces) > 0 {
+		compiled.namespaces = make(map[string]bool)
+		for _, namespace := range rule.Namespaces {
+			compiled.namespaces[namespace] = true
+		}
+	}
+
+	var err error
+	if rule.Expression == "" {
+		problems = append(problems, "expression is required")
+	} else if compiled.expression, err = compileCustomRuleExpression(env, rule.Expression); err != nil {
+		problems = append(problems, fmt.Sprintf("expression: %v", err))
+	}
+	if rule.Match != "" {
+		if compiled.match, err = compileCustomRuleExpression(env, rule.Match); err != nil {
+			problems = append(problems, fmt.Sprintf("match: %v", err))
+		}
+	}
+
+	return compiled, problems
+}
+
+// compileCustomRuleExpression compiles a CEL expression that must return a bool
+func compileCustomRuleExpression(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("must evaluate to bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast,
+		cel.CostLimit(customRuleCostLimit),
+		cel.InterruptCheckFrequency(100),
+	)
+}
+
+// CustomRuleConfig defines the custom rules to evaluate
+type CustomRuleConfig struct {
+	Rules *CustomRuleSet
+}
+
+// CustomRuleValidator evaluates user-defined CEL rules against cluster resources
+type CustomRuleValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               CustomRuleConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// NewCustomRuleValidator creates a new CustomRuleValidator with the given client, logger and config
+func NewCustomRuleValidator(client client.Client, log logr.Logger, config CustomRuleConfig) *CustomRuleValidator {
+	return &CustomRuleValidator{
+		client:       client,
+		log:          log.WithName("custom-rule-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *CustomRuleValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *CustomRuleValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *CustomRuleValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for custom rule validation
+func (v *CustomRuleValidator) GetValidationType() string {
+	return "custom_rule_validation"
+}
+
+// ValidateCluster evaluates every custom rule against the matching cluster resources
+func (v *CustomRuleValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	objects, err := v.listRuleResources(ctx)
+	if err != nil {
+		return err
+	}
+
+	var allErrors []ValidationError
+	if v.config.Rules != nil {
+		for _, rule := range v.config.Rules.rules {
+			for _, gvk := range rule.kinds {
+				for _, obj := range objects[gvk] {
+					if ve, violated := v.evaluateRule(ctx, rule, obj); violated {
+						allErrors = append(allErrors, ve)
+					}
+				}
+			}
+		}
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "custom_rule", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "custom_rule", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// listRuleResources lists each kind referenced by the rules once, so every rule is
+// evaluated against the same snapshot
+func (v *CustomRuleValidator) listRuleResources(ctx context.Context) (map[schema.GroupVersionKind][]unstructured.Unstructured, error) {
+	var kinds []schema.GroupVersionKind
+	if v.config.Rules != nil {
+		for _, rule := range v.config.Rules.rules {
+			kinds = append(kinds, rule.kinds...)
+		}
+	}
+	return listUnstructuredByKind(ctx, v.client, v.log, v.sharedConfig, kinds)
+}
+
+// evaluateRule checks a single resource against a rule, returning a finding when the
+// rule is violated or cannot be evaluated
+func (v *CustomRuleValidator) evaluateRule(ctx context.Context, rule compiledCustomRule, obj unstructured.Unstructured) (ValidationError, bool) {
+	if rule.namespaces != nil && !rule.namespaces[obj.GetNamespace()] {
+		return ValidationError{}, false
+	}
+
+	vars := map[string]interface{}{"object": obj.Object}
+	kind := obj.GetKind()
+
+	if rule.match != nil {
+		matched, err := evaluateCustomRuleProgram(ctx, rule.match, vars)
+		if err != nil {
+			return v.evaluationError(rule, obj, "match", err), true
+		}
+		if !matched {
+			return ValidationError{}, false
+		}
+	}
+
+	passed, err := evaluateCustomRuleProgram(ctx, rule.expression, vars)
+	if err != nil {
+		return v.evaluationError(rule, obj, "expression", err), true
+	}
+	if passed {
+		return ValidationError{}, false
+	}
+
+	ve := NewValidationErrorWithCode(kind, obj.GetName(), obj.GetNamespace(), "custom_rule_violation", rule.rule.ErrorCode,
+		fmt.Sprintf("Custom rule '%s' failed: %s", rule.rule.Name, rule.rule.Message)).
+		WithSeverity(rule.rule.Severity).
+		WithDetail("rule", rule.rule.Name).
+		WithDetail("expression", rule.rule.Expression)
+	if rule.rule.RemediationHint != "" {
+		ve = ve.WithRemediationHint(rule.rule.RemediationHint)
+	}
+	return ve, true
+}
+
+// evaluationError reports a rule that could not be evaluated against a resource,
+// typically because the expression accesses a field the resource does not set
+func (v *CustomRuleValidator) evaluationError(rule compiledCustomRule, obj unstructured.Unstructured, field string, err error) ValidationError {
+	errorCode := GetCustomRuleErrorCode("custom_rule_evaluation_failed")
+	return NewValidationErrorWithCode(obj.GetKind(), obj.GetName(), obj.GetNamespace(), "custom_rule_evaluation_failed", errorCode,
+		fmt.Sprintf("Custom rule '%s' could not be evaluated: %s %v", rule.rule.Name, field, err)).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Guard optional fields with has(), e.g. has(object.spec.field) && object.spec.field <= 5").
+		WithDetail("rule", rule.rule.Name)
+}
+
+// evaluateCustomRuleProgram runs a compiled expression and returns its boolean result
+func evaluateCustomRuleProgram(ctx context.Context, program cel.Program, vars map[string]interface{}) (bool, error) {
+	out, _, err := program.ContextEval(ctx, vars)
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("returned %v instead of a bool", out.Value())
+	}
+	return result, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testCustomRules = `rules:
+- name: prod-revision-history
+  description: Deployments in prod keep a short rollout history
+  resources:
+  - apiVersion: apps/v1
+    kind: Deployment
+  match: object.metadata.namespace.startsWith("prod")
+  expression: has(object.spec.revisionHistoryLimit) && object.spec.revisionHistoryLimit <= 5
+  message: Deployments in prod must set revisionHistoryLimit <= 5
+  errorCode: ACME-DEP-001
+  severity: warning
+  remediationHint: Set spec.revisionHistoryLimit to 5 or less
+- name: team-label
+  resources:
+  - apiVersion: apps/v1
+    kind: Deployment
+  - apiVersion: v1
+    kind: ConfigMap
+  namespaces: [payments]
+  expression: object.metadata.labels.team != ""
+  message: Resources must be labelled with their owning team
+  errorCode: ACME-META-001
+- name: widgets
+  resources:
+  - apiVersion: example.com/v1
+    kind: Widget
+  expression: "true"
+  message: unused
+  errorCode: ACME-WID-001
+`
+
+func TestCompileCustomRules(t *testing.T) {
+	rules, err := ParseCustomRules([]byte(testCustomRules))
+	if err != nil {
+		t.Fatalf("ParseCustomRules() error = %v", err)
+	}
+	set, err := CompileCustomRules(rules)
+	if err != nil {
+		t.Fatalf("CompileCustomRules() error = %v", err)
+	}
+	if set.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", set.Len())
+	}
+
+	invalid := []CustomRule{
+		{Name: "syntax", Resources: []CustomRuleResource{{APIVersion: "v1", Kind: "Pod"}}, Expression: "object.spec.", Message: "m", ErrorCode: "X-1"},
+		{Name: "not-bool", Resources: []CustomRuleResource{{APIVersion: "v1", Kind: "Pod"}}, Expression: "1 + 2", Message: "m", ErrorCode: "X-2"},
+		{Name: "missing-fields", Expression: "true", Severity: "fatal"},
+		{Name: "syntax", Resources: []CustomRuleResource{{APIVersion: "v1", Kind: "Pod"}}, Expression: "true", Message: "m", ErrorCode: "X-3"},
+	}
+	_, err = CompileCustomRules(invalid)
+	if err == nil {
+		t.Fatal("expected CompileCustomRules() to fail")
+	}
+	for _, want := range []string{
+		`rule "syntax": expression:`,
+		`rule "not-bool": expression: must evaluate to bool`,
+		`rule "missing-fields": errorCode is required`,
+		`rule "missing-fields": message is required`,
+		`rule "missing-fields": unknown severity "fatal"`,
+		`rule "missing-fields": at least one resource is required`,
+		`rule "syntax": duplicate rule name`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got:\n%v", want, err)
+		}
+	}
+
+	if _, err := ParseCustomRules([]byte("rules:\n- name: x\n  expresion: typo\n")); err == nil {
+		t.Error("expected unknown fields to be rejected")
+	}
+}
+
+func TestCustomRuleValidator_ValidateCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	limit := func(n int32) *int32 { return &n }
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "compliant", Namespace: "prod-eu"}, Spec: appsv1.DeploymentSpec{RevisionHistoryLimit: limit(3)}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "long-history", Namespace: "prod-eu"}, Spec: appsv1.DeploymentSpec{RevisionHistoryLimit: limit(10)}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "staging"}, Spec: appsv1.DeploymentSpec{RevisionHistoryLimit: limit(10)}},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "labelled", Namespace: "payments", Labels: map[string]string{"team": "billing"}}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "payments"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	rules, err := ParseCustomRules([]byte(testCustomRules))
+	if err != nil {
+		t.Fatalf("ParseCustomRules() error = %v", err)
+	}
+	set, err := CompileCustomRules(rules)
+	if err != nil {
+		t.Fatalf("CompileCustomRules() error = %v", err)
+	}
+
+	validator := NewCustomRuleValidator(fakeClient, logr.Discard(), CustomRuleConfig{Rules: set})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	findings := make(map[string]ValidationError)
+	for _, ve := range validator.GetLastValidationErrors() {
+		findings[ve.ResourceType+"/"+ve.ResourceName] = ve
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", validator.GetLastValidationErrors())
+	}
+
+	history, ok := findings["Deployment/long-history"]
+	if !ok {
+		t.Fatal("expected a finding for Deployment/long-history")
+	}
+	if history.ErrorCode != "ACME-DEP-001" || history.Severity != SeverityWarning || history.ValidationType != "custom_rule_violation" {
+		t.Errorf("unexpected finding: %+v", history)
+	}
+	if history.RemediationHint != "Set spec.revisionHistoryLimit to 5 or less" || history.Details["rule"] != "prod-revision-history" {
+		t.Errorf("unexpected finding details: %+v", history)
+	}
+
+	// The ConfigMap has no labels, so the expression cannot be evaluated
+	unlabelled, ok := findings["ConfigMap/unlabelled"]
+	if !ok {
+		t.Fatal("expected a finding for ConfigMap/unlabelled")
+	}
+	if unlabelled.ErrorCode != "KOGARO-CST-001" || unlabelled.Severity != SeverityWarning {
+		t.Errorf("unexpected evaluation failure finding: %+v", unlabelled)
+	}
+}
@@ -5,80 +5,413 @@
 
 package validators
 
+import (
+	"sort"
+	"strings"
+)
+
+// ErrorCodesDocURL is where the error codes are documented
+const ErrorCodesDocURL = "https://github.com/topiaruss/kogaro/blob/main/docs/ERROR-CODES.md"
+
 // ErrorCodeRegistry provides centralized error code mapping for all validators.
 // This eliminates scattered switch statements and provides a single source of truth.
 type ErrorCodeRegistry struct {
 	// Simple validation type → error code mappings
 	codes map[string]string
+	// Documentation of each error code, by code
+	catalog map[string]ErrorCodeInfo
 }
 
 // NewErrorCodeRegistry creates and initializes the error code registry.
 func NewErrorCodeRegistry() *ErrorCodeRegistry {
 	registry := &ErrorCodeRegistry{
-		codes: make(map[string]string),
+		codes:   make(map[string]string),
+		catalog: make(map[string]ErrorCodeInfo),
 	}
 	registry.registerAllCodes()
+	for code, controls := range complianceControlsByCode() {
+		if info, ok := registry.catalog[code]; ok {
+			info.Controls = controls
+			registry.catalog[code] = info
+		}
+	}
 	return registry
 }
 
+// register maps a key of the form category:validation_type, optionally followed by the
+// variant the code applies to, to an error code and records its documentation
+func (r *ErrorCodeRegistry) register(key string, info ErrorCodeInfo) {
+	parts := strings.SplitN(key, ":", 3)
+	info.Category = parts[0]
+	info.ValidationType = parts[1]
+	if category, ok := errorCodeCategories[codePrefix(info.Code)]; ok {
+		info.DocURL = ErrorCodesDocURL + "#" + category.anchor()
+	}
+	r.codes[key] = info.Code
+	r.catalog[info.Code] = info
+}
+
 // registerAllCodes registers all error codes from all validators.
 func (r *ErrorCodeRegistry) registerAllCodes() {
 	// Networking Validator (NET)
-	r.codes["networking:service_selector_mismatch"] = "KOGARO-NET-001"
-	r.codes["networking:service_no_endpoints"] = "KOGARO-NET-002"
-	r.codes["networking:service_port_mismatch"] = "KOGARO-NET-003"
-	r.codes["networking:pod_no_service"] = "KOGARO-NET-004"
-	r.codes["networking:network_policy_orphaned"] = "KOGARO-NET-005"
-	r.codes["networking:missing_network_policy_default_deny"] = "KOGARO-NET-006"
-	r.codes["networking:ingress_service_missing"] = "KOGARO-NET-007"
-	r.codes["networking:ingress_service_port_mismatch"] = "KOGARO-NET-008"
-	r.codes["networking:ingress_no_backend_pods"] = "KOGARO-NET-009"
+	r.register("networking:service_selector_mismatch", ErrorCodeInfo{Code: "KOGARO-NET-001", Severity: SeverityWarning, ResourceType: "Service",
+		Title: "Service selector does not match any pods", Checks: "spec.selector matches pod labels", Example: "Service selector {app:nonexistent-app} does not match any pods"})
+	r.register("networking:service_no_endpoints", ErrorCodeInfo{Code: "KOGARO-NET-002", Severity: SeverityError, ResourceType: "Service",
+		Title: "Service has no ready endpoints", Checks: "Service name matches Endpoints name", Example: "Service has no ready endpoints despite matching pods"})
+	r.register("networking:service_port_mismatch", ErrorCodeInfo{Code: "KOGARO-NET-003", Severity: SeverityError, ResourceType: "Service",
+		Title: "Service port does not match container ports", Checks: "spec.ports[].targetPort matches container ports", Example: "Service port (target: 9999) does not match any container ports in matching pods"})
+	r.register("networking:pod_no_service", ErrorCodeInfo{Code: "KOGARO-NET-004", Severity: SeverityInfo, ResourceType: "Pod",
+		Title: "Pod is not exposed by any Service", Checks: "Pod labels matched by any Service selector", Example: "Pod is not exposed by any Service (consider if this is intentional)"})
+	r.register("networking:network_policy_orphaned", ErrorCodeInfo{Code: "KOGARO-NET-005", Severity: SeverityWarning, ResourceType: "NetworkPolicy",
+		Title: "NetworkPolicy selector does not match any pods", Checks: "spec.podSelector matches pod labels", Example: "NetworkPolicy selector does not match any pods in namespace"})
+	r.register("networking:missing_network_policy_default_deny", ErrorCodeInfo{Code: "KOGARO-NET-006", Severity: SeverityWarning, ResourceType: "Namespace",
+		Title: "Namespace has NetworkPolicies but no default deny", Checks: "Namespace contains NetworkPolicies but no default deny", Example: "Namespace has NetworkPolicies but no default deny policy"})
+	r.register("networking:ingress_service_missing", ErrorCodeInfo{Code: "KOGARO-NET-007", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Ingress references non-existent service", Checks: "spec.rules[].http.paths[].backend.service.name", Example: "Ingress references non-existent service 'nonexistent-service'"})
+	r.register("networking:ingress_service_port_mismatch", ErrorCodeInfo{Code: "KOGARO-NET-008", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Ingress references service port that doesn't exist", Checks: "spec.rules[].http.paths[].backend.service.port", Example: "Ingress references service 'ingress-backend-service' port that doesn't exist"})
+	r.register("networking:ingress_no_backend_pods", ErrorCodeInfo{Code: "KOGARO-NET-009", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Ingress service has no ready backend pods", Checks: "Service backend has ready pods", Example: "Ingress service 'empty-backend-service' has no ready backend pods"})
+	r.register("networking:duplicate_service", ErrorCodeInfo{Code: "KOGARO-NET-010", Severity: SeverityWarning, ResourceType: "Service",
+		Title: "Service exposes the same selector and ports as another Service in the namespace", Checks: "spec.selector + spec.ports unique within namespace", Example: "Service 'web-old' exposes the same selector and ports as Service 'web'"})
+	r.register("networking:duplicate_ingress_rule", ErrorCodeInfo{Code: "KOGARO-NET-011", Severity: SeverityWarning, ResourceType: "Ingress",
+		Title: "Host and path already routed to the same backend by another Ingress of the same class in the namespace", Checks: "ingressClassName + rules[].host + paths[].path + backend unique within namespace", Example: "Ingress 'web-v2' routes host 'example.com' path '/' to the same backend as Ingress 'web'"})
+	r.register("networking:duplicate_network_policy", ErrorCodeInfo{Code: "KOGARO-NET-012", Severity: SeverityWarning, ResourceType: "NetworkPolicy",
+		Title: "Pod selector and rules already covered by another NetworkPolicy", Checks: "policyTypes + ingress/egress rules not covered by a policy with the same podSelector", Example: "NetworkPolicy 'allow-frontend' has the same pod selector as NetworkPolicy 'allow-all-sources', which already allows all of its rules"})
+	r.register("networking:ingress_host_collision", ErrorCodeInfo{Code: "KOGARO-NET-013", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Same host and path routed to a different backend by another Ingress of the same class, in any namespace", Checks: "ingressClassName + rules[].host + paths[].path -> one backend across namespaces", Example: "Ingress 'shop-preview' routes host 'shop.example.com' path '/' to a different backend than Ingress 'shop' in namespace 'team-a'"})
+	r.register("networking:ingress_wildcard_host_overlap", ErrorCodeInfo{Code: "KOGARO-NET-014", Severity: SeverityWarning, ResourceType: "Ingress",
+		Title: "Wildcard host overlaps another Ingress's host for the same path with a different backend", Checks: "rules[].host wildcard does not overlap another host + path with a different backend", Example: "Ingress 'catch-all' host '*.example.com' overlaps host 'shop.example.com' of Ingress 'shop' in namespace 'team-a' for path '/' with a different backend"})
+	r.register("networking:external_name_unresolvable", ErrorCodeInfo{Code: "KOGARO-NET-015", Severity: SeverityWarning, ResourceType: "Service",
+		Title: "ExternalName target does not resolve, or names a Service that does not exist (opt-in)", Checks: "spec.externalName resolves", Example: "ExternalName Service 'legacy' points to 'legacy.example.com', which does not resolve: lookup legacy.example.com: no such host"})
+	r.register("networking:dns_policy_none_without_config", ErrorCodeInfo{Code: "KOGARO-NET-016", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "dnsPolicy: None without dnsConfig nameservers", Checks: "spec.template.spec.dnsPolicy None -> dnsConfig.nameservers", Example: "Deployment 'no-dns' sets dnsPolicy None without dnsConfig nameservers"})
+	r.register("networking:host_alias_shadows_service", ErrorCodeInfo{Code: "KOGARO-NET-017", Severity: SeverityWarning, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "hostAliases hostname overrides the DNS name of a Service", Checks: "spec.template.spec.hostAliases[].hostnames != Service DNS name", Example: "hostAlias 'cache' -> 10.0.0.5 in Deployment 'web' shadows Service 'test-ns/cache'"})
+	r.register("networking:network_policy_blocks_dependency", ErrorCodeInfo{Code: "KOGARO-NET-018", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "NetworkPolicies allow no traffic to a Service the workload names in an environment variable", Checks: "spec.template.spec.containers[].env[].value Service name -> NetworkPolicies allow egress and ingress to its pods", Example: "Deployment 'web' references Service 'shop/db' in DATABASE_URL, but NetworkPolicies block its ingress traffic to every pod of the Service"})
+	r.register("networking:network_policy_egress_allow_all", ErrorCodeInfo{Code: "KOGARO-NET-019", Severity: SeverityWarning, ResourceType: "NetworkPolicy",
+		Title: "Egress rule allows traffic to any address (0.0.0.0/0 or ::/0)", Checks: "spec.egress[].to[].ipBlock.cidr not 0.0.0.0/0 or ::/0", Example: "NetworkPolicy egress rule 0 allows traffic to any address (0.0.0.0/0) on all ports"})
+	r.register("networking:network_policy_blocks_dns", ErrorCodeInfo{Code: "KOGARO-NET-020", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "NetworkPolicies block DNS queries to the cluster DNS pods", Checks: "NetworkPolicies allow egress to kube-system/kube-dns pods on 53/UDP", Example: "NetworkPolicies block DNS queries from Deployment 'web' to the cluster DNS pods (egress traffic on port 53)"})
+	r.register("networking:network_policy_namespace_selector_unmatched", ErrorCodeInfo{Code: "KOGARO-NET-021", Severity: SeverityWarning, ResourceType: "NetworkPolicy",
+		Title: "Rule namespaceSelector matches no namespace", Checks: "spec.ingress[].from[]/spec.egress[].to[].namespaceSelector matches namespace labels", Example: "NetworkPolicy egress rule 0 selects namespaces with 'team=paymnets', which matches no namespace"})
+	r.register("networking:network_policy_ipblock_overlaps_cluster", ErrorCodeInfo{Code: "KOGARO-NET-022", Severity: SeverityWarning, ResourceType: "NetworkPolicy",
+		Title: "ipBlock covers pod or Service addresses of the cluster", Checks: "spec.ingress[].from[]/spec.egress[].to[].ipBlock.cidr outside pod and Service addresses", Example: "NetworkPolicy egress rule 0 ipBlock 10.0.0.0/8 overlaps pod CIDR 10.244.0.0/24 of node worker-1"})
+	r.register("networking:service_local_traffic_policy_sparse", ErrorCodeInfo{Code: "KOGARO-NET-023", Severity: SeverityWarning, ResourceType: "Service",
+		Title: "externalTrafficPolicy: Local with ready pods on fewer than half of the nodes", Checks: "spec.externalTrafficPolicy Local -> ready pods on most nodes", Example: "Service has externalTrafficPolicy Local but ready pods on only 1 of 6 nodes; external traffic sent to the other nodes is dropped"})
+	r.register("networking:service_session_affinity_headless", ErrorCodeInfo{Code: "KOGARO-NET-024", Severity: SeverityWarning, ResourceType: "Service",
+		Title: "sessionAffinity: ClientIP on a headless Service has no effect", Checks: "spec.sessionAffinity with spec.clusterIP None", Example: "Service sets sessionAffinity ClientIP but is headless, so clients connect to pods directly and the affinity has no effect"})
+	r.register("networking:service_load_balancer_pending", ErrorCodeInfo{Code: "KOGARO-NET-025", Severity: SeverityError, ResourceType: "Service",
+		Title: "LoadBalancer Service has had no external address for over 10 minutes", Checks: "spec.type LoadBalancer -> status.loadBalancer.ingress", Example: "LoadBalancer Service has had no external address for 2h0m0s, and no LoadBalancer Service in the cluster has one, so the cluster appears to have no load balancer provider"})
+	r.register("networking:ingress_host_without_tls", ErrorCodeInfo{Code: "KOGARO-NET-026", Severity: SeverityWarning, ResourceType: "Ingress",
+		Title: "Ingress host is served without TLS (with --require-ingress-tls)", Checks: "rules[].host listed in spec.tls[].hosts", Example: "Ingress serves hosts without TLS: shop.example.com"})
+	r.register("networking:ingress_tls_host_without_rule", ErrorCodeInfo{Code: "KOGARO-NET-027", Severity: SeverityWarning, ResourceType: "Ingress",
+		Title: "TLS section lists a host that no rule routes", Checks: "spec.tls[].hosts routed by spec.rules[].host", Example: "Ingress TLS section 0 lists host 'shop.exmaple.com', which no rule of the Ingress routes"})
+	r.register("networking:ingress_tls_secret_wrong_type", ErrorCodeInfo{Code: "KOGARO-NET-028", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "TLS Secret is not of type kubernetes.io/tls", Checks: "spec.tls[].secretName type kubernetes.io/tls", Example: "Ingress TLS Secret 'shop-tls' has type 'Opaque', not 'kubernetes.io/tls'"})
+	r.register("networking:ingress_tls_certificate_host_mismatch", ErrorCodeInfo{Code: "KOGARO-NET-029", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Certificate subject alternative names do not cover the TLS hosts", Checks: "spec.tls[].hosts in certificate subject alternative names", Example: "Certificate in TLS Secret 'shop-tls' does not cover hosts: api.example.com"})
+	r.register("networking:ingress_path_overlap", ErrorCodeInfo{Code: "KOGARO-NET-030", Severity: SeverityWarning, ResourceType: "Ingress",
+		Title: "Path takes requests under another namespace's Prefix path for the same host to a different backend", Checks: "rules[].host + paths[].path not under a Prefix path of another namespace with a different backend", Example: "Ingress 'shop-beta' path '/api/v2' of host 'shop.example.com' takes requests under Prefix path '/api' of Ingress 'shop' in namespace 'team-a' to a different backend"})
 
 	// Security Validator (SEC)
-	r.codes["security:pod_running_as_root"] = "KOGARO-SEC-001"
-	r.codes["security:pod_allows_root_user"] = "KOGARO-SEC-002"
-	r.codes["security:container_running_as_root"] = "KOGARO-SEC-003"
-	r.codes["security:container_allows_privilege_escalation"] = "KOGARO-SEC-004"
-	r.codes["security:container_allows_privilege_escalation:privileged"] = "KOGARO-SEC-005"
-	r.codes["security:container_privileged_mode"] = "KOGARO-SEC-006"
-	r.codes["security:container_writable_root_filesystem"] = "KOGARO-SEC-007"
-	r.codes["security:container_additional_capabilities"] = "KOGARO-SEC-008"
-	r.codes["security:missing_pod_security_context"] = "KOGARO-SEC-009"
-	r.codes["security:missing_container_security_context"] = "KOGARO-SEC-010"
-	r.codes["security:serviceaccount_cluster_role_binding"] = "KOGARO-SEC-011"
-	r.codes["security:serviceaccount_excessive_permissions"] = "KOGARO-SEC-012"
+	r.register("security:pod_running_as_root", ErrorCodeInfo{Code: "KOGARO-SEC-001", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Pod SecurityContext specifies runAsUser: 0 (root)", Checks: "spec.template.spec.securityContext.runAsUser", Example: "Pod SecurityContext specifies runAsUser: 0 (root)"})
+	r.register("security:pod_allows_root_user", ErrorCodeInfo{Code: "KOGARO-SEC-002", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Pod SecurityContext does not enforce runAsNonRoot: true", Checks: "spec.template.spec.securityContext.runAsNonRoot", Example: "Pod SecurityContext does not enforce runAsNonRoot: true"})
+	r.register("security:container_running_as_root", ErrorCodeInfo{Code: "KOGARO-SEC-003", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container SecurityContext specifies runAsUser: 0 (root)", Checks: "spec.template.spec.containers[].securityContext.runAsUser", Example: "Container 'root-container' (container) SecurityContext specifies runAsUser: 0 (root)"})
+	r.register("security:container_allows_privilege_escalation", ErrorCodeInfo{Code: "KOGARO-SEC-004", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container does not set allowPrivilegeEscalation: false", Checks: "spec.template.spec.containers[].securityContext.allowPrivilegeEscalation", Example: "Container 'root-container' (container) SecurityContext does not set allowPrivilegeEscalation: false"})
+	r.register("security:container_allows_privilege_escalation:privileged", ErrorCodeInfo{Code: "KOGARO-SEC-005", Severity: SeverityError, ResourceType: "Container",
+		Title: "Privileged container does not set allowPrivilegeEscalation: false", Checks: "spec.template.spec.containers[].securityContext.allowPrivilegeEscalation", Example: "Container 'privileged-container' (container) SecurityContext does not set allowPrivilegeEscalation: false"})
+	r.register("security:container_privileged_mode", ErrorCodeInfo{Code: "KOGARO-SEC-006", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container SecurityContext specifies privileged: true", Checks: "spec.template.spec.containers[].securityContext.privileged", Example: "Container 'privileged-container' (container) SecurityContext specifies privileged: true"})
+	r.register("security:container_writable_root_filesystem", ErrorCodeInfo{Code: "KOGARO-SEC-007", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container does not set readOnlyRootFilesystem: true", Checks: "spec.template.spec.containers[].securityContext.readOnlyRootFilesystem", Example: "Container 'privileged-container' (container) SecurityContext does not set readOnlyRootFilesystem: true"})
+	r.register("security:container_additional_capabilities", ErrorCodeInfo{Code: "KOGARO-SEC-008", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container SecurityContext adds dangerous capabilities", Checks: "spec.template.spec.containers[].securityContext.capabilities.add", Example: "Container 'privileged-container' (container) SecurityContext adds capability: NET_ADMIN"})
+	r.register("security:missing_pod_security_context", ErrorCodeInfo{Code: "KOGARO-SEC-009", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Pod has no SecurityContext defined", Checks: "spec.template.spec.securityContext", Example: "Pod has no SecurityContext defined"})
+	r.register("security:missing_container_security_context", ErrorCodeInfo{Code: "KOGARO-SEC-010", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container has no SecurityContext defined", Checks: "spec.template.spec.containers[].securityContext", Example: "Container 'no-security-container' (container) has no SecurityContext defined"})
+	r.register("security:serviceaccount_cluster_role_binding", ErrorCodeInfo{Code: "KOGARO-SEC-011", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "ServiceAccount has excessive ClusterRoleBinding", Checks: "subjects[].name matches ServiceAccount", Example: "ServiceAccount has ClusterRoleBinding 'admin-service-account-binding' with role 'cluster-admin'"})
+	r.register("security:serviceaccount_excessive_permissions", ErrorCodeInfo{Code: "KOGARO-SEC-012", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "ServiceAccount has potentially excessive RoleBinding", Checks: "subjects[].name matches ServiceAccount", Example: "ServiceAccount has potentially excessive RoleBinding 'admin-role-binding' with role 'admin'"})
+	r.register("security:serviceaccount_wildcard_permissions", ErrorCodeInfo{Code: "KOGARO-SEC-013", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "Bound role grants * verbs or resources", Checks: "rules[].verbs or rules[].resources contains '*'", Example: "ServiceAccount is granted wildcard permissions in namespace shop by Role 'deployer' bound by RoleBinding 'deployer': * on deployments.apps"})
+	r.register("security:serviceaccount_cluster_secrets_access", ErrorCodeInfo{Code: "KOGARO-SEC-014", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "ClusterRoleBinding grants read access to Secrets in every namespace", Checks: "ClusterRoleBinding rules grant get/list/watch on secrets", Example: "ServiceAccount can get, list, watch Secrets in every namespace through ClusterRole 'secret-reader' bound by ClusterRoleBinding 'secret-reader'"})
+	r.register("security:serviceaccount_privilege_escalation_verbs", ErrorCodeInfo{Code: "KOGARO-SEC-015", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "Bound role grants the escalate, bind or impersonate verbs", Checks: "rules[].verbs contains escalate/bind/impersonate", Example: "ServiceAccount is granted the bind, escalate verbs in namespace shop by Role 'rbac-manager' bound by RoleBinding 'rbac-manager', which let it gain permissions beyond its own"})
+	r.register("security:serviceaccount_pod_exec_permissions", ErrorCodeInfo{Code: "KOGARO-SEC-016", Severity: SeverityWarning, ResourceType: "ServiceAccount",
+		Title: "Bound role allows creating pods or exec into pods (Warning)", Checks: "rules grant create on pods or pods/exec", Example: "ServiceAccount can create pods and exec into pods in namespace shop through Role 'debugger' bound by RoleBinding 'debugger'"})
+	r.register("security:clusterrole_aggregation_matches_nothing", ErrorCodeInfo{Code: "KOGARO-SEC-017", Severity: SeverityWarning, ResourceType: "ClusterRole",
+		Title: "aggregationRule selectors match no ClusterRole (Warning)", Checks: "aggregationRule.clusterRoleSelectors match the labels of a ClusterRole", Example: "ClusterRole aggregation selectors match no ClusterRole, so it grants no permissions"})
+	r.register("security:clusterrole_aggregation_loop", ErrorCodeInfo{Code: "KOGARO-SEC-018", Severity: SeverityWarning, ResourceType: "ClusterRole",
+		Title: "ClusterRole aggregates itself, directly or through other aggregated ClusterRoles (Warning)", Checks: "aggregationRule.clusterRoleSelectors do not match the ClusterRole itself", Example: "ClusterRole aggregates itself: monitoring -> monitoring-extra -> monitoring"})
+	r.register("security:unused_role", ErrorCodeInfo{Code: "KOGARO-SEC-019", Severity: SeverityInfo, ResourceType: "Role",
+		Title: "Role is not referenced by any RoleBinding (Info)", Checks: "roleRef.name of a RoleBinding in the namespace", Example: "Role is not referenced by any RoleBinding"})
+	r.register("security:binding_unverifiable_subject", ErrorCodeInfo{Code: "KOGARO-SEC-020", Severity: SeverityInfo, ResourceType: "RoleBinding/ClusterRoleBinding",
+		Title: "Binding grants its role to users or groups that cannot be verified in the cluster (Info)", Checks: "subjects[].kind User or Group", Example: "RoleBinding grants its role to subjects that cannot be verified in the cluster: User/alice@example.com, Group/contractors"})
+	r.register("security:host_path_sensitive_mount", ErrorCodeInfo{Code: "KOGARO-SEC-021", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "hostPath volume mounts /, /etc, a container runtime socket or another sensitive host path", Checks: "spec.volumes[].hostPath.path is not a sensitive host path", Example: "Volume 'docker' mounts the sensitive host path /var/run/docker.sock"})
+	r.register("security:host_namespace_sharing", ErrorCodeInfo{Code: "KOGARO-SEC-022", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Pod",
+		Title: "hostNetwork, hostPID or hostIPC outside --host-namespace-allowed-namespaces", Checks: "spec.hostNetwork/hostPID/hostIPC outside --host-namespace-allowed-namespaces", Example: "Pod shares the host's network, PID namespaces"})
+	r.register("security:host_port_collision", ErrorCodeInfo{Code: "KOGARO-SEC-023", Severity: SeverityError, ResourceType: "DaemonSet",
+		Title: "Another DaemonSet claims the same host port on the same nodes", Checks: "spec.template.spec.containers[].ports[].hostPort unique across DaemonSets", Example: "Host port 9100/TCP is also claimed by DaemonSet/metrics/node-exporter, so their pods cannot run on the same node"})
+	r.register("security:container_capabilities_not_dropped", ErrorCodeInfo{Code: "KOGARO-SEC-024", Severity: SeverityWarning, ResourceType: "Container",
+		Title: "Container does not set capabilities.drop: [\"ALL\"] (Warning; skipped by the standard and relaxed profiles)", Checks: "spec.template.spec.containers[].securityContext.capabilities.drop contains ALL", Example: "Container 'app' (container) SecurityContext does not drop all capabilities"})
+	r.register("security:pod_unsafe_sysctl", ErrorCodeInfo{Code: "KOGARO-SEC-025", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Pod SecurityContext sets a sysctl outside the Kubernetes safe set", Checks: "spec.template.spec.securityContext.sysctls[].name in the safe sysctl set", Example: "Pod SecurityContext sets unsafe sysctl kernel.msgmax=65536"})
+	r.register("security:container_unmasked_proc_mount", ErrorCodeInfo{Code: "KOGARO-SEC-026", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container SecurityContext sets procMount to Unmasked", Checks: "spec.template.spec.containers[].securityContext.procMount = Default", Example: "Container 'app' (container) SecurityContext specifies procMount: Unmasked"})
+	r.register("security:missing_network_policy_security_sensitive", ErrorCodeInfo{Code: "KOGARO-SEC-027", Severity: SeverityError, ResourceType: "Namespace",
+		Title: "Namespace required to have NetworkPolicies has none", Checks: "namespace selected by --security-required-namespaces or --networking-required-namespaces has NetworkPolicies", Example: "Namespace 'payments' requires NetworkPolicies but has none defined"})
+	r.register("security:missing_network_policy_production", ErrorCodeInfo{Code: "KOGARO-SEC-028", Severity: SeverityError, ResourceType: "Namespace",
+		Title: "Production-like namespace has no NetworkPolicies", Checks: "production-like namespace has NetworkPolicies", Example: "Production-like namespace 'prod' has no NetworkPolicies defined"})
 
 	// Resource Limits Validator (RES)
-	r.codes["resource_limits:missing_resource_requests:Deployment"] = "KOGARO-RES-001"
-	r.codes["resource_limits:missing_resource_requests:StatefulSet"] = "KOGARO-RES-002"
-	r.codes["resource_limits:missing_resource_limits:Deployment:no_requests"] = "KOGARO-RES-003"
-	r.codes["resource_limits:missing_resource_limits:Deployment:has_requests"] = "KOGARO-RES-004"
-	r.codes["resource_limits:missing_resource_limits:StatefulSet"] = "KOGARO-RES-005"
-	r.codes["resource_limits:insufficient_cpu_request"] = "KOGARO-RES-006"
-	r.codes["resource_limits:insufficient_memory_request"] = "KOGARO-RES-007"
-	r.codes["resource_limits:qos_class_issue:Deployment:BestEffort"] = "KOGARO-RES-008"
-	r.codes["resource_limits:qos_class_issue:StatefulSet:BestEffort"] = "KOGARO-RES-009"
-	r.codes["resource_limits:qos_class_issue:Deployment:Burstable"] = "KOGARO-RES-010"
+	r.register("resource_limits:missing_resource_requests:Deployment", ErrorCodeInfo{Code: "KOGARO-RES-001", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "Container has no resource requests defined", Checks: "spec.template.spec.containers[].resources.requests", Example: "Container 'test-container' has no resource requests defined"})
+	r.register("resource_limits:missing_resource_requests:StatefulSet", ErrorCodeInfo{Code: "KOGARO-RES-002", Severity: SeverityError, ResourceType: "StatefulSet",
+		Title: "Container has no resource requests defined", Checks: "spec.template.spec.containers[].resources.requests", Example: "Container 'test-container' has no resource requests defined"})
+	r.register("resource_limits:missing_resource_limits:Deployment:no_requests", ErrorCodeInfo{Code: "KOGARO-RES-003", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "Container has no resource limits (no requests either)", Checks: "spec.template.spec.containers[].resources.limits", Example: "Container 'test-container' has no resource limits defined"})
+	r.register("resource_limits:missing_resource_limits:Deployment:has_requests", ErrorCodeInfo{Code: "KOGARO-RES-004", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "Container has no resource limits (has requests)", Checks: "spec.template.spec.containers[].resources.limits", Example: "Container 'test-container' has no resource limits defined"})
+	r.register("resource_limits:missing_resource_limits:StatefulSet", ErrorCodeInfo{Code: "KOGARO-RES-005", Severity: SeverityError, ResourceType: "StatefulSet",
+		Title: "Container has no resource limits defined", Checks: "spec.template.spec.containers[].resources.limits", Example: "Container 'test-container' has no resource limits defined"})
+	r.register("resource_limits:insufficient_cpu_request", ErrorCodeInfo{Code: "KOGARO-RES-006", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "Container CPU request below minimum threshold", Checks: "spec.template.spec.containers[].resources.requests.cpu", Example: "Container 'test-container' CPU request 1m is below minimum 10m"})
+	r.register("resource_limits:insufficient_memory_request", ErrorCodeInfo{Code: "KOGARO-RES-007", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "Container memory request below minimum threshold", Checks: "spec.template.spec.containers[].resources.requests.memory", Example: "Container 'test-container' memory request 1Mi is below minimum 16Mi"})
+	r.register("resource_limits:qos_class_issue:Deployment:BestEffort", ErrorCodeInfo{Code: "KOGARO-RES-008", Severity: SeverityError, ResourceType: "Deployment",
+		Title: "BestEffort QoS: no resource constraints", Checks: "spec.template.spec.containers[].resources (QoS analysis)", Example: "Container 'test-container': BestEffort QoS: no resource constraints can be killed first under pressure"})
+	r.register("resource_limits:qos_class_issue:StatefulSet:BestEffort", ErrorCodeInfo{Code: "KOGARO-RES-009", Severity: SeverityError, ResourceType: "StatefulSet",
+		Title: "BestEffort QoS: no resource constraints", Checks: "spec.template.spec.containers[].resources (QoS analysis)", Example: "Container 'test-container': BestEffort QoS: no resource constraints can be killed first under pressure"})
+	r.register("resource_limits:qos_class_issue:Deployment:Burstable", ErrorCodeInfo{Code: "KOGARO-RES-010", Severity: SeverityWarning, ResourceType: "Deployment",
+		Title: "Burstable QoS: requests != limits", Checks: "spec.template.spec.containers[].resources (QoS analysis)", Example: "Container 'test-container': Burstable QoS: requests != limits may face throttling under pressure"})
+	r.register("resource_limits:request_exceeds_node_capacity", ErrorCodeInfo{Code: "KOGARO-RES-011", Severity: SeverityError, ResourceType: "Workload",
+		Title: "Pod requests fit on no schedulable node", Checks: "pod requests <= status.allocatable of a node matching spec.nodeSelector", Example: "Pod requests (cpu 16, memory 8Gi) fit on none of the 3 candidate nodes; the largest allocatable is cpu 8, memory 32Gi"})
+	r.register("resource_limits:limit_exceeds_node_capacity", ErrorCodeInfo{Code: "KOGARO-RES-012", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Container limit exceeds the allocatable capacity of every node", Checks: "spec.containers[].resources.limits <= largest node status.allocatable", Example: "Container 'app' memory limit 64Gi exceeds the allocatable memory of every node (largest 32Gi)"})
+	r.register("resource_limits:namespace_requests_exceed_capacity", ErrorCodeInfo{Code: "KOGARO-RES-013", Severity: SeverityWarning, ResourceType: "Namespace",
+		Title: "Namespace requests more than the allocatable capacity of all nodes", Checks: "sum of requests x replicas <= total node status.allocatable", Example: "Workloads in namespace 'batch' request more than the allocatable capacity of all 3 schedulable nodes: cpu 30 of 24"})
+	r.register("resource_limits:limit_request_ratio_exceeded", ErrorCodeInfo{Code: "KOGARO-RES-014", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Limit is more than the configured ratio times the request", Checks: "spec.containers[].resources.limits / requests <= max ratio", Example: "Container 'app' cpu limit 2 is 20.0x its request 100m, above the maximum ratio of 10"})
+	r.register("resource_limits:memory_limit_without_request", ErrorCodeInfo{Code: "KOGARO-RES-015", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Memory limit without a memory request, which defaults to the limit", Checks: "spec.containers[].resources.limits.memory requires requests.memory", Example: "Container 'app' sets a memory limit of 1Gi without a memory request, so the request defaults to the full limit"})
+	r.register("resource_limits:cpu_limit_forbidden", ErrorCodeInfo{Code: "KOGARO-RES-016", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "CPU limit set while --forbid-cpu-limits is enabled", Checks: "spec.containers[].resources.limits.cpu unset (with --forbid-cpu-limits)", Example: "Container 'app' sets a CPU limit of 500m, but CPU limits are not used in this cluster"})
+	r.register("resource_limits:request_deviates_from_vpa_recommendation", ErrorCodeInfo{Code: "KOGARO-RES-017", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Request deviates from the VerticalPodAutoscaler recommendation by more than --vpa-deviation-percent", Checks: "spec.containers[].resources.requests deviates from VerticalPodAutoscaler status.recommendation target (updateMode Off or Initial)", Example: "Container 'app' requests deviate from VerticalPodAutoscaler 'api' by up to 300%: cpu request 2 vs recommended 500m"})
+	r.register("resource_limits:usage_near_limit", ErrorCodeInfo{Code: "KOGARO-RES-018", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Container usage reported by metrics-server reaches --usage-limit-threshold of its limit", Checks: "metrics.k8s.io pod usage >= --usage-limit-threshold of spec.containers[].resources.limits", Example: "Container 'app' memory usage of 950Mi across 2 pods is 93% of its 1Gi limit"})
+	r.register("resource_limits:request_far_above_usage", ErrorCodeInfo{Code: "KOGARO-RES-019", Severity: SeverityInfo, ResourceType: "Workload",
+		Title: "Request is more than --usage-waste-ratio times the usage reported by metrics-server", Checks: "spec.containers[].resources.requests > --usage-waste-ratio times metrics.k8s.io pod usage", Example: "Container 'app' requests 2 cpu but uses at most 50m across 2 pods"})
+	r.register("resource_limits:container_oom_killed", ErrorCodeInfo{Code: "KOGARO-RES-020", Severity: SeverityError, ResourceType: "Workload",
+		Title: "Container OOM-killed within --oom-kill-window", Checks: "status.containerStatuses[].lastState.terminated.reason OOMKilled within --oom-kill-window", Example: "Container 'app' was OOMKilled in 2 of 2 pods in the last 24h (7 restarts) and has a 64Mi memory limit"})
+	r.register("resource_limits:container_restart_loop", ErrorCodeInfo{Code: "KOGARO-RES-021", Severity: SeverityWarning, ResourceType: "Workload",
+		Title: "Container restarted at least --restart-threshold times across the workload's pods", Checks: "sum of status.containerStatuses[].restartCount >= --restart-threshold", Example: "Container 'app' restarted 9 times across 1 pod"})
 
 	// Reference Validator (REF)
-	r.codes["reference:dangling_ingress_class"] = "KOGARO-REF-001"
-	r.codes["reference:dangling_service_reference"] = "KOGARO-REF-002"
-	r.codes["reference:dangling_configmap_volume"] = "KOGARO-REF-003"
-	r.codes["reference:dangling_configmap_envfrom"] = "KOGARO-REF-004"
-	r.codes["reference:dangling_secret_volume"] = "KOGARO-REF-005"
-	r.codes["reference:dangling_secret_envfrom"] = "KOGARO-REF-006"
-	r.codes["reference:dangling_secret_env"] = "KOGARO-REF-007"
-	r.codes["reference:dangling_tls_secret"] = "KOGARO-REF-008"
-	r.codes["reference:dangling_storage_class"] = "KOGARO-REF-009"
-	r.codes["reference:dangling_pvc_reference"] = "KOGARO-REF-010"
-	r.codes["reference:dangling_service_account"] = "KOGARO-REF-011"
+	r.register("reference:dangling_ingress_class", ErrorCodeInfo{Code: "KOGARO-REF-001", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "IngressClass referenced but does not exist", Checks: "spec.ingressClassName", Example: "IngressClass 'nonexistent-class' does not exist"})
+	r.register("reference:dangling_service_reference", ErrorCodeInfo{Code: "KOGARO-REF-002", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "Service referenced in Ingress does not exist", Checks: "spec.rules[].http.paths[].backend.service.name", Example: "Service 'nonexistent-service' referenced in Ingress does not exist"})
+	r.register("reference:dangling_configmap_volume", ErrorCodeInfo{Code: "KOGARO-REF-003", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ConfigMap referenced in volume does not exist", Checks: "spec.volumes[].configMap.name", Example: "ConfigMap 'missing-configmap' referenced in volume does not exist"})
+	r.register("reference:dangling_configmap_envfrom", ErrorCodeInfo{Code: "KOGARO-REF-004", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ConfigMap referenced in envFrom does not exist", Checks: "spec.containers[].envFrom[].configMapRef.name", Example: "ConfigMap 'nonexistent-config' referenced in envFrom does not exist"})
+	r.register("reference:dangling_secret_volume", ErrorCodeInfo{Code: "KOGARO-REF-005", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Secret referenced in volume does not exist", Checks: "spec.volumes[].secret.secretName", Example: "Secret 'missing-secret' referenced in volume does not exist"})
+	r.register("reference:dangling_secret_envfrom", ErrorCodeInfo{Code: "KOGARO-REF-006", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Secret referenced in envFrom does not exist", Checks: "spec.containers[].envFrom[].secretRef.name", Example: "Secret 'missing-env-secret' referenced in envFrom does not exist"})
+	r.register("reference:dangling_secret_env", ErrorCodeInfo{Code: "KOGARO-REF-007", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Secret referenced in env does not exist", Checks: "spec.containers[].env[].valueFrom.secretKeyRef.name", Example: "Secret 'missing-secret-key' referenced in env does not exist"})
+	r.register("reference:dangling_tls_secret", ErrorCodeInfo{Code: "KOGARO-REF-008", Severity: SeverityError, ResourceType: "Ingress",
+		Title: "TLS Secret referenced in Ingress does not exist", Checks: "spec.tls[].secretName", Example: "TLS Secret 'missing-tls-secret' referenced in Ingress does not exist"})
+	r.register("reference:dangling_storage_class", ErrorCodeInfo{Code: "KOGARO-REF-009", Severity: SeverityError, ResourceType: "PVC",
+		Title: "StorageClass referenced but does not exist", Checks: "spec.storageClassName", Example: "StorageClass 'nonexistent-storage' does not exist"})
+	r.register("reference:dangling_pvc_reference", ErrorCodeInfo{Code: "KOGARO-REF-010", Severity: SeverityError, ResourceType: "Pod",
+		Title: "PVC referenced in volume does not exist", Checks: "spec.volumes[].persistentVolumeClaim.claimName", Example: "PVC 'missing-pvc' referenced in volume does not exist"})
+	r.register("reference:dangling_service_account", ErrorCodeInfo{Code: "KOGARO-REF-011", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ServiceAccount referenced but does not exist", Checks: "spec.serviceAccountName", Example: "ServiceAccount 'missing-sa' does not exist"})
+	r.register("reference:dangling_configmap_env", ErrorCodeInfo{Code: "KOGARO-REF-012", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ConfigMap referenced in env configMapKeyRef does not exist", Checks: "spec.containers[].env[].valueFrom.configMapKeyRef.name", Example: "ConfigMap 'missing-config' referenced in env does not exist"})
+	r.register("reference:missing_configmap_key", ErrorCodeInfo{Code: "KOGARO-REF-013", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Key referenced in configMapKeyRef or volume items does not exist in ConfigMap", Checks: "spec.containers[].env[].valueFrom.configMapKeyRef.key / spec.volumes[].configMap.items[].key", Example: "Key 'log-format' referenced in env does not exist in ConfigMap 'app-config'"})
+	r.register("reference:missing_secret_key", ErrorCodeInfo{Code: "KOGARO-REF-014", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Key referenced in secretKeyRef or volume items does not exist in Secret", Checks: "spec.containers[].env[].valueFrom.secretKeyRef.key / spec.volumes[].secret.items[].key", Example: "Key 'password' referenced in env does not exist in Secret 'db-credentials'"})
+	r.register("reference:unused_configmap", ErrorCodeInfo{Code: "KOGARO-REF-015", Severity: SeverityInfo, ResourceType: "ConfigMap",
+		Title: "ConfigMap is not referenced by any workload (info)", Checks: "ConfigMap referenced by a pod or workload template volume/envFrom/env", Example: "ConfigMap 'legacy-config' is possibly unused: no workload references it in a volume or envFrom or env entry"})
+	r.register("reference:unused_secret", ErrorCodeInfo{Code: "KOGARO-REF-016", Severity: SeverityInfo, ResourceType: "Secret",
+		Title: "Secret is not referenced by any workload, Ingress or ServiceAccount (info)", Checks: "Secret referenced by a workload; Ingress TLS or ServiceAccount", Example: "Secret 'legacy-token' is possibly unused: no workload, Ingress or ServiceAccount references it"})
+	r.register("reference:unused_pvc", ErrorCodeInfo{Code: "KOGARO-REF-017", Severity: SeverityInfo, ResourceType: "PVC",
+		Title: "PVC is not mounted by any workload (info)", Checks: "PVC mounted by a workload or created from a StatefulSet volumeClaimTemplate", Example: "PersistentVolumeClaim 'scratch' is possibly unused: no workload mounts it"})
+	r.register("reference:unused_serviceaccount", ErrorCodeInfo{Code: "KOGARO-REF-018", Severity: SeverityInfo, ResourceType: "ServiceAccount",
+		Title: "ServiceAccount is not used by any workload or binding (info)", Checks: "ServiceAccount used by a workload or bound by a RoleBinding/ClusterRoleBinding", Example: "ServiceAccount 'ci' is possibly unused: no workload runs as it and no RoleBinding or ClusterRoleBinding grants it permissions"})
+	r.register("reference:dangling_projected_configmap", ErrorCodeInfo{Code: "KOGARO-REF-019", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ConfigMap referenced in a projected volume does not exist", Checks: "spec.volumes[].projected.sources[].configMap.name", Example: "ConfigMap 'missing-config' referenced in projected volume does not exist"})
+	r.register("reference:dangling_projected_secret", ErrorCodeInfo{Code: "KOGARO-REF-020", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Secret referenced in a projected volume does not exist", Checks: "spec.volumes[].projected.sources[].secret.name", Example: "Secret 'missing-secret' referenced in projected volume does not exist"})
+	r.register("reference:dangling_projected_token_service_account", ErrorCodeInfo{Code: "KOGARO-REF-021", Severity: SeverityError, ResourceType: "Pod",
+		Title: "ServiceAccount whose token is projected into a volume does not exist", Checks: "spec.volumes[].projected.sources[].serviceAccountToken / spec.serviceAccountName", Example: "ServiceAccount 'missing-sa' whose token is projected into volume 'token' does not exist"})
+	r.register("reference:dangling_secret_provider_class", ErrorCodeInfo{Code: "KOGARO-REF-022", Severity: SeverityError, ResourceType: "Pod",
+		Title: "SecretProviderClass mounted by a secrets-store CSI volume does not exist", Checks: "spec.volumes[].csi.volumeAttributes.secretProviderClass (driver secrets-store.csi.k8s.io)", Example: "SecretProviderClass 'vault-db' referenced in CSI volume does not exist"})
+	r.register("reference:dangling_image_pull_secret", ErrorCodeInfo{Code: "KOGARO-REF-023", Severity: SeverityError, ResourceType: "Pod",
+		Title: "Secret listed in imagePullSecrets does not exist", Checks: "spec.imagePullSecrets[].name", Example: "Image pull Secret 'registry-credentials' does not exist"})
+	r.register("reference:dangling_field_ref", ErrorCodeInfo{Code: "KOGARO-REF-024", Severity: SeverityWarning, ResourceType: "Pod",
+		Title: "Downward API fieldRef uses an unsupported path or selects a label or annotation the pod does not carry", Checks: "spec.containers[].env[].valueFrom.fieldRef.fieldPath / spec.volumes[].downwardAPI.items[].fieldRef.fieldPath", Example: "Env var 'TEAM' in container 'app' selects label 'tema', which is not set on the pod"})
+	r.register("reference:dangling_resource_field_ref", ErrorCodeInfo{Code: "KOGARO-REF-025", Severity: SeverityWarning, ResourceType: "Pod",
+		Title: "Downward API resourceFieldRef selects a missing container or a resource the container does not set", Checks: "spec.containers[].env[].valueFrom.resourceFieldRef / spec.volumes[].downwardAPI.items[].resourceFieldRef", Example: "Env var 'MEM_LIMIT' in container 'app' selects 'limits.memory' of container 'app', which sets no memory limit"})
 
 	// Image Validator (IMG)
-	r.codes["image:invalid_image_reference"] = "KOGARO-IMG-001"
-	r.codes["image:missing_image"] = "KOGARO-IMG-002"
-	r.codes["image:missing_image_warning"] = "KOGARO-IMG-003"
-	r.codes["image:architecture_mismatch"] = "KOGARO-IMG-004"
-	r.codes["image:architecture_mismatch_warning"] = "KOGARO-IMG-005"
+	r.register("image:invalid_image_reference", ErrorCodeInfo{Code: "KOGARO-IMG-001", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container has invalid image reference format", Checks: "spec.template.spec.containers[].image format", Example: "Container 'app' has invalid image reference: invalid@format"})
+	r.register("image:missing_image", ErrorCodeInfo{Code: "KOGARO-IMG-002", Severity: SeverityError, ResourceType: "Container",
+		Title: "Container references non-existent image in registry", Checks: "spec.template.spec.containers[].image registry existence", Example: "Container 'app' references non-existent image: myregistry/nonexistent:latest"})
+	r.register("image:missing_image_warning", ErrorCodeInfo{Code: "KOGARO-IMG-003", Severity: SeverityWarning, ResourceType: "Container",
+		Title: "Container references non-existent image (warning when allowed)", Checks: "spec.template.spec.containers[].image registry existence (warning)", Example: "Container 'app' references non-existent image: myregistry/nonexistent:latest (deployment allowed)"})
+	r.register("image:architecture_mismatch", ErrorCodeInfo{Code: "KOGARO-IMG-004", Severity: SeverityError, ResourceType: "Container",
+		Title: "Image architecture incompatible with cluster nodes", Checks: "spec.template.spec.containers[].image architecture compatibility", Example: "Container 'app' image architecture (arm64) incompatible with cluster nodes (amd64)"})
+	r.register("image:architecture_mismatch_warning", ErrorCodeInfo{Code: "KOGARO-IMG-005", Severity: SeverityWarning, ResourceType: "Container",
+		Title: "Architecture mismatch (warning when allowed)", Checks: "spec.template.spec.containers[].image architecture compatibility (warning)", Example: "Container 'app' image architecture (arm64) incompatible with cluster nodes (amd64) (deployment allowed)"})
+
+	// Secret Validator (SCR)
+	r.register("secret:empty_secret_referenced", ErrorCodeInfo{Code: "KOGARO-SCR-001", Severity: SeverityError, ResourceType: "Secret",
+		Title: "Secret referenced by workloads contains no data", Checks: "spec.volumes[].secret / envFrom / env / imagePullSecrets reference an empty Secret", Example: "Secret 'app-secret' is referenced by workloads but contains no data"})
+	r.register("secret:tls_secret_missing_keys", ErrorCodeInfo{Code: "KOGARO-SCR-002", Severity: SeverityError, ResourceType: "Secret",
+		Title: "TLS Secret is missing tls.crt or tls.key", Checks: "type kubernetes.io/tls has tls.crt and tls.key", Example: "TLS Secret 'web-tls' is missing required keys: tls.crt, tls.key"})
+	r.register("secret:tls_secret_invalid_certificate", ErrorCodeInfo{Code: "KOGARO-SCR-003", Severity: SeverityError, ResourceType: "Secret",
+		Title: "TLS Secret certificate cannot be parsed", Checks: "data[tls.crt] parses as PEM certificate", Example: "TLS Secret 'web-tls' contains a certificate that cannot be parsed"})
+	r.register("secret:tls_certificate_expired", ErrorCodeInfo{Code: "KOGARO-SCR-004", Severity: SeverityError, ResourceType: "Secret",
+		Title: "TLS Secret certificate has expired", Checks: "data[tls.crt] NotAfter is in the future", Example: "TLS Secret 'web-tls' certificate expired on 2025-05-31"})
+	r.register("secret:tls_certificate_expiring", ErrorCodeInfo{Code: "KOGARO-SCR-005", Severity: SeverityWarning, ResourceType: "Secret",
+		Title: "TLS Secret certificate expires within the warning window", Checks: "data[tls.crt] NotAfter is beyond --cert-expiry-warning-days", Example: "TLS Secret 'web-tls' certificate expires in 10 days"})
+	r.register("secret:dockerconfigjson_missing_key", ErrorCodeInfo{Code: "KOGARO-SCR-006", Severity: SeverityError, ResourceType: "Secret",
+		Title: "Registry Secret is missing .dockerconfigjson", Checks: "type kubernetes.io/dockerconfigjson has .dockerconfigjson", Example: "Registry Secret 'regcred' is missing the .dockerconfigjson key"})
+	r.register("secret:dockerconfigjson_malformed", ErrorCodeInfo{Code: "KOGARO-SCR-007", Severity: SeverityError, ResourceType: "Secret",
+		Title: "Registry Secret contains malformed docker config JSON", Checks: "data[.dockerconfigjson] is valid JSON with auths", Example: "Registry Secret 'regcred' does not contain valid docker config JSON with an 'auths' section"})
+	r.register("secret:basic_auth_missing_keys", ErrorCodeInfo{Code: "KOGARO-SCR-008", Severity: SeverityError, ResourceType: "Secret",
+		Title: "Basic-auth Secret is missing username or password", Checks: "type kubernetes.io/basic-auth has username and password", Example: "Basic-auth Secret 'creds' is missing required keys: password"})
+
+	// Volume Validator (VOL)
+	r.register("volume:duplicate_volume_name", ErrorCodeInfo{Code: "KOGARO-VOL-001", Severity: SeverityError, ResourceType: "Pod template",
+		Title: "Volume name declared more than once in the pod spec", Checks: "spec.template.spec.volumes[].name unique", Example: "Volume name 'data' is declared more than once"})
+	r.register("volume:duplicate_mount_path", ErrorCodeInfo{Code: "KOGARO-VOL-002", Severity: SeverityError, ResourceType: "Container",
+		Title: "Multiple volumes mounted at the same mountPath", Checks: "spec.template.spec.containers[].volumeMounts[].mountPath unique per container", Example: "Container 'app' mounts volumes 'data' and 'scratch' at the same path '/data'"})
+	r.register("volume:subpath_item_missing", ErrorCodeInfo{Code: "KOGARO-VOL-003", Severity: SeverityError, ResourceType: "Container",
+		Title: "subPath does not match an item provided by the ConfigMap, Secret or projected volume", Checks: "spec.template.spec.containers[].volumeMounts[].subPath matches volume items or keys", Example: "Container 'app' mounts subPath 'settings.yaml' which is not provided by volume 'config'"})
+	r.register("volume:readonly_mount_expected_writable", ErrorCodeInfo{Code: "KOGARO-VOL-004", Severity: SeverityWarning, ResourceType: "Container",
+		Title: "emptyDir mounted read-only by every container, or kogaro.io/writable-volumes volume mounted read-only", Checks: "volumeMounts[].readOnly on an emptyDir no container writes or a kogaro.io/writable-volumes volume", Example: "Container 'app' mounts volume 'tmp' read-only at '/tmp' but the application expects to write to it"})
+
+	// Quota Validator (QTA)
+	r.register("quota:resource_quota_exceeded", ErrorCodeInfo{Code: "KOGARO-QTA-001", Severity: SeverityError, ResourceType: "Workload",
+		Title: "New workload's aggregate requests would exceed the ResourceQuota", Checks: "status.used + replicas x pod requests <= spec.hard (new workloads)", Example: "Workload would exceed ResourceQuota 'compute': requests.cpu 2250m > 2"})
+	r.register("quota:limitrange_below_min", ErrorCodeInfo{Code: "KOGARO-QTA-002", Severity: SeverityError, ResourceType: "Workload",
+		Title: "Container request or limit is below the LimitRange minimum", Checks: "containers[].resources >= LimitRange spec.limits[type=Container].min", Example: "Container 'app' cpu request 10m is below the minimum 50m allowed by LimitRange 'limits'"})
+	r.register("quota:limitrange_above_max", ErrorCodeInfo{Code: "KOGARO-QTA-003", Severity: SeverityError, ResourceType: "Workload",
+		Title: "Container request or limit is above the LimitRange maximum", Checks: "containers[].resources <= LimitRange spec.limits[type=Container].max", Example: "Container 'app' memory limit 2Gi is above the maximum 1Gi allowed by LimitRange 'limits'"})
+	r.register("quota:quota_without_limitrange", ErrorCodeInfo{Code: "KOGARO-QTA-004", Severity: SeverityWarning, ResourceType: "ResourceQuota",
+		Title: "Namespace has compute quotas but no LimitRange defaults", Checks: "Namespace with compute ResourceQuota has LimitRange defaults", Example: "Namespace 'team-a' has ResourceQuota 'compute' on compute resources but no LimitRange providing defaults"})
+	r.register("quota:quota_requires_explicit_resources", ErrorCodeInfo{Code: "KOGARO-QTA-005", Severity: SeverityError, ResourceType: "Workload",
+		Title: "Containers omit resources the quota requires and no defaults apply", Checks: "containers[].resources declare quota-constrained resources", Example: "Pods will be rejected: ResourceQuota 'compute' requires requests.memory but containers do not set them and no LimitRange provides defaults"})
+
+	// Lifecycle Validator (LIFE)
+	r.register("lifecycle:orphaned_replicaset", ErrorCodeInfo{Code: "KOGARO-LIFE-001", Severity: SeverityWarning, ResourceType: "ReplicaSet",
+		Title: "ReplicaSet has zero replicas and no controlling owner", Checks: "spec.replicas = 0 and status.replicas = 0 requires a controller ownerReference", Example: "ReplicaSet 'web-7d9f8c' has zero replicas and is not owned by any controller"})
+	r.register("lifecycle:dangling_owner_reference", ErrorCodeInfo{Code: "KOGARO-LIFE-002", Severity: SeverityWarning, ResourceType: "ReplicaSet, Job, Pod",
+		Title: "ownerReference points at a UID that no longer exists", Checks: "metadata.ownerReferences[].uid -> existing owner", Example: "Pod 'web-7d9f8c-abcde' has an ownerReference to ReplicaSet/web-7d9f8c with UID 1234"})
+	r.register("lifecycle:cross_namespace_owner_reference", ErrorCodeInfo{Code: "KOGARO-LIFE-003", Severity: SeverityError, ResourceType: "ReplicaSet, Job, Pod",
+		Title: "ownerReference points at an owner in a different namespace", Checks: "metadata.ownerReferences[] -> owner in the same namespace", Example: "Job 'migrate' has an ownerReference to CronJob/migrate in namespace 'ops'; owners must be in the same namespace"})
+
+	// Workload Validator (WKL)
+	r.register("workload:statefulset_missing_service_name", ErrorCodeInfo{Code: "KOGARO-WKL-001", Severity: SeverityWarning, ResourceType: "StatefulSet",
+		Title: "StatefulSet sets no serviceName, so its pods get no stable DNS names", Checks: "spec.serviceName", Example: "StatefulSet 'db' does not set serviceName, so its pods get no stable DNS names"})
+	r.register("workload:statefulset_dangling_service", ErrorCodeInfo{Code: "KOGARO-WKL-002", Severity: SeverityError, ResourceType: "StatefulSet",
+		Title: "Governing Service named by serviceName does not exist", Checks: "spec.serviceName -> existing Service", Example: "Governing Service 'db-headless' of StatefulSet 'db' does not exist"})
+	r.register("workload:statefulset_service_not_headless", ErrorCodeInfo{Code: "KOGARO-WKL-003", Severity: SeverityWarning, ResourceType: "StatefulSet",
+		Title: "Governing Service is not headless (clusterIP: None)", Checks: "spec.serviceName -> Service spec.clusterIP = None", Example: "Governing Service 'db' of StatefulSet 'db' is not headless"})
+	r.register("workload:statefulset_dangling_storage_class", ErrorCodeInfo{Code: "KOGARO-WKL-004", Severity: SeverityError, ResourceType: "StatefulSet",
+		Title: "StorageClass requested by a volumeClaimTemplate does not exist", Checks: "spec.volumeClaimTemplates[].spec.storageClassName", Example: "StorageClass 'fast-ssd' requested by volumeClaimTemplate 'data' does not exist"})
+	r.register("workload:statefulset_ondelete_without_reason", ErrorCodeInfo{Code: "KOGARO-WKL-005", Severity: SeverityWarning, ResourceType: "StatefulSet",
+		Title: "OnDelete update strategy without a kogaro.io/on-delete-reason annotation", Checks: "spec.updateStrategy.type = OnDelete requires kogaro.io/on-delete-reason", Example: "StatefulSet 'db' uses the OnDelete update strategy without a documented reason"})
+	r.register("workload:daemonset_host_network_without_reason", ErrorCodeInfo{Code: "KOGARO-WKL-006", Severity: SeverityWarning, ResourceType: "DaemonSet",
+		Title: "hostNetwork without a kogaro.io/host-access-reason annotation", Checks: "spec.template.spec.hostNetwork requires kogaro.io/host-access-reason", Example: "DaemonSet 'node-agent' uses the host network without a documented reason"})
+	r.register("workload:daemonset_invalid_max_unavailable", ErrorCodeInfo{Code: "KOGARO-WKL-007", Severity: SeverityWarning, ResourceType: "DaemonSet",
+		Title: "Rolling update can make no progress (Error), or maxUnavailable covers every node (Warning)", Checks: "spec.updateStrategy.rollingUpdate.maxUnavailable", Example: "DaemonSet 'node-agent' rolling update maxUnavailable 100% takes the pods of every node down at once"})
+	r.register("workload:readiness_gate_condition_missing", ErrorCodeInfo{Code: "KOGARO-WKL-008", Severity: SeverityWarning, ResourceType: "Deployment/StatefulSet/DaemonSet/Job/Pod",
+		Title: "Readiness gate condition still unset on scheduled pods after --pod-stall-timeout", Checks: "status.conditions contains every spec.readinessGates[].conditionType after --pod-stall-timeout", Example: "Readiness gate 'target-health.elbv2.k8s.aws/api' has not been set on 2 of 2 pods after 10m, so the pods never become ready"})
+	r.register("workload:pod_unschedulable", ErrorCodeInfo{Code: "KOGARO-WKL-009", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Job/Pod",
+		Title: "Pods Pending longer than --pod-stall-timeout with scheduling failures", Checks: "status.phase Pending after --pod-stall-timeout with FailedScheduling events", Example: "The scheduler cannot place the pod, Pending for more than 10m: 0/3 nodes are available: 3 Insufficient memory."})
+	r.register("workload:pod_crash_loop_backoff", ErrorCodeInfo{Code: "KOGARO-WKL-010", Severity: SeverityError, ResourceType: "Deployment/StatefulSet/DaemonSet/Job/Pod",
+		Title: "Containers in CrashLoopBackOff", Checks: "status.containerStatuses[].state.waiting.reason = CrashLoopBackOff", Example: "Container 'app' is in CrashLoopBackOff in 1 of 2 pods; last terminated with exit code 1"})
+
+	// Availability Validator (AVL)
+	r.register("availability:replicas_not_spread", ErrorCodeInfo{Code: "KOGARO-AVL-001", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet",
+		Title: "Multiple replicas without topologySpreadConstraints or pod anti-affinity", Checks: "spec.replicas > 1 requires topologySpreadConstraints or podAntiAffinity", Example: "Deployment 'web' runs 3 replicas without topologySpreadConstraints or pod anti-affinity, so they may all be scheduled onto one node"})
+	r.register("availability:single_replica_production", ErrorCodeInfo{Code: "KOGARO-AVL-002", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet",
+		Title: "Single replica in a production-like namespace", Checks: "spec.replicas > 1 in production-like namespaces", Example: "Deployment 'api' runs a single replica in production-like namespace 'shop-prod'"})
+	r.register("availability:workload_pinned_to_single_zone", ErrorCodeInfo{Code: "KOGARO-AVL-003", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet",
+		Title: "nodeSelector or required node affinity pins the workload to one zone", Checks: "spec.template.spec.nodeSelector / required nodeAffinity on topology.kubernetes.io/zone", Example: "Deployment 'web' is pinned to zone 'eu-west-1a' by its nodeSelector, so a zone outage takes down every replica"})
+
+	// Cluster Drift (DRF) - reported by kogaro diff
+	r.register("drift:image_drift", ErrorCodeInfo{Code: "KOGARO-DRF-001", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet, DaemonSet",
+		Title: "Container runs a different image than in the source cluster", Checks: "spec.template.spec.containers[].image = source cluster", Example: "Deployment 'api' container 'app' runs image 'api:2.0' in prod but 'api:2.1' in staging"})
+	r.register("drift:resource_settings_drift", ErrorCodeInfo{Code: "KOGARO-DRF-002", Severity: SeverityInfo, ResourceType: "Deployment, StatefulSet, DaemonSet",
+		Title: "Container has different resource requests or limits", Checks: "spec.template.spec.containers[].resources = source cluster", Example: "Deployment 'api' container 'app' has different resource requests or limits in prod than in staging"})
+	r.register("drift:security_context_drift", ErrorCodeInfo{Code: "KOGARO-DRF-003", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet, DaemonSet",
+		Title: "Pod or container securityContext differs", Checks: "spec.template.spec[.containers[]].securityContext = source cluster", Example: "DaemonSet 'agent' has a different pod securityContext in prod than in staging"})
+	r.register("drift:replica_count_drift", ErrorCodeInfo{Code: "KOGARO-DRF-004", Severity: SeverityInfo, ResourceType: "Deployment, StatefulSet",
+		Title: "Workload runs a different number of replicas", Checks: "spec.replicas = source cluster", Example: "Deployment 'api' runs 6 replicas in prod but 2 in staging"})
+	r.register("drift:env_drift", ErrorCodeInfo{Code: "KOGARO-DRF-005", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet, DaemonSet",
+		Title: "Container env or envFrom differs from the manifests (--source-dir only)", Checks: "spec.template.spec.containers[].env/envFrom = manifests", Example: "Deployment 'web' container 'app' has different environment variables in prod than in deploy/prod"})
+	r.register("drift:resource_not_in_git", ErrorCodeInfo{Code: "KOGARO-DRF-006", Severity: SeverityWarning, ResourceType: "Deployment, StatefulSet, DaemonSet",
+		Title: "Workload runs in the cluster but is not defined in the manifests (--source-dir only)", Checks: "Workload defined in the manifests", Example: "Deployment 'debug' exists in prod but is not defined in deploy/prod"})
+
+	// Custom Rule Validator (CST) - rule violations carry the rule's own error code
+	r.register("custom_rule:custom_rule_evaluation_failed", ErrorCodeInfo{Code: "KOGARO-CST-001", Severity: SeverityWarning, ResourceType: "Any",
+		Title: "A rule's expression could not be evaluated against a resource, e.g. it reads a field the resource does not set", Checks: "match + expression evaluated with the resource bound to object", Example: "Custom rule 'prod-revision-history' could not be evaluated: expression no such key: revisionHistoryLimit"})
+
+	// PolicyReport Validator (POL)
+	r.register("policy_report:policy_violation", ErrorCodeInfo{Code: "KOGARO-POL-001", Severity: SeverityError, ResourceType: "Any",
+		Title: "A policy engine's PolicyReport records a failed rule for the resource", Checks: "PolicyReport and ClusterPolicyReport results[].result = fail", Example: "kyverno policy 'require-labels' rule 'check-team' failed: validation error: label 'team' is required"})
+	r.register("policy_report:policy_warning", ErrorCodeInfo{Code: "KOGARO-POL-002", Severity: SeverityWarning, ResourceType: "Any",
+		Title: "A policy engine's PolicyReport records a rule warning for the resource", Checks: "PolicyReport and ClusterPolicyReport results[].result = warn", Example: "kyverno policy 'disallow-latest-tag' rule 'validate-image-tag' warned: using a mutable image tag e.g. 'latest' is not allowed"})
+	r.register("policy_report:policy_evaluation_error", ErrorCodeInfo{Code: "KOGARO-POL-003", Severity: SeverityWarning, ResourceType: "Any",
+		Title: "A policy engine could not evaluate a rule for the resource", Checks: "PolicyReport and ClusterPolicyReport results[].result = error", Example: "kyverno could not evaluate policy 'check-registry' rule 'allowed-registries': failed to load context"})
+
+	// Plugin Validator (PLG) - plugin findings are namespaced as KOGARO-PLG-<PREFIX>-<CODE>
+	r.register("plugin:plugin_failed", ErrorCodeInfo{Code: "KOGARO-PLG-001", Severity: SeverityWarning, ResourceType: "Plugin",
+		Title: "A plugin exited with an error, timed out or returned an invalid response", Checks: "<plugin> validate exits 0 within --plugin-timeout and prints a kogaro.io/plugin/v1 response", Example: "Validator plugin 'acme-labels' failed: validate timed out after 30s"})
+
+	// Validator Registry (SYS) - failures of validators themselves during a scan
+	r.register("registry:validator_timeout", ErrorCodeInfo{Code: "KOGARO-SYS-001", Severity: SeverityWarning, ResourceType: "Validator",
+		Title: "A validator did not finish within --validator-timeout and was abandoned for the scan", Checks: "ValidateCluster returns within --validator-timeout", Example: "Validator 'image_validation' did not finish within 2m0s and was abandoned for this scan"})
+	r.register("registry:validator_panic", ErrorCodeInfo{Code: "KOGARO-SYS-002", Severity: SeverityError, ResourceType: "Validator",
+		Title: "A validator panicked; the panic was recovered and logged with its stack trace", Checks: "ValidateCluster returns without panicking", Example: "Validator 'plugin:acme' panicked: runtime error: index out of range"})
+
+	// Permission self-check (SYS) - permissions of Kogaro's own ServiceAccount
+	r.register("permission:agent_write_permissions", ErrorCodeInfo{Code: "KOGARO-SYS-003", Severity: SeverityError, ResourceType: "ServiceAccount",
+		Title: "Kogaro's own ServiceAccount holds write verbs that no enabled feature needs (reported by the permission self-check)", Checks: "SelfSubjectRulesReview grants no write verbs beyond enabled features", Example: "Kogaro's ServiceAccount holds write permissions no enabled feature needs: delete pods, patch deployments.apps"})
+	r.register("permission:agent_excess_read_permissions", ErrorCodeInfo{Code: "KOGARO-SYS-004", Severity: SeverityInfo, ResourceType: "ServiceAccount",
+		Title: "Kogaro's own ServiceAccount can read resources that no registered validator needs (reported by the permission self-check)", Checks: "SelfSubjectRulesReview grants no reads beyond registered validators", Example: "Kogaro's ServiceAccount can read resources no registered validator needs: get nodes"})
+
+	// Config decoding (SYS) - objects of a --config that do not match their schema
+	r.register("registry:invalid_config_object", ErrorCodeInfo{Code: "KOGARO-SYS-005", Severity: SeverityError, ResourceType: "Any",
+		Title: "A config object does not match the schema of its kind and was left out of validation", Checks: "Registered kinds decode into their typed form", Example: "Deployment 'web' does not match the apps/v1 schema and was not validated: json: cannot unmarshal string into Go struct field DeploymentSpec.spec.replicas of type int32"})
+	r.register("registry:unknown_config_field", ErrorCodeInfo{Code: "KOGARO-SYS-006", Severity: SeverityWarning, ResourceType: "Any",
+		Title: "A config object sets fields its kind, or the CustomResourceDefinition of the config for it, does not define; they are ignored, as by the API server", Checks: "Registered kinds decode without unknown fields; custom resources prune nothing", Example: "Deployment 'web' sets fields that apps/v1 does not define: spec.replica"})
+	r.register("registry:invalid_custom_resource", ErrorCodeInfo{Code: "KOGARO-SYS-007", Severity: SeverityError, ResourceType: "Any",
+		Title: "A custom resource does not match the schema of a CustomResourceDefinition in the same config, or uses a version it does not serve", Checks: "Served version + OpenAPI v3 schema of the config's CRD", Example: "Widget 'gadget' does not match the schema of CustomResourceDefinition 'widgets.example.com': spec.size in body must be of type integer: \"string\""})
+
+	// Offline validation (SYS) - checks skipped for lack of cluster context
+	r.register("registry:check_skipped_offline", ErrorCodeInfo{Code: "KOGARO-SYS-008", Severity: SeverityInfo, ResourceType: "Validator",
+		Title: "A check was skipped by --offline because it needs the live state of a cluster, such as its nodes, pods or metrics", Checks: "Enabled checks that need cluster context, under --offline", Example: "Check 'Node Capacity' of resource_limits_validation was skipped: it needs the cluster's nodes, which --offline does not read"})
 }
 
 // GetNetworkingErrorCode returns the error code for networking validation types.
@@ -153,9 +486,202 @@ func (r *ErrorCodeRegistry) GetImageErrorCode(validationType string) string {
 	return "KOGARO-IMG-UNKNOWN"
 }
 
+// GetSecretErrorCode returns the error code for secret validation types.
+func (r *ErrorCodeRegistry) GetSecretErrorCode(validationType string) string {
+	if code, exists := r.codes["secret:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-SCR-UNKNOWN"
+}
+
+// GetVolumeErrorCode returns the error code for volume validation types.
+func (r *ErrorCodeRegistry) GetVolumeErrorCode(validationType string) string {
+	if code, exists := r.codes["volume:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-VOL-UNKNOWN"
+}
+
+// GetQuotaErrorCode returns the error code for quota validation types.
+func (r *ErrorCodeRegistry) GetQuotaErrorCode(validationType string) string {
+	if code, exists := r.codes["quota:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-QTA-UNKNOWN"
+}
+
+// GetLifecycleErrorCode returns the error code for lifecycle validation types.
+func (r *ErrorCodeRegistry) GetLifecycleErrorCode(validationType string) string {
+	if code, exists := r.codes["lifecycle:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-LIFE-UNKNOWN"
+}
+
+// GetWorkloadErrorCode returns the error code for workload validation types.
+func (r *ErrorCodeRegistry) GetWorkloadErrorCode(validationType string) string {
+	if code, exists := r.codes["workload:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-WKL-UNKNOWN"
+}
+
+// GetAvailabilityErrorCode returns the error code for availability validation types.
+func (r *ErrorCodeRegistry) GetAvailabilityErrorCode(validationType string) string {
+	if code, exists := r.codes["availability:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-AVL-UNKNOWN"
+}
+
+// GetDriftErrorCode returns the error code for cluster drift types.
+func (r *ErrorCodeRegistry) GetDriftErrorCode(validationType string) string {
+	if code, exists := r.codes["drift:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-DRF-UNKNOWN"
+}
+
+// GetCustomRuleErrorCode returns the error code for custom rule validation types.
+func (r *ErrorCodeRegistry) GetCustomRuleErrorCode(validationType string) string {
+	if code, exists := r.codes["custom_rule:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-CST-UNKNOWN"
+}
+
+// GetPolicyReportErrorCode returns the error code for PolicyReport validation types.
+func (r *ErrorCodeRegistry) GetPolicyReportErrorCode(validationType string) string {
+	if code, exists := r.codes["policy_report:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-POL-UNKNOWN"
+}
+
+// GetPluginErrorCode returns the error code for plugin validation types.
+func (r *ErrorCodeRegistry) GetPluginErrorCode(validationType string) string {
+	if code, exists := r.codes["plugin:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-PLG-UNKNOWN"
+}
+
+// GetRegistryErrorCode returns the error code for validator registry types.
+func (r *ErrorCodeRegistry) GetRegistryErrorCode(validationType string) string {
+	if code, exists := r.codes["registry:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-SYS-UNKNOWN"
+}
+
+// GetPermissionErrorCode returns the error code for permission self-check types.
+func (r *ErrorCodeRegistry) GetPermissionErrorCode(validationType string) string {
+	if code, exists := r.codes["permission:"+validationType]; exists {
+		return code
+	}
+	return "KOGARO-SYS-UNKNOWN"
+}
+
+// ErrorCodeInfo describes an error code that validators report
+type ErrorCodeInfo struct {
+	Code           string `json:"code"`
+	Category       string `json:"category"`
+	ValidationType string `json:"validationType"`
+	// Title says in one line what the code reports
+	Title string `json:"title"`
+	// ResourceType is the kind of resource findings are reported on
+	ResourceType string `json:"resourceType"`
+	// Checks is the field or condition the validator inspects
+	Checks string `json:"checks"`
+	// Example is a typical finding message
+	Example string `json:"example"`
+	// Severity is the severity findings are reported with unless a ValidationPolicy
+	// overrides it
+	Severity Severity `json:"severity"`
+	// DocURL links to the documentation of the code's category
+	DocURL string `json:"docURL"`
+	// Controls are the compliance controls the code's checks support, as
+	// framework:control, such as cis:5.2.2
+	Controls []string `json:"controls,omitempty"`
+}
+
+// ErrorCodeCategory describes the validator behind the codes of one prefix, such as REF
+type ErrorCodeCategory struct {
+	Prefix      string
+	Title       string
+	Description string
+}
+
+// anchor returns the anchor of the category's section in ERROR-CODES.md
+func (c ErrorCodeCategory) anchor() string {
+	return strings.ToLower(strings.ReplaceAll(c.Title, " ", "-")) + "-" + strings.ToLower(c.Prefix)
+}
+
+// errorCodeCategories describes the error code prefixes, as documented in ERROR-CODES.md
+var errorCodeCategories = map[string]ErrorCodeCategory{
+	"REF":  {Prefix: "REF", Title: "Reference Validation", Description: "Validates references between Kubernetes resources to detect dangling references."},
+	"RES":  {Prefix: "RES", Title: "Resource Limits Validation", Description: "Validates resource requests, limits, and QoS configurations."},
+	"SEC":  {Prefix: "SEC", Title: "Security Validation", Description: "Validates security contexts, permissions, and compliance."},
+	"IMG":  {Prefix: "IMG", Title: "Image Validation", Description: "Validates container images, registry accessibility, and architecture compatibility."},
+	"NET":  {Prefix: "NET", Title: "Networking Validation", Description: "Validates service connectivity, network policies, and ingress configurations."},
+	"SCR":  {Prefix: "SCR", Title: "Secret Validation", Description: "Validates the contents of Secret objects. Findings only ever include metadata, never secret values."},
+	"VOL":  {Prefix: "VOL", Title: "Volume Validation", Description: "Validates volume declarations and container volume mounts."},
+	"QTA":  {Prefix: "QTA", Title: "Quota Validation", Description: "Validates workloads against namespace ResourceQuotas and LimitRanges."},
+	"LIFE": {Prefix: "LIFE", Title: "Lifecycle Validation", Description: "Validates ownerReferences and resources left behind by garbage collection."},
+	"WKL":  {Prefix: "WKL", Title: "Workload Validation", Description: "Validates settings specific to a workload kind, beyond the container checks all workloads share."},
+	"AVL":  {Prefix: "AVL", Title: "Availability Validation", Description: "Validates that Deployments and StatefulSets survive the loss of a node or zone."},
+	"DRF":  {Prefix: "DRF", Title: "Cluster Drift", Description: "Reported by kogaro diff, which compares workloads of the same namespace and name in a source and a target cluster, or in a directory of expected manifests and a target cluster."},
+	"CST":  {Prefix: "CST", Title: "Custom Rules", Description: "Evaluates user-defined CEL rules. Violations carry the error code and severity declared by the rule."},
+	"POL":  {Prefix: "POL", Title: "Policy Reports", Description: "Merges the results policy engines such as Kyverno publish as wgpolicyk8s.io PolicyReports and ClusterPolicyReports."},
+	"PLG":  {Prefix: "PLG", Title: "Validator Plugins", Description: "Runs external validator plugins. Their findings are namespaced as KOGARO-PLG-<PREFIX>-<CODE>."},
+	"SYS":  {Prefix: "SYS", Title: "Validator Registry", Description: "Reports validators that failed during a cluster scan, config objects that do not match their schema, and excess permissions of Kogaro's own ServiceAccount."},
+}
+
+// codePrefix returns the category prefix of a code, such as REF for KOGARO-REF-001
+func codePrefix(code string) string {
+	parts := strings.Split(code, "-")
+	if len(parts) < 3 {
+		return ""
+	}
+	return parts[1]
+}
+
+// Codes returns the registered error codes sorted by code.
+func (r *ErrorCodeRegistry) Codes() []ErrorCodeInfo {
+	codes := make([]ErrorCodeInfo, 0, len(r.catalog))
+	for _, info := range r.catalog {
+		codes = append(codes, info)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return codes
+}
+
+// Lookup returns the documentation of an error code. Codes are matched case-insensitively.
+func (r *ErrorCodeRegistry) Lookup(code string) (ErrorCodeInfo, bool) {
+	info, ok := r.catalog[strings.ToUpper(strings.TrimSpace(code))]
+	return info, ok
+}
+
 // Global error code registry instance
 var globalErrorCodeRegistry = NewErrorCodeRegistry()
 
+// ErrorCodes is a package-level convenience function.
+func ErrorCodes() []ErrorCodeInfo {
+	return globalErrorCodeRegistry.Codes()
+}
+
+// LookupErrorCode is a package-level convenience function.
+func LookupErrorCode(code string) (ErrorCodeInfo, bool) {
+	return globalErrorCodeRegistry.Lookup(code)
+}
+
+// ErrorCodeCategoryOf returns the category of an error code, such as Reference
+// Validation for KOGARO-REF-001
+func ErrorCodeCategoryOf(code string) (ErrorCodeCategory, bool) {
+	category, ok := errorCodeCategories[codePrefix(strings.ToUpper(code))]
+	return category, ok
+}
+
 // GetNetworkingErrorCode is a package-level convenience function.
 func GetNetworkingErrorCode(validationType string) string {
 	return globalErrorCodeRegistry.GetNetworkingErrorCode(validationType)
@@ -180,3 +706,63 @@ func GetReferenceErrorCode(validationType string) string {
 func GetImageErrorCode(validationType string) string {
 	return globalErrorCodeRegistry.GetImageErrorCode(validationType)
 }
+
+// GetSecretErrorCode is a package-level convenience function.
+func GetSecretErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetSecretErrorCode(validationType)
+}
+
+// GetVolumeErrorCode is a package-level convenience function.
+func GetVolumeErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetVolumeErrorCode(validationType)
+}
+
+// GetQuotaErrorCode is a package-level convenience function.
+func GetQuotaErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetQuotaErrorCode(validationType)
+}
+
+// GetLifecycleErrorCode is a package-level convenience function.
+func GetLifecycleErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetLifecycleErrorCode(validationType)
+}
+
+// GetWorkloadErrorCode is a package-level convenience function.
+func GetWorkloadErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetWorkloadErrorCode(validationType)
+}
+
+// GetAvailabilityErrorCode is a package-level convenience function.
+func GetAvailabilityErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetAvailabilityErrorCode(validationType)
+}
+
+// GetDriftErrorCode is a package-level convenience function.
+func GetDriftErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetDriftErrorCode(validationType)
+}
+
+// GetCustomRuleErrorCode is a package-level convenience function.
+func GetCustomRuleErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetCustomRuleErrorCode(validationType)
+}
+
+// GetPolicyReportErrorCode is a package-level convenience function.
+func GetPolicyReportErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetPolicyReportErrorCode(validationType)
+}
+
+// GetPluginErrorCode is a package-level convenience function.
+func GetPluginErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetPluginErrorCode(validationType)
+}
+
+// GetRegistryErrorCode is a package-level convenience function.
+func GetRegistryErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetRegistryErrorCode(validationType)
+}
+
+// GetPermissionErrorCode is a package-level convenience function.
+func GetPermissionErrorCode(validationType string) string {
+	return globalErrorCodeRegistry.GetPermissionErrorCode(validationType)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"encoding/csv"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// TestErrorCodes_MatchDocumentation checks the catalog against docs/ERROR-CODES.md and
+// docs/validations.csv, so neither can drift from the codes validators report
+func TestErrorCodes_MatchDocumentation(t *testing.T) {
+	markdown, err := os.ReadFile("../../docs/ERROR-CODES.md")
+	if err != nil {
+		t.Fatalf("failed to read ERROR-CODES.md: %v", err)
+	}
+	row := regexp.MustCompile("(?m)^\\| (KOGARO-[A-Z]+-\\d+) \\| `([a-z_]+)` \\| ([^|]+?) \\| (.+?) \\|$")
+	documented := make(map[string]bool)
+	for _, match := range row.FindAllStringSubmatch(string(markdown), -1) {
+		code := match[1]
+		documented[code] = true
+		info, ok := LookupErrorCode(code)
+		if !ok {
+			t.Errorf("%s is documented but not registered", code)
+			continue
+		}
+		if info.ValidationType != match[2] {
+			t.Errorf("%s validation type = %q, documented as %q", code, info.ValidationType, match[2])
+		}
+		if info.ResourceType != match[3] {
+			t.Errorf("%s resource type = %q, documented as %q", code, info.ResourceType, match[3])
+		}
+		if title := strings.ReplaceAll(match[4], "`", ""); info.Title != title {
+			t.Errorf("%s title = %q, documented as %q", code, info.Title, title)
+		}
+	}
+
+	file, err := os.Open("../../docs/validations.csv")
+	if err != nil {
+		t.Fatalf("failed to open validations.csv: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse validations.csv: %v", err)
+	}
+	for _, record := range records[1:] {
+		if len(record) < 8 {
+			continue
+		}
+		info, ok := LookupErrorCode(record[5])
+		if !ok {
+			t.Errorf("%s is listed in validations.csv but not registered", record[5])
+			continue
+		}
+		if string(info.Severity) != strings.ToLower(record[7]) {
+			t.Errorf("%s severity = %q, listed as %q", record[5], info.Severity, record[7])
+		}
+	}
+
+	for _, info := range ErrorCodes() {
+		if !documented[info.Code] {
+			t.Errorf("%s is registered but not documented in ERROR-CODES.md", info.Code)
+		}
+		if info.Title == "" || info.Checks == "" || info.Example == "" || info.Severity == "" {
+			t.Errorf("%s is missing documentation: %+v", info.Code, info)
+		}
+		if !strings.HasPrefix(info.DocURL, ErrorCodesDocURL+"#") {
+			t.Errorf("%s DocURL = %q, want an anchor in %s", info.Code, info.DocURL, ErrorCodesDocURL)
+		}
+	}
+}
+
+func TestErrorCodeRegistry_Lookup(t *testing.T) {
+	info, ok := LookupErrorCode(" kogaro-net-003")
+	if !ok {
+		t.Fatal("LookupErrorCode() did not find kogaro-net-003")
+	}
+	if info.Code != "KOGARO-NET-003" || info.Category != "networking" || info.ValidationType != "service_port_mismatch" {
+		t.Errorf("LookupErrorCode() = %+v", info)
+	}
+	if info.DocURL != ErrorCodesDocURL+"#networking-validation-net" {
+		t.Errorf("DocURL = %q", info.DocURL)
+	}
+	if GetNetworkingErrorCode(info.ValidationType) != info.Code {
+		t.Errorf("GetNetworkingErrorCode(%q) = %q, want %q", info.ValidationType, GetNetworkingErrorCode(info.ValidationType), info.Code)
+	}
+
+	if _, ok := LookupErrorCode("KOGARO-NET-999"); ok {
+		t.Error("LookupErrorCode() found an unregistered code")
+	}
+
+	category, ok := ErrorCodeCategoryOf("KOGARO-LIFE-001")
+	if !ok || category.Title != "Lifecycle Validation" {
+		t.Errorf("ErrorCodeCategoryOf() = %+v, %v", category, ok)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+// Exit codes of the CLI validation paths
+const (
+	// ExitCodeOK means no finding fails the run
+	ExitCodeOK = 0
+	// ExitCodeErrors means at least one finding has error severity
+	ExitCodeErrors = 1
+	// ExitCodeWarnings means the most severe findings are warnings and the policy is strict
+	ExitCodeWarnings = 2
+	// ExitCodeInternalFailure means validation could not run to completion, for example
+	// because the config file could not be parsed or the cluster could not be reached
+	ExitCodeInternalFailure = 3
+)
+
+// ExitCodePolicy decides the exit code of a validation run from the severity of its
+// findings. Findings of error severity always fail the run; warnings only fail it
+// when the policy is strict, and info findings never do. Findings whose codes a
+// SeverityPolicy remaps are judged by their remapped severity.
+type ExitCodePolicy struct {
+	// Strict fails runs whose most severe findings are warnings with ExitCodeWarnings
+	Strict bool
+}
+
+// ExitCode returns the exit code for a run that reported the given findings
+func (p ExitCodePolicy) ExitCode(errors []ValidationError) int {
+	warnings := false
+	for _, ve := range errors {
+		switch ve.Severity {
+		case SeverityInfo:
+		case SeverityWarning:
+			warnings = true
+		default:
+			return ExitCodeErrors
+		}
+	}
+	if warnings && p.Strict {
+		return ExitCodeWarnings
+	}
+	return ExitCodeOK
+}
+
+// exitCodeRank orders exit codes from the least to the most serious outcome, which is
+// not their numeric order: errors outrank warnings, and internal failures outrank both
+var exitCodeRank = map[int]int{
+	ExitCodeOK:              0,
+	ExitCodeWarnings:        1,
+	ExitCodeErrors:          2,
+	ExitCodeInternalFailure: 3,
+}
+
+// WorseExitCode returns the more serious of two exit codes, so that the results of
+// several runs can be combined into one exit code
+func WorseExitCode(a, b int) int {
+	if exitCodeRank[b] > exitCodeRank[a] {
+		return b
+	}
+	return a
+}
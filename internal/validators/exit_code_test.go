@@ -0,0 +1,75 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestExitCodePolicy_ExitCode(t *testing.T) {
+	finding := func(severity Severity) ValidationError {
+		return NewValidationError("Pod", "web", "team-a", "test", "finding").WithSeverity(severity)
+	}
+
+	tests := []struct {
+		name     string
+		findings []ValidationError
+		strict   bool
+		want     int
+	}{
+		{name: "no findings", want: ExitCodeOK},
+		{name: "info only", findings: []ValidationError{finding(SeverityInfo)}, strict: true, want: ExitCodeOK},
+		{name: "warnings only", findings: []ValidationError{finding(SeverityWarning), finding(SeverityInfo)}, want: ExitCodeOK},
+		{name: "warnings only when strict", findings: []ValidationError{finding(SeverityWarning)}, strict: true, want: ExitCodeWarnings},
+		{name: "errors and warnings", findings: []ValidationError{finding(SeverityWarning), finding(SeverityError)}, want: ExitCodeErrors},
+		{name: "errors and warnings when strict", findings: []ValidationError{finding(SeverityWarning), finding(SeverityError)}, strict: true, want: ExitCodeErrors},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (ExitCodePolicy{Strict: tt.strict}).ExitCode(tt.findings); got != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorseExitCode(t *testing.T) {
+	tests := []struct {
+		a, b, want int
+	}{
+		{ExitCodeOK, ExitCodeWarnings, ExitCodeWarnings},
+		{ExitCodeWarnings, ExitCodeErrors, ExitCodeErrors},
+		{ExitCodeErrors, ExitCodeWarnings, ExitCodeErrors},
+		{ExitCodeInternalFailure, ExitCodeErrors, ExitCodeInternalFailure},
+	}
+	for _, tt := range tests {
+		if got := WorseExitCode(tt.a, tt.b); got != tt.want {
+			t.Errorf("WorseExitCode(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValidatorRegistry_ExitCodePolicy(t *testing.T) {
+	warning := NewValidationError("Pod", "web", "team-a", "test", "finding").WithSeverity(SeverityWarning)
+
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: []ValidationError{warning}})
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	if got := registry.LastValidationResult().ExitCode; got != ExitCodeOK {
+		t.Errorf("ExitCode = %d, want %d without --strict", got, ExitCodeOK)
+	}
+	registry.SetExitCodePolicy(ExitCodePolicy{Strict: true})
+	if got := registry.LastValidationResult().ExitCode; got != ExitCodeWarnings {
+		t.Errorf("ExitCode = %d, want %d with --strict", got, ExitCodeWarnings)
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FlapSuppression holds back findings that appear and resolve repeatedly, such as
+// those of restarting pods or scaling workloads
+type FlapSuppression struct {
+	// ReportAfter is the number of consecutive cluster scans that must find a finding
+	// before it is reported; 0 keeps the default
+	ReportAfter int `json:"reportAfter,omitempty"`
+	// ResolveAfter is the number of consecutive cluster scans that must no longer find
+	// a reported finding before it is resolved; 0 keeps the default
+	ResolveAfter int `json:"resolveAfter,omitempty"`
+}
+
+// FlapPolicy decides the flap suppression of findings by error code. Exact codes take
+// precedence over prefixes, and longer prefixes over shorter ones.
+type FlapPolicy struct {
+	defaults FlapSuppression
+	exact    map[string]FlapSuppression
+	prefixes []flapPrefix
+}
+
+// flapPrefix is flap suppression that applies to every code with a prefix
+type flapPrefix struct {
+	prefix      string
+	suppression FlapSuppression
+}
+
+// NewFlapPolicy builds a FlapPolicy with the given defaults from the flap suppression
+// of one or more policies. Later policies take precedence when they set the same code.
+func NewFlapPolicy(defaults FlapSuppression, policies ...ValidationPolicy) (*FlapPolicy, error) {
+	if defaults.ReportAfter < 1 || defaults.ResolveAfter < 1 {
+		return nil, fmt.Errorf("findings must be reported and resolved after at least 1 scan, got %d and %d",
+			defaults.ReportAfter, defaults.ResolveAfter)
+	}
+	overrides := make(map[string]FlapSuppression)
+	var problems []string
+
+	for _, policy := range policies {
+		for key, suppression := range policy.Spec.FlapSuppression {
+			code := strings.ToUpper(strings.TrimSpace(key))
+			if !severityOverrideKeyPattern.MatchString(code) {
+				problems = append(problems, fmt.Sprintf("policy %q: invalid error code %q", policy.Name, key))
+				continue
+			}
+			if suppression.ReportAfter < 0 || suppression.ResolveAfter < 0 {
+				problems = append(problems, fmt.Sprintf("policy %q: %s has a negative number of scans", policy.Name, key))
+				continue
+			}
+			overrides[code] = suppression
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid flap suppression: %s", strings.Join(problems, "; "))
+	}
+
+	flapPolicy := &FlapPolicy{defaults: defaults, exact: make(map[string]FlapSuppression)}
+	for code, suppression := range overrides {
+		if suppression.ReportAfter == 0 {
+			suppression.ReportAfter = defaults.ReportAfter
+		}
+		if suppression.ResolveAfter == 0 {
+			suppression.ResolveAfter = defaults.ResolveAfter
+		}
+		if prefix, ok := strings.CutSuffix(code, "*"); ok {
+			flapPolicy.prefixes = append(flapPolicy.prefixes, flapPrefix{prefix: prefix, suppression: suppression})
+		} else {
+			flapPolicy.exact[code] = suppression
+		}
+	}
+	sort.Slice(flapPolicy.prefixes, func(i, j int) bool {
+		return len(flapPolicy.prefixes[i].prefix) > len(flapPolicy.prefixes[j].prefix)
+	})
+
+	return flapPolicy, nil
+}
+
+// Enabled reports whether the policy holds back any finding
+func (p *FlapPolicy) Enabled() bool {
+	if p == nil {
+		return false
+	}
+	if p.defaults.ReportAfter > 1 || p.defaults.ResolveAfter > 1 {
+		return true
+	}
+	for _, suppression := range p.exact {
+		if suppression.ReportAfter > 1 || suppression.ResolveAfter > 1 {
+			return true
+		}
+	}
+	for _, prefix := range p.prefixes {
+		if prefix.suppression.ReportAfter > 1 || prefix.suppression.ResolveAfter > 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Resolve returns the flap suppression of findings with the given error code
+func (p *FlapPolicy) Resolve(errorCode string) FlapSuppression {
+	if suppression, ok := p.exact[errorCode]; ok {
+		return suppression
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(errorCode, prefix.prefix) {
+			return prefix.suppression
+		}
+	}
+	return p.defaults
+}
+
+// flapTracker applies a FlapPolicy to the findings of each validator over successive
+// cluster scans
+type flapTracker struct {
+	policy *FlapPolicy
+
+	mu         sync.Mutex
+	validators map[Validator]*validatorFlaps
+}
+
+// validatorFlaps tracks the findings of one validator
+type validatorFlaps struct {
+	findings map[string]*flapState
+	// Findings reported after the validator's last run
+	reported []ValidationError
+}
+
+// flapState counts the consecutive scans that found or missed a finding
+type flapState struct {
+	finding  ValidationError
+	present  int
+	absent   int
+	reported bool
+}
+
+// newFlapTracker creates a tracker for a policy, or nil when the policy holds back
+// no finding
+func newFlapTracker(policy *FlapPolicy) *flapTracker {
+	if !policy.Enabled() {
+		return nil
+	}
+	return &flapTracker{policy: policy, validators: make(map[Validator]*validatorFlaps)}
+}
+
+// damp returns the findings of a validator's run that are reported: those found by
+// enough consecutive scans, and those reported earlier that too few consecutive scans
+// have missed, as last found
+func (t *flapTracker) damp(validator Validator, errors []ValidationError) []ValidationError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	flaps := t.validators[validator]
+	if flaps == nil {
+		flaps = &validatorFlaps{findings: make(map[string]*flapState)}
+		t.validators[validator] = flaps
+	}
+
+	reported := make([]ValidationError, 0, len(errors))
+	found := make(map[string]bool, len(errors))
+	for _, ve := range errors {
+		key := findingKey(ve)
+		state := flaps.findings[key]
+		if state == nil {
+			state = &flapState{}
+			flaps.findings[key] = state
+		}
+		if !found[key] {
+			found[key] = true
+			state.present++
+			state.absent = 0
+		}
+		state.finding = ve
+		if state.present >= t.policy.Resolve(ve.ErrorCode).ReportAfter {
+			state.reported = true
+		}
+		if state.reported {
+			reported = append(reported, ve)
+		}
+	}
+
+	missing := make([]string, 0, len(flaps.findings))
+	for key := range flaps.findings {
+		if !found[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	for _, key := range missing {
+		state := flaps.findings[key]
+		state.present = 0
+		state.absent++
+		if !state.reported || state.absent >= t.policy.Resolve(state.finding.ErrorCode).ResolveAfter {
+			delete(flaps.findings, key)
+			continue
+		}
+		reported = append(reported, state.finding)
+	}
+
+	flaps.reported = reported
+	return reported
+}
+
+// reported returns the findings reported after a validator's last run, if it has run
+func (t *flapTracker) reported(validator Validator) ([]ValidationError, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	flaps, ok := t.validators[validator]
+	if !ok {
+		return nil, false
+	}
+	return flaps.reported, true
+}
+
+// wrap returns a log receiver that holds back a validator's flapping findings
+func (t *flapTracker) wrap(receiver LogReceiver, validator Validator) LogReceiver {
+	if t == nil {
+		return receiver
+	}
+	return &flapLogReceiver{LogReceiver: receiver, tracker: t, validator: validator}
+}
+
+// findingDamper is implemented by log receivers that hold back flapping findings, so
+// that they are neither logged nor recorded in metrics until they are reported
+type findingDamper interface {
+	Damp(errors []ValidationError) []ValidationError
+}
+
+// flapLogReceiver forwards the findings of a validator that its tracker reports
+type flapLogReceiver struct {
+	LogReceiver
+	tracker   *flapTracker
+	validator Validator
+}
+
+// Damp returns the findings of the validator's run that are reported
+func (f *flapLogReceiver) Damp(errors []ValidationError) []ValidationError {
+	return f.tracker.damp(f.validator, errors)
+}
+
+// Reports returns whether the wrapped receiver reports a finding
+func (f *flapLogReceiver) Reports(validationError ValidationError) bool {
+	if filter, ok := f.LogReceiver.(findingFilter); ok {
+		return filter.Reports(validationError)
+	}
+	return true
+}
+
+// Cluster returns the cluster of the wrapped receiver
+func (f *flapLogReceiver) Cluster() string {
+	if scoped, ok := f.LogReceiver.(clusterScoped); ok {
+		return scoped.Cluster()
+	}
+	return ""
+}
@@ -0,0 +1,184 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewFlapPolicy(t *testing.T) {
+	defaults := FlapSuppression{ReportAfter: 2, ResolveAfter: 1}
+	policy, err := NewFlapPolicy(defaults,
+		ValidationPolicy{Spec: ValidationPolicySpec{FlapSuppression: map[string]FlapSuppression{
+			"KOGARO-RES-*":    {ReportAfter: 5},
+			"kogaro-res-008":  {ReportAfter: 4, ResolveAfter: 6},
+			"KOGARO-RES-01*":  {ResolveAfter: 3},
+			"KOGARO-NET-001 ": {ReportAfter: 1},
+		}}},
+		// A later policy replaces the suppression of the same code
+		ValidationPolicy{Spec: ValidationPolicySpec{FlapSuppression: map[string]FlapSuppression{
+			"KOGARO-NET-001": {ReportAfter: 3},
+		}}},
+	)
+	if err != nil {
+		t.Fatalf("NewFlapPolicy() error = %v", err)
+	}
+
+	tests := []struct {
+		code string
+		want FlapSuppression
+	}{
+		{"KOGARO-RES-008", FlapSuppression{ReportAfter: 4, ResolveAfter: 6}},
+		{"KOGARO-RES-012", FlapSuppression{ReportAfter: 2, ResolveAfter: 3}},
+		{"KOGARO-RES-001", FlapSuppression{ReportAfter: 5, ResolveAfter: 1}},
+		{"KOGARO-NET-001", FlapSuppression{ReportAfter: 3, ResolveAfter: 1}},
+		{"KOGARO-SEC-004", defaults},
+	}
+	for _, tt := range tests {
+		if got := policy.Resolve(tt.code); got != tt.want {
+			t.Errorf("Resolve(%s) = %+v, want %+v", tt.code, got, tt.want)
+		}
+	}
+	if !policy.Enabled() {
+		t.Error("Enabled() = false, want true")
+	}
+}
+
+func TestNewFlapPolicy_Invalid(t *testing.T) {
+	if _, err := NewFlapPolicy(FlapSuppression{ReportAfter: 0, ResolveAfter: 1}); err == nil {
+		t.Error("NewFlapPolicy() with a default of 0 scans succeeded")
+	}
+	_, err := NewFlapPolicy(FlapSuppression{ReportAfter: 1, ResolveAfter: 1},
+		ValidationPolicy{Spec: ValidationPolicySpec{FlapSuppression: map[string]FlapSuppression{
+			"not a code":     {ReportAfter: 2},
+			"KOGARO-RES-001": {ResolveAfter: -1},
+		}}},
+	)
+	if err == nil {
+		t.Fatal("NewFlapPolicy() with invalid suppression succeeded")
+	}
+
+	policy, err := NewFlapPolicy(FlapSuppression{ReportAfter: 1, ResolveAfter: 1})
+	if err != nil {
+		t.Fatalf("NewFlapPolicy() error = %v", err)
+	}
+	if policy.Enabled() || newFlapTracker(policy) != nil {
+		t.Error("a policy reporting and resolving after 1 scan is enabled")
+	}
+}
+
+func TestFlapTracker_Damp(t *testing.T) {
+	policy, err := NewFlapPolicy(FlapSuppression{ReportAfter: 3, ResolveAfter: 2})
+	if err != nil {
+		t.Fatalf("NewFlapPolicy() error = %v", err)
+	}
+	tracker := newFlapTracker(policy)
+	validator := &MockValidator{validationType: "test"}
+
+	stable := NewValidationErrorWithCode("Deployment", "api", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests")
+	flapping := NewValidationErrorWithCode("Pod", "worker-1", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "missing")
+
+	// The stable finding is found by every scan but the fifth and seventh, and the
+	// flapping finding by every other scan
+	scans := [][]ValidationError{
+		{stable, flapping},
+		{stable},
+		{stable, flapping},
+		{stable},
+		{flapping},
+		{stable},
+		{},
+		{},
+	}
+	want := []int{0, 0, 1, 1, 1, 1, 1, 0}
+	for i, scan := range scans {
+		reported := tracker.damp(validator, scan)
+		if len(reported) != want[i] {
+			t.Fatalf("scan %d: reported %d findings, want %d", i+1, len(reported), want[i])
+		}
+		for _, finding := range reported {
+			if findingKey(finding) != findingKey(stable) {
+				t.Errorf("scan %d: reported %s", i+1, findingKey(finding))
+			}
+		}
+	}
+	if reported, ok := tracker.reported(validator); !ok || len(reported) != 0 {
+		t.Errorf("reported() = %v, %v, want no findings", reported, ok)
+	}
+	if _, ok := tracker.reported(&MockValidator{}); ok {
+		t.Error("reported() of a validator that never ran succeeded")
+	}
+}
+
+// scanValidator logs a fixed set of findings on each cluster scan
+type scanValidator struct {
+	findings    []ValidationError
+	logReceiver LogReceiver
+}
+
+func (s *scanValidator) ValidateCluster(_ context.Context) error {
+	LogAndRecordErrors(s.logReceiver, "scan_test", s.findings)
+	return nil
+}
+
+func (s *scanValidator) GetValidationType() string                  { return "scan_test" }
+func (s *scanValidator) SetClient(_ client.Client)                  {}
+func (s *scanValidator) SetLogReceiver(lr LogReceiver)              { s.logReceiver = lr }
+func (s *scanValidator) GetLastValidationErrors() []ValidationError { return s.findings }
+
+func TestValidatorRegistry_FlapSuppression(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).Build()
+	policy, err := NewFlapPolicy(FlapSuppression{ReportAfter: 1, ResolveAfter: 1},
+		ValidationPolicy{Spec: ValidationPolicySpec{FlapSuppression: map[string]FlapSuppression{
+			"KOGARO-REF-*": {ReportAfter: 2, ResolveAfter: 2},
+		}}},
+	)
+	if err != nil {
+		t.Fatalf("NewFlapPolicy() error = %v", err)
+	}
+
+	dangling := NewValidationErrorWithCode("Pod", "worker", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "missing")
+	requests := NewValidationErrorWithCode("Pod", "worker", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests")
+	validator := &scanValidator{findings: []ValidationError{dangling, requests}}
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.SetFlapPolicy(policy)
+	registry.Register(validator)
+
+	scan := func() []string {
+		t.Helper()
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+		var codes []string
+		for _, finding := range registry.LastValidationResult().Errors {
+			codes = append(codes, finding.ErrorCode)
+		}
+		return codes
+	}
+
+	// Codes without suppression are reported by the first scan that finds them
+	if codes := scan(); len(codes) != 1 || codes[0] != "KOGARO-RES-001" {
+		t.Errorf("first scan reported %v, want KOGARO-RES-001", codes)
+	}
+	if codes := scan(); len(codes) != 2 {
+		t.Errorf("second scan reported %v, want both findings", codes)
+	}
+
+	// A reported finding stays reported until two scans have missed it
+	validator.findings = []ValidationError{requests}
+	if codes := scan(); len(codes) != 2 {
+		t.Errorf("third scan reported %v, want both findings", codes)
+	}
+	if codes := scan(); len(codes) != 1 || codes[0] != "KOGARO-RES-001" {
+		t.Errorf("fourth scan reported %v, want KOGARO-RES-001", codes)
+	}
+}
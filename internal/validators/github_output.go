@@ -0,0 +1,90 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"strings"
+)
+
+// attributeSources records the config file location of each error whose resource
+// was defined in the parsed config documents. Resources declared without a namespace
+// are matched by kind and name alone.
+func attributeSources(errors []ValidationError, sourceFile string, documents []configDocument) {
+	lines := make(map[string]int)
+	for _, document := range documents {
+		obj := document.Object
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		lines[fmt.Sprintf("%s/%s/%s", kind, obj.GetNamespace(), obj.GetName())] = document.Line
+		if obj.GetNamespace() == "" {
+			lines[fmt.Sprintf("%s/*/%s", kind, obj.GetName())] = document.Line
+		}
+	}
+
+	for i := range errors {
+		line, ok := lines[fmt.Sprintf("%s/%s/%s", errors[i].ResourceType, errors[i].Namespace, errors[i].ResourceName)]
+		if !ok {
+			line, ok = lines[fmt.Sprintf("%s/*/%s", errors[i].ResourceType, errors[i].ResourceName)]
+		}
+		if ok {
+			errors[i].SourceFile = sourceFile
+			errors[i].SourceLine = line
+		}
+	}
+}
+
+// FormatGitHubOutput formats validation results as GitHub Actions workflow commands
+// so findings appear as inline annotations on pull requests. Findings attributed to a
+// config file location are annotated at that line; others appear on the workflow run.
+func (r *ValidatorRegistry) FormatGitHubOutput(result ValidationResult) (string, error) {
+	var output strings.Builder
+
+	for _, ve := range result.Errors {
+		command := "error"
+		switch {
+		case ve.IsWarning():
+			command = "warning"
+		case ve.IsInfo():
+			command = "notice"
+		}
+
+		var properties []string
+		if ve.SourceFile != "" {
+			properties = append(properties, "file="+escapeWorkflowProperty(ve.SourceFile))
+			if ve.SourceLine > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", ve.SourceLine))
+			}
+		}
+		title := ve.ErrorCode
+		if title == "" {
+			title = ve.ValidationType
+		}
+		properties = append(properties, "title="+escapeWorkflowProperty(title))
+
+		message := fmt.Sprintf("%s %s: %s", ve.ResourceType, ve.GetResourceKey(), ve.Message)
+		if ve.RemediationHint != "" {
+			message += "\nHint: " + ve.RemediationHint
+		}
+
+		output.WriteString(fmt.Sprintf("::%s %s::%s\n", command, strings.Join(properties, ","), escapeWorkflowData(message)))
+	}
+
+	return output.String(), nil
+}
+
+// escapeWorkflowData escapes a workflow command message
+func escapeWorkflowData(value string) string {
+	value = strings.ReplaceAll(value, "%", "%25")
+	value = strings.ReplaceAll(value, "\r", "%0D")
+	return strings.ReplaceAll(value, "\n", "%0A")
+}
+
+// escapeWorkflowProperty escapes a workflow command property value
+func escapeWorkflowProperty(value string) string {
+	value = escapeWorkflowData(value)
+	value = strings.ReplaceAll(value, ":", "%3A")
+	return strings.ReplaceAll(value, ",", "%2C")
+}
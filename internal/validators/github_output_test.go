@@ -0,0 +1,127 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+const annotatedConfig = `# rendered by helm
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: app-config
+  namespace: team-a
+---
+# Source: chart/templates/deployment.yaml
+
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec: {}
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: team-a
+`
+
+func TestParseConfigDocuments_RecordsLines(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(annotatedConfig))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+
+	expected := []struct {
+		kind string
+		line int
+	}{
+		{"ConfigMap", 2},
+		{"Deployment", 10},
+		{"Service", 16},
+	}
+	if len(documents) != len(expected) {
+		t.Fatalf("got %d documents, want %d", len(documents), len(expected))
+	}
+	for i, want := range expected {
+		kind := documents[i].Object.GetObjectKind().GroupVersionKind().Kind
+		if kind != want.kind || documents[i].Line != want.line {
+			t.Errorf("document[%d] = %s at line %d, want %s at line %d", i, kind, documents[i].Line, want.kind, want.line)
+		}
+	}
+}
+
+func TestAttributeSources(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(annotatedConfig))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+
+	errors := []ValidationError{
+		NewValidationErrorWithCode("Service", "web", "team-a", "service_no_matching_pods", "KOGARO-NET-001", "no pods"),
+		NewValidationErrorWithCode("Deployment", "web", "default", "missing_resource_requests", "KOGARO-RES-002", "no requests"),
+		NewValidationErrorWithCode("Pod", "cluster-pod", "team-a", "missing_resource_requests", "KOGARO-RES-002", "no requests"),
+	}
+	attributeSources(errors, "manifests.yaml", documents)
+
+	if errors[0].SourceFile != "manifests.yaml" || errors[0].SourceLine != 16 {
+		t.Errorf("service attributed to %s:%d, want manifests.yaml:16", errors[0].SourceFile, errors[0].SourceLine)
+	}
+	if errors[1].SourceLine != 10 {
+		t.Errorf("namespace-less deployment attributed to line %d, want 10", errors[1].SourceLine)
+	}
+	if errors[2].SourceFile != "" || errors[2].SourceLine != 0 {
+		t.Errorf("cluster resource should not be attributed, got %s:%d", errors[2].SourceFile, errors[2].SourceLine)
+	}
+}
+
+func TestFormatGitHubOutput(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	attributed := NewValidationErrorWithCode("Service", "web", "team-a", "service_no_matching_pods", "KOGARO-NET-001", "selector app=web, tier=api matches 100% of nothing").
+		WithRemediationHint("Fix the selector")
+	attributed.SourceFile = "deploy/manifests.yaml"
+	attributed.SourceLine = 16
+
+	result := ValidationResult{Errors: []ValidationError{
+		attributed,
+		NewValidationErrorWithCode("Namespace", "team-a", "", "quota_without_limitrange", "KOGARO-QTA-004", "no limitrange").
+			WithSeverity(SeverityWarning),
+		NewValidationErrorWithCode("Pod", "web", "team-a", "pod_no_service", "", "not exposed").
+			WithSeverity(SeverityInfo),
+	}}
+
+	output, err := registry.FormatGitHubOutput(result)
+	if err != nil {
+		t.Fatalf("FormatGitHubOutput() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	expected := []string{
+		"::error file=deploy/manifests.yaml,line=16,title=KOGARO-NET-001::Service team-a/web: selector app=web, tier=api matches 100%25 of nothing%0AHint: Fix the selector",
+		"::warning title=KOGARO-QTA-004::Namespace team-a: no limitrange",
+		"::notice title=pod_no_service::Pod team-a/web: not exposed",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(expected), output)
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("line %d:\n got: %s\nwant: %s", i, lines[i], want)
+		}
+	}
+}
+
+func TestEscapeWorkflowProperty(t *testing.T) {
+	if got := escapeWorkflowProperty("C:\\a,b"); got != "C%3A\\a%2Cb" {
+		t.Errorf("escapeWorkflowProperty() = %q", got)
+	}
+}
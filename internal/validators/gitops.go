@@ -0,0 +1,254 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	// FluxKustomizationNameLabel is set by Flux on every object applied by a Kustomization
+	FluxKustomizationNameLabel = "kustomize.toolkit.fluxcd.io/name"
+	// FluxKustomizationNamespaceLabel records the namespace of the applying Kustomization
+	FluxKustomizationNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+	// FluxReconcileAnnotation disables reconciliation of an object by Flux when set to "disabled"
+	FluxReconcileAnnotation = "kustomize.toolkit.fluxcd.io/reconcile"
+
+	// fluxAPIGroupSuffix identifies Flux's own custom resources
+	fluxAPIGroupSuffix = "toolkit.fluxcd.io"
+)
+
+// ManifestFile is a manifest file read from a directory of rendered GitOps output
+type ManifestFile struct {
+	Path string
+	Data []byte
+}
+
+// LoadManifestDirectory reads the YAML manifests under a directory of rendered GitOps
+// output, such as the result of `flux build kustomization` or `kustomize build -o`.
+// Hidden directories and kustomization.yaml files are skipped. A path to a single
+// file is also accepted.
+func LoadManifestDirectory(path string) ([]ManifestFile, error) {
+	var files []ManifestFile
+
+	err := filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != path && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !IsManifestFile(entry.Name()) {
+			return nil
+		}
+
+		data, err := os.ReadFile(filePath) // nolint:gosec // Manifest directory is user-provided
+		if err != nil {
+			return fmt.Errorf("failed to read manifest %s: %w", filePath, err)
+		}
+		files = append(files, ManifestFile{Path: filePath, Data: data})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest directory: %w", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YAML manifests found in %s", path)
+	}
+
+	return files, nil
+}
+
+// LoadChangedManifests reads the YAML manifests among a list of changed files, such as
+// the output of `git diff --name-only`. Files that no longer exist were deleted by the
+// change and are skipped, as are kustomization.yaml files and files in hidden
+// directories. When dir is set, only files under it are read. No changed manifests is
+// not an error.
+func LoadChangedManifests(paths []string, dir string) ([]ManifestFile, error) {
+	var files []ManifestFile
+	seen := make(map[string]bool)
+
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if seen[path] || !IsManifestFile(filepath.Base(path)) {
+			continue
+		}
+		seen[path] = true
+		// Hidden directories are judged below dir, which may itself be in one
+		rel := path
+		if dir != "" {
+			var ok bool
+			if rel, ok = relativePath(dir, path); !ok {
+				continue
+			}
+		}
+		if hiddenDirectory(rel) {
+			continue
+		}
+
+		data, err := os.ReadFile(path) // nolint:gosec // Changed file list is user-provided
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+		}
+		files = append(files, ManifestFile{Path: path, Data: data})
+	}
+
+	return files, nil
+}
+
+// relativePath returns the path relative to dir, and false when it is outside dir.
+// Absolute paths are compared, so relative and absolute paths can be mixed.
+func relativePath(dir, path string) (string, bool) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// hiddenDirectory reports whether a relative path is inside a hidden directory
+func hiddenDirectory(path string) bool {
+	parts := strings.Split(filepath.Dir(path), string(filepath.Separator))
+	for _, part := range parts {
+		if strings.HasPrefix(part, ".") && part != "." && part != ".." {
+			return true
+		}
+	}
+	return false
+}
+
+// IsManifestFile reports whether a file name is a rendered YAML manifest rather than
+// a kustomize build configuration
+func IsManifestFile(name string) bool {
+	switch strings.ToLower(name) {
+	case "kustomization.yaml", "kustomization.yml":
+		return false
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// JoinManifests concatenates manifest files into a single multi-document YAML stream
+func JoinManifests(files []ManifestFile) []byte {
+	var joined bytes.Buffer
+	for i, file := range files {
+		if i > 0 {
+			joined.WriteString("\n---\n")
+		}
+		joined.Write(file.Data)
+	}
+	return joined.Bytes()
+}
+
+// isFluxManaged reports whether Flux reconciles an object from rendered Kustomization
+// output. Objects must carry the Kustomization labels Flux adds while building, must
+// not have reconciliation disabled, and must not be Flux's own custom resources.
+func isFluxManaged(obj client.Object) bool {
+	if obj.GetLabels()[FluxKustomizationNameLabel] == "" {
+		return false
+	}
+	if obj.GetAnnotations()[FluxReconcileAnnotation] == "disabled" {
+		return false
+	}
+	return !strings.HasSuffix(obj.GetObjectKind().GroupVersionKind().Group, fluxAPIGroupSuffix)
+}
+
+// ValidateGitOpsManifests validates rendered GitOps manifests against the existing
+// cluster state. It behaves like ValidateNewConfigWithScope over the combined manifests
+// and then attributes each finding to the file and line that defines its resource.
+// Use the "flux-managed" scope to report only resources that Flux reconciles.
+func (r *ValidatorRegistry) ValidateGitOpsManifests(ctx context.Context, files []ManifestFile, scope string) (*ValidationResult, error) {
+	result, err := r.ValidateNewConfigWithScopeAndData(ctx, "-", scope, JoinManifests(files))
+	if err != nil {
+		return nil, err
+	}
+	if err := attributeManifestSources(result.Errors, files); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ValidateFileOnlyManifests validates manifest files without any cluster context, like
+// ValidateFileOnlyData over the combined manifests, and attributes each finding to the
+// file and line that defines its resource
+func (r *ValidatorRegistry) ValidateFileOnlyManifests(ctx context.Context, files []ManifestFile) (*ValidationResult, error) {
+	result, err := r.ValidateFileOnlyData(ctx, "", JoinManifests(files))
+	if err != nil {
+		return nil, err
+	}
+	if err := attributeManifestSources(result.Errors, files); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attributeManifestSources attributes findings to the manifest file and line that
+// defines their resource, replacing any attribution to the combined manifests
+func attributeManifestSources(errors []ValidationError, files []ManifestFile) error {
+	for i := range errors {
+		errors[i].SourceFile = ""
+		errors[i].SourceLine = 0
+	}
+	for _, file := range files {
+		documents, err := parseConfigDocuments(file.Data)
+		if err != nil {
+			return fmt.Errorf("failed to parse manifest %s: %w", file.Path, err)
+		}
+		attributeSources(errors, file.Path, documents)
+	}
+	return nil
+}
+
+// NewManifestClient creates a client that serves only the objects defined in manifest
+// files, such as the expected state of a cluster kept in Git. It is the read-only
+// counterpart of the file-only client used to validate new configuration.
+func NewManifestClient(files []ManifestFile) (client.Client, error) {
+	documents, err := parseConfigDocuments(JoinManifests(files))
+	if err != nil {
+		return nil, err
+	}
+	objects, findings := decodeConfigObjects(documents)
+	for _, finding := range findings {
+		if finding.ValidationType == "invalid_config_object" {
+			return nil, errors.New(finding.Message)
+		}
+	}
+
+	seen := make(map[string]bool, len(objects))
+	for _, obj := range objects {
+		key := obj.GetObjectKind().GroupVersionKind().GroupKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+		if seen[key] {
+			return nil, fmt.Errorf("%s is defined more than once", key)
+		}
+		seen[key] = true
+	}
+
+	return withFieldIndexes(fake.NewClientBuilder()).WithObjects(objects...).Build(), nil
+}
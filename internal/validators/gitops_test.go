@@ -0,0 +1,242 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// gitopsDeployment renders a Deployment manifest with optional extra metadata lines
+func gitopsDeployment(name, metadata string) string {
+	return `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: ` + name + `
+  namespace: team-a
+` + metadata + `spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: ` + name + `
+  template:
+    metadata:
+      labels:
+        app: ` + name + `
+    spec:
+      containers:
+      - name: app
+        image: nginx
+`
+}
+
+func writeManifest(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadManifestDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "apps", "web.yaml"), gitopsDeployment("web", ""))
+	writeManifest(t, filepath.Join(dir, "apps", "api.yml"), gitopsDeployment("api", ""))
+	writeManifest(t, filepath.Join(dir, "kustomization.yaml"), "resources:\n- apps\n")
+	writeManifest(t, filepath.Join(dir, ".git", "config.yaml"), "ignored: true\n")
+	writeManifest(t, filepath.Join(dir, "README.md"), "# rendered output\n")
+
+	files, err := LoadManifestDirectory(dir)
+	if err != nil {
+		t.Fatalf("LoadManifestDirectory() error = %v", err)
+	}
+
+	var paths []string
+	for _, file := range files {
+		rel, _ := filepath.Rel(dir, file.Path)
+		paths = append(paths, rel)
+	}
+	if got := strings.Join(paths, ","); got != "apps/api.yml,apps/web.yaml" {
+		t.Errorf("loaded files = %s", got)
+	}
+
+	if _, err := LoadManifestDirectory(t.TempDir()); err == nil {
+		t.Error("expected an error for a directory without manifests")
+	}
+}
+
+func TestLoadChangedManifests(t *testing.T) {
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "apps", "web.yaml"), gitopsDeployment("web", ""))
+	writeManifest(t, filepath.Join(dir, "apps", "api.yml"), gitopsDeployment("api", ""))
+	writeManifest(t, filepath.Join(dir, "apps", "kustomization.yaml"), "resources:\n- web.yaml\n")
+	writeManifest(t, filepath.Join(dir, ".github", "workflows", "ci.yaml"), "on: push\n")
+	writeManifest(t, filepath.Join(dir, "docs", "values.yaml"), "replicas: 1\n")
+
+	changed := []string{
+		filepath.Join(dir, "apps", "web.yaml"),
+		filepath.Join(dir, "apps", "web.yaml"),
+		filepath.Join(dir, "apps", "kustomization.yaml"),
+		filepath.Join(dir, "apps", "deleted.yaml"),
+		filepath.Join(dir, ".github", "workflows", "ci.yaml"),
+		filepath.Join(dir, "docs", "values.yaml"),
+		filepath.Join(dir, "main.go"),
+	}
+	files, err := LoadChangedManifests(changed, filepath.Join(dir, "apps"))
+	if err != nil {
+		t.Fatalf("LoadChangedManifests() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Path != filepath.Join(dir, "apps", "web.yaml") {
+		t.Errorf("loaded files = %+v, want only apps/web.yaml", files)
+	}
+
+	// Without a directory every changed manifest outside hidden directories is read
+	files, err = LoadChangedManifests(changed, "")
+	if err != nil {
+		t.Fatalf("LoadChangedManifests() error = %v", err)
+	}
+	if len(files) != 2 || files[1].Path != filepath.Join(dir, "docs", "values.yaml") {
+		t.Errorf("loaded files = %+v, want apps/web.yaml and docs/values.yaml", files)
+	}
+}
+
+func TestValidateGitOpsManifests(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")},
+				},
+				Status: corev1.ResourceQuotaStatus{
+					Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")},
+				},
+			},
+		).
+		Build()
+
+	fluxLabels := "  labels:\n    kustomize.toolkit.fluxcd.io/name: apps\n    kustomize.toolkit.fluxcd.io/namespace: flux-system\n"
+	files := []ManifestFile{
+		{Path: "rendered/managed.yaml", Data: []byte("# managed by Flux\n" + gitopsDeployment("managed", fluxLabels))},
+		{Path: "rendered/other.yaml", Data: []byte(gitopsDeployment("unmanaged", "") + "---\n" +
+			gitopsDeployment("disabled", fluxLabels+"  annotations:\n    kustomize.toolkit.fluxcd.io/reconcile: disabled\n"))},
+	}
+
+	tests := []struct {
+		scope string
+		want  []string
+	}{
+		{scope: "flux-managed", want: []string{"managed"}},
+		{scope: "file-only", want: []string{"managed", "unmanaged", "disabled"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.scope, func(t *testing.T) {
+			registry := NewValidatorRegistry(logr.Discard(), clusterClient)
+			registry.Register(NewQuotaValidator(nil, logr.Discard(), QuotaConfig{EnableQuotaCapacityValidation: true}))
+
+			result, err := registry.ValidateGitOpsManifests(context.Background(), files, tt.scope)
+			if err != nil {
+				t.Fatalf("ValidateGitOpsManifests() error = %v", err)
+			}
+
+			found := make(map[string]ValidationError)
+			for _, ve := range result.Errors {
+				found[ve.ResourceName] = ve
+			}
+			if len(found) != len(tt.want) {
+				t.Errorf("expected findings for %v, got %+v", tt.want, result.Errors)
+			}
+			for _, name := range tt.want {
+				if _, ok := found[name]; !ok {
+					t.Errorf("expected a finding for %s", name)
+				}
+			}
+
+			if managed, ok := found["managed"]; ok {
+				if managed.SourceFile != "rendered/managed.yaml" || managed.SourceLine != 2 {
+					t.Errorf("managed finding attributed to %s:%d", managed.SourceFile, managed.SourceLine)
+				}
+			}
+			if disabled, ok := found["disabled"]; ok {
+				if disabled.SourceFile != "rendered/other.yaml" || disabled.SourceLine <= 1 {
+					t.Errorf("disabled finding attributed to %s:%d", disabled.SourceFile, disabled.SourceLine)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateFileOnlyManifests(t *testing.T) {
+	// The registry's cluster client is never used
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(NewResourceLimitsValidator(nil, logr.Discard(), ResourceLimitsConfig{EnableMissingRequestsValidation: true}))
+
+	files := []ManifestFile{
+		{Path: "oci://ghcr.io/acme/manifests:v1/web.yaml", Data: []byte(gitopsDeployment("web", ""))},
+		{Path: "oci://ghcr.io/acme/manifests:v1/api.yaml", Data: []byte("# api\n" + gitopsDeployment("api", ""))},
+	}
+	result, err := registry.ValidateFileOnlyManifests(context.Background(), files)
+	if err != nil {
+		t.Fatalf("ValidateFileOnlyManifests() error = %v", err)
+	}
+
+	sources := make(map[string]string)
+	for _, ve := range result.Errors {
+		sources[ve.ResourceName] = ve.SourceFile + ":" + strconv.Itoa(ve.SourceLine)
+	}
+	want := map[string]string{
+		"web": "oci://ghcr.io/acme/manifests:v1/web.yaml:1",
+		"api": "oci://ghcr.io/acme/manifests:v1/api.yaml:2",
+	}
+	if len(sources) != len(want) || sources["web"] != want["web"] || sources["api"] != want["api"] {
+		t.Errorf("findings attributed to %v, want %v", sources, want)
+	}
+}
+
+func TestNewManifestClient(t *testing.T) {
+	files := []ManifestFile{
+		{Path: "web.yaml", Data: []byte(gitopsDeployment("web", ""))},
+		{Path: "api.yaml", Data: []byte(gitopsDeployment("api", ""))},
+	}
+	manifests, err := NewManifestClient(files)
+	if err != nil {
+		t.Fatalf("NewManifestClient() error = %v", err)
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := manifests.List(context.Background(), &deployments); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(deployments.Items) != 2 || deployments.Items[0].Spec.Template.Spec.Containers[0].Image != "nginx" {
+		t.Errorf("List() = %+v, want the two manifest Deployments", deployments.Items)
+	}
+
+	if _, err := NewManifestClient(append(files, files[0])); err == nil {
+		t.Error("expected an error for a Deployment defined twice")
+	}
+}
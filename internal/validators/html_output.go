@@ -0,0 +1,170 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+// clusterScopedSection is the section title of findings on cluster-scoped resources
+const clusterScopedSection = "Cluster-scoped resources"
+
+// htmlReportTemplate renders a standalone HTML page. Styles are inline so the report
+// also renders in email clients that drop style sheets.
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body style="font-family: sans-serif; color: #24292f;">
+<h1 style="font-size: 20px;">{{.Title}}</h1>
+<p>{{.Summary}}</p>
+<p>Hygiene score: <strong>{{.Score}}/100</strong></p>
+{{- range .Namespaces}}
+<h2 style="font-size: 16px; margin-top: 24px;">{{.Name}}</h2>
+{{- range .Severities}}
+<h3 style="font-size: 14px; color: {{.Color}};">{{.Title}} ({{len .Findings}})</h3>
+<table style="border-collapse: collapse; width: 100%;">
+<tr><th align="left" style="border-bottom: 1px solid #d0d7de; padding: 4px;">Code</th><th align="left" style="border-bottom: 1px solid #d0d7de; padding: 4px;">Resource</th><th align="left" style="border-bottom: 1px solid #d0d7de; padding: 4px;">Message</th><th align="left" style="border-bottom: 1px solid #d0d7de; padding: 4px;">Hint</th></tr>
+{{- range .Findings}}
+<tr><td style="padding: 4px; white-space: nowrap;">{{if .DocURL}}<a href="{{.DocURL}}">{{.Code}}</a>{{else}}{{.Code}}{{end}}</td><td style="padding: 4px;"><code>{{.Resource}}</code></td><td style="padding: 4px;">{{.Message}}</td><td style="padding: 4px;">{{.Hint}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// htmlSeverities are the severity sections of the HTML report, most severe first
+var htmlSeverities = []struct {
+	severity Severity
+	title    string
+	color    string
+}{
+	{SeverityError, "Errors", "#cf222e"},
+	{SeverityWarning, "Warnings", "#9a6700"},
+	{SeverityInfo, "Info", "#0969da"},
+}
+
+type htmlReport struct {
+	Title      string
+	Summary    string
+	Score      int
+	Namespaces []htmlNamespace
+}
+
+type htmlNamespace struct {
+	Name       string
+	Severities []htmlSeverity
+}
+
+type htmlSeverity struct {
+	Title    string
+	Color    string
+	Findings []htmlFinding
+}
+
+type htmlFinding struct {
+	Code     string
+	DocURL   string
+	Resource string
+	Message  string
+	Hint     string
+}
+
+// FormatHTMLOutput formats validation results as a standalone HTML report
+func (r *ValidatorRegistry) FormatHTMLOutput(result ValidationResult) (string, error) {
+	return formatHTMLReport("Kogaro Validation Results", result.Errors, result.Summary.HygieneScore)
+}
+
+// FormatHTMLReport renders findings as a standalone HTML page with the given title.
+// Findings are grouped by namespace, with cluster-scoped resources last, and then by
+// severity, most severe first. The hygiene score rates the namespaces with findings,
+// weighed by the score policy.
+func FormatHTMLReport(title string, findings []ValidationError, policy *ScorePolicy) (string, error) {
+	return formatHTMLReport(title, findings, policy.ScoreFindings(findings, nil).Cluster)
+}
+
+// formatHTMLReport renders findings as a standalone HTML page headed by their score
+func formatHTMLReport(title string, findings []ValidationError, score int) (string, error) {
+	byNamespace := make(map[string][]ValidationError)
+	for _, ve := range findings {
+		byNamespace[ve.Namespace] = append(byNamespace[ve.Namespace], ve)
+	}
+	namespaces := make([]string, 0, len(byNamespace))
+	for namespace := range byNamespace {
+		if namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	if _, clusterScoped := byNamespace[""]; clusterScoped {
+		namespaces = append(namespaces, "")
+	}
+
+	report := htmlReport{Title: title, Summary: "No findings.", Score: score}
+	if len(findings) > 0 {
+		errors, warnings, info := countSeverities(findings)
+		report.Summary = fmt.Sprintf("%d findings: %d errors, %d warnings, %d info", len(findings), errors, warnings, info)
+	}
+	for _, namespace := range namespaces {
+		section := htmlNamespace{Name: namespace}
+		if namespace == "" {
+			section.Name = clusterScopedSection
+		}
+
+		sorted := make([]ValidationError, len(byNamespace[namespace]))
+		copy(sorted, byNamespace[namespace])
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return findingKey(sorted[i]) < findingKey(sorted[j])
+		})
+		for _, level := range htmlSeverities {
+			severity := htmlSeverity{Title: level.title, Color: level.color}
+			for _, ve := range sorted {
+				if effectiveSeverity(ve) != level.severity {
+					continue
+				}
+				finding := htmlFinding{
+					Code:     ve.ErrorCode,
+					Resource: ve.ResourceType + "/" + ve.ResourceName,
+					Message:  ve.Message,
+					Hint:     ve.RemediationHint,
+				}
+				if info, ok := LookupErrorCode(ve.ErrorCode); ok {
+					finding.DocURL = info.DocURL
+				}
+				severity.Findings = append(severity.Findings, finding)
+			}
+			if len(severity.Findings) > 0 {
+				section.Severities = append(section.Severities, severity)
+			}
+		}
+		report.Namespaces = append(report.Namespaces, section)
+	}
+
+	var output strings.Builder
+	if err := htmlReportTemplate.Execute(&output, report); err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return output.String(), nil
+}
+
+// effectiveSeverity returns the severity of a finding, treating findings without one
+// as errors like NewValidationErrorWithCode does
+func effectiveSeverity(ve ValidationError) Severity {
+	switch {
+	case ve.IsWarning():
+		return SeverityWarning
+	case ve.IsInfo():
+		return SeverityInfo
+	}
+	return SeverityError
+}
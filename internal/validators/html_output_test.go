@@ -0,0 +1,64 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatHTMLReport(t *testing.T) {
+	findings := []ValidationError{
+		NewValidationErrorWithCode("StorageClass", "fast", "", "missing_storage_class", "KOGARO-REF-009", "StorageClass 'fast' is unused").
+			WithSeverity(SeverityWarning),
+		NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap <settings> does not exist"),
+		NewValidationErrorWithCode("Pod", "api", "billing", "missing_resource_requests", "KOGARO-RES-002", "no requests").
+			WithSeverity(SeverityInfo),
+		NewValidationErrorWithCode("Pod", "cache", "shop", "missing_resource_limits", "KOGARO-RES-003", "no limits").
+			WithSeverity(SeverityWarning),
+	}
+
+	output, err := FormatHTMLReport("Digest", findings, nil)
+	if err != nil {
+		t.Fatalf("FormatHTMLReport() error = %v", err)
+	}
+
+	if !strings.Contains(output, "4 findings: 1 errors, 2 warnings, 1 info") {
+		t.Error("summary is missing")
+	}
+	if !strings.Contains(output, "Hygiene score: <strong>92/100</strong>") {
+		t.Error("hygiene score is missing")
+	}
+	// Namespaces are sorted, with cluster-scoped resources last
+	order := []string{"<h2", ">billing<", ">shop<", ">Errors (1)<", ">Warnings (1)<", ">" + clusterScopedSection + "<"}
+	position := 0
+	for _, part := range order {
+		index := strings.Index(output[position:], part)
+		if index < 0 {
+			t.Fatalf("%q is missing or out of order in:\n%s", part, output)
+		}
+		position += index
+	}
+	if !strings.Contains(output, "ConfigMap &lt;settings&gt; does not exist") {
+		t.Error("message is not escaped")
+	}
+	if info, ok := LookupErrorCode("KOGARO-REF-003"); ok && !strings.Contains(output, `<a href="`+info.DocURL+`">KOGARO-REF-003</a>`) {
+		t.Error("error code does not link to its documentation")
+	}
+	if !strings.Contains(output, "color: #cf222e;") {
+		t.Error("severity color is not rendered")
+	}
+}
+
+func TestFormatHTMLReport_NoFindings(t *testing.T) {
+	output, err := FormatHTMLReport("Digest", nil, nil)
+	if err != nil {
+		t.Fatalf("FormatHTMLReport() error = %v", err)
+	}
+	if !strings.Contains(output, "<p>No findings.</p>") || strings.Contains(output, "<h2") {
+		t.Errorf("unexpected report:\n%s", output)
+	}
+}
@@ -49,7 +49,7 @@ func NewImageValidator(client client.Client, k8sClient kubernetes.Interface, log
 		k8sClient:    k8sClient,
 		log:          log,
 		config:       config,
-		sharedConfig: DefaultSharedConfig(),
+		sharedConfig: ActiveSharedConfig(),
 	}
 }
 
@@ -145,21 +145,15 @@ func (v *ImageValidator) validateDeploymentImages(ctx context.Context, nodeArchi
 
 func (v *ImageValidator) validatePodImages(ctx context.Context, nodeArchitectures map[string]bool) ([]ValidationError, error) {
 	var errors []ValidationError
-	var pods corev1.PodList
-
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	for _, pod := range pods.Items {
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
 		// Skip system namespaces
 		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
+			return nil
 		}
 
 		// Skip pods managed by controllers (they're validated via their controllers)
 		if len(pod.OwnerReferences) > 0 {
-			continue
+			return nil
 		}
 
 		// Validate main containers
@@ -169,6 +163,10 @@ func (v *ImageValidator) validatePodImages(ctx context.Context, nodeArchitecture
 		// Validate init containers
 		initContainerErrors := v.validateContainerImages(pod.Spec.InitContainers, "Pod", pod.Name, pod.Namespace, nodeArchitectures)
 		errors = append(errors, initContainerErrors...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	return errors, nil
@@ -181,7 +179,7 @@ func (v *ImageValidator) validateContainerImages(containers []corev1.Container,
 		// Parse image reference
 		ref, err := reference.Parse(container.Image)
 		if err != nil {
-			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "invalid_image_reference", "KOGARO-IMG-001", fmt.Sprintf("Container '%s' has invalid image reference: %s", container.Name, container.Image)).
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "invalid_image_reference", GetImageErrorCode("invalid_image_reference"), fmt.Sprintf("Container '%s' has invalid image reference: %s", container.Name, container.Image)).
 				WithSeverity(SeverityError).
 				WithRemediationHint("Fix the image reference format").
 				WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
@@ -199,14 +197,14 @@ func (v *ImageValidator) validateContainerImages(containers []corev1.Container,
 
 		if !imageExists {
 			if !v.config.AllowMissingImages {
-				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "missing_image", "KOGARO-IMG-002", fmt.Sprintf("Container '%s' references non-existent image: %s", container.Name, container.Image)).
+				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "missing_image", GetImageErrorCode("missing_image"), fmt.Sprintf("Container '%s' references non-existent image: %s", container.Name, container.Image)).
 					WithSeverity(SeverityError).
 					WithRemediationHint("Ensure the image exists in the registry or set allowMissingImages: true to proceed").
 					WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 					WithDetail("container_name", container.Name).
 					WithDetail("image", container.Image))
 			} else {
-				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "missing_image_warning", "KOGARO-IMG-003", fmt.Sprintf("Container '%s' references non-existent image: %s (deployment allowed)", container.Name, container.Image)).
+				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "missing_image_warning", GetImageErrorCode("missing_image_warning"), fmt.Sprintf("Container '%s' references non-existent image: %s (deployment allowed)", container.Name, container.Image)).
 					WithSeverity(SeverityWarning).
 					WithRemediationHint("Ensure the image will be available before deployment").
 					WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
@@ -225,7 +223,7 @@ func (v *ImageValidator) validateContainerImages(containers []corev1.Container,
 
 			if !nodeArchitectures[arch] {
 				if !v.config.AllowArchitectureMismatch {
-					errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "architecture_mismatch", "KOGARO-IMG-004", fmt.Sprintf("Container '%s' image architecture (%s) is not compatible with any node in the cluster", container.Name, arch)).
+					errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "architecture_mismatch", GetImageErrorCode("architecture_mismatch"), fmt.Sprintf("Container '%s' image architecture (%s) is not compatible with any node in the cluster", container.Name, arch)).
 						WithSeverity(SeverityError).
 						WithRemediationHint("Use a multi-arch image or set allowArchitectureMismatch: true to proceed").
 						WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
@@ -234,7 +232,7 @@ func (v *ImageValidator) validateContainerImages(containers []corev1.Container,
 						WithDetail("image_architecture", arch).
 						WithDetail("node_architectures", strings.Join(getKeys(nodeArchitectures), ", ")))
 				} else {
-					errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "architecture_mismatch_warning", "KOGARO-IMG-005", fmt.Sprintf("Container '%s' image architecture (%s) is not compatible with any node in the cluster (deployment allowed)", container.Name, arch)).
+					errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "architecture_mismatch_warning", GetImageErrorCode("architecture_mismatch_warning"), fmt.Sprintf("Container '%s' image architecture (%s) is not compatible with any node in the cluster (deployment allowed)", container.Name, arch)).
 						WithSeverity(SeverityWarning).
 						WithRemediationHint("Ensure the image will be available for the node architecture before deployment").
 						WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
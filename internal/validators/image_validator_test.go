@@ -257,7 +257,7 @@ func TestImageValidator_ValidateCluster(t *testing.T) {
 					errorCode = "KOGARO-IMG-001"
 				}
 
-				validationErrors, err := metrics.ValidationErrors.GetMetricWithLabelValues("Deployment", expectedError, "test-namespace", "test-deployment", severity, "application", expectedPattern, errorCode)
+				validationErrors, err := metrics.ValidationErrors.GetMetricWithLabelValues("Deployment", expectedError, "test-namespace", "test-deployment", severity, "application", expectedPattern, errorCode, "")
 				if err != nil {
 					t.Fatalf("failed to get validation errors metric for %s: %v", expectedError, err)
 				}
@@ -0,0 +1,106 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// EndpointSliceServiceIndex indexes EndpointSlices by the name of their Service
+const EndpointSliceServiceIndex = "kogaro.io/service-name"
+
+// fieldIndex is a field index validators look objects up by
+type fieldIndex struct {
+	object  client.Object
+	field   string
+	extract client.IndexerFunc
+}
+
+// fieldIndexes are the field indexes registered on the manager cache. Pods and
+// NetworkPolicies are looked up by namespace through the namespace index every
+// cache keeps, so they need none.
+var fieldIndexes = []fieldIndex{
+	{
+		object: &discoveryv1.EndpointSlice{},
+		field:  EndpointSliceServiceIndex,
+		extract: func(obj client.Object) []string {
+			if name, ok := obj.GetLabels()[discoveryv1.LabelServiceName]; ok {
+				return []string{name}
+			}
+			return nil
+		},
+	},
+}
+
+// fieldIndexesRegistered records whether lookups may select by the field indexes
+var fieldIndexesRegistered atomic.Bool
+
+// RegisterFieldIndexes registers the field indexes validators look objects up by,
+// so that they list the objects they need instead of every object of a kind. It
+// must be called before the cache starts; until it is, lookups select by label.
+func RegisterFieldIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, index := range fieldIndexes {
+		if err := indexer.IndexField(ctx, index.object, index.field, index.extract); err != nil {
+			return fmt.Errorf("failed to index %T by %s: %w", index.object, index.field, err)
+		}
+	}
+	fieldIndexesRegistered.Store(true)
+	return nil
+}
+
+// withFieldIndexes registers the field indexes on a fake client, so that the
+// clients serving manifest files answer the same lookups as the cache
+func withFieldIndexes(builder *fake.ClientBuilder) *fake.ClientBuilder {
+	for _, index := range fieldIndexes {
+		builder = builder.WithIndex(index.object, index.field, index.extract)
+	}
+	return builder
+}
+
+// listServiceEndpointSlices returns the EndpointSlices of a Service
+func listServiceEndpointSlices(ctx context.Context, reader client.Reader, namespace, name string) ([]discoveryv1.EndpointSlice, error) {
+	selector := client.ListOption(client.MatchingLabels{discoveryv1.LabelServiceName: name})
+	if fieldIndexesRegistered.Load() {
+		selector = client.MatchingFields{EndpointSliceServiceIndex: name}
+	}
+	var endpointSlices discoveryv1.EndpointSliceList
+	if err := reader.List(ctx, &endpointSlices, client.InNamespace(namespace), selector); err != nil {
+		return nil, err
+	}
+	return endpointSlices.Items, nil
+}
+
+// namespacePods looks up the Pods of namespaces as a scan needs them, listing each
+// namespace once
+type namespacePods struct {
+	reader client.Reader
+	pods   map[string][]corev1.Pod
+}
+
+// newNamespacePods creates a lookup of the Pods of namespaces for one scan
+func newNamespacePods(reader client.Reader) *namespacePods {
+	return &namespacePods{reader: reader, pods: make(map[string][]corev1.Pod)}
+}
+
+// get returns the Pods of a namespace
+func (n *namespacePods) get(ctx context.Context, namespace string) ([]corev1.Pod, error) {
+	if pods, ok := n.pods[namespace]; ok {
+		return pods, nil
+	}
+	pods, err := listPods(ctx, n.reader, client.InNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+	n.pods[namespace] = pods
+	return pods, nil
+}
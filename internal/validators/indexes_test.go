@@ -0,0 +1,89 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// recordingIndexer records the fields indexed on it
+type recordingIndexer struct {
+	fields []string
+}
+
+func (r *recordingIndexer) IndexField(_ context.Context, _ client.Object, field string, _ client.IndexerFunc) error {
+	r.fields = append(r.fields, field)
+	return nil
+}
+
+func TestListServiceEndpointSlices(t *testing.T) {
+	slice := func(namespace, name, service string) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: namespace, Labels: map[string]string{discoveryv1.LabelServiceName: service},
+		}}
+	}
+	fakeClient := withFieldIndexes(fake.NewClientBuilder()).WithObjects(
+		slice("shop", "web-abc", "web"),
+		slice("shop", "web-def", "web"),
+		slice("shop", "api-abc", "api"),
+		slice("blog", "web-abc", "web"),
+	).Build()
+	ctx := context.Background()
+
+	lookup := func() {
+		t.Helper()
+		slices, err := listServiceEndpointSlices(ctx, fakeClient, "shop", "web")
+		if err != nil {
+			t.Fatalf("listServiceEndpointSlices() error = %v", err)
+		}
+		if len(slices) != 2 {
+			t.Errorf("listServiceEndpointSlices() = %d slices, want the 2 of shop/web", len(slices))
+		}
+	}
+
+	// Before the indexes are registered, slices are selected by label
+	lookup()
+
+	indexer := &recordingIndexer{}
+	if err := RegisterFieldIndexes(ctx, indexer); err != nil {
+		t.Fatalf("RegisterFieldIndexes() error = %v", err)
+	}
+	t.Cleanup(func() { fieldIndexesRegistered.Store(false) })
+	if len(indexer.fields) != 1 || indexer.fields[0] != EndpointSliceServiceIndex {
+		t.Errorf("indexed fields = %v, want %s", indexer.fields, EndpointSliceServiceIndex)
+	}
+	lookup()
+}
+
+func TestNamespacePods_ListsEachNamespaceOnce(t *testing.T) {
+	reader := &pagingReader{pods: []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	}}
+	lookup := newNamespacePods(reader)
+	ctx := context.Background()
+
+	for range 2 {
+		if _, err := lookup.get(ctx, "shop"); err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+	}
+	if _, err := lookup.get(ctx, "blog"); err != nil {
+		t.Fatalf("get() error = %v", err)
+	}
+	if len(reader.requests) != 2 {
+		t.Fatalf("requests = %d, want one per namespace", len(reader.requests))
+	}
+	if reader.requests[0].Namespace != "shop" || reader.requests[1].Namespace != "blog" {
+		t.Errorf("requests = %+v, want shop then blog", reader.requests)
+	}
+}
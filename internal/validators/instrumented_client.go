@@ -0,0 +1,72 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// instrumentedClient wraps a client to count the reads made and the
+// resources listed on behalf of a single validator
+type instrumentedClient struct {
+	client.Client
+	validatorType string
+
+	mu              sync.Mutex
+	resourcesListed int
+}
+
+// newInstrumentedClient creates an instrumentedClient for the given validator type
+func newInstrumentedClient(c client.Client, validatorType string) *instrumentedClient {
+	return &instrumentedClient{
+		Client:        c,
+		validatorType: validatorType,
+	}
+}
+
+// Get retrieves an object and records the read
+func (c *instrumentedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	metrics.ClientReads.WithLabelValues(c.validatorType, "get", c.kindOf(obj)).Inc()
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+// List retrieves a list of objects and records the read and the number of items returned
+func (c *instrumentedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	metrics.ClientReads.WithLabelValues(c.validatorType, "list", strings.TrimSuffix(c.kindOf(list), "List")).Inc()
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.resourcesListed += meta.LenList(list)
+	c.mu.Unlock()
+	return nil
+}
+
+// ResourcesListed returns the number of resources listed through this client
+func (c *instrumentedClient) ResourcesListed() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.resourcesListed
+}
+
+// kindOf resolves the kind of an object for metric labels
+func (c *instrumentedClient) kindOf(obj runtime.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return "unknown"
+	}
+	return gvk.Kind
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+func TestInstrumentedClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "ns"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns"}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s", Namespace: "ns"}},
+		).
+		Build()
+
+	instrumented := newInstrumentedClient(fakeClient, "instrumented_test")
+	listsBefore := testutil.ToFloat64(metrics.ClientReads.WithLabelValues("instrumented_test", "list", "ConfigMap"))
+	getsBefore := testutil.ToFloat64(metrics.ClientReads.WithLabelValues("instrumented_test", "get", "Secret"))
+
+	var configMaps corev1.ConfigMapList
+	if err := instrumented.List(context.Background(), &configMaps); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var secrets corev1.SecretList
+	if err := instrumented.List(context.Background(), &secrets); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var secret corev1.Secret
+	if err := instrumented.Get(context.Background(), client.ObjectKey{Name: "s", Namespace: "ns"}, &secret); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if got := instrumented.ResourcesListed(); got != 3 {
+		t.Errorf("ResourcesListed() = %d, want 3", got)
+	}
+	if got := testutil.ToFloat64(metrics.ClientReads.WithLabelValues("instrumented_test", "list", "ConfigMap")) - listsBefore; got != 1 {
+		t.Errorf("ConfigMap list reads = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.ClientReads.WithLabelValues("instrumented_test", "get", "Secret")) - getsBefore; got != 1 {
+		t.Errorf("Secret get reads = %v, want 1", got)
+	}
+}
+
+func TestValidatorRegistry_RecordsScanMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	validator := &MockValidator{validationType: "scan_metrics_test"}
+	registry.Register(validator)
+
+	before := testutil.CollectAndCount(metrics.ValidatorScanDuration)
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	if _, ok := validator.client.(*instrumentedClient); !ok {
+		t.Errorf("expected validator to receive an instrumented client, got %T", validator.client)
+	}
+	if after := testutil.CollectAndCount(metrics.ValidatorScanDuration); after != before+1 {
+		t.Errorf("expected a new scan duration series for the validator, got %d series (was %d)", after, before)
+	}
+	if got := testutil.CollectAndCount(metrics.ValidatorResourcesListed, "kogaro_validator_resources_listed"); got == 0 {
+		t.Error("expected resources listed to be recorded")
+	}
+}
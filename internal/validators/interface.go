@@ -28,20 +28,34 @@ const (
 // ValidationError represents a validation failure found during cluster scanning
 type ValidationError struct {
 	// Core identification fields
-	ResourceType   string
-	ResourceName   string
-	Namespace      string
-	ValidationType string
-	ErrorCode      string
-	Message        string
+	ResourceType   string `json:"resource_type"`
+	ResourceName   string `json:"resource_name"`
+	Namespace      string `json:"namespace,omitempty"`
+	ValidationType string `json:"validation_type"`
+	ErrorCode      string `json:"error_code,omitempty"`
+	Message        string `json:"message"`
 
 	// Enhanced context fields
-	Severity         Severity
-	RemediationHint  string
-	RelatedResources []string
+	Severity         Severity `json:"severity"`
+	RemediationHint  string   `json:"remediation_hint,omitempty"`
+	RelatedResources []string `json:"related_resources,omitempty"`
 
 	// Additional metadata
-	Details map[string]string
+	Details map[string]string `json:"details,omitempty"`
+
+	// Location of the resource in the validated config file, when known
+	SourceFile string `json:"source_file,omitempty"`
+	SourceLine int    `json:"source_line,omitempty"`
+
+	// Cluster the resource was found in, when Kogaro validates several clusters
+	Cluster string `json:"cluster,omitempty"`
+	// Shard that reported the finding, as "index/count", when replicas split the cluster
+	Shard string `json:"shard,omitempty"`
+	// Team that owns the namespace of the finding, when teams are configured
+	Team string `json:"team,omitempty"`
+
+	// Existing resources the missing reference may have been meant for, most likely first
+	SuggestedRefs []Reference `json:"suggested_refs,omitempty"`
 }
 
 // Error implements the error interface
@@ -77,7 +91,9 @@ func NewValidationErrorWithCode(resourceType, resourceName, namespace, validatio
 	}
 }
 
-// WithSeverity sets the severity level and returns the ValidationError for method chaining
+// WithSeverity sets the severity level and returns the ValidationError for method chaining.
+// The registry reports the finding with the severity of its SeverityPolicy, if it
+// overrides the error's code.
 func (v ValidationError) WithSeverity(severity Severity) ValidationError {
 	v.Severity = severity
 	return v
@@ -104,6 +120,12 @@ func (v ValidationError) WithDetail(key, value string) ValidationError {
 	return v
 }
 
+// WithSuggestedRefs adds suggested references and returns the ValidationError for method chaining
+func (v ValidationError) WithSuggestedRefs(refs ...Reference) ValidationError {
+	v.SuggestedRefs = append(v.SuggestedRefs, refs...)
+	return v
+}
+
 // IsError returns true if the validation error has error severity
 func (v ValidationError) IsError() bool {
 	return v.Severity == SeverityError
@@ -155,16 +177,31 @@ type Validator interface {
 	GetLastValidationErrors() []ValidationError
 }
 
+// ResultSchemaVersion identifies the layout of ValidationResult in structured output.
+// Bump it whenever a field is renamed or removed so consumers can detect the change.
+const ResultSchemaVersion = "kogaro.io/v1"
+
 // ValidationResult represents the result of a validation operation
 type ValidationResult struct {
-	Summary struct {
+	SchemaVersion string `json:"schema_version"`
+	Summary       struct {
 		TotalErrors   int      `json:"total_errors"`
 		MissingRefs   []string `json:"missing_refs,omitempty"`
 		SuggestedRefs []string `json:"suggested_refs,omitempty"`
+		// HygieneScore rates the validated resources from 0 to 100, see ScoreFindings
+		HygieneScore int `json:"hygiene_score"`
+		// NamespaceScores rates each validated namespace from 0 to 100
+		NamespaceScores map[string]int `json:"namespace_scores,omitempty"`
 	} `json:"summary"`
-	Errors        []ValidationError `json:"errors,omitempty"`
+	Errors        []ValidationError `json:"errors"`
 	SuggestedRefs []Reference       `json:"suggested_refs,omitempty"`
-	ExitCode      int               `json:"exit_code"`
+	// References reports where the references made by the resources of a validated
+	// config were resolved; it is only filled in when a config is validated
+	References []ReferenceResolution `json:"references,omitempty"`
+	ExitCode   int                   `json:"exit_code"`
+
+	// scorePolicy weighed the findings in the hygiene scores of the summary
+	scorePolicy *ScorePolicy
 }
 
 // Reference represents a suggested reference between resources
@@ -176,3 +213,8 @@ type Reference struct {
 	Confidence float64 `json:"confidence"`
 	Reason     string  `json:"reason,omitempty"`
 }
+
+// String formats the reference as "SourceType/SourceName -> TargetType/TargetName"
+func (r Reference) String() string {
+	return fmt.Sprintf("%s/%s -> %s/%s", r.SourceType, r.SourceName, r.TargetType, r.TargetName)
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errReadOnlyClient is returned by the write methods of a layeredClient
+var errReadOnlyClient = errors.New("layered client is read-only")
+
+// layeredClient is a read-only client that serves the objects of a config file on top
+// of the live cluster. Reads check the overlay of config objects first and fall back
+// to the cluster client, so validating a new config does not copy the cluster.
+type layeredClient struct {
+	client.Client
+	overlay client.Reader
+}
+
+// newLayeredClient creates a layeredClient whose overlay objects shadow the objects of
+// the same kind, namespace and name in the base client
+func newLayeredClient(overlay client.Reader, base client.Client) *layeredClient {
+	return &layeredClient{
+		Client:  base,
+		overlay: overlay,
+	}
+}
+
+// Get retrieves an object from the overlay, or from the cluster when the overlay does
+// not define it. Kinds that only one of the clients knows are read from that client.
+func (c *layeredClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	overlayErr := c.overlay.Get(ctx, key, obj, opts...)
+	if overlayErr == nil {
+		return nil
+	}
+	if !apierrors.IsNotFound(overlayErr) && !runtime.IsNotRegisteredError(overlayErr) {
+		return overlayErr
+	}
+	err := c.Client.Get(ctx, key, obj, opts...)
+	if runtime.IsNotRegisteredError(err) && apierrors.IsNotFound(overlayErr) {
+		return overlayErr
+	}
+	return err
+}
+
+// List retrieves the cluster's objects merged with the overlay's. Overlay objects replace
+// cluster objects of the same namespace and name and are appended otherwise. Kinds that
+// only one of the clients knows are listed from that client.
+func (c *layeredClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	overlayList, ok := list.DeepCopyObject().(client.ObjectList)
+	if !ok {
+		return fmt.Errorf("unexpected list type %T", list)
+	}
+
+	baseErr := c.Client.List(ctx, list, opts...)
+	if baseErr != nil && !runtime.IsNotRegisteredError(baseErr) {
+		return baseErr
+	}
+
+	if err := c.overlay.List(ctx, overlayList, opts...); err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return baseErr
+		}
+		return err
+	}
+	overlayItems, err := meta.ExtractList(overlayList)
+	if err != nil {
+		return fmt.Errorf("failed to read overlay list: %w", err)
+	}
+	if len(overlayItems) == 0 {
+		return nil
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to read list: %w", err)
+	}
+
+	pending := make(map[client.ObjectKey]runtime.Object, len(overlayItems))
+	for _, item := range overlayItems {
+		key, err := objectKeyOf(item)
+		if err != nil {
+			return err
+		}
+		pending[key] = item
+	}
+
+	merged := make([]runtime.Object, 0, len(items)+len(overlayItems))
+	for _, item := range items {
+		key, err := objectKeyOf(item)
+		if err != nil {
+			return err
+		}
+		if overlayItem, shadowed := pending[key]; shadowed {
+			item = overlayItem
+			delete(pending, key)
+		}
+		merged = append(merged, item)
+	}
+	for _, item := range overlayItems {
+		if key, _ := objectKeyOf(item); pending[key] != nil {
+			merged = append(merged, item)
+		}
+	}
+
+	return meta.SetList(list, merged)
+}
+
+// objectKeyOf returns the namespace and name of a list item
+func objectKeyOf(item runtime.Object) (client.ObjectKey, error) {
+	accessor, err := meta.Accessor(item)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("failed to read list item: %w", err)
+	}
+	return client.ObjectKey{Namespace: accessor.GetNamespace(), Name: accessor.GetName()}, nil
+}
+
+// Create is not supported by the read-only client
+func (c *layeredClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return errReadOnlyClient
+}
+
+// Update is not supported by the read-only client
+func (c *layeredClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return errReadOnlyClient
+}
+
+// Patch is not supported by the read-only client
+func (c *layeredClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return errReadOnlyClient
+}
+
+// Delete is not supported by the read-only client
+func (c *layeredClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return errReadOnlyClient
+}
+
+// DeleteAllOf is not supported by the read-only client
+func (c *layeredClient) DeleteAllOf(context.Context, client.Object, ...client.DeleteAllOfOption) error {
+	return errReadOnlyClient
+}
+
+// Status returns a status writer that only performs server-side dry runs
+func (c *layeredClient) Status() client.SubResourceWriter {
+	return client.NewDryRunClient(c.Client).Status()
+}
+
+// SubResource returns a subresource client that only performs server-side dry runs
+func (c *layeredClient) SubResource(subResource string) client.SubResourceClient {
+	return client.NewDryRunClient(c.Client).SubResource(subResource)
+}
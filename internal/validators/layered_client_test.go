@@ -0,0 +1,111 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLayeredClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	configMap := func(name, value string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Data:       map[string]string{"value": value},
+		}
+	}
+	base := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap("a", "cluster"), configMap("b", "cluster")).Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), base)
+	layered, _ := registry.createTemporaryClient([]byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+  namespace: ns
+data:
+  value: config
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: c
+  namespace: ns
+data:
+  value: config
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: ns
+`))
+	if layered == nil {
+		t.Fatal("createTemporaryClient() returned nil")
+	}
+	ctx := context.Background()
+
+	var configMaps corev1.ConfigMapList
+	if err := layered.List(ctx, &configMaps, client.InNamespace("ns")); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	got := make(map[string]string)
+	for _, cm := range configMaps.Items {
+		got[cm.Name] = cm.Data["value"]
+	}
+	want := map[string]string{"a": "cluster", "b": "config", "c": "config"}
+	if len(configMaps.Items) != len(want) {
+		t.Errorf("List() returned %d items, want %d", len(configMaps.Items), len(want))
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("ConfigMap %s = %q, want %q", name, got[name], value)
+		}
+	}
+
+	var cm corev1.ConfigMap
+	if err := layered.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "a"}, &cm); err != nil || cm.Data["value"] != "cluster" {
+		t.Errorf("Get(a) = %v, %v; want the cluster's ConfigMap", cm.Data, err)
+	}
+	if err := layered.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "b"}, &cm); err != nil || cm.Data["value"] != "config" {
+		t.Errorf("Get(b) = %v, %v; want the config's ConfigMap", cm.Data, err)
+	}
+	if err := layered.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "missing"}, &cm); !apierrors.IsNotFound(err) {
+		t.Errorf("Get(missing) error = %v, want NotFound", err)
+	}
+
+	// Kinds the cluster client does not know are served from the config alone
+	var deployments appsv1.DeploymentList
+	if err := layered.List(ctx, &deployments); err != nil || len(deployments.Items) != 1 {
+		t.Errorf("List(deployments) = %d items, %v; want the config's Deployment", len(deployments.Items), err)
+	}
+	var deployment appsv1.Deployment
+	if err := layered.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "other"}, &deployment); !apierrors.IsNotFound(err) {
+		t.Errorf("Get(other deployment) error = %v, want NotFound", err)
+	}
+
+	if err := layered.Create(ctx, configMap("d", "new")); !errors.Is(err, errReadOnlyClient) {
+		t.Errorf("Create() error = %v, want errReadOnlyClient", err)
+	}
+	if err := layered.Delete(ctx, configMap("a", "")); !errors.Is(err, errReadOnlyClient) {
+		t.Errorf("Delete() error = %v, want errReadOnlyClient", err)
+	}
+	if err := base.Get(ctx, client.ObjectKey{Namespace: "ns", Name: "a"}, &cm); err != nil {
+		t.Errorf("cluster ConfigMap was modified: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides ownerReference and garbage-collection validation functionality.
+//
+// This package implements validation of the ownership links the garbage collector
+// relies on: ReplicaSets scaled to zero that no controller owns any more,
+// ownerReferences whose owner UID no longer exists, and ownerReferences to an owner
+// in another namespace, which Kubernetes does not support.
+package validators
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// LifecycleConfig defines which lifecycle validation checks to perform
+type LifecycleConfig struct {
+	EnableOrphanedReplicaSetValidation bool
+	EnableOwnerReferenceValidation     bool
+}
+
+// LifecycleValidator validates ownerReferences and leftovers of garbage collection
+type LifecycleValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               LifecycleConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// ownerLocation is where an owner with a given UID lives
+type ownerLocation struct {
+	namespace string
+	name      string
+}
+
+// ownerIndex maps owner kinds to the locations of their objects by UID
+type ownerIndex map[schema.GroupKind]map[types.UID]ownerLocation
+
+// NewLifecycleValidator creates a new LifecycleValidator with the given client, logger and config
+func NewLifecycleValidator(client client.Client, log logr.Logger, config LifecycleConfig) *LifecycleValidator {
+	return &LifecycleValidator{
+		client:       client,
+		log:          log.WithName("lifecycle-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *LifecycleValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *LifecycleValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *LifecycleValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for lifecycle validation
+func (v *LifecycleValidator) GetValidationType() string {
+	return "lifecycle_validation"
+}
+
+// ValidateCluster performs ownerReference and garbage-collection validation across the cluster
+func (v *LifecycleValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	// Owners may lie in namespaces of other shards, which must be told apart from
+	// owners that no longer exist
+	reader := allShards(v.client)
+
+	var deployments appsv1.DeploymentList
+	if err := reader.List(ctx, &deployments); err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	var replicaSets appsv1.ReplicaSetList
+	if err := reader.List(ctx, &replicaSets); err != nil {
+		return fmt.Errorf("failed to list replicasets: %w", err)
+	}
+	var statefulSets appsv1.StatefulSetList
+	if err := reader.List(ctx, &statefulSets); err != nil {
+		return fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	var daemonSets appsv1.DaemonSetList
+	if err := reader.List(ctx, &daemonSets); err != nil {
+		return fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	var jobs batchv1.JobList
+	if err := reader.List(ctx, &jobs); err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	var cronJobs batchv1.CronJobList
+	if err := reader.List(ctx, &cronJobs); err != nil {
+		return fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+
+	var allErrors []ValidationError
+
+	if v.config.EnableOrphanedReplicaSetValidation {
+		allErrors = append(allErrors, v.validateOrphanedReplicaSets(replicaSets.Items)...)
+	}
+
+	if v.config.EnableOwnerReferenceValidation {
+		owners := ownerIndex{}
+		for i := range deployments.Items {
+			owners.add(appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind(), &deployments.Items[i])
+		}
+		for i := range replicaSets.Items {
+			owners.add(appsv1.SchemeGroupVersion.WithKind("ReplicaSet").GroupKind(), &replicaSets.Items[i])
+		}
+		for i := range statefulSets.Items {
+			owners.add(appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind(), &statefulSets.Items[i])
+		}
+		for i := range daemonSets.Items {
+			owners.add(appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind(), &daemonSets.Items[i])
+		}
+		for i := range jobs.Items {
+			owners.add(batchv1.SchemeGroupVersion.WithKind("Job").GroupKind(), &jobs.Items[i])
+		}
+		for i := range cronJobs.Items {
+			owners.add(batchv1.SchemeGroupVersion.WithKind("CronJob").GroupKind(), &cronJobs.Items[i])
+		}
+
+		for i := range replicaSets.Items {
+			allErrors = append(allErrors, v.validateOwnerReferences("ReplicaSet", &replicaSets.Items[i], owners)...)
+		}
+		for i := range jobs.Items {
+			allErrors = append(allErrors, v.validateOwnerReferences("Job", &jobs.Items[i], owners)...)
+		}
+		// Only the owner references of Pods are read, so their specs are not listed
+		err := forEachPodMetadata(ctx, v.client, func(pod metav1.Object) error {
+			allErrors = append(allErrors, v.validateOwnerReferences("Pod", pod, owners)...)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list pods: %w", err)
+		}
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "lifecycle", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "lifecycle", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// add records the location of an owner
+func (o ownerIndex) add(kind schema.GroupKind, obj metav1.Object) {
+	if o[kind] == nil {
+		o[kind] = make(map[types.UID]ownerLocation)
+	}
+	o[kind][obj.GetUID()] = ownerLocation{namespace: obj.GetNamespace(), name: obj.GetName()}
+}
+
+// validateOrphanedReplicaSets finds ReplicaSets scaled to zero that no controller owns.
+// ReplicaSets kept by a Deployment as rollout history are expected and not reported.
+func (v *LifecycleValidator) validateOrphanedReplicaSets(replicaSets []appsv1.ReplicaSet) []ValidationError {
+	var errors []ValidationError
+
+	for _, replicaSet := range replicaSets {
+		if v.sharedConfig.IsSystemNamespace(replicaSet.Namespace) {
+			continue
+		}
+		if replicaSet.Spec.Replicas == nil || *replicaSet.Spec.Replicas != 0 || replicaSet.Status.Replicas != 0 {
+			continue
+		}
+		if metav1.GetControllerOf(&replicaSet) != nil {
+			continue
+		}
+
+		errorCode := GetLifecycleErrorCode("orphaned_replicaset")
+		errors = append(errors, NewValidationErrorWithCode("ReplicaSet", replicaSet.Name, replicaSet.Namespace, "orphaned_replicaset", errorCode,
+			fmt.Sprintf("ReplicaSet '%s' has zero replicas and is not owned by any controller", replicaSet.Name)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Delete the ReplicaSet if it is no longer needed; it was likely left behind when its Deployment was deleted with --cascade=orphan").
+			WithDetail("created", replicaSet.CreationTimestamp.UTC().Format("2006-01-02T15:04:05Z")))
+	}
+
+	return errors
+}
+
+// validateOwnerReferences checks that each known owner of an object exists in the
+// object's namespace. Owners of kinds the validator does not index are not checked.
+func (v *LifecycleValidator) validateOwnerReferences(resourceType string, obj metav1.Object, owners ownerIndex) []ValidationError {
+	var errors []ValidationError
+
+	if v.sharedConfig.IsSystemNamespace(obj.GetNamespace()) {
+		return nil
+	}
+
+	for _, ref := range obj.GetOwnerReferences() {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			continue
+		}
+		byUID, indexed := owners[gv.WithKind(ref.Kind).GroupKind()]
+		if !indexed {
+			continue
+		}
+		owner := fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+
+		location, exists := byUID[ref.UID]
+		switch {
+		case !exists:
+			errorCode := GetLifecycleErrorCode("dangling_owner_reference")
+			errors = append(errors, NewValidationErrorWithCode(resourceType, obj.GetName(), obj.GetNamespace(), "dangling_owner_reference", errorCode,
+				fmt.Sprintf("%s '%s' has an ownerReference to %s with UID %s, which no longer exists", resourceType, obj.GetName(), owner, ref.UID)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Remove the stale ownerReference, or delete the resource if its owner was deleted intentionally").
+				WithRelatedResources(owner).
+				WithDetail("owner_kind", ref.Kind).
+				WithDetail("owner_name", ref.Name).
+				WithDetail("owner_uid", string(ref.UID)))
+		case location.namespace != obj.GetNamespace():
+			errorCode := GetLifecycleErrorCode("cross_namespace_owner_reference")
+			errors = append(errors, NewValidationErrorWithCode(resourceType, obj.GetName(), obj.GetNamespace(), "cross_namespace_owner_reference", errorCode,
+				fmt.Sprintf("%s '%s' has an ownerReference to %s in namespace '%s'; owners must be in the same namespace", resourceType, obj.GetName(), owner, location.namespace)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Remove the ownerReference; the garbage collector treats cross-namespace owners as absent and may delete the resource").
+				WithRelatedResources(fmt.Sprintf("%s/%s/%s", ref.Kind, location.namespace, location.name)).
+				WithDetail("owner_kind", ref.Kind).
+				WithDetail("owner_name", location.name).
+				WithDetail("owner_namespace", location.namespace).
+				WithDetail("owner_uid", string(ref.UID)))
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLifecycleValidator_GetValidationType(t *testing.T) {
+	validator := NewLifecycleValidator(nil, logr.Discard(), LifecycleConfig{})
+	if got := validator.GetValidationType(); got != "lifecycle_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "lifecycle_validation")
+	}
+}
+
+func TestLifecycleValidator_ValidateCluster(t *testing.T) {
+	ownerRef := func(apiVersion, kind, name string, uid types.UID) metav1.OwnerReference {
+		controller := true
+		return metav1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: uid, Controller: &controller}
+	}
+	deployment := func(name, namespace string, uid types.UID) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: uid}}
+	}
+	replicaSet := func(name string, replicas int32, owners ...metav1.OwnerReference) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns", UID: types.UID(name + "-uid"), OwnerReferences: owners},
+			Spec:       appsv1.ReplicaSetSpec{Replicas: int32Ptr(replicas)},
+			Status:     appsv1.ReplicaSetStatus{Replicas: replicas},
+		}
+	}
+	pod := func(name, namespace string, owners ...metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: owners}}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErrors []string
+	}{
+		{
+			name: "owned replicasets and pods are valid",
+			objects: []client.Object{
+				deployment("web", "test-ns", "web-uid"),
+				replicaSet("web-old", 0, ownerRef("apps/v1", "Deployment", "web", "web-uid")),
+				replicaSet("web-new", 2, ownerRef("apps/v1", "Deployment", "web", "web-uid")),
+				pod("web-new-abc", "test-ns", ownerRef("apps/v1", "ReplicaSet", "web-new", "web-new-uid")),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "orphaned replicaset with zero replicas",
+			objects: []client.Object{
+				replicaSet("web-old", 0),
+				replicaSet("standalone", 1),
+			},
+			expectedErrors: []string{"orphaned_replicaset"},
+		},
+		{
+			name: "owner uid no longer exists",
+			objects: []client.Object{
+				deployment("web", "test-ns", "web-uid-recreated"),
+				replicaSet("web-new", 1, ownerRef("apps/v1", "Deployment", "web", "web-uid")),
+			},
+			expectedErrors: []string{"dangling_owner_reference"},
+		},
+		{
+			name: "owner in another namespace",
+			objects: []client.Object{
+				&batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "ops", UID: "migrate-uid"}},
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:            "migrate-1",
+					Namespace:       "test-ns",
+					OwnerReferences: []metav1.OwnerReference{ownerRef("batch/v1", "CronJob", "migrate", "migrate-uid")},
+				}},
+			},
+			expectedErrors: []string{"cross_namespace_owner_reference"},
+		},
+		{
+			name: "unknown owner kinds and system namespaces ignored",
+			objects: []client.Object{
+				pod("operand", "test-ns", ownerRef("example.com/v1", "Widget", "w", "widget-uid")),
+				pod("kube-proxy-abc", "kube-system", ownerRef("apps/v1", "DaemonSet", "kube-proxy", "gone-uid")),
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = batchv1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewLifecycleValidator(fakeClient, logr.Discard(), LifecycleConfig{
+				EnableOrphanedReplicaSetValidation: true,
+				EnableOwnerReferenceValidation:     true,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-LIFE-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
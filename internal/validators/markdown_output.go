@@ -0,0 +1,305 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// validatorSections maps error code categories to the section titles used in
+// markdown output, in the order the sections are rendered.
+var validatorSections = []struct {
+	category string
+	title    string
+}{
+	{"REF", "Reference"},
+	{"RES", "Resource Limits"},
+	{"SEC", "Security"},
+	{"NET", "Networking"},
+	{"IMG", "Image"},
+	{"SCR", "Secret Hygiene"},
+	{"VOL", "Volume"},
+	{"QTA", "Quota"},
+	{"LIFE", "Lifecycle"},
+	{"AVL", "Availability"},
+	{"DRF", "Cluster Drift"},
+	{"CST", "Custom Rules"},
+	{"PLG", "Plugins"},
+}
+
+// LoadBaselineResult reads a validation result previously written with
+// --output=json or --output=yaml so later runs can be compared against it.
+func LoadBaselineResult(path string) (*ValidationResult, error) {
+	data, err := os.ReadFile(path) // nolint:gosec // Baseline path is user-provided
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var baseline ValidationResult
+	if err := yaml.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+	return &baseline, nil
+}
+
+// FormatMarkdownOutput formats validation results as markdown suitable for posting
+// as a pull request comment. Findings are grouped into collapsible per-validator
+// sections; when a baseline is provided, a delta section lists new and resolved findings.
+func (r *ValidatorRegistry) FormatMarkdownOutput(result ValidationResult, baseline *ValidationResult) (string, error) {
+	var output strings.Builder
+
+	output.WriteString("## Kogaro Validation Results\n\n")
+	output.WriteString(markdownSummaryLine(result.Errors))
+	output.WriteString(markdownScoreLine(result, baseline))
+
+	if baseline != nil {
+		newFindings, resolvedFindings, unchanged := DiffFindings(baseline.Errors, result.Errors)
+
+		output.WriteString("\n### Changes Since Baseline\n\n")
+		output.WriteString(fmt.Sprintf("| New | Resolved | Unchanged |\n|---|---|---|\n| %d | %d | %d |\n",
+			len(newFindings), len(resolvedFindings), unchanged))
+
+		if len(newFindings) > 0 {
+			writeMarkdownSection(&output, fmt.Sprintf("New findings (%d)", len(newFindings)), newFindings, true)
+		}
+		if len(resolvedFindings) > 0 {
+			writeMarkdownSection(&output, fmt.Sprintf("Resolved findings (%d)", len(resolvedFindings)), resolvedFindings, false)
+		}
+	}
+
+	writeMarkdownTeams(&output, result.Errors)
+	writeMarkdownReferences(&output, result.References)
+
+	if len(result.Errors) > 0 {
+		output.WriteString("\n### Findings by Validator\n")
+
+		grouped := make(map[string][]ValidationError)
+		for _, ve := range result.Errors {
+			category := errorCodeCategory(ve.ErrorCode)
+			grouped[category] = append(grouped[category], ve)
+		}
+
+		for _, section := range validatorSections {
+			if findings := grouped[section.category]; len(findings) > 0 {
+				writeMarkdownSection(&output, fmt.Sprintf("%s (%d)", section.title, len(findings)), findings, false)
+				delete(grouped, section.category)
+			}
+		}
+
+		// Findings without a recognised error code are collected at the end
+		var other []ValidationError
+		for _, findings := range grouped {
+			other = append(other, findings...)
+		}
+		if len(other) > 0 {
+			writeMarkdownSection(&output, fmt.Sprintf("Other (%d)", len(other)), other, false)
+		}
+	}
+
+	return output.String(), nil
+}
+
+// markdownSummaryLine renders the headline counts for a set of findings
+func markdownSummaryLine(findings []ValidationError) string {
+	if len(findings) == 0 {
+		return "**No findings.**\n"
+	}
+
+	errors, warnings, info := countSeverities(findings)
+	return fmt.Sprintf("**%d findings**: %d errors, %d warnings, %d info\n", len(findings), errors, warnings, info)
+}
+
+// markdownScoreLine renders the hygiene score of a result, and how it changed since the
+// baseline. The baseline is rated again over the namespaces it rated with the result's
+// score policy, since baselines written before scoring don't record a score.
+func markdownScoreLine(result ValidationResult, baseline *ValidationResult) string {
+	line := fmt.Sprintf("**Hygiene score: %d/%d**", result.Summary.HygieneScore, MaxHygieneScore)
+	if baseline != nil {
+		previous := result.scorePolicy.ScoreFindings(baseline.Errors, scoredNamespaces(*baseline)).Cluster
+		switch {
+		case result.Summary.HygieneScore < previous:
+			line += fmt.Sprintf(", down from %d at baseline", previous)
+		case result.Summary.HygieneScore > previous:
+			line += fmt.Sprintf(", up from %d at baseline", previous)
+		default:
+			line += ", unchanged since baseline"
+		}
+	}
+	return "\n" + line + "\n"
+}
+
+// countSeverities counts findings by severity; findings without a severity count as errors
+func countSeverities(findings []ValidationError) (errors, warnings, info int) {
+	for _, ve := range findings {
+		switch {
+		case ve.IsWarning():
+			warnings++
+		case ve.IsInfo():
+			info++
+		default:
+			errors++
+		}
+	}
+	return errors, warnings, info
+}
+
+// writeMarkdownTeams writes a table of finding counts per owning team, when any finding
+// is assigned to a team. Findings without a team are counted last as unassigned.
+func writeMarkdownTeams(output *strings.Builder, findings []ValidationError) {
+	grouped := GroupByTeam(findings)
+	teams := make([]string, 0, len(grouped))
+	for team := range grouped {
+		if team != "" {
+			teams = append(teams, team)
+		}
+	}
+	if len(teams) == 0 {
+		return
+	}
+	sort.Strings(teams)
+
+	output.WriteString("\n### Findings by Team\n\n")
+	output.WriteString("| Team | Errors | Warnings | Info |\n|---|---|---|---|\n")
+	if _, unassigned := grouped[""]; unassigned {
+		teams = append(teams, "")
+	}
+	for _, team := range teams {
+		errors, warnings, info := countSeverities(grouped[team])
+		name := "_unassigned_"
+		if team != "" {
+			name = markdownCode(team)
+		}
+		output.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", name, errors, warnings, info))
+	}
+}
+
+// writeMarkdownReferences writes a collapsible table of where the references of a
+// validated config were resolved, open when any of them is dangling
+func writeMarkdownReferences(output *strings.Builder, references []ReferenceResolution) {
+	if len(references) == 0 {
+		return
+	}
+	bundle, cluster, dangling := countReferences(references)
+	if dangling > 0 {
+		output.WriteString("\n<details open>\n")
+	} else {
+		output.WriteString("\n<details>\n")
+	}
+	output.WriteString(fmt.Sprintf("<summary>Reference Resolution: %d in bundle, %d in cluster, %d dangling</summary>\n\n", bundle, cluster, dangling))
+	output.WriteString("| Provenance | Namespace | Reference | Reason |\n")
+	output.WriteString("|---|---|---|---|\n")
+	for _, ref := range references {
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			ref.Provenance,
+			markdownCell(ref.Namespace),
+			markdownCode(ref.String()),
+			markdownCell(ref.Reason)))
+	}
+	output.WriteString("\n</details>\n")
+}
+
+// writeMarkdownSection writes a collapsible section containing a findings table
+func writeMarkdownSection(output *strings.Builder, title string, findings []ValidationError, open bool) {
+	if open {
+		output.WriteString("\n<details open>\n")
+	} else {
+		output.WriteString("\n<details>\n")
+	}
+	output.WriteString(fmt.Sprintf("<summary>%s</summary>\n\n", title))
+	output.WriteString("| Severity | Code | Resource | Message | Hint |\n")
+	output.WriteString("|---|---|---|---|---|\n")
+
+	sorted := make([]ValidationError, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return findingKey(sorted[i]) < findingKey(sorted[j])
+	})
+
+	for _, ve := range sorted {
+		severity := ve.Severity
+		if severity == "" {
+			severity = SeverityError
+		}
+		output.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			severity,
+			markdownCode(ve.ErrorCode),
+			markdownCode(ve.ResourceType+"/"+ve.GetResourceKey()),
+			markdownCell(ve.Message),
+			markdownCell(ve.RemediationHint)))
+	}
+	output.WriteString("\n</details>\n")
+}
+
+// markdownCell escapes a value so it can be placed in a markdown table cell
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "\r\n", " ")
+	value = strings.ReplaceAll(value, "\n", " ")
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// markdownCode renders a table cell value as inline code
+func markdownCode(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return "`" + markdownCell(strings.ReplaceAll(value, "`", "'")) + "`"
+}
+
+// errorCodeCategory extracts the category from a KOGARO-CCC-NNN error code or a
+// namespaced plugin code such as KOGARO-PLG-ACME-001
+func errorCodeCategory(code string) string {
+	parts := strings.Split(code, "-")
+	if len(parts) < 3 || parts[0] != "KOGARO" {
+		return ""
+	}
+	return parts[1]
+}
+
+// findingKey identifies a finding independently of its message wording so that
+// baselines remain comparable when message text changes between releases.
+func findingKey(ve ValidationError) string {
+	return strings.Join([]string{ve.ErrorCode, ve.ValidationType, ve.ResourceType, ve.GetResourceKey()}, "|")
+}
+
+// DiffFindings compares current findings with a baseline and returns findings that
+// are new, findings that have been resolved, and the number left unchanged.
+func DiffFindings(baseline, current []ValidationError) (newFindings, resolvedFindings []ValidationError, unchanged int) {
+	baselineCounts := make(map[string]int)
+	for _, ve := range baseline {
+		baselineCounts[findingKey(ve)]++
+	}
+
+	currentCounts := make(map[string]int)
+	for _, ve := range current {
+		key := findingKey(ve)
+		currentCounts[key]++
+		if currentCounts[key] > baselineCounts[key] {
+			newFindings = append(newFindings, ve)
+		} else {
+			unchanged++
+		}
+	}
+
+	seen := make(map[string]int)
+	for _, ve := range baseline {
+		key := findingKey(ve)
+		seen[key]++
+		if seen[key] > currentCounts[key] {
+			resolvedFindings = append(resolvedFindings, ve)
+		}
+	}
+
+	return newFindings, resolvedFindings, unchanged
+}
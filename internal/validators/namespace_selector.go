@@ -0,0 +1,129 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// namespaceNamePattern matches names, and globs over names, that only use characters
+// valid in a namespace name
+var namespaceNamePattern = regexp.MustCompile(`^[a-z0-9*?\[\]-]+$`)
+
+// NamespaceSelector selects namespaces by exact name, glob, regular expression or label
+// selector. Entries are interpreted as follows:
+//   - "environment=production" or "tier!=batch": a label selector
+//   - "prod-*" or "team-?": a glob over namespace names
+//   - "team-.*-live": a regular expression matching the whole name; any entry with a
+//     character that cannot appear in a namespace name is a regular expression
+//   - "payments": an exact name
+//
+// A namespace is selected when any entry matches it.
+type NamespaceSelector struct {
+	names     []string
+	globs     []string
+	patterns  []*regexp.Regexp
+	selectors []labels.Selector
+}
+
+// ParseNamespaceSelector parses namespace selector entries, ignoring blank entries
+func ParseNamespaceSelector(entries []string) (*NamespaceSelector, error) {
+	selector := &NamespaceSelector{}
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		switch {
+		case entry == "":
+			continue
+		case strings.Contains(entry, "="):
+			labelSelector, err := labels.Parse(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace label selector %q: %w", entry, err)
+			}
+			selector.selectors = append(selector.selectors, labelSelector)
+		case !namespaceNamePattern.MatchString(entry):
+			pattern, err := regexp.Compile("^(?:" + entry + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("invalid namespace pattern %q: %w", entry, err)
+			}
+			selector.patterns = append(selector.patterns, pattern)
+		case strings.ContainsAny(entry, "*?["):
+			if _, err := path.Match(entry, ""); err != nil {
+				return nil, fmt.Errorf("invalid namespace glob %q: %w", entry, err)
+			}
+			selector.globs = append(selector.globs, entry)
+		default:
+			selector.names = append(selector.names, entry)
+		}
+	}
+
+	return selector, nil
+}
+
+// Empty reports whether the selector has no entries
+func (s *NamespaceSelector) Empty() bool {
+	return s == nil || len(s.names)+len(s.globs)+len(s.patterns)+len(s.selectors) == 0
+}
+
+// Matches reports whether a namespace is selected
+func (s *NamespaceSelector) Matches(namespace corev1.Namespace) bool {
+	if s == nil {
+		return false
+	}
+	for _, name := range s.names {
+		if namespace.Name == name {
+			return true
+		}
+	}
+	for _, glob := range s.globs {
+		if matched, _ := path.Match(glob, namespace.Name); matched {
+			return true
+		}
+	}
+	for _, pattern := range s.patterns {
+		if pattern.MatchString(namespace.Name) {
+			return true
+		}
+	}
+	for _, labelSelector := range s.selectors {
+		if labelSelector.Matches(labels.Set(namespace.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the sorted names of the selected namespaces. Names given exactly are
+// always returned, even when the namespace does not exist.
+func (s *NamespaceSelector) Select(namespaces []corev1.Namespace) []string {
+	if s == nil {
+		return nil
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range s.names {
+		selected[name] = true
+	}
+	for _, namespace := range namespaces {
+		if s.Matches(namespace) {
+			selected[namespace.Name] = true
+		}
+	}
+
+	names := make([]string, 0, len(selected))
+	for name := range selected {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNamespaceSelector_Select(t *testing.T) {
+	namespace := func(name string, labels map[string]string) corev1.Namespace {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+	namespaces := []corev1.Namespace{
+		namespace("prod-eu", nil),
+		namespace("prod", nil),
+		namespace("team-a-live", nil),
+		namespace("team-a-test", nil),
+		namespace("billing", map[string]string{"environment": "production"}),
+		namespace("sandbox", map[string]string{"environment": "dev"}),
+	}
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    []string
+	}{
+		{name: "exact names are kept when missing", entries: []string{"prod", " payments "}, want: []string{"payments", "prod"}},
+		{name: "glob", entries: []string{"prod-*"}, want: []string{"prod-eu"}},
+		{name: "regular expression matches the whole name", entries: []string{"team-.*-live"}, want: []string{"team-a-live"}},
+		{name: "label selector", entries: []string{"environment=production"}, want: []string{"billing"}},
+		{name: "negated label selector", entries: []string{"environment!=production"}, want: []string{"prod", "prod-eu", "sandbox", "team-a-live", "team-a-test"}},
+		{name: "entries combine", entries: []string{"prod-*", "prod-eu", "environment=production", ""}, want: []string{"billing", "prod-eu"}},
+		{name: "no entries", entries: nil, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := ParseNamespaceSelector(tt.entries)
+			if err != nil {
+				t.Fatalf("ParseNamespaceSelector() error = %v", err)
+			}
+			if got := selector.Select(namespaces); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNamespaceSelector_Invalid(t *testing.T) {
+	for _, entry := range []string{"team-(.*", "prod-[", "environment=prod uction"} {
+		if _, err := ParseNamespaceSelector([]string{entry}); err == nil {
+			t.Errorf("ParseNamespaceSelector(%q) expected an error", entry)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// NetworkPolicyCoverage selects the checks of a NetworkPolicyAnalyzer
+type NetworkPolicyCoverage struct {
+	// Namespaces that must have NetworkPolicies, as names, patterns or label selectors
+	RequiredNamespaces []string
+	// Report production-like namespaces without NetworkPolicies
+	ProductionLike bool
+	// Report namespaces with NetworkPolicies but no default deny policy
+	DefaultDeny bool
+}
+
+// NetworkPolicyAnalyzer checks that namespaces are covered by NetworkPolicies. The
+// security and networking validators both check coverage through it, so that they
+// report it with one set of error codes: KOGARO-SEC-027 for namespaces required to
+// have NetworkPolicies, KOGARO-SEC-028 for production-like namespaces and
+// KOGARO-NET-006 for namespaces without a default deny policy. Each namespace is
+// reported at most once.
+type NetworkPolicyAnalyzer struct {
+	coverage     NetworkPolicyCoverage
+	sharedConfig SharedConfig
+}
+
+// NewNetworkPolicyAnalyzer creates a NetworkPolicyAnalyzer running the given checks
+func NewNetworkPolicyAnalyzer(coverage NetworkPolicyCoverage, sharedConfig SharedConfig) *NetworkPolicyAnalyzer {
+	return &NetworkPolicyAnalyzer{coverage: coverage, sharedConfig: sharedConfig}
+}
+
+// ShareNetworkPolicyCoverage leaves the namespaces the networking validator requires
+// NetworkPolicies in to the security validator when both check NetworkPolicy
+// coverage, so that a namespace without NetworkPolicies is reported once whichever
+// flags require it. A config is nil when its validator is disabled.
+func ShareNetworkPolicyCoverage(security *SecurityConfig, networking *NetworkingConfig) {
+	if security == nil || networking == nil || !security.EnableNetworkPolicyValidation || !networking.EnableNetworkPolicyValidation {
+		return
+	}
+	security.SecuritySensitiveNamespaces = slices.Concat(security.SecuritySensitiveNamespaces, networking.PolicyRequiredNamespaces)
+	networking.PolicyRequiredNamespaces = nil
+}
+
+// Analyze reports the namespaces the policies do not cover
+func (a *NetworkPolicyAnalyzer) Analyze(namespaces []corev1.Namespace, policies []networkingv1.NetworkPolicy) ([]ValidationError, error) {
+	requiredSelector, err := ParseNamespaceSelector(a.coverage.RequiredNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	required := make(map[string]bool)
+	for _, namespace := range requiredSelector.Select(namespaces) {
+		required[namespace] = true
+	}
+
+	policiesByNamespace := make(map[string][]networkingv1.NetworkPolicy)
+	for _, policy := range policies {
+		policiesByNamespace[policy.Namespace] = append(policiesByNamespace[policy.Namespace], policy)
+	}
+
+	var errors []ValidationError
+	for _, ns := range namespaces {
+		namespacePolicies := policiesByNamespace[ns.Name]
+		isRequired := required[ns.Name]
+		delete(required, ns.Name)
+
+		switch {
+		case len(namespacePolicies) == 0 && isRequired:
+			errors = append(errors, a.missingRequiredPolicy(ns.Name))
+		case len(namespacePolicies) == 0 && a.coverage.ProductionLike &&
+			!a.sharedConfig.IsSecurityExcludedNamespace(ns.Name) && a.sharedConfig.IsProductionLikeNamespace(ns.Name):
+			errors = append(errors, a.missingProductionPolicy(ns.Name))
+		case len(namespacePolicies) > 0 && a.coverage.DefaultDeny &&
+			!a.sharedConfig.IsNetworkingExcludedNamespace(ns.Name) && !slices.ContainsFunc(namespacePolicies, isDefaultDenyPolicy):
+			errors = append(errors, a.missingDefaultDeny(ns.Name, len(namespacePolicies)))
+		}
+	}
+
+	// Namespaces required by name are reported even when they don't exist yet
+	for _, namespace := range slices.Sorted(maps.Keys(required)) {
+		if len(policiesByNamespace[namespace]) == 0 {
+			errors = append(errors, a.missingRequiredPolicy(namespace))
+		}
+	}
+
+	return errors, nil
+}
+
+// missingRequiredPolicy reports a namespace required to have NetworkPolicies that has none
+func (a *NetworkPolicyAnalyzer) missingRequiredPolicy(namespace string) ValidationError {
+	errorCode := GetSecurityErrorCode("missing_network_policy_security_sensitive", nil)
+	return NewValidationErrorWithCode("Namespace", namespace, namespace, "missing_network_policy_security_sensitive", errorCode, fmt.Sprintf("Namespace '%s' requires NetworkPolicies but has none defined", namespace)).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Create NetworkPolicies to implement default-deny ingress/egress rules and explicitly allow required traffic").
+		WithRelatedResources("NetworkPolicy/default-deny-all").
+		WithDetail("namespace_type", "policy_required").
+		WithDetail("security_risk", "unrestricted_network_access").
+		WithDetail("recommended_policy", "default_deny_with_explicit_allow")
+}
+
+// missingProductionPolicy reports a production-like namespace without NetworkPolicies
+func (a *NetworkPolicyAnalyzer) missingProductionPolicy(namespace string) ValidationError {
+	errorCode := GetSecurityErrorCode("missing_network_policy_production", nil)
+	return NewValidationErrorWithCode("Namespace", namespace, namespace, "missing_network_policy_production", errorCode, fmt.Sprintf("Production-like namespace '%s' has no NetworkPolicies defined", namespace)).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Implement NetworkPolicies for production workloads with default-deny rules and specific ingress/egress allowlists").
+		WithRelatedResources("NetworkPolicy/production-default-deny").
+		WithDetail("namespace_type", "production_like").
+		WithDetail("security_risk", "production_traffic_exposure").
+		WithDetail("compliance_requirement", "network_segmentation")
+}
+
+// missingDefaultDeny reports a namespace whose NetworkPolicies do not deny traffic by default
+func (a *NetworkPolicyAnalyzer) missingDefaultDeny(namespace string, policies int) ValidationError {
+	errorCode := GetNetworkingErrorCode("missing_network_policy_default_deny")
+	return NewValidationErrorWithCode("Namespace", namespace, namespace, "missing_network_policy_default_deny", errorCode, "Namespace has NetworkPolicies but no default deny policy").
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Add a default deny NetworkPolicy to deny all ingress/egress traffic by default, then create specific allow policies").
+		WithRelatedResources("NetworkPolicy/default-deny-all").
+		WithDetail("existing_policies_count", fmt.Sprintf("%d", policies)).
+		WithDetail("security_best_practice", "default_deny_principle")
+}
+
+// isDefaultDenyPolicy checks if a NetworkPolicy is a default deny policy.
+func isDefaultDenyPolicy(policy networkingv1.NetworkPolicy) bool {
+	// A default deny policy typically:
+	// 1. Selects all pods (empty podSelector)
+	// 2. Has empty ingress and/or egress rules
+
+	if len(policy.Spec.PodSelector.MatchLabels) > 0 {
+		return false
+	}
+
+	if len(policy.Spec.PodSelector.MatchExpressions) > 0 {
+		return false
+	}
+
+	hasIngressDeny := false
+	hasEgressDeny := false
+
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == networkingv1.PolicyTypeIngress && len(policy.Spec.Ingress) == 0 {
+			hasIngressDeny = true
+		}
+		if policyType == networkingv1.PolicyTypeEgress && len(policy.Spec.Egress) == 0 {
+			hasEgressDeny = true
+		}
+	}
+
+	return hasIngressDeny || hasEgressDeny
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetworkPolicyAnalyzer_Analyze(t *testing.T) {
+	namespace := func(name string) corev1.Namespace {
+		return corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	policy := func(namespace string, defaultDeny bool) networkingv1.NetworkPolicy {
+		policy := networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy", Namespace: namespace}}
+		if defaultDeny {
+			policy.Spec.PolicyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeIngress}
+		} else {
+			policy.Spec.PodSelector.MatchLabels = map[string]string{"app": "web"}
+		}
+		return policy
+	}
+	namespaces := []corev1.Namespace{namespace("production"), namespace("payments"), namespace("docs"), namespace("shop")}
+	policies := []networkingv1.NetworkPolicy{policy("payments", false), policy("shop", true)}
+
+	tests := []struct {
+		name     string
+		coverage NetworkPolicyCoverage
+		want     []string
+	}{
+		{
+			name:     "required and production-like namespace reported once",
+			coverage: NetworkPolicyCoverage{RequiredNamespaces: []string{"production", "payments"}, ProductionLike: true, DefaultDeny: true},
+			want:     []string{"production/KOGARO-SEC-027", "payments/KOGARO-NET-006"},
+		},
+		{
+			name:     "production-like namespace",
+			coverage: NetworkPolicyCoverage{ProductionLike: true},
+			want:     []string{"production/KOGARO-SEC-028"},
+		},
+		{
+			name:     "required namespaces that don't exist",
+			coverage: NetworkPolicyCoverage{RequiredNamespaces: []string{"vault", "docs", "billing", "shop"}},
+			want:     []string{"docs/KOGARO-SEC-027", "billing/KOGARO-SEC-027", "vault/KOGARO-SEC-027"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := NewNetworkPolicyAnalyzer(tt.coverage, DefaultSharedConfig())
+			findings, err := analyzer.Analyze(namespaces, policies)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			var got []string
+			for _, finding := range findings {
+				got = append(got, finding.Namespace+"/"+finding.ErrorCode)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Analyze() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShareNetworkPolicyCoverage(t *testing.T) {
+	security := &SecurityConfig{EnableNetworkPolicyValidation: true, SecuritySensitiveNamespaces: []string{"vault"}}
+	networking := &NetworkingConfig{EnableNetworkPolicyValidation: true, PolicyRequiredNamespaces: []string{"payments"}}
+
+	ShareNetworkPolicyCoverage(security, networking)
+	if !slices.Equal(security.SecuritySensitiveNamespaces, []string{"vault", "payments"}) || networking.PolicyRequiredNamespaces != nil {
+		t.Errorf("required namespaces = %v and %v, want all checked by the security validator",
+			security.SecuritySensitiveNamespaces, networking.PolicyRequiredNamespaces)
+	}
+
+	// The networking validator keeps its namespaces when the security validator doesn't check NetworkPolicies
+	security = &SecurityConfig{SecuritySensitiveNamespaces: []string{"vault"}}
+	networking = &NetworkingConfig{EnableNetworkPolicyValidation: true, PolicyRequiredNamespaces: []string{"payments"}}
+	ShareNetworkPolicyCoverage(security, networking)
+	if !slices.Equal(networking.PolicyRequiredNamespaces, []string{"payments"}) {
+		t.Errorf("networking required namespaces = %v, want them kept", networking.PolicyRequiredNamespaces)
+	}
+	ShareNetworkPolicyCoverage(nil, networking)
+	if !slices.Equal(networking.PolicyRequiredNamespaces, []string{"payments"}) {
+		t.Errorf("networking required namespaces = %v, want them kept without a security validator", networking.PolicyRequiredNamespaces)
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+const (
+	// defaultDNSLookupTimeout bounds each ExternalName lookup when no timeout is configured
+	defaultDNSLookupTimeout = 5 * time.Second
+	// clusterDomain is the DNS domain of in-cluster Service names
+	clusterDomain = "cluster.local"
+)
+
+// Resolver resolves host names to addresses. *net.Resolver satisfies it, and tests
+// substitute their own implementation to avoid real DNS lookups.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// SetResolver replaces the resolver used for ExternalName lookups
+func (v *NetworkingValidator) SetResolver(r Resolver) {
+	v.resolver = r
+}
+
+// validateDNSConfiguration checks ExternalName Services, pod DNS policies and
+// hostAliases that would shadow cluster Service names
+func (v *NetworkingValidator) validateDNSConfiguration(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+
+	var services corev1.ServiceList
+	if err := v.client.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	if v.config.EnableExternalNameResolution {
+		errors = append(errors, v.validateExternalNames(ctx, services.Items)...)
+	}
+
+	serviceNames := clusterServiceNames(services.Items)
+	validateTemplate := func(spec corev1.PodSpec, resourceType, name, namespace string) {
+		if v.isSystemNamespace(namespace) {
+			return
+		}
+		errors = append(errors, v.validatePodDNSPolicy(spec, resourceType, name, namespace)...)
+		errors = append(errors, v.validateHostAliases(spec, resourceType, name, namespace, serviceNames)...)
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		validateTemplate(deployment.Spec.Template.Spec, "Deployment", deployment.Name, deployment.Namespace)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		validateTemplate(statefulSet.Spec.Template.Spec, "StatefulSet", statefulSet.Name, statefulSet.Namespace)
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		validateTemplate(daemonSet.Spec.Template.Spec, "DaemonSet", daemonSet.Name, daemonSet.Namespace)
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are validated via their controllers
+		if utils.HasOwnerReferences(*pod) {
+			return nil
+		}
+		validateTemplate(pod.Spec, "Pod", pod.Name, pod.Namespace)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return errors, nil
+}
+
+// validateExternalNames resolves the target of each ExternalName Service. Targets
+// that are cluster Service names are checked against the Services in the cluster
+// instead, so that results do not depend on where Kogaro runs.
+func (v *NetworkingValidator) validateExternalNames(ctx context.Context, services []corev1.Service) []ValidationError {
+	var errors []ValidationError
+
+	existing := make(map[string]bool)
+	for _, service := range services {
+		existing[service.Namespace+"/"+service.Name] = true
+	}
+
+	timeout := v.config.DNSLookupTimeout
+	if timeout <= 0 {
+		timeout = defaultDNSLookupTimeout
+	}
+
+	for _, service := range services {
+		if service.Spec.Type != corev1.ServiceTypeExternalName || v.isSystemNamespace(service.Namespace) {
+			continue
+		}
+		target := strings.TrimSuffix(service.Spec.ExternalName, ".")
+		if target == "" || net.ParseIP(target) != nil {
+			continue
+		}
+
+		var reason string
+		if name, namespace, ok := parseClusterServiceName(target); ok {
+			if existing[namespace+"/"+name] {
+				continue
+			}
+			reason = fmt.Sprintf("Service '%s' does not exist in namespace '%s'", name, namespace)
+		} else {
+			lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+			_, err := v.resolver.LookupHost(lookupCtx, target)
+			cancel()
+			if err == nil {
+				continue
+			}
+			reason = err.Error()
+		}
+
+		errorCode := GetNetworkingErrorCode("external_name_unresolvable")
+		errors = append(errors, NewValidationErrorWithCode("Service", service.Name, service.Namespace, "external_name_unresolvable", errorCode,
+			fmt.Sprintf("ExternalName Service '%s' points to '%s', which does not resolve: %s", service.Name, target, reason)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Update spec.externalName of Service '%s' to a resolvable host name, or delete the Service if the target was retired", service.Name)).
+			WithDetail("external_name", target).
+			WithDetail("lookup_error", reason))
+	}
+
+	return errors
+}
+
+// validatePodDNSPolicy reports pod specs with dnsPolicy None that provide no nameservers,
+// which the API server rejects when the pods are created
+func (v *NetworkingValidator) validatePodDNSPolicy(spec corev1.PodSpec, resourceType, name, namespace string) []ValidationError {
+	if spec.DNSPolicy != corev1.DNSNone {
+		return nil
+	}
+	if spec.DNSConfig != nil && len(spec.DNSConfig.Nameservers) > 0 {
+		return nil
+	}
+
+	errorCode := GetNetworkingErrorCode("dns_policy_none_without_config")
+	return []ValidationError{NewValidationErrorWithCode(resourceType, name, namespace, "dns_policy_none_without_config", errorCode,
+		fmt.Sprintf("%s '%s' sets dnsPolicy None without dnsConfig nameservers", resourceType, name)).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Add dnsConfig.nameservers to the pod spec, or use dnsPolicy ClusterFirst").
+		WithDetail("dns_policy", string(spec.DNSPolicy))}
+}
+
+// validateHostAliases reports hostAliases entries that override the name of a cluster
+// Service, so the pod reaches the aliased IP instead of the Service
+func (v *NetworkingValidator) validateHostAliases(spec corev1.PodSpec, resourceType, name, namespace string, serviceNames map[string]string) []ValidationError {
+	var errors []ValidationError
+
+	for _, alias := range spec.HostAliases {
+		for _, hostname := range alias.Hostnames {
+			hostname = strings.ToLower(strings.TrimSuffix(hostname, "."))
+			service, shadowed := serviceNames[hostname]
+			if !shadowed {
+				// Short names resolve against the pod's own namespace
+				service, shadowed = serviceNames[hostname+"."+namespace]
+			}
+			if !shadowed {
+				continue
+			}
+
+			errorCode := GetNetworkingErrorCode("host_alias_shadows_service")
+			errors = append(errors, NewValidationErrorWithCode(resourceType, name, namespace, "host_alias_shadows_service", errorCode,
+				fmt.Sprintf("hostAlias '%s' -> %s in %s '%s' shadows Service '%s'", hostname, alias.IP, resourceType, name, service)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(fmt.Sprintf("Remove hostname '%s' from hostAliases so the pod resolves the Service through cluster DNS", hostname)).
+				WithRelatedResources(fmt.Sprintf("Service/%s", service)).
+				WithDetail("hostname", hostname).
+				WithDetail("alias_ip", alias.IP))
+		}
+	}
+
+	return errors
+}
+
+// clusterServiceNames maps each DNS name of a Service, other than its bare name, to
+// the namespace/name of the Service
+func clusterServiceNames(services []corev1.Service) map[string]string {
+	names := make(map[string]string)
+	for _, service := range services {
+		ref := service.Namespace + "/" + service.Name
+		base := service.Name + "." + service.Namespace
+		names[base] = ref
+		names[base+".svc"] = ref
+		names[base+".svc."+clusterDomain] = ref
+	}
+	return names
+}
+
+// parseClusterServiceName splits a name of the form <service>.<namespace>.svc[.cluster.local]
+func parseClusterServiceName(host string) (name, namespace string, ok bool) {
+	host = strings.TrimSuffix(host, "."+clusterDomain)
+	parts := strings.Split(host, ".")
+	if len(parts) != 3 || parts[2] != "svc" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// mockResolver resolves only the hosts it knows and records every lookup
+type mockResolver struct {
+	hosts   map[string][]string
+	lookups []string
+}
+
+func (r *mockResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	r.lookups = append(r.lookups, host)
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+	return nil, fmt.Errorf("lookup %s: no such host", host)
+}
+
+func TestNetworkingValidator_ValidateDNSConfiguration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	externalName := func(name, target string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeExternalName, ExternalName: target},
+		}
+	}
+	deployment := func(name string, spec corev1.PodSpec) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+		}
+	}
+	database := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "data"}}
+
+	tests := []struct {
+		name            string
+		config          NetworkingConfig
+		objects         []client.Object
+		errorTypes      []string
+		expectedLookups int
+	}{
+		{
+			name:   "resolvable external names",
+			config: NetworkingConfig{EnableExternalNameResolution: true},
+			objects: []client.Object{
+				database,
+				externalName("payments", "api.payments.example.com."),
+				externalName("db-alias", "db.data.svc.cluster.local"),
+				externalName("static", "10.0.0.1"),
+			},
+			errorTypes:      []string{},
+			expectedLookups: 1,
+		},
+		{
+			name:   "unresolvable external names",
+			config: NetworkingConfig{EnableExternalNameResolution: true},
+			objects: []client.Object{
+				externalName("legacy", "legacy.example.com"),
+				externalName("cache-alias", "cache.data.svc"),
+			},
+			errorTypes:      []string{"external_name_unresolvable", "external_name_unresolvable"},
+			expectedLookups: 1,
+		},
+		{
+			name:   "resolution disabled",
+			config: NetworkingConfig{EnableDNSValidation: true},
+			objects: []client.Object{
+				externalName("legacy", "legacy.example.com"),
+			},
+			errorTypes: []string{},
+		},
+		{
+			name:   "dnsPolicy None",
+			config: NetworkingConfig{EnableDNSValidation: true},
+			objects: []client.Object{
+				deployment("custom-dns", corev1.PodSpec{
+					DNSPolicy: corev1.DNSNone,
+					DNSConfig: &corev1.PodDNSConfig{Nameservers: []string{"1.1.1.1"}},
+				}),
+				deployment("no-dns", corev1.PodSpec{DNSPolicy: corev1.DNSNone}),
+			},
+			errorTypes: []string{"dns_policy_none_without_config"},
+		},
+		{
+			name:   "hostAliases shadowing services",
+			config: NetworkingConfig{EnableDNSValidation: true},
+			objects: []client.Object{
+				database,
+				&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "test-ns"}},
+				deployment("web", corev1.PodSpec{HostAliases: []corev1.HostAlias{
+					{IP: "10.0.0.5", Hostnames: []string{"cache"}},
+					{IP: "10.0.0.6", Hostnames: []string{"db.data.svc.cluster.local", "db"}},
+					{IP: "10.0.0.7", Hostnames: []string{"metrics.example.com"}},
+				}}),
+			},
+			errorTypes: []string{"host_alias_shadows_service", "host_alias_shadows_service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			resolver := &mockResolver{hosts: map[string][]string{
+				"api.payments.example.com": {"203.0.113.10"},
+			}}
+			validator := NewNetworkingValidator(fakeClient, logr.Discard(), tt.config)
+			validator.SetResolver(resolver)
+
+			errors, err := validator.validateDNSConfiguration(context.TODO())
+			if err != nil {
+				t.Fatalf("validateDNSConfiguration() error = %v", err)
+			}
+
+			if len(errors) != len(tt.errorTypes) {
+				t.Fatalf("validateDNSConfiguration() got %d errors, want %d: %+v", len(errors), len(tt.errorTypes), errors)
+			}
+
+			for i, expectedType := range tt.errorTypes {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("Expected error type %s, got %s", expectedType, errors[i].ValidationType)
+				}
+				if errors[i].ErrorCode == "KOGARO-NET-UNKNOWN" {
+					t.Errorf("error[%d] has no error code", i)
+				}
+			}
+
+			if len(resolver.lookups) != tt.expectedLookups {
+				t.Errorf("got %d DNS lookups %v, want %d", len(resolver.lookups), resolver.lookups, tt.expectedLookups)
+			}
+		})
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// legacyIngressClassAnnotation selects the ingress controller of Ingresses that predate spec.ingressClassName
+const legacyIngressClassAnnotation = "kubernetes.io/ingress.class"
+
+// validateDuplicateResources finds Services, Ingress rules and NetworkPolicies that
+// repeat another resource in the same namespace. These are usually left behind by
+// renames or copied manifests and make it unclear which resource is in effect.
+func (v *NetworkingValidator) validateDuplicateResources(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+
+	var services corev1.ServiceList
+	if err := v.client.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	errors = append(errors, v.findDuplicateServices(services.Items)...)
+
+	var ingresses networkingv1.IngressList
+	if err := v.client.List(ctx, &ingresses); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	errors = append(errors, v.findDuplicateIngressRules(ingresses.Items)...)
+
+	var networkPolicies networkingv1.NetworkPolicyList
+	if err := v.client.List(ctx, &networkPolicies); err != nil {
+		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
+	}
+	errors = append(errors, v.findRedundantNetworkPolicies(networkPolicies.Items)...)
+
+	return errors, nil
+}
+
+// findDuplicateServices reports Services that select the same pods on the same ports
+// as another Service in their namespace. The first Service by name is kept.
+func (v *NetworkingValidator) findDuplicateServices(services []corev1.Service) []ValidationError {
+	var errors []ValidationError
+
+	sorted := append([]corev1.Service(nil), services...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	first := make(map[string]string)
+	for _, service := range sorted {
+		// Services without a selector are backed by manually managed endpoints
+		if v.isSystemNamespace(service.Namespace) || len(service.Spec.Selector) == 0 {
+			continue
+		}
+
+		key := strings.Join([]string{
+			service.Namespace,
+			string(service.Spec.Type),
+			labels.Set(service.Spec.Selector).String(),
+			servicePortsKey(service.Spec.Ports),
+		}, "|")
+		original, exists := first[key]
+		if !exists {
+			first[key] = service.Name
+			continue
+		}
+
+		errorCode := GetNetworkingErrorCode("duplicate_service")
+		errors = append(errors, NewValidationErrorWithCode("Service", service.Name, service.Namespace, "duplicate_service", errorCode,
+			fmt.Sprintf("Service '%s' exposes the same selector and ports as Service '%s'", service.Name, original)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Delete Service '%s' and point its clients at Service '%s', or change its selector or ports if both are needed", service.Name, original)).
+			WithRelatedResources(fmt.Sprintf("Service/%s", original)).
+			WithDetail("duplicate_of", original).
+			WithDetail("selector", labels.Set(service.Spec.Selector).String()).
+			WithDetail("ports", strings.Join(v.getServicePortNames(service), ",")))
+	}
+
+	return errors
+}
+
+// servicePortsKey returns the ports of a Service in a canonical, name-independent form
+func servicePortsKey(ports []corev1.ServicePort) string {
+	keys := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := port.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		keys = append(keys, fmt.Sprintf("%d/%s->%s", port.Port, protocol, port.TargetPort.String()))
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+// findDuplicateIngressRules reports Ingresses that route a host and path to the same
+// backend as another Ingress of the same class in their namespace. Rules routing to
+// different backends are reported by findIngressHostCollisions.
+func (v *NetworkingValidator) findDuplicateIngressRules(ingresses []networkingv1.Ingress) []ValidationError {
+	var errors []ValidationError
+
+	sorted := append([]networkingv1.Ingress(nil), ingresses...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Namespace != sorted[j].Namespace {
+			return sorted[i].Namespace < sorted[j].Namespace
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	first := make(map[string]string)
+	for _, ingress := range sorted {
+		if v.isSystemNamespace(ingress.Namespace) {
+			continue
+		}
+
+		reported := make(map[string]bool)
+		for _, rule := range ingress.Spec.Rules {
+			if rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				key := strings.Join([]string{ingress.Namespace, ingressClass(ingress), rule.Host, path.Path, ingressBackendKey(ingress.Namespace, path.Backend)}, "|")
+				original, exists := first[key]
+				if !exists {
+					first[key] = ingress.Name
+					continue
+				}
+				if original == ingress.Name || reported[key] {
+					continue
+				}
+				reported[key] = true
+
+				host := rule.Host
+				if host == "" {
+					host = "*"
+				}
+				errorCode := GetNetworkingErrorCode("duplicate_ingress_rule")
+				errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "duplicate_ingress_rule", errorCode,
+					fmt.Sprintf("Ingress '%s' routes host '%s' path '%s' to the same backend as Ingress '%s'", ingress.Name, host, path.Path, original)).
+					WithSeverity(SeverityWarning).
+					WithRemediationHint(fmt.Sprintf("Remove the rule from one of Ingresses '%s' and '%s'; keeping it in both makes later changes to either ambiguous", ingress.Name, original)).
+					WithRelatedResources(fmt.Sprintf("Ingress/%s", original)).
+					WithDetail("duplicate_of", original).
+					WithDetail("host", host).
+					WithDetail("path", path.Path))
+			}
+		}
+	}
+
+	return errors
+}
+
+// ingressClass returns the class an Ingress is served by, from its spec or the legacy annotation
+func ingressClass(ingress networkingv1.Ingress) string {
+	if ingress.Spec.IngressClassName != nil {
+		return *ingress.Spec.IngressClassName
+	}
+	return ingress.Annotations[legacyIngressClassAnnotation]
+}
+
+// findRedundantNetworkPolicies reports NetworkPolicies whose effect is already
+// provided by another policy with the same pod selector: the other policy isolates
+// at least the same traffic directions and allows every rule of this one. Of two
+// identical policies only the second by name is reported.
+func (v *NetworkingValidator) findRedundantNetworkPolicies(policies []networkingv1.NetworkPolicy) []ValidationError {
+	var errors []ValidationError
+
+	bySelector := make(map[string][]networkingv1.NetworkPolicy)
+	var keys []string
+	for _, policy := range policies {
+		if v.isSystemNamespace(policy.Namespace) {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		key := policy.Namespace + "|" + selector.String()
+		if _, exists := bySelector[key]; !exists {
+			keys = append(keys, key)
+		}
+		bySelector[key] = append(bySelector[key], policy)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		group := bySelector[key]
+		sort.Slice(group, func(i, j int) bool { return group[i].Name < group[j].Name })
+
+		for i, policy := range group {
+			for j, other := range group {
+				if i == j || !networkPolicyCovers(other, policy) {
+					continue
+				}
+				// Identical policies cover each other; keep the first
+				if j > i && networkPolicyCovers(policy, other) {
+					continue
+				}
+
+				errorCode := GetNetworkingErrorCode("duplicate_network_policy")
+				errors = append(errors, NewValidationErrorWithCode("NetworkPolicy", policy.Name, policy.Namespace, "duplicate_network_policy", errorCode,
+					fmt.Sprintf("NetworkPolicy '%s' has the same pod selector as NetworkPolicy '%s', which already allows all of its rules", policy.Name, other.Name)).
+					WithSeverity(SeverityWarning).
+					WithRemediationHint(fmt.Sprintf("Delete NetworkPolicy '%s' or merge any intended differences into NetworkPolicy '%s'", policy.Name, other.Name)).
+					WithRelatedResources(fmt.Sprintf("NetworkPolicy/%s", other.Name)).
+					WithDetail("duplicate_of", other.Name).
+					WithDetail("policy_selector", metav1.FormatLabelSelector(&policy.Spec.PodSelector)))
+				break
+			}
+		}
+	}
+
+	return errors
+}
+
+// networkPolicyCovers reports whether policy a isolates every direction policy b
+// isolates and allows every ingress and egress rule of b
+func networkPolicyCovers(a, b networkingv1.NetworkPolicy) bool {
+	aTypes := effectivePolicyTypes(a)
+	for policyType := range effectivePolicyTypes(b) {
+		if !aTypes[policyType] {
+			return false
+		}
+	}
+
+	for _, rule := range b.Spec.Ingress {
+		if !containsSemantic(a.Spec.Ingress, rule) {
+			return false
+		}
+	}
+	for _, rule := range b.Spec.Egress {
+		if !containsSemantic(a.Spec.Egress, rule) {
+			return false
+		}
+	}
+	return true
+}
+
+// effectivePolicyTypes returns the traffic directions a NetworkPolicy isolates, applying
+// the API defaults when policyTypes is not set
+func effectivePolicyTypes(policy networkingv1.NetworkPolicy) map[networkingv1.PolicyType]bool {
+	types := make(map[networkingv1.PolicyType]bool)
+	if len(policy.Spec.PolicyTypes) > 0 {
+		for _, policyType := range policy.Spec.PolicyTypes {
+			types[policyType] = true
+		}
+		return types
+	}
+	types[networkingv1.PolicyTypeIngress] = true
+	if len(policy.Spec.Egress) > 0 {
+		types[networkingv1.PolicyTypeEgress] = true
+	}
+	return types
+}
+
+// containsSemantic reports whether items contains a value semantically equal to item
+func containsSemantic[T any](items []T, item T) bool {
+	for _, candidate := range items {
+		if equality.Semantic.DeepEqual(candidate, item) {
+			return true
+		}
+	}
+	return false
+}
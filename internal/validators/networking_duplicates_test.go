@@ -0,0 +1,149 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNetworkingValidator_ValidateDuplicateResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	service := func(name string, selector map[string]string, port int32) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: corev1.ServiceSpec{
+				Selector: selector,
+				Ports:    []corev1.ServicePort{{Name: name, Port: port, TargetPort: intstr.FromInt32(8080)}},
+			},
+		}
+	}
+	ingress := func(name, class, host, path string) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To(class),
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:    path,
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "web"}},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+	fromApp := func(app string) networkingv1.NetworkPolicyIngressRule {
+		return networkingv1.NetworkPolicyIngressRule{From: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}},
+		}}}
+	}
+	policy := func(name string, rules ...networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+		return &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				Ingress:     rules,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		errorTypes []string
+	}{
+		{
+			name: "distinct resources",
+			objects: []client.Object{
+				service("web", map[string]string{"app": "web"}, 80),
+				service("web-metrics", map[string]string{"app": "web"}, 9090),
+				ingress("web", "nginx", "example.com", "/"),
+				ingress("web-internal", "internal", "example.com", "/"),
+				policy("allow-frontend", fromApp("frontend")),
+				policy("allow-monitoring", fromApp("prometheus")),
+			},
+			errorTypes: []string{},
+		},
+		{
+			name: "duplicate service and ingress rule",
+			objects: []client.Object{
+				service("web", map[string]string{"app": "web"}, 80),
+				service("web-old", map[string]string{"app": "web"}, 80),
+				ingress("web", "nginx", "example.com", "/"),
+				ingress("web-v2", "nginx", "example.com", "/"),
+			},
+			errorTypes: []string{"duplicate_service", "duplicate_ingress_rule"},
+		},
+		{
+			name: "identical and shadowed network policies",
+			objects: []client.Object{
+				policy("allow-all-sources", fromApp("frontend"), fromApp("prometheus")),
+				policy("allow-frontend", fromApp("frontend")),
+				policy("allow-frontend-copy", fromApp("frontend")),
+			},
+			errorTypes: []string{"duplicate_network_policy", "duplicate_network_policy"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewNetworkingValidator(fakeClient, logr.Discard(), NetworkingConfig{EnableDuplicateValidation: true})
+
+			errors, err := validator.validateDuplicateResources(context.TODO())
+			if err != nil {
+				t.Fatalf("validateDuplicateResources() error = %v", err)
+			}
+
+			if len(errors) != len(tt.errorTypes) {
+				t.Fatalf("validateDuplicateResources() got %d errors, want %d: %+v", len(errors), len(tt.errorTypes), errors)
+			}
+
+			for i, expectedType := range tt.errorTypes {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("Expected error type %s, got %s", expectedType, errors[i].ValidationType)
+				}
+				if errors[i].ErrorCode == "KOGARO-NET-UNKNOWN" {
+					t.Errorf("error[%d] has no error code", i)
+				}
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyCovers(t *testing.T) {
+	denyEgress := networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+	}}
+	ingressOnly := networkingv1.NetworkPolicy{}
+
+	if networkPolicyCovers(ingressOnly, denyEgress) {
+		t.Error("a policy that does not isolate egress must not cover one that denies egress")
+	}
+	if !networkPolicyCovers(denyEgress, ingressOnly) {
+		t.Error("a policy isolating ingress and egress covers an ingress-only policy without rules")
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// dnsNamespace and dnsServiceName locate the cluster DNS Service, which CoreDNS
+	// deployments keep under its historical kube-dns name
+	dnsNamespace   = "kube-system"
+	dnsServiceName = "kube-dns"
+	// dnsPort is the port pods send DNS queries to
+	dnsPort = 53
+	// maxReportedOverlaps bounds the overlapping ranges listed in a finding
+	maxReportedOverlaps = 5
+)
+
+// defaultDNSSelector selects the cluster DNS pods when the kube-dns Service cannot be read
+var defaultDNSSelector = map[string]string{"k8s-app": "kube-dns"}
+
+// clusterRange is an address range used inside the cluster
+type clusterRange struct {
+	cidr        *net.IPNet
+	description string
+}
+
+// validateEgressPolicies reports workloads whose NetworkPolicies block DNS, policy
+// peers whose namespaceSelector matches no namespace, and ipBlocks that overlap the
+// addresses of pods and Services. The cluster DNS pods and the addresses in use lie in
+// namespaces of every shard, so every shard's objects are read.
+func (v *NetworkingValidator) validateEgressPolicies(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+	reader := allShards(v.client)
+
+	var policies networkingv1.NetworkPolicyList
+	if err := reader.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
+	}
+	if len(policies.Items) == 0 {
+		return nil, nil
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := reader.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	// Without namespaces, as when validating manifests alone, every selector would match nothing
+	if len(namespaces.Items) > 0 {
+		errors = append(errors, v.validateNamespaceSelectors(policies.Items, namespaces.Items)...)
+	}
+
+	ranges, err := v.clusterRanges(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+	errors = append(errors, v.validateIPBlockOverlaps(policies.Items, ranges)...)
+
+	workloads, err := v.listPolicyWorkloads(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+	dnsPods, err := v.dnsPods(ctx, workloads)
+	if err != nil {
+		return nil, err
+	}
+	simulator := NewNetworkPolicySimulator(policies.Items, namespaces.Items)
+	for i := range workloads {
+		if finding := v.validateDNSEgress(simulator, &workloads[i], dnsPods); finding != nil {
+			errors = append(errors, *finding)
+		}
+	}
+
+	return errors, nil
+}
+
+// validateDNSEgress reports a workload resolving names through cluster DNS whose
+// NetworkPolicies allow no DNS queries over UDP to any cluster DNS pod
+func (v *NetworkingValidator) validateDNSEgress(simulator *NetworkPolicySimulator, source *policyWorkload, dnsPods []*corev1.Pod) *ValidationError {
+	spec := source.pod.Spec
+	if v.isSystemNamespace(source.pod.Namespace) || spec.HostNetwork {
+		return nil
+	}
+	// Only pods using cluster DNS query its pods
+	if spec.DNSPolicy != "" && spec.DNSPolicy != corev1.DNSClusterFirst && spec.DNSPolicy != corev1.DNSClusterFirstWithHostNet {
+		return nil
+	}
+
+	var blocked *TrafficVerdict
+	for _, dnsPod := range dnsPods {
+		verdict := simulator.Evaluate(&source.pod, dnsPod, intstr.FromInt32(dnsPort), corev1.ProtocolUDP)
+		if verdict.Allowed {
+			return nil
+		}
+		if blocked == nil {
+			blocked = &verdict
+		}
+	}
+	if blocked == nil {
+		return nil
+	}
+
+	related := make([]string, 0, len(blocked.Policies))
+	for _, policy := range blocked.Policies {
+		related = append(related, fmt.Sprintf("NetworkPolicy/%s", policy))
+	}
+	errorCode := GetNetworkingErrorCode("network_policy_blocks_dns")
+	finding := NewValidationErrorWithCode(source.kind, source.pod.Name, source.pod.Namespace, "network_policy_blocks_dns", errorCode,
+		fmt.Sprintf("NetworkPolicies block DNS queries from %s '%s' to the cluster DNS pods (%s traffic on port %d)", source.kind, source.pod.Name, blocked.BlockedBy, dnsPort)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(fmt.Sprintf("Add an egress rule to NetworkPolicy '%s' allowing UDP and TCP port %d to pods labelled k8s-app=kube-dns in namespace %s, selected with a namespaceSelector on kubernetes.io/metadata.name", blocked.Policies[0], dnsPort, dnsNamespace)).
+		WithRelatedResources(related...).
+		WithDetail("blocked_direction", blocked.BlockedBy).
+		WithDetail("blocking_policies", blocked.Namespace+"/"+strings.Join(blocked.Policies, ","))
+	return &finding
+}
+
+// dnsPods returns the cluster DNS pods selected by the kube-dns Service. When no
+// workload is selected, as when validating manifests alone, a pod with the usual
+// kube-dns labels and ports stands in for them.
+func (v *NetworkingValidator) dnsPods(ctx context.Context, workloads []policyWorkload) ([]*corev1.Pod, error) {
+	selector := defaultDNSSelector
+	var service corev1.Service
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: dnsNamespace, Name: dnsServiceName}, &service)
+	switch {
+	case err == nil && len(service.Spec.Selector) > 0:
+		selector = service.Spec.Selector
+	case err != nil && !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("failed to get dns service: %w", err)
+	}
+
+	var pods []*corev1.Pod
+	for i := range workloads {
+		pod := &workloads[i].pod
+		if pod.Namespace == dnsNamespace && labels.SelectorFromSet(selector).Matches(labels.Set(pod.Labels)) {
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		pods = append(pods, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: dnsServiceName, Namespace: dnsNamespace, Labels: selector},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "coredns", Ports: []corev1.ContainerPort{
+				{Name: "dns", ContainerPort: dnsPort, Protocol: corev1.ProtocolUDP},
+				{Name: "dns-tcp", ContainerPort: dnsPort, Protocol: corev1.ProtocolTCP},
+			}}}},
+		})
+	}
+	return pods, nil
+}
+
+// validateNamespaceSelectors reports policy peers whose namespaceSelector matches no
+// namespace, usually because of a misspelt label or a namespace that was never created
+func (v *NetworkingValidator) validateNamespaceSelectors(policies []networkingv1.NetworkPolicy, namespaces []corev1.Namespace) []ValidationError {
+	var errors []ValidationError
+
+	simulator := NewNetworkPolicySimulator(nil, namespaces)
+	matchesAny := func(selector *metav1.LabelSelector) bool {
+		for _, namespace := range namespaces {
+			if selectorMatches(selector, simulator.labelsOfNamespace(namespace.Name)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, policy := range policies {
+		if v.isSystemNamespace(policy.Namespace) {
+			continue
+		}
+		check := func(direction string, index int, peers []networkingv1.NetworkPolicyPeer) {
+			for _, peer := range peers {
+				if peer.NamespaceSelector == nil || matchesAny(peer.NamespaceSelector) {
+					continue
+				}
+				selector := metav1.FormatLabelSelector(peer.NamespaceSelector)
+				errorCode := GetNetworkingErrorCode("network_policy_namespace_selector_unmatched")
+				errors = append(errors, NewValidationErrorWithCode("NetworkPolicy", policy.Name, policy.Namespace, "network_policy_namespace_selector_unmatched", errorCode,
+					fmt.Sprintf("NetworkPolicy %s rule %d selects namespaces with '%s', which matches no namespace", direction, index, selector)).
+					WithSeverity(SeverityWarning).
+					WithRemediationHint("Correct the namespaceSelector labels, label the intended namespace, or select it by name with the kubernetes.io/metadata.name label").
+					WithRelatedResources(fmt.Sprintf("NetworkPolicy/%s", policy.Name)).
+					WithDetail("direction", direction).
+					WithDetail("namespace_selector", selector))
+			}
+		}
+		for i, rule := range policy.Spec.Egress {
+			check(TrafficBlockedByEgress, i, rule.To)
+		}
+		for i, rule := range policy.Spec.Ingress {
+			check(TrafficBlockedByIngress, i, rule.From)
+		}
+	}
+
+	return errors
+}
+
+// validateIPBlockOverlaps reports ipBlocks that cover addresses of pods or Services.
+// ipBlocks are meant for traffic leaving the cluster, and whether they match pod
+// traffic depends on the network plugin. Blocks covering every address are reported
+// as open egress instead.
+func (v *NetworkingValidator) validateIPBlockOverlaps(policies []networkingv1.NetworkPolicy, ranges []clusterRange) []ValidationError {
+	var errors []ValidationError
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	for _, policy := range policies {
+		if v.isSystemNamespace(policy.Namespace) {
+			continue
+		}
+		check := func(direction string, index int, peers []networkingv1.NetworkPolicyPeer) {
+			for _, peer := range peers {
+				if peer.IPBlock == nil {
+					continue
+				}
+				_, block, err := net.ParseCIDR(peer.IPBlock.CIDR)
+				if err != nil {
+					continue
+				}
+				if ones, _ := block.Mask.Size(); ones == 0 {
+					continue
+				}
+
+				var overlapping []string
+				for _, r := range ranges {
+					if cidrsOverlap(block, r.cidr) && !cidrExcluded(r.cidr, peer.IPBlock.Except) {
+						overlapping = append(overlapping, r.description)
+					}
+				}
+				if len(overlapping) == 0 {
+					continue
+				}
+				reported := overlapping
+				if len(reported) > maxReportedOverlaps {
+					reported = append(reported[:maxReportedOverlaps:maxReportedOverlaps], fmt.Sprintf("%d more", len(overlapping)-maxReportedOverlaps))
+				}
+
+				errorCode := GetNetworkingErrorCode("network_policy_ipblock_overlaps_cluster")
+				errors = append(errors, NewValidationErrorWithCode("NetworkPolicy", policy.Name, policy.Namespace, "network_policy_ipblock_overlaps_cluster", errorCode,
+					fmt.Sprintf("NetworkPolicy %s rule %d ipBlock %s overlaps %s", direction, index, peer.IPBlock.CIDR, overlapping[0])).
+					WithSeverity(SeverityWarning).
+					WithRemediationHint("Select in-cluster pods with podSelector and namespaceSelector peers, and narrow the ipBlock or add the cluster ranges to its except list").
+					WithRelatedResources(fmt.Sprintf("NetworkPolicy/%s", policy.Name)).
+					WithDetail("direction", direction).
+					WithDetail("cidr", peer.IPBlock.CIDR).
+					WithDetail("overlaps", strings.Join(reported, "; ")))
+			}
+		}
+		for i, rule := range policy.Spec.Egress {
+			check(TrafficBlockedByEgress, i, rule.To)
+		}
+		for i, rule := range policy.Spec.Ingress {
+			check(TrafficBlockedByIngress, i, rule.From)
+		}
+	}
+
+	return errors
+}
+
+// clusterRanges returns the configured cluster CIDRs, the pod CIDRs of nodes and the
+// ClusterIPs of Services. Node pod CIDRs are not set by every network plugin, so pod IPs
+// are used in their absence.
+func (v *NetworkingValidator) clusterRanges(ctx context.Context, reader client.Reader) ([]clusterRange, error) {
+	var ranges []clusterRange
+	add := func(cidr, description string) {
+		if _, parsed, err := net.ParseCIDR(cidr); err == nil {
+			ranges = append(ranges, clusterRange{cidr: parsed, description: description})
+		}
+	}
+	addIP := func(ip, description string) {
+		if parsed := net.ParseIP(ip); parsed != nil {
+			bits := 8 * net.IPv6len
+			if parsed.To4() != nil {
+				parsed, bits = parsed.To4(), 8*net.IPv4len
+			}
+			ranges = append(ranges, clusterRange{cidr: &net.IPNet{IP: parsed, Mask: net.CIDRMask(bits, bits)}, description: description})
+		}
+	}
+
+	for _, cidr := range v.config.ClusterCIDRs {
+		add(cidr, "cluster CIDR "+cidr)
+	}
+
+	var nodes corev1.NodeList
+	if err := reader.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	hasPodCIDRs := false
+	for _, node := range nodes.Items {
+		for _, cidr := range node.Spec.PodCIDRs {
+			hasPodCIDRs = true
+			add(cidr, fmt.Sprintf("pod CIDR %s of node %s", cidr, node.Name))
+		}
+	}
+
+	var services corev1.ServiceList
+	if err := reader.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	for _, service := range services.Items {
+		for _, ip := range service.Spec.ClusterIPs {
+			addIP(ip, fmt.Sprintf("ClusterIP %s of Service %s/%s", ip, service.Namespace, service.Name))
+		}
+	}
+
+	if !hasPodCIDRs {
+		err := forEachPod(ctx, reader, func(pod *corev1.Pod) error {
+			if pod.Spec.HostNetwork {
+				return nil
+			}
+			for _, ip := range pod.Status.PodIPs {
+				addIP(ip.IP, fmt.Sprintf("IP %s of pod %s/%s", ip.IP, pod.Namespace, pod.Name))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+	}
+
+	return ranges, nil
+}
+
+// cidrsOverlap reports whether two CIDRs share any address
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// cidrExcluded reports whether cidr lies wholly within one of the except CIDRs
+func cidrExcluded(cidr *net.IPNet, except []string) bool {
+	ones, _ := cidr.Mask.Size()
+	for _, e := range except {
+		_, excluded, err := net.ParseCIDR(e)
+		if err != nil {
+			continue
+		}
+		if excludedOnes, _ := excluded.Mask.Size(); excludedOnes <= ones && excluded.Contains(cidr.IP) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNetworkingValidator_ValidateEgressPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	deployment := func(namespace, name string, spec corev1.PodSpec) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": name}},
+				Spec:       spec,
+			}},
+		}
+	}
+	egressPolicy := func(namespace, name string, rules ...networkingv1.NetworkPolicyEgressRule) *networkingv1.NetworkPolicy {
+		return &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress:      rules,
+			},
+		}
+	}
+	udp := corev1.ProtocolUDP
+	dns := intstr.FromInt32(53)
+	toNamespace := func(labels map[string]string) networkingv1.NetworkPolicyPeer {
+		return networkingv1.NetworkPolicyPeer{NamespaceSelector: &metav1.LabelSelector{MatchLabels: labels}}
+	}
+
+	objects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "test-ns"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"team": "payments"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}, Spec: corev1.NodeSpec{PodCIDRs: []string{"10.244.0.0/24"}}},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-dns", Namespace: "kube-system"},
+			Spec:       corev1.ServiceSpec{Selector: map[string]string{"k8s-app": "kube-dns"}, ClusterIPs: []string{"10.96.0.10"}},
+		},
+		// shop denies all egress, so its workloads cannot resolve names
+		egressPolicy("shop", "default-deny"),
+		deployment("shop", "web", corev1.PodSpec{}),
+		deployment("shop", "node-dns", corev1.PodSpec{DNSPolicy: corev1.DNSDefault}),
+		// test-ns allows DNS to kube-system, a misspelt namespace and a range covering the pods
+		egressPolicy("test-ns", "allow-dns",
+			networkingv1.NetworkPolicyEgressRule{
+				To:    []networkingv1.NetworkPolicyPeer{toNamespace(map[string]string{"kubernetes.io/metadata.name": "kube-system"})},
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp, Port: &dns}},
+			},
+			networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{toNamespace(map[string]string{"team": "paymnets"})}},
+			networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}},
+			networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.96.0.0/12", "10.244.0.0/16"}}}}},
+			networkingv1.NetworkPolicyEgressRule{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.10.0/24"}}}},
+		),
+		deployment("test-ns", "api", corev1.PodSpec{}),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewNetworkingValidator(fakeClient, logr.Discard(), NetworkingConfig{EnableEgressValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	got := make(map[string][]ValidationError)
+	for _, finding := range validator.GetLastValidationErrors() {
+		got[finding.ValidationType] = append(got[finding.ValidationType], finding)
+	}
+
+	if dnsFindings := got["network_policy_blocks_dns"]; len(dnsFindings) != 1 || dnsFindings[0].ResourceName != "web" || dnsFindings[0].ErrorCode != "KOGARO-NET-020" {
+		t.Errorf("blocked DNS findings = %+v, want one on Deployment web", dnsFindings)
+	}
+	if selectorFindings := got["network_policy_namespace_selector_unmatched"]; len(selectorFindings) != 1 || selectorFindings[0].Details["namespace_selector"] != "team=paymnets" {
+		t.Errorf("unmatched namespaceSelector findings = %+v, want one for team=paymnets", selectorFindings)
+	}
+	overlaps := got["network_policy_ipblock_overlaps_cluster"]
+	if len(overlaps) != 1 {
+		t.Fatalf("ipBlock overlap findings = %+v, want one for the rule without except", overlaps)
+	}
+	if want := "pod CIDR 10.244.0.0/24 of node worker-1; ClusterIP 10.96.0.10 of Service kube-system/kube-dns"; overlaps[0].Details["overlaps"] != want {
+		t.Errorf("overlaps = %q, want %q", overlaps[0].Details["overlaps"], want)
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// ingressRoute is a single host and path an Ingress routes to a backend
+type ingressRoute struct {
+	namespace string
+	name      string
+	class     string
+	host      string
+	path      string
+	pathType  networkingv1.PathType
+	backend   string
+}
+
+// validateIngressHostCollisions lists all Ingresses, including those of other shards'
+// namespaces, and reports host collisions between them
+func (v *NetworkingValidator) validateIngressHostCollisions(ctx context.Context) ([]ValidationError, error) {
+	var ingresses networkingv1.IngressList
+	if err := allShards(v.client).List(ctx, &ingresses); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	return v.findIngressHostCollisions(ingresses.Items), nil
+}
+
+// findIngressHostCollisions reports Ingresses of the same class that route the same
+// path of the same host, or of a host matched by a wildcard host, to different
+// backends. Ingress controllers resolve these conflicts differently, and often by
+// creation order, so the backend that serves the traffic is not predictable. Each
+// conflicting pair of Ingresses is reported once, on the later Ingress by
+// namespace/name. With IngressCollisionCrossNamespaceOnly set, conflicts between
+// Ingresses in the same namespace are left to the namespace owner.
+//
+// An Exact path takes precedence over a Prefix path of the same value, so such routes
+// don't collide; they are reported with the other path overlaps of
+// findIngressPathOverlaps.
+func (v *NetworkingValidator) findIngressHostCollisions(ingresses []networkingv1.Ingress) []ValidationError {
+	var errors []ValidationError
+
+	byClass := make(map[string][]ingressRoute)
+	byClassAndPath := make(map[string][]ingressRoute)
+	for _, ingress := range ingresses {
+		if v.isSystemNamespace(ingress.Namespace) {
+			continue
+		}
+		for _, rule := range ingress.Spec.Rules {
+			// Rules without a host match all hosts and are not compared
+			if rule.Host == "" || rule.HTTP == nil {
+				continue
+			}
+			for _, path := range rule.HTTP.Paths {
+				route := ingressRoute{
+					namespace: ingress.Namespace,
+					name:      ingress.Name,
+					class:     ingressClass(ingress),
+					host:      rule.Host,
+					path:      path.Path,
+					pathType:  networkingv1.PathTypeImplementationSpecific,
+					backend:   ingressBackendKey(ingress.Namespace, path.Backend),
+				}
+				if path.PathType != nil {
+					route.pathType = *path.PathType
+				}
+				key := route.class + "|" + route.path
+				byClassAndPath[key] = append(byClassAndPath[key], route)
+				byClass[route.class] = append(byClass[route.class], route)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(byClassAndPath))
+	for key := range byClassAndPath {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	reported := make(map[string]bool)
+	for _, key := range keys {
+		routes := byClassAndPath[key]
+		sort.Slice(routes, func(i, j int) bool {
+			if routes[i].namespace != routes[j].namespace {
+				return routes[i].namespace < routes[j].namespace
+			}
+			return routes[i].name < routes[j].name
+		})
+
+		for j, route := range routes {
+			for _, earlier := range routes[:j] {
+				if earlier.namespace == route.namespace && earlier.name == route.name {
+					continue
+				}
+				if earlier.backend == route.backend || exactAndPrefix(earlier, route) {
+					continue
+				}
+				if v.config.IngressCollisionCrossNamespaceOnly && earlier.namespace == route.namespace {
+					continue
+				}
+
+				validationType := ""
+				switch {
+				case earlier.host == route.host:
+					validationType = "ingress_host_collision"
+				case wildcardHostMatches(earlier.host, route.host) || wildcardHostMatches(route.host, earlier.host):
+					validationType = "ingress_wildcard_host_overlap"
+				default:
+					continue
+				}
+
+				pair := strings.Join([]string{validationType, earlier.namespace, earlier.name, route.namespace, route.name}, "|")
+				if reported[pair] {
+					continue
+				}
+				reported[pair] = true
+
+				errors = append(errors, newIngressCollisionError(validationType, route, earlier))
+			}
+		}
+	}
+
+	return append(errors, findIngressPathOverlaps(byClass)...)
+}
+
+// findIngressPathOverlaps reports Ingresses that take requests under a Prefix path
+// another namespace routes for the same host to a different backend. The longest
+// matching path serves a request, so the traffic goes predictably to the longer or
+// Exact path, but one namespace silently carves part of another's path out. Overlaps
+// within a namespace are the usual way to split a host across Ingresses and are not
+// reported, nor are paths of type ImplementationSpecific, whose matching depends on the
+// ingress controller. Each overlapping pair of Ingresses is reported once, on the
+// Ingress whose path takes the requests.
+func findIngressPathOverlaps(byClass map[string][]ingressRoute) []ValidationError {
+	var errors []ValidationError
+
+	classes := make([]string, 0, len(byClass))
+	for class := range byClass {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	reported := make(map[string]bool)
+	for _, class := range classes {
+		routes := byClass[class]
+		sort.SliceStable(routes, func(i, j int) bool {
+			if routes[i].namespace != routes[j].namespace {
+				return routes[i].namespace < routes[j].namespace
+			}
+			return routes[i].name < routes[j].name
+		})
+
+		for _, route := range routes {
+			for _, prefix := range routes {
+				if prefix.namespace == route.namespace || prefix.host != route.host || prefix.backend == route.backend {
+					continue
+				}
+				if !prefixPathCovers(prefix, route) {
+					continue
+				}
+
+				pair := strings.Join([]string{route.namespace, route.name, prefix.namespace, prefix.name}, "|")
+				if reported[pair] {
+					continue
+				}
+				reported[pair] = true
+
+				errors = append(errors, newIngressCollisionError("ingress_path_overlap", route, prefix))
+			}
+		}
+	}
+
+	return errors
+}
+
+// prefixPathCovers reports whether the Prefix path of one route matches the requests
+// of another route's longer path, or its Exact path of the same value. Prefixes match
+// by path element, so /api covers /api/v1 but not /apis.
+func prefixPathCovers(prefix, route ingressRoute) bool {
+	if prefix.pathType != networkingv1.PathTypePrefix || route.pathType == networkingv1.PathTypeImplementationSpecific {
+		return false
+	}
+	if route.path == prefix.path {
+		return route.pathType == networkingv1.PathTypeExact
+	}
+	base := strings.TrimSuffix(prefix.path, "/")
+	return strings.TrimSuffix(route.path, "/") != base && strings.HasPrefix(route.path, base+"/")
+}
+
+// exactAndPrefix reports whether one route has an Exact path and the other a Prefix
+// path, so that the Exact path takes precedence rather than the routes colliding
+func exactAndPrefix(a, b ingressRoute) bool {
+	return (a.pathType == networkingv1.PathTypeExact && b.pathType == networkingv1.PathTypePrefix) ||
+		(a.pathType == networkingv1.PathTypePrefix && b.pathType == networkingv1.PathTypeExact)
+}
+
+func newIngressCollisionError(validationType string, route, other ingressRoute) ValidationError {
+	otherRef := fmt.Sprintf("Ingress/%s", other.name)
+	otherDescription := fmt.Sprintf("Ingress '%s'", other.name)
+	if other.namespace != route.namespace {
+		otherRef = fmt.Sprintf("Ingress/%s/%s", other.namespace, other.name)
+		otherDescription = fmt.Sprintf("Ingress '%s' in namespace '%s'", other.name, other.namespace)
+	}
+
+	message := fmt.Sprintf("Ingress '%s' routes host '%s' path '%s' to a different backend than %s", route.name, route.host, route.path, otherDescription)
+	severity := SeverityError
+	hint := "Serve each host and path from a single Ingress; the ingress controller decides nondeterministically which backend receives the traffic"
+	switch validationType {
+	case "ingress_wildcard_host_overlap":
+		message = fmt.Sprintf("Ingress '%s' host '%s' overlaps host '%s' of %s for path '%s' with a different backend", route.name, route.host, other.host, otherDescription, route.path)
+		severity = SeverityWarning
+		hint = "Check which backend should serve the overlapping hosts; ingress controllers differ in whether the exact host or the wildcard host wins"
+	case "ingress_path_overlap":
+		message = fmt.Sprintf("Ingress '%s' path '%s' of host '%s' takes requests under Prefix path '%s' of %s to a different backend", route.name, route.path, route.host, other.path, otherDescription)
+		severity = SeverityWarning
+		hint = fmt.Sprintf("Check that Ingress '%s' should serve requests under '%s' instead of the owner of the Prefix path, or give each namespace its own host", route.name, other.path)
+	}
+
+	errorCode := GetNetworkingErrorCode(validationType)
+	return NewValidationErrorWithCode("Ingress", route.name, route.namespace, validationType, errorCode, message).
+		WithSeverity(severity).
+		WithRemediationHint(hint).
+		WithRelatedResources(otherRef).
+		WithDetail("conflicting_ingress", other.namespace+"/"+other.name).
+		WithDetail("host", route.host).
+		WithDetail("conflicting_host", other.host).
+		WithDetail("path", route.path).
+		WithDetail("conflicting_path", other.path).
+		WithDetail("backend", route.backend).
+		WithDetail("conflicting_backend", other.backend)
+}
+
+// wildcardHostMatches reports whether a wildcard host such as *.example.com matches
+// host. As in the Ingress API, the wildcard covers exactly one DNS label.
+func wildcardHostMatches(wildcard, host string) bool {
+	if !strings.HasPrefix(wildcard, "*.") || strings.HasPrefix(host, "*.") {
+		return false
+	}
+	suffix := wildcard[1:]
+	if !strings.HasSuffix(host, suffix) {
+		return false
+	}
+	label := strings.TrimSuffix(host, suffix)
+	return label != "" && !strings.Contains(label, ".")
+}
+
+// ingressBackendKey identifies the backend of an Ingress path
+func ingressBackendKey(namespace string, backend networkingv1.IngressBackend) string {
+	switch {
+	case backend.Service != nil:
+		port := backend.Service.Port.Name
+		if backend.Service.Port.Number != 0 {
+			port = fmt.Sprintf("%d", backend.Service.Port.Number)
+		}
+		return fmt.Sprintf("Service/%s/%s:%s", namespace, backend.Service.Name, port)
+	case backend.Resource != nil:
+		return fmt.Sprintf("%s/%s/%s", backend.Resource.Kind, namespace, backend.Resource.Name)
+	default:
+		return ""
+	}
+}
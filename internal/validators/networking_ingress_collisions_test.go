@@ -0,0 +1,163 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestNetworkingValidator_FindIngressHostCollisions(t *testing.T) {
+	ingress := func(namespace, name, host, path, service string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: host,
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:    path,
+							Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: service}},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+
+	ingresses := []networkingv1.Ingress{
+		ingress("team-a", "shop", "shop.example.com", "/", "shop"),
+		ingress("team-b", "shop-preview", "shop.example.com", "/", "preview"),
+		ingress("team-a", "shop-api", "shop.example.com", "/api", "api"),
+		ingress("team-a", "shop-api-v2", "shop.example.com", "/api", "api-v2"),
+		ingress("team-c", "catch-all", "*.example.com", "/", "fallback"),
+		// Same backend as shop: a duplicate, not a collision
+		ingress("team-a", "shop-api-copy", "shop.example.com", "/api", "api"),
+		// Wildcards cover a single label only
+		ingress("team-d", "deep", "a.shop.example.com", "/", "deep"),
+	}
+
+	tests := []struct {
+		name               string
+		crossNamespaceOnly bool
+		expected           []string
+	}{
+		{
+			name: "all namespaces",
+			expected: []string{
+				"ingress_host_collision team-b/shop-preview",
+				"ingress_wildcard_host_overlap team-c/catch-all",
+				"ingress_wildcard_host_overlap team-c/catch-all",
+				"ingress_host_collision team-a/shop-api-v2",
+				"ingress_host_collision team-a/shop-api-v2",
+			},
+		},
+		{
+			name:               "cross namespace only",
+			crossNamespaceOnly: true,
+			expected: []string{
+				"ingress_host_collision team-b/shop-preview",
+				"ingress_wildcard_host_overlap team-c/catch-all",
+				"ingress_wildcard_host_overlap team-c/catch-all",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := NewNetworkingValidator(nil, logr.Discard(), NetworkingConfig{
+				EnableIngressCollisionValidation:   true,
+				IngressCollisionCrossNamespaceOnly: tt.crossNamespaceOnly,
+			})
+
+			errors := validator.findIngressHostCollisions(ingresses)
+			var got []string
+			for _, e := range errors {
+				got = append(got, e.ValidationType+" "+e.Namespace+"/"+e.ResourceName)
+				if e.ErrorCode == "KOGARO-NET-UNKNOWN" {
+					t.Errorf("%s has no error code", e.ValidationType)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("findIngressHostCollisions() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestNetworkingValidator_FindIngressPathOverlaps(t *testing.T) {
+	ingress := func(namespace, name, path string, pathType networkingv1.PathType, service string) networkingv1.Ingress {
+		return networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ptr.To("nginx"),
+				Rules: []networkingv1.IngressRule{{
+					Host: "shop.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{{
+							Path:     path,
+							PathType: ptr.To(pathType),
+							Backend:  networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: service}},
+						}},
+					}},
+				}},
+			},
+		}
+	}
+
+	ingresses := []networkingv1.Ingress{
+		ingress("team-a", "shop", "/api", networkingv1.PathTypePrefix, "api"),
+		ingress("team-b", "shop-beta", "/api/v2", networkingv1.PathTypeExact, "api-v2"),
+		ingress("team-c", "shop-health", "/api", networkingv1.PathTypeExact, "health"),
+		// Prefixes match by path element
+		ingress("team-d", "apis", "/apis", networkingv1.PathTypePrefix, "apis"),
+		// Splitting a host within a namespace is not an overlap
+		ingress("team-a", "shop-admin", "/api/admin", networkingv1.PathTypePrefix, "admin"),
+		// Matching of ImplementationSpecific paths is up to the controller
+		ingress("team-e", "legacy", "/api/legacy", networkingv1.PathTypeImplementationSpecific, "legacy"),
+	}
+
+	validator := NewNetworkingValidator(nil, logr.Discard(), NetworkingConfig{EnableIngressCollisionValidation: true})
+	var got []string
+	for _, e := range validator.findIngressHostCollisions(ingresses) {
+		got = append(got, e.ValidationType+" "+e.Namespace+"/"+e.ResourceName+" "+e.Details["conflicting_ingress"])
+		if e.ErrorCode != "KOGARO-NET-030" {
+			t.Errorf("%s has error code %s, want KOGARO-NET-030", e.ValidationType, e.ErrorCode)
+		}
+	}
+	// The Exact and Prefix /api paths don't collide: the Exact path takes precedence
+	expected := []string{
+		"ingress_path_overlap team-b/shop-beta team-a/shop",
+		"ingress_path_overlap team-c/shop-health team-a/shop",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("findIngressHostCollisions() =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(expected, "\n"))
+	}
+}
+
+func TestWildcardHostMatches(t *testing.T) {
+	tests := []struct {
+		wildcard, host string
+		want           bool
+	}{
+		{"*.example.com", "shop.example.com", true},
+		{"*.example.com", "a.shop.example.com", false},
+		{"*.example.com", "example.com", false},
+		{"shop.example.com", "shop.example.com", false},
+		{"*.example.com", "*.example.com", false},
+	}
+	for _, tt := range tests {
+		if got := wildcardHostMatches(tt.wildcard, tt.host); got != tt.want {
+			t.Errorf("wildcardHostMatches(%q, %q) = %v, want %v", tt.wildcard, tt.host, got, tt.want)
+		}
+	}
+}
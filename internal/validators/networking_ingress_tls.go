@@ -0,0 +1,177 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// validateIngressTLS checks that the TLS sections of Ingresses cover the hosts they
+// route and reference certificates that can serve those hosts
+func (v *NetworkingValidator) validateIngressTLS(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+
+	var ingresses networkingv1.IngressList
+	if err := v.client.List(ctx, &ingresses); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	secrets := make(map[string]*corev1.Secret)
+	for _, ingress := range ingresses.Items {
+		if v.isSystemNamespace(ingress.Namespace) {
+			continue
+		}
+		errors = append(errors, v.validateIngressTLSHosts(ingress)...)
+
+		// Certificates are only checked when Secret data may be read
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName == "" || !secretDataReadable() {
+				continue
+			}
+			key := ingress.Namespace + "/" + tls.SecretName
+			secret, seen := secrets[key]
+			if !seen {
+				secret = &corev1.Secret{}
+				err := v.client.Get(ctx, client.ObjectKey{Namespace: ingress.Namespace, Name: tls.SecretName}, secret)
+				switch {
+				case apierrors.IsNotFound(err):
+					// Missing Secrets are reported by the reference validator
+					secret = nil
+				case err != nil:
+					return nil, fmt.Errorf("failed to get secret %s: %w", key, err)
+				}
+				secrets[key] = secret
+			}
+			if secret != nil {
+				errors = append(errors, v.validateIngressTLSSecret(ingress, tls, *secret)...)
+			}
+		}
+	}
+
+	return errors, nil
+}
+
+// validateIngressTLSHosts compares the hosts of an Ingress's rules with the hosts of its
+// TLS sections. TLS sections without hosts apply to every host of the Ingress.
+func (v *NetworkingValidator) validateIngressTLSHosts(ingress networkingv1.Ingress) []ValidationError {
+	var errors []ValidationError
+
+	var ruleHosts, tlsHosts []string
+	coversAll := false
+	for _, rule := range ingress.Spec.Rules {
+		if rule.Host != "" {
+			ruleHosts = append(ruleHosts, rule.Host)
+		}
+	}
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			coversAll = true
+		}
+		tlsHosts = append(tlsHosts, tls.Hosts...)
+	}
+
+	if v.config.RequireIngressTLS && !coversAll {
+		var plain []string
+		for _, host := range ruleHosts {
+			if !hostCoveredBy(host, tlsHosts) && !slices.Contains(plain, host) {
+				plain = append(plain, host)
+			}
+		}
+		if len(plain) > 0 {
+			errorCode := GetNetworkingErrorCode("ingress_host_without_tls")
+			errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "ingress_host_without_tls", errorCode,
+				fmt.Sprintf("Ingress serves hosts without TLS: %s", strings.Join(plain, ", "))).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Add the hosts to a spec.tls section with a certificate Secret, or disable --require-ingress-tls if plain HTTP is intended").
+				WithDetail("hosts", strings.Join(plain, ",")))
+		}
+	}
+
+	for i, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			if hostCoveredBy(host, ruleHosts) {
+				continue
+			}
+			errorCode := GetNetworkingErrorCode("ingress_tls_host_without_rule")
+			errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "ingress_tls_host_without_rule", errorCode,
+				fmt.Sprintf("Ingress TLS section %d lists host '%s', which no rule of the Ingress routes", i, host)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(fmt.Sprintf("Add a rule for host '%s', or remove it from the TLS section; a typo here leaves the routed host without TLS", host)).
+				WithDetail("tls_host", host).
+				WithDetail("rule_hosts", strings.Join(ruleHosts, ",")))
+		}
+	}
+
+	return errors
+}
+
+// validateIngressTLSSecret checks the type of a TLS section's Secret and that its
+// certificate names the hosts the section serves
+func (v *NetworkingValidator) validateIngressTLSSecret(ingress networkingv1.Ingress, tls networkingv1.IngressTLS, secret corev1.Secret) []ValidationError {
+	if secret.Type != corev1.SecretTypeTLS {
+		errorCode := GetNetworkingErrorCode("ingress_tls_secret_wrong_type")
+		return []ValidationError{NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "ingress_tls_secret_wrong_type", errorCode,
+			fmt.Sprintf("Ingress TLS Secret '%s' has type '%s', not '%s'", secret.Name, secret.Type, corev1.SecretTypeTLS)).
+			WithSeverity(SeverityError).
+			WithRemediationHint(fmt.Sprintf("Recreate the Secret with 'kubectl create secret tls %s --cert=... --key=...'", secret.Name)).
+			WithRelatedResources(fmt.Sprintf("Secret/%s", secret.Name)).
+			WithDetail("secret_type", string(secret.Type))}
+	}
+
+	// Unparseable certificates are reported by the secret validator
+	cert, err := parseLeafCertificate(secretValue(secret, corev1.TLSCertKey))
+	if err != nil {
+		return nil
+	}
+
+	hosts := tls.Hosts
+	if len(hosts) == 0 {
+		for _, rule := range ingress.Spec.Rules {
+			if rule.Host != "" && !slices.Contains(hosts, rule.Host) {
+				hosts = append(hosts, rule.Host)
+			}
+		}
+	}
+	var unmatched []string
+	for _, host := range hosts {
+		if !hostCoveredBy(host, cert.DNSNames) {
+			unmatched = append(unmatched, host)
+		}
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+
+	errorCode := GetNetworkingErrorCode("ingress_tls_certificate_host_mismatch")
+	return []ValidationError{NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "ingress_tls_certificate_host_mismatch", errorCode,
+		fmt.Sprintf("Certificate in TLS Secret '%s' does not cover hosts: %s", secret.Name, strings.Join(unmatched, ", "))).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Issue a certificate whose subject alternative names include the hosts, or reference a Secret that has one").
+		WithRelatedResources(fmt.Sprintf("Secret/%s", secret.Name)).
+		WithDetail("hosts", strings.Join(unmatched, ",")).
+		WithDetail("certificate_dns_names", strings.Join(cert.DNSNames, ","))}
+}
+
+// hostCoveredBy reports whether host equals one of names, or is matched by a wildcard
+// among them. Host names are compared case-insensitively.
+func hostCoveredBy(host string, names []string) bool {
+	host = strings.ToLower(host)
+	for _, name := range names {
+		name = strings.ToLower(name)
+		if name == host || wildcardHostMatches(name, host) {
+			return true
+		}
+	}
+	return false
+}
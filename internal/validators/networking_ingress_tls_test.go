@@ -0,0 +1,146 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// generateHostCertificate returns a PEM-encoded self-signed certificate for hosts
+func generateHostCertificate(t *testing.T, hosts ...string) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		DNSNames:     hosts,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestNetworkingValidator_ValidateIngressTLS(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	ingress := func(name string, tls []networkingv1.IngressTLS, hosts ...string) client.Object {
+		var rules []networkingv1.IngressRule
+		for _, host := range hosts {
+			rules = append(rules, networkingv1.IngressRule{Host: host})
+		}
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec:       networkingv1.IngressSpec{TLS: tls, Rules: rules},
+		}
+	}
+	tlsSecret := func(name string, hosts ...string) client.Object {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Type:       corev1.SecretTypeTLS,
+			Data:       map[string][]byte{corev1.TLSCertKey: generateHostCertificate(t, hosts...), corev1.TLSPrivateKeyKey: []byte("key")},
+		}
+	}
+
+	objects := []client.Object{
+		tlsSecret("wildcard-tls", "*.example.com"),
+		tlsSecret("web-tls", "www.example.com"),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "opaque-tls", Namespace: "shop"}, Type: corev1.SecretTypeOpaque},
+		// Covered by a wildcard certificate
+		ingress("shop", []networkingv1.IngressTLS{{Hosts: []string{"shop.example.com"}, SecretName: "wildcard-tls"}}, "shop.example.com"),
+		// TLS host misspelt, so the routed host is served without TLS
+		ingress("typo", []networkingv1.IngressTLS{{Hosts: []string{"api.exmaple.com"}, SecretName: "missing-tls"}}, "api.example.com"),
+		ingress("opaque", []networkingv1.IngressTLS{{Hosts: []string{"blog.example.com"}, SecretName: "opaque-tls"}}, "blog.example.com"),
+		// TLS without hosts applies to every rule host, and the certificate only covers one
+		ingress("mismatch", []networkingv1.IngressTLS{{SecretName: "web-tls"}}, "www.example.com", "example.com"),
+		ingress("plain", nil, "docs.example.com"),
+	}
+
+	tests := []struct {
+		name       string
+		requireTLS bool
+		expected   []string
+	}{
+		{
+			name: "tls optional",
+			expected: []string{
+				"ingress_tls_certificate_host_mismatch mismatch",
+				"ingress_tls_host_without_rule typo",
+				"ingress_tls_secret_wrong_type opaque",
+			},
+		},
+		{
+			name:       "tls required",
+			requireTLS: true,
+			expected: []string{
+				"ingress_host_without_tls plain",
+				"ingress_host_without_tls typo",
+				"ingress_tls_certificate_host_mismatch mismatch",
+				"ingress_tls_host_without_rule typo",
+				"ingress_tls_secret_wrong_type opaque",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			validator := NewNetworkingValidator(fakeClient, logr.Discard(), NetworkingConfig{
+				EnableIngressTLSValidation: true,
+				RequireIngressTLS:          tt.requireTLS,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			var got []string
+			for _, finding := range validator.GetLastValidationErrors() {
+				got = append(got, finding.ValidationType+" "+finding.ResourceName)
+				if finding.ValidationType == "ingress_tls_certificate_host_mismatch" && finding.Details["hosts"] != "example.com" {
+					t.Errorf("mismatched hosts = %q, want %q", finding.Details["hosts"], "example.com")
+				}
+			}
+			sort.Strings(got)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("findings = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("finding %d = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
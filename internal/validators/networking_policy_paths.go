@@ -0,0 +1,377 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+// addressEnvSuffixes end the names of environment variables that hold a host name, so
+// that a bare Service name in them is taken as a dependency
+var addressEnvSuffixes = []string{"HOST", "HOSTNAME", "ADDR", "ADDRESS", "URL", "URI", "ENDPOINT", "SERVER", "SERVERS", "SERVICE"}
+
+// policyWorkload is a workload or bare pod whose pod template is simulated as a pod
+type policyWorkload struct {
+	kind string
+	pod  corev1.Pod
+}
+
+// serviceDependency is a Service that a workload names in an environment variable
+type serviceDependency struct {
+	service corev1.Service
+	// port is the port given alongside the name, or zero when none is
+	port   int32
+	envVar string
+}
+
+// validateNetworkPolicyPaths simulates the NetworkPolicies of the cluster to find
+// workloads with no allowed path to the Services they depend on, and reports egress
+// rules open to every address. Services and their backends may lie in namespaces of
+// other shards, so every shard's objects are simulated.
+func (v *NetworkingValidator) validateNetworkPolicyPaths(ctx context.Context) ([]ValidationError, error) {
+	reader := allShards(v.client)
+	var policies networkingv1.NetworkPolicyList
+	if err := reader.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
+	}
+
+	errors := v.validateEgressAllowAll(policies.Items)
+	if len(policies.Items) == 0 {
+		return errors, nil
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := reader.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	var services corev1.ServiceList
+	if err := reader.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	workloads, err := v.listPolicyWorkloads(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	simulator := NewNetworkPolicySimulator(policies.Items, namespaces.Items)
+	servicesByName := make(map[string]corev1.Service, len(services.Items))
+	for _, service := range services.Items {
+		servicesByName[service.Namespace+"/"+service.Name] = service
+	}
+
+	for i := range workloads {
+		source := &workloads[i]
+		if v.isSystemNamespace(source.pod.Namespace) {
+			continue
+		}
+		for _, dependency := range serviceDependencies(source.pod.Spec, source.pod.Namespace, servicesByName) {
+			if finding := v.validateDependencyPath(simulator, source, dependency, workloads); finding != nil {
+				errors = append(errors, *finding)
+			}
+		}
+	}
+
+	return errors, nil
+}
+
+// validateDependencyPath reports a workload whose traffic NetworkPolicies block on every
+// port of a Service it depends on, to every pod backing the Service
+func (v *NetworkingValidator) validateDependencyPath(simulator *NetworkPolicySimulator, source *policyWorkload, dependency serviceDependency, workloads []policyWorkload) *ValidationError {
+	service := dependency.service
+	var backends []*corev1.Pod
+	for i := range workloads {
+		pod := &workloads[i].pod
+		if pod.Namespace == service.Namespace && labels.SelectorFromSet(service.Spec.Selector).Matches(labels.Set(pod.Labels)) {
+			backends = append(backends, pod)
+		}
+	}
+	// Services without backends are reported by the Service connectivity checks
+	if len(backends) == 0 {
+		return nil
+	}
+
+	ports := service.Spec.Ports
+	if dependency.port != 0 {
+		for _, port := range service.Spec.Ports {
+			if port.Port == dependency.port {
+				ports = []corev1.ServicePort{port}
+				break
+			}
+		}
+	}
+
+	var blocked *TrafficVerdict
+	var blockedPort corev1.ServicePort
+	for _, port := range ports {
+		targetPort := port.TargetPort
+		if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+			targetPort = intstr.FromInt32(port.Port)
+		}
+		for _, backend := range backends {
+			verdict := simulator.Evaluate(&source.pod, backend, targetPort, port.Protocol)
+			if verdict.Allowed {
+				return nil
+			}
+			if blocked == nil {
+				blocked, blockedPort = &verdict, port
+			}
+		}
+	}
+	if blocked == nil {
+		return nil
+	}
+
+	serviceRef := service.Namespace + "/" + service.Name
+	var hint string
+	if blocked.BlockedBy == TrafficBlockedByEgress {
+		hint = fmt.Sprintf("Add an egress rule to NetworkPolicy '%s' in namespace '%s' allowing traffic to the pods of Service '%s' on port %d",
+			blocked.Policies[0], blocked.Namespace, serviceRef, blockedPort.Port)
+	} else {
+		hint = fmt.Sprintf("Add an ingress rule to NetworkPolicy '%s' in namespace '%s' allowing traffic from %s '%s' in namespace '%s' on port %d",
+			blocked.Policies[0], blocked.Namespace, source.kind, source.pod.Name, source.pod.Namespace, blockedPort.Port)
+	}
+	related := []string{fmt.Sprintf("Service/%s", service.Name)}
+	for _, policy := range blocked.Policies {
+		related = append(related, fmt.Sprintf("NetworkPolicy/%s", policy))
+	}
+
+	errorCode := GetNetworkingErrorCode("network_policy_blocks_dependency")
+	finding := NewValidationErrorWithCode(source.kind, source.pod.Name, source.pod.Namespace, "network_policy_blocks_dependency", errorCode,
+		fmt.Sprintf("%s '%s' references Service '%s' in %s, but NetworkPolicies block its %s traffic to every pod of the Service", source.kind, source.pod.Name, serviceRef, dependency.envVar, blocked.BlockedBy)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(hint).
+		WithRelatedResources(related...).
+		WithDetail("service", serviceRef).
+		WithDetail("service_port", strconv.Itoa(int(blockedPort.Port))).
+		WithDetail("env_var", dependency.envVar).
+		WithDetail("blocked_direction", blocked.BlockedBy).
+		WithDetail("blocking_policies", blocked.Namespace+"/"+strings.Join(blocked.Policies, ","))
+	return &finding
+}
+
+// validateEgressAllowAll reports egress rules allowing traffic to every IPv4 or IPv6 address
+func (v *NetworkingValidator) validateEgressAllowAll(policies []networkingv1.NetworkPolicy) []ValidationError {
+	var errors []ValidationError
+
+	for _, policy := range policies {
+		if v.isSystemNamespace(policy.Namespace) {
+			continue
+		}
+		for i, rule := range policy.Spec.Egress {
+			for _, peer := range rule.To {
+				if peer.IPBlock == nil {
+					continue
+				}
+				_, cidr, err := net.ParseCIDR(peer.IPBlock.CIDR)
+				if err != nil {
+					continue
+				}
+				if ones, _ := cidr.Mask.Size(); ones != 0 {
+					continue
+				}
+
+				// Rules limited to some ports, such as HTTPS to external APIs, are a common choice
+				severity, scope := SeverityWarning, "on all ports"
+				if len(rule.Ports) > 0 {
+					severity, scope = SeverityInfo, "on ports "+formatPolicyPorts(rule.Ports)
+				}
+				errorCode := GetNetworkingErrorCode("network_policy_egress_allow_all")
+				errors = append(errors, NewValidationErrorWithCode("NetworkPolicy", policy.Name, policy.Namespace, "network_policy_egress_allow_all", errorCode,
+					fmt.Sprintf("NetworkPolicy egress rule %d allows traffic to any address (%s) %s", i, peer.IPBlock.CIDR, scope)).
+					WithSeverity(severity).
+					WithRemediationHint("Restrict the egress rule to the CIDRs and ports of the external services the pods need, and reach in-cluster Services through podSelector and namespaceSelector peers").
+					WithRelatedResources(fmt.Sprintf("NetworkPolicy/%s", policy.Name)).
+					WithDetail("cidr", peer.IPBlock.CIDR).
+					WithDetail("except", strings.Join(peer.IPBlock.Except, ",")).
+					WithDetail("pod_selector", metav1.FormatLabelSelector(&policy.Spec.PodSelector)))
+			}
+		}
+	}
+
+	return errors
+}
+
+// listPolicyWorkloads returns the pod templates of Deployments, StatefulSets and
+// DaemonSets, and the pods no controller manages
+func (v *NetworkingValidator) listPolicyWorkloads(ctx context.Context, reader client.Reader) ([]policyWorkload, error) {
+	var workloads []policyWorkload
+	add := func(kind string, meta metav1.ObjectMeta, template corev1.PodTemplateSpec) {
+		workloads = append(workloads, policyWorkload{kind: kind, pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: meta.Name, Namespace: meta.Namespace, Labels: template.Labels},
+			Spec:       template.Spec,
+		}})
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := reader.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		add("Deployment", deployment.ObjectMeta, deployment.Spec.Template)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := reader.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		add("StatefulSet", statefulSet.ObjectMeta, statefulSet.Spec.Template)
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := reader.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		add("DaemonSet", daemonSet.ObjectMeta, daemonSet.Spec.Template)
+	}
+
+	err := forEachPod(ctx, reader, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are simulated via their controllers
+		if utils.HasOwnerReferences(*pod) {
+			return nil
+		}
+		workloads = append(workloads, policyWorkload{kind: "Pod", pod: *pod})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return workloads, nil
+}
+
+// serviceDependencies returns the Services with selectors that the containers of a pod
+// spec name in environment variables, such as DATABASE_URL=postgres://db.shop.svc:5432
+// or REDIS_HOST=redis. Qualified names always count; bare names count when given with a
+// port or scheme, or in a variable whose name says it holds an address.
+func serviceDependencies(spec corev1.PodSpec, namespace string, services map[string]corev1.Service) []serviceDependency {
+	var dependencies []serviceDependency
+	seen := make(map[string]bool)
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, env := range container.Env {
+			for _, token := range strings.FieldsFunc(env.Value, func(r rune) bool { return r == ',' || r == ';' || r == ' ' }) {
+				host, port, explicit := parseEnvAddress(token)
+				if host == "" {
+					continue
+				}
+
+				key := ""
+				if name, ns, ok := parseClusterServiceName(host); ok {
+					key = ns + "/" + name
+				} else if parts := strings.Split(host, "."); len(parts) == 2 {
+					key = parts[1] + "/" + parts[0]
+				} else if len(parts) == 1 && (explicit || hasAddressEnvSuffix(env.Name)) {
+					key = namespace + "/" + host
+				}
+				service, ok := services[key]
+				if !ok || len(service.Spec.Selector) == 0 || seen[key] {
+					continue
+				}
+				seen[key] = true
+				dependencies = append(dependencies, serviceDependency{service: service, port: port, envVar: env.Name})
+			}
+		}
+	}
+	return dependencies
+}
+
+// parseEnvAddress extracts the host and port from a URL or host[:port] value. explicit
+// is set when the value has a scheme or port, marking it clearly as an address.
+func parseEnvAddress(value string) (host string, port int32, explicit bool) {
+	if strings.Contains(value, "://") {
+		u, err := url.Parse(value)
+		if err != nil {
+			return "", 0, false
+		}
+		host, explicit = u.Hostname(), true
+		if p, err := strconv.ParseInt(u.Port(), 10, 32); err == nil {
+			port = int32(p)
+		}
+	} else {
+		value, _, _ = strings.Cut(value, "/")
+		if at := strings.LastIndex(value, "@"); at >= 0 {
+			value = value[at+1:]
+		}
+		host = value
+		if h, p, err := net.SplitHostPort(value); err == nil {
+			number, err := strconv.ParseInt(p, 10, 32)
+			if err != nil {
+				return "", 0, false
+			}
+			host, port, explicit = h, int32(number), true
+		}
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, label := range strings.Split(host, ".") {
+		if !isDNSLabel(label) {
+			return "", 0, false
+		}
+	}
+	return host, port, explicit
+}
+
+// hasAddressEnvSuffix reports whether an environment variable name says it holds an address
+func hasAddressEnvSuffix(name string) bool {
+	name = strings.ToUpper(name)
+	for _, suffix := range addressEnvSuffixes {
+		if name == suffix || strings.HasSuffix(name, "_"+suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isDNSLabel reports whether s is a lowercase RFC 1123 label
+func isDNSLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// formatPolicyPorts formats the ports of a rule as 443/TCP, 8000-8080/TCP
+func formatPolicyPorts(ports []networkingv1.NetworkPolicyPort) string {
+	formatted := make([]string, 0, len(ports))
+	for _, p := range ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		port := "all"
+		if p.Port != nil {
+			port = p.Port.String()
+			if p.EndPort != nil {
+				port += "-" + strconv.Itoa(int(*p.EndPort))
+			}
+		}
+		formatted = append(formatted, fmt.Sprintf("%s/%s", port, protocol))
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,341 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// namespaceNameLabel is set by the API server on every namespace to its name
+const namespaceNameLabel = "kubernetes.io/metadata.name"
+
+// Directions in which NetworkPolicies can block traffic
+const (
+	// TrafficBlockedByEgress means the policies selecting the source allow no egress to the destination
+	TrafficBlockedByEgress = "egress"
+	// TrafficBlockedByIngress means the policies selecting the destination allow no ingress from the source
+	TrafficBlockedByIngress = "ingress"
+)
+
+// NetworkPolicySimulator evaluates the combined effect of NetworkPolicies on traffic
+// between pods. As in Kubernetes, traffic leaving a pod is allowed when no policy
+// selects the pod for egress or when any policy that does allows it, and likewise for
+// traffic reaching a pod. Pods on the host network are not subject to policies.
+type NetworkPolicySimulator struct {
+	policies        map[string][]networkingv1.NetworkPolicy
+	namespaceLabels map[string]labels.Set
+}
+
+// TrafficVerdict is the outcome of simulating traffic from one pod to another
+type TrafficVerdict struct {
+	Allowed bool
+	// BlockedBy is TrafficBlockedByEgress or TrafficBlockedByIngress when traffic is blocked
+	BlockedBy string
+	// Namespace holds the Policies on the blocking side
+	Namespace string
+	// Policies name the policies selecting the pod on the blocking side, none of which allow the traffic
+	Policies []string
+}
+
+// SimulationQuery selects the pods and port of simulated traffic. An empty namespace
+// selects pods in all namespaces, and a nil selector selects all pods.
+type SimulationQuery struct {
+	SourceNamespace      string
+	SourceSelector       labels.Selector
+	DestinationNamespace string
+	DestinationSelector  labels.Selector
+	// Port is the destination port, as a number or a container port name
+	Port intstr.IntOrString
+	// Protocol defaults to TCP
+	Protocol corev1.Protocol
+}
+
+// SimulatedPath is the verdict for traffic from one selected pod to another
+type SimulatedPath struct {
+	Source      *corev1.Pod
+	Destination *corev1.Pod
+	TrafficVerdict
+}
+
+// NewNetworkPolicySimulator creates a simulator for the given policies. Namespaces
+// provide the labels namespaceSelectors match; namespaces not given are matched by
+// their kubernetes.io/metadata.name label only.
+func NewNetworkPolicySimulator(policies []networkingv1.NetworkPolicy, namespaces []corev1.Namespace) *NetworkPolicySimulator {
+	s := &NetworkPolicySimulator{
+		policies:        make(map[string][]networkingv1.NetworkPolicy),
+		namespaceLabels: make(map[string]labels.Set),
+	}
+	for _, policy := range policies {
+		s.policies[policy.Namespace] = append(s.policies[policy.Namespace], policy)
+	}
+	for _, namespace := range namespaces {
+		set := labels.Set{}
+		for key, value := range namespace.Labels {
+			set[key] = value
+		}
+		set[namespaceNameLabel] = namespace.Name
+		s.namespaceLabels[namespace.Name] = set
+	}
+	return s
+}
+
+// Simulate evaluates traffic between every pair of distinct pods selected by query
+func (s *NetworkPolicySimulator) Simulate(query SimulationQuery, pods []corev1.Pod) []SimulatedPath {
+	selected := func(pod *corev1.Pod, namespace string, selector labels.Selector) bool {
+		if namespace != "" && pod.Namespace != namespace {
+			return false
+		}
+		return selector == nil || selector.Matches(labels.Set(pod.Labels))
+	}
+
+	var paths []SimulatedPath
+	for i := range pods {
+		source := &pods[i]
+		if !selected(source, query.SourceNamespace, query.SourceSelector) {
+			continue
+		}
+		for j := range pods {
+			destination := &pods[j]
+			if i == j || !selected(destination, query.DestinationNamespace, query.DestinationSelector) {
+				continue
+			}
+			paths = append(paths, SimulatedPath{
+				Source:         source,
+				Destination:    destination,
+				TrafficVerdict: s.Evaluate(source, destination, query.Port, query.Protocol),
+			})
+		}
+	}
+	return paths
+}
+
+// Evaluate decides whether traffic from one pod may reach port of another. Named ports
+// are resolved against the containers of the destination pod.
+func (s *NetworkPolicySimulator) Evaluate(from, to *corev1.Pod, port intstr.IntOrString, protocol corev1.Protocol) TrafficVerdict {
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	number := port.IntVal
+	if port.Type == intstr.String {
+		number, _ = namedContainerPort(to, port.StrVal, protocol)
+	}
+	destination := destinationPort{pod: to, number: number, name: port.StrVal, protocol: protocol}
+
+	if !from.Spec.HostNetwork {
+		selecting := s.selectingPolicies(from, networkingv1.PolicyTypeEgress)
+		if len(selecting) > 0 && !s.egressAllowed(selecting, to, destination) {
+			return TrafficVerdict{BlockedBy: TrafficBlockedByEgress, Namespace: from.Namespace, Policies: policyNames(selecting)}
+		}
+	}
+	if !to.Spec.HostNetwork {
+		selecting := s.selectingPolicies(to, networkingv1.PolicyTypeIngress)
+		if len(selecting) > 0 && !s.ingressAllowed(selecting, from, destination) {
+			return TrafficVerdict{BlockedBy: TrafficBlockedByIngress, Namespace: to.Namespace, Policies: policyNames(selecting)}
+		}
+	}
+	return TrafficVerdict{Allowed: true}
+}
+
+// destinationPort is the port traffic is sent to, resolved against the destination pod
+type destinationPort struct {
+	pod *corev1.Pod
+	// number is zero when a named port does not resolve
+	number   int32
+	name     string
+	protocol corev1.Protocol
+}
+
+// selectingPolicies returns the policies that select pod and restrict policyType
+func (s *NetworkPolicySimulator) selectingPolicies(pod *corev1.Pod, policyType networkingv1.PolicyType) []networkingv1.NetworkPolicy {
+	var selecting []networkingv1.NetworkPolicy
+	for _, policy := range s.policies[pod.Namespace] {
+		if !policyHasType(policy, policyType) {
+			continue
+		}
+		if selectorMatches(&policy.Spec.PodSelector, pod.Labels) {
+			selecting = append(selecting, policy)
+		}
+	}
+	return selecting
+}
+
+// egressAllowed reports whether any egress rule of policies allows traffic to port of pod to
+func (s *NetworkPolicySimulator) egressAllowed(policies []networkingv1.NetworkPolicy, to *corev1.Pod, port destinationPort) bool {
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Egress {
+			if portsMatch(rule.Ports, port) && s.peersMatch(policy.Namespace, rule.To, to) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ingressAllowed reports whether any ingress rule of policies allows traffic from pod from to port
+func (s *NetworkPolicySimulator) ingressAllowed(policies []networkingv1.NetworkPolicy, from *corev1.Pod, port destinationPort) bool {
+	for _, policy := range policies {
+		for _, rule := range policy.Spec.Ingress {
+			if portsMatch(rule.Ports, port) && s.peersMatch(policy.Namespace, rule.From, from) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// peersMatch reports whether any of the peers of a rule in a policy of namespace
+// matches pod. A rule without peers matches all pods.
+func (s *NetworkPolicySimulator) peersMatch(namespace string, peers []networkingv1.NetworkPolicyPeer, pod *corev1.Pod) bool {
+	if len(peers) == 0 {
+		return true
+	}
+	for _, peer := range peers {
+		switch {
+		case peer.IPBlock != nil:
+			if ipBlockMatchesPod(peer.IPBlock, pod) {
+				return true
+			}
+		case peer.NamespaceSelector == nil:
+			// A podSelector alone selects pods in the policy's own namespace
+			if pod.Namespace == namespace && selectorMatches(peer.PodSelector, pod.Labels) {
+				return true
+			}
+		default:
+			if !selectorMatches(peer.NamespaceSelector, s.labelsOfNamespace(pod.Namespace)) {
+				continue
+			}
+			if peer.PodSelector == nil || selectorMatches(peer.PodSelector, pod.Labels) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// labelsOfNamespace returns the labels of a namespace, including its name label
+func (s *NetworkPolicySimulator) labelsOfNamespace(namespace string) labels.Set {
+	if set, ok := s.namespaceLabels[namespace]; ok {
+		return set
+	}
+	return labels.Set{namespaceNameLabel: namespace}
+}
+
+// policyHasType reports whether policy restricts traffic in the given direction.
+// Policies without policyTypes restrict ingress, and egress if they have egress rules.
+func policyHasType(policy networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress || len(policy.Spec.Egress) > 0
+	}
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// portsMatch reports whether the ports of a rule include port. A rule without ports
+// matches all ports.
+func portsMatch(ports []networkingv1.NetworkPolicyPort, port destinationPort) bool {
+	if len(ports) == 0 {
+		return true
+	}
+	for _, p := range ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		if protocol != port.protocol {
+			continue
+		}
+		if p.Port == nil {
+			return true
+		}
+		if p.Port.Type == intstr.String {
+			if p.Port.StrVal == port.name {
+				return true
+			}
+			if number, ok := namedContainerPort(port.pod, p.Port.StrVal, protocol); ok && number == port.number {
+				return true
+			}
+			continue
+		}
+		end := p.Port.IntVal
+		if p.EndPort != nil {
+			end = *p.EndPort
+		}
+		if port.number >= p.Port.IntVal && port.number <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// ipBlockMatchesPod reports whether an ipBlock peer matches a pod. How ipBlocks apply
+// to pod addresses depends on the network plugin, so pods without a known address are
+// matched only by blocks covering every address.
+func ipBlockMatchesPod(block *networkingv1.IPBlock, pod *corev1.Pod) bool {
+	_, cidr, err := net.ParseCIDR(block.CIDR)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(pod.Status.PodIP)
+	if ip == nil {
+		ones, _ := cidr.Mask.Size()
+		return ones == 0
+	}
+	if !cidr.Contains(ip) {
+		return false
+	}
+	for _, except := range block.Except {
+		if _, excluded, err := net.ParseCIDR(except); err == nil && excluded.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectorMatches reports whether a label selector matches set. Invalid selectors match nothing.
+func selectorMatches(selector *metav1.LabelSelector, set map[string]string) bool {
+	parsed, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return parsed.Matches(labels.Set(set))
+}
+
+// namedContainerPort resolves a container port name of pod to its number
+func namedContainerPort(pod *corev1.Pod, name string, protocol corev1.Protocol) (int32, bool) {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			portProtocol := port.Protocol
+			if portProtocol == "" {
+				portProtocol = corev1.ProtocolTCP
+			}
+			if port.Name == name && portProtocol == protocol {
+				return port.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// policyNames returns the sorted names of policies
+func policyNames(policies []networkingv1.NetworkPolicy) []string {
+	names := make([]string, 0, len(policies))
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// loadBalancerPendingGracePeriod is how long a LoadBalancer Service may wait for an
+	// address before it is reported as stuck
+	loadBalancerPendingGracePeriod = 10 * time.Minute
+	// excludeFromLoadBalancersLabel marks nodes that receive no load balancer traffic
+	excludeFromLoadBalancersLabel = "node.kubernetes.io/exclude-from-external-load-balancers"
+)
+
+// validateServiceTopology checks Services whose traffic policy, session affinity or
+// type cannot work as configured in this cluster
+func (v *NetworkingValidator) validateServiceTopology(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+
+	var services corev1.ServiceList
+	if err := v.client.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	var nodes corev1.NodeList
+	if err := v.client.List(ctx, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	namespacePods := newNamespacePods(v.client)
+
+	// A provider exists when any LoadBalancer Service has been given an address
+	providerDetected := false
+	for _, service := range services.Items {
+		if service.Spec.Type == corev1.ServiceTypeLoadBalancer && len(service.Status.LoadBalancer.Ingress) > 0 {
+			providerDetected = true
+			break
+		}
+	}
+
+	trafficNodes := loadBalancedNodes(nodes.Items)
+	for _, service := range services.Items {
+		if v.sharedConfig.IsNetworkingExcludedNamespace(service.Namespace) {
+			continue
+		}
+		// Only Local traffic policies depend on where the Pods of a Service run
+		if service.Spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyLocal {
+			pods, err := namespacePods.get(ctx, service.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list pods: %w", err)
+			}
+			errors = append(errors, v.validateLocalTrafficPolicy(service, pods, trafficNodes)...)
+		}
+		errors = append(errors, v.validateHeadlessSessionAffinity(service)...)
+		errors = append(errors, v.validateLoadBalancerPending(service, providerDetected)...)
+	}
+
+	return errors, nil
+}
+
+// validateLocalTrafficPolicy reports Services with externalTrafficPolicy Local whose
+// ready pods run on fewer than half of the nodes receiving external traffic. Traffic
+// sent to the other nodes is dropped unless the load balancer health-checks them out.
+func (v *NetworkingValidator) validateLocalTrafficPolicy(service corev1.Service, namespacePods []corev1.Pod, trafficNodes map[string]bool) []ValidationError {
+	if service.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal || len(service.Spec.Selector) == 0 {
+		return nil
+	}
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer && service.Spec.Type != corev1.ServiceTypeNodePort {
+		return nil
+	}
+	// Spreading across a single node is meaningless
+	if len(trafficNodes) < 2 {
+		return nil
+	}
+
+	nodesWithPods := make(map[string]bool)
+	for _, pod := range FindMatchingPods(namespacePods, service.Spec.Selector) {
+		if IsPodReady(pod) && trafficNodes[pod.Spec.NodeName] {
+			nodesWithPods[pod.Spec.NodeName] = true
+		}
+	}
+	// Services without ready pods are reported by the Service connectivity checks
+	if len(nodesWithPods) == 0 || len(nodesWithPods)*2 >= len(trafficNodes) {
+		return nil
+	}
+
+	errorCode := GetNetworkingErrorCode("service_local_traffic_policy_sparse")
+	return []ValidationError{NewValidationErrorWithCode("Service", service.Name, service.Namespace, "service_local_traffic_policy_sparse", errorCode,
+		fmt.Sprintf("Service has externalTrafficPolicy Local but ready pods on only %d of %d nodes; external traffic sent to the other nodes is dropped", len(nodesWithPods), len(trafficNodes))).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Run the backing pods on every node, for example as a DaemonSet, make sure the load balancer health-checks healthCheckNodePort, or use externalTrafficPolicy Cluster if the client source IP is not needed").
+		WithDetail("service_type", string(service.Spec.Type)).
+		WithDetail("nodes_with_pods", strconv.Itoa(len(nodesWithPods))).
+		WithDetail("traffic_nodes", strconv.Itoa(len(trafficNodes))).
+		WithDetail("health_check_node_port", strconv.Itoa(int(service.Spec.HealthCheckNodePort)))}
+}
+
+// validateHeadlessSessionAffinity reports session affinity on headless Services, which
+// has no effect because clients connect to pod addresses directly
+func (v *NetworkingValidator) validateHeadlessSessionAffinity(service corev1.Service) []ValidationError {
+	if service.Spec.ClusterIP != corev1.ClusterIPNone || service.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		return nil
+	}
+
+	errorCode := GetNetworkingErrorCode("service_session_affinity_headless")
+	return []ValidationError{NewValidationErrorWithCode("Service", service.Name, service.Namespace, "service_session_affinity_headless", errorCode,
+		"Service sets sessionAffinity ClientIP but is headless, so clients connect to pods directly and the affinity has no effect").
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Remove sessionAffinity from the headless Service, or give the Service a cluster IP if clients need to stick to one pod").
+		WithDetail("session_affinity", string(service.Spec.SessionAffinity))}
+}
+
+// validateLoadBalancerPending reports LoadBalancer Services that have waited longer
+// than the grace period for an address
+func (v *NetworkingValidator) validateLoadBalancerPending(service corev1.Service, providerDetected bool) []ValidationError {
+	if service.Spec.Type != corev1.ServiceTypeLoadBalancer || len(service.Status.LoadBalancer.Ingress) > 0 {
+		return nil
+	}
+	// Manifests that were never applied carry no creation time
+	if service.CreationTimestamp.IsZero() {
+		return nil
+	}
+	pending := time.Since(service.CreationTimestamp.Time)
+	if pending < loadBalancerPendingGracePeriod {
+		return nil
+	}
+
+	message := fmt.Sprintf("LoadBalancer Service has had no external address for %s, and no LoadBalancer Service in the cluster has one, so the cluster appears to have no load balancer provider", pending.Round(time.Minute))
+	hint := "Install a load balancer implementation such as MetalLB or the cloud provider's controller, or expose the Service as NodePort or through an Ingress"
+	severity := SeverityError
+	if providerDetected {
+		message = fmt.Sprintf("LoadBalancer Service has had no external address for %s", pending.Round(time.Minute))
+		hint = "Check the Service's events for errors from the load balancer controller, such as exhausted quotas or invalid annotations"
+		severity = SeverityWarning
+	}
+	if service.Spec.LoadBalancerClass != nil {
+		hint = fmt.Sprintf("Make sure a controller for loadBalancerClass '%s' is running, or remove the class to use the default implementation", *service.Spec.LoadBalancerClass)
+	}
+
+	errorCode := GetNetworkingErrorCode("service_load_balancer_pending")
+	finding := NewValidationErrorWithCode("Service", service.Name, service.Namespace, "service_load_balancer_pending", errorCode, message).
+		WithSeverity(severity).
+		WithRemediationHint(hint).
+		WithDetail("pending_for", pending.Round(time.Minute).String()).
+		WithDetail("provider_detected", strconv.FormatBool(providerDetected))
+	if service.Spec.LoadBalancerClass != nil {
+		finding = finding.WithDetail("load_balancer_class", *service.Spec.LoadBalancerClass)
+	}
+	return []ValidationError{finding}
+}
+
+// loadBalancedNodes returns the names of ready, schedulable nodes that are not
+// excluded from external load balancers
+func loadBalancedNodes(nodes []corev1.Node) map[string]bool {
+	names := make(map[string]bool)
+	for _, node := range nodes {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		if _, ok := node.Labels[excludeFromLoadBalancersLabel]; ok {
+			continue
+		}
+		ready := false
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready = true
+			}
+		}
+		if ready {
+			names[node.Name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNetworkingValidator_ValidateServiceTopology(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	readyNode := func(name string) client.Object {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status:     corev1.NodeStatus{Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}}},
+		}
+	}
+	readyPod := func(name, app, node string) client.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}},
+		}
+	}
+	service := func(name string, created time.Time, spec corev1.ServiceSpec) client.Object {
+		return &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", CreationTimestamp: metav1.NewTime(created)}, Spec: spec}
+	}
+	longAgo := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		objects []client.Object
+		want    map[string]Severity
+	}{
+		{
+			name: "local traffic policy with pods on few nodes",
+			objects: []client.Object{
+				readyNode("node-1"), readyNode("node-2"), readyNode("node-3"), readyNode("node-4"),
+				readyPod("web-1", "web", "node-1"), readyPod("web-2", "web", "node-1"),
+				readyPod("api-1", "api", "node-1"), readyPod("api-2", "api", "node-2"),
+				service("web", longAgo, corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal, Selector: map[string]string{"app": "web"}}),
+				// Pods on half of the nodes are enough
+				service("api", longAgo, corev1.ServiceSpec{Type: corev1.ServiceTypeNodePort, ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal, Selector: map[string]string{"app": "api"}}),
+			},
+			want: map[string]Severity{"web/service_local_traffic_policy_sparse": SeverityWarning},
+		},
+		{
+			name: "session affinity on headless service",
+			objects: []client.Object{
+				service("db", longAgo, corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone, SessionAffinity: corev1.ServiceAffinityClientIP}),
+				service("cache", longAgo, corev1.ServiceSpec{ClusterIP: "10.96.0.20", SessionAffinity: corev1.ServiceAffinityClientIP}),
+			},
+			want: map[string]Severity{"db/service_session_affinity_headless": SeverityWarning},
+		},
+		{
+			name: "load balancers pending without a provider",
+			objects: []client.Object{
+				service("public", longAgo, corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}),
+				service("new", time.Now(), corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}),
+			},
+			want: map[string]Severity{"public/service_load_balancer_pending": SeverityError},
+		},
+		{
+			name: "load balancer pending with a provider",
+			objects: []client.Object{
+				service("public", longAgo, corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer}),
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "assigned", Namespace: "shop", CreationTimestamp: metav1.NewTime(longAgo)},
+					Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+					Status:     corev1.ServiceStatus{LoadBalancer: corev1.LoadBalancerStatus{Ingress: []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}}},
+				},
+			},
+			want: map[string]Severity{"public/service_load_balancer_pending": SeverityWarning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).WithStatusSubresource(&corev1.Service{}).Build()
+			validator := NewNetworkingValidator(fakeClient, logr.Discard(), NetworkingConfig{EnableServiceTopologyValidation: true})
+			validator.SetLogReceiver(&MockLogReceiver{})
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			got := make(map[string]Severity)
+			for _, finding := range validator.GetLastValidationErrors() {
+				got[fmt.Sprintf("%s/%s", finding.ResourceName, finding.ValidationType)] = finding.Severity
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("findings = %v, want %v", got, tt.want)
+			}
+			for key, severity := range tt.want {
+				if got[key] != severity {
+					t.Errorf("finding %s severity = %q, want %q", key, got[key], severity)
+				}
+			}
+		})
+	}
+}
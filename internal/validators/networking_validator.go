@@ -14,10 +14,11 @@ package validators
 import (
 	"context"
 	"fmt"
+	"net"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
-	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -29,6 +30,30 @@ type NetworkingConfig struct {
 	EnableServiceValidation       bool
 	EnableNetworkPolicyValidation bool
 	EnableIngressValidation       bool
+	// Enable detection of duplicate Services, Ingress rules and NetworkPolicies
+	EnableDuplicateValidation bool
+	// Enable detection of Ingresses routing the same host and path to different backends
+	EnableIngressCollisionValidation bool
+	// Only report Ingress host collisions between different namespaces
+	IngressCollisionCrossNamespaceOnly bool
+	// Enable validation of Ingress TLS hosts, Secret types and certificate names
+	EnableIngressTLSValidation bool
+	// Report Ingress hosts that are served without TLS
+	RequireIngressTLS bool
+	// Enable simulation of NetworkPolicies between workloads and the Services they depend on
+	EnableNetworkPolicySimulation bool
+	// Enable detection of blocked DNS, unmatched namespaceSelectors and ipBlocks overlapping cluster addresses
+	EnableEgressValidation bool
+	// CIDRs of the cluster's pods and Services, in addition to the pod CIDRs of nodes and the ClusterIPs of Services
+	ClusterCIDRs []string
+	// Enable validation of Service traffic policies, session affinity and pending load balancers
+	EnableServiceTopologyValidation bool
+	// Enable validation of pod DNS policies and hostAliases
+	EnableDNSValidation bool
+	// Resolve the targets of ExternalName Services with DNS lookups
+	EnableExternalNameResolution bool
+	// Timeout of each ExternalName lookup
+	DNSLookupTimeout time.Duration
 	// Namespaces that require NetworkPolicy coverage
 	PolicyRequiredNamespaces []string
 	// Enable warnings for pods not exposed by services
@@ -43,6 +68,7 @@ type NetworkingValidator struct {
 	sharedConfig         SharedConfig
 	lastValidationErrors []ValidationError
 	logReceiver          LogReceiver
+	resolver             Resolver
 }
 
 // NewNetworkingValidator creates a new NetworkingValidator with the given client, logger and config
@@ -51,7 +77,8 @@ func NewNetworkingValidator(client client.Client, log logr.Logger, config Networ
 		client:       client,
 		log:          log.WithName("networking-validator"),
 		config:       config,
-		sharedConfig: DefaultSharedConfig(),
+		sharedConfig: ActiveSharedConfig(),
+		resolver:     net.DefaultResolver,
 	}
 }
 
@@ -90,6 +117,15 @@ func (v *NetworkingValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, serviceErrors...)
 	}
 
+	// Validate Service traffic policies and load balancers against the cluster topology
+	if v.config.EnableServiceTopologyValidation {
+		topologyErrors, err := v.validateServiceTopology(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate service topology: %w", err)
+		}
+		allErrors = append(allErrors, topologyErrors...)
+	}
+
 	// Validate NetworkPolicy coverage
 	if v.config.EnableNetworkPolicyValidation {
 		networkPolicyErrors, err := v.validateNetworkPolicyCoverage(ctx)
@@ -99,6 +135,24 @@ func (v *NetworkingValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, networkPolicyErrors...)
 	}
 
+	// Simulate NetworkPolicies to find blocked dependencies and open egress
+	if v.config.EnableNetworkPolicySimulation {
+		pathErrors, err := v.validateNetworkPolicyPaths(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to simulate networkpolicies: %w", err)
+		}
+		allErrors = append(allErrors, pathErrors...)
+	}
+
+	// Validate egress to cluster DNS and the peers of NetworkPolicy rules
+	if v.config.EnableEgressValidation {
+		egressErrors, err := v.validateEgressPolicies(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate egress policies: %w", err)
+		}
+		allErrors = append(allErrors, egressErrors...)
+	}
+
 	// Validate Ingress connectivity
 	if v.config.EnableIngressValidation {
 		ingressErrors, err := v.validateIngressConnectivity(ctx)
@@ -108,6 +162,48 @@ func (v *NetworkingValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, ingressErrors...)
 	}
 
+	// Validate Ingress TLS configuration
+	if v.config.EnableIngressTLSValidation {
+		tlsErrors, err := v.validateIngressTLS(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate ingress tls: %w", err)
+		}
+		allErrors = append(allErrors, tlsErrors...)
+	}
+
+	// Detect duplicate and shadowed resources
+	if v.config.EnableDuplicateValidation {
+		duplicateErrors, err := v.validateDuplicateResources(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate duplicate resources: %w", err)
+		}
+		allErrors = append(allErrors, duplicateErrors...)
+	}
+
+	// Detect Ingress host collisions, across namespaces too
+	if v.config.EnableIngressCollisionValidation {
+		collisionErrors, err := v.validateIngressHostCollisions(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate ingress host collisions: %w", err)
+		}
+		allErrors = append(allErrors, collisionErrors...)
+	}
+
+	// Validate DNS configuration and ExternalName targets
+	if v.config.EnableDNSValidation || v.config.EnableExternalNameResolution {
+		dnsErrors, err := v.validateDNSConfiguration(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate dns configuration: %w", err)
+		}
+		allErrors = append(allErrors, dnsErrors...)
+	}
+
+	// Report findings on controller-owned pods against their workloads
+	allErrors, err := attributePodFindings(ctx, v.client, allErrors)
+	if err != nil {
+		return fmt.Errorf("failed to attribute pod findings to workloads: %w", err)
+	}
+
 	// Log all validation errors and update metrics
 	LogAndRecordErrors(v.logReceiver, "networking", allErrors)
 
@@ -127,34 +223,8 @@ func (v *NetworkingValidator) validateServiceConnectivity(ctx context.Context) (
 		return nil, fmt.Errorf("failed to list services: %w", err)
 	}
 
-	// Get all Pods
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	// Get all EndpointSlices
-	var endpointSlices discoveryv1.EndpointSliceList
-	if err := v.client.List(ctx, &endpointSlices); err != nil {
-		return nil, fmt.Errorf("failed to list endpointslices: %w", err)
-	}
-
-	// Create maps for efficient lookup
-	podsByNamespace := make(map[string][]corev1.Pod)
-	endpointSlicesByService := make(map[string][]discoveryv1.EndpointSlice)
-
-	for _, pod := range pods.Items {
-		podsByNamespace[pod.Namespace] = append(podsByNamespace[pod.Namespace], pod)
-	}
-
-	// Group EndpointSlices by their parent service
-	// EndpointSlices have a label kubernetes.io/service-name that references the service
-	for _, eps := range endpointSlices.Items {
-		if serviceName, ok := eps.Labels[discoveryv1.LabelServiceName]; ok {
-			key := fmt.Sprintf("%s/%s", eps.Namespace, serviceName)
-			endpointSlicesByService[key] = append(endpointSlicesByService[key], eps)
-		}
-	}
+	// Look Pods up by namespace and EndpointSlices by Service as they are needed
+	namespacePods := newNamespacePods(v.client)
 
 	// Validate each service
 	for _, service := range services.Items {
@@ -163,24 +233,35 @@ func (v *NetworkingValidator) validateServiceConnectivity(ctx context.Context) (
 			continue
 		}
 
-		serviceErrors := v.validateService(service, podsByNamespace[service.Namespace], endpointSlicesByService)
+		serviceErrors, err := v.validateService(ctx, service, namespacePods)
+		if err != nil {
+			return nil, err
+		}
 		errors = append(errors, serviceErrors...)
 	}
 
 	// Optionally warn about unexposed pods
 	if v.config.WarnUnexposedPods {
-		unexposedErrors := v.findUnexposedPods(pods.Items, services.Items)
+		pods, err := listPods(ctx, v.client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
+		unexposedErrors := v.findUnexposedPods(pods, services.Items)
 		errors = append(errors, unexposedErrors...)
 	}
 
 	return errors, nil
 }
 
-func (v *NetworkingValidator) validateService(service corev1.Service, namespacePods []corev1.Pod, endpointSlicesMap map[string][]discoveryv1.EndpointSlice) []ValidationError {
+func (v *NetworkingValidator) validateService(ctx context.Context, service corev1.Service, lookup *namespacePods) ([]ValidationError, error) {
 	var errors []ValidationError
 
 	// Check if service selector matches any pods
 	if len(service.Spec.Selector) > 0 {
+		namespacePods, err := lookup.get(ctx, service.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
 		matchingPods := FindMatchingPods(namespacePods, service.Spec.Selector)
 
 		if len(matchingPods) == 0 {
@@ -194,8 +275,11 @@ func (v *NetworkingValidator) validateService(service corev1.Service, namespaceP
 		}
 
 		// Check if service has endpointslices
-		endpointSlicesKey := fmt.Sprintf("%s/%s", service.Namespace, service.Name)
-		if endpointSlices, exists := endpointSlicesMap[endpointSlicesKey]; exists {
+		endpointSlices, err := listServiceEndpointSlices(ctx, v.client, service.Namespace, service.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list endpointslices: %w", err)
+		}
+		if len(endpointSlices) > 0 {
 			if v.hasNoReadyEndpointsInSlices(endpointSlices) {
 				errorCode := GetNetworkingErrorCode("service_no_endpoints")
 				totalEndpoints := 0
@@ -225,7 +309,7 @@ func (v *NetworkingValidator) validateService(service corev1.Service, namespaceP
 		errors = append(errors, portErrors...)
 	}
 
-	return errors
+	return errors, nil
 }
 
 func (v *NetworkingValidator) validateServicePorts(service corev1.Service, matchingPods []corev1.Pod) []ValidationError {
@@ -265,7 +349,6 @@ func (v *NetworkingValidator) validateServicePorts(service corev1.Service, match
 	return errors
 }
 
-
 func (v *NetworkingValidator) findUnexposedPods(pods []corev1.Pod, services []corev1.Service) []ValidationError {
 	var errors []ValidationError
 
@@ -315,12 +398,6 @@ func (v *NetworkingValidator) validateNetworkPolicyCoverage(ctx context.Context)
 		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
 	}
 
-	// Get all Pods
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
 	// Get all Namespaces
 	var namespaces corev1.NamespaceList
 	if err := v.client.List(ctx, &namespaces); err != nil {
@@ -328,69 +405,35 @@ func (v *NetworkingValidator) validateNetworkPolicyCoverage(ctx context.Context)
 	}
 
 	// Validate NetworkPolicy coverage
-	coverageErrors := v.validatePolicyRequired(namespaces.Items, networkPolicies.Items)
+	analyzer := NewNetworkPolicyAnalyzer(NetworkPolicyCoverage{
+		RequiredNamespaces: v.config.PolicyRequiredNamespaces,
+		DefaultDeny:        true,
+	}, v.sharedConfig)
+	coverageErrors, err := analyzer.Analyze(namespaces.Items, networkPolicies.Items)
+	if err != nil {
+		return nil, err
+	}
 	errors = append(errors, coverageErrors...)
 
-	// Validate NetworkPolicy selectors
-	selectorErrors := v.validateNetworkPolicySelectors(networkPolicies.Items, pods.Items)
+	// Validate NetworkPolicy selectors against the Pods of their namespaces
+	selectorErrors, err := v.validateNetworkPolicySelectors(ctx, networkPolicies.Items, newNamespacePods(v.client))
+	if err != nil {
+		return nil, err
+	}
 	errors = append(errors, selectorErrors...)
 
 	return errors, nil
 }
 
-func (v *NetworkingValidator) validatePolicyRequired(namespaces []corev1.Namespace, policies []networkingv1.NetworkPolicy) []ValidationError {
-	var errors []ValidationError
-
-	// Create map of namespaces with policies
-	namespacesWithPolicies := make(map[string]bool)
-	for _, policy := range policies {
-		namespacesWithPolicies[policy.Namespace] = true
-	}
-
-	// Check policy-required namespaces
-	for _, requiredNS := range v.config.PolicyRequiredNamespaces {
-		if !namespacesWithPolicies[requiredNS] {
-			// Note: This validation type is not in the CSV - using UNKNOWN error code
-			errorCode := GetNetworkingErrorCode("missing_network_policy_required")
-			errors = append(errors, NewValidationErrorWithCode("Namespace", requiredNS, requiredNS, "missing_network_policy_required", errorCode, fmt.Sprintf("Policy-required namespace '%s' has no NetworkPolicies", requiredNS)).
-				WithSeverity(SeverityError).
-				WithRemediationHint("Create NetworkPolicies with default-deny ingress/egress rules and explicit allow rules for required traffic").
-				WithRelatedResources("NetworkPolicy/default-deny-all").
-				WithDetail("namespace_type", "policy_required").
-				WithDetail("security_risk", "unrestricted_network_access"))
-		}
-	}
-
-	// Check for missing default deny policies in namespaces with policies
-	for _, ns := range namespaces {
-		if v.isSystemNamespace(ns.Name) {
-			continue
-		}
-
-		if namespacesWithPolicies[ns.Name] {
-			hasDefaultDeny := v.hasDefaultDenyPolicy(policies, ns.Name)
-			if !hasDefaultDeny {
-				existingPolicies := v.getPoliciesInNamespace(policies, ns.Name)
-				errorCode := GetNetworkingErrorCode("missing_network_policy_default_deny")
-				errors = append(errors, NewValidationErrorWithCode("Namespace", ns.Name, ns.Name, "missing_network_policy_default_deny", errorCode, "Namespace has NetworkPolicies but no default deny policy").
-					WithSeverity(SeverityWarning).
-					WithRemediationHint("Add a default deny NetworkPolicy to deny all ingress/egress traffic by default, then create specific allow policies").
-					WithRelatedResources("NetworkPolicy/default-deny-all").
-					WithDetail("existing_policies_count", fmt.Sprintf("%d", len(existingPolicies))).
-					WithDetail("security_best_practice", "default_deny_principle"))
-			}
-		}
-	}
-
-	return errors
-}
-
-func (v *NetworkingValidator) validateNetworkPolicySelectors(policies []networkingv1.NetworkPolicy, pods []corev1.Pod) []ValidationError {
+func (v *NetworkingValidator) validateNetworkPolicySelectors(ctx context.Context, policies []networkingv1.NetworkPolicy, lookup *namespacePods) ([]ValidationError, error) {
 	var errors []ValidationError
 
 	for _, policy := range policies {
 		// Check if policy selector matches any pods in its namespace
-		namespacePods := GetPodsInNamespace(pods, policy.Namespace)
+		namespacePods, err := lookup.get(ctx, policy.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pods: %w", err)
+		}
 		matchingPods := v.findPodsMatchingPolicy(policy, namespacePods)
 
 		if len(matchingPods) == 0 {
@@ -404,7 +447,7 @@ func (v *NetworkingValidator) validateNetworkPolicySelectors(policies []networki
 		}
 	}
 
-	return errors
+	return errors, nil
 }
 
 func (v *NetworkingValidator) validateIngressConnectivity(ctx context.Context) ([]ValidationError, error) {
@@ -422,12 +465,6 @@ func (v *NetworkingValidator) validateIngressConnectivity(ctx context.Context) (
 		return nil, fmt.Errorf("failed to list services: %w", err)
 	}
 
-	// Get all Pods
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
 	// Create service lookup map
 	serviceMap := make(map[string]corev1.Service)
 	for _, service := range services.Items {
@@ -435,21 +472,28 @@ func (v *NetworkingValidator) validateIngressConnectivity(ctx context.Context) (
 		serviceMap[key] = service
 	}
 
-	// Validate each ingress
+	// Validate each ingress, looking up the Pods of backend namespaces as needed
+	namespacePods := newNamespacePods(v.client)
 	for _, ingress := range ingresses.Items {
-		ingressErrors := v.validateIngressBackends(ingress, serviceMap, pods.Items)
+		ingressErrors, err := v.validateIngressBackends(ctx, ingress, serviceMap, namespacePods)
+		if err != nil {
+			return nil, err
+		}
 		errors = append(errors, ingressErrors...)
 	}
 
 	return errors, nil
 }
 
-func (v *NetworkingValidator) validateIngressBackends(ingress networkingv1.Ingress, serviceMap map[string]corev1.Service, pods []corev1.Pod) []ValidationError {
+func (v *NetworkingValidator) validateIngressBackends(ctx context.Context, ingress networkingv1.Ingress, serviceMap map[string]corev1.Service, lookup *namespacePods) ([]ValidationError, error) {
 	var errors []ValidationError
 
 	// Check default backend
 	if ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil {
-		backendErrors := v.validateIngressServiceBackend(ingress, *ingress.Spec.DefaultBackend.Service, serviceMap, pods)
+		backendErrors, err := v.validateIngressServiceBackend(ctx, ingress, *ingress.Spec.DefaultBackend.Service, serviceMap, lookup)
+		if err != nil {
+			return nil, err
+		}
 		errors = append(errors, backendErrors...)
 	}
 
@@ -458,17 +502,20 @@ func (v *NetworkingValidator) validateIngressBackends(ingress networkingv1.Ingre
 		if rule.HTTP != nil {
 			for _, path := range rule.HTTP.Paths {
 				if path.Backend.Service != nil {
-					backendErrors := v.validateIngressServiceBackend(ingress, *path.Backend.Service, serviceMap, pods)
+					backendErrors, err := v.validateIngressServiceBackend(ctx, ingress, *path.Backend.Service, serviceMap, lookup)
+					if err != nil {
+						return nil, err
+					}
 					errors = append(errors, backendErrors...)
 				}
 			}
 		}
 	}
 
-	return errors
+	return errors, nil
 }
 
-func (v *NetworkingValidator) validateIngressServiceBackend(ingress networkingv1.Ingress, backend networkingv1.IngressServiceBackend, serviceMap map[string]corev1.Service, pods []corev1.Pod) []ValidationError {
+func (v *NetworkingValidator) validateIngressServiceBackend(ctx context.Context, ingress networkingv1.Ingress, backend networkingv1.IngressServiceBackend, serviceMap map[string]corev1.Service, lookup *namespacePods) ([]ValidationError, error) {
 	var errors []ValidationError
 
 	serviceKey := fmt.Sprintf("%s/%s", ingress.Namespace, backend.Name)
@@ -483,7 +530,7 @@ func (v *NetworkingValidator) validateIngressServiceBackend(ingress networkingv1
 			WithRelatedResources(fmt.Sprintf("Service/%s", backend.Name)).
 			WithDetail("service_name", backend.Name).
 			WithDetail("ingress_namespace", ingress.Namespace))
-		return errors
+		return errors, nil
 	}
 
 	// Check if service port matches
@@ -519,7 +566,10 @@ func (v *NetworkingValidator) validateIngressServiceBackend(ingress networkingv1
 	}
 
 	// Check if service has ready backend pods
-	namespacePods := GetPodsInNamespace(pods, service.Namespace)
+	namespacePods, err := lookup.get(ctx, service.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
 	matchingPods := FindMatchingPods(namespacePods, service.Spec.Selector)
 	readyPods := v.filterReadyPods(matchingPods)
 
@@ -534,6 +584,5 @@ func (v *NetworkingValidator) validateIngressServiceBackend(ingress networkingv1
 			WithDetail("ready_pods_count", "0"))
 	}
 
-	return errors
+	return errors, nil
 }
-
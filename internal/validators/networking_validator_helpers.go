@@ -75,9 +75,10 @@ func (v *NetworkingValidator) isSystemPod(pod corev1.Pod) bool {
 
 // isPodTypicallyUnexposed checks if a pod typically doesn't need a service.
 func (v *NetworkingValidator) isPodTypicallyUnexposed(pod corev1.Pod) bool {
-	// Check if pod is owned by a batch workload (typically don't need services)
+	// Check if pod is owned by a batch workload (typically don't need services) or by a
+	// DaemonSet, whose node agents are usually reached through the host, if at all
 	for _, owner := range pod.OwnerReferences {
-		if v.sharedConfig.IsBatchOwnerKind(owner.Kind) {
+		if v.sharedConfig.IsBatchOwnerKind(owner.Kind) || owner.Kind == "DaemonSet" {
 			return true
 		}
 	}
@@ -90,49 +91,6 @@ func (v *NetworkingValidator) isPodTypicallyUnexposed(pod corev1.Pod) bool {
 	return false
 }
 
-// hasDefaultDenyPolicy checks if a namespace has a default deny NetworkPolicy.
-func (v *NetworkingValidator) hasDefaultDenyPolicy(policies []networkingv1.NetworkPolicy, namespace string) bool {
-	for _, policy := range policies {
-		if policy.Namespace != namespace {
-			continue
-		}
-
-		if v.isDefaultDenyPolicy(policy) {
-			return true
-		}
-	}
-	return false
-}
-
-// isDefaultDenyPolicy checks if a NetworkPolicy is a default deny policy.
-func (v *NetworkingValidator) isDefaultDenyPolicy(policy networkingv1.NetworkPolicy) bool {
-	// A default deny policy typically:
-	// 1. Selects all pods (empty podSelector)
-	// 2. Has empty ingress and/or egress rules
-
-	if len(policy.Spec.PodSelector.MatchLabels) > 0 {
-		return false
-	}
-
-	if len(policy.Spec.PodSelector.MatchExpressions) > 0 {
-		return false
-	}
-
-	hasIngressDeny := false
-	hasEgressDeny := false
-
-	for _, policyType := range policy.Spec.PolicyTypes {
-		if policyType == networkingv1.PolicyTypeIngress && len(policy.Spec.Ingress) == 0 {
-			hasIngressDeny = true
-		}
-		if policyType == networkingv1.PolicyTypeEgress && len(policy.Spec.Egress) == 0 {
-			hasEgressDeny = true
-		}
-	}
-
-	return hasIngressDeny || hasEgressDeny
-}
-
 // findPodsMatchingPolicy returns pods that match a NetworkPolicy's pod selector.
 func (v *NetworkingValidator) findPodsMatchingPolicy(policy networkingv1.NetworkPolicy, pods []corev1.Pod) []corev1.Pod {
 	var matchingPods []corev1.Pod
@@ -172,17 +130,6 @@ func (v *NetworkingValidator) isSystemNamespace(namespace string) bool {
 	return v.sharedConfig.IsNetworkingExcludedNamespace(namespace)
 }
 
-// getPoliciesInNamespace returns all NetworkPolicies in the specified namespace.
-func (v *NetworkingValidator) getPoliciesInNamespace(policies []networkingv1.NetworkPolicy, namespace string) []networkingv1.NetworkPolicy {
-	var namespacePolicies []networkingv1.NetworkPolicy
-	for _, policy := range policies {
-		if policy.Namespace == namespace {
-			namespacePolicies = append(namespacePolicies, policy)
-		}
-	}
-	return namespacePolicies
-}
-
 // getServicePortNames returns a list of port names/numbers for a service.
 func (v *NetworkingValidator) getServicePortNames(service corev1.Service) []string {
 	var portNames []string
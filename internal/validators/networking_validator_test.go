@@ -298,7 +298,20 @@ func TestNetworkingValidator_ValidateNetworkPolicyCoverage(t *testing.T) {
 				EnableNetworkPolicyValidation: true,
 				PolicyRequiredNamespaces:      []string{"production"},
 			},
-			expectedErrors: []string{"missing_network_policy_required"},
+			expectedErrors: []string{"missing_network_policy_security_sensitive"},
+		},
+		{
+			name: "missing required NetworkPolicy in namespaces selected by pattern and label",
+			objects: []client.Object{
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod-eu"}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"environment": "production"}}},
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "staging"}},
+			},
+			config: NetworkingConfig{
+				EnableNetworkPolicyValidation: true,
+				PolicyRequiredNamespaces:      []string{"prod-*", "environment=production"},
+			},
+			expectedErrors: []string{"missing_network_policy_security_sensitive", "missing_network_policy_security_sensitive"},
 		},
 		{
 			name: "missing default deny policy",
@@ -717,7 +730,7 @@ func TestNetworkingValidator_HelperFunctions(t *testing.T) {
 				Ingress: []networkingv1.NetworkPolicyIngressRule{},
 			},
 		}
-		if !validator.isDefaultDenyPolicy(defaultDenyPolicy) {
+		if !isDefaultDenyPolicy(defaultDenyPolicy) {
 			t.Error("Expected empty ingress rules with empty selector to be default deny")
 		}
 
@@ -736,7 +749,7 @@ func TestNetworkingValidator_HelperFunctions(t *testing.T) {
 				},
 			},
 		}
-		if validator.isDefaultDenyPolicy(allowPolicy) {
+		if isDefaultDenyPolicy(allowPolicy) {
 			t.Error("Expected policy with ingress rules to not be default deny")
 		}
 	})
@@ -0,0 +1,181 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/yaml"
+)
+
+// FormatJSONOutput formats validation results as indented JSON for tooling consumption
+func (r *ValidatorRegistry) FormatJSONOutput(result ValidationResult) (string, error) {
+	data, err := json.MarshalIndent(normalizeResult(result), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation result to JSON: %w", err)
+	}
+	return string(data), nil
+}
+
+// FormatYAMLOutput formats validation results as YAML using the same field names as JSON
+func (r *ValidatorRegistry) FormatYAMLOutput(result ValidationResult) (string, error) {
+	data, err := yaml.Marshal(normalizeResult(result))
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal validation result to YAML: %w", err)
+	}
+	return string(data), nil
+}
+
+// LastValidationResult builds a ValidationResult from the errors each registered
+// validator recorded during its most recent run. It lets cluster-wide validation
+// share the structured output formats used for config file validation.
+func (r *ValidatorRegistry) LastValidationResult() ValidationResult {
+	r.mu.RLock()
+	validators := make([]Validator, len(r.validators))
+	copy(validators, r.validators)
+	resolver := r.profileResolver
+	shard := r.shard
+	failures := r.validatorFailures
+	owners := r.ownership
+	teams := r.teamResolver
+	snoozes := r.snoozeResolver
+	flaps := r.flaps
+	namespaces := r.namespaces
+	severities := r.severityPolicy
+	scorePolicy := r.scorePolicy
+	r.mu.RUnlock()
+
+	var result ValidationResult
+	for _, validator := range validators {
+		// Cluster scans hold back flapping findings, and keep reporting findings
+		// until they have been resolved for long enough
+		validatorErrors := validator.GetLastValidationErrors()
+		if reported, ok := flaps.reported(validator); ok {
+			validatorErrors = reported
+		}
+		// Severity overrides apply before profiles filter findings by severity
+		for _, validationError := range snoozes.filter(resolver.filter(severities.Apply(validatorErrors))) {
+			if shard.Owns(validationError.Namespace) {
+				result.Errors = append(result.Errors, validationError)
+			}
+		}
+	}
+	// Every shard reports its own failed validators
+	result.Errors = append(result.Errors, severities.Apply(failures)...)
+	if cluster := r.Cluster(); cluster != "" {
+		for i := range result.Errors {
+			result.Errors[i].Cluster = cluster
+		}
+	}
+	if label := shard.Label(); label != "" {
+		for i := range result.Errors {
+			result.Errors[i].Shard = label
+		}
+	}
+	if owners != nil {
+		for i := range result.Errors {
+			owners.annotate(&result.Errors[i])
+		}
+	}
+	teams.assign(result.Errors)
+
+	result.ExitCode = r.exitCode(result.Errors)
+	summarizeReferences(&result)
+	scoreResult(&result, namespaces, scorePolicy)
+	return result
+}
+
+// summarizeReferences fills in the total error count, the missing and suggested
+// references of the summary, and the suggested references the findings carry
+func summarizeReferences(result *ValidationResult) {
+	result.Summary.TotalErrors = len(result.Errors)
+	result.Summary.MissingRefs = nil
+	result.Summary.SuggestedRefs = nil
+	result.SuggestedRefs = nil
+	for _, ve := range result.Errors {
+		if ve.ValidationType == validationTypeMissingReference {
+			result.Summary.MissingRefs = append(result.Summary.MissingRefs, ve.Message)
+		}
+		if ve.ValidationType == validationTypeSuggestedReference {
+			result.Summary.SuggestedRefs = append(result.Summary.SuggestedRefs, ve.Message)
+		}
+		for _, ref := range ve.SuggestedRefs {
+			result.SuggestedRefs = append(result.SuggestedRefs, ref)
+			result.Summary.SuggestedRefs = append(result.Summary.SuggestedRefs, ref.String())
+		}
+	}
+}
+
+// LastScanResult returns the findings from the most recent successful cluster scan and
+// the time it completed. The boolean is false until a scan has completed.
+func (r *ValidatorRegistry) LastScanResult() (ValidationResult, time.Time, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.lastScanResult == nil {
+		return ValidationResult{}, time.Time{}, false
+	}
+	return normalizeResult(*r.lastScanResult), r.lastScanTime, true
+}
+
+// LastScanDuration returns how long the most recent successful cluster scan took, or
+// zero until a scan has completed
+func (r *ValidatorRegistry) LastScanDuration() time.Duration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.lastScanDuration
+}
+
+// NewResult builds the result of findings reported outside the validators, such as
+// the checks skipped offline, with the registry's severities, exit code and scores
+func (r *ValidatorRegistry) NewResult(findings []ValidationError) ValidationResult {
+	result := ValidationResult{Errors: r.severityOverrides().Apply(findings)}
+	result.ExitCode = r.exitCode(result.Errors)
+	result.Summary.TotalErrors = len(result.Errors)
+	scoreResult(&result, nil, r.scoreWeights())
+	return result
+}
+
+// MergeResults combines the results of validating several clusters into one result.
+// The exit code is the most serious exit code of the merged results, and the hygiene
+// scores are rated again over the namespaces the results rated, each finding weighed
+// by the score policy of its result.
+func MergeResults(results ...ValidationResult) ValidationResult {
+	var merged ValidationResult
+	var policies []*ScorePolicy
+	for _, result := range results {
+		merged.Errors = append(merged.Errors, result.Errors...)
+		for range result.Errors {
+			policies = append(policies, result.scorePolicy)
+		}
+		merged.SuggestedRefs = append(merged.SuggestedRefs, result.SuggestedRefs...)
+		merged.References = append(merged.References, result.References...)
+		merged.Summary.MissingRefs = append(merged.Summary.MissingRefs, result.Summary.MissingRefs...)
+		merged.Summary.SuggestedRefs = append(merged.Summary.SuggestedRefs, result.Summary.SuggestedRefs...)
+		merged.ExitCode = WorseExitCode(merged.ExitCode, result.ExitCode)
+	}
+	merged.Summary.TotalErrors = len(merged.Errors)
+	setScores(&merged, scoreFindings(merged.Errors, scoredNamespaces(results...), func(i int) *ScorePolicy { return policies[i] }))
+	// Results scored with one policy keep it for rating their findings again
+	if len(results) > 0 && !slices.ContainsFunc(results, func(result ValidationResult) bool { return result.scorePolicy != results[0].scorePolicy }) {
+		merged.scorePolicy = results[0].scorePolicy
+	}
+	return merged
+}
+
+// normalizeResult stamps the schema version and replaces nil collections with empty
+// ones so consumers always see the same set of keys.
+func normalizeResult(result ValidationResult) ValidationResult {
+	result.SchemaVersion = ResultSchemaVersion
+	if result.Errors == nil {
+		result.Errors = []ValidationError{}
+	}
+	return result
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/yaml"
+)
+
+func testValidationResult() ValidationResult {
+	var result ValidationResult
+	result.Summary.TotalErrors = 1
+	result.Errors = []ValidationError{
+		NewValidationErrorWithCode("Pod", "web", "team-a", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'app-config' referenced in volume does not exist").
+			WithSeverity(SeverityError).
+			WithRemediationHint("Create ConfigMap 'app-config'").
+			WithRelatedResources("ConfigMap/app-config").
+			WithDetail("volume_name", "config"),
+	}
+	result.ExitCode = 1
+	return result
+}
+
+func TestFormatJSONOutput(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	output, err := registry.FormatJSONOutput(testValidationResult())
+	if err != nil {
+		t.Fatalf("FormatJSONOutput() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, output)
+	}
+
+	if decoded["schema_version"] != ResultSchemaVersion {
+		t.Errorf("schema_version = %v, want %v", decoded["schema_version"], ResultSchemaVersion)
+	}
+	if decoded["exit_code"] != float64(1) {
+		t.Errorf("exit_code = %v, want 1", decoded["exit_code"])
+	}
+
+	errors, ok := decoded["errors"].([]interface{})
+	if !ok || len(errors) != 1 {
+		t.Fatalf("expected one error, got %v", decoded["errors"])
+	}
+	finding := errors[0].(map[string]interface{})
+	expected := map[string]interface{}{
+		"resource_type":    "Pod",
+		"resource_name":    "web",
+		"namespace":        "team-a",
+		"validation_type":  "dangling_configmap_volume",
+		"error_code":       "KOGARO-REF-003",
+		"severity":         "error",
+		"remediation_hint": "Create ConfigMap 'app-config'",
+	}
+	for key, want := range expected {
+		if finding[key] != want {
+			t.Errorf("errors[0].%s = %v, want %v", key, finding[key], want)
+		}
+	}
+	if details, ok := finding["details"].(map[string]interface{}); !ok || details["volume_name"] != "config" {
+		t.Errorf("errors[0].details = %v, want volume_name=config", finding["details"])
+	}
+}
+
+func TestFormatYAMLOutput(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	output, err := registry.FormatYAMLOutput(testValidationResult())
+	if err != nil {
+		t.Fatalf("FormatYAMLOutput() error = %v", err)
+	}
+
+	var decoded ValidationResult
+	if err := yaml.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, output)
+	}
+
+	if decoded.SchemaVersion != ResultSchemaVersion {
+		t.Errorf("SchemaVersion = %q, want %q", decoded.SchemaVersion, ResultSchemaVersion)
+	}
+	if len(decoded.Errors) != 1 || decoded.Errors[0].ErrorCode != "KOGARO-REF-003" {
+		t.Errorf("round-tripped errors = %+v", decoded.Errors)
+	}
+	if decoded.Errors[0].RelatedResources[0] != "ConfigMap/app-config" {
+		t.Errorf("RelatedResources = %v", decoded.Errors[0].RelatedResources)
+	}
+}
+
+func TestFormatJSONOutput_EmptyResultHasStableKeys(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	output, err := registry.FormatJSONOutput(ValidationResult{})
+	if err != nil {
+		t.Fatalf("FormatJSONOutput() error = %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	for _, key := range []string{"schema_version", "summary", "errors", "exit_code"} {
+		if _, ok := decoded[key]; !ok {
+			t.Errorf("expected key %q in output: %s", key, output)
+		}
+	}
+}
+
+func TestLastValidationResult(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: testValidationResult().Errors})
+	registry.Register(&MockValidator{validationType: "second"})
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	result := registry.LastValidationResult()
+	if result.Summary.TotalErrors != 1 || len(result.Errors) != 1 {
+		t.Errorf("expected 1 error, got summary %d and %d errors", result.Summary.TotalErrors, len(result.Errors))
+	}
+	if result.ExitCode != 1 {
+		t.Errorf("ExitCode = %d, want 1", result.ExitCode)
+	}
+}
+
+func TestMergeResults_TagsClusters(t *testing.T) {
+	var results []ValidationResult
+	for _, cluster := range []string{"staging", "prod"} {
+		registry := NewValidatorRegistry(logr.Discard(), nil)
+		registry.SetCluster(cluster)
+		registry.Register(&MockValidator{validationType: "first", lastValidationErrors: testValidationResult().Errors})
+
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+		results = append(results, registry.LastValidationResult())
+	}
+	results = append(results, ValidationResult{})
+
+	merged := MergeResults(results...)
+	if merged.Summary.TotalErrors != 2 || len(merged.Errors) != 2 {
+		t.Fatalf("expected 2 errors, got summary %d and %d errors", merged.Summary.TotalErrors, len(merged.Errors))
+	}
+	if merged.Errors[0].Cluster != "staging" || merged.Errors[1].Cluster != "prod" {
+		t.Errorf("clusters = %q, %q, want staging, prod", merged.Errors[0].Cluster, merged.Errors[1].Cluster)
+	}
+	if merged.ExitCode != 1 {
+		t.Errorf("ExitCode = %d, want 1", merged.ExitCode)
+	}
+}
+
+func TestFormatMarkdownOutput(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	result := testValidationResult()
+	result.Errors = append(result.Errors,
+		NewValidationErrorWithCode("Deployment", "api", "team-a", "duplicate_mount_path", "KOGARO-VOL-002", "mounts a|b collide").
+			WithSeverity(SeverityWarning),
+	)
+
+	output, err := registry.FormatMarkdownOutput(result, nil)
+	if err != nil {
+		t.Fatalf("FormatMarkdownOutput() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"## Kogaro Validation Results",
+		"**2 findings**: 1 errors, 1 warnings, 0 info",
+		"<summary>Reference (1)</summary>",
+		"<summary>Volume (1)</summary>",
+		"| error | `KOGARO-REF-003` | `Pod/team-a/web` |",
+		"mounts a\\|b collide",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Changes Since Baseline") {
+		t.Errorf("delta section rendered without a baseline\n%s", output)
+	}
+	if strings.Index(output, "Reference (1)") > strings.Index(output, "Volume (1)") {
+		t.Errorf("expected Reference section before Volume section")
+	}
+}
+
+func TestFormatMarkdownOutput_WithBaseline(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	resolved := NewValidationErrorWithCode("Pod", "old", "team-a", "missing_resource_requests", "KOGARO-RES-002", "old finding")
+	persisting := testValidationResult().Errors[0]
+	added := NewValidationErrorWithCode("Pod", "new", "team-a", "duplicate_volume_name", "KOGARO-VOL-001", "new finding")
+
+	baseline := ValidationResult{Errors: []ValidationError{resolved, persisting}}
+	current := ValidationResult{Errors: []ValidationError{persisting, added}}
+	scoreResult(&current, nil, nil)
+
+	output, err := registry.FormatMarkdownOutput(current, &baseline)
+	if err != nil {
+		t.Fatalf("FormatMarkdownOutput() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"### Changes Since Baseline",
+		"| 1 | 1 | 1 |",
+		"<details open>\n<summary>New findings (1)</summary>",
+		"<summary>Resolved findings (1)</summary>",
+		"**Hygiene score: 75/100**, unchanged since baseline",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatMarkdownOutput_References(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	result := ValidationResult{References: []ReferenceResolution{
+		{SourceType: "Deployment", SourceName: "web", Namespace: "shop", TargetType: "ConfigMap", TargetName: "settings", Provenance: ReferenceInBundle},
+		{SourceType: "Deployment", SourceName: "web", Namespace: "shop", TargetType: "Secret", TargetName: "db-creds", Provenance: ReferenceDangling, Reason: "not defined in the config"},
+	}}
+
+	output, err := registry.FormatMarkdownOutput(result, nil)
+	if err != nil {
+		t.Fatalf("FormatMarkdownOutput() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"<details open>\n<summary>Reference Resolution: 1 in bundle, 0 in cluster, 1 dangling</summary>",
+		"| bundle | shop | `Deployment/web -> ConfigMap/settings` | - |",
+		"| dangling | shop | `Deployment/web -> Secret/db-creds` | not defined in the config |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q\n%s", want, output)
+		}
+	}
+}
+
+func TestDiffFindings(t *testing.T) {
+	finding := func(name, message string) ValidationError {
+		return NewValidationErrorWithCode("Pod", name, "ns", "duplicate_volume_name", "KOGARO-VOL-001", message)
+	}
+
+	tests := []struct {
+		name              string
+		baseline          []ValidationError
+		current           []ValidationError
+		expectedNew       int
+		expectedResolved  int
+		expectedUnchanged int
+	}{
+		{
+			name:              "identical findings",
+			baseline:          []ValidationError{finding("a", "msg")},
+			current:           []ValidationError{finding("a", "msg")},
+			expectedUnchanged: 1,
+		},
+		{
+			name:              "message wording change is not a new finding",
+			baseline:          []ValidationError{finding("a", "old wording")},
+			current:           []ValidationError{finding("a", "new wording")},
+			expectedUnchanged: 1,
+		},
+		{
+			name:              "additional duplicate of an existing finding",
+			baseline:          []ValidationError{finding("a", "msg")},
+			current:           []ValidationError{finding("a", "msg"), finding("a", "msg")},
+			expectedNew:       1,
+			expectedUnchanged: 1,
+		},
+		{
+			name:             "empty current run resolves everything",
+			baseline:         []ValidationError{finding("a", "msg"), finding("b", "msg")},
+			expectedResolved: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newFindings, resolvedFindings, unchanged := DiffFindings(tt.baseline, tt.current)
+			if len(newFindings) != tt.expectedNew || len(resolvedFindings) != tt.expectedResolved || unchanged != tt.expectedUnchanged {
+				t.Errorf("DiffFindings() = new %d, resolved %d, unchanged %d; want %d, %d, %d",
+					len(newFindings), len(resolvedFindings), unchanged,
+					tt.expectedNew, tt.expectedResolved, tt.expectedUnchanged)
+			}
+		})
+	}
+}
+
+func TestLoadBaselineResult(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+
+	output, err := registry.FormatJSONOutput(testValidationResult())
+	if err != nil {
+		t.Fatalf("FormatJSONOutput() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(output), 0o600); err != nil {
+		t.Fatalf("failed to write baseline: %v", err)
+	}
+
+	baseline, err := LoadBaselineResult(path)
+	if err != nil {
+		t.Fatalf("LoadBaselineResult() error = %v", err)
+	}
+	if len(baseline.Errors) != 1 || baseline.Errors[0].ErrorCode != "KOGARO-REF-003" {
+		t.Errorf("unexpected baseline errors: %+v", baseline.Errors)
+	}
+
+	if _, err := LoadBaselineResult(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected error for missing baseline file")
+	}
+}
+
+func TestLastScanResult(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: testValidationResult().Errors})
+
+	if _, _, ok := registry.LastScanResult(); ok {
+		t.Fatal("expected no scan result before the first scan")
+	}
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	result, scanTime, ok := registry.LastScanResult()
+	if !ok {
+		t.Fatal("expected a scan result after ValidateCluster")
+	}
+	if len(result.Errors) != 1 || result.SchemaVersion != ResultSchemaVersion {
+		t.Errorf("unexpected scan result: %+v", result)
+	}
+	if scanTime.IsZero() {
+		t.Error("expected scan time to be recorded")
+	}
+}
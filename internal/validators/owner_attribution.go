@@ -0,0 +1,156 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// workloadRef names the workload that owns a pod
+type workloadRef struct {
+	kind string
+	name string
+}
+
+// attributedKinds are the workloads that pod findings are attributed to
+var attributedKinds = map[string]bool{
+	DeploymentType: true,
+	"StatefulSet":  true,
+	"DaemonSet":    true,
+}
+
+// attributePodFindings attributes findings on controller-owned Pods to the Deployment,
+// StatefulSet or DaemonSet that owns them, so that findings stay stable across pod
+// churn. The Pod is listed in the related resources of the finding, and identical
+// findings on several replicas of a workload are merged into one. Findings on pods
+// whose workload cannot be resolved are kept as they are.
+func attributePodFindings(ctx context.Context, reader client.Reader, findings []ValidationError) ([]ValidationError, error) {
+	hasPodFindings := false
+	for _, finding := range findings {
+		if finding.ResourceType == "Pod" {
+			hasPodFindings = true
+			break
+		}
+	}
+	if !hasPodFindings {
+		return findings, nil
+	}
+
+	podOwners := make(map[string]*metav1.OwnerReference)
+	err := forEachPodMetadata(ctx, reader, func(pod metav1.Object) error {
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			podOwners[pod.GetNamespace()+"/"+pod.GetName()] = owner
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	resolver := newPodControllerResolver(reader)
+
+	attributed := make([]ValidationError, 0, len(findings))
+	merged := make(map[string]int)
+	for _, finding := range findings {
+		if finding.ResourceType == "Pod" {
+			if owner, owned := podOwners[finding.Namespace+"/"+finding.ResourceName]; owned {
+				if ref := resolver.controller(ctx, finding.Namespace, owner); attributedKinds[ref.kind] {
+					pod := "Pod/" + finding.ResourceName
+					finding.RelatedResources = append(append([]string(nil), finding.RelatedResources...), pod)
+					finding.ResourceType = ref.kind
+					finding.ResourceName = ref.name
+				}
+			}
+		}
+
+		key := strings.Join([]string{finding.ResourceType, finding.Namespace, finding.ResourceName,
+			finding.ValidationType, finding.ErrorCode, finding.Message}, "\x00")
+		if index, exists := merged[key]; exists {
+			attributed[index].RelatedResources = appendMissing(attributed[index].RelatedResources, finding.RelatedResources...)
+			continue
+		}
+		merged[key] = len(attributed)
+		attributed = append(attributed, finding)
+	}
+	return attributed, nil
+}
+
+// appendMissing appends the values that the slice does not already hold
+func appendMissing(values []string, additions ...string) []string {
+	for _, addition := range additions {
+		found := false
+		for _, value := range values {
+			if value == addition {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, addition)
+		}
+	}
+	return values
+}
+
+// podControllerResolver maps pods to the workloads managing them, following
+// ReplicaSets to the Deployments and Jobs to the CronJobs that own them. Each
+// ReplicaSet and Job is read once, when a pod it owns is first resolved.
+type podControllerResolver struct {
+	reader client.Reader
+	// owners maps kind/namespace/name of a ReplicaSet or Job to its controller
+	owners map[string]workloadRef
+}
+
+// newPodControllerResolver creates a podControllerResolver reading owners through reader
+func newPodControllerResolver(reader client.Reader) *podControllerResolver {
+	return &podControllerResolver{reader: reader, owners: make(map[string]workloadRef)}
+}
+
+// resolve returns the workload managing pod, or the pod itself when it is standalone
+func (r *podControllerResolver) resolve(ctx context.Context, pod corev1.Pod) workloadRef {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return workloadRef{kind: "Pod", name: pod.Name}
+	}
+	return r.controller(ctx, pod.Namespace, owner)
+}
+
+// controller returns the workload managing a pod whose controller is owner. A
+// ReplicaSet or Job without a Deployment or CronJob, or that cannot be read, manages
+// the pod itself.
+func (r *podControllerResolver) controller(ctx context.Context, namespace string, owner *metav1.OwnerReference) workloadRef {
+	ref := workloadRef{kind: owner.Kind, name: owner.Name}
+	var obj client.Object
+	var parentKind string
+	switch owner.Kind {
+	case "ReplicaSet":
+		obj, parentKind = &appsv1.ReplicaSet{}, DeploymentType
+	case "Job":
+		obj, parentKind = &batchv1.Job{}, "CronJob"
+	default:
+		return ref
+	}
+
+	key := owner.Kind + "/" + namespace + "/" + owner.Name
+	if resolved, cached := r.owners[key]; cached {
+		return resolved
+	}
+	if err := r.reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, obj); err == nil {
+		if parent := metav1.GetControllerOf(obj); parent != nil && parent.Kind == parentKind {
+			ref = workloadRef{kind: parentKind, name: parent.Name}
+		}
+	}
+	r.owners[key] = ref
+	return ref
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReferenceValidator_AttributesPodFindingsToWorkloads(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	controllerOf := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: ptr.To(true)}}
+	}
+	pod := func(name string, owners []metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", OwnerReferences: owners},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "app",
+					EnvFrom: []corev1.EnvFromSource{{
+						ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config"}},
+					}},
+				}},
+			},
+		}
+	}
+
+	objects := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-7f9c", Namespace: "shop", OwnerReferences: controllerOf("Deployment", "web")}},
+		pod("web-7f9c-abcde", controllerOf("ReplicaSet", "web-7f9c")),
+		pod("web-7f9c-fghij", controllerOf("ReplicaSet", "web-7f9c")),
+		pod("db-0", controllerOf("StatefulSet", "db")),
+		pod("standalone", nil),
+		pod("orphan-5d6e-klmno", controllerOf("ReplicaSet", "orphan-5d6e")),
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{EnableConfigMapValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, finding := range validator.GetLastValidationErrors() {
+		if finding.ValidationType != "dangling_configmap_envfrom" {
+			continue
+		}
+		key := finding.ResourceType + "/" + finding.ResourceName
+		if _, duplicate := got[key]; duplicate {
+			t.Errorf("finding on %s reported more than once", key)
+		}
+		got[key] = finding.RelatedResources
+	}
+
+	want := map[string][]string{
+		"Deployment/web":        {"Pod/web-7f9c-abcde", "Pod/web-7f9c-fghij"},
+		"StatefulSet/db":        {"Pod/db-0"},
+		"Pod/standalone":        nil,
+		"Pod/orphan-5d6e-klmno": nil,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("findings = %v, want %v", got, want)
+	}
+	for key, wantRelated := range want {
+		related, found := got[key]
+		if !found {
+			t.Errorf("missing finding on %s, got %v", key, got)
+			continue
+		}
+		want := append([]string{"ConfigMap/missing-config"}, wantRelated...)
+		if !slices.Equal(related, want) {
+			t.Errorf("finding on %s related resources = %v, want %v", key, related, want)
+		}
+	}
+}
+
+func TestPodControllerResolver(t *testing.T) {
+	controllerOf := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: ptr.To(true)}}
+	}
+	pod := func(name string, owners []metav1.OwnerReference) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", OwnerReferences: owners}}
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-7f9c", Namespace: "shop", OwnerReferences: controllerOf("Deployment", "web")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: "shop"}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "nightly-2901", Namespace: "shop", OwnerReferences: controllerOf("CronJob", "nightly")}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "shop"}},
+	).Build()
+	resolver := newPodControllerResolver(fakeClient)
+
+	tests := []struct {
+		pod  corev1.Pod
+		want workloadRef
+	}{
+		{pod("web-7f9c-abcde", controllerOf("ReplicaSet", "web-7f9c")), workloadRef{kind: "Deployment", name: "web"}},
+		{pod("bare-abcde", controllerOf("ReplicaSet", "bare")), workloadRef{kind: "ReplicaSet", name: "bare"}},
+		{pod("gone-abcde", controllerOf("ReplicaSet", "gone")), workloadRef{kind: "ReplicaSet", name: "gone"}},
+		{pod("nightly-2901-xyz", controllerOf("Job", "nightly-2901")), workloadRef{kind: "CronJob", name: "nightly"}},
+		{pod("migrate-xyz", controllerOf("Job", "migrate")), workloadRef{kind: "Job", name: "migrate"}},
+		{pod("db-0", controllerOf("StatefulSet", "db")), workloadRef{kind: "StatefulSet", name: "db"}},
+		{pod("standalone", nil), workloadRef{kind: "Pod", name: "standalone"}},
+	}
+	for _, tt := range tests {
+		if got := resolver.resolve(context.Background(), tt.pod); got != tt.want {
+			t.Errorf("resolve(%s) = %+v, want %+v", tt.pod.Name, got, tt.want)
+		}
+	}
+}
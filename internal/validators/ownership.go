@@ -0,0 +1,179 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// HelmReleaseNameAnnotation is set by Helm 3 on every resource a release installs
+	HelmReleaseNameAnnotation = "meta.helm.sh/release-name"
+	// helmManagedByValue marks resources managed by Helm in the app.kubernetes.io/managed-by label
+	helmManagedByValue = "Helm"
+	managedByLabel     = "app.kubernetes.io/managed-by"
+	instanceLabel      = "app.kubernetes.io/instance"
+
+	// DefaultComponentLabel is the label naming the Backstage component of a resource
+	DefaultComponentLabel = "backstage.io/kubernetes-id"
+	// ComponentDetail is the key of the details of a finding that holds its component
+	ComponentDetail = "component"
+
+	// maxOwnerDepth bounds the owner chain, guarding against owner reference cycles
+	maxOwnerDepth = 8
+	// ownerLookupTimeout bounds each lookup, so that a kind Kogaro may not read can't stall a scan
+	ownerLookupTimeout = 5 * time.Second
+)
+
+// ownerKinds creates empty objects of the kinds whose owners are followed. They are the
+// workload kinds every workload-reading validator already has permission to read.
+var ownerKinds = map[string]func() client.Object{
+	"Pod":         func() client.Object { return &corev1.Pod{} },
+	"ReplicaSet":  func() client.Object { return &appsv1.ReplicaSet{} },
+	"Deployment":  func() client.Object { return &appsv1.Deployment{} },
+	"StatefulSet": func() client.Object { return &appsv1.StatefulSet{} },
+	"DaemonSet":   func() client.Object { return &appsv1.DaemonSet{} },
+	"Job":         func() client.Object { return &batchv1.Job{} },
+	"CronJob":     func() client.Object { return &batchv1.CronJob{} },
+}
+
+// ownership is what is known about who owns the resource of a finding
+type ownership struct {
+	// Controllers owning the resource, from its direct owner upward, as Kind/name
+	chain []string
+	// Name of the Helm release that installed the resource or one of its owners
+	helmRelease string
+	// Component the resource or one of its owners is labelled with
+	component string
+}
+
+// ownershipIndex holds the ownership of resources by kind/namespace/name
+type ownershipIndex map[string]ownership
+
+// ownershipKey identifies the resource of a finding in an ownershipIndex
+func ownershipKey(finding ValidationError) string {
+	return finding.ResourceType + "/" + finding.Namespace + "/" + finding.ResourceName
+}
+
+// resolveOwnership looks up the owner chain, Helm release and component of the
+// namespaced resources the findings are reported on. Owners are followed through
+// controller references, such as Pod to ReplicaSet to Deployment. An owner of a kind
+// Kogaro doesn't read ends the chain, and a failed lookup ends it early, so the
+// index is always usable. The component is the value of componentLabel on the
+// resource or its nearest owner; no component is looked up when it is empty.
+func resolveOwnership(ctx context.Context, reader client.Reader, findings []ValidationError, componentLabel string) ownershipIndex {
+	index := make(ownershipIndex)
+	unreadable := make(map[string]bool)
+	objects := make(map[string]client.Object)
+
+	get := func(kind, namespace, name string) client.Object {
+		key := kind + "/" + namespace + "/" + name
+		if obj, cached := objects[key]; cached {
+			return obj
+		}
+		newObject, known := ownerKinds[kind]
+		if !known || unreadable[kind] {
+			return nil
+		}
+		obj := newObject()
+		lookupCtx, cancel := context.WithTimeout(ctx, ownerLookupTimeout)
+		defer cancel()
+		if err := reader.Get(lookupCtx, client.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+			if lookupCtx.Err() != nil {
+				unreadable[kind] = true
+			}
+			obj = nil
+		}
+		objects[key] = obj
+		return obj
+	}
+
+	for _, finding := range findings {
+		key := ownershipKey(finding)
+		if _, resolved := index[key]; resolved || finding.Namespace == "" {
+			continue
+		}
+
+		var owned ownership
+		obj := get(finding.ResourceType, finding.Namespace, finding.ResourceName)
+		for depth := 0; obj != nil && depth < maxOwnerDepth; depth++ {
+			if owned.helmRelease == "" {
+				owned.helmRelease = helmRelease(obj)
+			}
+			if owned.component == "" && componentLabel != "" {
+				owned.component = obj.GetLabels()[componentLabel]
+			}
+			owner := metav1.GetControllerOf(obj)
+			if owner == nil {
+				break
+			}
+			owned.chain = append(owned.chain, owner.Kind+"/"+owner.Name)
+			obj = get(owner.Kind, finding.Namespace, owner.Name)
+		}
+		index[key] = owned
+	}
+	return index
+}
+
+// helmRelease returns the Helm release that installed an object, from the annotation
+// Helm 3 sets or, for objects created from a Helm-managed template, the instance label
+func helmRelease(obj client.Object) string {
+	if release := obj.GetAnnotations()[HelmReleaseNameAnnotation]; release != "" {
+		return release
+	}
+	if obj.GetLabels()[managedByLabel] == helmManagedByValue {
+		return obj.GetLabels()[instanceLabel]
+	}
+	return ""
+}
+
+// annotate adds the owner chain, namespace and Helm release of a finding's resource
+// to its related resources as Kind/name entries, and records them in its details as
+// owner_chain, such as "Pod/web-7d9f-x2x → ReplicaSet/web-7d9f → Deployment/web",
+// and helm_release, so that notifications can be routed to the owning team. The
+// resource's component is recorded as component.
+func (index ownershipIndex) annotate(finding *ValidationError) {
+	if finding.Namespace == "" {
+		return
+	}
+	owned := index[ownershipKey(*finding)]
+
+	related := append([]string(nil), finding.RelatedResources...)
+	related = appendMissing(related, owned.chain...)
+	related = appendMissing(related, "Namespace/"+finding.Namespace)
+	if owned.helmRelease != "" {
+		related = appendMissing(related, "HelmRelease/"+owned.helmRelease)
+	}
+	finding.RelatedResources = related
+
+	if len(owned.chain) == 0 && owned.helmRelease == "" && owned.component == "" {
+		return
+	}
+	details := maps.Clone(finding.Details)
+	if details == nil {
+		details = make(map[string]string)
+	}
+	if len(owned.chain) > 0 {
+		resource := finding.ResourceType + "/" + finding.ResourceName
+		details["owner_chain"] = strings.Join(append([]string{resource}, owned.chain...), " → ")
+	}
+	if owned.helmRelease != "" {
+		details["helm_release"] = owned.helmRelease
+	}
+	if owned.component != "" {
+		details[ComponentDetail] = owned.component
+	}
+	finding.Details = details
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidatorRegistry_OwnershipInRelatedResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	controllerRef := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: ptr.To(true)}}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop",
+			Labels:      map[string]string{DefaultComponentLabel: "storefront-web"},
+			Annotations: map[string]string{HelmReleaseNameAnnotation: "storefront"}}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f", Namespace: "shop", OwnerReferences: controllerRef("Deployment", "web")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-7d9f-x2x", Namespace: "shop", OwnerReferences: controllerRef("ReplicaSet", "web-7d9f")}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "shop",
+			Labels: map[string]string{managedByLabel: helmManagedByValue, instanceLabel: "toolbox"}}},
+	).Build()
+
+	findings := []ValidationError{
+		*NewValidationErrorWithCode("Pod", "web-7d9f-x2x", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests").
+			WithRelatedResources("ConfigMap/web-config"),
+		NewValidationErrorWithCode("Pod", "debug", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests"),
+		NewValidationErrorWithCode("Service", "web", "shop", "service_selector_mismatch", "KOGARO-NET-001", "no pods"),
+		NewValidationErrorWithCode("StorageClass", "fast", "", "invalid_storage_class", "KOGARO-REF-010", "missing"),
+	}
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: findings})
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	result := registry.LastValidationResult()
+
+	tests := []struct {
+		related     []string
+		ownerChain  string
+		helmRelease string
+		component   string
+	}{
+		{
+			related:     []string{"ConfigMap/web-config", "ReplicaSet/web-7d9f", "Deployment/web", "Namespace/shop", "HelmRelease/storefront"},
+			ownerChain:  "Pod/web-7d9f-x2x → ReplicaSet/web-7d9f → Deployment/web",
+			helmRelease: "storefront",
+			component:   "storefront-web",
+		},
+		{related: []string{"Namespace/shop", "HelmRelease/toolbox"}, helmRelease: "toolbox"},
+		{related: []string{"Namespace/shop"}},
+		{related: nil},
+	}
+	for i, tt := range tests {
+		finding := result.Errors[i]
+		if len(finding.RelatedResources)+len(tt.related) > 0 && !reflect.DeepEqual(finding.RelatedResources, tt.related) {
+			t.Errorf("%s related resources = %q, want %q", finding.ResourceName, finding.RelatedResources, tt.related)
+		}
+		if finding.Details["owner_chain"] != tt.ownerChain || finding.Details["helm_release"] != tt.helmRelease || finding.Details[ComponentDetail] != tt.component {
+			t.Errorf("%s details = %v", finding.ResourceName, finding.Details)
+		}
+	}
+
+	// The validator's own findings are not changed
+	if len(findings[0].RelatedResources) != 1 || findings[0].Details["owner_chain"] != "" {
+		t.Errorf("validator finding was modified: %+v", findings[0])
+	}
+}
+
+func TestValidatorRegistry_ComponentLabel(t *testing.T) {
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop",
+			Labels: map[string]string{DefaultComponentLabel: "storefront-web", "app.kubernetes.io/part-of": "storefront"}}},
+	).Build()
+	finding := NewValidationErrorWithCode("Pod", "web", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests")
+
+	for label, want := range map[string]string{"app.kubernetes.io/part-of": "storefront", "": ""} {
+		registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+		registry.SetComponentLabel(label)
+		registry.Register(&MockValidator{validationType: "first", lastValidationErrors: []ValidationError{finding}})
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+		if got := registry.LastValidationResult().Errors[0].Details[ComponentDetail]; got != want {
+			t.Errorf("component with label %q = %q, want %q", label, got, want)
+		}
+	}
+}
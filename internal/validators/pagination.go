@@ -0,0 +1,100 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"slices"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podPageSize is the number of Pods validators request per page; 0 lists all Pods at once
+var podPageSize atomic.Int64
+
+// SetPodPageSize makes validators list Pods a page of the given size at a time,
+// processing each page before requesting the next, so that the Pods of a large
+// cluster are never all held in memory. A size of 0 lists all Pods at once. Pages
+// are only served by the API server, so Pods must not be read from the informer
+// cache when a page size is set.
+func SetPodPageSize(size int64) {
+	podPageSize.Store(size)
+}
+
+// forEachPod calls fn with each Pod the options select, a page at a time
+func forEachPod(ctx context.Context, reader client.Reader, fn func(pod *corev1.Pod) error, opts ...client.ListOption) error {
+	var pods corev1.PodList
+	return listPages(ctx, reader, &pods, func() error {
+		for i := range pods.Items {
+			if err := fn(&pods.Items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, opts...)
+}
+
+// listPods returns all the Pods the options select, requested a page at a time, for
+// checks that need every Pod at once
+func listPods(ctx context.Context, reader client.Reader, opts ...client.ListOption) ([]corev1.Pod, error) {
+	var all []corev1.Pod
+	var pods corev1.PodList
+	err := listPages(ctx, reader, &pods, func() error {
+		all = append(all, pods.Items...)
+		return nil
+	}, opts...)
+	return all, err
+}
+
+// forEachPodMetadata calls fn with the metadata of each Pod the options select, a page
+// at a time, for checks that read neither the spec nor the status of Pods. Without a
+// page size, Pods come from the informer cache, which holds them in full anyway, so
+// they are listed in full rather than starting a second informer for their metadata.
+func forEachPodMetadata(ctx context.Context, reader client.Reader, fn func(pod metav1.Object) error, opts ...client.ListOption) error {
+	if podPageSize.Load() == 0 {
+		return forEachPod(ctx, reader, func(pod *corev1.Pod) error { return fn(pod) }, opts...)
+	}
+	pods := &metav1.PartialObjectMetadataList{}
+	pods.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("PodList"))
+	return listPages(ctx, reader, pods, func() error {
+		for i := range pods.Items {
+			if err := fn(&pods.Items[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, opts...)
+}
+
+// listPages lists objects into list a page at a time, calling page after each page.
+// The items of a page are dropped before the next is requested, so that they are
+// not reused to decode it.
+func listPages(ctx context.Context, reader client.Reader, list client.ObjectList, page func() error, opts ...client.ListOption) error {
+	limit := podPageSize.Load()
+	token := ""
+	for {
+		pageOpts := opts
+		if limit > 0 {
+			pageOpts = append(slices.Clone(opts), client.Limit(limit), client.Continue(token))
+		}
+		if err := reader.List(ctx, list, pageOpts...); err != nil {
+			return err
+		}
+		if err := page(); err != nil {
+			return err
+		}
+		if err := meta.SetList(list, nil); err != nil {
+			return err
+		}
+		if token = list.GetContinue(); limit == 0 || token == "" {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pagingReader serves Pods a page at a time like the API server, which the fake
+// client does not
+type pagingReader struct {
+	pods     []corev1.Pod
+	requests []client.ListOptions
+}
+
+func (r *pagingReader) Get(_ context.Context, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (r *pagingReader) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	r.requests = append(r.requests, listOpts)
+
+	start, end := 0, len(r.pods)
+	if listOpts.Continue != "" {
+		start, _ = strconv.Atoi(listOpts.Continue)
+	}
+	if listOpts.Limit > 0 && start+int(listOpts.Limit) < end {
+		end = start + int(listOpts.Limit)
+	}
+	next := ""
+	if end < len(r.pods) {
+		next = strconv.Itoa(end)
+	}
+
+	switch list := list.(type) {
+	case *corev1.PodList:
+		list.Items = append(list.Items, r.pods[start:end]...)
+		list.Continue = next
+	case *metav1.PartialObjectMetadataList:
+		for _, pod := range r.pods[start:end] {
+			list.Items = append(list.Items, metav1.PartialObjectMetadata{ObjectMeta: pod.ObjectMeta})
+		}
+		list.Continue = next
+	default:
+		return fmt.Errorf("unexpected list %T", list)
+	}
+	return nil
+}
+
+func TestForEachPod_Pages(t *testing.T) {
+	reader := &pagingReader{}
+	for i := range 5 {
+		reader.pods = append(reader.pods, corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("pod-%d", i), Namespace: "shop"}})
+	}
+	ctx := context.Background()
+
+	var names []string
+	collect := func(pod *corev1.Pod) error {
+		names = append(names, pod.Name)
+		return nil
+	}
+	if err := forEachPod(ctx, reader, collect, client.InNamespace("shop")); err != nil {
+		t.Fatalf("forEachPod() error = %v", err)
+	}
+	if len(reader.requests) != 1 || reader.requests[0].Limit != 0 || len(names) != 5 {
+		t.Errorf("without a page size: %d requests, %d pods, want all pods in one request", len(reader.requests), len(names))
+	}
+
+	SetPodPageSize(2)
+	t.Cleanup(func() { SetPodPageSize(0) })
+	reader.requests, names = nil, nil
+	if err := forEachPod(ctx, reader, collect, client.InNamespace("shop")); err != nil {
+		t.Fatalf("forEachPod() error = %v", err)
+	}
+	// Items are not carried over from one page into the next
+	if len(reader.requests) != 3 || len(names) != 5 {
+		t.Fatalf("with a page size of 2: %d requests, %d pods %v, want 3 requests for 5 pods", len(reader.requests), len(names), names)
+	}
+	for _, request := range reader.requests {
+		if request.Limit != 2 || request.Namespace != "shop" {
+			t.Errorf("request = %+v, want a limit of 2 in namespace shop", request)
+		}
+	}
+	if reader.requests[2].Continue != "4" {
+		t.Errorf("last request continues from %q, want 4", reader.requests[2].Continue)
+	}
+
+	pods, err := listPods(ctx, reader)
+	if err != nil || len(pods) != 5 || pods[4].Name != "pod-4" {
+		t.Errorf("listPods() = %d pods, %v, want all 5", len(pods), err)
+	}
+
+	reader.requests, names = nil, nil
+	err = forEachPodMetadata(ctx, reader, func(pod metav1.Object) error {
+		if _, ok := pod.(*metav1.PartialObjectMetadata); !ok {
+			t.Errorf("pod = %T, want its metadata only", pod)
+		}
+		names = append(names, pod.GetName())
+		return nil
+	})
+	if err != nil || len(names) != 5 {
+		t.Errorf("forEachPodMetadata() = %d pods, %v, want all 5", len(names), err)
+	}
+
+	stop := fmt.Errorf("stop")
+	reader.requests = nil
+	if err := forEachPod(ctx, reader, func(*corev1.Pod) error { return stop }); err != stop || len(reader.requests) != 1 {
+		t.Errorf("forEachPod() = %v after %d requests, want the callback's error after the first page", err, len(reader.requests))
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides an audit of Kogaro's own RBAC permissions.
+//
+// This package implements a self-check that reviews the rules granted to Kogaro's
+// ServiceAccount and reports permissions beyond those its registered validators and
+// enabled features need, so that Kogaro stays read-only unless it is asked to write.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// maxListedPermissions bounds the permissions named in a finding's message; details list all
+const maxListedPermissions = 10
+
+// PermissionConfig identifies Kogaro's ServiceAccount and the permissions it needs
+type PermissionConfig struct {
+	// Namespace and ServiceAccount identify the ServiceAccount Kogaro runs as. Rules are
+	// reviewed in this namespace, so they include cluster-wide grants.
+	Namespace      string
+	ServiceAccount string
+	// Required are the cluster-wide rules Kogaro needs, from RequiredPermissions
+	Required []rbacv1.PolicyRule
+	// LeaderElection permits the rules leader election needs in Namespace
+	LeaderElection bool
+	// Handoff permits the rules the finding state ConfigMap needs in Namespace
+	Handoff bool
+}
+
+// PermissionValidator audits the RBAC permissions of Kogaro's own ServiceAccount
+type PermissionValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               PermissionConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// NewPermissionValidator creates a new PermissionValidator with the given client, logger and config
+func NewPermissionValidator(client client.Client, log logr.Logger, config PermissionConfig) *PermissionValidator {
+	return &PermissionValidator{
+		client: client,
+		log:    log.WithName("permission-validator"),
+		config: config,
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *PermissionValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *PermissionValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *PermissionValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for permission validation
+func (v *PermissionValidator) GetValidationType() string {
+	return "permission_validation"
+}
+
+// Audit reviews the rules granted to Kogaro and returns the write and read permissions
+// it holds beyond those it needs
+func (v *PermissionValidator) Audit(ctx context.Context) (writes, reads []string, err error) {
+	review := &authorizationv1.SelfSubjectRulesReview{
+		Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: v.config.Namespace},
+	}
+	if err := v.client.Create(ctx, review); err != nil {
+		return nil, nil, fmt.Errorf("failed to review own permissions: %w", err)
+	}
+	if review.Status.Incomplete {
+		// Rules from authorizers that cannot list them, such as webhooks, are missing, so
+		// the audit may miss excess permissions but never reports needed ones
+		v.log.V(1).Info("permission review is incomplete", "reason", review.Status.EvaluationError)
+	}
+
+	granted := make([]rbacv1.PolicyRule, 0, len(review.Status.ResourceRules))
+	for _, rule := range review.Status.ResourceRules {
+		granted = append(granted, rbacv1.PolicyRule{APIGroups: rule.APIGroups, Resources: rule.Resources, Verbs: rule.Verbs})
+	}
+
+	allowed := append(append([]rbacv1.PolicyRule{}, v.config.Required...), selfReviewRules...)
+	if v.config.LeaderElection {
+		allowed = append(allowed, LeaderElectionPermissions()...)
+	}
+	if v.config.Handoff {
+		allowed = append(allowed, HandoffPermissions()...)
+	}
+	writes, reads = excessPermissions(granted, allowed)
+	return writes, reads, nil
+}
+
+// ValidateCluster reports the permissions Kogaro's ServiceAccount holds beyond those it needs
+func (v *PermissionValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	writes, reads, err := v.Audit(ctx)
+	if err != nil {
+		return err
+	}
+
+	var allErrors []ValidationError
+	if len(writes) > 0 {
+		allErrors = append(allErrors, NewValidationErrorWithCode("ServiceAccount", v.config.ServiceAccount, v.config.Namespace, "agent_write_permissions", GetPermissionErrorCode("agent_write_permissions"),
+			fmt.Sprintf("Kogaro's ServiceAccount holds write permissions no enabled feature needs: %s", summarizePermissions(writes))).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Remove the write verbs from the roles bound to the ServiceAccount, or replace them with the output of `kogaro rbac-manifest`").
+			WithDetail("permissions", strings.Join(writes, ", ")))
+	}
+	if len(reads) > 0 {
+		allErrors = append(allErrors, NewValidationErrorWithCode("ServiceAccount", v.config.ServiceAccount, v.config.Namespace, "agent_excess_read_permissions", GetPermissionErrorCode("agent_excess_read_permissions"),
+			fmt.Sprintf("Kogaro's ServiceAccount can read resources no registered validator needs: %s", summarizePermissions(reads))).
+			WithSeverity(SeverityInfo).
+			WithRemediationHint("Remove the unused permissions from the roles bound to the ServiceAccount, or replace them with the output of `kogaro rbac-manifest`").
+			WithDetail("permissions", strings.Join(reads, ", ")))
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "permission", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "permission", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// summarizePermissions joins permissions for a message, naming at most maxListedPermissions
+func summarizePermissions(permissions []string) string {
+	if len(permissions) <= maxListedPermissions {
+		return strings.Join(permissions, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(permissions[:maxListedPermissions], ", "), len(permissions)-maxListedPermissions)
+}
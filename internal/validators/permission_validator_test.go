@@ -0,0 +1,185 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestRequiredPermissions(t *testing.T) {
+	rules, unknown := RequiredPermissions(PermissionOptions{
+		ValidationTypes: []string{"resource_limits_validation", "lifecycle_validation", "plugin:acme"},
+		AutoRemediation: true,
+	})
+
+	want := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}},
+		{APIGroups: []string{""}, Resources: []string{"namespaces", "nodes", "pods"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"replicasets"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"daemonsets", "deployments", "statefulsets"}, Verbs: []string{"get", "list", "watch", "patch"}},
+		{APIGroups: []string{"autoscaling.k8s.io"}, Resources: []string{"verticalpodautoscalers"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"batch"}, Resources: []string{"cronjobs", "jobs"}, Verbs: []string{"get", "list", "watch"}},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("RequiredPermissions() rules = %+v, want %+v", rules, want)
+	}
+	if !reflect.DeepEqual(unknown, []string{"plugin:acme"}) {
+		t.Errorf("RequiredPermissions() unknown = %v, want [plugin:acme]", unknown)
+	}
+}
+
+func TestExcessPermissions(t *testing.T) {
+	allowed := []rbacv1.PolicyRule{
+		readRule("", "pods", "namespaces"),
+		readRule("apps", "deployments"),
+	}
+
+	tests := []struct {
+		name       string
+		granted    []rbacv1.PolicyRule
+		wantWrites []string
+		wantReads  []string
+	}{
+		{
+			name:    "exactly what is needed",
+			granted: []rbacv1.PolicyRule{readRule("", "pods"), readRule("apps", "deployments")},
+		},
+		{
+			name: "write verbs and unused reads",
+			granted: []rbacv1.PolicyRule{
+				{APIGroups: []string{"", "apps"}, Resources: []string{"pods", "deployments"}, Verbs: []string{"get", "delete"}},
+				readRule("", "nodes"),
+			},
+			wantWrites: []string{"delete deployments", "delete deployments.apps", "delete pods", "delete pods.apps"},
+			wantReads:  []string{"get deployments", "get nodes", "get pods.apps", "list nodes", "watch nodes"},
+		},
+		{
+			name:       "wildcards are never needed",
+			granted:    []rbacv1.PolicyRule{{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}}},
+			wantWrites: []string{"* *.*"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writes, reads := excessPermissions(tt.granted, allowed)
+			if len(writes) != 0 || len(tt.wantWrites) != 0 {
+				if !reflect.DeepEqual(writes, tt.wantWrites) {
+					t.Errorf("writes = %v, want %v", writes, tt.wantWrites)
+				}
+			}
+			if len(reads) != 0 || len(tt.wantReads) != 0 {
+				if !reflect.DeepEqual(reads, tt.wantReads) {
+					t.Errorf("reads = %v, want %v", reads, tt.wantReads)
+				}
+			}
+		})
+	}
+}
+
+func TestPermissionValidator_ValidateCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = authorizationv1.AddToScheme(scheme)
+
+	granted := []authorizationv1.ResourceRule{
+		{APIGroups: []string{""}, Resources: []string{"pods", "configmaps"}, Verbs: []string{"get", "list", "watch"}},
+		{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"get", "list", "watch", "patch"}},
+		{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get", "create", "update"}},
+		{APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectrulesreviews"}, Verbs: []string{"create"}},
+	}
+	var reviewedNamespace string
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			review := obj.(*authorizationv1.SelfSubjectRulesReview)
+			reviewedNamespace = review.Spec.Namespace
+			review.Status.ResourceRules = granted
+			return nil
+		},
+	}).Build()
+
+	required, _ := RequiredPermissions(PermissionOptions{ValidationTypes: []string{"resource_limits_validation"}})
+	validator := NewPermissionValidator(fakeClient, logr.Discard(), PermissionConfig{
+		Namespace:      "kogaro-system",
+		ServiceAccount: "kogaro",
+		Required:       required,
+		LeaderElection: true,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if reviewedNamespace != "kogaro-system" {
+		t.Errorf("reviewed namespace = %q, want kogaro-system", reviewedNamespace)
+	}
+
+	findings := validator.GetLastValidationErrors()
+	if len(findings) != 2 {
+		t.Fatalf("got %d findings, want 2: %+v", len(findings), findings)
+	}
+	want := []struct {
+		code, severity, permissions string
+	}{
+		{"KOGARO-SYS-003", "error", "patch deployments.apps"},
+		{"KOGARO-SYS-004", "info", "get configmaps, list configmaps, watch configmaps"},
+	}
+	for i, w := range want {
+		finding := findings[i]
+		if finding.ErrorCode != w.code || string(finding.Severity) != w.severity || finding.Details["permissions"] != w.permissions {
+			t.Errorf("finding %d = %s %s %q, want %s %s %q", i, finding.ErrorCode, finding.Severity, finding.Details["permissions"], w.code, w.severity, w.permissions)
+		}
+		if finding.ResourceType != "ServiceAccount" || finding.ResourceName != "kogaro" || finding.Namespace != "kogaro-system" {
+			t.Errorf("finding %d is about %s %s/%s, want ServiceAccount kogaro-system/kogaro", i, finding.ResourceType, finding.Namespace, finding.ResourceName)
+		}
+	}
+}
+
+func TestPermissionChecker_Missing(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = authorizationv1.AddToScheme(scheme)
+
+	granted := map[string]bool{"list pods": true, "get validationreports/status": true}
+	reviews := 0
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			reviews++
+			attributes := obj.(*authorizationv1.SelfSubjectAccessReview).Spec.ResourceAttributes
+			resource := attributes.Resource
+			if attributes.Subresource != "" {
+				resource += "/" + attributes.Subresource
+			}
+			obj.(*authorizationv1.SelfSubjectAccessReview).Status.Allowed = granted[attributes.Verb+" "+resource]
+			return nil
+		},
+	}).Build()
+
+	checker := NewPermissionChecker(fakeClient)
+	rules := []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"list", "watch"}},
+		{APIGroups: []string{"kogaro.io"}, Resources: []string{"validationreports/status"}, Verbs: []string{"get"}},
+	}
+	for i := 0; i < 2; i++ {
+		missing, err := checker.Missing(context.Background(), rules)
+		if err != nil {
+			t.Fatalf("Missing() error = %v", err)
+		}
+		if !reflect.DeepEqual(missing, []string{"watch pods"}) {
+			t.Errorf("Missing() = %v, want [watch pods]", missing)
+		}
+	}
+	if reviews != 3 {
+		t.Errorf("made %d access reviews, want 3 with results cached", reviews)
+	}
+}
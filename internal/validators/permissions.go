@@ -0,0 +1,359 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readVerbs are the verbs validators need: the manager's cache lists and watches every
+// resource it serves, and clients get single objects
+var readVerbs = []string{"get", "list", "watch"}
+
+// writeVerbs change cluster state or grant further access
+var writeVerbs = map[string]bool{
+	"*": true, "create": true, "update": true, "patch": true, "delete": true, "deletecollection": true,
+	"escalate": true, "bind": true, "impersonate": true, "approve": true, "sign": true,
+}
+
+// readRule grants the read verbs on resources of group
+func readRule(group string, resources ...string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{APIGroups: []string{group}, Resources: resources, Verbs: readVerbs}
+}
+
+// workloadReadRules grants read access to pods and the workloads that own them
+var workloadReadRules = []rbacv1.PolicyRule{
+	readRule("", "pods"),
+	readRule("apps", "deployments", "statefulsets", "daemonsets", "replicasets"),
+	readRule("batch", "jobs", "cronjobs"),
+}
+
+// validatorPermissions lists the rules each built-in validator needs, by validation type.
+// Keep it in step with the resources the validators read.
+var validatorPermissions = map[string][]rbacv1.PolicyRule{
+	"reference_validation": append([]rbacv1.PolicyRule{
+		readRule("", "configmaps", "secrets", "services", "serviceaccounts", "persistentvolumeclaims"),
+		readRule("networking.k8s.io", "ingresses", "ingressclasses"),
+		readRule("storage.k8s.io", "storageclasses"),
+		readRule("rbac.authorization.k8s.io", "rolebindings", "clusterrolebindings"),
+		readRule("secrets-store.csi.x-k8s.io", "secretproviderclasses"),
+	}, workloadReadRules...),
+	"resource_limits_validation": append([]rbacv1.PolicyRule{
+		readRule("", "nodes"),
+		readRule("autoscaling.k8s.io", "verticalpodautoscalers"),
+	}, workloadReadRules...),
+	"security_validation": append([]rbacv1.PolicyRule{
+		readRule("", "serviceaccounts"),
+		readRule("networking.k8s.io", "networkpolicies"),
+		readRule("rbac.authorization.k8s.io", "rolebindings", "clusterrolebindings", "roles", "clusterroles"),
+	}, workloadReadRules...),
+	"networking_validation": append([]rbacv1.PolicyRule{
+		readRule("", "services", "nodes", "secrets"),
+		readRule("discovery.k8s.io", "endpointslices"),
+		readRule("networking.k8s.io", "ingresses", "networkpolicies"),
+	}, workloadReadRules...),
+	"image_validation": append([]rbacv1.PolicyRule{
+		readRule("", "nodes"),
+	}, workloadReadRules...),
+	"secret_validation": {
+		readRule("", "pods", "secrets"),
+		readRule("networking.k8s.io", "ingresses"),
+	},
+	"volume_validation": append([]rbacv1.PolicyRule{
+		readRule("", "configmaps", "secrets"),
+	}, workloadReadRules...),
+	"quota_validation": append([]rbacv1.PolicyRule{
+		readRule("", "resourcequotas", "limitranges"),
+	}, workloadReadRules...),
+	"lifecycle_validation": workloadReadRules,
+	"availability_validation": {
+		readRule("apps", "deployments", "statefulsets"),
+	},
+	"workload_validation": {
+		readRule("", "services", "pods", "events"),
+		readRule("apps", "statefulsets", "daemonsets", "replicasets"),
+		readRule("storage.k8s.io", "storageclasses"),
+	},
+	"policy_report_validation": {
+		readRule("wgpolicyk8s.io", "policyreports", "clusterpolicyreports"),
+	},
+}
+
+// selfReviewRules are granted to every authenticated user and let Kogaro review its own
+// permissions, so they are never excess
+var selfReviewRules = []rbacv1.PolicyRule{
+	{APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectaccessreviews", "selfsubjectrulesreviews"}, Verbs: []string{"create"}},
+	{APIGroups: []string{"authentication.k8s.io"}, Resources: []string{"selfsubjectreviews"}, Verbs: []string{"create"}},
+}
+
+// PermissionOptions selects the validators and optional features whose permissions
+// Kogaro needs
+type PermissionOptions struct {
+	// ValidationTypes are the types of the registered validators
+	ValidationTypes []string
+	// ValidationPolicies reads ValidationPolicy resources
+	ValidationPolicies bool
+	// WorkloadAnnotations patches workloads and pods with summaries of their findings
+	WorkloadAnnotations bool
+	// ValidationReports maintains the status of ValidationReport resources
+	ValidationReports bool
+	// PolicyReports creates, replaces and deletes Kogaro's PolicyReports
+	PolicyReports bool
+	// AutoRemediation applies safe defaults to workloads and records Events
+	AutoRemediation bool
+	// UsageMetrics reads pod usage from metrics-server
+	UsageMetrics bool
+}
+
+// RequiredPermissions returns the cluster-wide rules Kogaro needs with the given options,
+// merged so that each API group lists resources once per set of verbs. Validation types
+// whose permissions are not known in advance, such as custom rules and plugins, are
+// returned separately.
+func RequiredPermissions(options PermissionOptions) ([]rbacv1.PolicyRule, []string) {
+	// Validation profiles and shards resolve namespaces before any validator runs
+	rules := []rbacv1.PolicyRule{readRule("", "namespaces")}
+	var unknown []string
+	for _, validationType := range options.ValidationTypes {
+		validatorRules, ok := validatorPermissions[validationType]
+		if !ok {
+			unknown = append(unknown, validationType)
+			continue
+		}
+		rules = append(rules, validatorRules...)
+	}
+
+	if options.ValidationPolicies {
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"kogaro.io"}, Resources: []string{"validationpolicies"}, Verbs: []string{"get", "list"}})
+	}
+	if options.WorkloadAnnotations {
+		rules = append(rules,
+			rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"patch"}},
+			rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets", "daemonsets"}, Verbs: []string{"patch"}},
+			readRule("", "pods"),
+			readRule("apps", "deployments", "statefulsets", "daemonsets"))
+	}
+	if options.ValidationReports {
+		rules = append(rules,
+			readRule("kogaro.io", "validationreports"),
+			rbacv1.PolicyRule{APIGroups: []string{"kogaro.io"}, Resources: []string{"validationreports/status"}, Verbs: []string{"get", "patch", "update"}})
+	}
+	if options.PolicyReports {
+		rules = append(rules,
+			readRule("wgpolicyk8s.io", "policyreports", "clusterpolicyreports"),
+			rbacv1.PolicyRule{APIGroups: []string{"wgpolicyk8s.io"}, Resources: []string{"policyreports", "clusterpolicyreports"}, Verbs: []string{"create", "update", "delete"}})
+	}
+	if options.AutoRemediation {
+		rules = append(rules,
+			rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments", "statefulsets", "daemonsets"}, Verbs: []string{"get", "patch"}},
+			rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}})
+	}
+	if options.UsageMetrics {
+		// The metrics API serves no watches
+		rules = append(rules, rbacv1.PolicyRule{APIGroups: []string{"metrics.k8s.io"}, Resources: []string{"pods"}, Verbs: []string{"get", "list"}})
+	}
+	return mergeRules(rules), unknown
+}
+
+// LeaderElectionPermissions returns the rules leader election needs in Kogaro's own namespace
+func LeaderElectionPermissions() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get", "create", "update"}},
+		{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}},
+	}
+}
+
+// HandoffPermissions returns the rules the leader needs in Kogaro's own namespace to
+// persist its finding state in a ConfigMap for the next leader
+func HandoffPermissions() []rbacv1.PolicyRule {
+	return []rbacv1.PolicyRule{
+		{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "create", "update"}},
+	}
+}
+
+// mergeRules combines rules so that each API group lists its resources once per set of
+// verbs, sorted for stable manifests
+func mergeRules(rules []rbacv1.PolicyRule) []rbacv1.PolicyRule {
+	verbsByResource := make(map[string]map[string]map[string]bool)
+	for _, rule := range rules {
+		for _, group := range rule.APIGroups {
+			if verbsByResource[group] == nil {
+				verbsByResource[group] = make(map[string]map[string]bool)
+			}
+			for _, resource := range rule.Resources {
+				if verbsByResource[group][resource] == nil {
+					verbsByResource[group][resource] = make(map[string]bool)
+				}
+				for _, verb := range rule.Verbs {
+					verbsByResource[group][resource][verb] = true
+				}
+			}
+		}
+	}
+
+	groups := make([]string, 0, len(verbsByResource))
+	for group := range verbsByResource {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	var merged []rbacv1.PolicyRule
+	for _, group := range groups {
+		resourcesByVerbs := make(map[string][]string)
+		var verbSets []string
+		for resource, verbs := range verbsByResource[group] {
+			key := strings.Join(sortedVerbs(verbs), ",")
+			if _, ok := resourcesByVerbs[key]; !ok {
+				verbSets = append(verbSets, key)
+			}
+			resourcesByVerbs[key] = append(resourcesByVerbs[key], resource)
+		}
+		sort.Strings(verbSets)
+		for _, key := range verbSets {
+			resources := resourcesByVerbs[key]
+			sort.Strings(resources)
+			merged = append(merged, rbacv1.PolicyRule{APIGroups: []string{group}, Resources: resources, Verbs: strings.Split(key, ",")})
+		}
+	}
+	return merged
+}
+
+// sortedVerbs lists verbs with the read verbs first, in the order RBAC documentation uses
+func sortedVerbs(verbs map[string]bool) []string {
+	order := map[string]int{"get": 0, "list": 1, "watch": 2}
+	list := make([]string, 0, len(verbs))
+	for verb := range verbs {
+		list = append(list, verb)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		oi, iok := order[list[i]]
+		oj, jok := order[list[j]]
+		switch {
+		case iok && jok:
+			return oi < oj
+		case iok != jok:
+			return iok
+		default:
+			return list[i] < list[j]
+		}
+	})
+	return list
+}
+
+// excessPermissions returns the permissions granted beyond allowed, split into write and
+// read permissions. Each is formatted as "verb resource" with the resource qualified by
+// its API group, such as "patch deployments.apps".
+func excessPermissions(granted, allowed []rbacv1.PolicyRule) (writes, reads []string) {
+	seen := make(map[string]bool)
+	for _, rule := range granted {
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				for _, verb := range rule.Verbs {
+					if permits(allowed, group, resource, verb) {
+						continue
+					}
+					permission := verb + " " + qualifiedResource(group, resource)
+					if seen[permission] {
+						continue
+					}
+					seen[permission] = true
+					if writeVerbs[verb] {
+						writes = append(writes, permission)
+					} else {
+						reads = append(reads, permission)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(writes)
+	sort.Strings(reads)
+	return writes, reads
+}
+
+// permits reports whether any rule grants verb on resource of group. Wildcards in rules
+// match anything, while a wildcard being checked only matches a wildcard.
+func permits(rules []rbacv1.PolicyRule, group, resource, verb string) bool {
+	for _, rule := range rules {
+		if matchesRuleValue(rule.APIGroups, group) && matchesRuleValue(rule.Resources, resource) && matchesRuleValue(rule.Verbs, verb) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRuleValue reports whether values contain value or the wildcard
+func matchesRuleValue(values []string, value string) bool {
+	for _, v := range values {
+		if v == value || v == rbacv1.ResourceAll {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedResource formats resource with its API group, as kubectl does
+func qualifiedResource(group, resource string) string {
+	if group == "" {
+		return resource
+	}
+	return resource + "." + group
+}
+
+// PermissionChecker asks the API server whether the client's user holds permissions
+// cluster-wide, remembering each answer so that rules shared by validators are
+// reviewed once
+type PermissionChecker struct {
+	client  client.Client
+	allowed map[string]bool
+}
+
+// NewPermissionChecker creates a PermissionChecker that reviews the permissions of the
+// user the client authenticates as
+func NewPermissionChecker(c client.Client) *PermissionChecker {
+	return &PermissionChecker{client: c, allowed: make(map[string]bool)}
+}
+
+// Missing returns the permissions of rules that are not granted cluster-wide, each
+// formatted as "verb resource" with the resource qualified by its API group
+func (c *PermissionChecker) Missing(ctx context.Context, rules []rbacv1.PolicyRule) ([]string, error) {
+	var missing []string
+	for _, rule := range mergeRules(rules) {
+		for _, resource := range rule.Resources {
+			for _, verb := range rule.Verbs {
+				permission := verb + " " + qualifiedResource(rule.APIGroups[0], resource)
+				allowed, ok := c.allowed[permission]
+				if !ok {
+					review := &authorizationv1.SelfSubjectAccessReview{
+						Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+							ResourceAttributes: &authorizationv1.ResourceAttributes{Group: rule.APIGroups[0], Resource: resource, Verb: verb},
+						},
+					}
+					// Subresources such as validationreports/status are reviewed as such
+					if name, subresource, found := strings.Cut(resource, "/"); found {
+						review.Spec.ResourceAttributes.Resource = name
+						review.Spec.ResourceAttributes.Subresource = subresource
+					}
+					if err := c.client.Create(ctx, review); err != nil {
+						return nil, fmt.Errorf("failed to review permission to %s: %w", permission, err)
+					}
+					allowed = review.Status.Allowed
+					c.allowed[permission] = allowed
+				}
+				if !allowed {
+					missing = append(missing, permission)
+				}
+			}
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,340 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides external validator plugins.
+//
+// This package implements an exec-based plugin protocol so organisations can
+// ship their own validators without forking Kogaro. A plugin is an executable
+// that supports two commands:
+//
+//   - `<plugin> describe` prints a PluginDescription declaring the plugin's name,
+//     error code prefix and the resource kinds it validates.
+//   - `<plugin> validate` reads a PluginRequest holding the resource snapshot
+//     from stdin and prints a PluginResponse with its findings.
+//
+// Error codes returned by plugins are namespaced as KOGARO-PLG-<PREFIX>-<CODE>
+// so they cannot collide with native error codes.
+package validators
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// PluginProtocolVersion identifies the version of the plugin protocol
+const PluginProtocolVersion = "kogaro.io/plugin/v1"
+
+var (
+	// pluginNamePattern restricts plugin names to lowercase DNS labels
+	pluginNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+	// pluginErrorCodePrefixPattern restricts error code prefixes to short uppercase identifiers
+	pluginErrorCodePrefixPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]{1,15}$`)
+	// pluginErrorCodePattern restricts the codes plugins return before namespacing
+	pluginErrorCodePattern = regexp.MustCompile(`^[A-Z0-9]+$`)
+)
+
+// PluginDescription is printed by `<plugin> describe`
+type PluginDescription struct {
+	APIVersion      string           `json:"apiVersion"`
+	Name            string           `json:"name"`
+	ErrorCodePrefix string           `json:"errorCodePrefix"`
+	Resources       []PluginResource `json:"resources"`
+}
+
+// PluginResource identifies a kind of resource a plugin validates
+type PluginResource struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+}
+
+// PluginRequest is written to the stdin of `<plugin> validate`
+type PluginRequest struct {
+	APIVersion string                   `json:"apiVersion"`
+	Resources  []map[string]interface{} `json:"resources"`
+}
+
+// PluginResponse is read from the stdout of `<plugin> validate`. Findings use the
+// same fields as Kogaro's JSON output.
+type PluginResponse struct {
+	APIVersion string            `json:"apiVersion"`
+	Errors     []ValidationError `json:"errors"`
+}
+
+// PluginConfig defines an external validator plugin
+type PluginConfig struct {
+	// Path to the plugin executable
+	Path string
+	// Timeout bounds each invocation of the plugin
+	Timeout time.Duration
+	// Description is the plugin's response to the describe command
+	Description PluginDescription
+}
+
+// PluginValidator runs an external validator plugin against cluster resources
+type PluginValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               PluginConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// NewPluginValidator creates a new PluginValidator with the given client, logger and config
+func NewPluginValidator(client client.Client, log logr.Logger, config PluginConfig) *PluginValidator {
+	return &PluginValidator{
+		client:       client,
+		log:          log.WithName("plugin-validator").WithValues("plugin", config.Description.Name),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *PluginValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *PluginValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *PluginValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for the plugin
+func (v *PluginValidator) GetValidationType() string {
+	return "plugin:" + v.config.Description.Name
+}
+
+// ValidateCluster sends the resources the plugin validates to the plugin and records
+// its findings. A plugin that fails is reported as a finding rather than failing the
+// scan, so a broken plugin cannot hide the results of the native validators.
+func (v *PluginValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	var kinds []schema.GroupVersionKind
+	for _, resource := range v.config.Description.Resources {
+		gv, _ := schema.ParseGroupVersion(resource.APIVersion)
+		kinds = append(kinds, gv.WithKind(resource.Kind))
+	}
+
+	objects, err := listUnstructuredByKind(ctx, v.client, v.log, v.sharedConfig, kinds)
+	if err != nil {
+		return err
+	}
+
+	request := PluginRequest{APIVersion: PluginProtocolVersion, Resources: []map[string]interface{}{}}
+	for _, gvk := range kinds {
+		for _, obj := range objects[gvk] {
+			request.Resources = append(request.Resources, obj.Object)
+		}
+	}
+
+	var allErrors []ValidationError
+	findings, err := v.runValidate(ctx, request)
+	if err != nil {
+		v.log.Error(err, "plugin failed")
+		errorCode := GetPluginErrorCode("plugin_failed")
+		allErrors = append(allErrors, NewValidationErrorWithCode("Plugin", v.config.Description.Name, "", "plugin_failed", errorCode,
+			fmt.Sprintf("Validator plugin '%s' failed: %v", v.config.Description.Name, err)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Run '%s validate' with a request on stdin to reproduce the failure", v.config.Path)).
+			WithDetail("plugin", v.config.Description.Name))
+	} else {
+		allErrors = findings
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "plugin", allErrors)
+
+	v.log.Info("validation completed", "validator_type", v.GetValidationType(), "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// runValidate invokes the plugin's validate command and namespaces its findings
+func (v *PluginValidator) runValidate(ctx context.Context, request PluginRequest) ([]ValidationError, error) {
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+
+	output, err := runPlugin(ctx, v.config.Path, "validate", input, v.config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var response PluginResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if response.APIVersion != PluginProtocolVersion {
+		return nil, fmt.Errorf("unsupported response apiVersion %q, expected %q", response.APIVersion, PluginProtocolVersion)
+	}
+
+	findings := make([]ValidationError, 0, len(response.Errors))
+	for _, ve := range response.Errors {
+		if ve.ResourceType == "" || ve.ResourceName == "" || ve.ValidationType == "" || ve.Message == "" {
+			return nil, fmt.Errorf("finding is missing resource_type, resource_name, validation_type or message: %+v", ve)
+		}
+		switch ve.Severity {
+		case "":
+			ve.Severity = SeverityError
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			return nil, fmt.Errorf("finding for %s/%s has unknown severity %q", ve.ResourceType, ve.ResourceName, ve.Severity)
+		}
+
+		ve.ErrorCode = namespacePluginErrorCode(v.config.Description.ErrorCodePrefix, ve.ErrorCode)
+		ve.SourceFile = ""
+		ve.SourceLine = 0
+		findings = append(findings, ve.WithDetail("plugin", v.config.Description.Name))
+	}
+	return findings, nil
+}
+
+// namespacePluginErrorCode places a plugin's error code under the KOGARO-PLG category
+func namespacePluginErrorCode(prefix, code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if !pluginErrorCodePattern.MatchString(code) {
+		code = "UNKNOWN"
+	}
+	return fmt.Sprintf("KOGARO-PLG-%s-%s", prefix, code)
+}
+
+// DescribePlugin runs a plugin's describe command and validates its description
+func DescribePlugin(ctx context.Context, path string, timeout time.Duration) (PluginDescription, error) {
+	var description PluginDescription
+
+	output, err := runPlugin(ctx, path, "describe", nil, timeout)
+	if err != nil {
+		return description, err
+	}
+	if err := json.Unmarshal(output, &description); err != nil {
+		return description, fmt.Errorf("invalid description: %w", err)
+	}
+
+	var problems []string
+	if description.APIVersion != PluginProtocolVersion {
+		problems = append(problems, fmt.Sprintf("apiVersion must be %q", PluginProtocolVersion))
+	}
+	if !pluginNamePattern.MatchString(description.Name) {
+		problems = append(problems, fmt.Sprintf("invalid name %q", description.Name))
+	}
+	if !pluginErrorCodePrefixPattern.MatchString(description.ErrorCodePrefix) {
+		problems = append(problems, fmt.Sprintf("invalid errorCodePrefix %q, expected 2-16 uppercase letters and digits", description.ErrorCodePrefix))
+	}
+	if len(description.Resources) == 0 {
+		problems = append(problems, "at least one resource is required")
+	}
+	for _, resource := range description.Resources {
+		if _, err := schema.ParseGroupVersion(resource.APIVersion); err != nil || resource.APIVersion == "" || resource.Kind == "" {
+			problems = append(problems, fmt.Sprintf("invalid resource %s/%s", resource.APIVersion, resource.Kind))
+		}
+	}
+	if len(problems) > 0 {
+		return description, fmt.Errorf("invalid description: %s", strings.Join(problems, "; "))
+	}
+
+	return description, nil
+}
+
+// DiscoverPlugins describes every executable file in a directory, in name order.
+// Hidden files and subdirectories are ignored.
+func DiscoverPlugins(ctx context.Context, dir string, timeout time.Duration) ([]PluginConfig, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var plugins []PluginConfig
+	names := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		description, err := DescribePlugin(ctx, path, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("plugin %s: %w", path, err)
+		}
+		if existing, ok := names[description.Name]; ok {
+			return nil, fmt.Errorf("plugin %s: name %q is already used by %s", path, description.Name, existing)
+		}
+		names[description.Name] = path
+
+		plugins = append(plugins, PluginConfig{Path: path, Timeout: timeout, Description: description})
+	}
+
+	return plugins, nil
+}
+
+// RegisterPlugins discovers the plugins in a directory and registers a validator for
+// each, returning the number of plugins registered
+func (r *ValidatorRegistry) RegisterPlugins(ctx context.Context, dir string, timeout time.Duration) (int, error) {
+	plugins, err := DiscoverPlugins(ctx, dir, timeout)
+	if err != nil {
+		return 0, err
+	}
+	for _, plugin := range plugins {
+		r.Register(NewPluginValidator(r.client, r.log, plugin))
+	}
+	return len(plugins), nil
+}
+
+// runPlugin executes a plugin command with optional stdin and returns its stdout.
+// Stderr is included in the error when the plugin fails.
+func runPlugin(ctx context.Context, path, command string, input []byte, timeout time.Duration) ([]byte, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, command) // nolint:gosec // Plugins are installed by the cluster operator
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out after %s", command, timeout)
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("%s failed: %w: %s", command, err, message)
+		}
+		return nil, fmt.Errorf("%s failed: %w", command, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// labelPluginScript describes itself and reports every Deployment named "unlabelled"
+const labelPluginScript = `#!/bin/sh
+case "$1" in
+describe)
+  echo '{"apiVersion":"kogaro.io/plugin/v1","name":"acme-labels","errorCodePrefix":"ACME","resources":[{"apiVersion":"apps/v1","kind":"Deployment"}]}'
+  ;;
+validate)
+  input=$(cat)
+  case "$input" in
+  *'"name":"unlabelled"'*)
+    echo '{"apiVersion":"kogaro.io/plugin/v1","errors":[{"resource_type":"Deployment","resource_name":"unlabelled","namespace":"team-a","validation_type":"missing_team_label","error_code":"001","message":"Deployment has no team label","severity":"warning"}]}'
+    ;;
+  *)
+    echo '{"apiVersion":"kogaro.io/plugin/v1","errors":[]}'
+    ;;
+  esac
+  ;;
+esac
+`
+
+func writePlugin(t *testing.T, dir, name, script string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil { // nolint:gosec // Test plugin must be executable
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme-labels", labelPluginScript)
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	plugins, err := DiscoverPlugins(context.Background(), dir, 5*time.Second)
+	if err != nil {
+		t.Fatalf("DiscoverPlugins() error = %v", err)
+	}
+	if len(plugins) != 1 || plugins[0].Description.Name != "acme-labels" || plugins[0].Description.ErrorCodePrefix != "ACME" {
+		t.Fatalf("unexpected plugins: %+v", plugins)
+	}
+
+	writePlugin(t, dir, "zz-duplicate", labelPluginScript)
+	if _, err := DiscoverPlugins(context.Background(), dir, 5*time.Second); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Errorf("expected duplicate plugin names to be rejected, got %v", err)
+	}
+
+	invalid := writePlugin(t, t.TempDir(), "invalid", `#!/bin/sh
+echo '{"apiVersion":"kogaro.io/plugin/v1","name":"Bad Name","errorCodePrefix":"kogaro"}'
+`)
+	_, err = DescribePlugin(context.Background(), invalid, 5*time.Second)
+	if err == nil {
+		t.Fatal("expected an invalid description to be rejected")
+	}
+	for _, want := range []string{`invalid name "Bad Name"`, `invalid errorCodePrefix "kogaro"`, "at least one resource is required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error to contain %q, got %v", want, err)
+		}
+	}
+}
+
+func TestPluginValidator_ValidateCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "unlabelled", Namespace: "team-a"}},
+			&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "labelled", Namespace: "team-a", Labels: map[string]string{"team": "a"}}},
+		).
+		Build()
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "acme-labels", labelPluginScript)
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	count, err := registry.RegisterPlugins(context.Background(), dir, 5*time.Second)
+	if err != nil || count != 1 {
+		t.Fatalf("RegisterPlugins() = %d, %v", count, err)
+	}
+	if got := registry.GetValidators()[0].GetValidationType(); got != "plugin:acme-labels" {
+		t.Errorf("GetValidationType() = %q", got)
+	}
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	result := registry.LastValidationResult()
+	if len(result.Errors) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", result.Errors)
+	}
+	finding := result.Errors[0]
+	if finding.ErrorCode != "KOGARO-PLG-ACME-001" || finding.Severity != SeverityWarning || finding.ResourceName != "unlabelled" {
+		t.Errorf("unexpected finding: %+v", finding)
+	}
+	if finding.Details["plugin"] != "acme-labels" {
+		t.Errorf("expected finding to record its plugin, got %v", finding.Details)
+	}
+}
+
+func TestPluginValidator_ReportsFailures(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		timeout time.Duration
+		want    string
+	}{
+		{
+			name:   "non-zero exit",
+			script: "#!/bin/sh\necho 'cannot reach policy server' >&2\nexit 3\n",
+			want:   "cannot reach policy server",
+		},
+		{
+			name:   "invalid response",
+			script: "#!/bin/sh\ncat > /dev/null\necho 'not json'\n",
+			want:   "invalid response",
+		},
+		{
+			name:   "wrong protocol version",
+			script: "#!/bin/sh\ncat > /dev/null\necho '{\"apiVersion\":\"v0\",\"errors\":[]}'\n",
+			want:   "unsupported response apiVersion",
+		},
+		{
+			name:    "timeout",
+			script:  "#!/bin/sh\nexec sleep 5\n",
+			timeout: 100 * time.Millisecond,
+			want:    "timed out",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = appsv1.AddToScheme(scheme)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+			validator := NewPluginValidator(fakeClient, logr.Discard(), PluginConfig{
+				Path:    writePlugin(t, t.TempDir(), "plugin", tt.script),
+				Timeout: tt.timeout,
+				Description: PluginDescription{
+					APIVersion:      PluginProtocolVersion,
+					Name:            "broken",
+					ErrorCodePrefix: "BRK",
+					Resources:       []PluginResource{{APIVersion: "apps/v1", Kind: "Deployment"}},
+				},
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != 1 || errors[0].ErrorCode != "KOGARO-PLG-001" {
+				t.Fatalf("expected a plugin_failed finding, got %+v", errors)
+			}
+			if !strings.Contains(errors[0].Message, tt.want) {
+				t.Errorf("expected message to contain %q, got %q", tt.want, errors[0].Message)
+			}
+		})
+	}
+}
+
+func TestNamespacePluginErrorCode(t *testing.T) {
+	tests := map[string]string{
+		"001":        "KOGARO-PLG-ACME-001",
+		" lbl1 ":     "KOGARO-PLG-ACME-LBL1",
+		"":           "KOGARO-PLG-ACME-UNKNOWN",
+		"KOGARO-001": "KOGARO-PLG-ACME-UNKNOWN",
+	}
+	for code, want := range tests {
+		if got := namespacePluginErrorCode("ACME", code); got != want {
+			t.Errorf("namespacePluginErrorCode(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ValidationPolicyGVK identifies the ValidationPolicy custom resource
+var ValidationPolicyGVK = schema.GroupVersionKind{Group: "kogaro.io", Version: "v1alpha1", Kind: "ValidationPolicy"}
+
+// severityOverrideKeyPattern matches an error code, or a code prefix ending in "*"
+var severityOverrideKeyPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]*\*?$`)
+
+// ValidationPolicy tunes how Kogaro classifies resources and reports findings. It is
+// read from the cluster as a kogaro.io/v1alpha1 ValidationPolicy resource, or from a
+// file with the same content.
+type ValidationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ValidationPolicySpec `json:"spec"`
+}
+
+// ValidationPolicySpec holds the settings of a ValidationPolicy
+type ValidationPolicySpec struct {
+	// SeverityOverrides maps error codes to the severity their findings are reported
+	// with. A key ending in "*" matches every code with that prefix.
+	SeverityOverrides map[string]Severity `json:"severityOverrides,omitempty"`
+
+	// SharedConfig extends the system namespaces, dangerous roles and pod patterns
+	// shared by all validators
+	SharedConfig *SharedConfigOverrides `json:"sharedConfig,omitempty"`
+
+	// Profiles defines validation profiles, or replaces the built-in strict, standard
+	// and relaxed profiles of the same name
+	Profiles map[string]ValidationProfile `json:"profiles,omitempty"`
+
+	// NamespaceProfiles binds namespaces to profiles by name. Bindings take precedence
+	// over the kogaro.io/profile namespace label.
+	NamespaceProfiles map[string]string `json:"namespaceProfiles,omitempty"`
+
+	// DefaultProfile is the profile of namespaces without a binding or label; by
+	// default such namespaces report every finding
+	DefaultProfile string `json:"defaultProfile,omitempty"`
+
+	// Teams maps team names to the namespaces they own and the notification channels
+	// their findings are routed to. Mappings take precedence over the kogaro.io/team
+	// namespace label.
+	Teams map[string]TeamMapping `json:"teams,omitempty"`
+
+	// FlapSuppression maps error codes to the number of consecutive cluster scans
+	// that must find their findings before they are reported, and miss them before
+	// they are resolved. A key ending in "*" matches every code with that prefix.
+	FlapSuppression map[string]FlapSuppression `json:"flapSuppression,omitempty"`
+
+	// Snoozes suppress the findings of error codes until a date, after which they
+	// are reported again
+	Snoozes []Snooze `json:"snoozes,omitempty"`
+
+	// ScoreWeights maps error codes to the weight the penalty of their findings is
+	// multiplied by in hygiene scores, replacing the weight of their category. A key
+	// ending in "*" matches every code with that prefix, such as KOGARO-SEC-* for
+	// all security findings.
+	ScoreWeights map[string]float64 `json:"scoreWeights,omitempty"`
+}
+
+// ParseValidationPolicy parses a ValidationPolicy manifest, rejecting unknown fields
+func ParseValidationPolicy(data []byte) (ValidationPolicy, error) {
+	var policy ValidationPolicy
+	if err := yaml.UnmarshalStrict(data, &policy); err != nil {
+		return policy, fmt.Errorf("failed to parse validation policy: %w", err)
+	}
+	if gvk := policy.GroupVersionKind(); gvk != ValidationPolicyGVK {
+		return policy, fmt.Errorf("expected apiVersion %s and kind %s, got %q and %q",
+			ValidationPolicyGVK.GroupVersion(), ValidationPolicyGVK.Kind, policy.APIVersion, policy.Kind)
+	}
+	return policy, nil
+}
+
+// ListValidationPolicies returns the ValidationPolicy resources in the cluster sorted
+// by name. It returns no policies when the ValidationPolicy CRD is not installed.
+func ListValidationPolicies(ctx context.Context, reader client.Reader) ([]ValidationPolicy, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(ValidationPolicyGVK.GroupVersion().WithKind(ValidationPolicyGVK.Kind + "List"))
+	if err := reader.List(ctx, list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list validation policies: %w", err)
+	}
+
+	policies := make([]ValidationPolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		var policy ValidationPolicy
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &policy); err != nil {
+			return nil, fmt.Errorf("failed to decode validation policy %s: %w", item.GetName(), err)
+		}
+		policies = append(policies, policy)
+	}
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+
+	return policies, nil
+}
+
+// NewSharedConfig applies the shared configuration overrides of one or more policies to
+// DefaultSharedConfig, in order
+func NewSharedConfig(policies ...ValidationPolicy) SharedConfig {
+	config := DefaultSharedConfig()
+	for _, policy := range policies {
+		if policy.Spec.SharedConfig != nil {
+			config = config.WithOverrides(*policy.Spec.SharedConfig)
+		}
+	}
+	return config
+}
+
+// SeverityPolicy remaps the severity of findings by error code. Exact codes take
+// precedence over prefixes, and longer prefixes over shorter ones.
+type SeverityPolicy struct {
+	exact    map[string]Severity
+	prefixes []severityPrefix
+}
+
+// severityPrefix is a severity override that applies to every code with a prefix
+type severityPrefix struct {
+	prefix   string
+	severity Severity
+}
+
+// NewSeverityPolicy builds a SeverityPolicy from the severity overrides of one or more
+// policies. Later policies take precedence when they override the same code.
+func NewSeverityPolicy(policies ...ValidationPolicy) (*SeverityPolicy, error) {
+	overrides := make(map[string]Severity)
+	var problems []string
+
+	for _, policy := range policies {
+		for key, severity := range policy.Spec.SeverityOverrides {
+			code := strings.ToUpper(strings.TrimSpace(key))
+			if !severityOverrideKeyPattern.MatchString(code) {
+				problems = append(problems, fmt.Sprintf("policy %q: invalid error code %q", policy.Name, key))
+				continue
+			}
+			switch severity {
+			case SeverityError, SeverityWarning, SeverityInfo:
+			default:
+				problems = append(problems, fmt.Sprintf("policy %q: %s has unknown severity %q, expected error, warning or info", policy.Name, key, severity))
+				continue
+			}
+			overrides[code] = severity
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid severity overrides: %s", strings.Join(problems, "; "))
+	}
+
+	severityPolicy := &SeverityPolicy{exact: make(map[string]Severity)}
+	for code, severity := range overrides {
+		if prefix, ok := strings.CutSuffix(code, "*"); ok {
+			severityPolicy.prefixes = append(severityPolicy.prefixes, severityPrefix{prefix: prefix, severity: severity})
+		} else {
+			severityPolicy.exact[code] = severity
+		}
+	}
+	sort.Slice(severityPolicy.prefixes, func(i, j int) bool {
+		return len(severityPolicy.prefixes[i].prefix) > len(severityPolicy.prefixes[j].prefix)
+	})
+
+	return severityPolicy, nil
+}
+
+// Len returns the number of severity overrides in the policy
+func (p *SeverityPolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.exact) + len(p.prefixes)
+}
+
+// Resolve returns the severity a finding with the given error code is reported with
+func (p *SeverityPolicy) Resolve(errorCode string, severity Severity) Severity {
+	if p == nil || errorCode == "" {
+		return severity
+	}
+	if override, ok := p.exact[errorCode]; ok {
+		return override
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(errorCode, prefix.prefix) {
+			return prefix.severity
+		}
+	}
+	return severity
+}
+
+// Apply returns the findings with the severities the policy reports them with. The
+// findings are returned unchanged when the policy has no overrides.
+func (p *SeverityPolicy) Apply(findings []ValidationError) []ValidationError {
+	if p.Len() == 0 {
+		return findings
+	}
+	resolved := make([]ValidationError, len(findings))
+	for i, finding := range findings {
+		finding.Severity = p.Resolve(finding.ErrorCode, finding.Severity)
+		resolved[i] = finding
+	}
+	return resolved
+}
+
+// wrap returns a log receiver that reports findings with the policy's severities
+func (p *SeverityPolicy) wrap(receiver LogReceiver) LogReceiver {
+	if p.Len() == 0 {
+		return receiver
+	}
+	return &severityLogReceiver{LogReceiver: receiver, policy: p}
+}
+
+// severityResolver is implemented by log receivers that override the severities of
+// findings, so that profiles filter and metrics record the overridden severities
+type severityResolver interface {
+	ResolveSeverities(errors []ValidationError) []ValidationError
+}
+
+// severityLogReceiver forwards findings with the severities of its policy
+type severityLogReceiver struct {
+	LogReceiver
+	policy *SeverityPolicy
+}
+
+// ResolveSeverities returns the findings with the policy's severities
+func (s *severityLogReceiver) ResolveSeverities(errors []ValidationError) []ValidationError {
+	return s.policy.Apply(errors)
+}
+
+// LogValidationError forwards a finding with the policy's severity
+func (s *severityLogReceiver) LogValidationError(validatorType string, validationError ValidationError) {
+	validationError.Severity = s.policy.Resolve(validationError.ErrorCode, validationError.Severity)
+	s.LogReceiver.LogValidationError(validatorType, validationError)
+}
+
+// Reports returns whether the wrapped receiver reports a finding
+func (s *severityLogReceiver) Reports(validationError ValidationError) bool {
+	if filter, ok := s.LogReceiver.(findingFilter); ok {
+		return filter.Reports(validationError)
+	}
+	return true
+}
+
+// Damp returns the findings the wrapped receiver reports while they flap
+func (s *severityLogReceiver) Damp(errors []ValidationError) []ValidationError {
+	if damper, ok := s.LogReceiver.(findingDamper); ok {
+		return damper.Damp(errors)
+	}
+	return errors
+}
+
+// Cluster returns the cluster of the wrapped receiver
+func (s *severityLogReceiver) Cluster() string {
+	if scoped, ok := s.LogReceiver.(clusterScoped); ok {
+		return scoped.Cluster()
+	}
+	return ""
+}
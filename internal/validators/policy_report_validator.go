@@ -0,0 +1,246 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides PolicyReport ingestion functionality.
+//
+// This package merges the results of policy engines such as Kyverno into Kogaro's
+// findings. Engines publish their results as wgpolicyk8s.io PolicyReport and
+// ClusterPolicyReport resources; each failed rule, warning or evaluation error is
+// reported as a finding on the resource it applies to, so that teams get one
+// consolidated hygiene report.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+var (
+	// PolicyReportGVK is the kind policy engines publish namespaced results as
+	PolicyReportGVK = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "PolicyReport"}
+	// ClusterPolicyReportGVK is the kind policy engines publish results for cluster-scoped
+	// resources as
+	ClusterPolicyReportGVK = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "ClusterPolicyReport"}
+)
+
+// PolicyReportSource is the source of the results Kogaro writes to PolicyReports with
+// --enable-policy-reports. They are not read back as findings.
+const PolicyReportSource = "kogaro"
+
+// policyReportValidationTypes maps the results of a PolicyReport that are reported as
+// findings to their validation types. Passed and skipped rules are only counted.
+var policyReportValidationTypes = map[string]string{
+	"fail":  "policy_violation",
+	"warn":  "policy_warning",
+	"error": "policy_evaluation_error",
+}
+
+// policyReportSeverities maps the severity a policy declares to a finding's severity
+var policyReportSeverities = map[string]Severity{
+	"critical": SeverityError,
+	"high":     SeverityError,
+	"medium":   SeverityWarning,
+	"low":      SeverityInfo,
+	"info":     SeverityInfo,
+}
+
+// PolicyReportValidator reports the failed rules recorded in PolicyReports
+type PolicyReportValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// NewPolicyReportValidator creates a new PolicyReportValidator with the given client and logger
+func NewPolicyReportValidator(client client.Client, log logr.Logger) *PolicyReportValidator {
+	return &PolicyReportValidator{
+		client:       client,
+		log:          log.WithName("policy-report-validator"),
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *PolicyReportValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *PolicyReportValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *PolicyReportValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for PolicyReport ingestion
+func (v *PolicyReportValidator) GetValidationType() string {
+	return "policy_report_validation"
+}
+
+// ValidateCluster reads the PolicyReports and ClusterPolicyReports of the cluster and
+// reports their failed rules, warnings and evaluation errors. Clusters without a policy
+// engine installed have no reports, and nothing is reported.
+func (v *PolicyReportValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	kinds := []schema.GroupVersionKind{PolicyReportGVK, ClusterPolicyReportGVK}
+	reports, err := listUnstructuredByKind(ctx, v.client, v.log, v.sharedConfig, kinds)
+	if err != nil {
+		return err
+	}
+
+	var allErrors []ValidationError
+	// Results are counted by engine and result, whether they are reported or not
+	counts := make(map[string]map[string]int)
+	for _, gvk := range kinds {
+		for i := range reports[gvk] {
+			findings, reportCounts := v.validatePolicyReport(&reports[gvk][i])
+			allErrors = append(allErrors, findings...)
+			for source, results := range reportCounts {
+				if counts[source] == nil {
+					counts[source] = make(map[string]int)
+				}
+				for result, count := range results {
+					counts[source][result] += count
+				}
+			}
+		}
+	}
+
+	cluster := ""
+	if scoped, ok := v.logReceiver.(clusterScoped); ok {
+		cluster = scoped.Cluster()
+	}
+	metrics.RecordPolicyReportResults(cluster, counts)
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "policy_report", allErrors)
+
+	v.log.Info("validation completed", "validator_type", v.GetValidationType(), "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// validatePolicyReport reports the results of a report that are not passed or skipped,
+// once for each resource they apply to, and counts its results by engine and result
+func (v *PolicyReportValidator) validatePolicyReport(report *unstructured.Unstructured) ([]ValidationError, map[string]map[string]int) {
+	results, _, _ := unstructured.NestedSlice(report.Object, "results")
+	scope, _, _ := unstructured.NestedMap(report.Object, "scope")
+
+	var findings []ValidationError
+	counts := make(map[string]map[string]int)
+	for _, item := range results {
+		result, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		outcome := strings.ToLower(nestedString(result, "result"))
+		source := nestedString(result, "source")
+		if source == PolicyReportSource {
+			continue
+		}
+		if source == "" {
+			source = "unknown"
+		}
+		if counts[source] == nil {
+			counts[source] = make(map[string]int)
+		}
+		counts[source][outcome]++
+
+		validationType, reported := policyReportValidationTypes[outcome]
+		if !reported {
+			continue
+		}
+
+		// Results name the resources they apply to, or apply to the scope of a
+		// per-resource report
+		resources, _, _ := unstructured.NestedSlice(result, "resources")
+		if len(resources) == 0 && scope != nil {
+			resources = []interface{}{scope}
+		}
+		for _, resource := range resources {
+			ref, ok := resource.(map[string]interface{})
+			if !ok || nestedString(ref, "kind") == "" || nestedString(ref, "name") == "" {
+				continue
+			}
+			namespace := nestedString(ref, "namespace")
+			if namespace == "" && nestedString(ref, "kind") != "Namespace" {
+				namespace = report.GetNamespace()
+			}
+			if v.sharedConfig.IsSystemNamespace(namespace) {
+				continue
+			}
+			findings = append(findings, newPolicyReportFinding(report, result, validationType, source, nestedString(ref, "kind"), nestedString(ref, "name"), namespace))
+		}
+	}
+	return findings, counts
+}
+
+// newPolicyReportFinding builds the finding for a result of a report on one resource.
+// The policy's declared severity is used for failed rules; warnings and evaluation
+// errors keep the severity of their error code.
+func newPolicyReportFinding(report *unstructured.Unstructured, result map[string]interface{}, validationType, source, kind, name, namespace string) ValidationError {
+	policy := nestedString(result, "policy")
+	rule := nestedString(result, "rule")
+	errorCode := GetPolicyReportErrorCode(validationType)
+	info, _ := LookupErrorCode(errorCode)
+
+	severity := info.Severity
+	if declared, ok := policyReportSeverities[strings.ToLower(nestedString(result, "severity"))]; ok && validationType == "policy_violation" {
+		severity = declared
+	}
+
+	var message string
+	switch validationType {
+	case "policy_violation":
+		message = fmt.Sprintf("%s policy '%s' rule '%s' failed", source, policy, rule)
+	case "policy_warning":
+		message = fmt.Sprintf("%s policy '%s' rule '%s' warned", source, policy, rule)
+	default:
+		message = fmt.Sprintf("%s could not evaluate policy '%s' rule '%s'", source, policy, rule)
+	}
+	if detail := nestedString(result, "message"); detail != "" {
+		message += ": " + detail
+	}
+
+	reportName := report.GetName()
+	if report.GetNamespace() != "" {
+		reportName = report.GetNamespace() + "/" + reportName
+	}
+	finding := NewValidationErrorWithCode(kind, name, namespace, validationType, errorCode, message).
+		WithSeverity(severity).
+		WithRemediationHint(fmt.Sprintf("Change the %s to satisfy rule '%s' of %s policy '%s', or add a policy exception", kind, rule, source, policy)).
+		WithDetail("source", source).
+		WithDetail("policy", policy).
+		WithDetail("report", report.GetKind()+" "+reportName)
+	if rule != "" {
+		finding = finding.WithDetail("rule", rule)
+	}
+	if category := nestedString(result, "category"); category != "" {
+		finding = finding.WithDetail("category", category)
+	}
+	return finding
+}
+
+// nestedString returns a string field of an unstructured map, or "" if it is unset
+func nestedString(obj map[string]interface{}, fields ...string) string {
+	value, _, _ := unstructured.NestedString(obj, fields...)
+	return value
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// policyReport builds a PolicyReport, or a ClusterPolicyReport without a namespace
+func policyReport(namespace, name string, scope map[string]interface{}, results ...interface{}) *unstructured.Unstructured {
+	report := &unstructured.Unstructured{Object: map[string]interface{}{"results": results}}
+	if namespace == "" {
+		report.SetGroupVersionKind(ClusterPolicyReportGVK)
+	} else {
+		report.SetGroupVersionKind(PolicyReportGVK)
+		report.SetNamespace(namespace)
+	}
+	report.SetName(name)
+	if scope != nil {
+		report.Object["scope"] = scope
+	}
+	return report
+}
+
+// policyResult builds a result of a PolicyReport, applying to resources when given
+func policyResult(result, policy, rule, severity, message string, resources ...interface{}) map[string]interface{} {
+	item := map[string]interface{}{
+		"source":   "kyverno",
+		"policy":   policy,
+		"rule":     rule,
+		"result":   result,
+		"severity": severity,
+		"message":  message,
+	}
+	if len(resources) > 0 {
+		item["resources"] = resources
+	}
+	return item
+}
+
+func TestPolicyReportValidator_ValidateCluster(t *testing.T) {
+	deployment := map[string]interface{}{"apiVersion": "apps/v1", "kind": "Deployment", "name": "web", "namespace": "shop"}
+	// Kogaro's own results, published with --enable-policy-reports, are not read back
+	own := policyResult("fail", "KOGARO-REF-003", "dangling_configmap_volume", "high", "ConfigMap 'app' referenced in volume does not exist",
+		map[string]interface{}{"kind": "Pod", "name": "api-1", "namespace": "shop"})
+	own["source"] = PolicyReportSource
+	fakeClient := fake.NewClientBuilder().WithScheme(runtime.NewScheme()).WithObjects(
+		// A per-resource report, whose results apply to its scope
+		policyReport("shop", "web-report", deployment,
+			policyResult("pass", "require-requests", "check-requests", "medium", ""),
+			policyResult("fail", "require-labels", "check-team", "high", "validation error: label 'team' is required"),
+			policyResult("fail", "disallow-latest-tag", "validate-image-tag", "low", "using a mutable image tag is not allowed"),
+			policyResult("skip", "require-probes", "check-probes", "medium", ""),
+		),
+		// A namespace report, whose results name the resources they apply to
+		policyReport("shop", "polr-ns-shop", nil,
+			policyResult("warn", "restrict-ports", "check-ports", "", "port 22 is discouraged",
+				map[string]interface{}{"kind": "Service", "name": "ssh"}),
+			policyResult("error", "check-registry", "allowed-registries", "", "failed to load context",
+				map[string]interface{}{"kind": "Pod", "name": "api-1", "namespace": "shop"}),
+		),
+		policyReport("shop", "kogaro", nil, own),
+		policyReport("", "cpol-require-ns-labels", nil,
+			policyResult("fail", "require-ns-labels", "check-owner", "", "label 'owner' is required",
+				map[string]interface{}{"kind": "Namespace", "name": "shop"})),
+		// Results in system namespaces are left to their operators
+		policyReport("kube-system", "polr-ns-kube-system", nil,
+			policyResult("fail", "require-labels", "check-team", "high", "",
+				map[string]interface{}{"kind": "Pod", "name": "coredns", "namespace": "kube-system"})),
+	).Build()
+
+	validator := NewPolicyReportValidator(fakeClient, logr.Discard())
+	logReceiver := &MockLogReceiver{}
+	validator.SetLogReceiver(logReceiver)
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	type want struct {
+		code     string
+		severity Severity
+		message  string
+	}
+	wants := map[string]want{
+		"Deployment/shop/web/require-labels": {"KOGARO-POL-001", SeverityError,
+			"kyverno policy 'require-labels' rule 'check-team' failed: validation error: label 'team' is required"},
+		"Deployment/shop/web/disallow-latest-tag": {"KOGARO-POL-001", SeverityInfo,
+			"kyverno policy 'disallow-latest-tag' rule 'validate-image-tag' failed: using a mutable image tag is not allowed"},
+		"Service/shop/ssh/restrict-ports": {"KOGARO-POL-002", SeverityWarning,
+			"kyverno policy 'restrict-ports' rule 'check-ports' warned: port 22 is discouraged"},
+		"Pod/shop/api-1/check-registry": {"KOGARO-POL-003", SeverityWarning,
+			"kyverno could not evaluate policy 'check-registry' rule 'allowed-registries': failed to load context"},
+		"Namespace//shop/require-ns-labels": {"KOGARO-POL-001", SeverityError,
+			"kyverno policy 'require-ns-labels' rule 'check-owner' failed: label 'owner' is required"},
+	}
+
+	findings := validator.GetLastValidationErrors()
+	if len(findings) != len(wants) {
+		t.Errorf("got %d findings, want %d: %+v", len(findings), len(wants), findings)
+	}
+	for _, finding := range findings {
+		key := finding.ResourceType + "/" + finding.Namespace + "/" + finding.ResourceName + "/" + finding.Details["policy"]
+		expected, ok := wants[key]
+		if !ok {
+			t.Errorf("unexpected finding %s: %+v", key, finding)
+			continue
+		}
+		if finding.ErrorCode != expected.code || finding.Severity != expected.severity || finding.Message != expected.message {
+			t.Errorf("%s = %s %s %q, want %s %s %q", key, finding.ErrorCode, finding.Severity, finding.Message, expected.code, expected.severity, expected.message)
+		}
+		if finding.Details["source"] != "kyverno" || finding.Details["report"] == "" {
+			t.Errorf("%s details = %v, want the source and report", key, finding.Details)
+		}
+	}
+	if len(logReceiver.LoggedErrors) != len(findings) {
+		t.Errorf("logged %d findings, want %d", len(logReceiver.LoggedErrors), len(findings))
+	}
+
+	// Passed and skipped results are counted too, but not reports in system namespaces
+	for result, count := range map[string]float64{"pass": 1, "fail": 3, "warn": 1, "error": 1, "skip": 1} {
+		if got := testutil.ToFloat64(metrics.PolicyReportResults.WithLabelValues("kyverno", result, "")); got != count {
+			t.Errorf("kogaro_policy_report_results{result=%q} = %v, want %v", result, got, count)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testValidationPolicy = `apiVersion: kogaro.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: platform
+spec:
+  severityOverrides:
+    KOGARO-SEC-005: warning
+    KOGARO-RES-*: info
+    KOGARO-RES-002: error
+`
+
+func TestParseValidationPolicy(t *testing.T) {
+	policy, err := ParseValidationPolicy([]byte(testValidationPolicy))
+	if err != nil {
+		t.Fatalf("ParseValidationPolicy() error = %v", err)
+	}
+	if policy.Name != "platform" {
+		t.Errorf("Name = %q, want platform", policy.Name)
+	}
+	if got := policy.Spec.SeverityOverrides["KOGARO-SEC-005"]; got != SeverityWarning {
+		t.Errorf("KOGARO-SEC-005 override = %q, want warning", got)
+	}
+
+	invalid := map[string]string{
+		"wrong kind":    "apiVersion: kogaro.io/v1alpha1\nkind: ValidationReport\nspec: {}\n",
+		"unknown field": "apiVersion: kogaro.io/v1alpha1\nkind: ValidationPolicy\nspec:\n  severities: {}\n",
+	}
+	for name, data := range invalid {
+		if _, err := ParseValidationPolicy([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSeverityPolicy_Resolve(t *testing.T) {
+	policy, err := ParseValidationPolicy([]byte(testValidationPolicy))
+	if err != nil {
+		t.Fatalf("ParseValidationPolicy() error = %v", err)
+	}
+	override := ValidationPolicy{Spec: ValidationPolicySpec{SeverityOverrides: map[string]Severity{
+		"kogaro-sec-005": SeverityInfo,
+	}}}
+
+	severityPolicy, err := NewSeverityPolicy(policy, override)
+	if err != nil {
+		t.Fatalf("NewSeverityPolicy() error = %v", err)
+	}
+	if severityPolicy.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", severityPolicy.Len())
+	}
+
+	tests := []struct {
+		code     string
+		severity Severity
+		want     Severity
+	}{
+		{"KOGARO-SEC-005", SeverityError, SeverityInfo},      // later policy wins
+		{"KOGARO-RES-001", SeverityWarning, SeverityInfo},    // prefix
+		{"KOGARO-RES-002", SeverityWarning, SeverityError},   // exact beats prefix
+		{"KOGARO-REF-001", SeverityWarning, SeverityWarning}, // no override
+		{"", SeverityWarning, SeverityWarning},
+	}
+	for _, tt := range tests {
+		if got := severityPolicy.Resolve(tt.code, tt.severity); got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %q, want %q", tt.code, tt.severity, got, tt.want)
+		}
+	}
+
+	var nilPolicy *SeverityPolicy
+	if got := nilPolicy.Resolve("KOGARO-SEC-005", SeverityError); got != SeverityError {
+		t.Errorf("nil policy Resolve() = %q, want error", got)
+	}
+}
+
+func TestNewSeverityPolicy_Invalid(t *testing.T) {
+	policy := ValidationPolicy{Spec: ValidationPolicySpec{SeverityOverrides: map[string]Severity{
+		"KOGARO SEC":     SeverityWarning,
+		"KOGARO-SEC-005": "critical",
+	}}}
+	policy.Name = "broken"
+
+	_, err := NewSeverityPolicy(policy)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{`invalid error code "KOGARO SEC"`, `unknown severity "critical"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestSeverityPolicy_AppliedToFindings(t *testing.T) {
+	severityPolicy, err := NewSeverityPolicy(ValidationPolicy{Spec: ValidationPolicySpec{SeverityOverrides: map[string]Severity{
+		"KOGARO-SEC-005": SeverityInfo,
+		"KOGARO-RES-001": SeverityWarning,
+	}}})
+	if err != nil {
+		t.Fatalf("NewSeverityPolicy() error = %v", err)
+	}
+
+	findings := severityPolicy.Apply([]ValidationError{
+		NewValidationErrorWithCode("Pod", "web", "team-a", "missing_read_only_root_filesystem", "KOGARO-SEC-005", "not read-only").
+			WithSeverity(SeverityError),
+		NewValidationErrorWithCode("Pod", "web", "team-a", "missing_resource_requests", "KOGARO-RES-001", "no requests"),
+	})
+	readOnly, limits := findings[0], findings[1]
+	if readOnly.Severity != SeverityInfo {
+		t.Errorf("KOGARO-SEC-005 severity = %q, want info", readOnly.Severity)
+	}
+	if limits.Severity != SeverityWarning {
+		t.Errorf("KOGARO-RES-001 severity = %q, want warning", limits.Severity)
+	}
+
+	strict := ExitCodePolicy{Strict: true}
+	if got := strict.ExitCode([]ValidationError{readOnly}); got != ExitCodeOK {
+		t.Errorf("exit code for info-only findings = %d, want %d", got, ExitCodeOK)
+	}
+	if got := strict.ExitCode([]ValidationError{readOnly, limits}); got != ExitCodeWarnings {
+		t.Errorf("exit code with a warning = %d, want %d", got, ExitCodeWarnings)
+	}
+
+	output, err := (&ValidatorRegistry{}).FormatCIOutput(ValidationResult{Errors: []ValidationError{readOnly}})
+	if err != nil {
+		t.Fatalf("FormatCIOutput() error = %v", err)
+	}
+	if !strings.Contains(output, "- [info] Pod/web: not read-only") {
+		t.Errorf("FormatCIOutput() does not show the overridden severity:\n%s", output)
+	}
+}
+
+func TestValidatorRegistry_KeepsPoliciesPerCluster(t *testing.T) {
+	severityPolicy, err := NewSeverityPolicy(ValidationPolicy{Spec: ValidationPolicySpec{SeverityOverrides: map[string]Severity{
+		"KOGARO-SEC-005": SeverityWarning,
+	}}})
+	if err != nil {
+		t.Fatalf("NewSeverityPolicy() error = %v", err)
+	}
+	scorePolicy, err := NewScorePolicy(ValidationPolicy{Spec: ValidationPolicySpec{ScoreWeights: map[string]float64{
+		"KOGARO-SEC-*": 5,
+	}}})
+	if err != nil {
+		t.Fatalf("NewScorePolicy() error = %v", err)
+	}
+
+	newRegistry := func(namespace string) *ValidatorRegistry {
+		registry := NewValidatorRegistry(logr.Discard(), nil)
+		registry.Register(&MockValidator{validationType: "security", lastValidationErrors: []ValidationError{
+			NewValidationErrorWithCode("Pod", "web", namespace, "missing_read_only_root_filesystem", "KOGARO-SEC-005", "not read-only"),
+		}})
+		return registry
+	}
+	// Only the first cluster's policy overrides severities and weights
+	eu, us := newRegistry("shop"), newRegistry("web")
+	eu.SetSeverityPolicy(severityPolicy)
+	eu.SetScorePolicy(scorePolicy)
+
+	var results []ValidationResult
+	for _, registry := range []*ValidatorRegistry{eu, us} {
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+		results = append(results, registry.LastValidationResult())
+	}
+	if got := results[0].Errors[0].Severity; got != SeverityWarning {
+		t.Errorf("first cluster severity = %q, want warning", got)
+	}
+	if got := results[1].Errors[0].Severity; got != SeverityError {
+		t.Errorf("second cluster severity = %q, want error", got)
+	}
+	// A warning weighed 5 takes 15 points, an error in its security category 20
+	if results[0].Summary.HygieneScore != 85 || results[1].Summary.HygieneScore != 80 {
+		t.Errorf("hygiene scores = %d and %d, want 85 and 80", results[0].Summary.HygieneScore, results[1].Summary.HygieneScore)
+	}
+
+	// Findings reported outside the validators take the registry's policies too
+	skipped := eu.NewResult([]ValidationError{NewValidationErrorWithCode("Pod", "web", "shop", "check_skipped", "KOGARO-SEC-005", "skipped")})
+	if skipped.Errors[0].Severity != SeverityWarning || skipped.ScorePolicy() != scorePolicy {
+		t.Errorf("NewResult() = %+v, want the first cluster's policies", skipped)
+	}
+
+	merged := MergeResults(results...)
+	if merged.Summary.NamespaceScores["shop"] != 85 || merged.Summary.NamespaceScores["web"] != 80 || merged.Summary.HygieneScore != 83 {
+		t.Errorf("merged scores = %d %v, want each finding weighed by its cluster's policy", merged.Summary.HygieneScore, merged.Summary.NamespaceScores)
+	}
+}
+
+func TestListValidationPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(ValidationPolicyGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(ValidationPolicyGVK.GroupVersion().WithKind("ValidationPolicyList"), &unstructured.UnstructuredList{})
+
+	newPolicy := func(name, code string) *unstructured.Unstructured {
+		policy := &unstructured.Unstructured{}
+		policy.SetGroupVersionKind(ValidationPolicyGVK)
+		policy.SetName(name)
+		_ = unstructured.SetNestedStringMap(policy.Object, map[string]string{code: "info"}, "spec", "severityOverrides")
+		return policy
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newPolicy("zz-team", "KOGARO-RES-001"), newPolicy("aa-platform", "KOGARO-SEC-005")).
+		Build()
+
+	policies, err := ListValidationPolicies(context.Background(), fakeClient)
+	if err != nil {
+		t.Fatalf("ListValidationPolicies() error = %v", err)
+	}
+	if len(policies) != 2 || policies[0].Name != "aa-platform" || policies[1].Name != "zz-team" {
+		t.Fatalf("ListValidationPolicies() = %+v, want aa-platform and zz-team", policies)
+	}
+	if got := policies[1].Spec.SeverityOverrides["KOGARO-RES-001"]; got != SeverityInfo {
+		t.Errorf("zz-team override = %q, want info", got)
+	}
+}
+
+func TestNewSharedConfig(t *testing.T) {
+	platform, err := ParseValidationPolicy([]byte(`apiVersion: kogaro.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: platform
+spec:
+  sharedConfig:
+    systemNamespaces: [platform-system, kube-system]
+    dangerousRoles: [break-glass]
+`))
+	if err != nil {
+		t.Fatalf("ParseValidationPolicy() error = %v", err)
+	}
+	strict, err := ParseValidationPolicy([]byte(`apiVersion: kogaro.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: strict
+spec:
+  sharedConfig:
+    replaceDefaults: true
+    securityExcludedNamespaces: [kube-system]
+`))
+	if err != nil {
+		t.Fatalf("ParseValidationPolicy() error = %v", err)
+	}
+
+	config := NewSharedConfig(platform, strict)
+
+	if !config.IsSystemNamespace("platform-system") || !config.IsSystemNamespace("kube-system") {
+		t.Error("expected platform-system to be added to the built-in system namespaces")
+	}
+	if len(config.SystemNamespaces) != len(DefaultSharedConfig().SystemNamespaces)+1 {
+		t.Errorf("SystemNamespaces = %v, want kube-system listed once", config.SystemNamespaces)
+	}
+	if !config.IsDangerousRole("break-glass") || !config.IsDangerousRole("cluster-admin") {
+		t.Error("expected break-glass to be added to the built-in dangerous roles")
+	}
+	if config.IsSecurityExcludedNamespace("monitoring") || !config.IsSecurityExcludedNamespace("kube-system") {
+		t.Errorf("SecurityExcludedNamespaces = %v, want only kube-system", config.SecurityExcludedNamespaces)
+	}
+	// Lists without entries keep their built-in values even when replacing defaults
+	if !config.IsNetworkingExcludedNamespace("monitoring") {
+		t.Error("expected the built-in networking exclusions to be kept")
+	}
+}
+
+func TestActiveSharedConfig(t *testing.T) {
+	config := DefaultSharedConfig().WithOverrides(SharedConfigOverrides{SystemNamespaces: []string{"platform-system"}})
+	SetSharedConfig(&config)
+	t.Cleanup(func() { SetSharedConfig(nil) })
+
+	validator := NewReferenceValidator(nil, logr.Discard(), ValidationConfig{})
+	if !validator.sharedConfig.IsSystemNamespace("platform-system") {
+		t.Error("expected validators to be created with the active shared configuration")
+	}
+
+	SetSharedConfig(nil)
+	if defaults := ActiveSharedConfig(); defaults.IsSystemNamespace("platform-system") {
+		t.Error("expected SetSharedConfig(nil) to restore the defaults")
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NamespaceProfileLabel binds a namespace to a validation profile by name
+const NamespaceProfileLabel = "kogaro.io/profile"
+
+// Built-in validation profiles
+const (
+	// ProfileStrict reports every finding, including QoS guarantees and capabilities not dropped
+	ProfileStrict = "strict"
+	// ProfileStandard reports every finding except QoS class issues and capabilities not dropped
+	ProfileStandard = "standard"
+	// ProfileRelaxed skips resource limit checks, capabilities not dropped and informational findings
+	ProfileRelaxed = "relaxed"
+)
+
+// ValidationProfile selects the findings reported for the namespaces bound to it
+type ValidationProfile struct {
+	// SkipErrorCodes lists error codes, or code prefixes ending in "*", that are not reported
+	SkipErrorCodes []string `json:"skipErrorCodes,omitempty"`
+	// SkipValidationTypes lists validation types that are not reported
+	SkipValidationTypes []string `json:"skipValidationTypes,omitempty"`
+	// MinSeverity is the least severe finding that is reported; empty reports all
+	MinSeverity Severity `json:"minSeverity,omitempty"`
+}
+
+// builtinProfiles returns the strict, standard and relaxed profiles
+func builtinProfiles() map[string]ValidationProfile {
+	return map[string]ValidationProfile{
+		ProfileStrict:   {},
+		ProfileStandard: {SkipValidationTypes: []string{"qos_class_issue", "container_capabilities_not_dropped"}},
+		ProfileRelaxed:  {SkipErrorCodes: []string{"KOGARO-RES-*"}, SkipValidationTypes: []string{"container_capabilities_not_dropped"}, MinSeverity: SeverityWarning},
+	}
+}
+
+// reports returns whether a finding is reported under the profile
+func (p ValidationProfile) reports(validationError ValidationError) bool {
+	if slices.Contains(p.SkipValidationTypes, validationError.ValidationType) {
+		return false
+	}
+	for _, code := range p.SkipErrorCodes {
+		if prefix, ok := strings.CutSuffix(code, "*"); ok {
+			if strings.HasPrefix(validationError.ErrorCode, prefix) {
+				return false
+			}
+		} else if validationError.ErrorCode == code {
+			return false
+		}
+	}
+	return severityRank(validationError.Severity) >= severityRank(p.MinSeverity)
+}
+
+// severityRank orders severities from info to error; unknown severities rank lowest
+func severityRank(severity Severity) int {
+	switch severity {
+	case SeverityError:
+		return 3
+	case SeverityWarning:
+		return 2
+	case SeverityInfo:
+		return 1
+	}
+	return 0
+}
+
+// ProfilePolicy binds validation profiles to namespaces. A namespace uses the profile
+// bound to it by a policy, else the profile named by its NamespaceProfileLabel, else
+// the default profile. Without a default profile, unbound namespaces report every finding.
+type ProfilePolicy struct {
+	profiles       map[string]ValidationProfile
+	namespaces     map[string]string
+	defaultProfile string
+}
+
+// NewProfilePolicy builds a ProfilePolicy from the profile settings of one or more
+// policies. Later policies take precedence when they define the same profile or
+// binding. Without policies, namespaces are bound to built-in profiles by label only.
+func NewProfilePolicy(policies ...ValidationPolicy) (*ProfilePolicy, error) {
+	profilePolicy := &ProfilePolicy{
+		profiles:   builtinProfiles(),
+		namespaces: make(map[string]string),
+	}
+	var problems []string
+
+	for _, policy := range policies {
+		for name, profile := range policy.Spec.Profiles {
+			for _, code := range profile.SkipErrorCodes {
+				if !severityOverrideKeyPattern.MatchString(code) {
+					problems = append(problems, fmt.Sprintf("policy %q: profile %q has invalid error code %q", policy.Name, name, code))
+				}
+			}
+			if profile.MinSeverity != "" && severityRank(profile.MinSeverity) == 0 {
+				problems = append(problems, fmt.Sprintf("policy %q: profile %q has unknown minSeverity %q, expected error, warning or info", policy.Name, name, profile.MinSeverity))
+			}
+			profilePolicy.profiles[name] = profile
+		}
+		for namespace, name := range policy.Spec.NamespaceProfiles {
+			profilePolicy.namespaces[namespace] = name
+		}
+		if policy.Spec.DefaultProfile != "" {
+			profilePolicy.defaultProfile = policy.Spec.DefaultProfile
+		}
+	}
+
+	for namespace, name := range profilePolicy.namespaces {
+		if _, exists := profilePolicy.profiles[name]; !exists {
+			problems = append(problems, fmt.Sprintf("namespace %q is bound to unknown profile %q", namespace, name))
+		}
+	}
+	if name := profilePolicy.defaultProfile; name != "" {
+		if _, exists := profilePolicy.profiles[name]; !exists {
+			problems = append(problems, fmt.Sprintf("default profile %q is unknown", name))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid validation profiles: %s", strings.Join(problems, "; "))
+	}
+
+	return profilePolicy, nil
+}
+
+// Len returns the number of namespaces bound to a profile by the policy
+func (p *ProfilePolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.namespaces)
+}
+
+// resolve reads the namespace labels of the cluster and returns the profile of each
+// namespace. A nil policy resolves to nil, which reports every finding.
+func (p *ProfilePolicy) resolve(ctx context.Context, reader client.Reader) (*profileResolver, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	resolver := &profileResolver{byNamespace: make(map[string]ValidationProfile)}
+	if p.defaultProfile != "" {
+		profile := p.profiles[p.defaultProfile]
+		resolver.fallback = &profile
+	}
+
+	if reader != nil {
+		var namespaces corev1.NamespaceList
+		if err := reader.List(ctx, &namespaces); err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		for _, namespace := range namespaces.Items {
+			name := namespace.Labels[NamespaceProfileLabel]
+			if name == "" {
+				continue
+			}
+			profile, exists := p.profiles[name]
+			if !exists {
+				// An unknown label value must not silently relax validation
+				continue
+			}
+			resolver.byNamespace[namespace.Name] = profile
+		}
+	}
+
+	for namespace, name := range p.namespaces {
+		resolver.byNamespace[namespace] = p.profiles[name]
+	}
+	return resolver, nil
+}
+
+// profileResolver holds the effective profile of each namespace for one validation run
+type profileResolver struct {
+	byNamespace map[string]ValidationProfile
+	fallback    *ValidationProfile
+}
+
+// reports returns whether a finding is reported under its namespace's profile.
+// Cluster-scoped findings use the default profile.
+func (r *profileResolver) reports(validationError ValidationError) bool {
+	if r == nil {
+		return true
+	}
+	if profile, exists := r.byNamespace[validationError.Namespace]; exists {
+		return profile.reports(validationError)
+	}
+	if r.fallback != nil {
+		return r.fallback.reports(validationError)
+	}
+	return true
+}
+
+// filter returns the findings reported under their namespaces' profiles
+func (r *profileResolver) filter(errors []ValidationError) []ValidationError {
+	if r == nil {
+		return errors
+	}
+	var reported []ValidationError
+	for _, validationError := range errors {
+		if r.reports(validationError) {
+			reported = append(reported, validationError)
+		}
+	}
+	return reported
+}
+
+// wrap returns a log receiver that drops findings the profiles do not report
+func (r *profileResolver) wrap(receiver LogReceiver) LogReceiver {
+	if r == nil {
+		return receiver
+	}
+	return &profileLogReceiver{LogReceiver: receiver, resolver: r}
+}
+
+// findingFilter is implemented by log receivers that drop some findings, so that no
+// metrics are recorded for them either
+type findingFilter interface {
+	Reports(validationError ValidationError) bool
+}
+
+// profileLogReceiver forwards the findings reported under their namespaces' profiles
+type profileLogReceiver struct {
+	LogReceiver
+	resolver *profileResolver
+}
+
+// LogValidationError forwards a finding if its namespace's profile reports it
+func (p *profileLogReceiver) LogValidationError(validatorType string, validationError ValidationError) {
+	if p.resolver.reports(validationError) {
+		p.LogReceiver.LogValidationError(validatorType, validationError)
+	}
+}
+
+// Reports returns whether a finding is reported under its namespace's profile and by
+// the wrapped receiver
+func (p *profileLogReceiver) Reports(validationError ValidationError) bool {
+	if !p.resolver.reports(validationError) {
+		return false
+	}
+	if filter, ok := p.LogReceiver.(findingFilter); ok {
+		return filter.Reports(validationError)
+	}
+	return true
+}
+
+// Cluster returns the cluster of the wrapped receiver
+func (p *profileLogReceiver) Cluster() string {
+	if scoped, ok := p.LogReceiver.(clusterScoped); ok {
+		return scoped.Cluster()
+	}
+	return ""
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidationProfile_Reports(t *testing.T) {
+	profiles := builtinProfiles()
+	missingLimits := ValidationError{ValidationType: "missing_resource_limits", ErrorCode: "KOGARO-RES-003", Severity: SeverityError}
+	qos := ValidationError{ValidationType: "qos_class_issue", ErrorCode: "KOGARO-RES-008", Severity: SeverityWarning}
+	info := ValidationError{ValidationType: "unused_configmap", ErrorCode: "KOGARO-REF-014", Severity: SeverityInfo}
+	privileged := ValidationError{ValidationType: "privileged_container", ErrorCode: "KOGARO-SEC-004", Severity: SeverityError}
+	capabilities := ValidationError{ValidationType: "container_capabilities_not_dropped", ErrorCode: "KOGARO-SEC-024", Severity: SeverityWarning}
+
+	tests := []struct {
+		profile string
+		finding ValidationError
+		want    bool
+	}{
+		{ProfileStrict, qos, true},
+		{ProfileStrict, info, true},
+		{ProfileStandard, qos, false},
+		{ProfileStandard, missingLimits, true},
+		{ProfileRelaxed, missingLimits, false},
+		{ProfileRelaxed, info, false},
+		{ProfileRelaxed, privileged, true},
+		{ProfileStrict, capabilities, true},
+		{ProfileStandard, capabilities, false},
+		{ProfileRelaxed, capabilities, false},
+	}
+	for _, tt := range tests {
+		if got := profiles[tt.profile].reports(tt.finding); got != tt.want {
+			t.Errorf("%s.reports(%s) = %v, want %v", tt.profile, tt.finding.ErrorCode, got, tt.want)
+		}
+	}
+}
+
+func TestNewProfilePolicy_Invalid(t *testing.T) {
+	policies := map[string]ValidationPolicySpec{
+		"unknown binding":  {NamespaceProfiles: map[string]string{"dev": "lenient"}},
+		"unknown default":  {DefaultProfile: "lenient"},
+		"unknown severity": {Profiles: map[string]ValidationProfile{"quiet": {MinSeverity: "critical"}}},
+		"invalid code":     {Profiles: map[string]ValidationProfile{"quiet": {SkipErrorCodes: []string{"res-*"}}}},
+	}
+	for name, spec := range policies {
+		if _, err := NewProfilePolicy(ValidationPolicy{Spec: spec}); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidatorRegistry_ProfilesPerNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := func(namespace string) client.Object {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "app:1"}},
+			}}},
+		}
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{NamespaceProfileLabel: ProfileRelaxed}}},
+		// The policy binding takes precedence over the label
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{NamespaceProfileLabel: ProfileRelaxed}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "staging"}},
+		deployment("dev"), deployment("prod"), deployment("staging"),
+	).Build()
+
+	profilePolicy, err := NewProfilePolicy(ValidationPolicy{Spec: ValidationPolicySpec{
+		NamespaceProfiles: map[string]string{"prod": ProfileStrict},
+		DefaultProfile:    ProfileStandard,
+	}})
+	if err != nil {
+		t.Fatalf("NewProfilePolicy() error = %v", err)
+	}
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.SetProfilePolicy(profilePolicy)
+	receiver := &BufferedLogReceiver{}
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{
+		EnableMissingRequestsValidation: true,
+		EnableMissingLimitsValidation:   true,
+		EnableQoSValidation:             true,
+	})
+	registry.Register(&fixedReceiverValidator{Validator: validator, receiver: receiver})
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range registry.LastValidationResult().Errors {
+		got = append(got, finding.Namespace+" "+finding.ValidationType)
+	}
+	sort.Strings(got)
+	want := []string{
+		"prod missing_resource_limits",
+		"prod missing_resource_requests",
+		"prod qos_class_issue",
+		"staging missing_resource_limits",
+		"staging missing_resource_requests",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if logged := receiver.GetErrors(); len(logged) != len(want) {
+		t.Errorf("logged %d findings, want %d", len(logged), len(want))
+	}
+}
+
+// fixedReceiverValidator records the findings logged by a validator in a receiver
+// that sits behind the log receiver installed by the registry
+type fixedReceiverValidator struct {
+	Validator
+	receiver *BufferedLogReceiver
+}
+
+func (f *fixedReceiverValidator) SetLogReceiver(receiver LogReceiver) {
+	if wrapped, ok := receiver.(*profileLogReceiver); ok {
+		wrapped.LogReceiver = f.receiver
+		f.Validator.SetLogReceiver(wrapped)
+		return
+	}
+	f.Validator.SetLogReceiver(f.receiver)
+}
@@ -0,0 +1,542 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides ResourceQuota and LimitRange validation functionality.
+//
+// This package implements validation of workloads against the namespace-level
+// admission constraints that silently block pod creation: ResourceQuotas that a
+// new workload would push over their hard limits, LimitRange min/max bounds on
+// container requests and limits, and quotas that force explicit resource
+// requests because no LimitRange supplies defaults.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+// QuotaConfig defines which quota validation checks to perform
+type QuotaConfig struct {
+	EnableQuotaCapacityValidation     bool
+	EnableLimitRangeValidation        bool
+	EnableMissingLimitRangeValidation bool
+}
+
+// QuotaValidator validates workloads against ResourceQuotas and LimitRanges
+type QuotaValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               QuotaConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// quotaWorkload is a pod-producing resource together with the number of pods it creates
+type quotaWorkload struct {
+	resourceType string
+	name         string
+	namespace    string
+	template     corev1.PodTemplateSpec
+	replicas     int64
+	// isNew is true when the workload has never been reconciled, so its pods are
+	// not yet reflected in ResourceQuota status.used
+	isNew bool
+}
+
+// quotaComputeResources are the quota keys that require every pod to declare the matching value
+var quotaComputeResources = []corev1.ResourceName{
+	corev1.ResourceRequestsCPU,
+	corev1.ResourceRequestsMemory,
+	corev1.ResourceLimitsCPU,
+	corev1.ResourceLimitsMemory,
+	corev1.ResourceCPU,
+	corev1.ResourceMemory,
+}
+
+// NewQuotaValidator creates a new QuotaValidator with the given client, logger and config
+func NewQuotaValidator(client client.Client, log logr.Logger, config QuotaConfig) *QuotaValidator {
+	return &QuotaValidator{
+		client:       client,
+		log:          log.WithName("quota-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *QuotaValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *QuotaValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *QuotaValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for quota validation
+func (v *QuotaValidator) GetValidationType() string {
+	return "quota_validation"
+}
+
+// ValidateCluster performs ResourceQuota and LimitRange validation across the cluster
+func (v *QuotaValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	var quotas corev1.ResourceQuotaList
+	if err := v.client.List(ctx, &quotas); err != nil {
+		return fmt.Errorf("failed to list resourcequotas: %w", err)
+	}
+
+	var limitRanges corev1.LimitRangeList
+	if err := v.client.List(ctx, &limitRanges); err != nil {
+		return fmt.Errorf("failed to list limitranges: %w", err)
+	}
+
+	workloads, err := v.listWorkloads(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	limitRangesByNamespace := make(map[string][]corev1.LimitRange)
+	for _, limitRange := range limitRanges.Items {
+		limitRangesByNamespace[limitRange.Namespace] = append(limitRangesByNamespace[limitRange.Namespace], limitRange)
+	}
+	workloadsByNamespace := make(map[string][]quotaWorkload)
+	for _, workload := range workloads {
+		workloadsByNamespace[workload.namespace] = append(workloadsByNamespace[workload.namespace], workload)
+	}
+
+	var allErrors []ValidationError
+
+	if v.config.EnableMissingLimitRangeValidation {
+		allErrors = append(allErrors, v.validateMissingLimitRanges(quotas.Items, limitRangesByNamespace, workloadsByNamespace)...)
+	}
+
+	if v.config.EnableLimitRangeValidation {
+		for _, workload := range workloads {
+			allErrors = append(allErrors, v.validateLimitRanges(workload, limitRangesByNamespace[workload.namespace])...)
+		}
+	}
+
+	if v.config.EnableQuotaCapacityValidation {
+		for _, quota := range quotas.Items {
+			allErrors = append(allErrors, v.validateQuotaCapacity(quota, workloadsByNamespace[quota.Namespace], limitRangesByNamespace[quota.Namespace])...)
+		}
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "quota", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "quota", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+func (v *QuotaValidator) listWorkloads(ctx context.Context) ([]quotaWorkload, error) {
+	var workloads []quotaWorkload
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if v.sharedConfig.IsSystemNamespace(deployment.Namespace) {
+			continue
+		}
+		workloads = append(workloads, quotaWorkload{
+			resourceType: "Deployment",
+			name:         deployment.Name,
+			namespace:    deployment.Namespace,
+			template:     deployment.Spec.Template,
+			replicas:     replicaCount(deployment.Spec.Replicas),
+			isNew:        deployment.Status.ObservedGeneration == 0,
+		})
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if v.sharedConfig.IsSystemNamespace(statefulSet.Namespace) {
+			continue
+		}
+		workloads = append(workloads, quotaWorkload{
+			resourceType: "StatefulSet",
+			name:         statefulSet.Name,
+			namespace:    statefulSet.Namespace,
+			template:     statefulSet.Spec.Template,
+			replicas:     replicaCount(statefulSet.Spec.Replicas),
+			isNew:        statefulSet.Status.ObservedGeneration == 0,
+		})
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are accounted for via their controllers
+		if v.sharedConfig.IsSystemNamespace(pod.Namespace) || utils.HasOwnerReferences(*pod) {
+			return nil
+		}
+		workloads = append(workloads, quotaWorkload{
+			resourceType: "Pod",
+			name:         pod.Name,
+			namespace:    pod.Namespace,
+			template:     corev1.PodTemplateSpec{Spec: pod.Spec},
+			replicas:     1,
+			isNew:        pod.Status.Phase == "",
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	return workloads, nil
+}
+
+func (v *QuotaValidator) validateMissingLimitRanges(quotas []corev1.ResourceQuota, limitRangesByNamespace map[string][]corev1.LimitRange, workloadsByNamespace map[string][]quotaWorkload) []ValidationError {
+	var errors []ValidationError
+
+	reportedNamespaces := make(map[string]bool)
+	for _, quota := range quotas {
+		if v.sharedConfig.IsSystemNamespace(quota.Namespace) || reportedNamespaces[quota.Namespace] {
+			continue
+		}
+
+		constrained := constrainedComputeResources(quota)
+		if len(constrained) == 0 || hasContainerDefaults(limitRangesByNamespace[quota.Namespace]) {
+			continue
+		}
+		reportedNamespaces[quota.Namespace] = true
+
+		errorCode := GetQuotaErrorCode("quota_without_limitrange")
+		errors = append(errors, NewValidationErrorWithCode("ResourceQuota", quota.Name, quota.Namespace, "quota_without_limitrange", errorCode, fmt.Sprintf("Namespace '%s' has ResourceQuota '%s' on compute resources but no LimitRange providing defaults", quota.Namespace, quota.Name)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Add a LimitRange with default and defaultRequest values to namespace '%s' so pods without explicit resources are not rejected", quota.Namespace)).
+			WithDetail("constrained_resources", resourceNameList(constrained)))
+
+		for _, workload := range workloadsByNamespace[quota.Namespace] {
+			missing := missingQuotaResources(workload.template.Spec, constrained)
+			if len(missing) == 0 {
+				continue
+			}
+			errorCode := GetQuotaErrorCode("quota_requires_explicit_resources")
+			errors = append(errors, NewValidationErrorWithCode(workload.resourceType, workload.name, workload.namespace, "quota_requires_explicit_resources", errorCode, fmt.Sprintf("Pods will be rejected: ResourceQuota '%s' requires %s but containers do not set them and no LimitRange provides defaults", quota.Name, resourceNameList(missing))).
+				WithSeverity(SeverityError).
+				WithRemediationHint(fmt.Sprintf("Set %s on every container, or add a LimitRange with defaults to namespace '%s'", resourceNameList(missing), quota.Namespace)).
+				WithRelatedResources(fmt.Sprintf("ResourceQuota/%s", quota.Name)).
+				WithDetail("missing_resources", resourceNameList(missing)))
+		}
+	}
+
+	return errors
+}
+
+func (v *QuotaValidator) validateLimitRanges(workload quotaWorkload, limitRanges []corev1.LimitRange) []ValidationError {
+	var errors []ValidationError
+
+	containers := make([]corev1.Container, 0, len(workload.template.Spec.InitContainers)+len(workload.template.Spec.Containers))
+	containers = append(containers, workload.template.Spec.InitContainers...)
+	containers = append(containers, workload.template.Spec.Containers...)
+
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+
+			for _, container := range containers {
+				requests, limits := effectiveContainerResources(container, []corev1.LimitRange{limitRange})
+
+				for _, resourceName := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+					checks := []struct {
+						kind  string
+						value resource.Quantity
+						set   bool
+					}{
+						{"request", requests[resourceName], hasResource(requests, resourceName)},
+						{"limit", limits[resourceName], hasResource(limits, resourceName)},
+					}
+
+					for _, check := range checks {
+						if !check.set {
+							continue
+						}
+						if minimum, ok := item.Min[resourceName]; ok && check.value.Cmp(minimum) < 0 {
+							errors = append(errors, v.limitRangeError(workload, limitRange, container.Name, "limitrange_below_min", resourceName, check.kind, check.value, minimum))
+						}
+						if maximum, ok := item.Max[resourceName]; ok && check.value.Cmp(maximum) > 0 {
+							errors = append(errors, v.limitRangeError(workload, limitRange, container.Name, "limitrange_above_max", resourceName, check.kind, check.value, maximum))
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return errors
+}
+
+func (v *QuotaValidator) limitRangeError(workload quotaWorkload, limitRange corev1.LimitRange, containerName, validationType string, resourceName corev1.ResourceName, kind string, value, bound resource.Quantity) ValidationError {
+	comparison, boundName := "below the minimum", "min"
+	if validationType == "limitrange_above_max" {
+		comparison, boundName = "above the maximum", "max"
+	}
+
+	errorCode := GetQuotaErrorCode(validationType)
+	return NewValidationErrorWithCode(workload.resourceType, workload.name, workload.namespace, validationType, errorCode, fmt.Sprintf("Container '%s' %s %s %s is %s %s allowed by LimitRange '%s'", containerName, resourceName, kind, value.String(), comparison, bound.String(), limitRange.Name)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(fmt.Sprintf("Set the %s %s of container '%s' within the LimitRange '%s' bounds (%s: %s)", resourceName, kind, containerName, limitRange.Name, boundName, bound.String())).
+		WithRelatedResources(fmt.Sprintf("LimitRange/%s", limitRange.Name)).
+		WithDetail("container_name", containerName).
+		WithDetail("resource", string(resourceName)).
+		WithDetail("value", value.String()).
+		WithDetail(boundName, bound.String())
+}
+
+func (v *QuotaValidator) validateQuotaCapacity(quota corev1.ResourceQuota, workloads []quotaWorkload, limitRanges []corev1.LimitRange) []ValidationError {
+	var errors []ValidationError
+
+	// Scoped quotas only apply to a subset of pods, which cannot be determined from templates alone
+	if v.sharedConfig.IsSystemNamespace(quota.Namespace) || len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+		return nil
+	}
+
+	projected := make(corev1.ResourceList)
+	for resourceName, used := range quota.Status.Used {
+		projected[resourceName] = used.DeepCopy()
+	}
+
+	for _, workload := range workloads {
+		if !workload.isNew {
+			continue
+		}
+
+		demand := workloadQuotaDemand(workload, limitRanges)
+		var exceeded []string
+		for resourceName, hard := range quota.Spec.Hard {
+			needed, ok := demand[resourceName]
+			if !ok {
+				continue
+			}
+			total := projected[resourceName]
+			total.Add(needed)
+			projected[resourceName] = total
+			if total.Cmp(hard) > 0 {
+				exceeded = append(exceeded, fmt.Sprintf("%s %s > %s", resourceName, total.String(), hard.String()))
+			}
+		}
+		if len(exceeded) == 0 {
+			continue
+		}
+		sort.Strings(exceeded)
+
+		errorCode := GetQuotaErrorCode("resource_quota_exceeded")
+		errors = append(errors, NewValidationErrorWithCode(workload.resourceType, workload.name, workload.namespace, "resource_quota_exceeded", errorCode, fmt.Sprintf("Workload would exceed ResourceQuota '%s': %s", quota.Name, strings.Join(exceeded, ", "))).
+			WithSeverity(SeverityError).
+			WithRemediationHint(fmt.Sprintf("Reduce replicas or resource requests, or raise the hard limits of ResourceQuota '%s' in namespace '%s'", quota.Name, quota.Namespace)).
+			WithRelatedResources(fmt.Sprintf("ResourceQuota/%s", quota.Name)).
+			WithDetail("replicas", fmt.Sprintf("%d", workload.replicas)).
+			WithDetail("exceeded", strings.Join(exceeded, ", ")))
+	}
+
+	return errors
+}
+
+// workloadQuotaDemand returns the quota usage all pods of a workload would consume
+func workloadQuotaDemand(workload quotaWorkload, limitRanges []corev1.LimitRange) corev1.ResourceList {
+	requests, limits := podEffectiveResources(workload.template.Spec, limitRanges)
+	demand := corev1.ResourceList{
+		corev1.ResourcePods: *resource.NewQuantity(workload.replicas, resource.DecimalSI),
+	}
+
+	scale := func(quantity resource.Quantity) resource.Quantity {
+		return *resource.NewMilliQuantity(quantity.MilliValue()*workload.replicas, quantity.Format)
+	}
+
+	if cpu, ok := requests[corev1.ResourceCPU]; ok {
+		demand[corev1.ResourceRequestsCPU] = scale(cpu)
+		demand[corev1.ResourceCPU] = scale(cpu)
+	}
+	if memory, ok := requests[corev1.ResourceMemory]; ok {
+		demand[corev1.ResourceRequestsMemory] = scale(memory)
+		demand[corev1.ResourceMemory] = scale(memory)
+	}
+	if cpu, ok := limits[corev1.ResourceCPU]; ok {
+		demand[corev1.ResourceLimitsCPU] = scale(cpu)
+	}
+	if memory, ok := limits[corev1.ResourceMemory]; ok {
+		demand[corev1.ResourceLimitsMemory] = scale(memory)
+	}
+
+	return demand
+}
+
+// podEffectiveResources returns the pod-level requests and limits after LimitRange
+// defaulting. Init containers run sequentially, so the pod needs the larger of the
+// summed app containers and the largest single init container.
+func podEffectiveResources(spec corev1.PodSpec, limitRanges []corev1.LimitRange) (corev1.ResourceList, corev1.ResourceList) {
+	podRequests := make(corev1.ResourceList)
+	podLimits := make(corev1.ResourceList)
+
+	for _, container := range spec.Containers {
+		requests, limits := effectiveContainerResources(container, limitRanges)
+		addResources(podRequests, requests)
+		addResources(podLimits, limits)
+	}
+
+	for _, container := range spec.InitContainers {
+		requests, limits := effectiveContainerResources(container, limitRanges)
+		maxResources(podRequests, requests)
+		maxResources(podLimits, limits)
+	}
+
+	return podRequests, podLimits
+}
+
+// effectiveContainerResources applies LimitRange defaults the way admission does:
+// a missing limit takes the default, and a missing request takes defaultRequest,
+// falling back to the (possibly defaulted) limit.
+func effectiveContainerResources(container corev1.Container, limitRanges []corev1.LimitRange) (corev1.ResourceList, corev1.ResourceList) {
+	requests := container.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = make(corev1.ResourceList)
+	}
+	limits := container.Resources.Limits.DeepCopy()
+	if limits == nil {
+		limits = make(corev1.ResourceList)
+	}
+
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type != corev1.LimitTypeContainer {
+				continue
+			}
+			for resourceName, value := range item.Default {
+				if !hasResource(limits, resourceName) {
+					limits[resourceName] = value.DeepCopy()
+				}
+			}
+			for resourceName, value := range item.DefaultRequest {
+				if !hasResource(requests, resourceName) {
+					requests[resourceName] = value.DeepCopy()
+				}
+			}
+		}
+	}
+
+	for resourceName, limit := range limits {
+		if !hasResource(requests, resourceName) {
+			requests[resourceName] = limit.DeepCopy()
+		}
+	}
+
+	return requests, limits
+}
+
+// constrainedComputeResources returns the compute resources a quota places hard limits on
+func constrainedComputeResources(quota corev1.ResourceQuota) []corev1.ResourceName {
+	var constrained []corev1.ResourceName
+	for _, resourceName := range quotaComputeResources {
+		if _, ok := quota.Spec.Hard[resourceName]; ok {
+			constrained = append(constrained, resourceName)
+		}
+	}
+	return constrained
+}
+
+// hasContainerDefaults reports whether any LimitRange supplies container defaults
+func hasContainerDefaults(limitRanges []corev1.LimitRange) bool {
+	for _, limitRange := range limitRanges {
+		for _, item := range limitRange.Spec.Limits {
+			if item.Type == corev1.LimitTypeContainer && (len(item.Default) > 0 || len(item.DefaultRequest) > 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// missingQuotaResources returns the quota-constrained resources some container does not declare
+func missingQuotaResources(spec corev1.PodSpec, constrained []corev1.ResourceName) []corev1.ResourceName {
+	var missing []corev1.ResourceName
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+
+	for _, resourceName := range constrained {
+		for _, container := range containers {
+			var declared bool
+			switch resourceName {
+			case corev1.ResourceRequestsCPU, corev1.ResourceCPU:
+				declared = hasResource(container.Resources.Requests, corev1.ResourceCPU) || hasResource(container.Resources.Limits, corev1.ResourceCPU)
+			case corev1.ResourceRequestsMemory, corev1.ResourceMemory:
+				declared = hasResource(container.Resources.Requests, corev1.ResourceMemory) || hasResource(container.Resources.Limits, corev1.ResourceMemory)
+			case corev1.ResourceLimitsCPU:
+				declared = hasResource(container.Resources.Limits, corev1.ResourceCPU)
+			case corev1.ResourceLimitsMemory:
+				declared = hasResource(container.Resources.Limits, corev1.ResourceMemory)
+			}
+			if !declared {
+				missing = append(missing, resourceName)
+				break
+			}
+		}
+	}
+
+	return missing
+}
+
+func hasResource(list corev1.ResourceList, resourceName corev1.ResourceName) bool {
+	_, ok := list[resourceName]
+	return ok
+}
+
+func addResources(total, add corev1.ResourceList) {
+	for resourceName, value := range add {
+		current := total[resourceName]
+		current.Add(value)
+		total[resourceName] = current
+	}
+}
+
+func maxResources(total, candidate corev1.ResourceList) {
+	for resourceName, value := range candidate {
+		if current, ok := total[resourceName]; !ok || value.Cmp(current) > 0 {
+			total[resourceName] = value.DeepCopy()
+		}
+	}
+}
+
+func replicaCount(replicas *int32) int64 {
+	if replicas == nil {
+		return 1
+	}
+	return int64(*replicas)
+}
+
+func resourceNameList(names []corev1.ResourceName) string {
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		values = append(values, string(name))
+	}
+	return strings.Join(values, ", ")
+}
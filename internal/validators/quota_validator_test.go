@@ -0,0 +1,211 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestQuotaValidator_GetValidationType(t *testing.T) {
+	validator := NewQuotaValidator(nil, logr.Discard(), QuotaConfig{})
+	if got := validator.GetValidationType(); got != "quota_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "quota_validation")
+	}
+}
+
+func TestQuotaValidator_ValidateCluster(t *testing.T) {
+	resources := func(cpu, memory string) corev1.ResourceList {
+		list := corev1.ResourceList{}
+		if cpu != "" {
+			list[corev1.ResourceCPU] = resource.MustParse(cpu)
+		}
+		if memory != "" {
+			list[corev1.ResourceMemory] = resource.MustParse(memory)
+		}
+		return list
+	}
+	deployment := func(name string, replicas int32, observedGeneration int64, requirements corev1.ResourceRequirements) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(replicas),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "nginx", Resources: requirements}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{ObservedGeneration: observedGeneration},
+		}
+	}
+	quota := func(hard, used corev1.ResourceList) *corev1.ResourceQuota {
+		return &corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "test-ns"},
+			Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+			Status:     corev1.ResourceQuotaStatus{Hard: hard, Used: used},
+		}
+	}
+	limitRange := func(item corev1.LimitRangeItem) *corev1.LimitRange {
+		item.Type = corev1.LimitTypeContainer
+		return &corev1.LimitRange{
+			ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "test-ns"},
+			Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+		}
+	}
+	requestsQuota := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+		corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+	}
+	defaults := corev1.LimitRangeItem{DefaultRequest: resources("100m", "128Mi")}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErrors []string
+	}{
+		{
+			name: "new workload fits within quota headroom",
+			objects: []client.Object{
+				quota(requestsQuota, corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1"), corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}),
+				limitRange(defaults),
+				deployment("web", 2, 0, corev1.ResourceRequirements{Requests: resources("250m", "256Mi")}),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "new workload exceeds quota headroom",
+			objects: []client.Object{
+				quota(requestsQuota, corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m"), corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}),
+				limitRange(defaults),
+				deployment("web", 3, 0, corev1.ResourceRequirements{Requests: resources("250m", "256Mi")}),
+			},
+			expectedErrors: []string{"resource_quota_exceeded"},
+		},
+		{
+			name: "reconciled workload is already counted in quota usage",
+			objects: []client.Object{
+				quota(requestsQuota, corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("1500m"), corev1.ResourceRequestsMemory: resource.MustParse("1Gi")}),
+				limitRange(defaults),
+				deployment("web", 3, 4, corev1.ResourceRequirements{Requests: resources("250m", "256Mi")}),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "pod count quota exceeded",
+			objects: []client.Object{
+				quota(corev1.ResourceList{corev1.ResourcePods: resource.MustParse("5")}, corev1.ResourceList{corev1.ResourcePods: resource.MustParse("4")}),
+				deployment("web", 2, 0, corev1.ResourceRequirements{}),
+			},
+			expectedErrors: []string{"resource_quota_exceeded"},
+		},
+		{
+			name: "container outside limitrange bounds",
+			objects: []client.Object{
+				limitRange(corev1.LimitRangeItem{
+					Min: resources("50m", "64Mi"),
+					Max: resources("1", "1Gi"),
+				}),
+				deployment("web", 1, 0, corev1.ResourceRequirements{
+					Requests: resources("10m", "128Mi"),
+					Limits:   resources("500m", "2Gi"),
+				}),
+			},
+			expectedErrors: []string{"limitrange_below_min", "limitrange_above_max"},
+		},
+		{
+			name: "quota without limitrange and workload without requests",
+			objects: []client.Object{
+				quota(requestsQuota, nil),
+				deployment("web", 1, 0, corev1.ResourceRequirements{Requests: resources("100m", "")}),
+			},
+			expectedErrors: []string{"quota_without_limitrange", "quota_requires_explicit_resources"},
+		},
+		{
+			name: "system namespace ignored",
+			objects: []client.Object{
+				&corev1.ResourceQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "kube-system"},
+					Spec:       corev1.ResourceQuotaSpec{Hard: requestsQuota},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewQuotaValidator(fakeClient, logr.Discard(), QuotaConfig{
+				EnableQuotaCapacityValidation:     true,
+				EnableLimitRangeValidation:        true,
+				EnableMissingLimitRangeValidation: true,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-QTA-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPodEffectiveResources(t *testing.T) {
+	spec := corev1.PodSpec{
+		InitContainers: []corev1.Container{{
+			Name:      "migrate",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+		}},
+		Containers: []corev1.Container{
+			{Name: "app", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("200m")}}},
+			{Name: "sidecar", Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")}}},
+		},
+	}
+
+	requests, limits := podEffectiveResources(spec, nil)
+
+	if cpu := requests[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("1")) != 0 {
+		t.Errorf("cpu request = %s, want 1 (largest init container)", cpu.String())
+	}
+	if memory := requests[corev1.ResourceMemory]; memory.Cmp(resource.MustParse("64Mi")) != 0 {
+		t.Errorf("memory request = %s, want 64Mi (defaulted from limit)", memory.String())
+	}
+	if memory := limits[corev1.ResourceMemory]; memory.Cmp(resource.MustParse("64Mi")) != 0 {
+		t.Errorf("memory limit = %s, want 64Mi", memory.String())
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// downwardAPISubscript matches the fieldPath of a single label or annotation, such as metadata.labels['app']
+var downwardAPISubscript = regexp.MustCompile(`^metadata\.(labels|annotations)\['(.*)'\]$`)
+
+// envFieldPaths are the fieldPaths an env var may select besides labels and annotations
+var envFieldPaths = map[string]bool{
+	"metadata.name":           true,
+	"metadata.namespace":      true,
+	"metadata.uid":            true,
+	"spec.nodeName":           true,
+	"spec.serviceAccountName": true,
+	"status.hostIP":           true,
+	"status.hostIPs":          true,
+	"status.podIP":            true,
+	"status.podIPs":           true,
+}
+
+// volumeFieldPaths are the fieldPaths a downward API volume item may select besides
+// single labels and annotations
+var volumeFieldPaths = map[string]bool{
+	"metadata.name":        true,
+	"metadata.namespace":   true,
+	"metadata.uid":         true,
+	"metadata.labels":      true,
+	"metadata.annotations": true,
+}
+
+// controllerInjectedLabels are set by workload controllers on the pods they create, so
+// pod templates may select them although the template does not set them
+var controllerInjectedLabels = map[string]bool{
+	"pod-template-hash":                        true,
+	"controller-revision-hash":                 true,
+	"pod-template-generation":                  true,
+	"statefulset.kubernetes.io/pod-name":       true,
+	"apps.kubernetes.io/pod-index":             true,
+	"job-name":                                 true,
+	"controller-uid":                           true,
+	"batch.kubernetes.io/job-name":             true,
+	"batch.kubernetes.io/controller-uid":       true,
+	"batch.kubernetes.io/job-completion-index": true,
+}
+
+// downwardAPIResources are the resources a resourceFieldRef may select besides hugepages
+var downwardAPIResources = map[string]bool{
+	"limits.cpu":                 true,
+	"limits.memory":              true,
+	"limits.ephemeral-storage":   true,
+	"requests.cpu":               true,
+	"requests.memory":            true,
+	"requests.ephemeral-storage": true,
+}
+
+// validateDownwardAPIReferences checks the fieldRef and resourceFieldRef selectors of
+// env vars and downward API volumes. A fieldRef to a label or annotation the pod does
+// not carry, or a resourceFieldRef to a resource the container does not set, silently
+// yields an empty value or the node's allocatable capacity instead of failing.
+func (v *ReferenceValidator) validateDownwardAPIReferences(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+
+	// Get all Pods and workload templates to check Downward API references
+	sources, err := v.listPodSpecs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, source := range sources {
+		containers := append(append([]corev1.Container{}, source.spec.InitContainers...), source.spec.Containers...)
+		for _, container := range containers {
+			for _, env := range container.Env {
+				if env.ValueFrom == nil {
+					continue
+				}
+				location := fmt.Sprintf("Env var '%s' in container '%s'", env.Name, container.Name)
+				if env.ValueFrom.FieldRef != nil {
+					if validationError := v.validateFieldRef(source, env.ValueFrom.FieldRef.FieldPath, envFieldPaths, location); validationError != nil {
+						errors = append(errors, validationError.
+							WithDetail("container_name", container.Name).
+							WithDetail("env_var_name", env.Name))
+					}
+				}
+				if env.ValueFrom.ResourceFieldRef != nil {
+					// An env var without a container name selects its own container
+					if validationError := v.validateResourceFieldRef(source, env.ValueFrom.ResourceFieldRef, container.Name, location); validationError != nil {
+						errors = append(errors, validationError.
+							WithDetail("container_name", container.Name).
+							WithDetail("env_var_name", env.Name))
+					}
+				}
+			}
+		}
+
+		for _, volume := range source.spec.Volumes {
+			var items []corev1.DownwardAPIVolumeFile
+			if volume.DownwardAPI != nil {
+				items = append(items, volume.DownwardAPI.Items...)
+			}
+			if volume.Projected != nil {
+				for _, projection := range volume.Projected.Sources {
+					if projection.DownwardAPI != nil {
+						items = append(items, projection.DownwardAPI.Items...)
+					}
+				}
+			}
+
+			for _, item := range items {
+				location := fmt.Sprintf("Downward API item '%s' of volume '%s'", item.Path, volume.Name)
+				if item.FieldRef != nil {
+					if validationError := v.validateFieldRef(source, item.FieldRef.FieldPath, volumeFieldPaths, location); validationError != nil {
+						errors = append(errors, validationError.WithDetail("volume_name", volume.Name))
+					}
+				}
+				if item.ResourceFieldRef != nil {
+					if validationError := v.validateResourceFieldRef(source, item.ResourceFieldRef, "", location); validationError != nil {
+						errors = append(errors, validationError.WithDetail("volume_name", volume.Name))
+					}
+				}
+			}
+		}
+	}
+
+	return errors, nil
+}
+
+// validateFieldRef checks that a fieldPath is supported and that the label or
+// annotation it selects is set on the pod or pod template
+func (v *ReferenceValidator) validateFieldRef(source podSpecSource, fieldPath string, supported map[string]bool, location string) *ValidationError {
+	if supported[fieldPath] {
+		return nil
+	}
+
+	match := downwardAPISubscript.FindStringSubmatch(fieldPath)
+	if match == nil {
+		validationError := NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_field_ref", GetReferenceErrorCode("dangling_field_ref"), fmt.Sprintf("%s selects unsupported fieldPath '%s'", location, fieldPath)).
+			WithSeverity(SeverityError).
+			WithRemediationHint(fmt.Sprintf("Use a supported fieldPath such as metadata.name, metadata.labels['<key>'] or status.podIP instead of '%s'", fieldPath)).
+			WithDetail("field_path", fieldPath)
+		return &validationError
+	}
+
+	kind, key := strings.TrimSuffix(match[1], "s"), match[2]
+	values := source.labels
+	if kind == "annotation" {
+		values = source.annotations
+	}
+	if _, exists := values[key]; exists {
+		return nil
+	}
+	// Controllers add these labels to the pods they create from a template
+	if kind == "label" && source.resourceType != "Pod" && controllerInjectedLabels[key] {
+		return nil
+	}
+
+	validationError := NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_field_ref", GetReferenceErrorCode("dangling_field_ref"), fmt.Sprintf("%s selects %s '%s', which is not set on the pod", location, kind, key)).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint(fmt.Sprintf("Add %s '%s' to the pod metadata or correct the fieldPath '%s'; a missing %s yields an empty value", kind, key, fieldPath, kind)).
+		WithDetail("field_path", fieldPath).
+		WithDetail("missing_"+kind, key)
+	return &validationError
+}
+
+// validateResourceFieldRef checks that a resourceFieldRef selects a supported resource
+// of an existing container that sets it. defaultContainer is used when the reference
+// names no container.
+func (v *ReferenceValidator) validateResourceFieldRef(source podSpecSource, ref *corev1.ResourceFieldSelector, defaultContainer, location string) *ValidationError {
+	containerName := ref.ContainerName
+	if containerName == "" {
+		containerName = defaultContainer
+	}
+
+	newError := func(severity Severity, message, hint string) *ValidationError {
+		validationError := NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_resource_field_ref", GetReferenceErrorCode("dangling_resource_field_ref"), message).
+			WithSeverity(severity).
+			WithRemediationHint(hint).
+			WithDetail("resource", ref.Resource).
+			WithDetail("referenced_container", containerName)
+		return &validationError
+	}
+
+	if !downwardAPIResources[ref.Resource] && !strings.HasPrefix(ref.Resource, "limits.hugepages-") && !strings.HasPrefix(ref.Resource, "requests.hugepages-") {
+		return newError(SeverityError, fmt.Sprintf("%s selects unsupported resource '%s'", location, ref.Resource),
+			"Select one of limits.cpu, limits.memory, limits.ephemeral-storage, requests.cpu, requests.memory or requests.ephemeral-storage")
+	}
+	if containerName == "" {
+		return newError(SeverityError, fmt.Sprintf("%s selects resource '%s' without naming a container", location, ref.Resource),
+			"Set containerName on the resourceFieldRef of the downward API volume item")
+	}
+
+	var container *corev1.Container
+	for _, candidate := range append(append([]corev1.Container{}, source.spec.InitContainers...), source.spec.Containers...) {
+		if candidate.Name == containerName {
+			container = &candidate
+			break
+		}
+	}
+	if container == nil {
+		return newError(SeverityError, fmt.Sprintf("%s selects resource '%s' of container '%s', which does not exist", location, ref.Resource, containerName),
+			fmt.Sprintf("Update containerName to one of the containers of %s '%s'", source.resourceType, source.name))
+	}
+
+	kind, resourceName, _ := strings.Cut(ref.Resource, ".")
+	_, hasLimit := container.Resources.Limits[corev1.ResourceName(resourceName)]
+	_, hasRequest := container.Resources.Requests[corev1.ResourceName(resourceName)]
+	switch {
+	case kind == "limits" && !hasLimit:
+		return newError(SeverityWarning, fmt.Sprintf("%s selects '%s' of container '%s', which sets no %s limit", location, ref.Resource, containerName, resourceName),
+			fmt.Sprintf("Set a %s limit on container '%s'; without one the value is the node's allocatable %s", resourceName, containerName, resourceName))
+	case kind == "requests" && !hasRequest && !hasLimit:
+		// Requests default to limits, so either one makes the value meaningful
+		return newError(SeverityWarning, fmt.Sprintf("%s selects '%s' of container '%s', which sets no %s request", location, ref.Resource, containerName, resourceName),
+			fmt.Sprintf("Set a %s request on container '%s'; without one the value is zero", resourceName, containerName))
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// maxSuggestedNames bounds the close matches offered in a remediation hint
+	maxSuggestedNames = 3
+	// maxListedNames bounds the available names listed in a remediation hint
+	maxListedNames = 5
+
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+)
+
+// namespacedReferenceKinds maps the detail that names a missing namespaced resource to its kind
+var namespacedReferenceKinds = map[string]string{
+	"missing_configmap":       "ConfigMap",
+	"missing_secret":          "Secret",
+	"missing_tls_secret":      "Secret",
+	"missing_pvc":             "PersistentVolumeClaim",
+	"missing_service":         "Service",
+	"missing_service_account": "ServiceAccount",
+}
+
+// referenceHints lists existing resources to suggest in remediation hints. Lists are
+// made once per kind and namespace, and only for namespaces that have findings.
+type referenceHints struct {
+	v     *ReferenceValidator
+	names map[string][]string
+}
+
+// addReferenceHints suggests existing resources for dangling reference findings. Close
+// name matches are recorded as suggested references scored by confidence, and the
+// remediation hint is extended with values from the cluster: the names of similarly
+// named resources or keys, or the StorageClasses or IngressClasses that do exist. Hints
+// are best effort; resources that cannot be listed leave the finding unchanged.
+func (v *ReferenceValidator) addReferenceHints(ctx context.Context, errs []ValidationError) []ValidationError {
+	hints := &referenceHints{v: v, names: make(map[string][]string)}
+	for i := range errs {
+		errs[i] = hints.suggest(ctx, errs[i])
+	}
+	return errs
+}
+
+// suggest returns finding with the references and hint suggested for it
+func (h *referenceHints) suggest(ctx context.Context, finding ValidationError) ValidationError {
+	if key, ok := finding.Details["missing_key"]; ok {
+		if name, ok := finding.Details["configmap_name"]; ok {
+			matches := closestNames(key, h.configMapKeys(ctx, name, finding.Namespace))
+			return withMatches(finding, "ConfigMapKey", fmt.Sprintf("key of ConfigMap '%s'", name), key, matches)
+		}
+		if name, ok := finding.Details["secret_name"]; ok {
+			matches := closestNames(key, h.secretKeys(ctx, name, finding.Namespace))
+			return withMatches(finding, "SecretKey", fmt.Sprintf("key of Secret '%s'", name), key, matches)
+		}
+		return finding
+	}
+
+	for detail, kind := range namespacedReferenceKinds {
+		if name, ok := finding.Details[detail]; ok {
+			matches := closestNames(name, h.list(ctx, kind, finding.Namespace))
+			return withMatches(finding, kind, kind, name, matches)
+		}
+	}
+
+	if name, ok := finding.Details["missing_storage_class"]; ok {
+		return withClassMatches(finding, "StorageClass", "StorageClasses", name, h.list(ctx, "StorageClass", ""))
+	}
+	if name, ok := finding.Details["missing_class"]; ok && finding.ResourceType == "Ingress" {
+		return withClassMatches(finding, "IngressClass", "IngressClasses", name, h.list(ctx, "IngressClass", ""))
+	}
+	return finding
+}
+
+// list returns the names of the resources of kind in namespace, or of the cluster-scoped
+// resources of kind when namespace is empty. Default StorageClasses and IngressClasses
+// are marked as such.
+func (h *referenceHints) list(ctx context.Context, kind, namespace string) []string {
+	cacheKey := kind + "/" + namespace
+	if names, ok := h.names[cacheKey]; ok {
+		return names
+	}
+
+	var list client.ObjectList
+	defaultAnnotation := ""
+	switch kind {
+	case "ConfigMap":
+		list = &corev1.ConfigMapList{}
+	case "Secret":
+		list = newSecretList()
+	case "PersistentVolumeClaim":
+		list = &corev1.PersistentVolumeClaimList{}
+	case "Service":
+		list = &corev1.ServiceList{}
+	case "ServiceAccount":
+		list = &corev1.ServiceAccountList{}
+	case "StorageClass":
+		list = &storagev1.StorageClassList{}
+		defaultAnnotation = defaultStorageClassAnnotation
+	case "IngressClass":
+		list = &networkingv1.IngressClassList{}
+		defaultAnnotation = defaultIngressClassAnnotation
+	default:
+		return nil
+	}
+
+	var names []string
+	if err := h.v.client.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		h.v.log.V(1).Info("unable to list resources for remediation hints", "kind", kind, "namespace", namespace, "error", err)
+	} else if items, err := meta.ExtractList(list); err == nil {
+		for _, item := range items {
+			obj, ok := item.(metav1.Object)
+			if !ok {
+				continue
+			}
+			name := obj.GetName()
+			if defaultAnnotation != "" && obj.GetAnnotations()[defaultAnnotation] == "true" {
+				name += " (default)"
+			}
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	h.names[cacheKey] = names
+	return names
+}
+
+// configMapKeys returns the keys of the named ConfigMap
+func (h *referenceHints) configMapKeys(ctx context.Context, name, namespace string) []string {
+	configMap, err := h.v.getConfigMap(ctx, name, namespace)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(configMap.Data)+len(configMap.BinaryData))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	for key := range configMap.BinaryData {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// secretKeys returns the keys of the named Secret, never its values
+func (h *referenceHints) secretKeys(ctx context.Context, name, namespace string) []string {
+	if !secretDataReadable() {
+		return nil
+	}
+	secret, err := h.v.getSecret(ctx, name, namespace)
+	if err != nil {
+		return nil
+	}
+	keys := make([]string, 0, len(secret.Data)+len(secret.StringData))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	for key := range secret.StringData {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// withMatches records matches as references from the finding's resource to targetType
+// and asks whether one of them was meant in the remediation hint
+func withMatches(finding ValidationError, targetType, description, missing string, matches []nameMatch) ValidationError {
+	if len(matches) == 0 {
+		return finding
+	}
+	names := make([]string, len(matches))
+	for i, match := range matches {
+		names[i] = match.name
+		finding = finding.WithSuggestedRefs(Reference{
+			SourceType: finding.ResourceType,
+			SourceName: finding.ResourceName,
+			TargetType: targetType,
+			TargetName: match.name,
+			Confidence: match.confidence,
+			Reason:     fmt.Sprintf("Existing %s within edit distance %d of '%s'", description, match.distance, missing),
+		})
+	}
+	finding.RemediationHint = fmt.Sprintf("%s; did you mean %s?", finding.RemediationHint, joinQuoted(names))
+	return finding
+}
+
+// withClassMatches suggests the classes named like the missing class, or lists the
+// classes that exist in the remediation hint when none is
+func withClassMatches(finding ValidationError, kind, plural, name string, available []string) ValidationError {
+	if matches := closestNames(name, available); len(matches) > 0 {
+		return withMatches(finding, kind, kind, name, matches)
+	}
+	if len(available) == 0 {
+		finding.RemediationHint = fmt.Sprintf("%s. No %s exist in the cluster", finding.RemediationHint, plural)
+		return finding
+	}
+	listed := available
+	more := ""
+	if len(listed) > maxListedNames {
+		more = fmt.Sprintf(" and %d more", len(listed)-maxListedNames)
+		listed = listed[:maxListedNames]
+	}
+	finding.RemediationHint = fmt.Sprintf("%s. Available %s: %s%s", finding.RemediationHint, plural, strings.Join(listed, ", "), more)
+	return finding
+}
+
+// nameMatch is an existing name close to a missing one. Confidence falls from 1 as the
+// edit distance grows relative to the length of the longer name.
+type nameMatch struct {
+	name       string
+	distance   int
+	confidence float64
+}
+
+// closestNames returns up to maxSuggestedNames candidates that are named like name,
+// most confident first. Candidates match when they are within a small edit distance of
+// name, relative to its length, or when one name contains the other, as with
+// environment suffixes such as app-config and app-config-prod.
+func closestNames(name string, candidates []string) []nameMatch {
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	var matches []nameMatch
+	for _, candidate := range candidates {
+		bare := strings.TrimSuffix(candidate, " (default)")
+		if bare == name || bare == "" {
+			continue
+		}
+		distance := levenshtein(name, bare)
+		contains := len(name) >= 3 && len(bare) >= 3 && (strings.Contains(bare, name) || strings.Contains(name, bare))
+		if distance > maxDistance && !contains {
+			continue
+		}
+		longest := max(len([]rune(name)), len([]rune(bare)))
+		confidence := 1 - float64(distance)/float64(longest)
+		matches = append(matches, nameMatch{name: bare, distance: distance, confidence: math.Round(confidence*100) / 100})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].confidence != matches[j].confidence {
+			return matches[i].confidence > matches[j].confidence
+		}
+		return matches[i].name < matches[j].name
+	})
+	if len(matches) > maxSuggestedNames {
+		matches = matches[:maxSuggestedNames]
+	}
+	return matches
+}
+
+// levenshtein returns the number of single-character insertions, deletions and
+// substitutions needed to turn a into b
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// joinQuoted quotes names and joins them as 'a', 'b' or 'c'
+func joinQuoted(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = "'" + name + "'"
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
@@ -0,0 +1,230 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"app", "", 3},
+		{"app-config", "app-config", 0},
+		{"app-conifg", "app-config", 2},
+		{"kitten", "sitting", 3},
+		{"app-config", "app-config-prod", 5},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestClosestNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		target     string
+		candidates []string
+		want       []string
+	}{
+		{
+			name:       "typo and environment suffix",
+			target:     "app-config",
+			candidates: []string{"app-conifg", "app-config-prod", "redis", "kube-root-ca.crt"},
+			want:       []string{"app-conifg", "app-config-prod"},
+		},
+		{
+			name:       "default marker is ignored",
+			target:     "gp2-fast",
+			candidates: []string{"gp3-fast (default)", "standard"},
+			want:       []string{"gp3-fast"},
+		},
+		{
+			name:       "unrelated names",
+			target:     "database",
+			candidates: []string{"redis", "cache"},
+			want:       nil,
+		},
+		{
+			name:       "closest matches are limited",
+			target:     "api",
+			candidates: []string{"api-1", "api-2", "api-3", "api-4"},
+			want:       []string{"api-1", "api-2", "api-3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, match := range closestNames(tt.target, tt.candidates) {
+				got = append(got, match.name)
+			}
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("closestNames() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReferenceValidator_ContextAwareHints(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+
+	className := "nginx-public"
+	storageClass := "ssd"
+	objects := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-config-prod", Namespace: "shop"},
+			Data:       map[string]string{"DATABASE_URL": "postgres://db"},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "other"},
+		},
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "gp3", Annotations: map[string]string{defaultStorageClassAnnotation: "true"}},
+		},
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "standard"},
+		},
+		&networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx"},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       networkingv1.IngressSpec{IngressClassName: &className},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "shop"},
+			Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:    "api",
+					EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}}},
+					Env: []corev1.EnvVar{{
+						Name: "DB",
+						ValueFrom: &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "app-config-prod"},
+							Key:                  "DATABASE_ULR",
+						}},
+					}},
+				}},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{
+		EnableIngressValidation:   true,
+		EnableConfigMapValidation: true,
+		EnablePVCValidation:       true,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	hints := map[string]string{}
+	for _, finding := range validator.GetLastValidationErrors() {
+		hints[finding.ValidationType] = finding.RemediationHint
+	}
+
+	wantSuffixes := map[string]string{
+		"dangling_configmap_envfrom": "; did you mean 'app-config-prod'?",
+		"missing_configmap_key":      "; did you mean 'DATABASE_URL'?",
+		"dangling_ingress_class":     "; did you mean 'nginx'?",
+		"dangling_storage_class":     ". Available StorageClasses: gp3 (default), standard",
+	}
+	for validationType, suffix := range wantSuffixes {
+		hint, ok := hints[validationType]
+		if !ok {
+			t.Errorf("no %s finding, got %v", validationType, hints)
+			continue
+		}
+		if !strings.HasSuffix(hint, suffix) {
+			t.Errorf("%s hint = %q, want suffix %q", validationType, hint, suffix)
+		}
+	}
+}
+
+func TestReferenceValidator_SuggestedRefs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	objects := []client.Object{
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-config-prod", Namespace: "shop"}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app-conifg", Namespace: "shop"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:    "api",
+					EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-config"}}}},
+				}},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{EnableConfigMapValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.Register(validator)
+	result := registry.LastValidationResult()
+
+	want := []Reference{
+		{SourceType: "Pod", SourceName: "api", TargetType: "ConfigMap", TargetName: "app-conifg", Confidence: 0.8, Reason: "Existing ConfigMap within edit distance 2 of 'app-config'"},
+		{SourceType: "Pod", SourceName: "api", TargetType: "ConfigMap", TargetName: "app-config-prod", Confidence: 0.67, Reason: "Existing ConfigMap within edit distance 5 of 'app-config'"},
+	}
+	if !reflect.DeepEqual(result.SuggestedRefs, want) {
+		t.Errorf("SuggestedRefs = %+v, want %+v", result.SuggestedRefs, want)
+	}
+	if len(result.Summary.SuggestedRefs) != 2 || result.Summary.SuggestedRefs[0] != "Pod/api -> ConfigMap/app-conifg" {
+		t.Errorf("Summary.SuggestedRefs = %v", result.Summary.SuggestedRefs)
+	}
+
+	output, err := registry.FormatCIOutput(result)
+	if err != nil {
+		t.Fatalf("FormatCIOutput() error = %v", err)
+	}
+	if !strings.Contains(output, "- Pod/api -> ConfigMap/app-config-prod (confidence: 0.67)") {
+		t.Errorf("CI output does not list suggested references:\n%s", output)
+	}
+}
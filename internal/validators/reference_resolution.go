@@ -0,0 +1,281 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ExternalReferencesAnnotation lists (comma-separated, as Kind/name) the resources a
+// resource or pod template references that are created outside its config, such as
+// Secrets provisioned by an operator. Without a cluster to look them up in, file-only
+// validation reports them as expected in the cluster rather than dangling.
+const ExternalReferencesAnnotation = "kogaro.io/external-references"
+
+// Provenances of a reference in the reference resolution report
+const (
+	// ReferenceInBundle is a reference whose target the config defines, or creates through
+	// a resource it defines
+	ReferenceInBundle = "bundle"
+	// ReferenceInCluster is a reference whose target was found in the cluster, or is
+	// expected to exist there when no cluster was consulted
+	ReferenceInCluster = "cluster"
+	// ReferenceDangling is a reference whose target exists nowhere
+	ReferenceDangling = "dangling"
+)
+
+// ReferenceResolution reports where a reference made by a resource of a validated config
+// was resolved
+type ReferenceResolution struct {
+	SourceType string `json:"source_type"`
+	SourceName string `json:"source_name"`
+	Namespace  string `json:"namespace,omitempty"`
+	TargetType string `json:"target_type"`
+	TargetName string `json:"target_name"`
+	Provenance string `json:"provenance"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// String formats the reference as "SourceType/SourceName -> TargetType/TargetName"
+func (r ReferenceResolution) String() string {
+	return fmt.Sprintf("%s/%s -> %s/%s", r.SourceType, r.SourceName, r.TargetType, r.TargetName)
+}
+
+// clusterScopedReferenceKinds are the referenced kinds that are not namespaced
+var clusterScopedReferenceKinds = map[string]bool{
+	"IngressClass": true,
+	"StorageClass": true,
+}
+
+// clusterProvidedReferences explains, by kind, the references that are satisfied by the
+// cluster rather than by the configs deployed to it. Names are matched when given.
+var clusterProvidedReferences = []struct {
+	kind, name, reason string
+}{
+	{"IngressClass", "", "IngressClasses are installed with the cluster's ingress controllers"},
+	{"StorageClass", "", "StorageClasses are provided by the cluster's storage provisioners"},
+	{"ServiceAccount", "default", "every namespace has a default ServiceAccount"},
+	{"ConfigMap", "kube-root-ca.crt", "the cluster publishes its CA bundle to every namespace"},
+}
+
+// resourceReference is a reference from a resource to the resource it needs
+type resourceReference struct {
+	ReferenceResolution
+	// declared is set when the source lists the target in ExternalReferencesAnnotation
+	declared bool
+	// resolved is set when the target was found
+	resolved bool
+}
+
+// referenceRecorderKey is the context key of a referenceRecorder
+type referenceRecorderKey struct{}
+
+// referenceRecorder collects the references validators resolve while a config is
+// validated, so that the registry can report where each was resolved
+type referenceRecorder struct {
+	mu         sync.Mutex
+	references map[string]resourceReference
+}
+
+// withReferenceRecorder returns a context whose validators record the references they
+// resolve in recorder
+func withReferenceRecorder(ctx context.Context, recorder *referenceRecorder) context.Context {
+	return context.WithValue(ctx, referenceRecorderKey{}, recorder)
+}
+
+// recordReference notes that a resource referenced targetType/targetName, and whether
+// the target was found, when ctx records references. annotations are those of the
+// source, which may declare the target external.
+func recordReference(ctx context.Context, sourceType, sourceName, namespace string, annotations map[string]string, targetType, targetName string, resolved bool) {
+	recorder, ok := ctx.Value(referenceRecorderKey{}).(*referenceRecorder)
+	if !ok || targetName == "" {
+		return
+	}
+
+	reference := resourceReference{
+		ReferenceResolution: ReferenceResolution{
+			SourceType: sourceType,
+			SourceName: sourceName,
+			Namespace:  namespace,
+			TargetType: targetType,
+			TargetName: targetName,
+		},
+		declared: externalReferences(annotations)[targetType+"/"+targetName],
+		resolved: resolved,
+	}
+	key := namespace + "/" + reference.String()
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	if recorder.references == nil {
+		recorder.references = make(map[string]resourceReference)
+	}
+	// A target found by any lookup is resolved
+	if previous, ok := recorder.references[key]; ok && previous.resolved {
+		return
+	}
+	recorder.references[key] = reference
+}
+
+// externalReferences parses the external-references annotation into a set of Kind/name
+func externalReferences(annotations map[string]string) map[string]bool {
+	declared := make(map[string]bool)
+	for _, reference := range strings.Split(annotations[ExternalReferencesAnnotation], ",") {
+		if reference = strings.TrimSpace(reference); reference != "" {
+			declared[reference] = true
+		}
+	}
+	return declared
+}
+
+// report classifies the recorded references made by the resources of a config. Found
+// targets the config defines are in the bundle and others were found in the cluster.
+// Missing targets are in the bundle when a resource of the config creates them; when
+// clusterConsulted is false, those the cluster provides or the source declares
+// external are expected in the cluster. Everything else is dangling.
+func (r *referenceRecorder) report(documents []configDocument, clusterConsulted bool) []ReferenceResolution {
+	bundle := newBundleIndex(documents)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := make([]ReferenceResolution, 0, len(r.references))
+	for _, reference := range r.references {
+		if !bundle.defines(reference.SourceType, reference.Namespace, reference.SourceName) {
+			continue
+		}
+		resolution := reference.ReferenceResolution
+		targetNamespace := reference.Namespace
+		if clusterScopedReferenceKinds[reference.TargetType] {
+			targetNamespace = ""
+		}
+
+		switch {
+		case reference.resolved && (!clusterConsulted || bundle.defines(reference.TargetType, targetNamespace, reference.TargetName)):
+			resolution.Provenance = ReferenceInBundle
+		case reference.resolved:
+			resolution.Provenance = ReferenceInCluster
+			resolution.Reason = "found in the cluster"
+		case bundle.creates(reference.TargetType, targetNamespace, reference.TargetName) != "":
+			resolution.Provenance = ReferenceInBundle
+			resolution.Reason = "created from " + bundle.creates(reference.TargetType, targetNamespace, reference.TargetName)
+		case !clusterConsulted && reference.declared:
+			resolution.Provenance = ReferenceInCluster
+			resolution.Reason = "declared in " + ExternalReferencesAnnotation
+		case !clusterConsulted && clusterProvidedReason(reference.TargetType, reference.TargetName) != "":
+			resolution.Provenance = ReferenceInCluster
+			resolution.Reason = clusterProvidedReason(reference.TargetType, reference.TargetName)
+		default:
+			resolution.Provenance = ReferenceDangling
+			if !clusterConsulted {
+				resolution.Reason = "not defined in the config"
+			}
+		}
+		report = append(report, resolution)
+	}
+
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.TargetType+"/"+a.TargetName != b.TargetType+"/"+b.TargetName {
+			return a.TargetType+"/"+a.TargetName < b.TargetType+"/"+b.TargetName
+		}
+		return a.SourceType+"/"+a.SourceName < b.SourceType+"/"+b.SourceName
+	})
+	return report
+}
+
+// countReferences counts references by provenance
+func countReferences(references []ReferenceResolution) (bundle, cluster, dangling int) {
+	for _, reference := range references {
+		switch reference.Provenance {
+		case ReferenceInBundle:
+			bundle++
+		case ReferenceInCluster:
+			cluster++
+		default:
+			dangling++
+		}
+	}
+	return bundle, cluster, dangling
+}
+
+// clusterProvidedReason returns why the cluster provides kind/name, or "" if it does not
+func clusterProvidedReason(kind, name string) string {
+	for _, provided := range clusterProvidedReferences {
+		if provided.kind == kind && (provided.name == "" || provided.name == name) {
+			return provided.reason
+		}
+	}
+	return ""
+}
+
+// bundleIndex indexes the resources a config defines, and the Secrets its resources
+// have controllers create, by Kind/namespace/name
+type bundleIndex struct {
+	defined map[string]bool
+	created map[string]string
+}
+
+// newBundleIndex indexes the resources of a config. Secrets are created from
+// cert-manager Certificates, ExternalSecrets and SealedSecrets.
+func newBundleIndex(documents []configDocument) bundleIndex {
+	index := bundleIndex{defined: make(map[string]bool), created: make(map[string]string)}
+	for _, document := range documents {
+		obj := document.Object
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		index.defined[bundleKey(kind, obj.GetNamespace(), obj.GetName())] = true
+
+		u, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			continue
+		}
+		secretName := ""
+		switch u.GroupVersionKind().GroupKind().String() {
+		case "Certificate.cert-manager.io":
+			secretName, _, _ = unstructured.NestedString(u.Object, "spec", "secretName")
+		case "ExternalSecret.external-secrets.io":
+			secretName, _, _ = unstructured.NestedString(u.Object, "spec", "target", "name")
+			if secretName == "" {
+				secretName = u.GetName()
+			}
+		case "SealedSecret.bitnami.com":
+			secretName = u.GetName()
+		}
+		if secretName != "" {
+			index.created[bundleKey("Secret", u.GetNamespace(), secretName)] = fmt.Sprintf("%s '%s'", kind, u.GetName())
+		}
+	}
+	return index
+}
+
+// bundleKey identifies a resource of a config by kind, namespace and name
+func bundleKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// defines reports whether the config defines kind/name in namespace. Resources of the
+// config without a namespace match any namespace, since they are applied to one.
+func (b bundleIndex) defines(kind, namespace, name string) bool {
+	return b.defined[bundleKey(kind, namespace, name)] || b.defined[bundleKey(kind, "", name)]
+}
+
+// creates returns the resource of the config a controller creates kind/name in namespace
+// from, or "" if there is none
+func (b bundleIndex) creates(kind, namespace, name string) string {
+	if creator, ok := b.created[bundleKey(kind, namespace, name)]; ok {
+		return creator
+	}
+	return b.created[bundleKey(kind, "", name)]
+}
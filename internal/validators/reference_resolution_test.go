@@ -0,0 +1,196 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// referenceBundle is a config whose Deployment references Secrets and ConfigMaps that
+// the config defines, creates, declares external or leaves dangling
+const referenceBundle = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: shop
+data:
+  mode: live
+---
+apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: web-cert
+  namespace: shop
+spec:
+  secretName: web-tls
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+      annotations:
+        kogaro.io/external-references: Secret/vault-token
+    spec:
+      containers:
+      - name: web
+        image: web:1.0
+        envFrom:
+        - configMapRef:
+            name: settings
+        - secretRef:
+            name: vault-token
+        - secretRef:
+            name: db-creds
+      volumes:
+      - name: tls
+        secret:
+          secretName: web-tls
+      - name: ca
+        configMap:
+          name: kube-root-ca.crt
+`
+
+// referenceValidatorForReport returns a registry whose only validator checks Secret,
+// ConfigMap and ServiceAccount references
+func referenceValidatorForReport(cluster *fake.ClientBuilder) *ValidatorRegistry {
+	registry := NewValidatorRegistry(logr.Discard(), cluster.Build())
+	registry.Register(NewReferenceValidator(nil, logr.Discard(), ValidationConfig{
+		EnableConfigMapValidation:      true,
+		EnableSecretValidation:         true,
+		EnableServiceAccountValidation: true,
+	}))
+	return registry
+}
+
+// provenances maps each reported reference to its provenance and reason
+func provenances(references []ReferenceResolution) map[string]string {
+	got := make(map[string]string)
+	for _, ref := range references {
+		got[ref.TargetType+"/"+ref.TargetName] = ref.Provenance + ": " + ref.Reason
+	}
+	return got
+}
+
+func TestValidateFileOnly_ReportsReferenceResolution(t *testing.T) {
+	registry := referenceValidatorForReport(fake.NewClientBuilder())
+
+	result, err := registry.ValidateFileOnlyData(context.Background(), "shop.yaml", []byte(referenceBundle))
+	if err != nil {
+		t.Fatalf("ValidateFileOnlyData() error = %v", err)
+	}
+
+	want := map[string]string{
+		"ConfigMap/settings":         "bundle: ",
+		"Secret/web-tls":             "bundle: created from Certificate 'web-cert'",
+		"Secret/vault-token":         "cluster: declared in kogaro.io/external-references",
+		"ConfigMap/kube-root-ca.crt": "cluster: the cluster publishes its CA bundle to every namespace",
+		"ServiceAccount/default":     "cluster: every namespace has a default ServiceAccount",
+		"Secret/db-creds":            "dangling: not defined in the config",
+	}
+	got := provenances(result.References)
+	if len(got) != len(want) {
+		t.Errorf("references = %v, want %v", got, want)
+	}
+	for target, provenance := range want {
+		if got[target] != provenance {
+			t.Errorf("%s = %q, want %q", target, got[target], provenance)
+		}
+	}
+	for _, ref := range result.References {
+		if ref.SourceType != "Deployment" || ref.SourceName != "web" || ref.Namespace != "shop" {
+			t.Errorf("reference %+v, want it made by Deployment shop/web", ref)
+		}
+	}
+
+	output, err := registry.FormatCIOutput(*result)
+	if err != nil {
+		t.Fatalf("FormatCIOutput() error = %v", err)
+	}
+	for _, line := range []string{
+		"Reference Resolution: 2 in bundle, 3 in cluster, 1 dangling",
+		"- [dangling] Deployment/web -> Secret/db-creds in namespace shop: not defined in the config",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("CI output does not contain %q:\n%s", line, output)
+		}
+	}
+}
+
+func TestValidateNewConfigWithScope_ReportsReferenceResolution(t *testing.T) {
+	cluster := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-creds", Namespace: "shop"}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "shop"}},
+	)
+	registry := referenceValidatorForReport(cluster)
+
+	result, err := registry.ValidateNewConfigWithScopeAndData(context.Background(), "-", "file-only", []byte(referenceBundle))
+	if err != nil {
+		t.Fatalf("ValidateNewConfigWithScopeAndData() error = %v", err)
+	}
+
+	// With a cluster to look them up in, missing targets are dangling whatever the
+	// config declares
+	want := map[string]string{
+		"ConfigMap/settings":         "bundle: ",
+		"Secret/web-tls":             "bundle: created from Certificate 'web-cert'",
+		"Secret/db-creds":            "cluster: found in the cluster",
+		"ServiceAccount/default":     "cluster: found in the cluster",
+		"Secret/vault-token":         "dangling: ",
+		"ConfigMap/kube-root-ca.crt": "dangling: ",
+	}
+	got := provenances(result.References)
+	if len(got) != len(want) {
+		t.Errorf("references = %v, want %v", got, want)
+	}
+	for target, provenance := range want {
+		if got[target] != provenance {
+			t.Errorf("%s = %q, want %q", target, got[target], provenance)
+		}
+	}
+}
+
+func TestReferenceRecorder_ReportsOnlyConfigResources(t *testing.T) {
+	documents, err := parseConfigDocuments([]byte(referenceBundle))
+	if err != nil {
+		t.Fatalf("parseConfigDocuments() error = %v", err)
+	}
+
+	recorder := &referenceRecorder{}
+	ctx := withReferenceRecorder(context.Background(), recorder)
+	recordReference(ctx, "Deployment", "legacy", "shop", nil, "Secret", "db-creds", false)
+	recordReference(ctx, "Deployment", "web", "shop", nil, "Secret", "db-creds", false)
+	recordReference(ctx, "Pod", "web-1", "shop", nil, "Secret", "db-creds", true)
+	// A target found by one lookup stays resolved when another misses it
+	recordReference(ctx, "Deployment", "web", "shop", nil, "ConfigMap", "settings", true)
+	recordReference(ctx, "Deployment", "web", "shop", nil, "ConfigMap", "settings", false)
+	// Without a recorder on the context nothing is recorded
+	recordReference(context.Background(), "Deployment", "web", "shop", nil, "Secret", "other", false)
+
+	got := provenances(recorder.report(documents, true))
+	want := map[string]string{
+		"Secret/db-creds":    "dangling: ",
+		"ConfigMap/settings": "bundle: ",
+	}
+	if len(got) != len(want) || got["Secret/db-creds"] != want["Secret/db-creds"] || got["ConfigMap/settings"] != want["ConfigMap/settings"] {
+		t.Errorf("report = %v, want %v", got, want)
+	}
+}
@@ -8,23 +8,37 @@
 // This package implements comprehensive validation of resource references within
 // a Kubernetes cluster, detecting dangling references that could cause silent
 // failures in applications. It supports validation of Ingress, ConfigMap, Secret,
-// PVC, and ServiceAccount references with configurable validation rules.
+// PVC, and ServiceAccount references with configurable validation rules, and
+// reports resources that exist but are no longer referenced.
 package validators
 
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/topiaruss/kogaro/internal/metrics"
 )
 
+// secretsStoreCSIDriver is the name of the Secrets Store CSI driver
+const secretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+
+// secretProviderClassGVK identifies the SecretProviderClass resource of the Secrets Store CSI driver
+var secretProviderClassGVK = schema.GroupVersionKind{Group: "secrets-store.csi.x-k8s.io", Version: "v1", Kind: "SecretProviderClass"}
+
 // ValidationConfig defines which types of validation checks to perform
 type ValidationConfig struct {
 	EnableIngressValidation        bool
@@ -32,6 +46,10 @@ type ValidationConfig struct {
 	EnableSecretValidation         bool
 	EnablePVCValidation            bool
 	EnableServiceAccountValidation bool
+	EnableUnusedResourceValidation bool
+	EnableDownwardAPIValidation    bool
+	// UnusedResourceMinAge is the age a resource must reach before it may be reported as unused
+	UnusedResourceMinAge time.Duration
 }
 
 // ReferenceValidator validates Kubernetes resource references across the cluster
@@ -55,7 +73,7 @@ func NewReferenceValidator(client client.Client, log logr.Logger, config Validat
 		client:       client,
 		log:          log.WithName("reference-validator"),
 		config:       config,
-		sharedConfig: DefaultSharedConfig(),
+		sharedConfig: ActiveSharedConfig(),
 	}
 }
 
@@ -116,6 +134,15 @@ func (v *ReferenceValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, pvcErrors...)
 	}
 
+	// Validate Downward API field references
+	if v.config.EnableDownwardAPIValidation {
+		downwardAPIErrors, err := v.validateDownwardAPIReferences(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate downward API references: %w", err)
+		}
+		allErrors = append(allErrors, downwardAPIErrors...)
+	}
+
 	// Validate ServiceAccount references
 	if v.config.EnableServiceAccountValidation {
 		saErrors, err := v.validateServiceAccountReferences(ctx)
@@ -125,6 +152,24 @@ func (v *ReferenceValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, saErrors...)
 	}
 
+	// Detect resources that nothing references
+	if v.config.EnableUnusedResourceValidation {
+		unusedErrors, err := v.validateUnusedResources(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate unused resources: %w", err)
+		}
+		allErrors = append(allErrors, unusedErrors...)
+	}
+
+	// Report findings on controller-owned pods against their workloads
+	allErrors, err := attributePodFindings(ctx, v.client, allErrors)
+	if err != nil {
+		return fmt.Errorf("failed to attribute pod findings to workloads: %w", err)
+	}
+
+	// Suggest existing resources for dangling references
+	allErrors = v.addReferenceHints(ctx, allErrors)
+
 	// Log all validation errors and update metrics
 	LogAndRecordErrors(v.logReceiver, "reference", allErrors)
 
@@ -165,8 +210,9 @@ func (v *ReferenceValidator) validateIngressReferences(ctx context.Context) ([]V
 
 		if ingress.Spec.IngressClassName != nil {
 			className := *ingress.Spec.IngressClassName
+			recordReference(ctx, "Ingress", ingress.Name, ingress.Namespace, ingress.Annotations, "IngressClass", className, existingClasses[className])
 			if !existingClasses[className] {
-				errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_ingress_class", "KOGARO-REF-001", fmt.Sprintf("IngressClass '%s' does not exist", className)).
+				errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_ingress_class", GetReferenceErrorCode("dangling_ingress_class"), fmt.Sprintf("IngressClass '%s' does not exist", className)).
 					WithSeverity(SeverityError).
 					WithRemediationHint(fmt.Sprintf("Create IngressClass '%s' or update Ingress to use an existing IngressClass", className)).
 					WithRelatedResources(fmt.Sprintf("IngressClass/%s", className)).
@@ -186,9 +232,10 @@ func (v *ReferenceValidator) validateIngressReferences(ctx context.Context) ([]V
 						Name:      serviceName,
 						Namespace: ingress.Namespace,
 					}, &service)
+					recordReference(ctx, "Ingress", ingress.Name, ingress.Namespace, ingress.Annotations, "Service", serviceName, err == nil)
 
 					if err != nil {
-						errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_service_reference", "KOGARO-REF-002", fmt.Sprintf("Service '%s' referenced in Ingress does not exist", serviceName)).
+						errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_service_reference", GetReferenceErrorCode("dangling_service_reference"), fmt.Sprintf("Service '%s' referenced in Ingress does not exist", serviceName)).
 							WithSeverity(SeverityError).
 							WithRemediationHint(fmt.Sprintf("Create Service '%s' in namespace '%s' or update Ingress to reference an existing Service", serviceName, ingress.Namespace)).
 							WithRelatedResources(fmt.Sprintf("Service/%s", serviceName)).
@@ -206,48 +253,147 @@ func (v *ReferenceValidator) validateIngressReferences(ctx context.Context) ([]V
 func (v *ReferenceValidator) validateConfigMapReferences(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
 
-	// Get all Pods to check ConfigMap references
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+	// Get all Pods and workload templates to check ConfigMap references
+	sources, err := v.listPodSpecs(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, pod := range pods.Items {
-		// Skip system namespaces
-		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
-		}
-
-		// Check ConfigMap references in volumes
-		for _, volume := range pod.Spec.Volumes {
+	for _, source := range sources {
+		// Check ConfigMap references in volumes, including projected item keys
+		for _, volume := range source.spec.Volumes {
 			if volume.ConfigMap != nil {
 				configMapName := volume.ConfigMap.Name
-				if err := v.validateConfigMapExists(ctx, configMapName, pod.Namespace); err != nil {
-					errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_configmap_volume", "KOGARO-REF-003", fmt.Sprintf("ConfigMap '%s' referenced in volume does not exist", configMapName)).
+				optional := volume.ConfigMap.Optional != nil && *volume.ConfigMap.Optional
+				configMap, err := v.getConfigMap(ctx, configMapName, source.namespace)
+				if err == nil || !optional {
+					source.record(ctx, "ConfigMap", configMapName, err)
+				}
+				if err != nil {
+					if !optional {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_configmap_volume", GetReferenceErrorCode("dangling_configmap_volume"), fmt.Sprintf("ConfigMap '%s' referenced in volume does not exist", configMapName)).
+							WithSeverity(SeverityError).
+							WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s' or update the volume reference to use an existing ConfigMap", configMapName, source.namespace)).
+							WithRelatedResources(fmt.Sprintf("ConfigMap/%s", configMapName)).
+							WithDetail("missing_configmap", configMapName).
+							WithDetail("volume_name", volume.Name))
+					}
+					continue
+				}
+
+				if optional {
+					continue
+				}
+				for _, item := range volume.ConfigMap.Items {
+					if configMapHasKey(configMap, item.Key) {
+						continue
+					}
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_configmap_key", GetReferenceErrorCode("missing_configmap_key"), fmt.Sprintf("Key '%s' referenced in volume does not exist in ConfigMap '%s'", item.Key, configMapName)).
+						WithSeverity(SeverityError).
+						WithRemediationHint(fmt.Sprintf("Add key '%s' to ConfigMap '%s' or update the volume items to reference an existing key", item.Key, configMapName)).
+						WithRelatedResources(fmt.Sprintf("ConfigMap/%s", configMapName)).
+						WithDetail("configmap_name", configMapName).
+						WithDetail("missing_key", item.Key).
+						WithDetail("volume_name", volume.Name))
+				}
+			}
+
+			if volume.Projected == nil {
+				continue
+			}
+			for _, projection := range volume.Projected.Sources {
+				if projection.ConfigMap == nil {
+					continue
+				}
+				configMapName := projection.ConfigMap.Name
+				optional := projection.ConfigMap.Optional != nil && *projection.ConfigMap.Optional
+				configMap, err := v.getConfigMap(ctx, configMapName, source.namespace)
+				if err == nil || !optional {
+					source.record(ctx, "ConfigMap", configMapName, err)
+				}
+				if err != nil {
+					if !optional {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_projected_configmap", GetReferenceErrorCode("dangling_projected_configmap"), fmt.Sprintf("ConfigMap '%s' referenced in projected volume does not exist", configMapName)).
+							WithSeverity(SeverityError).
+							WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s', mark the projection optional or remove it from the projected volume", configMapName, source.namespace)).
+							WithRelatedResources(fmt.Sprintf("ConfigMap/%s", configMapName)).
+							WithDetail("missing_configmap", configMapName).
+							WithDetail("volume_name", volume.Name))
+					}
+					continue
+				}
+
+				if optional {
+					continue
+				}
+				for _, item := range projection.ConfigMap.Items {
+					if configMapHasKey(configMap, item.Key) {
+						continue
+					}
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_configmap_key", GetReferenceErrorCode("missing_configmap_key"), fmt.Sprintf("Key '%s' referenced in projected volume does not exist in ConfigMap '%s'", item.Key, configMapName)).
 						WithSeverity(SeverityError).
-						WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s' or update the volume reference to use an existing ConfigMap", configMapName, pod.Namespace)).
+						WithRemediationHint(fmt.Sprintf("Add key '%s' to ConfigMap '%s' or update the projected volume items to reference an existing key", item.Key, configMapName)).
 						WithRelatedResources(fmt.Sprintf("ConfigMap/%s", configMapName)).
-						WithDetail("missing_configmap", configMapName).
+						WithDetail("configmap_name", configMapName).
+						WithDetail("missing_key", item.Key).
 						WithDetail("volume_name", volume.Name))
 				}
 			}
 		}
 
 		// Check ConfigMap references in envFrom
-		for _, container := range pod.Spec.Containers {
+		for _, container := range source.spec.Containers {
 			for _, envFrom := range container.EnvFrom {
 				if envFrom.ConfigMapRef != nil {
 					configMapName := envFrom.ConfigMapRef.Name
-					if err := v.validateConfigMapExists(ctx, configMapName, pod.Namespace); err != nil {
-						errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_configmap_envfrom", "KOGARO-REF-004", fmt.Sprintf("ConfigMap '%s' referenced in envFrom does not exist", configMapName)).
+					err := v.validateConfigMapExists(ctx, configMapName, source.namespace)
+					source.record(ctx, "ConfigMap", configMapName, err)
+					if err != nil {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_configmap_envfrom", GetReferenceErrorCode("dangling_configmap_envfrom"), fmt.Sprintf("ConfigMap '%s' referenced in envFrom does not exist", configMapName)).
 							WithSeverity(SeverityError).
-							WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s' or update the envFrom reference to use an existing ConfigMap", configMapName, pod.Namespace)).
+							WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s' or update the envFrom reference to use an existing ConfigMap", configMapName, source.namespace)).
 							WithRelatedResources(fmt.Sprintf("ConfigMap/%s", configMapName)).
 							WithDetail("missing_configmap", configMapName).
 							WithDetail("container_name", container.Name))
 					}
 				}
 			}
+
+			for _, env := range container.Env {
+				if env.ValueFrom == nil || env.ValueFrom.ConfigMapKeyRef == nil {
+					continue
+				}
+				keyRef := env.ValueFrom.ConfigMapKeyRef
+				optional := keyRef.Optional != nil && *keyRef.Optional
+				configMap, err := v.getConfigMap(ctx, keyRef.Name, source.namespace)
+				if err == nil || !optional {
+					source.record(ctx, "ConfigMap", keyRef.Name, err)
+				}
+				if err != nil {
+					if !optional {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_configmap_env", GetReferenceErrorCode("dangling_configmap_env"), fmt.Sprintf("ConfigMap '%s' referenced in env does not exist", keyRef.Name)).
+							WithSeverity(SeverityError).
+							WithRemediationHint(fmt.Sprintf("Create ConfigMap '%s' in namespace '%s' or update the env reference to use an existing ConfigMap", keyRef.Name, source.namespace)).
+							WithRelatedResources(fmt.Sprintf("ConfigMap/%s", keyRef.Name)).
+							WithDetail("missing_configmap", keyRef.Name).
+							WithDetail("container_name", container.Name).
+							WithDetail("env_var_name", env.Name))
+					}
+					continue
+				}
+
+				if optional || configMapHasKey(configMap, keyRef.Key) {
+					continue
+				}
+				errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_configmap_key", GetReferenceErrorCode("missing_configmap_key"), fmt.Sprintf("Key '%s' referenced in env does not exist in ConfigMap '%s'", keyRef.Key, keyRef.Name)).
+					WithSeverity(SeverityError).
+					WithRemediationHint(fmt.Sprintf("Add key '%s' to ConfigMap '%s' or update the configMapKeyRef to reference an existing key", keyRef.Key, keyRef.Name)).
+					WithRelatedResources(fmt.Sprintf("ConfigMap/%s", keyRef.Name)).
+					WithDetail("configmap_name", keyRef.Name).
+					WithDetail("missing_key", keyRef.Key).
+					WithDetail("container_name", container.Name).
+					WithDetail("env_var_name", env.Name))
+			}
 		}
 	}
 
@@ -262,45 +408,154 @@ func (v *ReferenceValidator) validateConfigMapExists(ctx context.Context, name,
 	}, &configMap)
 }
 
+func (v *ReferenceValidator) getConfigMap(ctx context.Context, name, namespace string) (*corev1.ConfigMap, error) {
+	var configMap corev1.ConfigMap
+	if err := v.client.Get(ctx, types.NamespacedName{
+		Name:      name,
+		Namespace: namespace,
+	}, &configMap); err != nil {
+		return nil, err
+	}
+	return &configMap, nil
+}
+
+// configMapHasKey reports whether key is present in either the Data or BinaryData of the ConfigMap
+func configMapHasKey(configMap *corev1.ConfigMap, key string) bool {
+	if _, ok := configMap.Data[key]; ok {
+		return true
+	}
+	_, ok := configMap.BinaryData[key]
+	return ok
+}
+
 func (v *ReferenceValidator) validateSecretReferences(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
 
-	// Get all Pods to check Secret references
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+	// Get all Pods and workload templates to check Secret references
+	sources, err := v.listPodSpecs(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, pod := range pods.Items {
-		// Skip system namespaces
-		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
-		}
+	for _, source := range sources {
+		checkedTokenServiceAccount := false
 
 		// Check Secret references in volumes
-		for _, volume := range pod.Spec.Volumes {
+		for _, volume := range source.spec.Volumes {
 			if volume.Secret != nil {
 				secretName := volume.Secret.SecretName
-				if err := v.validateSecretExists(ctx, secretName, pod.Namespace); err != nil {
-					errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_secret_volume", "KOGARO-REF-005", fmt.Sprintf("Secret '%s' referenced in volume does not exist", secretName)).
+				optional := volume.Secret.Optional != nil && *volume.Secret.Optional
+				secret, err := v.getSecret(ctx, secretName, source.namespace)
+				if err == nil || !optional {
+					source.record(ctx, "Secret", secretName, err)
+				}
+				if err != nil {
+					if !optional {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_secret_volume", GetReferenceErrorCode("dangling_secret_volume"), fmt.Sprintf("Secret '%s' referenced in volume does not exist", secretName)).
+							WithSeverity(SeverityError).
+							WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the volume reference to use an existing Secret", secretName, source.namespace)).
+							WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
+							WithDetail("missing_secret", secretName).
+							WithDetail("volume_name", volume.Name))
+					}
+					continue
+				}
+
+				if optional {
+					continue
+				}
+				for _, item := range volume.Secret.Items {
+					if secretHasKey(secret, item.Key) {
+						continue
+					}
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_secret_key", GetReferenceErrorCode("missing_secret_key"), fmt.Sprintf("Key '%s' referenced in volume does not exist in Secret '%s'", item.Key, secretName)).
 						WithSeverity(SeverityError).
-						WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the volume reference to use an existing Secret", secretName, pod.Namespace)).
+						WithRemediationHint(fmt.Sprintf("Add key '%s' to Secret '%s' or update the volume items to reference an existing key", item.Key, secretName)).
 						WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
-						WithDetail("missing_secret", secretName).
+						WithDetail("secret_name", secretName).
+						WithDetail("missing_key", item.Key).
+						WithDetail("volume_name", volume.Name))
+				}
+			}
+
+			if volume.CSI != nil && volume.CSI.Driver == secretsStoreCSIDriver {
+				errors = append(errors, v.validateSecretProviderClass(ctx, source, volume)...)
+			}
+
+			if volume.Projected == nil {
+				continue
+			}
+			for _, projection := range volume.Projected.Sources {
+				if projection.ServiceAccountToken != nil && !checkedTokenServiceAccount {
+					checkedTokenServiceAccount = true
+					errors = append(errors, v.validateTokenServiceAccount(ctx, source, volume)...)
+				}
+				if projection.Secret == nil {
+					continue
+				}
+				secretName := projection.Secret.Name
+				optional := projection.Secret.Optional != nil && *projection.Secret.Optional
+				secret, err := v.getSecret(ctx, secretName, source.namespace)
+				if err == nil || !optional {
+					source.record(ctx, "Secret", secretName, err)
+				}
+				if err != nil {
+					if !optional {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_projected_secret", GetReferenceErrorCode("dangling_projected_secret"), fmt.Sprintf("Secret '%s' referenced in projected volume does not exist", secretName)).
+							WithSeverity(SeverityError).
+							WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s', mark the projection optional or remove it from the projected volume", secretName, source.namespace)).
+							WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
+							WithDetail("missing_secret", secretName).
+							WithDetail("volume_name", volume.Name))
+					}
+					continue
+				}
+
+				if optional {
+					continue
+				}
+				for _, item := range projection.Secret.Items {
+					if secretHasKey(secret, item.Key) {
+						continue
+					}
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_secret_key", GetReferenceErrorCode("missing_secret_key"), fmt.Sprintf("Key '%s' referenced in projected volume does not exist in Secret '%s'", item.Key, secretName)).
+						WithSeverity(SeverityError).
+						WithRemediationHint(fmt.Sprintf("Add key '%s' to Secret '%s' or update the projected volume items to reference an existing key", item.Key, secretName)).
+						WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
+						WithDetail("secret_name", secretName).
+						WithDetail("missing_key", item.Key).
 						WithDetail("volume_name", volume.Name))
 				}
 			}
 		}
 
+		// Check image pull Secrets
+		for _, pullSecret := range source.spec.ImagePullSecrets {
+			if pullSecret.Name == "" {
+				continue
+			}
+			err := v.validateSecretExists(ctx, pullSecret.Name, source.namespace)
+			source.record(ctx, "Secret", pullSecret.Name, err)
+			if err != nil {
+				errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_image_pull_secret", GetReferenceErrorCode("dangling_image_pull_secret"), fmt.Sprintf("Image pull Secret '%s' does not exist", pullSecret.Name)).
+					WithSeverity(SeverityError).
+					WithRemediationHint(fmt.Sprintf("Create docker-registry Secret '%s' in namespace '%s' or remove it from imagePullSecrets", pullSecret.Name, source.namespace)).
+					WithRelatedResources(fmt.Sprintf("Secret/%s", pullSecret.Name)).
+					WithDetail("missing_secret", pullSecret.Name))
+			}
+		}
+
 		// Check Secret references in envFrom and env
-		for _, container := range pod.Spec.Containers {
+		for _, container := range source.spec.Containers {
 			for _, envFrom := range container.EnvFrom {
 				if envFrom.SecretRef != nil {
 					secretName := envFrom.SecretRef.Name
-					if err := v.validateSecretExists(ctx, secretName, pod.Namespace); err != nil {
-						errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_secret_envfrom", "KOGARO-REF-006", fmt.Sprintf("Secret '%s' referenced in envFrom does not exist", secretName)).
+					err := v.validateSecretExists(ctx, secretName, source.namespace)
+					source.record(ctx, "Secret", secretName, err)
+					if err != nil {
+						errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_secret_envfrom", GetReferenceErrorCode("dangling_secret_envfrom"), fmt.Sprintf("Secret '%s' referenced in envFrom does not exist", secretName)).
 							WithSeverity(SeverityError).
-							WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the envFrom reference to use an existing Secret", secretName, pod.Namespace)).
+							WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the envFrom reference to use an existing Secret", secretName, source.namespace)).
 							WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
 							WithDetail("missing_secret", secretName).
 							WithDetail("container_name", container.Name))
@@ -310,16 +565,37 @@ func (v *ReferenceValidator) validateSecretReferences(ctx context.Context) ([]Va
 
 			for _, env := range container.Env {
 				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
-					secretName := env.ValueFrom.SecretKeyRef.Name
-					if err := v.validateSecretExists(ctx, secretName, pod.Namespace); err != nil {
-						errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_secret_env", "KOGARO-REF-007", fmt.Sprintf("Secret '%s' referenced in env does not exist", secretName)).
-							WithSeverity(SeverityError).
-							WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the env reference to use an existing Secret", secretName, pod.Namespace)).
-							WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
-							WithDetail("missing_secret", secretName).
-							WithDetail("container_name", container.Name).
-							WithDetail("env_var_name", env.Name))
+					keyRef := env.ValueFrom.SecretKeyRef
+					secretName := keyRef.Name
+					optional := keyRef.Optional != nil && *keyRef.Optional
+					secret, err := v.getSecret(ctx, secretName, source.namespace)
+					if err == nil || !optional {
+						source.record(ctx, "Secret", secretName, err)
 					}
+					if err != nil {
+						if !optional {
+							errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_secret_env", GetReferenceErrorCode("dangling_secret_env"), fmt.Sprintf("Secret '%s' referenced in env does not exist", secretName)).
+								WithSeverity(SeverityError).
+								WithRemediationHint(fmt.Sprintf("Create Secret '%s' in namespace '%s' or update the env reference to use an existing Secret", secretName, source.namespace)).
+								WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
+								WithDetail("missing_secret", secretName).
+								WithDetail("container_name", container.Name).
+								WithDetail("env_var_name", env.Name))
+						}
+						continue
+					}
+
+					if optional || secretHasKey(secret, keyRef.Key) {
+						continue
+					}
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "missing_secret_key", GetReferenceErrorCode("missing_secret_key"), fmt.Sprintf("Key '%s' referenced in env does not exist in Secret '%s'", keyRef.Key, secretName)).
+						WithSeverity(SeverityError).
+						WithRemediationHint(fmt.Sprintf("Add key '%s' to Secret '%s' or update the secretKeyRef to reference an existing key", keyRef.Key, secretName)).
+						WithRelatedResources(fmt.Sprintf("Secret/%s", secretName)).
+						WithDetail("secret_name", secretName).
+						WithDetail("missing_key", keyRef.Key).
+						WithDetail("container_name", container.Name).
+						WithDetail("env_var_name", env.Name))
 				}
 			}
 		}
@@ -339,8 +615,10 @@ func (v *ReferenceValidator) validateSecretReferences(ctx context.Context) ([]Va
 
 		for _, tls := range ingress.Spec.TLS {
 			if tls.SecretName != "" {
-				if err := v.validateSecretExists(ctx, tls.SecretName, ingress.Namespace); err != nil {
-					errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_tls_secret", "KOGARO-REF-008", fmt.Sprintf("TLS Secret '%s' referenced in Ingress does not exist", tls.SecretName)).
+				err := v.validateSecretExists(ctx, tls.SecretName, ingress.Namespace)
+				recordReference(ctx, "Ingress", ingress.Name, ingress.Namespace, ingress.Annotations, "Secret", tls.SecretName, err == nil)
+				if err != nil {
+					errors = append(errors, NewValidationErrorWithCode("Ingress", ingress.Name, ingress.Namespace, "dangling_tls_secret", GetReferenceErrorCode("dangling_tls_secret"), fmt.Sprintf("TLS Secret '%s' referenced in Ingress does not exist", tls.SecretName)).
 						WithSeverity(SeverityError).
 						WithRemediationHint(fmt.Sprintf("Create TLS Secret '%s' in namespace '%s' or update the Ingress TLS configuration to use an existing Secret", tls.SecretName, ingress.Namespace)).
 						WithRelatedResources(fmt.Sprintf("Secret/%s", tls.SecretName)).
@@ -354,6 +632,56 @@ func (v *ReferenceValidator) validateSecretReferences(ctx context.Context) ([]Va
 	return errors, nil
 }
 
+// validateSecretProviderClass checks that the SecretProviderClass a secrets-store CSI
+// volume mounts exists in the namespace of the pod
+func (v *ReferenceValidator) validateSecretProviderClass(ctx context.Context, source podSpecSource, volume corev1.Volume) []ValidationError {
+	className := volume.CSI.VolumeAttributes["secretProviderClass"]
+	if className == "" {
+		return []ValidationError{NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_secret_provider_class", GetReferenceErrorCode("dangling_secret_provider_class"), fmt.Sprintf("CSI volume '%s' of the secrets-store driver names no SecretProviderClass", volume.Name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Set the secretProviderClass volume attribute to the name of a SecretProviderClass in the same namespace").
+			WithDetail("volume_name", volume.Name)}
+	}
+
+	providerClass := &unstructured.Unstructured{}
+	providerClass.SetGroupVersionKind(secretProviderClassGVK)
+	err := v.client.Get(ctx, types.NamespacedName{Name: className, Namespace: source.namespace}, providerClass)
+	source.record(ctx, "SecretProviderClass", className, err)
+	if err == nil {
+		return nil
+	}
+	hint := fmt.Sprintf("Create SecretProviderClass '%s' in namespace '%s' or update the CSI volume to use an existing SecretProviderClass", className, source.namespace)
+	if meta.IsNoMatchError(err) {
+		hint = fmt.Sprintf("Install the Secrets Store CSI driver and create SecretProviderClass '%s' in namespace '%s'", className, source.namespace)
+	}
+	return []ValidationError{NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_secret_provider_class", GetReferenceErrorCode("dangling_secret_provider_class"), fmt.Sprintf("SecretProviderClass '%s' referenced in CSI volume does not exist", className)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(hint).
+		WithRelatedResources(fmt.Sprintf("SecretProviderClass/%s", className)).
+		WithDetail("missing_secret_provider_class", className).
+		WithDetail("volume_name", volume.Name)}
+}
+
+// validateTokenServiceAccount checks that the ServiceAccount whose token a projected
+// volume requests exists, since the kubelet cannot mint a token without it
+func (v *ReferenceValidator) validateTokenServiceAccount(ctx context.Context, source podSpecSource, volume corev1.Volume) []ValidationError {
+	saName := source.spec.ServiceAccountName
+	if saName == "" {
+		saName = v.sharedConfig.DefaultSecurityContext.DefaultServiceAccountName
+	}
+	err := v.validateServiceAccountExists(ctx, saName, source.namespace)
+	source.record(ctx, "ServiceAccount", saName, err)
+	if err == nil {
+		return nil
+	}
+	return []ValidationError{NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_projected_token_service_account", GetReferenceErrorCode("dangling_projected_token_service_account"), fmt.Sprintf("ServiceAccount '%s' whose token is projected into volume '%s' does not exist", saName, volume.Name)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(fmt.Sprintf("Create ServiceAccount '%s' in namespace '%s' or update %s to run as an existing ServiceAccount", saName, source.namespace, source.resourceType)).
+		WithRelatedResources(fmt.Sprintf("ServiceAccount/%s", saName)).
+		WithDetail("missing_service_account", saName).
+		WithDetail("volume_name", volume.Name)}
+}
+
 func (v *ReferenceValidator) validatePVCReferences(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
 
@@ -383,8 +711,9 @@ func (v *ReferenceValidator) validatePVCReferences(ctx context.Context) ([]Valid
 
 		if pvc.Spec.StorageClassName != nil {
 			className := *pvc.Spec.StorageClassName
+			recordReference(ctx, "PersistentVolumeClaim", pvc.Name, pvc.Namespace, pvc.Annotations, "StorageClass", className, existingClasses[className])
 			if !existingClasses[className] {
-				errors = append(errors, NewValidationErrorWithCode("PersistentVolumeClaim", pvc.Name, pvc.Namespace, "dangling_storage_class", "KOGARO-REF-009", fmt.Sprintf("StorageClass '%s' does not exist", className)).
+				errors = append(errors, NewValidationErrorWithCode("PersistentVolumeClaim", pvc.Name, pvc.Namespace, "dangling_storage_class", GetReferenceErrorCode("dangling_storage_class"), fmt.Sprintf("StorageClass '%s' does not exist", className)).
 					WithSeverity(SeverityError).
 					WithRemediationHint(fmt.Sprintf("Create StorageClass '%s' or update PVC to use an existing StorageClass", className)).
 					WithRelatedResources(fmt.Sprintf("StorageClass/%s", className)).
@@ -393,25 +722,22 @@ func (v *ReferenceValidator) validatePVCReferences(ctx context.Context) ([]Valid
 		}
 	}
 
-	// Check Pod volumes referencing PVCs
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+	// Check Pod and workload template volumes referencing PVCs
+	sources, err := v.listPodSpecs(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, pod := range pods.Items {
-		// Skip system namespaces
-		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
-		}
-
-		for _, volume := range pod.Spec.Volumes {
+	for _, source := range sources {
+		for _, volume := range source.spec.Volumes {
 			if volume.PersistentVolumeClaim != nil {
 				pvcName := volume.PersistentVolumeClaim.ClaimName
-				if err := v.validatePVCExists(ctx, pvcName, pod.Namespace); err != nil {
-					errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_pvc_reference", "KOGARO-REF-010", fmt.Sprintf("PVC '%s' referenced in volume does not exist", pvcName)).
+				err := v.validatePVCExists(ctx, pvcName, source.namespace)
+				source.record(ctx, "PersistentVolumeClaim", pvcName, err)
+				if err != nil {
+					errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_pvc_reference", GetReferenceErrorCode("dangling_pvc_reference"), fmt.Sprintf("PVC '%s' referenced in volume does not exist", pvcName)).
 						WithSeverity(SeverityError).
-						WithRemediationHint(fmt.Sprintf("Create PVC '%s' in namespace '%s' or update the volume reference to use an existing PVC", pvcName, pod.Namespace)).
+						WithRemediationHint(fmt.Sprintf("Create PVC '%s' in namespace '%s' or update the volume reference to use an existing PVC", pvcName, source.namespace)).
 						WithDetail("missing_pvc", pvcName).
 						WithDetail("volume_name", volume.Name))
 				}
@@ -425,27 +751,24 @@ func (v *ReferenceValidator) validatePVCReferences(ctx context.Context) ([]Valid
 func (v *ReferenceValidator) validateServiceAccountReferences(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
 
-	// Get all Pods to check ServiceAccount references
-	var pods corev1.PodList
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+	// Get all Pods and workload templates to check ServiceAccount references
+	sources, err := v.listPodSpecs(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, pod := range pods.Items {
-		// Skip system namespaces
-		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
-		}
-
-		saName := pod.Spec.ServiceAccountName
+	for _, source := range sources {
+		saName := source.spec.ServiceAccountName
 		if saName == "" {
 			saName = v.sharedConfig.DefaultSecurityContext.DefaultServiceAccountName
 		}
 
-		if err := v.validateServiceAccountExists(ctx, saName, pod.Namespace); err != nil {
-			errors = append(errors, NewValidationErrorWithCode("Pod", pod.Name, pod.Namespace, "dangling_service_account", "KOGARO-REF-011", fmt.Sprintf("ServiceAccount '%s' does not exist", saName)).
+		err := v.validateServiceAccountExists(ctx, saName, source.namespace)
+		source.record(ctx, "ServiceAccount", saName, err)
+		if err != nil {
+			errors = append(errors, NewValidationErrorWithCode(source.resourceType, source.name, source.namespace, "dangling_service_account", GetReferenceErrorCode("dangling_service_account"), fmt.Sprintf("ServiceAccount '%s' does not exist", saName)).
 				WithSeverity(SeverityError).
-				WithRemediationHint(fmt.Sprintf("Create ServiceAccount '%s' in namespace '%s' or update Pod to use an existing ServiceAccount", saName, pod.Namespace)).
+				WithRemediationHint(fmt.Sprintf("Create ServiceAccount '%s' in namespace '%s' or update %s to use an existing ServiceAccount", saName, source.namespace, source.resourceType)).
 				WithRelatedResources(fmt.Sprintf("ServiceAccount/%s", saName)).
 				WithDetail("missing_service_account", saName))
 		}
@@ -454,12 +777,98 @@ func (v *ReferenceValidator) validateServiceAccountReferences(ctx context.Contex
 	return errors, nil
 }
 
+// podSpecSource is a Pod, or a workload whose pod template is validated
+type podSpecSource struct {
+	resourceType string
+	name         string
+	namespace    string
+	labels       map[string]string
+	annotations  map[string]string
+	spec         corev1.PodSpec
+}
+
+// record notes the source's reference to targetType/targetName for the reference
+// resolution report, with err the result of looking the target up
+func (s podSpecSource) record(ctx context.Context, targetType, targetName string, err error) {
+	recordReference(ctx, s.resourceType, s.name, s.namespace, s.annotations, targetType, targetName, err == nil)
+}
+
+// listPodSpecs returns the Pods and the pod templates of Deployments, StatefulSets,
+// DaemonSets and CronJobs outside system namespaces. Templates are validated directly
+// so that a workload scaled to zero or a CronJob between runs still reports its
+// dangling references; the findings of its pods are attributed to the same workload.
+func (v *ReferenceValidator) listPodSpecs(ctx context.Context) ([]podSpecSource, error) {
+	var sources []podSpecSource
+	add := func(resourceType, name, namespace string, objectMeta metav1.ObjectMeta, spec corev1.PodSpec) {
+		if !v.sharedConfig.IsSystemNamespace(namespace) {
+			sources = append(sources, podSpecSource{resourceType: resourceType, name: name, namespace: namespace,
+				labels: objectMeta.Labels, annotations: objectMeta.Annotations, spec: spec})
+		}
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		add("Pod", pod.Name, pod.Namespace, pod.ObjectMeta, pod.Spec)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		add("Deployment", deployment.Name, deployment.Namespace, deployment.Spec.Template.ObjectMeta, deployment.Spec.Template.Spec)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		add("StatefulSet", statefulSet.Name, statefulSet.Namespace, statefulSet.Spec.Template.ObjectMeta, statefulSet.Spec.Template.Spec)
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		add("DaemonSet", daemonSet.Name, daemonSet.Namespace, daemonSet.Spec.Template.ObjectMeta, daemonSet.Spec.Template.Spec)
+	}
+
+	var cronJobs batchv1.CronJobList
+	if err := v.client.List(ctx, &cronJobs); err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cronJob := range cronJobs.Items {
+		add("CronJob", cronJob.Name, cronJob.Namespace, cronJob.Spec.JobTemplate.Spec.Template.ObjectMeta, cronJob.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	return sources, nil
+}
+
 func (v *ReferenceValidator) validateSecretExists(ctx context.Context, name, namespace string) error {
-	var secret corev1.Secret
-	return v.client.Get(ctx, types.NamespacedName{
-		Name:      name,
-		Namespace: namespace,
-	}, &secret)
+	_, err := getSecret(ctx, v.client, namespace, name)
+	return err
+}
+
+func (v *ReferenceValidator) getSecret(ctx context.Context, name, namespace string) (*corev1.Secret, error) {
+	return getSecret(ctx, v.client, namespace, name)
+}
+
+// secretHasKey reports whether key is present in either the Data or StringData of the
+// Secret. Keys are assumed present when Secret data must not be read.
+func secretHasKey(secret *corev1.Secret, key string) bool {
+	if !secretDataReadable() {
+		return true
+	}
+	if _, ok := secret.Data[key]; ok {
+		return true
+	}
+	_, ok := secret.StringData[key]
+	return ok
 }
 
 func (v *ReferenceValidator) validatePVCExists(ctx context.Context, name, namespace string) error {
@@ -10,9 +10,12 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -24,6 +27,8 @@ func TestReferenceValidator_ValidateIngressReferences(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = networkingv1.AddToScheme(scheme)
 	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 
 	tests := []struct {
 		name           string
@@ -150,6 +155,8 @@ func TestReferenceValidator_ValidateIngressReferences(t *testing.T) {
 func TestReferenceValidator_ValidateConfigMapReferences(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 
 	tests := []struct {
 		name           string
@@ -256,6 +263,142 @@ func TestReferenceValidator_ValidateConfigMapReferences(t *testing.T) {
 			expectedErrors: 1,
 			errorTypes:     []string{"dangling_configmap_envfrom"},
 		},
+		{
+			name: "pod with missing configmap in env configMapKeyRef",
+			objects: []client.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "test-container",
+								Image: "nginx",
+								Env: []corev1.EnvVar{
+									{
+										Name: "LOG_LEVEL",
+										ValueFrom: &corev1.EnvVarSource{
+											ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config"},
+												Key:                  "log-level",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: 1,
+			errorTypes:     []string{"dangling_configmap_env"},
+		},
+		{
+			name: "pod with optional references to a missing configmap",
+			objects: []client.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "config-volume",
+								VolumeSource: corev1.VolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config"},
+										Optional:             boolPtr(true),
+									},
+								},
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name:  "test-container",
+								Image: "nginx",
+								Env: []corev1.EnvVar{
+									{
+										Name: "LOG_LEVEL",
+										ValueFrom: &corev1.EnvVarSource{
+											ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config"},
+												Key:                  "log-level",
+												Optional:             boolPtr(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: 0,
+		},
+		{
+			name: "pod with missing keys in existing configmap",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: "test-ns"},
+					Data:       map[string]string{"log-level": "info"},
+					BinaryData: map[string][]byte{"logo.png": []byte("png")},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "config-volume",
+								VolumeSource: corev1.VolumeSource{
+									ConfigMap: &corev1.ConfigMapVolumeSource{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "test-config"},
+										Items: []corev1.KeyToPath{
+											{Key: "logo.png", Path: "logo.png"},
+											{Key: "app.yaml", Path: "app.yaml"},
+										},
+									},
+								},
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name:  "test-container",
+								Image: "nginx",
+								Env: []corev1.EnvVar{
+									{
+										Name: "LOG_LEVEL",
+										ValueFrom: &corev1.EnvVarSource{
+											ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "test-config"},
+												Key:                  "log-level",
+											},
+										},
+									},
+									{
+										Name: "LOG_FORMAT",
+										ValueFrom: &corev1.EnvVarSource{
+											ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "test-config"},
+												Key:                  "log-format",
+											},
+										},
+									},
+									{
+										Name: "FEATURE_FLAG",
+										ValueFrom: &corev1.EnvVarSource{
+											ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "test-config"},
+												Key:                  "feature-flag",
+												Optional:             boolPtr(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: 2,
+			errorTypes:     []string{"missing_configmap_key", "missing_configmap_key"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -293,6 +436,8 @@ func TestReferenceValidator_ValidateConfigMapReferences(t *testing.T) {
 func TestReferenceValidator_ValidateSecretReferences(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
 
 	tests := []struct {
@@ -349,6 +494,93 @@ func TestReferenceValidator_ValidateSecretReferences(t *testing.T) {
 			expectedErrors: 1,
 			errorTypes:     []string{"dangling_tls_secret"},
 		},
+		{
+			name: "pod with missing keys in existing secret",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-secret", Namespace: "test-ns"},
+					Data:       map[string][]byte{"username": []byte("admin")},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "secret-volume",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "test-secret",
+										Items: []corev1.KeyToPath{
+											{Key: "username", Path: "username"},
+											{Key: "ca.crt", Path: "ca.crt"},
+										},
+									},
+								},
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name:  "test-container",
+								Image: "nginx",
+								Env: []corev1.EnvVar{
+									{
+										Name: "DB_PASSWORD",
+										ValueFrom: &corev1.EnvVarSource{
+											SecretKeyRef: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "test-secret"},
+												Key:                  "password",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: 2,
+			errorTypes:     []string{"missing_secret_key", "missing_secret_key"},
+		},
+		{
+			name: "pod with optional references to a missing secret",
+			objects: []client.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{
+							{
+								Name: "secret-volume",
+								VolumeSource: corev1.VolumeSource{
+									Secret: &corev1.SecretVolumeSource{
+										SecretName: "missing-secret",
+										Optional:   boolPtr(true),
+									},
+								},
+							},
+						},
+						Containers: []corev1.Container{
+							{
+								Name:  "test-container",
+								Image: "nginx",
+								Env: []corev1.EnvVar{
+									{
+										Name: "DB_PASSWORD",
+										ValueFrom: &corev1.EnvVarSource{
+											SecretKeyRef: &corev1.SecretKeySelector{
+												LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"},
+												Key:                  "password",
+												Optional:             boolPtr(true),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: 0,
+		},
 	}
 
 	for _, tt := range tests {
@@ -386,6 +618,8 @@ func TestReferenceValidator_ValidateSecretReferences(t *testing.T) {
 func TestReferenceValidator_ValidatePVCReferences(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 	_ = storagev1.AddToScheme(scheme)
 
 	tests := []struct {
@@ -460,6 +694,8 @@ func TestReferenceValidator_ValidatePVCReferences(t *testing.T) {
 func TestReferenceValidator_ValidateCluster(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
 	_ = networkingv1.AddToScheme(scheme)
 	_ = storagev1.AddToScheme(scheme)
 
@@ -496,3 +732,224 @@ func TestReferenceValidator_ValidateCluster(t *testing.T) {
 	}
 }
 
+
+func TestReferenceValidator_ValidatesWorkloadTemplates(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "worker",
+			Containers: []corev1.Container{{
+				Name: "app",
+				EnvFrom: []corev1.EnvFromSource{{
+					SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"}},
+				}},
+			}},
+		},
+	}
+	zero := int32(0)
+	objects := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "shop"}},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &zero, Template: template},
+		},
+		&batchv1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "shop"},
+			Spec:       batchv1.CronJobSpec{JobTemplate: batchv1.JobTemplateSpec{Spec: batchv1.JobSpec{Template: template}}},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{
+		EnableSecretValidation:         true,
+		EnableServiceAccountValidation: true,
+	})
+	errors, err := validator.validateSecretReferences(context.TODO())
+	if err != nil {
+		t.Fatalf("validateSecretReferences() error = %v", err)
+	}
+
+	got := make(map[string]bool)
+	for _, validationError := range errors {
+		if validationError.ValidationType != "dangling_secret_envfrom" {
+			t.Errorf("unexpected finding %s on %s/%s", validationError.ValidationType, validationError.ResourceType, validationError.ResourceName)
+		}
+		got[validationError.ResourceType+"/"+validationError.ResourceName] = true
+	}
+	if len(got) != 2 || !got["Deployment/web"] || !got["CronJob/nightly"] {
+		t.Errorf("findings on %v, want Deployment/web and CronJob/nightly", got)
+	}
+
+	serviceAccountErrors, err := validator.validateServiceAccountReferences(context.TODO())
+	if err != nil {
+		t.Fatalf("validateServiceAccountReferences() error = %v", err)
+	}
+	if len(serviceAccountErrors) != 0 {
+		t.Errorf("validateServiceAccountReferences() got %d errors, want 0", len(serviceAccountErrors))
+	}
+}
+
+func TestReferenceValidator_ValidateProjectedCSIAndPullSecretReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	optional := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "missing-sa",
+			ImagePullSecrets:   []corev1.LocalObjectReference{{Name: "registry-credentials"}, {Name: "missing-registry"}},
+			Containers:         []corev1.Container{{Name: "app", Image: "app:1"}},
+			Volumes: []corev1.Volume{
+				{
+					Name: "bundle",
+					VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{Sources: []corev1.VolumeProjection{
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-config"}}},
+						{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "optional-config"}, Optional: &optional}},
+						{Secret: &corev1.SecretProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "missing-secret"}}},
+						{Secret: &corev1.SecretProjection{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "registry-credentials"},
+							Items:                []corev1.KeyToPath{{Key: "absent", Path: "absent"}},
+						}},
+						{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}},
+					}}},
+				},
+				{
+					Name: "vault",
+					VolumeSource: corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+						Driver:           secretsStoreCSIDriver,
+						VolumeAttributes: map[string]string{"secretProviderClass": "missing-provider"},
+					}},
+				},
+			},
+		},
+	}
+	objects := []client.Object{
+		pod,
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "registry-credentials", Namespace: "shop"}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{EnableConfigMapValidation: true, EnableSecretValidation: true})
+
+	configMapErrors, err := validator.validateConfigMapReferences(context.TODO())
+	if err != nil {
+		t.Fatalf("validateConfigMapReferences() error = %v", err)
+	}
+	secretErrors, err := validator.validateSecretReferences(context.TODO())
+	if err != nil {
+		t.Fatalf("validateSecretReferences() error = %v", err)
+	}
+
+	got := make(map[string]string)
+	for _, validationError := range append(configMapErrors, secretErrors...) {
+		got[validationError.ValidationType] = validationError.ErrorCode
+	}
+	want := map[string]string{
+		"dangling_projected_configmap":             "KOGARO-REF-019",
+		"dangling_projected_secret":                "KOGARO-REF-020",
+		"missing_secret_key":                       "KOGARO-REF-014",
+		"dangling_projected_token_service_account": "KOGARO-REF-021",
+		"dangling_secret_provider_class":           "KOGARO-REF-022",
+		"dangling_image_pull_secret":               "KOGARO-REF-023",
+	}
+	if len(configMapErrors) != 1 || len(secretErrors) != 5 {
+		t.Errorf("got %d ConfigMap and %d Secret findings, want 1 and 5: %v", len(configMapErrors), len(secretErrors), got)
+	}
+	for validationType, code := range want {
+		if got[validationType] != code {
+			t.Errorf("finding %s has code %q, want %q", validationType, got[validationType], code)
+		}
+	}
+}
+
+func TestReferenceValidator_ValidateDownwardAPIReferences(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	fieldEnv := func(name, fieldPath string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: fieldPath}}}
+	}
+	resourceEnv := func(name, containerName, resource string) corev1.EnvVar {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: containerName, Resource: resource}}}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"team": "payments"}},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+					Env: []corev1.EnvVar{
+						fieldEnv("POD_NAME", "metadata.name"),
+						fieldEnv("TEAM", "metadata.labels['team']"),
+						fieldEnv("HASH", "metadata.labels['pod-template-hash']"),
+						fieldEnv("TIER", "metadata.labels['tier']"),
+						fieldEnv("NODE", "spec.node"),
+						resourceEnv("CPU_REQUEST", "", "requests.cpu"),
+						resourceEnv("MEMORY_LIMIT", "", "limits.memory"),
+						resourceEnv("SIDECAR_CPU", "sidecar", "limits.cpu"),
+					},
+				}},
+				Volumes: []corev1.Volume{{
+					Name: "podinfo",
+					VolumeSource: corev1.VolumeSource{DownwardAPI: &corev1.DownwardAPIVolumeSource{Items: []corev1.DownwardAPIVolumeFile{
+						{Path: "labels", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+						{Path: "owner", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['owner']"}},
+					}}},
+				}},
+			},
+		}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{EnableDownwardAPIValidation: true})
+
+	errors, err := validator.validateDownwardAPIReferences(context.TODO())
+	if err != nil {
+		t.Fatalf("validateDownwardAPIReferences() error = %v", err)
+	}
+
+	got := make(map[string]ValidationError)
+	for _, validationError := range errors {
+		key := validationError.Details["env_var_name"]
+		if key == "" {
+			key = validationError.Details["volume_name"]
+		}
+		got[key] = validationError
+	}
+	want := map[string]struct {
+		validationType string
+		severity       Severity
+	}{
+		"TIER":         {"dangling_field_ref", SeverityWarning},
+		"NODE":         {"dangling_field_ref", SeverityError},
+		"MEMORY_LIMIT": {"dangling_resource_field_ref", SeverityWarning},
+		"SIDECAR_CPU":  {"dangling_resource_field_ref", SeverityError},
+		"podinfo":      {"dangling_field_ref", SeverityWarning},
+	}
+	if len(got) != len(want) {
+		t.Errorf("got findings for %d selectors, want %d: %v", len(got), len(want), got)
+	}
+	for key, expected := range want {
+		finding, found := got[key]
+		if !found {
+			t.Errorf("missing finding for %s", key)
+			continue
+		}
+		if finding.ValidationType != expected.validationType || finding.Severity != expected.severity {
+			t.Errorf("finding for %s = %s (%s), want %s (%s)", key, finding.ValidationType, finding.Severity, expected.validationType, expected.severity)
+		}
+	}
+}
@@ -7,25 +7,40 @@
 package validators
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"runtime/debug"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/go-logr/logr"
-	corev1 "k8s.io/api/core/v1"
-	networkingv1 "k8s.io/api/networking/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/schedule"
 )
 
 // DirectLogReceiver logs validation errors immediately to the logger
 type DirectLogReceiver struct {
-	log logr.Logger
+	log     logr.Logger
+	cluster string
+}
+
+// clusterScoped is implemented by log receivers that belong to a named cluster, so
+// that the metrics recorded for their errors carry a cluster label
+type clusterScoped interface {
+	Cluster() string
+}
+
+// Cluster returns the cluster the receiver logs errors for, empty for a single cluster
+func (d *DirectLogReceiver) Cluster() string {
+	return d.cluster
 }
 
 // LogValidationError logs a validation error immediately
@@ -68,6 +83,10 @@ func (b *BufferedLogReceiver) GetErrors() []ValidationError {
 	return errors
 }
 
+// ErrScanInProgress is returned when a cluster scan is requested while another scan
+// of the same registry is still running
+var ErrScanInProgress = errors.New("a cluster scan is already in progress")
+
 const (
 	validationTypeMissingReference   = "missing_reference"
 	validationTypeSuggestedReference = "suggested_reference"
@@ -79,17 +98,239 @@ type ValidatorRegistry struct {
 	log        logr.Logger
 	mu         sync.RWMutex
 	client     client.Client
+
+	// Name of the cluster the registry validates; empty for a single cluster
+	cluster string
+
+	// Share of the cluster's namespaces the registry validates
+	shard Shard
+
+	// API requests a scan may issue before low-priority validators are skipped
+	apiBudget             int
+	lowPriorityValidators []string
+
+	// Validation profiles bound to namespaces, and their resolution for the last scan
+	profiles        *ProfilePolicy
+	profileResolver *profileResolver
+	// Teams owning namespaces, and their resolution for the last scan
+	teams        *TeamPolicy
+	teamResolver *teamResolver
+	// Snoozed findings, and their resolution for the last scan
+	snoozes        *SnoozePolicy
+	snoozeResolver *snoozeResolver
+	// Holds back flapping findings over successive cluster scans; nil when disabled
+	flaps *flapTracker
+	// Severities findings are reported with, and weights of findings in hygiene
+	// scores; nil keeps the validators' severities and the category weights
+	severityPolicy *SeverityPolicy
+	scorePolicy    *ScorePolicy
+
+	// Time each validator may run during a cluster scan; 0 is unlimited
+	validatorTimeout time.Duration
+	// Runs abandoned on timeout, by validator, each closed once the run returns
+	abandonedRuns map[Validator]chan struct{}
+	// Decides the exit code of validation results from the severity of their findings
+	exitCodePolicy ExitCodePolicy
+	// Findings for the validators that timed out or panicked during the last scan
+	validatorFailures []ValidationError
+	// Owners of the resources the last scan reported findings on
+	ownership ownershipIndex
+	// Namespaces the last scan validated, which are rated by hygiene scores
+	namespaces []string
+	// Label naming the component of the resources findings are reported on
+	componentLabel string
+
+	// Set while a cluster scan runs, so that overlapping scans are rejected
+	scanning atomic.Bool
+	// Set once Kogaro shuts down, so that scans stop after the validator in flight
+	draining atomic.Bool
+
+	// Snapshot of the most recent successful cluster scan
+	lastScanResult   *ValidationResult
+	lastScanTime     time.Time
+	lastScanDuration time.Duration
+	scanListeners    []ScanListener
+
+	// Listeners that alert people, held back during quiet hours
+	notifiers  []ScanListener
+	quietHours *schedule.QuietHours
+
+	// Listeners called once the scan listeners and notifiers have handled a scan
+	completionListeners []ScanListener
 }
 
+// ScanListener is notified with the findings of each successful cluster scan
+type ScanListener func(result ValidationResult, scanTime time.Time)
+
 // NewValidatorRegistry creates a new ValidatorRegistry with the given logger.
 func NewValidatorRegistry(log logr.Logger, client client.Client) *ValidatorRegistry {
 	return &ValidatorRegistry{
-		validators: make([]Validator, 0),
-		log:        log.WithName("validator-registry"),
-		client:     client,
+		validators:     make([]Validator, 0),
+		log:            log.WithName("validator-registry"),
+		client:         client,
+		componentLabel: DefaultComponentLabel,
 	}
 }
 
+// SetCluster names the cluster the registry validates. Findings and metrics are
+// tagged with the name, so that registries for several clusters can share a process.
+func (r *ValidatorRegistry) SetCluster(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cluster = name
+	r.log = r.log.WithValues("cluster", name)
+}
+
+// Cluster returns the name of the cluster the registry validates, empty for a single cluster.
+func (r *ValidatorRegistry) Cluster() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cluster
+}
+
+// SetShard restricts cluster validation to the namespaces a shard owns. Validators
+// only list objects in those namespaces, and only shard 0 reports findings on
+// cluster-scoped resources, so several replicas can split one cluster.
+func (r *ValidatorRegistry) SetShard(shard Shard) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.shard = shard
+	if shard.Enabled() {
+		r.log = r.log.WithValues("shard", shard.Label())
+	}
+}
+
+// Shard returns the share of the cluster's namespaces the registry validates
+func (r *ValidatorRegistry) Shard() Shard {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.shard
+}
+
+// SetAPIBudget limits the API requests of each cluster scan. High-priority validators
+// run first; once the scan has sent budget requests to the API server, the
+// low-priority validators that have not run yet are skipped, keeping their findings
+// from their last run. Requests are counted by the client transport, which must be
+// wrapped with CountAPIRequests; reads served from the informer cache are not counted.
+// Low-priority validators are given by validation type, or by a prefix ending in "*".
+// A budget of 0 is unlimited.
+func (r *ValidatorRegistry) SetAPIBudget(budget int, lowPriorityValidators []string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.apiBudget = budget
+	r.lowPriorityValidators = lowPriorityValidators
+}
+
+// SetValidatorTimeout limits the time each validator may run during a cluster scan.
+// A validator that exceeds it is abandoned for the scan and reported with a
+// validator_timeout finding. A timeout of 0 is unlimited.
+func (r *ValidatorRegistry) SetValidatorTimeout(timeout time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.validatorTimeout = timeout
+}
+
+// SetComponentLabel sets the label naming the component, such as a Backstage
+// component, of the resources cluster findings are reported on. The component of a
+// resource or its nearest owner is recorded in the findings' details. An empty
+// label records no components.
+func (r *ValidatorRegistry) SetComponentLabel(label string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.componentLabel = label
+}
+
+// SetExitCodePolicy sets the policy that turns the findings of a validation result
+// into its exit code. The default policy only fails on findings of error severity.
+func (r *ValidatorRegistry) SetExitCodePolicy(policy ExitCodePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exitCodePolicy = policy
+}
+
+// exitCode returns the exit code of a result that reported the given findings
+func (r *ValidatorRegistry) exitCode(errors []ValidationError) int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.exitCodePolicy.ExitCode(errors)
+}
+
+// SetProfilePolicy binds validation profiles to namespaces. The effective profile of
+// each namespace is resolved at the start of every validation run, and findings its
+// profile does not report are dropped before they are logged or recorded. A nil
+// policy reports every finding.
+func (r *ValidatorRegistry) SetProfilePolicy(policy *ProfilePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.profiles = policy
+}
+
+// SetTeamPolicy assigns namespaces to teams. The team of each namespace is resolved at
+// the start of every validation run and recorded on the findings reported in it. A nil
+// policy assigns no finding to a team.
+func (r *ValidatorRegistry) SetTeamPolicy(policy *TeamPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.teams = policy
+}
+
+// SetSnoozePolicy snoozes findings until a date. Findings snoozed by the policy, or by
+// the annotations of their resource, are dropped before they are logged or recorded
+// until their snooze expires. A nil policy snoozes no finding.
+func (r *ValidatorRegistry) SetSnoozePolicy(policy *SnoozePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snoozes = policy
+}
+
+// SetFlapPolicy sets how many consecutive cluster scans must find a finding before
+// it is reported, and miss it before it is resolved. Findings reported by earlier
+// scans are forgotten.
+func (r *ValidatorRegistry) SetFlapPolicy(policy *FlapPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flaps = newFlapTracker(policy)
+}
+
+// SetSeverityPolicy remaps the severity of the findings the registry reports by error
+// code. A nil policy keeps the severities chosen by the validators.
+func (r *ValidatorRegistry) SetSeverityPolicy(policy *SeverityPolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.severityPolicy = policy
+}
+
+// SetScorePolicy weighs the findings of the registry's results in hygiene scores by
+// error code. A nil policy weighs findings by their category.
+func (r *ValidatorRegistry) SetScorePolicy(policy *ScorePolicy) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scorePolicy = policy
+}
+
+// TeamChannels returns the notification channels the findings of a team are routed
+// to, as of the last cluster scan
+func (r *ValidatorRegistry) TeamChannels(team string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.teamResolver.channelsOf(team)
+}
+
 // Register adds a validator to the registry.
 func (r *ValidatorRegistry) Register(validator Validator) {
 	r.mu.Lock()
@@ -99,11 +340,89 @@ func (r *ValidatorRegistry) Register(validator Validator) {
 	r.log.Info("validator registered", "type", validator.GetValidationType())
 }
 
-// ValidateCluster runs validation across all registered validators.
+// AddScanListener registers a listener that is called after each successful cluster scan.
+func (r *ValidatorRegistry) AddScanListener(listener ScanListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.scanListeners = append(r.scanListeners, listener)
+}
+
+// AddNotifier registers a listener that alerts people to the findings of each successful
+// cluster scan. Unlike other scan listeners, notifiers are not called during quiet hours.
+func (r *ValidatorRegistry) AddNotifier(notifier ScanListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.notifiers = append(r.notifiers, notifier)
+}
+
+// AddCompletionListener registers a listener called after the scan listeners and
+// notifiers have handled each successful cluster scan, including during quiet hours,
+// such as one that persists their state
+func (r *ValidatorRegistry) AddCompletionListener(listener ScanListener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.completionListeners = append(r.completionListeners, listener)
+}
+
+// SetQuietHours holds back notifiers during the given quiet hours, while scans and other
+// scan listeners still run. Nil quiet hours never hold notifiers back.
+func (r *ValidatorRegistry) SetQuietHours(quietHours *schedule.QuietHours) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.quietHours = quietHours
+}
+
+// QuietHours returns the quiet hours during which notifiers are held back, or nil
+func (r *ValidatorRegistry) QuietHours() *schedule.QuietHours {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.quietHours
+}
+
+// InQuietHours reports whether notifiers are held back at t, and records it in the
+// kogaro_quiet_hours metric when quiet hours are set
+func (r *ValidatorRegistry) InQuietHours(t time.Time) bool {
+	quietHours := r.QuietHours()
+	if quietHours == nil {
+		return false
+	}
+	quiet := quietHours.Contains(t)
+	value := 0.0
+	if quiet {
+		value = 1
+	}
+	metrics.QuietHours.WithLabelValues(r.Cluster()).Set(value)
+	return quiet
+}
+
+// ValidateCluster runs validation across all registered validators. Only one scan
+// runs at a time; a scan requested while another is running returns ErrScanInProgress.
 func (r *ValidatorRegistry) ValidateCluster(ctx context.Context) error {
+	if !r.scanning.CompareAndSwap(false, true) {
+		r.log.Info("rejecting cluster scan, the previous scan is still in progress")
+		metrics.ScansRejected.WithLabelValues(r.Cluster()).Inc()
+		return ErrScanInProgress
+	}
+	defer r.scanning.Store(false)
+
 	r.mu.RLock()
 	validators := make([]Validator, len(r.validators))
 	copy(validators, r.validators)
+	cluster := r.cluster
+	shard := r.shard
+	profiles := r.profiles
+	teamPolicy := r.teams
+	snoozePolicy := r.snoozes
+	flaps := r.flaps
+	severities := r.severityPolicy
+	apiBudget := r.apiBudget
+	lowPriority := r.lowPriorityValidators
+	validatorTimeout := r.validatorTimeout
 	r.mu.RUnlock()
 
 	if len(validators) == 0 {
@@ -111,27 +430,232 @@ func (r *ValidatorRegistry) ValidateCluster(ctx context.Context) error {
 		return nil
 	}
 
+	// Resolve the validation profile of each namespace before any validator runs
+	resolver, err := profiles.resolve(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("failed to resolve validation profiles: %w", err)
+	}
+	teams, err := teamPolicy.resolve(ctx, r.client)
+	if err != nil {
+		return fmt.Errorf("failed to resolve teams: %w", err)
+	}
+	snoozes := snoozePolicy.resolve(r.client)
+	r.mu.Lock()
+	r.profileResolver = resolver
+	r.teamResolver = teams
+	r.snoozeResolver = snoozes
+	r.mu.Unlock()
+
+	// Record the share of the cluster this replica validates, whose namespaces are
+	// rated by hygiene scores
+	if r.client != nil {
+		owned, err := shard.ownedNamespaces(ctx, r.client)
+		switch {
+		case err != nil && shard.Enabled():
+			return fmt.Errorf("failed to list shard namespaces: %w", err)
+		case err != nil:
+			// Only the namespaces with findings are rated then
+			r.log.Error(err, "failed to list namespaces for hygiene scores")
+		case shard.Enabled():
+			metrics.ShardNamespaces.Set(float64(len(owned)))
+		}
+		r.mu.Lock()
+		r.namespaces = owned
+		r.mu.Unlock()
+	}
+
+	// Run high-priority validators first, so that a scan over its API request budget
+	// only skips low-priority ones
+	if apiBudget > 0 {
+		sort.SliceStable(validators, func(i, j int) bool {
+			return !isLowPriority(validators[i], lowPriority) && isLowPriority(validators[j], lowPriority)
+		})
+	}
+
 	r.log.Info("starting cluster validation", "validator_count", len(validators))
+	scanStart := time.Now()
+	var failures []ValidationError
+
+	// Count the requests the scan sends to the API server, which exclude the reads
+	// served from the informer cache
+	var requests atomic.Int64
+	ctx = withAPIRequestCounter(ctx, &requests)
 
 	for _, validator := range validators {
 		validatorType := validator.GetValidationType()
+		if r.draining.Load() {
+			r.log.Info("shutting down, skipping the remaining validators of the scan", "next", validatorType)
+			break
+		}
+		if apiBudget > 0 && requests.Load() >= int64(apiBudget) && isLowPriority(validator, lowPriority) {
+			r.log.Info("skipping low-priority validator, scan API request budget exhausted", "type", validatorType,
+				"requests", requests.Load(), "budget", apiBudget)
+			metrics.ValidatorsSkipped.WithLabelValues(validatorType, cluster).Inc()
+			continue
+		}
+		if r.abandonedRunInProgress(validator) {
+			// Reusing the validator would race with its abandoned run for its client,
+			// log receiver and findings
+			finding := NewValidationErrorWithCode("Validator", validatorType, "", "validator_timeout", GetRegistryErrorCode("validator_timeout"),
+				fmt.Sprintf("Validator '%s' is still running the scan it was abandoned in and was skipped for this scan", validatorType)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Check the validator's dependencies, such as image registries or plugins; it runs again once its abandoned run returns").
+				WithDetail("validator", validatorType)
+			r.log.Info("skipping validator, its abandoned run is still in progress", "type", validatorType)
+			metrics.ValidatorFailures.WithLabelValues(validatorType, finding.ValidationType, cluster).Inc()
+			failures = append(failures, finding)
+			continue
+		}
 		r.log.V(1).Info("running validator", "type", validatorType)
 
 		// Always use DirectLogReceiver for regular cluster validation
-		directReceiver := &DirectLogReceiver{log: r.log}
-		validator.SetLogReceiver(directReceiver)
+		directReceiver := &DirectLogReceiver{log: r.log, cluster: cluster}
+		validator.SetLogReceiver(severities.wrap(flaps.wrap(snoozes.wrap(resolver.wrap(wrapShard(directReceiver, shard))), validator)))
+
+		// Route the validator's reads through an instrumented client so that reads
+		// and listed resources are attributed to it
+		var instrumented *instrumentedClient
+		if r.client != nil {
+			instrumented = newInstrumentedClient(NewShardClient(r.client, shard), validatorType)
+			validator.SetClient(instrumented)
+		}
 
-		if err := validator.ValidateCluster(ctx); err != nil {
+		validatorStart := time.Now()
+		failure, err := r.runValidator(ctx, validator, validatorTimeout)
+
+		resourcesListed := 0
+		if instrumented != nil {
+			resourcesListed = instrumented.ResourcesListed()
+		}
+		scanErr := err
+		if failure != nil {
+			scanErr = errors.New(failure.Message)
+		}
+		metrics.RecordValidatorScan(cluster, validatorType, time.Since(validatorStart), resourcesListed, scanErr)
+
+		// A validator cut off by the drain timeout ends the scan, whose findings are
+		// still published
+		if err != nil && r.draining.Load() && ctx.Err() != nil {
+			r.log.Info("shutting down, abandoned validator after the drain timeout", "type", validatorType)
+			break
+		}
+		if err != nil {
 			return fmt.Errorf("validator %s failed: %w", validatorType, err)
 		}
+		if failure != nil {
+			// A hanging or crashing validator must not stall or abort the whole scan
+			r.log.Error(scanErr, "validator failed, continuing the scan without it", "type", validatorType,
+				"reason", failure.ValidationType)
+			metrics.ValidatorFailures.WithLabelValues(validatorType, failure.ValidationType, cluster).Inc()
+			failures = append(failures, *failure)
+			continue
+		}
 
-		r.log.V(1).Info("validator completed", "type", validatorType)
+		r.log.V(1).Info("validator completed", "type", validatorType,
+			"duration", time.Since(validatorStart), "resources_listed", resourcesListed)
+	}
+
+	metrics.ScanDuration.Observe(time.Since(scanStart).Seconds())
+	LogAndRecordErrors(&DirectLogReceiver{log: r.log, cluster: cluster}, r.GetValidationType(), failures)
+	r.mu.Lock()
+	r.validatorFailures = failures
+	r.ownership = nil
+	r.mu.Unlock()
+
+	// Look up who owns the resources of the findings, so that they can be routed to
+	// the owning team
+	if r.client != nil {
+		r.mu.RLock()
+		componentLabel := r.componentLabel
+		r.mu.RUnlock()
+		owners := resolveOwnership(ctx, r.client, r.LastValidationResult().Errors, componentLabel)
+		r.mu.Lock()
+		r.ownership = owners
+		r.mu.Unlock()
+	}
+	if apiBudget > 0 && requests.Load() > int64(apiBudget) {
+		r.log.Info("cluster scan exceeded its API request budget", "requests", requests.Load(), "budget", apiBudget)
 	}
 
-	r.log.Info("cluster validation completed successfully", "validator_count", len(validators))
+	// Keep a snapshot of the findings so readers don't race with the next scan
+	result := r.LastValidationResult()
+	recordTeamFindings(cluster, result.Errors)
+	metrics.RecordHygieneScores(cluster, result.Summary.HygieneScore, result.Summary.NamespaceScores)
+	scanTime := time.Now()
+	r.mu.Lock()
+	r.lastScanResult = &result
+	r.lastScanTime = scanTime
+	r.lastScanDuration = scanTime.Sub(scanStart)
+	listeners := make([]ScanListener, len(r.scanListeners))
+	copy(listeners, r.scanListeners)
+	notifiers := make([]ScanListener, len(r.notifiers))
+	copy(notifiers, r.notifiers)
+	completionListeners := make([]ScanListener, len(r.completionListeners))
+	copy(completionListeners, r.completionListeners)
+	r.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(normalizeResult(result), scanTime)
+	}
+	if r.InQuietHours(scanTime) {
+		if len(notifiers) > 0 {
+			r.log.Info("holding back notifications during quiet hours", "notifiers", len(notifiers), "quiet_hours", r.QuietHours().String())
+			metrics.NotificationsSuppressed.WithLabelValues(cluster).Inc()
+		}
+	} else {
+		for _, notifier := range notifiers {
+			notifier(normalizeResult(result), scanTime)
+		}
+	}
+	for _, listener := range completionListeners {
+		listener(normalizeResult(result), scanTime)
+	}
+
+	r.log.Info("cluster validation completed successfully", "validator_count", len(validators),
+		"duration", time.Since(scanStart))
 	return nil
 }
 
+// Drain makes the scan in flight, and any later scan, stop once its running validator
+// returns or its context is cancelled. The skipped validators keep the findings of
+// their last run, and the scan's findings are published to the listeners and notifiers
+// as usual, so that a scan interrupted by shutdown is not lost.
+func (r *ValidatorRegistry) Drain() {
+	r.draining.Store(true)
+}
+
+// Flush calls the notifiers and completion listeners again with the findings of the
+// last scan, so that notifications that failed, which are otherwise retried after the
+// next scan, are retried before Kogaro stops. Notifiers are held back during quiet
+// hours as usual.
+func (r *ValidatorRegistry) Flush() {
+	r.mu.RLock()
+	lastResult := r.lastScanResult
+	scanTime := r.lastScanTime
+	notifiers := make([]ScanListener, len(r.notifiers))
+	copy(notifiers, r.notifiers)
+	completionListeners := make([]ScanListener, len(r.completionListeners))
+	copy(completionListeners, r.completionListeners)
+	r.mu.RUnlock()
+
+	if lastResult == nil {
+		return
+	}
+	if !r.InQuietHours(time.Now()) {
+		for _, notifier := range notifiers {
+			notifier(normalizeResult(*lastResult), scanTime)
+		}
+	}
+	for _, listener := range completionListeners {
+		listener(normalizeResult(*lastResult), scanTime)
+	}
+}
+
+// ScanInProgress reports whether a cluster scan is running
+func (r *ValidatorRegistry) ScanInProgress() bool {
+	return r.scanning.Load()
+}
+
 // GetValidators returns a copy of all registered validators (for testing).
 func (r *ValidatorRegistry) GetValidators() []Validator {
 	r.mu.RLock()
@@ -155,6 +679,7 @@ func (r *ValidatorRegistry) FormatCIOutput(result ValidationResult) (string, err
 	// Add summary header
 	output.WriteString("Validation Summary:\n")
 	output.WriteString(fmt.Sprintf("Total Errors: %d\n", result.Summary.TotalErrors))
+	output.WriteString(fmt.Sprintf("Hygiene Score: %d/%d\n", result.Summary.HygieneScore, MaxHygieneScore))
 	output.WriteString(fmt.Sprintf("Missing References: %d\n", len(result.Summary.MissingRefs)))
 	output.WriteString(fmt.Sprintf("Suggested References: %d\n", len(result.Summary.SuggestedRefs)))
 
@@ -162,7 +687,13 @@ func (r *ValidatorRegistry) FormatCIOutput(result ValidationResult) (string, err
 	if len(result.Errors) > 0 {
 		output.WriteString("\nDetailed Errors:\n")
 		for _, err := range result.Errors {
-			output.WriteString(fmt.Sprintf("- %s/%s: %s\n",
+			// Show the severity after any SeverityPolicy overrides were applied
+			severity := ""
+			if err.Severity != "" {
+				severity = fmt.Sprintf("[%s] ", err.Severity)
+			}
+			output.WriteString(fmt.Sprintf("- %s%s/%s: %s\n",
+				severity,
 				err.ResourceType,
 				err.ResourceName,
 				err.Message))
@@ -194,12 +725,38 @@ func (r *ValidatorRegistry) FormatCIOutput(result ValidationResult) (string, err
 		}
 	}
 
+	// Add where the references of the config were resolved
+	if len(result.References) > 0 {
+		bundle, cluster, dangling := countReferences(result.References)
+		output.WriteString(fmt.Sprintf("\nReference Resolution: %d in bundle, %d in cluster, %d dangling\n", bundle, cluster, dangling))
+		for _, ref := range result.References {
+			output.WriteString(fmt.Sprintf("- [%s] %s", ref.Provenance, ref.String()))
+			if ref.Namespace != "" {
+				output.WriteString(fmt.Sprintf(" in namespace %s", ref.Namespace))
+			}
+			if ref.Reason != "" {
+				output.WriteString(fmt.Sprintf(": %s", ref.Reason))
+			}
+			output.WriteString("\n")
+		}
+	}
+
 	return output.String(), nil
 }
 
 // ValidateFileOnly validates only the configuration file without any cluster context.
 // This is ideal for CI/CD pipelines where developers only want to see errors in their changes.
 func (r *ValidatorRegistry) ValidateFileOnly(ctx context.Context, configPath string) (*ValidationResult, error) {
+	configData, err := ReadConfig(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+	return r.ValidateFileOnlyData(ctx, configPath, configData)
+}
+
+// ValidateFileOnlyData validates configuration read elsewhere, such as rendered chart
+// output, without any cluster context. Findings are attributed to configPath.
+func (r *ValidatorRegistry) ValidateFileOnlyData(ctx context.Context, configPath string, configData []byte) (*ValidationResult, error) {
 	r.mu.RLock()
 	validators := make([]Validator, len(r.validators))
 	copy(validators, r.validators)
@@ -212,22 +769,30 @@ func (r *ValidatorRegistry) ValidateFileOnly(ctx context.Context, configPath str
 
 	r.log.Info("starting file-only validation", "config", configPath)
 
-	// Read and parse the configuration file
-	configData, err := os.ReadFile(configPath) // nolint:gosec // Config file path is user-provided
-	if err != nil {
-		return nil, fmt.Errorf("failed to read config file: %w", err)
-	}
-
 	// Create a fake client with only the file objects (no cluster resources)
-	client := r.createFileOnlyClient(configData)
+	client, decodeErrors := r.createFileOnlyClient(configData)
 	if client == nil {
 		return nil, fmt.Errorf("failed to create file-only client")
 	}
 
-	// Run all validators with the file-only client
-	var allErrors []ValidationError
-	var missingRefs []string
-	var suggestedRefs []string
+	// Resolve the validation profile of each namespace before any validator runs
+	resolver, err := r.profilePolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validation profiles: %w", err)
+	}
+	teams, err := r.teamPolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve teams: %w", err)
+	}
+	snoozes := r.snoozePolicy().resolve(client)
+	severities := r.severityOverrides()
+
+	// Run all validators with the file-only client, whose objects share a
+	// resourceVersion, so that they are validated rather than served from the cache
+	ctx = withoutResultCache(ctx)
+	recorder := &referenceRecorder{}
+	ctx = withReferenceRecorder(ctx, recorder)
+	allErrors := snoozes.filter(resolver.filter(severities.Apply(decodeErrors)))
 
 	for _, validator := range validators {
 		validatorType := validator.GetValidationType()
@@ -239,8 +804,8 @@ func (r *ValidatorRegistry) ValidateFileOnly(ctx context.Context, configPath str
 		}
 
 		// Use DirectLogReceiver for file-only validation (shows all errors)
-		directReceiver := &DirectLogReceiver{log: r.log}
-		validator.SetLogReceiver(directReceiver)
+		directReceiver := &DirectLogReceiver{log: r.log, cluster: r.cluster}
+		validator.SetLogReceiver(severities.wrap(snoozes.wrap(resolver.wrap(directReceiver))))
 
 		// Run validation and collect errors
 		if err := validator.ValidateCluster(ctx); err != nil {
@@ -248,42 +813,31 @@ func (r *ValidatorRegistry) ValidateFileOnly(ctx context.Context, configPath str
 		}
 
 		// Collect validation errors from validator
-		validationErrors := validator.GetLastValidationErrors()
+		validationErrors := snoozes.filter(resolver.filter(severities.Apply(validator.GetLastValidationErrors())))
 		allErrors = append(allErrors, validationErrors...)
 
-		// Process errors for missing/suggested references
-		for _, ve := range validationErrors {
-			if ve.ValidationType == validationTypeMissingReference {
-				missingRefs = append(missingRefs, ve.Message)
-			}
-			if ve.ValidationType == validationTypeSuggestedReference {
-				suggestedRefs = append(suggestedRefs, ve.Message)
-			}
-		}
-
 		r.log.V(1).Info("validator completed", "type", validatorType)
 	}
 
 	// Prepare result
 	result := &ValidationResult{
-		Summary: struct {
-			TotalErrors   int      `json:"total_errors"`
-			MissingRefs   []string `json:"missing_refs,omitempty"`
-			SuggestedRefs []string `json:"suggested_refs,omitempty"`
-		}{
-			TotalErrors:   len(allErrors),
-			MissingRefs:   missingRefs,
-			SuggestedRefs: suggestedRefs,
-		},
 		Errors:   allErrors,
-		ExitCode: 0,
+		ExitCode: r.exitCode(allErrors),
 	}
-
-	if len(allErrors) > 0 {
-		result.ExitCode = 1
+	summarizeReferences(result)
+	scoreResult(result, nil, r.scoreWeights())
+	teams.assign(result.Errors)
+
+	// Attribute errors to their location in the config file for annotation output, and
+	// report where the references of its resources were resolved
+	if configDocuments, err := parseConfigDocuments(configData); err == nil {
+		attributeSources(result.Errors, configPath, configDocuments)
+		result.References = recorder.report(configDocuments, false)
 	}
 
-	r.log.Info("file-only validation completed", "total_errors", len(allErrors))
+	bundle, cluster, dangling := countReferences(result.References)
+	r.log.Info("file-only validation completed", "total_errors", len(allErrors),
+		"bundle_refs", bundle, "cluster_refs", cluster, "dangling_refs", dangling)
 	return result, nil
 }
 
@@ -292,6 +846,7 @@ func (r *ValidatorRegistry) ValidateFileOnly(ctx context.Context, configPath str
 // when exact references don't exist. The scope parameter controls which errors are returned:
 // - "all": return all validation errors (existing behavior)
 // - "file-only": return only errors for resources defined in the config file
+// - "flux-managed": return only errors for config file resources that Flux reconciles
 func (r *ValidatorRegistry) ValidateNewConfigWithScope(ctx context.Context, configPath string, scope string) (*ValidationResult, error) {
 	return r.ValidateNewConfigWithScopeAndData(ctx, configPath, scope, nil)
 }
@@ -320,21 +875,25 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 	} else if configPath == "-" {
 		return nil, fmt.Errorf("stdin input (-) requires pre-read data to be provided")
 	} else {
-		configData, err = os.ReadFile(configPath) // nolint:gosec // Config file path is user-provided
+		configData, err = ReadConfig(ctx, configPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read config file: %w", err)
+			return nil, err
 		}
 	}
 
 	// Parse config file to track which resources are from the file
-	configObjects, err := parseConfigFile(configData)
+	configDocuments, err := parseConfigDocuments(configData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
 	// Create resource key set for filtering
 	configResourceKeys := make(map[string]bool)
-	for _, obj := range configObjects {
+	for _, document := range configDocuments {
+		obj := document.Object
+		if scope == "flux-managed" && !isFluxManaged(obj) {
+			continue
+		}
 		// Use the object's GVK to get kind
 		gvk := obj.GetObjectKind().GroupVersionKind()
 		key := fmt.Sprintf("%s/%s/%s", gvk.Kind, obj.GetNamespace(), obj.GetName())
@@ -342,15 +901,32 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 	}
 
 	// Create a temporary client that includes both cluster and new config resources
-	client := r.createTemporaryClient(ctx, configData)
+	client, decodeErrors := r.createTemporaryClient(configData)
 	if client == nil {
 		return nil, fmt.Errorf("failed to create temporary client")
 	}
 
-	// Run all validators with the temporary client
-	var allErrors []ValidationError
-	var missingRefs []string
-	var suggestedRefs []string
+	// Resolve the validation profile of each namespace before any validator runs
+	resolver, err := r.profilePolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validation profiles: %w", err)
+	}
+	teams, err := r.teamPolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve teams: %w", err)
+	}
+	snoozes := r.snoozePolicy().resolve(client)
+	severities := r.severityOverrides()
+
+	// Run all validators with the temporary client, bypassing the result cache
+	// since its config objects share a resourceVersion
+	ctx = withoutResultCache(ctx)
+	recorder := &referenceRecorder{}
+	ctx = withReferenceRecorder(ctx, recorder)
+	allErrors := snoozes.filter(resolver.filter(severities.Apply(decodeErrors)))
+	if scope == "file-only" || scope == "flux-managed" {
+		allErrors = r.filterErrorsByScope(allErrors, configResourceKeys)
+	}
 
 	for _, validator := range validators {
 		validatorType := validator.GetValidationType()
@@ -362,14 +938,14 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 		}
 
 		// Set up logging based on scope
-		if scope == "file-only" {
+		if scope == "file-only" || scope == "flux-managed" {
 			// Use BufferedLogReceiver for file-only scope to filter logs
 			bufferedReceiver := &BufferedLogReceiver{}
-			validator.SetLogReceiver(bufferedReceiver)
+			validator.SetLogReceiver(severities.wrap(snoozes.wrap(resolver.wrap(bufferedReceiver))))
 		} else {
 			// For "all" scope, use DirectLogReceiver for immediate logging
-			directReceiver := &DirectLogReceiver{log: r.log}
-			validator.SetLogReceiver(directReceiver)
+			directReceiver := &DirectLogReceiver{log: r.log, cluster: r.cluster}
+			validator.SetLogReceiver(severities.wrap(snoozes.wrap(resolver.wrap(directReceiver))))
 		}
 
 		// Run validation and collect errors
@@ -378,10 +954,10 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 		}
 
 		// Collect validation errors from validator
-		validationErrors := validator.GetLastValidationErrors()
+		validationErrors := snoozes.filter(resolver.filter(severities.Apply(validator.GetLastValidationErrors())))
 
 		// Filter errors based on scope and log appropriately
-		if scope == "file-only" {
+		if scope == "file-only" || scope == "flux-managed" {
 			filteredErrors := r.filterErrorsByScope(validationErrors, configResourceKeys)
 			r.log.V(1).Info("filtered validation errors",
 				"validator_type", validatorType,
@@ -390,7 +966,7 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 				"config_resource_keys", len(configResourceKeys))
 
 			// Log only the filtered errors to maintain consistency with scope
-			directReceiver := &DirectLogReceiver{log: r.log}
+			directReceiver := &DirectLogReceiver{log: r.log, cluster: r.cluster}
 			for _, err := range filteredErrors {
 				directReceiver.LogValidationError(validatorType, err)
 			}
@@ -400,39 +976,29 @@ func (r *ValidatorRegistry) ValidateNewConfigWithScopeAndData(ctx context.Contex
 			allErrors = append(allErrors, validationErrors...)
 		}
 
-		// Process errors for missing/suggested references
-		for _, ve := range validationErrors {
-			if ve.ValidationType == validationTypeMissingReference {
-				missingRefs = append(missingRefs, ve.Message)
-			}
-			if ve.ValidationType == validationTypeSuggestedReference {
-				suggestedRefs = append(suggestedRefs, ve.Message)
-			}
-		}
-
 		r.log.V(1).Info("validator completed", "type", validatorType)
 	}
 
 	// Prepare result
 	result := &ValidationResult{
-		Summary: struct {
-			TotalErrors   int      `json:"total_errors"`
-			MissingRefs   []string `json:"missing_refs,omitempty"`
-			SuggestedRefs []string `json:"suggested_refs,omitempty"`
-		}{
-			TotalErrors:   len(allErrors),
-			MissingRefs:   missingRefs,
-			SuggestedRefs: suggestedRefs,
-		},
 		Errors:   allErrors,
-		ExitCode: 0,
+		ExitCode: r.exitCode(allErrors),
 	}
-
-	if len(allErrors) > 0 {
-		result.ExitCode = 1
+	summarizeReferences(result)
+	scoreResult(result, nil, r.scoreWeights())
+	teams.assign(result.Errors)
+
+	// Attribute errors to their location in the config file for annotation output
+	sourceFile := configPath
+	if sourceFile == "-" {
+		sourceFile = ""
 	}
+	attributeSources(result.Errors, sourceFile, configDocuments)
+	result.References = recorder.report(configDocuments, true)
 
-	r.log.Info("new configuration validation completed", "total_errors", len(allErrors), "scope", scope)
+	bundle, cluster, dangling := countReferences(result.References)
+	r.log.Info("new configuration validation completed", "total_errors", len(allErrors), "scope", scope,
+		"bundle_refs", bundle, "cluster_refs", cluster, "dangling_refs", dangling)
 	return result, nil
 }
 
@@ -460,15 +1026,27 @@ func (r *ValidatorRegistry) ValidateNewConfig(ctx context.Context, configPath st
 	}
 
 	// Create a temporary client that includes both cluster and new config resources
-	client := r.createTemporaryClient(ctx, configData)
+	client, decodeErrors := r.createTemporaryClient(configData)
 	if client == nil {
 		return nil, fmt.Errorf("failed to create temporary client")
 	}
 
-	// Run all validators with the temporary client
-	var allErrors []ValidationError
-	var missingRefs []string
-	var suggestedRefs []string
+	// Resolve the validation profile of each namespace before any validator runs
+	resolver, err := r.profilePolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validation profiles: %w", err)
+	}
+	teams, err := r.teamPolicy().resolve(ctx, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve teams: %w", err)
+	}
+	snoozes := r.snoozePolicy().resolve(client)
+	severities := r.severityOverrides()
+
+	// Run all validators with the temporary client, bypassing the result cache
+	// since its config objects share a resourceVersion
+	ctx = withoutResultCache(ctx)
+	allErrors := snoozes.filter(resolver.filter(severities.Apply(decodeErrors)))
 
 	for _, validator := range validators {
 		validatorType := validator.GetValidationType()
@@ -480,8 +1058,8 @@ func (r *ValidatorRegistry) ValidateNewConfig(ctx context.Context, configPath st
 		}
 
 		// Use DirectLogReceiver for new config validation (shows all errors)
-		directReceiver := &DirectLogReceiver{log: r.log}
-		validator.SetLogReceiver(directReceiver)
+		directReceiver := &DirectLogReceiver{log: r.log, cluster: r.cluster}
+		validator.SetLogReceiver(severities.wrap(snoozes.wrap(resolver.wrap(directReceiver))))
 
 		// Run validation and collect errors
 		if err := validator.ValidateCluster(ctx); err != nil {
@@ -489,181 +1067,207 @@ func (r *ValidatorRegistry) ValidateNewConfig(ctx context.Context, configPath st
 		}
 
 		// Collect validation errors from validator
-		validationErrors := validator.GetLastValidationErrors()
+		validationErrors := snoozes.filter(resolver.filter(severities.Apply(validator.GetLastValidationErrors())))
 		allErrors = append(allErrors, validationErrors...)
 
-		// Process errors for missing/suggested references
-		for _, ve := range validationErrors {
-			if ve.ValidationType == validationTypeMissingReference {
-				missingRefs = append(missingRefs, ve.Message)
-			}
-			if ve.ValidationType == validationTypeSuggestedReference {
-				suggestedRefs = append(suggestedRefs, ve.Message)
-			}
-		}
-
 		r.log.V(1).Info("validator completed", "type", validatorType)
 	}
 
 	// Prepare result
 	result := &ValidationResult{
-		Summary: struct {
-			TotalErrors   int      `json:"total_errors"`
-			MissingRefs   []string `json:"missing_refs,omitempty"`
-			SuggestedRefs []string `json:"suggested_refs,omitempty"`
-		}{
-			TotalErrors:   len(allErrors),
-			MissingRefs:   missingRefs,
-			SuggestedRefs: suggestedRefs,
-		},
 		Errors:   allErrors,
-		ExitCode: len(allErrors),
+		ExitCode: r.exitCode(allErrors),
 	}
+	summarizeReferences(result)
+	scoreResult(result, nil, r.scoreWeights())
+	teams.assign(result.Errors)
 
 	r.log.Info("new configuration validation completed",
 		"total_errors", len(allErrors),
-		"missing_refs", len(missingRefs),
-		"suggested_refs", len(suggestedRefs))
+		"missing_refs", len(result.Summary.MissingRefs),
+		"suggested_refs", len(result.SuggestedRefs))
 
 	return result, nil
 }
 
-// createFileOnlyClient creates a client that includes only the config file resources
-func (r *ValidatorRegistry) createFileOnlyClient(configData []byte) client.Client {
+// createFileOnlyClient creates a client that includes only the config file resources,
+// along with findings for config objects that could not be decoded
+func (r *ValidatorRegistry) createFileOnlyClient(configData []byte) (client.Client, []ValidationError) {
 	// Create a fake client builder
-	builder := fake.NewClientBuilder()
+	builder := withFieldIndexes(fake.NewClientBuilder())
 
 	// Parse the config file into Kubernetes objects
-	objects, err := parseConfigFile(configData)
+	documents, err := parseConfigDocuments(configData)
 	if err != nil {
 		r.log.Error(err, "failed to parse config file")
-		return nil
+		return nil, nil
 	}
+	objects, findings := decodeConfigObjects(documents)
 
 	// Add only config objects to the fake client (no cluster resources)
 	builder = builder.WithObjects(objects...)
 
 	// Create the file-only client
-	return builder.Build()
+	return builder.Build(), findings
 }
 
-// createTemporaryClient creates a client that includes both cluster and new config resources
-func (r *ValidatorRegistry) createTemporaryClient(ctx context.Context, configData []byte) client.Client {
-	// Create a fake client builder
-	builder := fake.NewClientBuilder()
-
-	// Parse the config file into Kubernetes objects
-	objects, err := parseConfigFile(configData)
-	if err != nil {
-		r.log.Error(err, "failed to parse config file")
-		return nil
+// createTemporaryClient creates a read-only client that serves the new config resources
+// on top of the live cluster, without copying the cluster's objects
+func (r *ValidatorRegistry) createTemporaryClient(configData []byte) (client.Client, []ValidationError) {
+	// Serve only the config objects from a fake client
+	fileClient, findings := r.createFileOnlyClient(configData)
+	if fileClient == nil {
+		return nil, nil
 	}
 
-	// Add new config objects to the fake client
-	builder = builder.WithObjects(objects...)
+	// Fall back to the cluster for everything the config does not define
+	return newLayeredClient(fileClient, r.client), findings
+}
 
-	// Get existing cluster objects
-	clusterObjects, err := r.getClusterObjects(ctx)
-	if err != nil {
-		r.log.Error(err, "failed to get cluster objects")
-		return nil
+// runValidator runs a validator's cluster scan, isolating the scan from the validator's
+// panics and, with a timeout, from a validator that does not return. A validator that
+// panics or times out is reported as a finding instead of an error. Validators should
+// honour context cancellation; one that does not keeps running in the background
+// after it is abandoned, and is skipped by later scans until the run returns.
+func (r *ValidatorRegistry) runValidator(ctx context.Context, validator Validator, timeout time.Duration) (*ValidationError, error) {
+	runCtx, cancel := ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
 	}
+	defer cancel()
 
-	// Add cluster objects to the fake client
-	builder = builder.WithObjects(clusterObjects...)
+	type outcome struct {
+		err       error
+		recovered interface{}
+		stack     []byte
+	}
+	done := make(chan outcome, 1)
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- outcome{recovered: recovered, stack: debug.Stack()}
+			}
+		}()
+		done <- outcome{err: validator.ValidateCluster(runCtx)}
+	}()
+
+	validatorType := validator.GetValidationType()
+	timedOut := func() *ValidationError {
+		finding := NewValidationErrorWithCode("Validator", validatorType, "", "validator_timeout", GetRegistryErrorCode("validator_timeout"),
+			fmt.Sprintf("Validator '%s' did not finish within %s and was abandoned for this scan", validatorType, timeout)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Check the validator's dependencies, such as image registries or plugins, or raise --validator-timeout").
+			WithDetail("validator", validatorType).
+			WithDetail("timeout", timeout.String())
+		return &finding
+	}
 
-	// Create the temporary client
-	return builder.Build()
+	select {
+	case result := <-done:
+		if result.recovered != nil {
+			r.log.Error(fmt.Errorf("panic: %v", result.recovered), "validator panicked", "type", validatorType,
+				"stack", string(result.stack))
+			finding := NewValidationErrorWithCode("Validator", validatorType, "", "validator_panic", GetRegistryErrorCode("validator_panic"),
+				fmt.Sprintf("Validator '%s' panicked: %v", validatorType, result.recovered)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Report the panic and the stack trace logged by Kogaro; the validator's findings are kept from its last complete run").
+				WithDetail("validator", validatorType)
+			return &finding, nil
+		}
+		// A validator that honours its context returns the deadline error itself
+		if result.err != nil && ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return timedOut(), nil
+		}
+		return nil, result.err
+	case <-runCtx.Done():
+		r.mu.Lock()
+		if r.abandonedRuns == nil {
+			r.abandonedRuns = make(map[Validator]chan struct{})
+		}
+		r.abandonedRuns[validator] = finished
+		r.mu.Unlock()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return timedOut(), nil
+	}
 }
 
-// parseConfigFile parses a Kubernetes config file into objects
-func parseConfigFile(data []byte) ([]client.Object, error) {
-	// Split the file into individual YAML documents
-	docs := bytes.Split(data, []byte("---"))
-	var objects []client.Object
-
-	for _, doc := range docs {
-		if len(bytes.TrimSpace(doc)) == 0 {
-			continue
-		}
+// abandonedRunInProgress returns whether a run of the validator abandoned by an
+// earlier scan has yet to return
+func (r *ValidatorRegistry) abandonedRunInProgress(validator Validator) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-		// Check for Helm template syntax
-		docStr := string(bytes.TrimSpace(doc))
-		if strings.Contains(docStr, "{{") && strings.Contains(docStr, "}}") {
-			return nil, fmt.Errorf("file appears to contain Helm templates. Please render the template first using 'helm template' and validate the resulting YAML")
-		}
+	finished, abandoned := r.abandonedRuns[validator]
+	if !abandoned {
+		return false
+	}
+	select {
+	case <-finished:
+		delete(r.abandonedRuns, validator)
+		return false
+	default:
+		return true
+	}
+}
 
-		// Parse the YAML into an unstructured object
-		obj := &unstructured.Unstructured{}
-		if err := yaml.Unmarshal(doc, obj); err != nil {
-			return nil, fmt.Errorf("failed to parse YAML: %w", err)
+// isLowPriority returns whether a validator's type matches a low-priority validation
+// type or prefix
+func isLowPriority(validator Validator, lowPriority []string) bool {
+	validatorType := validator.GetValidationType()
+	for _, entry := range lowPriority {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(validatorType, prefix) {
+				return true
+			}
+		} else if validatorType == entry {
+			return true
 		}
-
-		objects = append(objects, obj)
 	}
+	return false
+}
 
-	return objects, nil
+// profilePolicy returns the validation profiles bound to namespaces
+func (r *ValidatorRegistry) profilePolicy() *ProfilePolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.profiles
 }
 
-// getClusterObjects retrieves all relevant objects from the cluster
-func (r *ValidatorRegistry) getClusterObjects(ctx context.Context) ([]client.Object, error) {
-	var objects []client.Object
+// teamPolicy returns the teams owning namespaces
+func (r *ValidatorRegistry) teamPolicy() *TeamPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	// Get all namespaces
-	var namespaces corev1.NamespaceList
-	if err := r.client.List(ctx, &namespaces); err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
-	}
+	return r.teams
+}
 
-	// For each namespace, get all relevant resources
-	for _, ns := range namespaces.Items {
-		// Get ConfigMaps
-		var configMaps corev1.ConfigMapList
-		if err := r.client.List(ctx, &configMaps, client.InNamespace(ns.Name)); err != nil {
-			return nil, fmt.Errorf("failed to list ConfigMaps: %w", err)
-		}
-		for i := range configMaps.Items {
-			objects = append(objects, &configMaps.Items[i])
-		}
+// snoozePolicy returns the snoozes of findings
+func (r *ValidatorRegistry) snoozePolicy() *SnoozePolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-		// Get Secrets
-		var secrets corev1.SecretList
-		if err := r.client.List(ctx, &secrets, client.InNamespace(ns.Name)); err != nil {
-			return nil, fmt.Errorf("failed to list Secrets: %w", err)
-		}
-		for i := range secrets.Items {
-			objects = append(objects, &secrets.Items[i])
-		}
+	return r.snoozes
+}
 
-		// Get Services
-		var services corev1.ServiceList
-		if err := r.client.List(ctx, &services, client.InNamespace(ns.Name)); err != nil {
-			return nil, fmt.Errorf("failed to list Services: %w", err)
-		}
-		for i := range services.Items {
-			objects = append(objects, &services.Items[i])
-		}
+// severityOverrides returns the severities findings are reported with
+func (r *ValidatorRegistry) severityOverrides() *SeverityPolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-		// Get Ingresses
-		var ingresses networkingv1.IngressList
-		if err := r.client.List(ctx, &ingresses, client.InNamespace(ns.Name)); err != nil {
-			return nil, fmt.Errorf("failed to list Ingresses: %w", err)
-		}
-		for i := range ingresses.Items {
-			objects = append(objects, &ingresses.Items[i])
-		}
+	return r.severityPolicy
+}
 
-		// Get PVCs
-		var pvcs corev1.PersistentVolumeClaimList
-		if err := r.client.List(ctx, &pvcs, client.InNamespace(ns.Name)); err != nil {
-			return nil, fmt.Errorf("failed to list PVCs: %w", err)
-		}
-		for i := range pvcs.Items {
-			objects = append(objects, &pvcs.Items[i])
-		}
-	}
+// scoreWeights returns the weights of findings in hygiene scores
+func (r *ValidatorRegistry) scoreWeights() *ScorePolicy {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
-	return objects, nil
+	return r.scorePolicy
 }
 
 // updateValidatorClient updates a validator's client to use the temporary client
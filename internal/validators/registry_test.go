@@ -11,14 +11,21 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/schedule"
 )
 
 const (
@@ -511,13 +518,19 @@ func TestFormatCIOutput(t *testing.T) {
 					TotalErrors   int      `json:"total_errors"`
 					MissingRefs   []string `json:"missing_refs,omitempty"`
 					SuggestedRefs []string `json:"suggested_refs,omitempty"`
+					// HygieneScore rates the validated resources from 0 to 100, see ScoreFindings
+					HygieneScore int `json:"hygiene_score"`
+					// NamespaceScores rates each validated namespace from 0 to 100
+					NamespaceScores map[string]int `json:"namespace_scores,omitempty"`
 				}{
-					TotalErrors: 0,
+					TotalErrors:  0,
+					HygieneScore: 100,
 				},
 				ExitCode: 0,
 			},
 			expected: `Validation Summary:
 Total Errors: 0
+Hygiene Score: 100/100
 Missing References: 0
 Suggested References: 0
 `,
@@ -529,9 +542,14 @@ Suggested References: 0
 					TotalErrors   int      `json:"total_errors"`
 					MissingRefs   []string `json:"missing_refs,omitempty"`
 					SuggestedRefs []string `json:"suggested_refs,omitempty"`
+					// HygieneScore rates the validated resources from 0 to 100, see ScoreFindings
+					HygieneScore int `json:"hygiene_score"`
+					// NamespaceScores rates each validated namespace from 0 to 100
+					NamespaceScores map[string]int `json:"namespace_scores,omitempty"`
 				}{
-					TotalErrors: 2,
-					MissingRefs: []string{"ConfigMap/test"},
+					TotalErrors:  2,
+					MissingRefs:  []string{"ConfigMap/test"},
+					HygieneScore: 90,
 				},
 				Errors: []ValidationError{
 					{
@@ -557,6 +575,7 @@ Suggested References: 0
 			},
 			expected: `Validation Summary:
 Total Errors: 2
+Hygiene Score: 90/100
 Missing References: 1
 Suggested References: 0
 
@@ -683,3 +702,417 @@ func TestValidateClusterWithNoErrors(t *testing.T) {
 	}
 }
 
+
+func TestValidateNewConfigWithScope_UsesClusterQuotas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&corev1.ResourceQuota{
+				ObjectMeta: metav1.ObjectMeta{Name: "compute", Namespace: "team-a"},
+				Spec: corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("3")},
+				},
+				Status: corev1.ResourceQuotaStatus{
+					Used: corev1.ResourceList{corev1.ResourcePods: resource.MustParse("2")},
+				},
+			},
+		).
+		Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), clusterClient)
+	registry.Register(NewQuotaValidator(nil, logr.Discard(), QuotaConfig{EnableQuotaCapacityValidation: true}))
+
+	config := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team-a
+spec:
+  replicas: 2
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+`)
+
+	result, err := registry.ValidateNewConfigWithScopeAndData(context.Background(), "-", "file-only", config)
+	if err != nil {
+		t.Fatalf("ValidateNewConfigWithScopeAndData() error = %v", err)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].ValidationType != "resource_quota_exceeded" {
+		t.Fatalf("expected a single resource_quota_exceeded error, got %+v", result.Errors)
+	}
+	if result.Errors[0].ResourceName != "web" {
+		t.Errorf("expected error for Deployment 'web', got %s/%s", result.Errors[0].ResourceType, result.Errors[0].ResourceName)
+	}
+}
+
+func TestValidateNewConfigWithScope_ReportsUndecodableObjects(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), fake.NewClientBuilder().Build())
+	registry.Register(NewQuotaValidator(nil, logr.Discard(), QuotaConfig{EnableQuotaCapacityValidation: true}))
+
+	config := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: team-a
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: team-a
+spec:
+  replicas: "two"
+`)
+
+	result, err := registry.ValidateNewConfigWithScopeAndData(context.Background(), "deploy.yaml", "file-only", config)
+	if err != nil {
+		t.Fatalf("ValidateNewConfigWithScopeAndData() error = %v", err)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].ValidationType != "invalid_config_object" {
+		t.Fatalf("expected a single invalid_config_object error, got %+v", result.Errors)
+	}
+	if got := result.Errors[0]; got.SourceFile != "deploy.yaml" || got.SourceLine != 7 {
+		t.Errorf("finding attributed to %s:%d, want deploy.yaml:7", got.SourceFile, got.SourceLine)
+	}
+	if result.ExitCode == 0 {
+		t.Error("expected a non-zero exit code for an undecodable object")
+	}
+}
+
+func TestValidateNewConfigWithScope_SeesClusterKinds(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+	_ = storagev1.AddToScheme(scheme)
+
+	// Cluster-scoped and namespaced objects that only exist in the cluster
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}},
+			&networkingv1.IngressClass{ObjectMeta: metav1.ObjectMeta{Name: "nginx"}},
+			&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast"}, Provisioner: "example.com/fast"},
+			&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app-sa", Namespace: "team-a"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}},
+		).
+		Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), clusterClient)
+	registry.Register(NewReferenceValidator(nil, logr.Discard(), ValidationConfig{
+		EnableIngressValidation:        true,
+		EnablePVCValidation:            true,
+		EnableServiceAccountValidation: true,
+	}))
+
+	config := []byte(`apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: web
+  namespace: team-a
+spec:
+  ingressClassName: nginx
+  rules:
+  - http:
+      paths:
+      - path: /
+        pathType: Prefix
+        backend:
+          service:
+            name: web
+            port:
+              number: 80
+---
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: team-a
+spec:
+  storageClassName: fast
+  accessModes: ["ReadWriteOnce"]
+  resources:
+    requests:
+      storage: 1Gi
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: app
+  namespace: team-a
+spec:
+  serviceAccountName: app-sa
+  containers:
+  - name: app
+    image: nginx
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: other
+  namespace: team-a
+spec:
+  serviceAccountName: missing-sa
+  containers:
+  - name: app
+    image: nginx
+`)
+
+	result, err := registry.ValidateNewConfigWithScopeAndData(context.Background(), "-", "file-only", config)
+	if err != nil {
+		t.Fatalf("ValidateNewConfigWithScopeAndData() error = %v", err)
+	}
+
+	if len(result.Errors) != 1 || result.Errors[0].ValidationType != "dangling_service_account" || result.Errors[0].ResourceName != "other" {
+		t.Fatalf("expected only the missing ServiceAccount of Pod 'other', got %+v", result.Errors)
+	}
+}
+
+func TestValidatorRegistry_APIBudgetSkipsLowPriorityValidators(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	var order []string
+	listing := func(validationType string, requests int) *mockValidator {
+		validator := &mockValidator{validationType: validationType}
+		validator.validateFunc = func(ctx context.Context) error {
+			order = append(order, validationType)
+			// Reads served from the cache do not count towards the budget
+			for i := 0; i < 5; i++ {
+				if err := validator.client.List(ctx, &corev1.PodList{}); err != nil {
+					return err
+				}
+			}
+			for i := 0; i < requests; i++ {
+				if err := sendAPIRequest(ctx); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return validator
+	}
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.SetAPIBudget(3, []string{"image_validation", "plugin:*"})
+	registry.Register(listing("image_validation", 1))
+	registry.Register(listing("reference_validation", 1))
+	registry.Register(listing("plugin:scanner", 1))
+	registry.Register(listing("security_validation", 1))
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	// High-priority validators run first and always run; low-priority validators
+	// run until the scan has used the budget, and are skipped then
+	want := []string{"reference_validation", "security_validation", "image_validation"}
+	if strings.Join(order, ",") != strings.Join(want, ",") {
+		t.Errorf("validators run = %v, want %v", order, want)
+	}
+}
+
+func TestValidatorRegistry_IsolatesHangingAndPanickingValidators(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.SetValidatorTimeout(50 * time.Millisecond)
+
+	release := make(chan struct{})
+	defer close(release)
+	healthy := &MockValidator{validationType: "reference_validation"}
+	registry.Register(&mockValidator{validationType: "image_validation", validateFunc: func(context.Context) error {
+		<-release // Ignores its context, like a client stuck on a registry
+		return nil
+	}})
+	registry.Register(&mockValidator{validationType: "plugin:acme", validateFunc: func(context.Context) error {
+		var findings []ValidationError
+		_ = findings[1]
+		return nil
+	}})
+	registry.Register(&mockValidator{validationType: "quota_validation", validateFunc: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+	registry.Register(healthy)
+
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if healthy.GetCallCount() != 1 {
+		t.Errorf("healthy validator ran %d times, want 1", healthy.GetCallCount())
+	}
+
+	var got []string
+	for _, finding := range registry.LastValidationResult().Errors {
+		got = append(got, finding.ResourceName+" "+finding.ErrorCode)
+	}
+	want := []string{"image_validation KOGARO-SYS-001", "plugin:acme KOGARO-SYS-002", "quota_validation KOGARO-SYS-001"}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings =\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestValidatorRegistry_SkipsValidatorUntilAbandonedRunReturns(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.SetValidatorTimeout(50 * time.Millisecond)
+
+	release := make(chan struct{})
+	returned := make(chan struct{})
+	var runs atomic.Int32
+	registry.Register(&mockValidator{validationType: "image_validation", validateFunc: func(context.Context) error {
+		if runs.Add(1) == 1 {
+			<-release // Ignores its context, like a client stuck on a registry
+			close(returned)
+		}
+		return nil
+	}})
+
+	scan := func() []ValidationError {
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+		return registry.LastValidationResult().Errors
+	}
+
+	if findings := scan(); len(findings) != 1 || findings[0].ErrorCode != "KOGARO-SYS-001" {
+		t.Fatalf("findings of the first scan = %+v, want a timeout", findings)
+	}
+	// The abandoned run still uses the validator, so the next scan leaves it alone
+	findings := scan()
+	if runs.Load() != 1 {
+		t.Errorf("validator ran %d times while its abandoned run was in progress, want 1", runs.Load())
+	}
+	if len(findings) != 1 || findings[0].ErrorCode != "KOGARO-SYS-001" || !strings.Contains(findings[0].Message, "still running") {
+		t.Errorf("findings of the second scan = %+v, want the skipped validator reported", findings)
+	}
+
+	close(release)
+	<-returned
+	// Wait for the abandoned run's goroutine, which returns right after the validator
+	for registry.abandonedRunInProgress(registry.validators[0]) {
+		time.Sleep(time.Millisecond)
+	}
+	if findings := scan(); len(findings) != 0 || runs.Load() != 2 {
+		t.Errorf("after the abandoned run returned, findings = %+v and runs = %d, want none and 2", findings, runs.Load())
+	}
+}
+
+func TestValidatorRegistry_QuietHoursHoldBackNotifiers(t *testing.T) {
+	registry, _ := setupTestRegistry(t)
+	registry.validators = make([]Validator, 0)
+	registry.Register(&MockValidator{validationType: "test_validator"})
+
+	var listened, notified int
+	registry.AddScanListener(func(ValidationResult, time.Time) { listened++ })
+	registry.AddNotifier(func(ValidationResult, time.Time) { notified++ })
+
+	alwaysQuiet, err := schedule.ParseQuietHours("Sun-Sat", nil)
+	if err != nil {
+		t.Fatalf("ParseQuietHours() error = %v", err)
+	}
+	registry.SetQuietHours(alwaysQuiet)
+	if err := registry.ValidateCluster(context.TODO()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if listened != 1 || notified != 0 {
+		t.Errorf("during quiet hours listeners ran %d times and notifiers %d times, want 1 and 0", listened, notified)
+	}
+
+	registry.SetQuietHours(nil)
+	if err := registry.ValidateCluster(context.TODO()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if listened != 2 || notified != 1 {
+		t.Errorf("outside quiet hours listeners ran %d times and notifiers %d times, want 2 and 1", listened, notified)
+	}
+}
+
+func TestValidatorRegistry_CompletionListenersRunLast(t *testing.T) {
+	registry, _ := setupTestRegistry(t)
+	registry.validators = make([]Validator, 0)
+	registry.Register(&MockValidator{validationType: "test_validator"})
+
+	var calls []string
+	registry.AddCompletionListener(func(ValidationResult, time.Time) { calls = append(calls, "completion") })
+	registry.AddNotifier(func(ValidationResult, time.Time) { calls = append(calls, "notifier") })
+	registry.AddScanListener(func(ValidationResult, time.Time) { calls = append(calls, "listener") })
+
+	if err := registry.ValidateCluster(context.TODO()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if got := strings.Join(calls, ","); got != "listener,notifier,completion" {
+		t.Errorf("calls = %s, want listener,notifier,completion", got)
+	}
+}
+
+func TestValidatorRegistry_Drain(t *testing.T) {
+	registry, _ := setupTestRegistry(t)
+	registry.validators = make([]Validator, 0)
+
+	finding := NewValidationError("Pod", "web", "shop", "dangling_configmap_volume", "ConfigMap 'settings' does not exist")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// Shutdown starts while the first validator runs, which then finishes; the drain
+	// timeout cuts off the second, and the third never runs
+	registry.Register(&mockValidator{validationType: "finishing", lastValidationErrors: []ValidationError{*finding}, validateFunc: func(context.Context) error {
+		registry.Drain()
+		return nil
+	}})
+	second := &ContextAwareValidator{shouldBlock: true}
+	registry.Register(second)
+	third := &MockValidator{validationType: "skipped"}
+	registry.Register(third)
+
+	var published []ValidationError
+	registry.AddScanListener(func(result ValidationResult, _ time.Time) { published = result.Errors })
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	if err := registry.ValidateCluster(ctx); err != nil {
+		t.Fatalf("ValidateCluster() error = %v, want the drained scan to complete", err)
+	}
+	if third.callCount != 0 {
+		t.Errorf("validator after the drain ran %d times, want 0", third.callCount)
+	}
+	if len(published) != 1 || published[0].ResourceName != "web" {
+		t.Errorf("published findings = %v, want the finishing validator's", published)
+	}
+}
+
+func TestValidatorRegistry_Flush(t *testing.T) {
+	registry, _ := setupTestRegistry(t)
+	registry.validators = make([]Validator, 0)
+	registry.Register(&MockValidator{validationType: "test_validator"})
+
+	var notified, completed int
+	registry.AddNotifier(func(ValidationResult, time.Time) { notified++ })
+	registry.AddCompletionListener(func(ValidationResult, time.Time) { completed++ })
+
+	// Nothing to flush before the first scan
+	registry.Flush()
+	if notified != 0 || completed != 0 {
+		t.Fatalf("Flush() before a scan notified %d and completed %d times, want 0", notified, completed)
+	}
+
+	if err := registry.ValidateCluster(context.TODO()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	registry.Flush()
+	if notified != 2 || completed != 2 {
+		t.Errorf("after a scan and a flush notifiers ran %d times and completion listeners %d times, want 2 and 2", notified, completed)
+	}
+}
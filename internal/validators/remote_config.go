@@ -0,0 +1,223 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+)
+
+const (
+	// ociConfigScheme prefixes configs published as OCI artifacts
+	ociConfigScheme = "oci://"
+	// remoteConfigTimeout bounds fetching a remote config
+	remoteConfigTimeout = 2 * time.Minute
+	// maxRemoteConfigSize bounds the manifests read from a remote config, so that a
+	// wrong reference can't exhaust memory
+	maxRemoteConfigSize = 64 << 20
+	// ociTitleAnnotation names the file held by a layer of an OCI artifact, as set by
+	// oras push
+	ociTitleAnnotation = "org.opencontainers.image.title"
+)
+
+// IsRemoteConfig reports whether a config is fetched rather than read from the local
+// filesystem: an OCI artifact given as oci://registry/repository:tag, or a manifest
+// hosted at an http:// or https:// URL
+func IsRemoteConfig(configPath string) bool {
+	return strings.HasPrefix(configPath, ociConfigScheme) ||
+		strings.HasPrefix(configPath, "https://") || strings.HasPrefix(configPath, "http://")
+}
+
+// ReadConfig reads a config file, fetching it when it is remote. The manifests of a
+// remote config holding several files are joined into one YAML stream.
+func ReadConfig(ctx context.Context, configPath string) ([]byte, error) {
+	if !IsRemoteConfig(configPath) {
+		configData, err := os.ReadFile(configPath) // nolint:gosec // Config file path is user-provided
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		return configData, nil
+	}
+	files, err := FetchRemoteManifests(ctx, configPath)
+	if err != nil {
+		return nil, err
+	}
+	return JoinManifests(files), nil
+}
+
+// FetchRemoteManifests fetches the YAML manifests of a remote config.
+//
+// An OCI artifact may hold manifest files as layers, as pushed by oras, or tarballs of
+// a directory of manifests, as pushed by flux push artifact. Tarballs are read like a
+// manifest directory: hidden directories and kustomization.yaml files are skipped.
+// Registry credentials come from the Docker config, as for docker pull.
+//
+// A URL is read as a single manifest file. Credentials may be given in the URL.
+func FetchRemoteManifests(ctx context.Context, ref string) ([]ManifestFile, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteConfigTimeout)
+	defer cancel()
+
+	var files []ManifestFile
+	var err error
+	if strings.HasPrefix(ref, ociConfigScheme) {
+		files, err = fetchOCIManifests(ctx, ref)
+	} else {
+		files, err = fetchURLManifest(ctx, ref)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no YAML manifests found in %s", ref)
+	}
+	return files, nil
+}
+
+// fetchURLManifest downloads a manifest file
+func fetchURLManifest(ctx context.Context, url string) ([]ManifestFile, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config URL: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch config %s: %s", req.URL.Redacted(), resp.Status)
+	}
+
+	data, err := readLimited(resp.Body, maxRemoteConfigSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch config %s: %w", req.URL.Redacted(), err)
+	}
+	return []ManifestFile{{Path: req.URL.Redacted(), Data: data}}, nil
+}
+
+// fetchOCIManifests pulls the layers of an OCI artifact and reads the manifests they hold
+func fetchOCIManifests(ctx context.Context, ref string) ([]ManifestFile, error) {
+	reference, err := name.ParseReference(strings.TrimPrefix(ref, ociConfigScheme))
+	if err != nil {
+		return nil, fmt.Errorf("invalid OCI reference %s: %w", ref, err)
+	}
+	image, err := remote.Image(reference, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull %s: %w", ref, err)
+	}
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest of %s: %w", ref, err)
+	}
+
+	var files []ManifestFile
+	remaining := int64(maxRemoteConfigSize)
+	for _, descriptor := range manifest.Layers {
+		layer, err := image.LayerByDigest(descriptor.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read layer %s of %s: %w", descriptor.Digest, ref, err)
+		}
+		blob, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull layer %s of %s: %w", descriptor.Digest, ref, err)
+		}
+		data, err := readLimited(blob, remaining)
+		_ = blob.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull layer %s of %s: %w", descriptor.Digest, ref, err)
+		}
+
+		layerFiles, err := layerManifests(data, descriptor.Annotations[ociTitleAnnotation], ref, remaining)
+		if err != nil {
+			return nil, fmt.Errorf("layer %s of %s: %w", descriptor.Digest, ref, err)
+		}
+		for _, file := range layerFiles {
+			remaining -= int64(len(file.Data))
+		}
+		files = append(files, layerFiles...)
+	}
+	return files, nil
+}
+
+// layerManifests reads the manifests of an artifact layer, which is a manifest file or
+// a tarball of them, either possibly gzipped. A file is only read when its title names
+// a YAML manifest; layers without a title are assumed to be manifests.
+func layerManifests(data []byte, title, ref string, limit int64) ([]ManifestFile, error) {
+	if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress: %w", err)
+		}
+		if data, err = readLimited(reader, limit); err != nil {
+			return nil, fmt.Errorf("failed to decompress: %w", err)
+		}
+		title = strings.TrimSuffix(strings.TrimSuffix(title, ".gz"), ".tgz")
+	}
+
+	if !isTarball(data) {
+		if title != "" && !IsManifestFile(title) {
+			return nil, nil
+		}
+		filePath := ref
+		if title != "" {
+			filePath = ref + "/" + title
+		}
+		return []ManifestFile{{Path: filePath, Data: data}}, nil
+	}
+
+	var files []ManifestFile
+	archive := tar.NewReader(bytes.NewReader(data))
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tarball: %w", err)
+		}
+		entry := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		if header.Typeflag != tar.TypeReg || !IsManifestFile(path.Base(entry)) || hiddenDirectory(entry) {
+			continue
+		}
+		content, err := readLimited(archive, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from tarball: %w", entry, err)
+		}
+		files = append(files, ManifestFile{Path: ref + "/" + entry, Data: content})
+	}
+}
+
+// isTarball reports whether data starts with a tar header
+func isTarball(data []byte) bool {
+	// The ustar magic follows the 257 bytes of name, mode, ids, size, times and link
+	return len(data) > 262 && bytes.HasPrefix(data[257:], []byte("ustar"))
+}
+
+// readLimited reads at most limit bytes, failing when there are more
+func readLimited(reader io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("config exceeds %d MiB", maxRemoteConfigSize>>20)
+	}
+	return data, nil
+}
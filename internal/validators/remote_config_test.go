@@ -0,0 +1,148 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const remoteDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+`
+
+const remoteService = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  namespace: shop
+`
+
+func TestIsRemoteConfig(t *testing.T) {
+	for configPath, want := range map[string]bool{
+		"oci://ghcr.io/acme/manifests:v1":        true,
+		"https://example.com/app/manifests.yaml": true,
+		"http://localhost:8000/manifests.yaml":   true,
+		"manifests.yaml":                         false,
+		"-":                                      false,
+	} {
+		if got := IsRemoteConfig(configPath); got != want {
+			t.Errorf("IsRemoteConfig(%q) = %v, want %v", configPath, got, want)
+		}
+	}
+}
+
+func TestFetchRemoteManifests_URL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/manifests.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(remoteDeployment))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	files, err := FetchRemoteManifests(ctx, server.URL+"/app/manifests.yaml")
+	if err != nil {
+		t.Fatalf("FetchRemoteManifests() error = %v", err)
+	}
+	if len(files) != 1 || string(files[0].Data) != remoteDeployment {
+		t.Errorf("files = %v, want the hosted manifest", files)
+	}
+
+	if _, err := FetchRemoteManifests(ctx, server.URL+"/missing.yaml"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("FetchRemoteManifests() of a missing file error = %v, want the HTTP status", err)
+	}
+}
+
+func TestFetchRemoteManifests_OCI(t *testing.T) {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	// A manifest pushed as a file layer, as by oras, and a gzipped tarball of a manifest
+	// directory, as by flux push artifact
+	var tarball bytes.Buffer
+	gz := gzip.NewWriter(&tarball)
+	archive := tar.NewWriter(gz)
+	for path, content := range map[string]string{
+		"./apps/service.yaml":       remoteService,
+		"./apps/kustomization.yaml": "resources: []\n",
+		"./.git/config.yaml":        "hidden: true\n",
+	} {
+		if err := archive.WriteHeader(&tar.Header{Name: path, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	image, err := mutate.Append(empty.Image,
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte(remoteDeployment), "application/yaml"),
+			Annotations: map[string]string{ociTitleAnnotation: "deployment.yaml"},
+		},
+		mutate.Addendum{
+			Layer:       static.NewLayer([]byte("# Shop manifests\n"), "text/markdown"),
+			Annotations: map[string]string{ociTitleAnnotation: "README.md"},
+		},
+		mutate.Addendum{Layer: static.NewLayer(tarball.Bytes(), types.MediaType("application/vnd.cncf.flux.content.v1.tar+gzip"))},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reference, err := name.ParseReference(host + "/shop/manifests:v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(reference, image); err != nil {
+		t.Fatalf("failed to push artifact: %v", err)
+	}
+
+	ref := "oci://" + host + "/shop/manifests:v1"
+	files, err := FetchRemoteManifests(context.Background(), ref)
+	if err != nil {
+		t.Fatalf("FetchRemoteManifests() error = %v", err)
+	}
+	paths := make(map[string]string)
+	for _, file := range files {
+		paths[file.Path] = string(file.Data)
+	}
+	if len(paths) != 2 || paths[ref+"/deployment.yaml"] != remoteDeployment || paths[ref+"/apps/service.yaml"] != remoteService {
+		t.Errorf("files = %v, want the manifest layer and the manifest of the tarball", paths)
+	}
+
+	if _, err := FetchRemoteManifests(context.Background(), "oci://"+host+"/shop/missing:v1"); err == nil {
+		t.Error("FetchRemoteManifests() of a missing artifact succeeded, want an error")
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+// capacityResources are the resources compared against node allocatable in aggregate
+var capacityResources = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory}
+
+// capacityWorkload is a pod-producing resource together with the number of pods it runs
+type capacityWorkload struct {
+	resourceType string
+	name         string
+	namespace    string
+	spec         corev1.PodSpec
+	replicas     int64
+}
+
+// validateNodeCapacity compares the requests and limits of workloads with the
+// allocatable capacity of the cluster's schedulable nodes
+func (v *ResourceLimitsValidator) validateNodeCapacity(ctx context.Context) ([]ValidationError, error) {
+	var nodeList corev1.NodeList
+	if err := v.client.List(ctx, &nodeList); err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	var nodes []corev1.Node
+	for _, node := range nodeList.Items {
+		if !node.Spec.Unschedulable {
+			nodes = append(nodes, node)
+		}
+	}
+	// Without nodes there is no capacity to compare against
+	if len(nodes) == 0 {
+		return nil, nil
+	}
+
+	workloads, err := v.listCapacityWorkloads(ctx, int64(len(nodes)))
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []ValidationError
+	demandByNamespace := make(map[string]corev1.ResourceList)
+	for _, workload := range workloads {
+		requests, _ := podEffectiveResources(workload.spec, nil)
+		candidates := nodesForPod(nodes, workload.spec)
+
+		errors = append(errors, v.validatePodFitsNode(workload, requests, candidates)...)
+		errors = append(errors, v.validateLimitsFitNode(workload, candidates)...)
+
+		demand, ok := demandByNamespace[workload.namespace]
+		if !ok {
+			demand = make(corev1.ResourceList)
+			demandByNamespace[workload.namespace] = demand
+		}
+		for _, resourceName := range capacityResources {
+			if request, ok := requests[resourceName]; ok {
+				addResources(demand, corev1.ResourceList{resourceName: *resource.NewMilliQuantity(request.MilliValue()*workload.replicas, request.Format)})
+			}
+		}
+	}
+
+	allocatable := make(corev1.ResourceList)
+	for _, node := range nodes {
+		addResources(allocatable, node.Status.Allocatable)
+	}
+	namespaces := make([]string, 0, len(demandByNamespace))
+	for namespace := range demandByNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		errors = append(errors, v.validateNamespaceDemand(namespace, demandByNamespace[namespace], allocatable, len(nodes))...)
+	}
+
+	return errors, nil
+}
+
+// listCapacityWorkloads lists the Deployments, StatefulSets, DaemonSets and standalone
+// Pods outside system namespaces. DaemonSets are counted once per schedulable node.
+func (v *ResourceLimitsValidator) listCapacityWorkloads(ctx context.Context, nodeCount int64) ([]capacityWorkload, error) {
+	var workloads []capacityWorkload
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, capacityWorkload{DeploymentType, deployment.Name, deployment.Namespace, deployment.Spec.Template.Spec, replicaCount(deployment.Spec.Replicas)})
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		workloads = append(workloads, capacityWorkload{StatefulSetType, statefulSet.Name, statefulSet.Namespace, statefulSet.Spec.Template.Spec, replicaCount(statefulSet.Spec.Replicas)})
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		workloads = append(workloads, capacityWorkload{"DaemonSet", daemonSet.Name, daemonSet.Namespace, daemonSet.Spec.Template.Spec, nodeCount})
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are accounted for via their controllers, and
+		// finished pods no longer hold their requests
+		if utils.HasOwnerReferences(*pod) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return nil
+		}
+		workloads = append(workloads, capacityWorkload{"Pod", pod.Name, pod.Namespace, pod.Spec, 1})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var filtered []capacityWorkload
+	for _, workload := range workloads {
+		if !v.sharedConfig.IsSystemNamespace(workload.namespace) {
+			filtered = append(filtered, workload)
+		}
+	}
+	return filtered, nil
+}
+
+// validatePodFitsNode reports workloads whose pod requests exceed the allocatable
+// capacity of every candidate node, so their pods stay Pending
+func (v *ResourceLimitsValidator) validatePodFitsNode(workload capacityWorkload, requests corev1.ResourceList, candidates []corev1.Node) []ValidationError {
+	if len(requests) == 0 {
+		return nil
+	}
+	for _, node := range candidates {
+		if nodeFits(node, requests) {
+			return nil
+		}
+	}
+
+	largest := largestAllocatable(candidates)
+	var requested, available []string
+	for _, resourceName := range sortedResourceNames(requests) {
+		request := requests[resourceName]
+		if request.IsZero() {
+			continue
+		}
+		requested = append(requested, fmt.Sprintf("%s %s", resourceName, request.String()))
+		capacity := largest[resourceName]
+		available = append(available, fmt.Sprintf("%s %s", resourceName, capacity.String()))
+	}
+
+	errorCode := GetResourceLimitsErrorCode("request_exceeds_node_capacity", workload.resourceType, "", true)
+	return []ValidationError{NewValidationErrorWithCode(workload.resourceType, workload.name, workload.namespace, "request_exceeds_node_capacity", errorCode,
+		fmt.Sprintf("Pod requests (%s) fit on none of the %d candidate nodes; the largest allocatable is %s", strings.Join(requested, ", "), len(candidates), strings.Join(available, ", "))).
+		WithSeverity(SeverityError).
+		WithRemediationHint("Lower the requests below the allocatable capacity of a node, or add nodes large enough to run the pod; until then its pods stay Pending").
+		WithDetail("pod_requests", strings.Join(requested, ",")).
+		WithDetail("largest_allocatable", strings.Join(available, ",")).
+		WithDetail("candidate_nodes", fmt.Sprintf("%d", len(candidates)))}
+}
+
+// validateLimitsFitNode reports containers whose CPU or memory limit is larger than
+// any candidate node can provide, so the limit never constrains the container
+func (v *ResourceLimitsValidator) validateLimitsFitNode(workload capacityWorkload, candidates []corev1.Node) []ValidationError {
+	var errors []ValidationError
+
+	largest := largestAllocatable(candidates)
+	containers := append(append([]corev1.Container{}, workload.spec.InitContainers...), workload.spec.Containers...)
+	for _, container := range containers {
+		for _, resourceName := range capacityResources {
+			limit, ok := container.Resources.Limits[resourceName]
+			if !ok || limit.Cmp(largest[resourceName]) <= 0 {
+				continue
+			}
+			capacity := largest[resourceName]
+			errorCode := GetResourceLimitsErrorCode("limit_exceeds_node_capacity", workload.resourceType, "", true)
+			errors = append(errors, NewValidationErrorWithCode(workload.resourceType, workload.name, workload.namespace, "limit_exceeds_node_capacity", errorCode,
+				fmt.Sprintf("Container '%s' %s limit %s exceeds the allocatable %s of every node (largest %s)", container.Name, resourceName, limit.String(), resourceName, capacity.String())).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(fmt.Sprintf("Lower the %s limit to at most %s; a limit above node capacity does not protect the node from the container", resourceName, capacity.String())).
+				WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+				WithDetail("container_name", container.Name).
+				WithDetail("resource", string(resourceName)).
+				WithDetail("limit", limit.String()).
+				WithDetail("largest_allocatable", capacity.String()))
+		}
+	}
+
+	return errors
+}
+
+// validateNamespaceDemand reports namespaces whose workloads together request more CPU
+// or memory than all schedulable nodes provide
+func (v *ResourceLimitsValidator) validateNamespaceDemand(namespace string, demand, allocatable corev1.ResourceList, nodeCount int) []ValidationError {
+	var exceeded []string
+	for _, resourceName := range capacityResources {
+		requested, ok := demand[resourceName]
+		capacity := allocatable[resourceName]
+		if ok && requested.Cmp(capacity) > 0 {
+			exceeded = append(exceeded, fmt.Sprintf("%s %s of %s", resourceName, requested.String(), capacity.String()))
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	errorCode := GetResourceLimitsErrorCode("namespace_requests_exceed_capacity", "Namespace", "", true)
+	return []ValidationError{NewValidationErrorWithCode("Namespace", namespace, namespace, "namespace_requests_exceed_capacity", errorCode,
+		fmt.Sprintf("Workloads in namespace '%s' request more than the allocatable capacity of all %d schedulable nodes: %s", namespace, nodeCount, strings.Join(exceeded, ", "))).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Reduce replicas or requests in the namespace, or add node capacity; some pods cannot be scheduled even on an otherwise empty cluster").
+		WithDetail("exceeded", strings.Join(exceeded, ", "))}
+}
+
+// nodesForPod returns the nodes matching the pod's nodeSelector. A selector matching no
+// node leaves every node as a candidate, since that is a different problem.
+func nodesForPod(nodes []corev1.Node, spec corev1.PodSpec) []corev1.Node {
+	if len(spec.NodeSelector) == 0 {
+		return nodes
+	}
+	selector := labels.SelectorFromSet(spec.NodeSelector)
+	var matching []corev1.Node
+	for _, node := range nodes {
+		if selector.Matches(labels.Set(node.Labels)) {
+			matching = append(matching, node)
+		}
+	}
+	if len(matching) == 0 {
+		return nodes
+	}
+	return matching
+}
+
+// nodeFits reports whether the node's allocatable capacity covers every request
+func nodeFits(node corev1.Node, requests corev1.ResourceList) bool {
+	for resourceName, request := range requests {
+		if request.Cmp(node.Status.Allocatable[resourceName]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// largestAllocatable returns the largest allocatable amount of each resource among nodes
+func largestAllocatable(nodes []corev1.Node) corev1.ResourceList {
+	largest := make(corev1.ResourceList)
+	for _, node := range nodes {
+		maxResources(largest, node.Status.Allocatable)
+	}
+	return largest
+}
+
+// sortedResourceNames returns the names of a resource list in a stable order
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for resourceName := range list {
+		names = append(names, resourceName)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
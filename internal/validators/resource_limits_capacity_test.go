@@ -0,0 +1,128 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceLimitsValidator_ValidateNodeCapacity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	node := func(name, cpu, memory string, nodeLabels map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels},
+			Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			}},
+		}
+	}
+	resources := func(cpu, memory string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu), corev1.ResourceMemory: resource.MustParse(memory)}
+	}
+	deployment := func(namespace, name string, replicas int32, nodeSelector map[string]string, requirements corev1.ResourceRequirements) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					NodeSelector: nodeSelector,
+					Containers:   []corev1.Container{{Name: "app", Resources: requirements}},
+				}},
+			},
+		}
+	}
+
+	objects := []client.Object{
+		node("small-1", "4", "16Gi", map[string]string{"pool": "small"}),
+		node("small-2", "4", "16Gi", map[string]string{"pool": "small"}),
+		node("large", "16", "64Gi", map[string]string{"pool": "large"}),
+		// Cordoned nodes provide no capacity
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "cordoned"},
+			Spec:       corev1.NodeSpec{Unschedulable: true},
+			Status:     corev1.NodeStatus{Allocatable: resources("64", "256Gi")},
+		},
+		// Fits on the large node only
+		deployment("shop", "api", 1, nil, corev1.ResourceRequirements{Requests: resources("8", "32Gi")}),
+		// Too big for the small pool it is restricted to
+		deployment("shop", "worker", 1, map[string]string{"pool": "small"}, corev1.ResourceRequirements{Requests: resources("8", "8Gi")}),
+		// Fits on no node, and its memory limit exceeds every node
+		deployment("shop", "cache", 1, nil, corev1.ResourceRequirements{Requests: resources("1", "96Gi"), Limits: resources("2", "128Gi")}),
+		// Each pod fits, but together they need more CPU than the cluster has
+		deployment("batch", "crunch", 10, nil, corev1.ResourceRequirements{Requests: resources("3", "1Gi")}),
+		deployment("kube-system", "huge", 1, nil, corev1.ResourceRequirements{Requests: resources("100", "1Ti")}),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{EnableNodeCapacityValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		got = append(got, finding.ValidationType+" "+finding.ResourceType+"/"+finding.ResourceName+" "+finding.ErrorCode)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"limit_exceeds_node_capacity Deployment/cache KOGARO-RES-012",
+		"namespace_requests_exceed_capacity Namespace/batch KOGARO-RES-013",
+		"namespace_requests_exceed_capacity Namespace/shop KOGARO-RES-013",
+		"request_exceeds_node_capacity Deployment/cache KOGARO-RES-011",
+		"request_exceeds_node_capacity Deployment/worker KOGARO-RES-011",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestResourceLimitsValidator_ValidateNodeCapacityWithoutNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	replicas := int32(1)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:      "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("64")}},
+			}}}},
+		},
+	}).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{EnableNodeCapacityValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if errs := validator.GetLastValidationErrors(); len(errs) != 0 {
+		t.Errorf("expected no findings without nodes, got %v", errs)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultMaxCPULimitRequestRatio is the default largest CPU limit-to-request ratio
+	DefaultMaxCPULimitRequestRatio = 10.0
+	// DefaultMaxMemoryLimitRequestRatio is the default largest memory limit-to-request ratio
+	DefaultMaxMemoryLimitRequestRatio = 4.0
+)
+
+// validateOvercommit reports containers whose limits are far above their requests,
+// since the scheduler only reserves the request and the node is overcommitted by the
+// difference, and memory limits set without a memory request
+func (v *ResourceLimitsValidator) validateOvercommit(container corev1.Container, resourceType, resourceName, namespace, containerType string) []ValidationError {
+	var errors []ValidationError
+
+	maxRatios := map[corev1.ResourceName]float64{
+		corev1.ResourceCPU:    v.config.MaxCPULimitRequestRatio,
+		corev1.ResourceMemory: v.config.MaxMemoryLimitRequestRatio,
+	}
+	for _, name := range capacityResources {
+		maxRatio := maxRatios[name]
+		request, hasRequest := container.Resources.Requests[name]
+		limit, hasLimit := container.Resources.Limits[name]
+		if maxRatio <= 0 || !hasRequest || !hasLimit || request.IsZero() {
+			continue
+		}
+		ratio := float64(limit.MilliValue()) / float64(request.MilliValue())
+		if ratio <= maxRatio {
+			continue
+		}
+		formattedRatio := strconv.FormatFloat(ratio, 'f', 1, 64)
+		errorCode := GetResourceLimitsErrorCode("limit_request_ratio_exceeded", resourceType, "", true)
+		errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "limit_request_ratio_exceeded", errorCode,
+			fmt.Sprintf("Container '%s' %s limit %s is %sx its request %s, above the maximum ratio of %s", container.Name, name, limit.String(), formattedRatio, request.String(), strconv.FormatFloat(maxRatio, 'f', -1, 64))).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Raise the %s request towards the container's real usage or lower the limit; only the request is reserved, so nodes running several such containers are overcommitted", name)).
+			WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+			WithDetail("container_name", container.Name).
+			WithDetail("container_type", containerType).
+			WithDetail("resource", string(name)).
+			WithDetail("ratio", formattedRatio).
+			WithDetail("max_ratio", strconv.FormatFloat(maxRatio, 'f', -1, 64)))
+	}
+
+	if !container.Resources.Limits.Memory().IsZero() && container.Resources.Requests.Memory().IsZero() {
+		limit := container.Resources.Limits.Memory()
+		errorCode := GetResourceLimitsErrorCode("memory_limit_without_request", resourceType, "", true)
+		errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "memory_limit_without_request", errorCode,
+			fmt.Sprintf("Container '%s' sets a memory limit of %s without a memory request, so the request defaults to the full limit", container.Name, limit.String())).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Set an explicit memory request matching the container's usage; the defaulted request reserves the whole limit on the node, or the request is silently changed when someone lowers the limit").
+			WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+			WithDetail("container_name", container.Name).
+			WithDetail("container_type", containerType).
+			WithDetail("memory_limit", limit.String()))
+	}
+
+	return errors
+}
+
+// validateCPULimitPolicy reports CPU limits in clusters whose policy is not to set
+// them, since CPU limits throttle containers even when the node has idle CPU
+func (v *ResourceLimitsValidator) validateCPULimitPolicy(container corev1.Container, resourceType, resourceName, namespace, containerType string) []ValidationError {
+	if container.Resources.Limits.Cpu().IsZero() {
+		return nil
+	}
+
+	limit := container.Resources.Limits.Cpu()
+	errorCode := GetResourceLimitsErrorCode("cpu_limit_forbidden", resourceType, "", true)
+	return []ValidationError{NewValidationErrorWithCode(resourceType, resourceName, namespace, "cpu_limit_forbidden", errorCode,
+		fmt.Sprintf("Container '%s' sets a CPU limit of %s, but CPU limits are not used in this cluster", container.Name, limit.String())).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Remove the CPU limit and size the CPU request to the container's needs; CPU limits throttle the container even when the node has idle CPU").
+		WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+		WithDetail("container_name", container.Name).
+		WithDetail("container_type", containerType).
+		WithDetail("cpu_limit", limit.String())}
+}
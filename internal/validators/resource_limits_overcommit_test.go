@@ -0,0 +1,123 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceLimitsValidator_ValidateOvercommit(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	pod := func(name string, requests, limits corev1.ResourceList) client.Object {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:      "app",
+				Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits},
+			}}},
+		}
+	}
+	list := func(cpu, memory string) corev1.ResourceList {
+		resources := corev1.ResourceList{}
+		if cpu != "" {
+			resources[corev1.ResourceCPU] = resource.MustParse(cpu)
+		}
+		if memory != "" {
+			resources[corev1.ResourceMemory] = resource.MustParse(memory)
+		}
+		return resources
+	}
+
+	objects := []client.Object{
+		pod("balanced", list("500m", "1Gi"), list("1", "2Gi")),
+		pod("cpu-gamble", list("100m", "1Gi"), list("2", "1Gi")),
+		pod("memory-gamble", list("1", "256Mi"), list("1", "2Gi")),
+		pod("limit-only", list("500m", ""), list("", "1Gi")),
+		pod("no-cpu-limit", list("500m", "1Gi"), list("", "1Gi")),
+		// A CPU limit alone satisfies missing_resource_limits unless CPU limits are forbidden
+		pod("cpu-limit-only", list("500m", "1Gi"), list("1", "")),
+	}
+
+	tests := []struct {
+		name     string
+		config   ResourceLimitsConfig
+		expected []string
+	}{
+		{
+			name: "default ratios",
+			config: ResourceLimitsConfig{
+				EnableOvercommitValidation: true,
+				MaxCPULimitRequestRatio:    DefaultMaxCPULimitRequestRatio,
+				MaxMemoryLimitRequestRatio: DefaultMaxMemoryLimitRequestRatio,
+			},
+			expected: []string{
+				"limit_request_ratio_exceeded cpu-gamble",
+				"limit_request_ratio_exceeded memory-gamble",
+				"memory_limit_without_request limit-only",
+			},
+		},
+		{
+			name:     "ratio checks disabled",
+			config:   ResourceLimitsConfig{EnableOvercommitValidation: true},
+			expected: []string{"memory_limit_without_request limit-only"},
+		},
+		{
+			name:   "cpu limits required",
+			config: ResourceLimitsConfig{EnableMissingLimitsValidation: true},
+		},
+		{
+			name:   "cpu limits forbidden",
+			config: ResourceLimitsConfig{EnableMissingLimitsValidation: true, ForbidCPULimits: true},
+			expected: []string{
+				"cpu_limit_forbidden balanced",
+				"cpu_limit_forbidden cpu-gamble",
+				"cpu_limit_forbidden cpu-limit-only",
+				"cpu_limit_forbidden memory-gamble",
+				"missing_resource_limits cpu-limit-only",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+			validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), tt.config)
+			validator.SetLogReceiver(&MockLogReceiver{})
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			var got []string
+			for _, finding := range validator.GetLastValidationErrors() {
+				got = append(got, finding.ValidationType+" "+finding.ResourceName)
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.expected) {
+				t.Fatalf("findings = %v, want %v", got, tt.expected)
+			}
+			for i := range tt.expected {
+				if got[i] != tt.expected[i] {
+					t.Errorf("finding %d = %q, want %q", i, got[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
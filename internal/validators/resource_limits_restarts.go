@@ -0,0 +1,234 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultRestartThreshold is the default number of container restarts across the pods
+	// of a workload at which the container is reported as restarting repeatedly
+	DefaultRestartThreshold = 5
+	// DefaultOOMKillWindow is the default age up to which OOM kills are reported
+	DefaultOOMKillWindow = 24 * time.Hour
+)
+
+// oomKilledReason is the termination reason of containers killed for exceeding their memory limit
+const oomKilledReason = "OOMKilled"
+
+// containerRestarts is the restart history of one container across the pods of a workload
+type containerRestarts struct {
+	controller    workloadRef
+	namespace     string
+	container     corev1.Container
+	pods          int
+	restarts      int32
+	oomKilledPods int
+	lastOOMKill   time.Time
+}
+
+// validateRestarts reports containers recently OOM-killed and containers restarting
+// repeatedly, from the container statuses of the pods of each workload
+func (v *ResourceLimitsValidator) validateRestarts(ctx context.Context) ([]ValidationError, error) {
+	resolver := newPodControllerResolver(v.client)
+
+	window := v.config.OOMKillWindow
+	if window <= 0 {
+		window = DefaultOOMKillWindow
+	}
+	since := v.now().Add(-window)
+
+	restartsByContainer := make(map[string]*containerRestarts)
+	var keys []string
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		if v.sharedConfig.IsSystemNamespace(pod.Namespace) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return nil
+		}
+		controller := resolver.resolve(ctx, *pod)
+		for _, container := range pod.Spec.Containers {
+			status := containerStatus(pod.Status.ContainerStatuses, container.Name)
+			if status == nil {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s/%s", pod.Namespace, controller.kind, controller.name, container.Name)
+			history, ok := restartsByContainer[key]
+			if !ok {
+				history = &containerRestarts{controller: controller, namespace: pod.Namespace, container: container}
+				restartsByContainer[key] = history
+				keys = append(keys, key)
+			}
+			history.pods++
+			history.restarts += status.RestartCount
+			if killedAt, ok := lastOOMKill(*status); ok && killedAt.After(since) {
+				history.oomKilledPods++
+				if killedAt.After(history.lastOOMKill) {
+					history.lastOOMKill = killedAt
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	sort.Strings(keys)
+
+	var errors []ValidationError
+	for _, key := range keys {
+		errors = append(errors, v.validateContainerRestarts(restartsByContainer[key], window)...)
+	}
+	return errors, nil
+}
+
+// validateContainerRestarts reports an OOM-killed container together with its memory
+// limit, or a container whose restarts reach the configured threshold
+func (v *ResourceLimitsValidator) validateContainerRestarts(history *containerRestarts, window time.Duration) []ValidationError {
+	container := history.container
+	resourceType := history.controller.kind
+	resourceName := history.controller.name
+
+	if history.oomKilledPods > 0 {
+		limitDescription := "no memory limit"
+		hint := "Set a memory limit and request above the container's peak usage, or fix the memory leak; without a limit the node evicts the container under memory pressure"
+		memoryLimit := ""
+		if limit, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+			memoryLimit = limit.String()
+			limitDescription = "a " + memoryLimit + " memory limit"
+			hint = fmt.Sprintf("Raise the memory limit above %s to the container's peak usage, or fix the memory leak; the container is killed each time it reaches the limit", memoryLimit)
+		}
+		errorCode := GetResourceLimitsErrorCode("container_oom_killed", resourceType, "", true)
+		return []ValidationError{NewValidationErrorWithCode(resourceType, resourceName, history.namespace, "container_oom_killed", errorCode,
+			fmt.Sprintf("Container '%s' was OOMKilled in %d of %s in the last %s (%d restarts) and has %s", container.Name, history.oomKilledPods, countOf(history.pods, "pod"), formatWindow(window), history.restarts, limitDescription)).
+			WithSeverity(SeverityError).
+			WithRemediationHint(hint).
+			WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+			WithDetail("container_name", container.Name).
+			WithDetail("container_type", "container").
+			WithDetail("oom_killed_pods", fmt.Sprintf("%d", history.oomKilledPods)).
+			WithDetail("restarts", fmt.Sprintf("%d", history.restarts)).
+			WithDetail("memory_limit", memoryLimit).
+			WithDetail("last_oom_kill", history.lastOOMKill.UTC().Format(time.RFC3339))}
+	}
+
+	threshold := v.config.RestartThreshold
+	if threshold <= 0 || history.restarts < threshold {
+		return nil
+	}
+	errorCode := GetResourceLimitsErrorCode("container_restart_loop", resourceType, "", true)
+	return []ValidationError{NewValidationErrorWithCode(resourceType, resourceName, history.namespace, "container_restart_loop", errorCode,
+		fmt.Sprintf("Container '%s' restarted %d times across %s", container.Name, history.restarts, countOf(history.pods, "pod"))).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Check the container's logs and last termination reason; repeated restarts often come from failing probes, missing configuration or limits set below the container's needs").
+		WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+		WithDetail("container_name", container.Name).
+		WithDetail("container_type", "container").
+		WithDetail("restarts", fmt.Sprintf("%d", history.restarts))}
+}
+
+// oomRelatedFindings are the resource findings that can cause or worsen OOM kills
+var oomRelatedFindings = map[string]bool{
+	"missing_resource_requests":    true,
+	"missing_resource_limits":      true,
+	"insufficient_memory_request":  true,
+	"memory_limit_without_request": true,
+	"limit_request_ratio_exceeded": true,
+	"usage_near_limit":             true,
+}
+
+// correlateRestarts links the resource findings of OOM-killed and restarting containers
+// with the runtime evidence, so the configuration problems that cause failures stand out.
+// Memory findings for OOM-killed containers are raised to error severity.
+func correlateRestarts(findings []ValidationError) []ValidationError {
+	runtimeFindings := make(map[string]ValidationError)
+	for _, finding := range findings {
+		if isRestartFinding(finding) {
+			runtimeFindings[containerFindingKey(finding)] = finding
+		}
+	}
+	if len(runtimeFindings) == 0 {
+		return findings
+	}
+
+	correlated := make(map[string][]string)
+	for i, finding := range findings {
+		key := containerFindingKey(finding)
+		runtimeFinding, ok := runtimeFindings[key]
+		if !ok || isRestartFinding(finding) || finding.Details["container_type"] != "container" {
+			continue
+		}
+		finding = finding.WithDetail("runtime_evidence", runtimeFinding.Message)
+		if runtimeFinding.ValidationType == "container_oom_killed" && oomRelatedFindings[finding.ValidationType] && finding.Details["resource"] != string(corev1.ResourceCPU) {
+			finding = finding.WithSeverity(SeverityError)
+		}
+		findings[i] = finding
+		correlated[key] = append(correlated[key], finding.ErrorCode)
+	}
+
+	for i, finding := range findings {
+		if codes := correlated[containerFindingKey(finding)]; isRestartFinding(finding) && len(codes) > 0 {
+			findings[i] = finding.WithDetail("correlated_findings", strings.Join(codes, ","))
+		}
+	}
+	return findings
+}
+
+// isRestartFinding reports whether a finding comes from container restart history
+func isRestartFinding(finding ValidationError) bool {
+	return finding.ValidationType == "container_oom_killed" || finding.ValidationType == "container_restart_loop"
+}
+
+// containerFindingKey identifies the container a finding refers to
+func containerFindingKey(finding ValidationError) string {
+	return fmt.Sprintf("%s/%s/%s/%s", finding.Namespace, finding.ResourceType, finding.ResourceName, finding.Details["container_name"])
+}
+
+// containerStatus returns the status of the named container
+func containerStatus(statuses []corev1.ContainerStatus, name string) *corev1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// lastOOMKill returns when the container was last OOM-killed, from its current or
+// previous termination
+func lastOOMKill(status corev1.ContainerStatus) (time.Time, bool) {
+	for _, state := range []corev1.ContainerState{status.State, status.LastTerminationState} {
+		if state.Terminated != nil && state.Terminated.Reason == oomKilledReason {
+			return state.Terminated.FinishedAt.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// countOf formats a count of nouns, such as "1 pod" or "3 pods"
+func countOf(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// formatWindow formats a window in whole hours or minutes where possible, such as
+// "24h" rather than "24h0m0s"
+func formatWindow(window time.Duration) string {
+	switch {
+	case window >= time.Hour && window%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(window/time.Hour))
+	case window >= time.Minute && window%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(window/time.Minute))
+	}
+	return window.String()
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceLimitsValidator_ValidateRestarts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	controller := true
+	replicas := int32(2)
+	memoryLimit := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+	}
+	pod := func(name, owner string, resources corev1.ResourceRequirements, restarts int32, oomKilledAt time.Time) *corev1.Pod {
+		status := corev1.ContainerStatus{Name: "app", RestartCount: restarts}
+		if !oomKilledAt.IsZero() {
+			status.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: metav1.NewTime(oomKilledAt)}
+		}
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: resources}}},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{status}},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		return pod
+	}
+
+	objects := []client.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Resources: memoryLimit}}}},
+			},
+		},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-7c9d", Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "api", Controller: &controller}},
+		}},
+		// OOM-killed in both pods within the window
+		pod("api-7c9d-a", "api-7c9d", memoryLimit, 4, now.Add(-2*time.Hour)),
+		pod("api-7c9d-b", "api-7c9d", memoryLimit, 3, now.Add(-30*time.Minute)),
+		// Restarting repeatedly for another reason
+		pod("crashy", "", memoryLimit, 9, time.Time{}),
+		// OOM-killed too long ago, and few restarts
+		pod("old-oom", "", memoryLimit, 1, now.Add(-72*time.Hour)),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{
+		EnableMissingLimitsValidation: true,
+		EnableOvercommitValidation:    true,
+		EnableRestartValidation:       true,
+		RestartThreshold:              DefaultRestartThreshold,
+	})
+	validator.now = func() time.Time { return now }
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	findings := make(map[string]ValidationError)
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		if finding.ValidationType != "container_oom_killed" && finding.ValidationType != "container_restart_loop" {
+			continue
+		}
+		key := finding.ValidationType + " " + finding.ResourceType + "/" + finding.ResourceName
+		findings[key] = finding
+		got = append(got, key)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"container_oom_killed Deployment/api",
+		"container_restart_loop Pod/crashy",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	oomKilled := findings["container_oom_killed Deployment/api"]
+	wantMessage := "Container 'app' was OOMKilled in 2 of 2 pods in the last 24h (7 restarts) and has a 64Mi memory limit"
+	if oomKilled.Message != wantMessage {
+		t.Errorf("message = %q, want %q", oomKilled.Message, wantMessage)
+	}
+	if oomKilled.Details["last_oom_kill"] != "2025-06-01T11:30:00Z" {
+		t.Errorf("last_oom_kill = %q", oomKilled.Details["last_oom_kill"])
+	}
+	// The memory limit without a memory request is what gets the container killed
+	if oomKilled.Details["correlated_findings"] != "KOGARO-RES-015" {
+		t.Errorf("correlated_findings = %q, want KOGARO-RES-015", oomKilled.Details["correlated_findings"])
+	}
+}
+
+func TestCorrelateRestarts(t *testing.T) {
+	container := func(finding ValidationError) ValidationError {
+		return finding.WithDetail("container_name", "app").WithDetail("container_type", "container")
+	}
+	findings := []ValidationError{
+		container(NewValidationErrorWithCode("Deployment", "api", "shop", "container_oom_killed", "KOGARO-RES-020", "Container 'app' was OOMKilled").WithSeverity(SeverityError)),
+		container(NewValidationErrorWithCode("Deployment", "api", "shop", "memory_limit_without_request", "KOGARO-RES-015", "no memory request").WithSeverity(SeverityWarning)),
+		container(NewValidationErrorWithCode("Deployment", "api", "shop", "request_far_above_usage", "KOGARO-RES-019", "cpu request unused").WithSeverity(SeverityInfo)),
+		container(NewValidationErrorWithCode("Deployment", "web", "shop", "memory_limit_without_request", "KOGARO-RES-015", "no memory request").WithSeverity(SeverityWarning)),
+	}
+
+	correlated := correlateRestarts(findings)
+
+	if correlated[1].Severity != SeverityError || correlated[1].Details["runtime_evidence"] != "Container 'app' was OOMKilled" {
+		t.Errorf("memory finding of OOM-killed container = %s %v, want error severity with runtime evidence", correlated[1].Severity, correlated[1].Details)
+	}
+	if correlated[2].Severity != SeverityInfo || correlated[2].Details["runtime_evidence"] == "" {
+		t.Errorf("unrelated finding of OOM-killed container = %s %v, want unchanged severity with runtime evidence", correlated[2].Severity, correlated[2].Details)
+	}
+	if correlated[3].Severity != SeverityWarning || correlated[3].Details["runtime_evidence"] != "" {
+		t.Errorf("finding of another workload = %s %v, want unchanged", correlated[3].Severity, correlated[3].Details)
+	}
+	if correlated[0].Details["correlated_findings"] != "KOGARO-RES-015,KOGARO-RES-019" {
+		t.Errorf("correlated_findings = %q", correlated[0].Details["correlated_findings"])
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// DefaultUsageLimitThreshold is the default fraction of a limit at which usage is
+	// reported as too close to the limit
+	DefaultUsageLimitThreshold = 0.9
+	// DefaultUsageWasteRatio is the default request-to-usage ratio above which a request
+	// is reported as wasted
+	DefaultUsageWasteRatio = 10.0
+)
+
+// podMetricsGVR identifies the pod usage served by metrics-server
+var podMetricsGVR = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+// containerUsage is the peak usage of one container across the pods of a workload
+type containerUsage struct {
+	controller workloadRef
+	namespace  string
+	container  corev1.Container
+	peak       corev1.ResourceList
+	pods       int
+}
+
+// validateUsage compares the requests and limits of running containers with their
+// current usage reported by metrics-server. Without a metrics client, or when the
+// metrics API is not served, no findings are reported.
+func (v *ResourceLimitsValidator) validateUsage(ctx context.Context) ([]ValidationError, error) {
+	if v.metricsClient == nil {
+		return nil, nil
+	}
+
+	list, err := v.metricsClient.Resource(podMetricsGVR).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) || apierrors.IsServiceUnavailable(err) {
+			v.log.Info("skipping usage validation, metrics-server is not available", "error", err.Error())
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+	usageByPod := podUsage(list.Items)
+
+	resolver := newPodControllerResolver(v.client)
+
+	usageByContainer := make(map[string]*containerUsage)
+	var keys []string
+	err = forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		if v.sharedConfig.IsSystemNamespace(pod.Namespace) || pod.Status.Phase != corev1.PodRunning {
+			return nil
+		}
+		containerUsages, ok := usageByPod[pod.Namespace+"/"+pod.Name]
+		if !ok {
+			return nil
+		}
+		controller := resolver.resolve(ctx, *pod)
+		for _, container := range pod.Spec.Containers {
+			usage, ok := containerUsages[container.Name]
+			if !ok {
+				continue
+			}
+			key := fmt.Sprintf("%s/%s/%s/%s", pod.Namespace, controller.kind, controller.name, container.Name)
+			aggregate, ok := usageByContainer[key]
+			if !ok {
+				aggregate = &containerUsage{controller: controller, namespace: pod.Namespace, container: container, peak: make(corev1.ResourceList)}
+				usageByContainer[key] = aggregate
+				keys = append(keys, key)
+			}
+			maxResources(aggregate.peak, usage)
+			aggregate.pods++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	sort.Strings(keys)
+
+	var errors []ValidationError
+	for _, key := range keys {
+		errors = append(errors, v.validateContainerUsage(usageByContainer[key])...)
+	}
+	return errors, nil
+}
+
+// validateContainerUsage reports usage close to a container's limit, where memory is
+// OOM-killed and CPU throttled, and requests far above usage, which reserve capacity
+// the container does not use
+func (v *ResourceLimitsValidator) validateContainerUsage(usage *containerUsage) []ValidationError {
+	var errors []ValidationError
+	container := usage.container
+	resourceType := usage.controller.kind
+	resourceName := usage.controller.name
+
+	for _, name := range capacityResources {
+		peak, ok := usage.peak[name]
+		if !ok {
+			continue
+		}
+
+		limit, hasLimit := container.Resources.Limits[name]
+		if v.config.UsageLimitThreshold > 0 && hasLimit && !limit.IsZero() &&
+			float64(peak.MilliValue()) >= float64(limit.MilliValue())*v.config.UsageLimitThreshold {
+			hint := fmt.Sprintf("Raise the memory limit above the container's peak usage of %s; at the limit the container is OOM-killed", peak.String())
+			if name == corev1.ResourceCPU {
+				hint = fmt.Sprintf("Raise the CPU limit above the container's peak usage of %s, or remove it; at the limit the container is throttled", peak.String())
+			}
+			errorCode := GetResourceLimitsErrorCode("usage_near_limit", resourceType, "", true)
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, usage.namespace, "usage_near_limit", errorCode,
+				fmt.Sprintf("Container '%s' %s usage of %s across %s is %s%% of its %s limit", container.Name, name, peak.String(), countOf(usage.pods, "pod"), usagePercent(peak, limit), limit.String())).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(hint).
+				WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+				WithDetail("container_name", container.Name).
+				WithDetail("container_type", "container").
+				WithDetail("resource", string(name)).
+				WithDetail("peak_usage", peak.String()).
+				WithDetail("limit", limit.String()))
+		}
+
+		request, hasRequest := container.Resources.Requests[name]
+		if v.config.UsageWasteRatio > 0 && hasRequest && !peak.IsZero() &&
+			float64(request.MilliValue()) > float64(peak.MilliValue())*v.config.UsageWasteRatio {
+			errorCode := GetResourceLimitsErrorCode("request_far_above_usage", resourceType, "", true)
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, usage.namespace, "request_far_above_usage", errorCode,
+				fmt.Sprintf("Container '%s' requests %s %s but uses at most %s across %s", container.Name, request.String(), name, peak.String(), countOf(usage.pods, "pod"))).
+				WithSeverity(SeverityInfo).
+				WithRemediationHint(fmt.Sprintf("Lower the %s request towards the container's usage; the request is reserved on the node whether or not it is used", name)).
+				WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
+				WithDetail("container_name", container.Name).
+				WithDetail("container_type", "container").
+				WithDetail("resource", string(name)).
+				WithDetail("peak_usage", peak.String()).
+				WithDetail("request", request.String()))
+		}
+	}
+
+	return errors
+}
+
+// podUsage indexes PodMetrics by namespace/name and container name
+func podUsage(items []unstructured.Unstructured) map[string]map[string]corev1.ResourceList {
+	usage := make(map[string]map[string]corev1.ResourceList)
+	for _, item := range items {
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		byContainer := make(map[string]corev1.ResourceList)
+		for _, entry := range containers {
+			container, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(container, "name")
+			values, _, _ := unstructured.NestedStringMap(container, "usage")
+			resources := make(corev1.ResourceList)
+			for _, resourceName := range capacityResources {
+				if quantity, err := resource.ParseQuantity(values[string(resourceName)]); err == nil {
+					resources[resourceName] = quantity
+				}
+			}
+			byContainer[name] = resources
+		}
+		usage[item.GetNamespace()+"/"+item.GetName()] = byContainer
+	}
+	return usage
+}
+
+// usagePercent formats usage as a whole percentage of limit
+func usagePercent(usage, limit resource.Quantity) string {
+	return strconv.FormatFloat(float64(usage.MilliValue())/float64(limit.MilliValue())*100, 'f', 0, 64)
+}
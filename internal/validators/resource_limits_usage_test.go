@@ -0,0 +1,146 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceLimitsValidator_ValidateUsage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	controller := true
+	pod := func(name, owner, cpuRequest, memoryLimit string) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuRequest)},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(memoryLimit)},
+				},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		return pod
+	}
+	podMetrics := func(name, cpu, memory string) runtime.Object {
+		metrics := &unstructured.Unstructured{}
+		metrics.SetAPIVersion("metrics.k8s.io/v1beta1")
+		metrics.SetKind("PodMetrics")
+		metrics.SetName(name)
+		metrics.SetNamespace("shop")
+		_ = unstructured.SetNestedSlice(metrics.Object, []interface{}{
+			map[string]interface{}{"name": "app", "usage": map[string]interface{}{"cpu": cpu, "memory": memory}},
+		}, "containers")
+		return metrics
+	}
+
+	objects := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-5d4f", Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: "api", Controller: &controller}},
+		}},
+		// Two replicas: the peak of one is close to the memory limit
+		pod("api-5d4f-a", "api-5d4f", "200m", "1Gi"),
+		pod("api-5d4f-b", "api-5d4f", "200m", "1Gi"),
+		// Requests twenty times its CPU usage
+		pod("reporting", "", "2", "1Gi"),
+		// Sized to its usage
+		pod("worker", "", "100m", "1Gi"),
+		// Not yet reported by metrics-server
+		pod("fresh", "", "4", "64Mi"),
+	}
+	metrics := []runtime.Object{
+		podMetrics("api-5d4f-a", "150m", "512Mi"),
+		podMetrics("api-5d4f-b", "180m", "980Mi"),
+		podMetrics("reporting", "100m", "256Mi"),
+		podMetrics("worker", "80m", "300Mi"),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{
+		EnableUsageValidation: true,
+		UsageLimitThreshold:   DefaultUsageLimitThreshold,
+		UsageWasteRatio:       DefaultUsageWasteRatio,
+	})
+	metricsClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{podMetricsGVR: "PodMetricsList"})
+	for _, object := range metrics {
+		// PodMetrics are served as the pods resource, which the fake cannot guess from the kind
+		if err := metricsClient.Tracker().Create(podMetricsGVR, object, "shop"); err != nil {
+			t.Fatalf("failed to add pod metrics: %v", err)
+		}
+	}
+	validator.SetMetricsClient(metricsClient)
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		got = append(got, finding.ValidationType+" "+finding.ResourceType+"/"+finding.ResourceName+" "+finding.Details["peak_usage"])
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"request_far_above_usage Pod/reporting 100m",
+		"usage_near_limit Deployment/api 980Mi",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestResourceLimitsValidator_ValidateUsageWithoutMetricsServer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	metricsClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{podMetricsGVR: "PodMetricsList"})
+	metricsClient.PrependReactor("list", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(podMetricsGVR.GroupResource(), "")
+	})
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{EnableUsageValidation: true, UsageWasteRatio: DefaultUsageWasteRatio})
+	validator.SetMetricsClient(metricsClient)
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if errs := validator.GetLastValidationErrors(); len(errs) != 0 {
+		t.Errorf("expected no findings without metrics-server, got %v", errs)
+	}
+}
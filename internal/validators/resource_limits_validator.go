@@ -14,11 +14,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/topiaruss/kogaro/internal/metrics"
@@ -36,6 +38,33 @@ type ResourceLimitsConfig struct {
 	EnableMissingRequestsValidation bool
 	EnableMissingLimitsValidation   bool
 	EnableQoSValidation             bool
+	// Enable comparison of requests and limits with the allocatable capacity of nodes
+	EnableNodeCapacityValidation bool
+	// Enable limit-to-request ratio and memory-limit-without-request checks
+	EnableOvercommitValidation bool
+	// Largest allowed limit-to-request ratios; 0 disables the ratio check for the resource
+	MaxCPULimitRequestRatio    float64
+	MaxMemoryLimitRequestRatio float64
+	// ForbidCPULimits inverts the CPU limit check for clusters whose policy is not to
+	// set CPU limits: CPU limits are reported, and only memory limits are required
+	ForbidCPULimits bool
+	// Enable comparison of requests with VerticalPodAutoscaler recommendations
+	EnableVPARecommendationValidation bool
+	// Largest allowed deviation of a request from the VPA recommendation, in percent
+	VPADeviationPercent float64
+	// Enable comparison of requests and limits with usage reported by metrics-server
+	EnableUsageValidation bool
+	// Fraction of a limit at which usage is reported; 0 disables the check
+	UsageLimitThreshold float64
+	// Request-to-usage ratio above which a request is reported; 0 disables the check
+	UsageWasteRatio float64
+	// Enable OOM kill and restart checks on pod container statuses, correlated with
+	// the resource findings of the same containers
+	EnableRestartValidation bool
+	// Restarts across a workload's pods at which a container is reported; 0 disables the check
+	RestartThreshold int32
+	// Age up to which OOM kills are reported
+	OOMKillWindow time.Duration
 	// Minimum resource thresholds for validation
 	MinCPURequest    *resource.Quantity
 	MinMemoryRequest *resource.Quantity
@@ -49,6 +78,15 @@ type ResourceLimitsValidator struct {
 	sharedConfig         SharedConfig
 	lastValidationErrors []ValidationError
 	logReceiver          LogReceiver
+	// Findings of workloads' containers by resourceVersion, reused while the
+	// workloads are unchanged
+	cache *resultCache
+	// metricsClient reads pod usage from the metrics API, which the cached client
+	// cannot serve since the API does not support watches
+	metricsClient dynamic.Interface
+
+	// For testing
+	now func() time.Time
 }
 
 // NewResourceLimitsValidator creates a new ResourceLimitsValidator with the given client, logger and config
@@ -57,7 +95,9 @@ func NewResourceLimitsValidator(client client.Client, log logr.Logger, config Re
 		client:       client,
 		log:          log.WithName("resource-limits-validator"),
 		config:       config,
-		sharedConfig: DefaultSharedConfig(),
+		sharedConfig: ActiveSharedConfig(),
+		cache:        newResultCache("resource_limits"),
+		now:          time.Now,
 	}
 }
 
@@ -66,6 +106,11 @@ func (v *ResourceLimitsValidator) SetClient(c client.Client) {
 	v.client = c
 }
 
+// SetMetricsClient sets the client used to read pod usage from metrics-server
+func (v *ResourceLimitsValidator) SetMetricsClient(c dynamic.Interface) {
+	v.metricsClient = c
+}
+
 // SetLogReceiver updates the log receiver used by the validator
 func (v *ResourceLimitsValidator) SetLogReceiver(lr LogReceiver) {
 	v.logReceiver = lr
@@ -84,11 +129,13 @@ func (v *ResourceLimitsValidator) GetValidationType() string {
 // ValidateCluster performs comprehensive validation of resource limits across the entire cluster
 func (v *ResourceLimitsValidator) ValidateCluster(ctx context.Context) error {
 	metrics.ValidationRuns.Inc()
+	v.cache.beginScan()
 
 	var allErrors []ValidationError
 
 	// Validate Deployments
-	if v.config.EnableMissingRequestsValidation || v.config.EnableMissingLimitsValidation || v.config.EnableQoSValidation {
+	if v.config.EnableMissingRequestsValidation || v.config.EnableMissingLimitsValidation || v.config.EnableQoSValidation ||
+		v.config.EnableOvercommitValidation || v.config.ForbidCPULimits {
 		deploymentErrors, err := v.validateDeploymentResources(ctx)
 		if err != nil {
 			return fmt.Errorf("failed to validate deployment resources: %w", err)
@@ -117,6 +164,45 @@ func (v *ResourceLimitsValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, podErrors...)
 	}
 
+	// Compare requests and limits with node capacity
+	if v.config.EnableNodeCapacityValidation {
+		capacityErrors, err := v.validateNodeCapacity(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate node capacity: %w", err)
+		}
+		allErrors = append(allErrors, capacityErrors...)
+	}
+
+	// Compare requests with VerticalPodAutoscaler recommendations
+	if v.config.EnableVPARecommendationValidation {
+		vpaErrors, err := v.validateVPARecommendations(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate vpa recommendations: %w", err)
+		}
+		allErrors = append(allErrors, vpaErrors...)
+	}
+
+	// Compare requests and limits with usage reported by metrics-server
+	if v.config.EnableUsageValidation {
+		usageErrors, err := v.validateUsage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate resource usage: %w", err)
+		}
+		allErrors = append(allErrors, usageErrors...)
+	}
+
+	// Report OOM-killed and restarting containers, and mark the resource findings of
+	// the same containers with the runtime evidence
+	if v.config.EnableRestartValidation {
+		restartErrors, err := v.validateRestarts(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate container restarts: %w", err)
+		}
+		allErrors = correlateRestarts(append(allErrors, restartErrors...))
+	}
+
+	v.cache.endScan()
+
 	// Log all validation errors and update metrics
 	LogAndRecordErrors(v.logReceiver, "resource_limits", allErrors)
 
@@ -141,11 +227,11 @@ func (v *ResourceLimitsValidator) validateDeploymentResources(ctx context.Contex
 			continue
 		}
 
-		containerErrors := v.validateContainerResources(deployment.Spec.Template.Spec.Containers, "Deployment", deployment.Name, deployment.Namespace)
-		errors = append(errors, containerErrors...)
-
-		initContainerErrors := v.validateContainerResources(deployment.Spec.Template.Spec.InitContainers, "Deployment", deployment.Name, deployment.Namespace)
-		errors = append(errors, initContainerErrors...)
+		errors = append(errors, v.cache.findings(ctx, "Deployment", &deployment, func() []ValidationError {
+			containerErrors := v.validateContainerResources(deployment.Spec.Template.Spec.Containers, "Deployment", deployment.Name, deployment.Namespace, false)
+			initContainerErrors := v.validateContainerResources(deployment.Spec.Template.Spec.InitContainers, "Deployment", deployment.Name, deployment.Namespace, true)
+			return append(containerErrors, initContainerErrors...)
+		})...)
 	}
 
 	return errors, nil
@@ -165,11 +251,11 @@ func (v *ResourceLimitsValidator) validateStatefulSetResources(ctx context.Conte
 			continue
 		}
 
-		containerErrors := v.validateContainerResources(statefulSet.Spec.Template.Spec.Containers, "StatefulSet", statefulSet.Name, statefulSet.Namespace)
-		errors = append(errors, containerErrors...)
-
-		initContainerErrors := v.validateContainerResources(statefulSet.Spec.Template.Spec.InitContainers, "StatefulSet", statefulSet.Name, statefulSet.Namespace)
-		errors = append(errors, initContainerErrors...)
+		errors = append(errors, v.cache.findings(ctx, "StatefulSet", &statefulSet, func() []ValidationError {
+			containerErrors := v.validateContainerResources(statefulSet.Spec.Template.Spec.Containers, "StatefulSet", statefulSet.Name, statefulSet.Namespace, false)
+			initContainerErrors := v.validateContainerResources(statefulSet.Spec.Template.Spec.InitContainers, "StatefulSet", statefulSet.Name, statefulSet.Namespace, true)
+			return append(containerErrors, initContainerErrors...)
+		})...)
 	}
 
 	return errors, nil
@@ -189,11 +275,11 @@ func (v *ResourceLimitsValidator) validateDaemonSetResources(ctx context.Context
 			continue
 		}
 
-		containerErrors := v.validateContainerResources(daemonSet.Spec.Template.Spec.Containers, "DaemonSet", daemonSet.Name, daemonSet.Namespace)
-		errors = append(errors, containerErrors...)
-
-		initContainerErrors := v.validateContainerResources(daemonSet.Spec.Template.Spec.InitContainers, "DaemonSet", daemonSet.Name, daemonSet.Namespace)
-		errors = append(errors, initContainerErrors...)
+		errors = append(errors, v.cache.findings(ctx, "DaemonSet", &daemonSet, func() []ValidationError {
+			containerErrors := v.validateContainerResources(daemonSet.Spec.Template.Spec.Containers, "DaemonSet", daemonSet.Name, daemonSet.Namespace, false)
+			initContainerErrors := v.validateContainerResources(daemonSet.Spec.Template.Spec.InitContainers, "DaemonSet", daemonSet.Name, daemonSet.Namespace, true)
+			return append(containerErrors, initContainerErrors...)
+		})...)
 	}
 
 	return errors, nil
@@ -201,36 +287,39 @@ func (v *ResourceLimitsValidator) validateDaemonSetResources(ctx context.Context
 
 func (v *ResourceLimitsValidator) validatePodResources(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
-	var pods corev1.PodList
-
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
-	}
-
-	for _, pod := range pods.Items {
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
 		// Skip system namespaces
 		if v.sharedConfig.IsSystemNamespace(pod.Namespace) {
-			continue
+			return nil
 		}
 
 		// Skip pods managed by controllers (they're validated via their controllers)
 		if len(pod.OwnerReferences) > 0 {
-			continue
+			return nil
 		}
 
-		containerErrors := v.validateContainerResources(pod.Spec.Containers, "Pod", pod.Name, pod.Namespace)
-		errors = append(errors, containerErrors...)
-
-		initContainerErrors := v.validateContainerResources(pod.Spec.InitContainers, "Pod", pod.Name, pod.Namespace)
-		errors = append(errors, initContainerErrors...)
+		errors = append(errors, v.cache.findings(ctx, "Pod", pod, func() []ValidationError {
+			containerErrors := v.validateContainerResources(pod.Spec.Containers, "Pod", pod.Name, pod.Namespace, false)
+			initContainerErrors := v.validateContainerResources(pod.Spec.InitContainers, "Pod", pod.Name, pod.Namespace, true)
+			return append(containerErrors, initContainerErrors...)
+		})...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	return errors, nil
 }
 
-func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1.Container, resourceType, resourceName, namespace string) []ValidationError {
+func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1.Container, resourceType, resourceName, namespace string, isInitContainer bool) []ValidationError {
 	var errors []ValidationError
 
+	containerType := "container"
+	if isInitContainer {
+		containerType = "init container"
+	}
+
 	for _, container := range containers {
 		// Check for missing resource requests
 		if v.config.EnableMissingRequestsValidation {
@@ -242,6 +331,7 @@ func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1
 					WithRemediationHint(fmt.Sprintf("Add resource requests to prevent resource contention (e.g., cpu: %s, memory: %s)", v.sharedConfig.DefaultResourceRecommendations.DefaultCPURequest, v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryRequest)).
 					WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 					WithDetail("container_name", container.Name).
+					WithDetail("container_type", containerType).
 					WithDetail("recommended_cpu", v.sharedConfig.DefaultResourceRecommendations.DefaultCPURequest).
 					WithDetail("recommended_memory", v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryRequest))
 			} else {
@@ -253,6 +343,7 @@ func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1
 						WithRemediationHint(fmt.Sprintf("Increase CPU request to at least %s to meet minimum requirements", v.config.MinCPURequest.String())).
 						WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 						WithDetail("container_name", container.Name).
+						WithDetail("container_type", containerType).
 						WithDetail("current_cpu_request", container.Resources.Requests.Cpu().String()).
 						WithDetail("minimum_cpu_request", v.config.MinCPURequest.String()))
 				}
@@ -265,30 +356,46 @@ func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1
 						WithRemediationHint(fmt.Sprintf("Increase memory request to at least %s to meet minimum requirements", v.config.MinMemoryRequest.String())).
 						WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 						WithDetail("container_name", container.Name).
+						WithDetail("container_type", containerType).
 						WithDetail("current_memory_request", container.Resources.Requests.Memory().String()).
 						WithDetail("minimum_memory_request", v.config.MinMemoryRequest.String()))
 				}
 			}
 		}
 
-		// Check for missing resource limits
+		// Check for missing resource limits; without CPU limits only a memory limit is expected
 		if v.config.EnableMissingLimitsValidation {
 			if container.Resources.Limits == nil ||
-				(container.Resources.Limits.Cpu().IsZero() && container.Resources.Limits.Memory().IsZero()) {
+				((v.config.ForbidCPULimits || container.Resources.Limits.Cpu().IsZero()) && container.Resources.Limits.Memory().IsZero()) {
 				// Check if container has resource requests to determine the error code context
 				hasRequests := container.Resources.Requests != nil &&
 					(!container.Resources.Requests.Cpu().IsZero() || !container.Resources.Requests.Memory().IsZero())
 				errorCode := GetResourceLimitsErrorCode("missing_resource_limits", resourceType, "", hasRequests)
+				remediationHint := fmt.Sprintf("Add resource limits to prevent resource overconsumption (e.g., cpu: %s, memory: %s)", v.sharedConfig.DefaultResourceRecommendations.DefaultCPULimit, v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryLimit)
+				if v.config.ForbidCPULimits {
+					remediationHint = fmt.Sprintf("Add a memory limit to prevent memory overconsumption (e.g., memory: %s); CPU limits are not used in this cluster", v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryLimit)
+				}
 				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "missing_resource_limits", errorCode, fmt.Sprintf("Container '%s' has no resource limits defined", container.Name)).
 					WithSeverity(SeverityError).
-					WithRemediationHint(fmt.Sprintf("Add resource limits to prevent resource overconsumption (e.g., cpu: %s, memory: %s)", v.sharedConfig.DefaultResourceRecommendations.DefaultCPULimit, v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryLimit)).
+					WithRemediationHint(remediationHint).
 					WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 					WithDetail("container_name", container.Name).
+					WithDetail("container_type", containerType).
 					WithDetail("recommended_cpu_limit", v.sharedConfig.DefaultResourceRecommendations.DefaultCPULimit).
 					WithDetail("recommended_memory_limit", v.sharedConfig.DefaultResourceRecommendations.DefaultMemoryLimit))
 			}
 		}
 
+		// Check limit-to-request ratios and memory limits without requests
+		if v.config.EnableOvercommitValidation {
+			errors = append(errors, v.validateOvercommit(container, resourceType, resourceName, namespace, containerType)...)
+		}
+
+		// Check CPU limits against a no-CPU-limits policy
+		if v.config.ForbidCPULimits {
+			errors = append(errors, v.validateCPULimitPolicy(container, resourceType, resourceName, namespace, containerType)...)
+		}
+
 		// Check QoS class implications
 		if v.config.EnableQoSValidation {
 			qosIssues := v.analyzeQoSClass(container)
@@ -315,6 +422,7 @@ func (v *ResourceLimitsValidator) validateContainerResources(containers []corev1
 					WithRemediationHint(remediationHint).
 					WithRelatedResources(fmt.Sprintf("Container/%s", container.Name)).
 					WithDetail("container_name", container.Name).
+					WithDetail("container_type", containerType).
 					WithDetail("qos_issue_type", issue))
 			}
 		}
@@ -0,0 +1,155 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultVPADeviationPercent is the default largest deviation of a request from the
+// VerticalPodAutoscaler recommendation, as a percentage of the recommendation
+const DefaultVPADeviationPercent = 50.0
+
+// verticalPodAutoscalerGVK identifies the VerticalPodAutoscaler CRD of the Kubernetes autoscaler
+var verticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+// vpaApplyingModes are the update modes in which the VPA sets the requests of running
+// pods itself, so the requests in the pod template are expected to differ
+var vpaApplyingModes = map[string]bool{"": true, "Auto": true, "Recreate": true, "InPlaceOrRecreate": true}
+
+// validateVPARecommendations compares container requests with the recommendations of
+// VerticalPodAutoscalers in recommendation-only modes. Clusters without the VPA CRD
+// yield no findings.
+func (v *ResourceLimitsValidator) validateVPARecommendations(ctx context.Context) ([]ValidationError, error) {
+	objects, err := listUnstructuredByKind(ctx, v.client, v.log, v.sharedConfig, []schema.GroupVersionKind{verticalPodAutoscalerGVK})
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []ValidationError
+	for _, vpa := range objects[verticalPodAutoscalerGVK] {
+		updateMode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+		if vpaApplyingModes[updateMode] {
+			continue
+		}
+		kind, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "kind")
+		name, _, _ := unstructured.NestedString(vpa.Object, "spec", "targetRef", "name")
+		spec, found, err := v.vpaTargetPodSpec(ctx, kind, name, vpa.GetNamespace())
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		recommendations, _, _ := unstructured.NestedSlice(vpa.Object, "status", "recommendation", "containerRecommendations")
+		for _, item := range recommendations {
+			recommendation, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerName, _, _ := unstructured.NestedString(recommendation, "containerName")
+			target, _, _ := unstructured.NestedStringMap(recommendation, "target")
+			for _, container := range spec.Containers {
+				if container.Name == containerName {
+					errors = append(errors, v.validateVPADeviation(container, target, kind, name, vpa.GetNamespace(), vpa.GetName(), updateMode)...)
+				}
+			}
+		}
+	}
+
+	return errors, nil
+}
+
+// vpaTargetPodSpec returns the pod template of the workload a VPA targets, reporting
+// false for missing targets and kinds without a known pod template
+func (v *ResourceLimitsValidator) vpaTargetPodSpec(ctx context.Context, kind, name, namespace string) (corev1.PodSpec, bool, error) {
+	var object client.Object
+	switch kind {
+	case DeploymentType:
+		object = &appsv1.Deployment{}
+	case StatefulSetType:
+		object = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		object = &appsv1.DaemonSet{}
+	default:
+		return corev1.PodSpec{}, false, nil
+	}
+
+	if err := v.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, object); err != nil {
+		if apierrors.IsNotFound(err) {
+			return corev1.PodSpec{}, false, nil
+		}
+		return corev1.PodSpec{}, false, fmt.Errorf("failed to get %s %s/%s: %w", kind, namespace, name, err)
+	}
+
+	switch workload := object.(type) {
+	case *appsv1.Deployment:
+		return workload.Spec.Template.Spec, true, nil
+	case *appsv1.StatefulSet:
+		return workload.Spec.Template.Spec, true, nil
+	case *appsv1.DaemonSet:
+		return workload.Spec.Template.Spec, true, nil
+	}
+	return corev1.PodSpec{}, false, nil
+}
+
+// validateVPADeviation reports a container whose CPU or memory request deviates from the
+// VPA target by more than the configured percentage. Missing requests are left to the
+// missing_resource_requests check.
+func (v *ResourceLimitsValidator) validateVPADeviation(container corev1.Container, target map[string]string, resourceType, resourceName, namespace, vpaName, updateMode string) []ValidationError {
+	var deviations []string
+	recommended := make(map[corev1.ResourceName]string)
+	largestDeviation := 0.0
+	for _, name := range capacityResources {
+		recommendation, err := resource.ParseQuantity(target[string(name)])
+		if err != nil || recommendation.IsZero() {
+			continue
+		}
+		recommended[name] = recommendation.String()
+
+		request, ok := container.Resources.Requests[name]
+		if !ok {
+			continue
+		}
+		deviation := math.Abs(float64(request.MilliValue()-recommendation.MilliValue())) / float64(recommendation.MilliValue()) * 100
+		if deviation <= v.config.VPADeviationPercent {
+			continue
+		}
+		deviations = append(deviations, fmt.Sprintf("%s request %s vs recommended %s", name, request.String(), recommendation.String()))
+		largestDeviation = math.Max(largestDeviation, deviation)
+	}
+	if len(deviations) == 0 {
+		return nil
+	}
+
+	errorCode := GetResourceLimitsErrorCode("request_deviates_from_vpa_recommendation", resourceType, "", true)
+	return []ValidationError{NewValidationErrorWithCode(resourceType, resourceName, namespace, "request_deviates_from_vpa_recommendation", errorCode,
+		fmt.Sprintf("Container '%s' requests deviate from VerticalPodAutoscaler '%s' by up to %s%%: %s", container.Name, vpaName, strconv.FormatFloat(largestDeviation, 'f', 0, 64), strings.Join(deviations, ", "))).
+		WithSeverity(SeverityWarning).
+		WithRemediationHint("Set the container's requests to the VPA recommendation; requests far above it waste reserved capacity, and requests far below it risk throttling, eviction and OOM kills").
+		WithRelatedResources(fmt.Sprintf("Container/%s", container.Name), fmt.Sprintf("VerticalPodAutoscaler/%s", vpaName)).
+		WithDetail("container_name", container.Name).
+		WithDetail("container_type", "container").
+		WithDetail("vpa_name", vpaName).
+		WithDetail("update_mode", updateMode).
+		WithDetail("deviation_percent", strconv.FormatFloat(largestDeviation, 'f', 0, 64)).
+		WithDetail("recommended_cpu", recommended[corev1.ResourceCPU]).
+		WithDetail("recommended_memory", recommended[corev1.ResourceMemory])}
+}
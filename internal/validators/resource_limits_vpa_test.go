@@ -0,0 +1,132 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestResourceLimitsValidator_ValidateVPARecommendations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(verticalPodAutoscalerGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(verticalPodAutoscalerGVK.GroupVersion().WithKind("VerticalPodAutoscalerList"), &unstructured.UnstructuredList{})
+
+	deployment := func(name, cpu, memory string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "app",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}}}}},
+		}
+	}
+	vpa := func(name, target, updateMode, cpu, memory string) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{}
+		object.SetGroupVersionKind(verticalPodAutoscalerGVK)
+		object.SetName(name)
+		object.SetNamespace("shop")
+		_ = unstructured.SetNestedStringMap(object.Object, map[string]string{"apiVersion": "apps/v1", "kind": "Deployment", "name": target}, "spec", "targetRef")
+		if updateMode != "" {
+			_ = unstructured.SetNestedField(object.Object, updateMode, "spec", "updatePolicy", "updateMode")
+		}
+		_ = unstructured.SetNestedSlice(object.Object, []interface{}{
+			map[string]interface{}{"containerName": "app", "target": map[string]interface{}{"cpu": cpu, "memory": memory}},
+		}, "status", "recommendation", "containerRecommendations")
+		return object
+	}
+
+	objects := []client.Object{
+		deployment("api", "2", "1Gi"),
+		deployment("worker", "500m", "1Gi"),
+		deployment("auto", "2", "1Gi"),
+		deployment("batch", "500m", "256Mi"),
+		// Overprovisioned CPU
+		vpa("api", "api", "Off", "500m", "1Gi"),
+		// Within the allowed deviation
+		vpa("worker", "worker", "Off", "600m", "1200Mi"),
+		// The VPA sets the requests of running pods itself
+		vpa("auto", "auto", "", "100m", "64Mi"),
+		// Underprovisioned memory
+		vpa("batch", "batch", "Initial", "500m", "1Gi"),
+		// Targets a missing workload
+		vpa("gone", "gone", "Off", "1", "1Gi"),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{
+		EnableVPARecommendationValidation: true,
+		VPADeviationPercent:               DefaultVPADeviationPercent,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	findings := validator.GetLastValidationErrors()
+	var got []string
+	for _, finding := range findings {
+		got = append(got, finding.ValidationType+" "+finding.ResourceType+"/"+finding.ResourceName+" "+finding.ErrorCode)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"request_deviates_from_vpa_recommendation Deployment/api KOGARO-RES-017",
+		"request_deviates_from_vpa_recommendation Deployment/batch KOGARO-RES-017",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	for _, finding := range findings {
+		if finding.ResourceName != "api" {
+			continue
+		}
+		if finding.Details["recommended_cpu"] != "500m" || finding.Details["recommended_memory"] != "1Gi" {
+			t.Errorf("recommendation details = %v, want cpu 500m and memory 1Gi", finding.Details)
+		}
+		if finding.Details["deviation_percent"] != "300" {
+			t.Errorf("deviation_percent = %q, want %q", finding.Details["deviation_percent"], "300")
+		}
+	}
+}
+
+func TestResourceLimitsValidator_ValidateVPARecommendationsWithoutCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	validator := NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{EnableVPARecommendationValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if errs := validator.GetLastValidationErrors(); len(errs) != 0 {
+		t.Errorf("expected no findings without the VPA CRD, got %v", errs)
+	}
+}
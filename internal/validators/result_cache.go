@@ -0,0 +1,128 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// resultCache keeps the findings of objects by resourceVersion, so that objects a
+// scan finds unchanged are not validated again. It only suits checks that read
+// nothing but the object and the validator's configuration; checks of references
+// between objects must run on every scan.
+type resultCache struct {
+	validatorType string
+
+	mu      sync.Mutex
+	entries map[resultCacheKey]*resultCacheEntry
+	scan    uint64
+}
+
+// resultCacheKey identifies a cached object
+type resultCacheKey struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// resultCacheEntry holds the findings of one version of an object
+type resultCacheEntry struct {
+	resourceVersion string
+	findings        []ValidationError
+	// Last scan that found the object
+	scan uint64
+}
+
+// newResultCache creates a cache for the findings of a validator
+func newResultCache(validatorType string) *resultCache {
+	return &resultCache{validatorType: validatorType, entries: make(map[resultCacheKey]*resultCacheEntry)}
+}
+
+// beginScan starts a scan
+func (c *resultCache) beginScan() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.scan++
+}
+
+// resultCacheBypassKey marks contexts of validations that must not use the cache
+type resultCacheBypassKey struct{}
+
+// withoutResultCache returns a context whose validations bypass the result cache.
+// Manifest validation needs it: the fake client serving manifest objects gives them
+// all the same resourceVersion, so different manifests would share findings.
+func withoutResultCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, resultCacheBypassKey{}, true)
+}
+
+// findings returns the cached findings of an object if its resourceVersion is
+// unchanged, else the findings of validate, which are cached. Objects without a
+// resourceVersion, and those of contexts from withoutResultCache, are always validated.
+func (c *resultCache) findings(ctx context.Context, kind string, object metav1.Object, validate func() []ValidationError) []ValidationError {
+	if c == nil || object.GetResourceVersion() == "" || ctx.Value(resultCacheBypassKey{}) != nil {
+		return validate()
+	}
+	key := resultCacheKey{kind: kind, namespace: object.GetNamespace(), name: object.GetName()}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && entry.resourceVersion == object.GetResourceVersion() {
+		entry.scan = c.scan
+		findings := cloneFindings(entry.findings)
+		c.mu.Unlock()
+		metrics.ValidationCacheLookups.WithLabelValues(c.validatorType, "hit").Inc()
+		return findings
+	}
+	c.mu.Unlock()
+
+	metrics.ValidationCacheLookups.WithLabelValues(c.validatorType, "miss").Inc()
+	findings := validate()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = &resultCacheEntry{resourceVersion: object.GetResourceVersion(), findings: cloneFindings(findings), scan: c.scan}
+	return findings
+}
+
+// endScan forgets the objects the scan did not find, such as deleted objects
+func (c *resultCache) endScan() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.scan != c.scan {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cloneFindings copies findings deeply enough that the copies can be annotated, for
+// example with runtime evidence, without changing the originals
+func cloneFindings(findings []ValidationError) []ValidationError {
+	if findings == nil {
+		return nil
+	}
+	clones := make([]ValidationError, len(findings))
+	for i, finding := range findings {
+		finding.Details = maps.Clone(finding.Details)
+		finding.RelatedResources = slices.Clone(finding.RelatedResources)
+		finding.SuggestedRefs = slices.Clone(finding.SuggestedRefs)
+		clones[i] = finding
+	}
+	return clones
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+func TestResultCache_Findings(t *testing.T) {
+	ctx := context.Background()
+	cache := newResultCache("cache_test")
+	validations := 0
+	validate := func() []ValidationError {
+		validations++
+		return []ValidationError{NewValidationErrorWithCode("Deployment", "web", "shop", "missing_resource_requests", "KOGARO-RES-001", "no requests")}
+	}
+	web := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", ResourceVersion: "10"}}
+
+	cache.beginScan()
+	findings := cache.findings(ctx, "Deployment", web, validate)
+	cache.endScan()
+	// Annotating the findings of a scan leaves the cached findings unchanged
+	findings[0] = findings[0].WithDetail("runtime_evidence", "OOMKilled")
+
+	cache.beginScan()
+	findings = cache.findings(ctx, "Deployment", web, validate)
+	cache.endScan()
+	if validations != 1 {
+		t.Errorf("validated an unchanged object %d times, want once", validations)
+	}
+	if _, ok := findings[0].Details["runtime_evidence"]; ok {
+		t.Error("cached findings kept the annotation of an earlier scan")
+	}
+
+	// A changed object is validated again
+	web.ResourceVersion = "11"
+	cache.beginScan()
+	cache.findings(ctx, "Deployment", web, validate)
+	cache.endScan()
+	if validations != 2 {
+		t.Errorf("validations = %d after the object changed, want 2", validations)
+	}
+
+	// An object a scan misses, such as a deleted one, is forgotten
+	cache.beginScan()
+	cache.endScan()
+	cache.beginScan()
+	cache.findings(ctx, "Deployment", web, validate)
+	cache.endScan()
+	if validations != 3 {
+		t.Errorf("validations = %d after the object was missed, want 3", validations)
+	}
+
+	// Objects without a resourceVersion, such as those of manifest files, are not cached
+	manifest := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}}
+	for range 2 {
+		cache.findings(ctx, "Deployment", manifest, validate)
+	}
+	if validations != 5 {
+		t.Errorf("validations = %d, want manifest objects validated every time", validations)
+	}
+
+	// Manifest validation bypasses the cache, since the fake client serving the
+	// objects gives every manifest the same resourceVersion
+	for range 2 {
+		cache.findings(withoutResultCache(ctx), "Deployment", web, validate)
+	}
+	if validations != 7 {
+		t.Errorf("validations = %d, want objects of manifest validation validated every time", validations)
+	}
+}
+
+func TestSecurityValidator_ReusesUnchangedFindings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "nginx:1.27"}},
+		}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{EnableSecurityContextValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	ctx := context.Background()
+
+	hits := func() float64 {
+		return testutil.ToFloat64(metrics.ValidationCacheLookups.WithLabelValues("security", "hit"))
+	}
+	before := hits()
+	for range 2 {
+		if err := validator.ValidateCluster(ctx); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+	}
+	if got := hits() - before; got != 1 {
+		t.Errorf("cache hits = %v, want 1", got)
+	}
+	if got := len(validator.GetLastValidationErrors()); got != 2 {
+		t.Fatalf("findings = %d, want the missing pod and container security contexts", got)
+	}
+
+	// Adding a SecurityContext changes the resourceVersion, so the fix is seen
+	if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	deployment.Spec.Template.Spec.SecurityContext = &corev1.PodSecurityContext{}
+	if err := fakeClient.Update(ctx, deployment); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := validator.ValidateCluster(ctx); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	if got := len(validator.GetLastValidationErrors()); got != 1 {
+		t.Errorf("findings = %d after the fix, want 1", got)
+	}
+}
+
+func TestValidatorRegistry_ManifestValidationBypassesResultCache(t *testing.T) {
+	manifest := func(name, securityContext string) string {
+		path := filepath.Join(t.TempDir(), name)
+		data := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:` + securityContext + `
+      containers:
+        - name: app
+          image: nginx:1.27
+`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		return path
+	}
+	// Both manifests define Deployment shop/web, which the fake client serving them
+	// gives the same resourceVersion; only the first lacks a pod SecurityContext
+	insecure := manifest("insecure.yaml", "")
+	fixed := manifest("fixed.yaml", "\n      securityContext: {}")
+
+	tests := []struct {
+		name     string
+		validate func(*ValidatorRegistry, string) (*ValidationResult, error)
+	}{
+		{name: "file only", validate: func(r *ValidatorRegistry, path string) (*ValidationResult, error) {
+			return r.ValidateFileOnly(context.Background(), path)
+		}},
+		{name: "new config", validate: func(r *ValidatorRegistry, path string) (*ValidationResult, error) {
+			return r.ValidateNewConfig(context.Background(), path)
+		}},
+		{name: "new config with scope", validate: func(r *ValidatorRegistry, path string) (*ValidationResult, error) {
+			return r.ValidateNewConfigWithScopeAndData(context.Background(), path, "all", nil)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().Build()
+			registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+			registry.Register(NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{EnableSecurityContextValidation: true}))
+
+			for _, want := range []struct {
+				path     string
+				findings int
+			}{{insecure, 2}, {fixed, 1}, {insecure, 2}} {
+				result, err := tt.validate(registry, want.path)
+				if err != nil {
+					t.Fatalf("validate(%s) error = %v", filepath.Base(want.path), err)
+				}
+				if got := len(result.Errors); got != want.findings {
+					t.Errorf("validate(%s) findings = %d, want %d", filepath.Base(want.path), got, want.findings)
+				}
+			}
+		})
+	}
+}
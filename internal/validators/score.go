@@ -0,0 +1,245 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MaxHygieneScore is the hygiene score of resources without findings
+const MaxHygieneScore = 100
+
+// severityPenalties are the points a finding takes off the hygiene score, by severity.
+// Findings without a severity count as errors.
+var severityPenalties = map[Severity]float64{
+	SeverityError:   10,
+	SeverityWarning: 3,
+	SeverityInfo:    1,
+}
+
+// categoryWeights multiply the penalty of the findings of an error code category,
+// unless a score policy weighs their code. Security findings weigh most, then the
+// broken references and networking that stop applications from working; other
+// categories weigh 1.
+var categoryWeights = map[string]float64{
+	"SEC": 2,
+	"REF": 1.5,
+	"NET": 1.5,
+}
+
+// HygieneScores rates the hygiene of a cluster and of each of its namespaces from 0
+// to 100
+type HygieneScores struct {
+	// Cluster is the mean score of the namespaces, with the cluster-scoped resources
+	// counted as one more namespace when they have findings
+	Cluster int
+	// Namespaces holds the score of each namespace validated or with findings
+	Namespaces map[string]int
+}
+
+// ScorePolicy weighs the findings of error codes in hygiene scores, so that scores
+// reflect the priorities of an organization. Exact codes take precedence over
+// prefixes, and longer prefixes over shorter ones. Codes the policy does not weigh
+// keep the weight of their category.
+type ScorePolicy struct {
+	exact    map[string]float64
+	prefixes []scoreWeightPrefix
+}
+
+// scoreWeightPrefix is a score weight that applies to every code with a prefix
+type scoreWeightPrefix struct {
+	prefix string
+	weight float64
+}
+
+// NewScorePolicy builds a ScorePolicy from the score weights of one or more policies.
+// Later policies take precedence when they weigh the same code.
+func NewScorePolicy(policies ...ValidationPolicy) (*ScorePolicy, error) {
+	weights := make(map[string]float64)
+	var problems []string
+
+	for _, policy := range policies {
+		for key, weight := range policy.Spec.ScoreWeights {
+			code := strings.ToUpper(strings.TrimSpace(key))
+			if !severityOverrideKeyPattern.MatchString(code) {
+				problems = append(problems, fmt.Sprintf("policy %q: invalid error code %q", policy.Name, key))
+				continue
+			}
+			if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+				problems = append(problems, fmt.Sprintf("policy %q: %s has invalid weight %v, expected a number of at least 0", policy.Name, key, weight))
+				continue
+			}
+			weights[code] = weight
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid score weights: %s", strings.Join(problems, "; "))
+	}
+
+	scorePolicy := &ScorePolicy{exact: make(map[string]float64)}
+	for code, weight := range weights {
+		if prefix, ok := strings.CutSuffix(code, "*"); ok {
+			scorePolicy.prefixes = append(scorePolicy.prefixes, scoreWeightPrefix{prefix: prefix, weight: weight})
+		} else {
+			scorePolicy.exact[code] = weight
+		}
+	}
+	sort.Slice(scorePolicy.prefixes, func(i, j int) bool {
+		return len(scorePolicy.prefixes[i].prefix) > len(scorePolicy.prefixes[j].prefix)
+	})
+
+	return scorePolicy, nil
+}
+
+// Len returns the number of score weights in the policy
+func (p *ScorePolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.exact) + len(p.prefixes)
+}
+
+// Weight returns the weight the penalty of a finding with the given error code is
+// multiplied by
+func (p *ScorePolicy) Weight(errorCode string) float64 {
+	if p != nil && errorCode != "" {
+		if weight, ok := p.exact[errorCode]; ok {
+			return weight
+		}
+		for _, prefix := range p.prefixes {
+			if strings.HasPrefix(errorCode, prefix.prefix) {
+				return prefix.weight
+			}
+		}
+	}
+	if weight, ok := categoryWeights[errorCodeCategory(errorCode)]; ok {
+		return weight
+	}
+	return 1
+}
+
+// penalty returns the points a finding takes off the score of its namespace
+func (p *ScorePolicy) penalty(ve ValidationError) float64 {
+	penalty, ok := severityPenalties[ve.Severity]
+	if !ok {
+		penalty = severityPenalties[SeverityError]
+	}
+	return penalty * p.Weight(ve.ErrorCode)
+}
+
+// HygieneScore rates a set of findings, such as those of one namespace or component,
+// from 100 without findings down to 0. Each finding takes points off by its severity,
+// weighted by the policy or its category.
+func (p *ScorePolicy) HygieneScore(findings []ValidationError) int {
+	penalty := 0.0
+	for _, ve := range findings {
+		penalty += p.penalty(ve)
+	}
+	return scoreFromPenalty(penalty)
+}
+
+// ScoreFindings rates the hygiene of each namespace from its findings weighted by the
+// policy, and the cluster's as the mean of its namespaces. Namespaces lists the
+// namespaces that were validated, which score 100 without findings; when it is empty
+// only the namespaces with findings are rated.
+func (p *ScorePolicy) ScoreFindings(findings []ValidationError, namespaces []string) HygieneScores {
+	return scoreFindings(findings, namespaces, func(int) *ScorePolicy { return p })
+}
+
+// HygieneScore rates a set of findings with the weights of their categories, see
+// ScorePolicy.HygieneScore
+func HygieneScore(findings []ValidationError) int {
+	return (*ScorePolicy)(nil).HygieneScore(findings)
+}
+
+// ScoreFindings rates the hygiene of each namespace and of the cluster with the
+// weights of the findings' categories, see ScorePolicy.ScoreFindings
+func ScoreFindings(findings []ValidationError, namespaces []string) HygieneScores {
+	return (*ScorePolicy)(nil).ScoreFindings(findings, namespaces)
+}
+
+// ScorePolicy returns the score policy that weighed the result's findings, nil for
+// the category weights
+func (r ValidationResult) ScorePolicy() *ScorePolicy {
+	return r.scorePolicy
+}
+
+// scoreFromPenalty converts the points taken off by findings into a score
+func scoreFromPenalty(penalty float64) int {
+	return max(0, MaxHygieneScore-int(math.Round(penalty)))
+}
+
+// scoreFindings rates findings by namespace, weighing the finding at each index with
+// the score policy policyOf returns for it, so that merged results keep the weights
+// of the registries that produced them
+func scoreFindings(findings []ValidationError, namespaces []string, policyOf func(i int) *ScorePolicy) HygieneScores {
+	penalties := make(map[string]float64)
+	for _, namespace := range namespaces {
+		penalties[namespace] = 0
+	}
+	for i, ve := range findings {
+		penalties[ve.Namespace] += policyOf(i).penalty(ve)
+	}
+
+	scores := HygieneScores{Cluster: MaxHygieneScore, Namespaces: make(map[string]int)}
+	total := 0
+	for namespace, penalty := range penalties {
+		score := scoreFromPenalty(penalty)
+		total += score
+		if namespace != "" {
+			scores.Namespaces[namespace] = score
+		}
+	}
+	if len(penalties) > 0 {
+		scores.Cluster = int(math.Round(float64(total) / float64(len(penalties))))
+	}
+	return scores
+}
+
+// ScoredNamespaces lists the namespaces a scan of the whole cluster rates, which are
+// all but the system namespaces, for scores computed outside of a ValidatorRegistry
+func ScoredNamespaces(ctx context.Context, reader client.Reader) ([]string, error) {
+	return Shard{}.ownedNamespaces(ctx, reader)
+}
+
+// scoreResult fills in the hygiene scores of a result's summary, weighing its findings
+// with policy
+func scoreResult(result *ValidationResult, namespaces []string, policy *ScorePolicy) {
+	result.scorePolicy = policy
+	setScores(result, policy.ScoreFindings(result.Errors, namespaces))
+}
+
+// setScores fills in a result's summary with hygiene scores
+func setScores(result *ValidationResult, scores HygieneScores) {
+	result.Summary.HygieneScore = scores.Cluster
+	result.Summary.NamespaceScores = scores.Namespaces
+	if len(scores.Namespaces) == 0 {
+		result.Summary.NamespaceScores = nil
+	}
+}
+
+// scoredNamespaces returns the namespaces rated in the summaries of results, sorted
+func scoredNamespaces(results ...ValidationResult) []string {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, result := range results {
+		for namespace := range result.Summary.NamespaceScores {
+			if !seen[namespace] {
+				seen[namespace] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestHygieneScore(t *testing.T) {
+	finding := func(code string, severity Severity) ValidationError {
+		return NewValidationErrorWithCode("Pod", "web", "shop", "test", code, "finding").WithSeverity(severity)
+	}
+	many := make([]ValidationError, 8)
+	for i := range many {
+		many[i] = finding("KOGARO-SEC-006", SeverityError)
+	}
+
+	tests := []struct {
+		name     string
+		findings []ValidationError
+		want     int
+	}{
+		{name: "no findings", want: 100},
+		{name: "error", findings: []ValidationError{finding("KOGARO-RES-001", SeverityError)}, want: 90},
+		{name: "warning", findings: []ValidationError{finding("KOGARO-RES-002", SeverityWarning)}, want: 97},
+		{name: "info", findings: []ValidationError{finding("KOGARO-WKL-001", SeverityInfo)}, want: 99},
+		{name: "security weighs double", findings: []ValidationError{finding("KOGARO-SEC-006", SeverityError)}, want: 80},
+		{name: "references weigh one and a half", findings: []ValidationError{finding("KOGARO-REF-003", SeverityWarning)}, want: 95},
+		{name: "missing severity counts as error", findings: []ValidationError{{ResourceType: "Pod", ErrorCode: "KOGARO-VOL-001"}}, want: 90},
+		{name: "never below zero", findings: many, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HygieneScore(tt.findings); got != tt.want {
+				t.Errorf("HygieneScore() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreFindings(t *testing.T) {
+	findings := []ValidationError{
+		NewValidationErrorWithCode("Pod", "web", "shop", "test", "KOGARO-SEC-006", "privileged"),
+		NewValidationErrorWithCode("Pod", "api", "billing", "test", "KOGARO-RES-002", "no requests").WithSeverity(SeverityWarning),
+		NewValidationErrorWithCode("StorageClass", "fast", "", "test", "KOGARO-REF-010", "missing"),
+	}
+
+	// Only the namespaces with findings, and the cluster-scoped resources, are rated
+	scores := ScoreFindings(findings, nil)
+	if scores.Namespaces["shop"] != 80 || scores.Namespaces["billing"] != 97 || len(scores.Namespaces) != 2 {
+		t.Errorf("namespace scores = %v", scores.Namespaces)
+	}
+	if scores.Cluster != 87 {
+		t.Errorf("cluster score = %d, want the mean of 80, 97 and 85", scores.Cluster)
+	}
+
+	// Validated namespaces without findings score 100
+	scores = ScoreFindings(findings, []string{"shop", "billing", "web", "docs"})
+	if scores.Namespaces["web"] != 100 || len(scores.Namespaces) != 4 {
+		t.Errorf("namespace scores = %v", scores.Namespaces)
+	}
+	if scores.Cluster != 92 {
+		t.Errorf("cluster score = %d, want the mean of 80, 97, 100, 100 and 85", scores.Cluster)
+	}
+
+	if scores := ScoreFindings(nil, nil); scores.Cluster != 100 || len(scores.Namespaces) != 0 {
+		t.Errorf("scores without findings = %+v", scores)
+	}
+}
+
+func TestValidatorRegistry_ScoresValidatedNamespaces(t *testing.T) {
+	namespace := func(name string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	fakeClient := fake.NewClientBuilder().WithObjects(namespace("shop"), namespace("web"), namespace("kube-system")).Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: []ValidationError{
+		NewValidationErrorWithCode("Pod", "web", "shop", "test", "KOGARO-RES-001", "no requests"),
+	}})
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	summary := registry.LastValidationResult().Summary
+	want := map[string]int{"shop": 90, "web": 100}
+	if len(summary.NamespaceScores) != len(want) || summary.NamespaceScores["shop"] != 90 || summary.NamespaceScores["web"] != 100 {
+		t.Errorf("namespace scores = %v, want %v without system namespaces", summary.NamespaceScores, want)
+	}
+	if summary.HygieneScore != 95 {
+		t.Errorf("hygiene score = %d, want 95", summary.HygieneScore)
+	}
+}
+
+func TestNewScorePolicy(t *testing.T) {
+	policies, err := ParseValidationPolicy([]byte(`apiVersion: kogaro.io/v1alpha1
+kind: ValidationPolicy
+metadata:
+  name: scoring
+spec:
+  scoreWeights:
+    KOGARO-SEC-*: 5
+    KOGARO-REF-*: 1
+    kogaro-ref-003 : 2.5
+    KOGARO-WKL-*: 0
+`))
+	if err != nil {
+		t.Fatalf("ParseValidationPolicy() error = %v", err)
+	}
+	// A later policy replaces the weight of the same code
+	policy, err := NewScorePolicy(policies, ValidationPolicy{Spec: ValidationPolicySpec{ScoreWeights: map[string]float64{
+		"KOGARO-SEC-*": 4,
+	}}})
+	if err != nil {
+		t.Fatalf("NewScorePolicy() error = %v", err)
+	}
+	if policy.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", policy.Len())
+	}
+
+	tests := []struct {
+		code string
+		want float64
+	}{
+		{"KOGARO-SEC-006", 4},
+		{"KOGARO-REF-003", 2.5},
+		{"KOGARO-REF-001", 1},
+		{"KOGARO-WKL-001", 0},
+		{"KOGARO-NET-001", 1.5},
+		{"KOGARO-RES-001", 1},
+	}
+	for _, tt := range tests {
+		if got := policy.Weight(tt.code); got != tt.want {
+			t.Errorf("Weight(%s) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+
+	findings := []ValidationError{
+		NewValidationErrorWithCode("Pod", "web", "shop", "test", "KOGARO-SEC-006", "privileged"),
+		NewValidationErrorWithCode("Pod", "web", "shop", "test", "KOGARO-WKL-001", "no probe"),
+	}
+	if got := policy.HygieneScore(findings); got != 60 {
+		t.Errorf("HygieneScore() = %d, want 60 with security weighed 4 and workloads left out", got)
+	}
+}
+
+func TestNewScorePolicy_Invalid(t *testing.T) {
+	_, err := NewScorePolicy(ValidationPolicy{Spec: ValidationPolicySpec{ScoreWeights: map[string]float64{
+		"not a code":     2,
+		"KOGARO-RES-001": -1,
+	}}})
+	if err == nil {
+		t.Fatal("NewScorePolicy() with invalid weights succeeded")
+	}
+	if !strings.Contains(err.Error(), `invalid error code "not a code"`) || !strings.Contains(err.Error(), "KOGARO-RES-001 has invalid weight -1") {
+		t.Errorf("error = %v", err)
+	}
+
+	// Without a policy, findings keep the weight of their category
+	var policy *ScorePolicy
+	if got := policy.Weight("KOGARO-SEC-006"); got != 2 {
+		t.Errorf("Weight() of a nil policy = %v, want the category weight 2", got)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretMetadataOnly makes validators read only the metadata of Secrets
+var secretMetadataOnly atomic.Bool
+
+// SetSecretMetadataOnly makes validators read only the metadata of Secrets, so that
+// the informer cache watches Secret metadata and never holds Secret data. References
+// to missing Secrets are still reported, but the keys, types and contents of Secrets
+// are not checked. The Secret hygiene validator reads Secret data and must not run.
+func SetSecretMetadataOnly(enabled bool) {
+	secretMetadataOnly.Store(enabled)
+}
+
+// secretDataReadable reports whether validators may read the data of Secrets
+func secretDataReadable() bool {
+	return !secretMetadataOnly.Load()
+}
+
+// getSecret reads a Secret, or only its metadata when Secret data must not be read
+func getSecret(ctx context.Context, reader client.Reader, namespace, name string) (*corev1.Secret, error) {
+	key := client.ObjectKey{Namespace: namespace, Name: name}
+	if secretDataReadable() {
+		secret := &corev1.Secret{}
+		if err := reader.Get(ctx, key, secret); err != nil {
+			return nil, err
+		}
+		return secret, nil
+	}
+
+	metadata := &metav1.PartialObjectMetadata{}
+	metadata.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
+	if err := reader.Get(ctx, key, metadata); err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{TypeMeta: metadata.TypeMeta, ObjectMeta: metadata.ObjectMeta}, nil
+}
+
+// newSecretList returns the list Secrets are listed into: Secrets, or only their
+// metadata when Secret data must not be read
+func newSecretList() client.ObjectList {
+	if secretDataReadable() {
+		return &corev1.SecretList{}
+	}
+	list := &metav1.PartialObjectMetadataList{}
+	list.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("SecretList"))
+	return list
+}
+
+// listSecrets lists Secrets, or only their metadata when Secret data must not be read
+func listSecrets(ctx context.Context, reader client.Reader, opts ...client.ListOption) ([]corev1.Secret, error) {
+	list := newSecretList()
+	if err := reader.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	if secrets, ok := list.(*corev1.SecretList); ok {
+		return secrets.Items, nil
+	}
+
+	items := list.(*metav1.PartialObjectMetadataList).Items
+	secrets := make([]corev1.Secret, len(items))
+	for i, item := range items {
+		secrets[i] = corev1.Secret{TypeMeta: item.TypeMeta, ObjectMeta: item.ObjectMeta}
+	}
+	return secrets, nil
+}
+
+// secretType returns the type of a Secret. Secrets read as metadata carry no type,
+// so service account token and Helm release Secrets are recognised by the
+// annotation and labels that the controllers creating them set.
+func secretType(secret *corev1.Secret) corev1.SecretType {
+	if secret.Type != "" || secretDataReadable() {
+		return secret.Type
+	}
+	if _, ok := secret.Annotations[corev1.ServiceAccountNameKey]; ok {
+		return corev1.SecretTypeServiceAccountToken
+	}
+	if secret.Labels["owner"] == "helm" && strings.HasPrefix(secret.Name, "sh.helm.release.v1.") {
+		return helmReleaseSecretType
+	}
+	return ""
+}
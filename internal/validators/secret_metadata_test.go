@@ -0,0 +1,108 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestReferenceValidator_SecretMetadataOnly(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "shop"},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "app",
+				Image: "app:1",
+				Env: []corev1.EnvVar{
+					{Name: "PASSWORD", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "credentials"}, Key: "absent",
+					}}},
+					{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Key: "token",
+					}}},
+				},
+			}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "shop"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	}
+	// Reading a Secret in full fails the test, as it would cache Secret data
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod, secret).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*corev1.Secret); ok {
+				return fmt.Errorf("read Secret %s in full", key)
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*corev1.SecretList); ok {
+				return fmt.Errorf("listed Secrets in full")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+
+	SetSecretMetadataOnly(true)
+	t.Cleanup(func() { SetSecretMetadataOnly(false) })
+
+	validator := NewReferenceValidator(fakeClient, logr.Discard(), ValidationConfig{EnableSecretValidation: true})
+	findings, err := validator.validateSecretReferences(context.Background())
+	if err != nil {
+		t.Fatalf("validateSecretReferences() error = %v", err)
+	}
+	// The missing Secret is reported, the key of the existing one cannot be checked
+	if len(findings) != 1 || findings[0].ValidationType != "dangling_secret_env" {
+		t.Fatalf("findings = %v, want only the missing Secret", findings)
+	}
+
+	secrets, err := listSecrets(context.Background(), fakeClient, client.InNamespace("shop"))
+	if err != nil {
+		t.Fatalf("listSecrets() error = %v", err)
+	}
+	if len(secrets) != 1 || secrets[0].Name != "credentials" || secrets[0].Data != nil {
+		t.Errorf("listSecrets() = %v, want the metadata of credentials", secrets)
+	}
+}
+
+func TestSecretType_Metadata(t *testing.T) {
+	SetSecretMetadataOnly(true)
+	t.Cleanup(func() { SetSecretMetadataOnly(false) })
+
+	tests := []struct {
+		meta metav1.ObjectMeta
+		want corev1.SecretType
+	}{
+		{metav1.ObjectMeta{Name: "builder-token", Annotations: map[string]string{corev1.ServiceAccountNameKey: "builder"}}, corev1.SecretTypeServiceAccountToken},
+		{metav1.ObjectMeta{Name: "sh.helm.release.v1.shop.v3", Labels: map[string]string{"owner": "helm"}}, helmReleaseSecretType},
+		{metav1.ObjectMeta{Name: "credentials", Labels: map[string]string{"owner": "helm"}}, ""},
+	}
+	for _, tt := range tests {
+		if got := secretType(&corev1.Secret{ObjectMeta: tt.meta}); got != tt.want {
+			t.Errorf("secretType(%s) = %q, want %q", tt.meta.Name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides Secret content hygiene validation functionality.
+//
+// This package implements opt-in validation of Secret objects themselves,
+// detecting Secrets that exist but cannot be used as intended: empty Secrets
+// referenced by workloads, expired or expiring TLS certificates, malformed
+// registry credentials, and incomplete basic-auth credentials. Secret values
+// are never logged or reported; findings only carry metadata.
+package validators
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+const (
+	// DefaultCertificateExpiryWarningDays is the default window before expiry in which TLS certificates are flagged
+	DefaultCertificateExpiryWarningDays = 30
+)
+
+// SecretConfig defines which Secret content hygiene checks to perform
+type SecretConfig struct {
+	EnableEmptySecretValidation      bool
+	EnableTLSSecretValidation        bool
+	EnableDockerConfigJSONValidation bool
+	EnableBasicAuthValidation        bool
+	// Number of days before certificate expiry to start warning
+	CertificateExpiryWarningDays int
+}
+
+// SecretValidator validates the content of Secret objects without exposing their values
+type SecretValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               SecretConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+
+	// For testing
+	now func() time.Time
+}
+
+// NewSecretValidator creates a new SecretValidator with the given client, logger and config
+func NewSecretValidator(client client.Client, log logr.Logger, config SecretConfig) *SecretValidator {
+	if config.CertificateExpiryWarningDays <= 0 {
+		config.CertificateExpiryWarningDays = DefaultCertificateExpiryWarningDays
+	}
+	return &SecretValidator{
+		client:       client,
+		log:          log.WithName("secret-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+		now:          time.Now,
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *SecretValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *SecretValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *SecretValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for secret validation
+func (v *SecretValidator) GetValidationType() string {
+	return "secret_validation"
+}
+
+// ValidateCluster performs Secret content hygiene validation across the entire cluster
+func (v *SecretValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	var secrets corev1.SecretList
+	if err := v.client.List(ctx, &secrets); err != nil {
+		return fmt.Errorf("failed to list secrets: %w", err)
+	}
+
+	var allErrors []ValidationError
+
+	// Validate empty Secrets referenced by workloads
+	if v.config.EnableEmptySecretValidation {
+		referenced, err := v.collectReferencedSecrets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to collect secret references: %w", err)
+		}
+		allErrors = append(allErrors, v.validateEmptySecrets(secrets.Items, referenced)...)
+	}
+
+	for _, secret := range secrets.Items {
+		// Skip system namespaces
+		if v.sharedConfig.IsSystemNamespace(secret.Namespace) {
+			continue
+		}
+
+		switch secret.Type {
+		case corev1.SecretTypeTLS:
+			if v.config.EnableTLSSecretValidation {
+				allErrors = append(allErrors, v.validateTLSSecret(secret)...)
+			}
+		case corev1.SecretTypeDockerConfigJson:
+			if v.config.EnableDockerConfigJSONValidation {
+				allErrors = append(allErrors, v.validateDockerConfigJSONSecret(secret)...)
+			}
+		case corev1.SecretTypeBasicAuth:
+			if v.config.EnableBasicAuthValidation {
+				allErrors = append(allErrors, v.validateBasicAuthSecret(secret)...)
+			}
+		}
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "secret", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "secret", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// collectReferencedSecrets returns the set of namespace/name Secret keys referenced by Pods and Ingresses,
+// mapped to a description of the first referencing resource.
+func (v *SecretValidator) collectReferencedSecrets(ctx context.Context) (map[string]string, error) {
+	referenced := make(map[string]string)
+	add := func(namespace, name, referrer string) {
+		key := namespace + "/" + name
+		if _, exists := referenced[key]; !exists {
+			referenced[key] = referrer
+		}
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		referrer := fmt.Sprintf("Pod/%s", pod.Name)
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Secret != nil {
+				add(pod.Namespace, volume.Secret.SecretName, referrer)
+			}
+		}
+		for _, container := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					add(pod.Namespace, envFrom.SecretRef.Name, referrer)
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					add(pod.Namespace, env.ValueFrom.SecretKeyRef.Name, referrer)
+				}
+			}
+		}
+		for _, pullSecret := range pod.Spec.ImagePullSecrets {
+			add(pod.Namespace, pullSecret.Name, referrer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var ingresses networkingv1.IngressList
+	if err := v.client.List(ctx, &ingresses); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName != "" {
+				add(ingress.Namespace, tls.SecretName, fmt.Sprintf("Ingress/%s", ingress.Name))
+			}
+		}
+	}
+
+	return referenced, nil
+}
+
+func (v *SecretValidator) validateEmptySecrets(secrets []corev1.Secret, referenced map[string]string) []ValidationError {
+	var errors []ValidationError
+
+	for _, secret := range secrets {
+		// Skip system namespaces
+		if v.sharedConfig.IsSystemNamespace(secret.Namespace) {
+			continue
+		}
+
+		referrer, isReferenced := referenced[secret.Namespace+"/"+secret.Name]
+		if !isReferenced || len(secretKeys(secret)) > 0 {
+			continue
+		}
+
+		errorCode := GetSecretErrorCode("empty_secret_referenced")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "empty_secret_referenced", errorCode, fmt.Sprintf("Secret '%s' is referenced by workloads but contains no data", secret.Name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Populate the Secret with the keys expected by the referencing workloads or remove the reference").
+			WithRelatedResources(referrer).
+			WithDetail("secret_type", string(secret.Type)).
+			WithDetail("referenced_by", referrer))
+	}
+
+	return errors
+}
+
+func (v *SecretValidator) validateTLSSecret(secret corev1.Secret) []ValidationError {
+	var errors []ValidationError
+
+	if missing := missingSecretKeys(secret, corev1.TLSCertKey, corev1.TLSPrivateKeyKey); len(missing) > 0 {
+		errorCode := GetSecretErrorCode("tls_secret_missing_keys")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "tls_secret_missing_keys", errorCode, fmt.Sprintf("TLS Secret '%s' is missing required keys: %s", secret.Name, strings.Join(missing, ", "))).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Recreate the Secret with both tls.crt and tls.key, e.g. using 'kubectl create secret tls'").
+			WithDetail("secret_type", string(secret.Type)).
+			WithDetail("missing_keys", strings.Join(missing, ",")))
+		return errors
+	}
+
+	cert, err := parseLeafCertificate(secretValue(secret, corev1.TLSCertKey))
+	if err != nil {
+		errorCode := GetSecretErrorCode("tls_secret_invalid_certificate")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "tls_secret_invalid_certificate", errorCode, fmt.Sprintf("TLS Secret '%s' contains a certificate that cannot be parsed", secret.Name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Ensure tls.crt contains a PEM-encoded X.509 certificate").
+			WithDetail("secret_type", string(secret.Type)).
+			WithDetail("parse_error", err.Error()))
+		return errors
+	}
+
+	now := v.now()
+	expiry := cert.NotAfter.UTC().Format(time.RFC3339)
+	warningWindow := time.Duration(v.config.CertificateExpiryWarningDays) * 24 * time.Hour
+
+	if now.After(cert.NotAfter) {
+		errorCode := GetSecretErrorCode("tls_certificate_expired")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "tls_certificate_expired", errorCode, fmt.Sprintf("TLS Secret '%s' certificate expired on %s", secret.Name, expiry)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Renew the certificate and update the Secret, or configure automated renewal (e.g., cert-manager)").
+			WithDetail("not_after", expiry).
+			WithDetail("subject", cert.Subject.CommonName))
+	} else if cert.NotAfter.Sub(now) < warningWindow {
+		daysLeft := int(cert.NotAfter.Sub(now).Hours() / 24)
+		errorCode := GetSecretErrorCode("tls_certificate_expiring")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "tls_certificate_expiring", errorCode, fmt.Sprintf("TLS Secret '%s' certificate expires in %d days", secret.Name, daysLeft)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Renew the certificate before it expires, or configure automated renewal (e.g., cert-manager)").
+			WithDetail("not_after", expiry).
+			WithDetail("days_until_expiry", fmt.Sprintf("%d", daysLeft)).
+			WithDetail("warning_window_days", fmt.Sprintf("%d", v.config.CertificateExpiryWarningDays)).
+			WithDetail("subject", cert.Subject.CommonName))
+	}
+
+	return errors
+}
+
+func (v *SecretValidator) validateDockerConfigJSONSecret(secret corev1.Secret) []ValidationError {
+	var errors []ValidationError
+
+	if missing := missingSecretKeys(secret, corev1.DockerConfigJsonKey); len(missing) > 0 {
+		errorCode := GetSecretErrorCode("dockerconfigjson_missing_key")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "dockerconfigjson_missing_key", errorCode, fmt.Sprintf("Registry Secret '%s' is missing the %s key", secret.Name, corev1.DockerConfigJsonKey)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Recreate the Secret using 'kubectl create secret docker-registry'").
+			WithDetail("secret_type", string(secret.Type)).
+			WithDetail("missing_keys", corev1.DockerConfigJsonKey))
+		return errors
+	}
+
+	var dockerConfig struct {
+		Auths map[string]json.RawMessage `json:"auths"`
+	}
+	if err := json.Unmarshal(secretValue(secret, corev1.DockerConfigJsonKey), &dockerConfig); err != nil || dockerConfig.Auths == nil {
+		// Never include the parse error verbatim: json errors can echo fragments of the value
+		errorCode := GetSecretErrorCode("dockerconfigjson_malformed")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "dockerconfigjson_malformed", errorCode, fmt.Sprintf("Registry Secret '%s' does not contain valid docker config JSON with an 'auths' section", secret.Name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Regenerate the Secret using 'kubectl create secret docker-registry' so image pulls can authenticate").
+			WithDetail("secret_type", string(secret.Type)))
+	}
+
+	return errors
+}
+
+func (v *SecretValidator) validateBasicAuthSecret(secret corev1.Secret) []ValidationError {
+	var errors []ValidationError
+
+	if missing := missingSecretKeys(secret, corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey); len(missing) > 0 {
+		errorCode := GetSecretErrorCode("basic_auth_missing_keys")
+		errors = append(errors, NewValidationErrorWithCode("Secret", secret.Name, secret.Namespace, "basic_auth_missing_keys", errorCode, fmt.Sprintf("Basic-auth Secret '%s' is missing required keys: %s", secret.Name, strings.Join(missing, ", "))).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Add both username and password keys to the basic-auth Secret").
+			WithDetail("secret_type", string(secret.Type)).
+			WithDetail("missing_keys", strings.Join(missing, ",")))
+	}
+
+	return errors
+}
+
+// secretKeys returns the sorted set of keys present in a Secret's data or stringData.
+func secretKeys(secret corev1.Secret) []string {
+	keys := make(map[string]bool)
+	for k := range secret.Data {
+		keys[k] = true
+	}
+	for k := range secret.StringData {
+		keys[k] = true
+	}
+	result := make([]string, 0, len(keys))
+	for k := range keys {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// secretValue returns the value for a key, preferring data over stringData.
+func secretValue(secret corev1.Secret, key string) []byte {
+	if value, ok := secret.Data[key]; ok {
+		return value
+	}
+	if value, ok := secret.StringData[key]; ok {
+		return []byte(value)
+	}
+	return nil
+}
+
+// missingSecretKeys returns the required keys that are absent or empty in the Secret.
+func missingSecretKeys(secret corev1.Secret, required ...string) []string {
+	var missing []string
+	for _, key := range required {
+		if len(secretValue(secret, key)) == 0 {
+			missing = append(missing, key)
+		}
+	}
+	return missing
+}
+
+// parseLeafCertificate parses the first PEM-encoded certificate from data.
+func parseLeafCertificate(data []byte) (*x509.Certificate, error) {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("no PEM certificate block found")
+		}
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+}
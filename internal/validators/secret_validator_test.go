@@ -0,0 +1,305 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// generateTestCertificate returns a PEM-encoded self-signed certificate expiring at notAfter
+func generateTestCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestSecretValidator_GetValidationType(t *testing.T) {
+	validator := NewSecretValidator(nil, logr.Discard(), SecretConfig{})
+	if got := validator.GetValidationType(); got != "secret_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "secret_validation")
+	}
+}
+
+func TestSecretValidator_ValidateCluster(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErrors []string
+	}{
+		{
+			name: "empty secret referenced by pod",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-secret", Namespace: "test-ns"},
+				},
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test-ns"},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{
+							Name: "app",
+							EnvFrom: []corev1.EnvFromSource{{
+								SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "app-secret"}},
+							}},
+						}},
+					},
+				},
+			},
+			expectedErrors: []string{"empty_secret_referenced"},
+		},
+		{
+			name: "empty secret not referenced is ignored",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "test-ns"},
+				},
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "empty TLS secret referenced by ingress",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeTLS,
+				},
+				&networkingv1.Ingress{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "test-ns"},
+					Spec: networkingv1.IngressSpec{
+						TLS: []networkingv1.IngressTLS{{SecretName: "tls"}},
+					},
+				},
+			},
+			expectedErrors: []string{"empty_secret_referenced", "tls_secret_missing_keys"},
+		},
+		{
+			name: "expired TLS certificate",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       generateTestCertificate(t, now.Add(-24*time.Hour)),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				},
+			},
+			expectedErrors: []string{"tls_certificate_expired"},
+		},
+		{
+			name: "TLS certificate expiring soon",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       generateTestCertificate(t, now.Add(10*24*time.Hour)),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				},
+			},
+			expectedErrors: []string{"tls_certificate_expiring"},
+		},
+		{
+			name: "valid TLS certificate",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       generateTestCertificate(t, now.Add(90*24*time.Hour)),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				},
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "unparseable TLS certificate",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeTLS,
+					Data: map[string][]byte{
+						corev1.TLSCertKey:       []byte("not-a-certificate"),
+						corev1.TLSPrivateKeyKey: []byte("key"),
+					},
+				},
+			},
+			expectedErrors: []string{"tls_secret_invalid_certificate"},
+		},
+		{
+			name: "malformed dockerconfigjson",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(`{"auths": {"registry.example.com": `),
+					},
+				},
+			},
+			expectedErrors: []string{"dockerconfigjson_malformed"},
+		},
+		{
+			name: "valid dockerconfigjson",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeDockerConfigJson,
+					Data: map[string][]byte{
+						corev1.DockerConfigJsonKey: []byte(`{"auths": {"registry.example.com": {"auth": "dXNlcjpwYXNz"}}}`),
+					},
+				},
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "basic-auth secret missing password",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "test-ns"},
+					Type:       corev1.SecretTypeBasicAuth,
+					StringData: map[string]string{corev1.BasicAuthUsernameKey: "admin"},
+				},
+			},
+			expectedErrors: []string{"basic_auth_missing_keys"},
+		},
+		{
+			name: "system namespace ignored",
+			objects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "kube-system"},
+					Type:       corev1.SecretTypeBasicAuth,
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = networkingv1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewSecretValidator(fakeClient, logr.Discard(), SecretConfig{
+				EnableEmptySecretValidation:      true,
+				EnableTLSSecretValidation:        true,
+				EnableDockerConfigJSONValidation: true,
+				EnableBasicAuthValidation:        true,
+			})
+			validator.now = func() time.Time { return now }
+			mockLogReceiver := &MockLogReceiver{}
+			validator.SetLogReceiver(mockLogReceiver)
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-SCR-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
+
+func TestSecretValidator_NeverReportsSecretValues(t *testing.T) {
+	const sensitive = "super-secret-password-value"
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "regcred", Namespace: "test-ns"},
+				Type:       corev1.SecretTypeDockerConfigJson,
+				Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths": "` + sensitive)},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "test-ns"},
+				Type:       corev1.SecretTypeBasicAuth,
+				Data:       map[string][]byte{corev1.BasicAuthPasswordKey: []byte(sensitive)},
+			},
+		).
+		Build()
+
+	validator := NewSecretValidator(fakeClient, logr.Discard(), SecretConfig{
+		EnableDockerConfigJSONValidation: true,
+		EnableBasicAuthValidation:        true,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	for _, ve := range validator.GetLastValidationErrors() {
+		fields := []string{ve.Message, ve.RemediationHint, strings.Join(ve.RelatedResources, ",")}
+		for _, value := range ve.Details {
+			fields = append(fields, value)
+		}
+		for _, field := range fields {
+			if strings.Contains(field, sensitive) {
+				t.Errorf("validation error %s leaks secret value: %q", ve.ValidationType, field)
+			}
+		}
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+// sensitiveHostPaths are host directories and sockets whose mounting gives a container
+// control of the node: the root filesystem, host configuration and credentials, kernel
+// interfaces, and the container runtime and kubelet state. Paths below them are
+// sensitive too, except below the root filesystem.
+var sensitiveHostPaths = []string{
+	"/etc",
+	"/root",
+	"/boot",
+	"/proc",
+	"/sys",
+	"/dev",
+	"/var/run/docker.sock",
+	"/run/docker.sock",
+	"/var/run/containerd",
+	"/run/containerd",
+	"/var/run/crio",
+	"/run/crio",
+	"/var/lib/docker",
+	"/var/lib/kubelet",
+}
+
+// hostAccessWorkload is a workload whose pod spec is checked for host access
+type hostAccessWorkload struct {
+	kind string
+	meta metav1.ObjectMeta
+	spec corev1.PodSpec
+}
+
+// validateHostAccess reports pods that reach into the node: hostPath volumes mounting
+// sensitive host paths, host network, PID or IPC namespaces used outside the allowed
+// namespaces, and DaemonSets claiming the same host port
+func (v *SecurityValidator) validateHostAccess(ctx context.Context) ([]ValidationError, error) {
+	workloads, err := v.listHostAccessWorkloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var allowed map[string]bool
+	if v.config.EnableHostNamespaceValidation {
+		allowed, err = v.hostNamespaceAllowedNamespaces(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errors []ValidationError
+	for _, workload := range workloads {
+		if v.config.EnableHostPathValidation {
+			errors = append(errors, hostPathErrors(workload)...)
+		}
+		// Node agents that document why they need host access may use the host's namespaces
+		if v.config.EnableHostNamespaceValidation && !allowed[workload.meta.Namespace] &&
+			!(workload.kind == "DaemonSet" && HasHostAccessReason(workload.meta)) {
+			if finding, ok := hostNamespaceError(workload); ok {
+				errors = append(errors, finding)
+			}
+		}
+	}
+
+	if v.config.EnableHostPortValidation {
+		var daemonSets []hostAccessWorkload
+		for _, workload := range workloads {
+			if workload.kind == "DaemonSet" {
+				daemonSets = append(daemonSets, workload)
+			}
+		}
+		errors = append(errors, hostPortCollisionErrors(daemonSets)...)
+	}
+
+	return errors, nil
+}
+
+// listHostAccessWorkloads lists the Deployments, StatefulSets, DaemonSets and standalone
+// pods outside the excluded namespaces
+func (v *SecurityValidator) listHostAccessWorkloads(ctx context.Context) ([]hostAccessWorkload, error) {
+	var workloads []hostAccessWorkload
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		workloads = append(workloads, hostAccessWorkload{kind: "Deployment", meta: deployment.ObjectMeta, spec: deployment.Spec.Template.Spec})
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		workloads = append(workloads, hostAccessWorkload{kind: "StatefulSet", meta: statefulSet.ObjectMeta, spec: statefulSet.Spec.Template.Spec})
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		workloads = append(workloads, hostAccessWorkload{kind: "DaemonSet", meta: daemonSet.ObjectMeta, spec: daemonSet.Spec.Template.Spec})
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are validated via their controllers
+		if utils.HasOwnerReferences(*pod) {
+			return nil
+		}
+		workloads = append(workloads, hostAccessWorkload{kind: "Pod", meta: pod.ObjectMeta, spec: pod.Spec})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	included := workloads[:0]
+	for _, workload := range workloads {
+		if !v.sharedConfig.IsSecurityExcludedNamespace(workload.meta.Namespace) {
+			included = append(included, workload)
+		}
+	}
+	return included, nil
+}
+
+// hostNamespaceAllowedNamespaces returns the namespaces allowed to use the host's
+// network, PID and IPC namespaces
+func (v *SecurityValidator) hostNamespaceAllowedNamespaces(ctx context.Context) (map[string]bool, error) {
+	selector, err := ParseNamespaceSelector(v.config.HostNamespaceAllowedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]bool)
+	if selector.Empty() {
+		return allowed, nil
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := v.client.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, namespace := range selector.Select(namespaces.Items) {
+		allowed[namespace] = true
+	}
+	return allowed, nil
+}
+
+// hostPathErrors reports the hostPath volumes of a workload that mount sensitive host paths
+func hostPathErrors(workload hostAccessWorkload) []ValidationError {
+	var errors []ValidationError
+	for _, volume := range workload.spec.Volumes {
+		if volume.HostPath == nil {
+			continue
+		}
+		hostPath := path.Clean(volume.HostPath.Path)
+		if !isSensitiveHostPath(hostPath) {
+			continue
+		}
+		errorCode := GetSecurityErrorCode("host_path_sensitive_mount", nil)
+		errors = append(errors, NewValidationErrorWithCode(workload.kind, workload.meta.Name, workload.meta.Namespace, "host_path_sensitive_mount", errorCode,
+			fmt.Sprintf("Volume '%s' mounts the sensitive host path %s", volume.Name, hostPath)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Replace the hostPath volume with a ConfigMap, Secret, emptyDir or PersistentVolumeClaim; a container with access to this path can take over the node").
+			WithRelatedResources(fmt.Sprintf("Volume/%s", volume.Name)).
+			WithDetail("volume_name", volume.Name).
+			WithDetail("host_path", hostPath).
+			WithDetail("security_risk", "node_compromise"))
+	}
+	return errors
+}
+
+// isSensitiveHostPath reports whether a cleaned host path is the root filesystem, one of
+// the sensitive host paths or below one
+func isSensitiveHostPath(hostPath string) bool {
+	if hostPath == "/" {
+		return true
+	}
+	for _, sensitive := range sensitiveHostPaths {
+		if hostPath == sensitive || strings.HasPrefix(hostPath, sensitive+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// hostNamespaceError reports a workload sharing the host's network, PID or IPC namespace
+func hostNamespaceError(workload hostAccessWorkload) (ValidationError, bool) {
+	var shared []string
+	if workload.spec.HostNetwork {
+		shared = append(shared, "network")
+	}
+	if workload.spec.HostPID {
+		shared = append(shared, "PID")
+	}
+	if workload.spec.HostIPC {
+		shared = append(shared, "IPC")
+	}
+	if len(shared) == 0 {
+		return ValidationError{}, false
+	}
+
+	namespaces := strings.Join(shared, ", ")
+	noun := "namespace"
+	if len(shared) > 1 {
+		noun = "namespaces"
+	}
+	hint := "Remove hostNetwork, hostPID and hostIPC unless the workload needs them, or allow its namespace with --host-namespace-allowed-namespaces"
+	if workload.kind == "DaemonSet" {
+		hint = fmt.Sprintf("Remove hostNetwork, hostPID and hostIPC unless the node agent needs them, or explain why it does in the %s annotation", HostAccessReasonAnnotation)
+	}
+	errorCode := GetSecurityErrorCode("host_namespace_sharing", nil)
+	return NewValidationErrorWithCode(workload.kind, workload.meta.Name, workload.meta.Namespace, "host_namespace_sharing", errorCode,
+		fmt.Sprintf("Pod shares the host's %s %s", namespaces, noun)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(hint).
+		WithDetail("host_namespaces", strings.ToLower(strings.Join(shared, ","))).
+		WithDetail("security_risk", "host_isolation_bypass"), true
+}
+
+// hostPortCollisionErrors reports DaemonSets claiming a host port another DaemonSet also
+// claims on the same nodes. Their pods cannot run together, so one DaemonSet's pods stay
+// Pending on every node the other runs on.
+func hostPortCollisionErrors(daemonSets []hostAccessWorkload) []ValidationError {
+	claims := make(map[string][]hostAccessWorkload)
+	var ports []string
+	for _, daemonSet := range daemonSets {
+		seen := make(map[string]bool)
+		containers := append(append([]corev1.Container{}, daemonSet.spec.InitContainers...), daemonSet.spec.Containers...)
+		for _, container := range containers {
+			for _, port := range container.Ports {
+				if port.HostPort == 0 {
+					continue
+				}
+				protocol := port.Protocol
+				if protocol == "" {
+					protocol = corev1.ProtocolTCP
+				}
+				key := fmt.Sprintf("%d/%s", port.HostPort, protocol)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				if _, ok := claims[key]; !ok {
+					ports = append(ports, key)
+				}
+				claims[key] = append(claims[key], daemonSet)
+			}
+		}
+	}
+	sort.Strings(ports)
+
+	var errors []ValidationError
+	for _, port := range ports {
+		claimants := claims[port]
+		for i, daemonSet := range claimants {
+			var others []string
+			for j, other := range claimants {
+				if i != j && nodeSelectorsOverlap(daemonSet.spec.NodeSelector, other.spec.NodeSelector) {
+					others = append(others, fmt.Sprintf("DaemonSet/%s/%s", other.meta.Namespace, other.meta.Name))
+				}
+			}
+			if len(others) == 0 {
+				continue
+			}
+			errorCode := GetSecurityErrorCode("host_port_collision", nil)
+			errors = append(errors, NewValidationErrorWithCode("DaemonSet", daemonSet.meta.Name, daemonSet.meta.Namespace, "host_port_collision", errorCode,
+				fmt.Sprintf("Host port %s is also claimed by %s, so their pods cannot run on the same node", port, strings.Join(others, ", "))).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Give each DaemonSet its own host port, or use a Service instead of hostPort if the port does not need to be reachable on the node's address").
+				WithRelatedResources(others...).
+				WithDetail("host_port", port))
+		}
+	}
+	return errors
+}
+
+// nodeSelectorsOverlap reports whether two node selectors can select the same node,
+// which they cannot when they require different values for the same label
+func nodeSelectorsOverlap(a, b map[string]string) bool {
+	for key, value := range a {
+		if other, ok := b[key]; ok && other != value {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecurityValidator_ValidateHostAccess(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	hostPathVolume := func(name, hostPath string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: hostPath}}}
+	}
+	deployment := func(name, namespace string, spec corev1.PodSpec) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: spec}},
+		}
+	}
+	daemonSet := func(name string, hostPort int32, nodeSelector map[string]string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "metrics"},
+			Spec: appsv1.DaemonSetSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				NodeSelector: nodeSelector,
+				Containers:   []corev1.Container{{Name: "agent", Ports: []corev1.ContainerPort{{ContainerPort: hostPort, HostPort: hostPort}}}},
+			}}},
+		}
+	}
+
+	objects := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "networking", Labels: map[string]string{"host-access": "allowed"}}},
+		deployment("builder", "shop", corev1.PodSpec{Volumes: []corev1.Volume{
+			hostPathVolume("docker", "/var/run/docker.sock"),
+			hostPathVolume("certs", "/etc/ssl/certs/"),
+			hostPathVolume("scratch", "/mnt/scratch"),
+		}}),
+		deployment("debug", "shop", corev1.PodSpec{HostNetwork: true, HostPID: true}),
+		// Allowed by the namespace's label
+		deployment("gateway", "networking", corev1.PodSpec{HostNetwork: true}),
+		// Same port on the same nodes
+		daemonSet("node-exporter", 9100, nil),
+		daemonSet("legacy-exporter", 9100, nil),
+		// Same port on different nodes
+		daemonSet("gpu-exporter", 9400, map[string]string{"accelerator": "nvidia"}),
+		daemonSet("tpu-exporter", 9400, map[string]string{"accelerator": "tpu"}),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{
+		EnableHostPathValidation:       true,
+		EnableHostNamespaceValidation:  true,
+		EnableHostPortValidation:       true,
+		HostNamespaceAllowedNamespaces: []string{"host-access=allowed"},
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	messages := make(map[string]string)
+	for _, finding := range validator.GetLastValidationErrors() {
+		key := finding.ValidationType + " " + finding.ResourceType + "/" + finding.ResourceName + " " + finding.Details["host_path"]
+		messages[key] = finding.Message
+		got = append(got, key)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"host_namespace_sharing Deployment/debug ",
+		"host_path_sensitive_mount Deployment/builder /etc/ssl/certs",
+		"host_path_sensitive_mount Deployment/builder /var/run/docker.sock",
+		"host_port_collision DaemonSet/legacy-exporter ",
+		"host_port_collision DaemonSet/node-exporter ",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	wantMessages := map[string]string{
+		"host_namespace_sharing Deployment/debug ":       "Pod shares the host's network, PID namespaces",
+		"host_port_collision DaemonSet/legacy-exporter ": "Host port 9100/TCP is also claimed by DaemonSet/metrics/node-exporter, so their pods cannot run on the same node",
+	}
+	for key, want := range wantMessages {
+		if messages[key] != want {
+			t.Errorf("%s message = %q, want %q", key, messages[key], want)
+		}
+	}
+}
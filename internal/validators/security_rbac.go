@@ -0,0 +1,209 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// privilegeEscalationVerbs are the RBAC verbs that let a subject gain permissions it was
+// not granted: escalate and bind on roles, and impersonate on users, groups and
+// ServiceAccounts
+var privilegeEscalationVerbs = []string{"bind", "escalate", "impersonate"}
+
+// roleRules holds the rules of the Roles and ClusterRoles in the cluster, so bindings
+// can be checked against what they actually grant
+type roleRules struct {
+	roles        map[string][]rbacv1.PolicyRule
+	clusterRoles map[string][]rbacv1.PolicyRule
+}
+
+// listRoleRules lists the rules of all Roles and ClusterRoles
+func (v *SecurityValidator) listRoleRules(ctx context.Context) (*roleRules, error) {
+	var roles rbacv1.RoleList
+	if err := v.client.List(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	var clusterRoles rbacv1.ClusterRoleList
+	if err := v.client.List(ctx, &clusterRoles); err != nil {
+		return nil, fmt.Errorf("failed to list clusterroles: %w", err)
+	}
+
+	rules := &roleRules{
+		roles:        make(map[string][]rbacv1.PolicyRule),
+		clusterRoles: make(map[string][]rbacv1.PolicyRule),
+	}
+	for _, role := range roles.Items {
+		rules.roles[role.Namespace+"/"+role.Name] = role.Rules
+	}
+	for _, clusterRole := range clusterRoles.Items {
+		rules.clusterRoles[clusterRole.Name] = clusterRole.Rules
+	}
+	return rules, nil
+}
+
+// lookup returns the rules of the role a binding in namespace refers to. ClusterRoleBindings
+// pass an empty namespace.
+func (r *roleRules) lookup(roleRef rbacv1.RoleRef, namespace string) ([]rbacv1.PolicyRule, bool) {
+	if roleRef.Kind == "ClusterRole" {
+		rules, ok := r.clusterRoles[roleRef.Name]
+		return rules, ok
+	}
+	rules, ok := r.roles[namespace+"/"+roleRef.Name]
+	return rules, ok
+}
+
+// validateBoundRules reports the dangerous permissions a ServiceAccount is granted by the
+// rules of the role a binding refers to: wildcard verbs or resources, read access to
+// Secrets across the cluster, verbs that escalate privileges, and creating or exec-ing
+// into pods. Well-known administrative roles are reported by name instead, and roles
+// that do not exist are left to reference validation.
+func (v *SecurityValidator) validateBoundRules(sa corev1.ServiceAccount, bindingKind, bindingName string, roleRef rbacv1.RoleRef, rules *roleRules) []ValidationError {
+	if v.isDangerousRole(roleRef.Name) {
+		return nil
+	}
+	namespace := sa.Namespace
+	clusterWide := bindingKind == "ClusterRoleBinding"
+	if clusterWide {
+		namespace = ""
+	}
+	policyRules, ok := rules.lookup(roleRef, namespace)
+	if !ok {
+		return nil
+	}
+
+	scope := "in namespace " + sa.Namespace
+	if clusterWide {
+		scope = "cluster-wide"
+	}
+	grant := fmt.Sprintf("%s '%s' bound by %s '%s'", roleRef.Kind, roleRef.Name, bindingKind, bindingName)
+	newError := func(validationType, message string) ValidationError {
+		errorCode := GetSecurityErrorCode(validationType, nil)
+		return NewValidationErrorWithCode("ServiceAccount", sa.Name, sa.Namespace, validationType, errorCode, message).
+			WithRelatedResources(fmt.Sprintf("%s/%s", bindingKind, bindingName), fmt.Sprintf("%s/%s", roleRef.Kind, roleRef.Name)).
+			WithDetail("binding_kind", bindingKind).
+			WithDetail("binding_name", bindingName).
+			WithDetail("role_kind", roleRef.Kind).
+			WithDetail("role_name", roleRef.Name).
+			WithDetail("scope", scope)
+	}
+
+	var errors []ValidationError
+
+	if wildcards := wildcardRules(policyRules); len(wildcards) > 0 {
+		errors = append(errors, newError("serviceaccount_wildcard_permissions",
+			fmt.Sprintf("ServiceAccount is granted wildcard permissions %s by %s: %s", scope, grant, strings.Join(wildcards, "; "))).
+			WithSeverity(SeverityError).
+			WithRemediationHint("List the verbs and resources the workload needs instead of '*'; wildcards also grant resources and verbs added to the cluster later").
+			WithDetail("permissions", strings.Join(wildcards, "; ")).
+			WithDetail("security_risk", "wildcard_permissions"))
+	}
+
+	if clusterWide {
+		if verbs := permittedVerbs(policyRules, "", "secrets", readVerbs); len(verbs) > 0 {
+			errors = append(errors, newError("serviceaccount_cluster_secrets_access",
+				fmt.Sprintf("ServiceAccount can %s Secrets in every namespace through %s", strings.Join(verbs, ", "), grant)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Bind the role with RoleBindings in the namespaces whose Secrets the workload needs, or restrict it to named Secrets with resourceNames").
+				WithDetail("verbs", strings.Join(verbs, ",")).
+				WithDetail("security_risk", "cluster_wide_secret_access"))
+		}
+	}
+
+	if verbs := escalationVerbs(policyRules); len(verbs) > 0 {
+		errors = append(errors, newError("serviceaccount_privilege_escalation_verbs",
+			fmt.Sprintf("ServiceAccount is granted the %s verbs %s by %s, which let it gain permissions beyond its own", strings.Join(verbs, ", "), scope, grant)).
+			WithSeverity(SeverityError).
+			WithRemediationHint("Remove the escalate, bind and impersonate verbs unless the workload manages RBAC or acts on behalf of users, and restrict them with resourceNames where it does").
+			WithDetail("verbs", strings.Join(verbs, ",")).
+			WithDetail("security_risk", "privilege_escalation"))
+	}
+
+	var podAccess []string
+	if permits(policyRules, "", "pods", "create") {
+		podAccess = append(podAccess, "create pods")
+	}
+	if permits(policyRules, "", "pods/exec", "create") || permits(policyRules, "", "pods/exec", "get") {
+		podAccess = append(podAccess, "exec into pods")
+	}
+	if len(podAccess) > 0 {
+		errors = append(errors, newError("serviceaccount_pod_exec_permissions",
+			fmt.Sprintf("ServiceAccount can %s %s through %s", strings.Join(podAccess, " and "), scope, grant)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Remove pod create and pods/exec permissions unless the workload is a controller or operator that needs them; they allow running code with any ServiceAccount and Secret in scope").
+			WithDetail("permissions", strings.Join(podAccess, ",")).
+			WithDetail("security_risk", "pod_execution"))
+	}
+
+	return errors
+}
+
+// wildcardRules formats the rules using '*' for verbs or resources, such as
+// "* on deployments.apps"
+func wildcardRules(rules []rbacv1.PolicyRule) []string {
+	var wildcards []string
+	for _, rule := range rules {
+		if !matchesWildcard(rule.Verbs) && !matchesWildcard(rule.Resources) {
+			continue
+		}
+		var resources []string
+		for _, group := range rule.APIGroups {
+			for _, resource := range rule.Resources {
+				resources = append(resources, qualifiedResource(group, resource))
+			}
+		}
+		wildcards = append(wildcards, fmt.Sprintf("%s on %s", strings.Join(rule.Verbs, ","), strings.Join(resources, ",")))
+	}
+	return wildcards
+}
+
+// matchesWildcard reports whether values contain the RBAC wildcard
+func matchesWildcard(values []string) bool {
+	for _, value := range values {
+		if value == rbacv1.ResourceAll {
+			return true
+		}
+	}
+	return false
+}
+
+// permittedVerbs returns the verbs of candidates that rules grant on resource of group
+func permittedVerbs(rules []rbacv1.PolicyRule, group, resource string, candidates []string) []string {
+	var verbs []string
+	for _, verb := range candidates {
+		if permits(rules, group, resource, verb) {
+			verbs = append(verbs, verb)
+		}
+	}
+	return verbs
+}
+
+// escalationVerbs returns the privilege escalation verbs rules name explicitly. Wildcard
+// verbs grant them too but are reported as wildcard permissions.
+func escalationVerbs(rules []rbacv1.PolicyRule) []string {
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		for _, verb := range rule.Verbs {
+			for _, escalation := range privilegeEscalationVerbs {
+				if verb == escalation {
+					seen[verb] = true
+				}
+			}
+		}
+	}
+	var verbs []string
+	for verb := range seen {
+		verbs = append(verbs, verb)
+	}
+	sort.Strings(verbs)
+	return verbs
+}
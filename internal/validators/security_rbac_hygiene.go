@@ -0,0 +1,205 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// systemRBACPrefix prefixes the names of the roles, bindings, users and groups the
+// Kubernetes control plane manages
+const systemRBACPrefix = "system:"
+
+// validateRBACHygiene reports RBAC objects that grant nothing useful or cannot be checked:
+// aggregated ClusterRoles whose selectors match no ClusterRole or aggregate in a loop,
+// Roles no RoleBinding references, and bindings to users and groups, which exist only in
+// the cluster's authenticator and cannot be verified
+func (v *SecurityValidator) validateRBACHygiene(ctx context.Context) ([]ValidationError, error) {
+	var clusterRoles rbacv1.ClusterRoleList
+	if err := v.client.List(ctx, &clusterRoles); err != nil {
+		return nil, fmt.Errorf("failed to list clusterroles: %w", err)
+	}
+	var roles rbacv1.RoleList
+	if err := v.client.List(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	var roleBindings rbacv1.RoleBindingList
+	if err := v.client.List(ctx, &roleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list rolebindings: %w", err)
+	}
+	var clusterRoleBindings rbacv1.ClusterRoleBindingList
+	if err := v.client.List(ctx, &clusterRoleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list clusterrolebindings: %w", err)
+	}
+
+	var errors []ValidationError
+	errors = append(errors, v.validateClusterRoleAggregation(clusterRoles.Items)...)
+	errors = append(errors, v.validateUnusedRoles(roles.Items, roleBindings.Items)...)
+
+	for _, rb := range roleBindings.Items {
+		if v.sharedConfig.IsSecurityExcludedNamespace(rb.Namespace) {
+			continue
+		}
+		if finding, ok := unverifiableSubjectsError("RoleBinding", rb.Name, rb.Namespace, rb.Subjects); ok {
+			errors = append(errors, finding)
+		}
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		if strings.HasPrefix(crb.Name, systemRBACPrefix) {
+			continue
+		}
+		if finding, ok := unverifiableSubjectsError("ClusterRoleBinding", crb.Name, "", crb.Subjects); ok {
+			errors = append(errors, finding)
+		}
+	}
+
+	return errors, nil
+}
+
+// validateClusterRoleAggregation reports aggregated ClusterRoles whose selectors match
+// no other ClusterRole, which therefore grant nothing, and ClusterRoles that aggregate
+// themselves directly or through other aggregated ClusterRoles
+func (v *SecurityValidator) validateClusterRoleAggregation(clusterRoles []rbacv1.ClusterRole) []ValidationError {
+	aggregates := make(map[string][]string)
+	for _, clusterRole := range clusterRoles {
+		if clusterRole.AggregationRule == nil {
+			continue
+		}
+		var matched []string
+		for _, labelSelector := range clusterRole.AggregationRule.ClusterRoleSelectors {
+			selector, err := metav1.LabelSelectorAsSelector(&labelSelector)
+			if err != nil || selector.Empty() {
+				continue
+			}
+			for _, candidate := range clusterRoles {
+				if selector.Matches(labels.Set(candidate.Labels)) {
+					matched = append(matched, candidate.Name)
+				}
+			}
+		}
+		aggregates[clusterRole.Name] = matched
+	}
+
+	var names []string
+	for name := range aggregates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errors []ValidationError
+	for _, name := range names {
+		if strings.HasPrefix(name, systemRBACPrefix) {
+			continue
+		}
+		matched := aggregates[name]
+		if len(matched) == 0 {
+			errorCode := GetSecurityErrorCode("clusterrole_aggregation_matches_nothing", nil)
+			errors = append(errors, NewValidationErrorWithCode("ClusterRole", name, "", "clusterrole_aggregation_matches_nothing", errorCode,
+				"ClusterRole aggregation selectors match no ClusterRole, so it grants no permissions").
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Fix the aggregationRule selectors to match the labels of the ClusterRoles to aggregate, or delete the ClusterRole if nothing provides them any more").
+				WithDetail("security_risk", "unused_permissions"))
+			continue
+		}
+		if cycle := aggregationCycle(name, aggregates); len(cycle) > 0 {
+			errorCode := GetSecurityErrorCode("clusterrole_aggregation_loop", nil)
+			relatedResources := make([]string, 0, len(cycle))
+			for _, member := range cycle[1:] {
+				relatedResources = append(relatedResources, "ClusterRole/"+member)
+			}
+			errors = append(errors, NewValidationErrorWithCode("ClusterRole", name, "", "clusterrole_aggregation_loop", errorCode,
+				fmt.Sprintf("ClusterRole aggregates itself: %s", strings.Join(cycle, " -> "))).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Remove the aggregation labels that make an aggregated ClusterRole match its own selectors; the controller keeps merging the roles into each other and their rules only ever grow").
+				WithRelatedResources(relatedResources...).
+				WithDetail("aggregation_path", strings.Join(cycle, " -> ")))
+		}
+	}
+	return errors
+}
+
+// aggregationCycle returns the path by which a ClusterRole aggregates itself, starting
+// and ending with name, or nil when it does not
+func aggregationCycle(name string, aggregates map[string][]string) []string {
+	visited := make(map[string]bool)
+	var walk func(current string, path []string) []string
+	walk = func(current string, path []string) []string {
+		for _, next := range aggregates[current] {
+			if next == name {
+				return append(append([]string{}, path...), next)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if cycle := walk(next, append(path, next)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+	return walk(name, []string{name})
+}
+
+// validateUnusedRoles reports Roles that no RoleBinding in their namespace references
+func (v *SecurityValidator) validateUnusedRoles(roles []rbacv1.Role, roleBindings []rbacv1.RoleBinding) []ValidationError {
+	referenced := make(map[string]bool)
+	for _, rb := range roleBindings {
+		if rb.RoleRef.Kind == "Role" {
+			referenced[rb.Namespace+"/"+rb.RoleRef.Name] = true
+		}
+	}
+
+	var errors []ValidationError
+	for _, role := range roles {
+		if v.sharedConfig.IsSecurityExcludedNamespace(role.Namespace) || strings.HasPrefix(role.Name, systemRBACPrefix) {
+			continue
+		}
+		if referenced[role.Namespace+"/"+role.Name] {
+			continue
+		}
+		errorCode := GetSecurityErrorCode("unused_role", nil)
+		errors = append(errors, NewValidationErrorWithCode("Role", role.Name, role.Namespace, "unused_role", errorCode,
+			"Role is not referenced by any RoleBinding").
+			WithSeverity(SeverityInfo).
+			WithRemediationHint("Delete the Role if it is no longer needed; unused roles make it harder to review who can do what and can be bound again unnoticed").
+			WithDetail("rule_count", fmt.Sprintf("%d", len(role.Rules))))
+	}
+	return errors
+}
+
+// unverifiableSubjectsError reports the users and groups a binding grants its role to.
+// They are defined by the cluster's authenticator rather than the API, so Kogaro cannot
+// tell whether they still exist. Control plane users and groups are not reported.
+func unverifiableSubjectsError(bindingKind, bindingName, namespace string, subjects []rbacv1.Subject) (ValidationError, bool) {
+	var unverifiable []string
+	for _, subject := range subjects {
+		if subject.Kind != rbacv1.UserKind && subject.Kind != rbacv1.GroupKind {
+			continue
+		}
+		if strings.HasPrefix(subject.Name, systemRBACPrefix) {
+			continue
+		}
+		unverifiable = append(unverifiable, fmt.Sprintf("%s/%s", subject.Kind, subject.Name))
+	}
+	if len(unverifiable) == 0 {
+		return ValidationError{}, false
+	}
+
+	errorCode := GetSecurityErrorCode("binding_unverifiable_subject", nil)
+	return NewValidationErrorWithCode(bindingKind, bindingName, namespace, "binding_unverifiable_subject", errorCode,
+		fmt.Sprintf("%s grants its role to subjects that cannot be verified in the cluster: %s", bindingKind, strings.Join(unverifiable, ", "))).
+		WithSeverity(SeverityInfo).
+		WithRemediationHint("Check with your identity provider that these users and groups still exist and still need this access; bindings outlive the accounts they were created for").
+		WithDetail("subjects", strings.Join(unverifiable, ",")), true
+}
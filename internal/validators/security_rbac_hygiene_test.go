@@ -0,0 +1,109 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSecurityValidator_ValidateRBACHygiene(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	aggregated := func(name string, selector map[string]string, labels map[string]string) *rbacv1.ClusterRole {
+		return &rbacv1.ClusterRole{
+			ObjectMeta:      metav1.ObjectMeta{Name: name, Labels: labels},
+			AggregationRule: &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{{MatchLabels: selector}}},
+		}
+	}
+	role := func(name string) *rbacv1.Role {
+		return &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"}}
+	}
+
+	objects := []client.Object{
+		// Aggregates a labelled ClusterRole
+		aggregated("reporting", map[string]string{"rbac.example.com/aggregate-to-reporting": "true"}, nil),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "reporting-reader", Labels: map[string]string{"rbac.example.com/aggregate-to-reporting": "true"}}},
+		// Nothing carries the label any more
+		aggregated("legacy", map[string]string{"rbac.example.com/aggregate-to-legacy": "true"}, nil),
+		// Each aggregates the other
+		aggregated("monitoring", map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"},
+			map[string]string{"rbac.example.com/aggregate-to-monitoring-extra": "true"}),
+		aggregated("monitoring-extra", map[string]string{"rbac.example.com/aggregate-to-monitoring-extra": "true"},
+			map[string]string{"rbac.example.com/aggregate-to-monitoring": "true"}),
+
+		role("deployer"),
+		role("leftover"),
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "deployer", Namespace: "shop"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "deployer"},
+				{Kind: rbacv1.UserKind, Name: "alice@example.com"},
+				{Kind: rbacv1.GroupKind, Name: "contractors"},
+				{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:shop"},
+			},
+			RoleRef: rbacv1.RoleRef{Kind: "Role", Name: "deployer"},
+		},
+		// Managed by the control plane
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "system:basic-user"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "system:basic-user"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{EnableRBACHygieneValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	findings := make(map[string]ValidationError)
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		key := finding.ValidationType + " " + finding.ResourceType + "/" + finding.ResourceName
+		findings[key] = finding
+		got = append(got, key)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"binding_unverifiable_subject RoleBinding/deployer",
+		"clusterrole_aggregation_loop ClusterRole/monitoring",
+		"clusterrole_aggregation_loop ClusterRole/monitoring-extra",
+		"clusterrole_aggregation_matches_nothing ClusterRole/legacy",
+		"unused_role Role/leftover",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	loop := findings["clusterrole_aggregation_loop ClusterRole/monitoring"]
+	if loop.Details["aggregation_path"] != "monitoring -> monitoring-extra -> monitoring" {
+		t.Errorf("aggregation_path = %q", loop.Details["aggregation_path"])
+	}
+	subjects := findings["binding_unverifiable_subject RoleBinding/deployer"]
+	if subjects.Details["subjects"] != "User/alice@example.com,Group/contractors" {
+		t.Errorf("subjects = %q, want the user and the non-system group", subjects.Details["subjects"])
+	}
+}
@@ -9,11 +9,14 @@
 // a Kubernetes cluster, detecting security misconfigurations that could
 // expose workloads to unnecessary risk. It validates SecurityContext settings,
 // root privilege usage, ServiceAccount permissions, and NetworkPolicy coverage.
+// ServiceAccount permissions are checked against the rules of the roles they are
+// bound to, not only the roles' names.
 package validators
 
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -37,6 +40,17 @@ type SecurityConfig struct {
 	EnableSecurityContextValidation bool
 	EnableServiceAccountValidation  bool
 	EnableNetworkPolicyValidation   bool
+	// Enable detection of unused Roles, aggregated ClusterRoles matching nothing and
+	// bindings to users and groups
+	EnableRBACHygieneValidation bool
+	// Enable detection of hostPath volumes mounting sensitive host paths
+	EnableHostPathValidation bool
+	// Enable detection of hostNetwork, hostPID and hostIPC outside the allowed namespaces
+	EnableHostNamespaceValidation bool
+	// Enable detection of DaemonSets claiming the same host port
+	EnableHostPortValidation bool
+	// Namespaces whose workloads may use the host's network, PID and IPC namespaces
+	HostNamespaceAllowedNamespaces []string
 	// Namespaces that require NetworkPolicies for security compliance
 	SecuritySensitiveNamespaces []string
 }
@@ -49,6 +63,9 @@ type SecurityValidator struct {
 	sharedConfig         SharedConfig
 	lastValidationErrors []ValidationError
 	logReceiver          LogReceiver
+	// Findings of workloads' pod templates by resourceVersion, reused while the
+	// workloads are unchanged
+	cache *resultCache
 }
 
 // NewSecurityValidator creates a new SecurityValidator with the given client, logger and config
@@ -57,7 +74,8 @@ func NewSecurityValidator(client client.Client, log logr.Logger, config Security
 		client:       client,
 		log:          log.WithName("security-validator"),
 		config:       config,
-		sharedConfig: DefaultSharedConfig(),
+		sharedConfig: ActiveSharedConfig(),
+		cache:        newResultCache("security"),
 	}
 }
 
@@ -84,6 +102,7 @@ func (v *SecurityValidator) GetValidationType() string {
 // ValidateCluster performs comprehensive validation of security configurations across the entire cluster
 func (v *SecurityValidator) ValidateCluster(ctx context.Context) error {
 	metrics.ValidationRuns.Inc()
+	v.cache.beginScan()
 
 	var allErrors []ValidationError
 
@@ -123,6 +142,24 @@ func (v *SecurityValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, serviceAccountErrors...)
 	}
 
+	// Validate host access
+	if v.config.EnableHostPathValidation || v.config.EnableHostNamespaceValidation || v.config.EnableHostPortValidation {
+		hostAccessErrors, err := v.validateHostAccess(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate host access: %w", err)
+		}
+		allErrors = append(allErrors, hostAccessErrors...)
+	}
+
+	// Validate RBAC hygiene
+	if v.config.EnableRBACHygieneValidation {
+		rbacErrors, err := v.validateRBACHygiene(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate rbac hygiene: %w", err)
+		}
+		allErrors = append(allErrors, rbacErrors...)
+	}
+
 	// Validate NetworkPolicy coverage
 	if v.config.EnableNetworkPolicyValidation {
 		networkPolicyErrors, err := v.validateNetworkPolicyCoverage(ctx)
@@ -132,6 +169,8 @@ func (v *SecurityValidator) ValidateCluster(ctx context.Context) error {
 		allErrors = append(allErrors, networkPolicyErrors...)
 	}
 
+	v.cache.endScan()
+
 	// Log all validation errors and update metrics
 	LogAndRecordErrors(v.logReceiver, "security", allErrors)
 
@@ -155,7 +194,9 @@ func (v *SecurityValidator) validateDeploymentSecurity(ctx context.Context) ([]V
 		if v.sharedConfig.IsSecurityExcludedNamespace(deployment.Namespace) {
 			continue
 		}
-		securityErrors := v.validatePodTemplateSecurity(deployment.Spec.Template, "Deployment", deployment.Name, deployment.Namespace)
+		securityErrors := v.cache.findings(ctx, "Deployment", &deployment, func() []ValidationError {
+			return v.validatePodTemplateSecurity(deployment.Spec.Template, "Deployment", deployment.Name, deployment.Namespace)
+		})
 		errors = append(errors, securityErrors...)
 	}
 
@@ -175,7 +216,9 @@ func (v *SecurityValidator) validateStatefulSetSecurity(ctx context.Context) ([]
 		if v.sharedConfig.IsSecurityExcludedNamespace(statefulSet.Namespace) {
 			continue
 		}
-		securityErrors := v.validatePodTemplateSecurity(statefulSet.Spec.Template, "StatefulSet", statefulSet.Name, statefulSet.Namespace)
+		securityErrors := v.cache.findings(ctx, "StatefulSet", &statefulSet, func() []ValidationError {
+			return v.validatePodTemplateSecurity(statefulSet.Spec.Template, "StatefulSet", statefulSet.Name, statefulSet.Namespace)
+		})
 		errors = append(errors, securityErrors...)
 	}
 
@@ -195,37 +238,60 @@ func (v *SecurityValidator) validateDaemonSetSecurity(ctx context.Context) ([]Va
 		if v.sharedConfig.IsSecurityExcludedNamespace(daemonSet.Namespace) {
 			continue
 		}
-		securityErrors := v.validatePodTemplateSecurity(daemonSet.Spec.Template, "DaemonSet", daemonSet.Name, daemonSet.Namespace)
+		securityErrors := v.cache.findings(ctx, "DaemonSet", &daemonSet, func() []ValidationError {
+			securityErrors := v.validatePodTemplateSecurity(daemonSet.Spec.Template, "DaemonSet", daemonSet.Name, daemonSet.Namespace)
+			if HasHostAccessReason(daemonSet.ObjectMeta) {
+				securityErrors = toleratePrivilegedContainers(securityErrors)
+			}
+			return securityErrors
+		})
 		errors = append(errors, securityErrors...)
 	}
 
 	return errors, nil
 }
 
-func (v *SecurityValidator) validatePodSecurity(ctx context.Context) ([]ValidationError, error) {
-	var errors []ValidationError
-	var pods corev1.PodList
-
-	if err := v.client.List(ctx, &pods); err != nil {
-		return nil, fmt.Errorf("failed to list pods: %w", err)
+// toleratePrivilegedContainers drops the privileged mode findings of a node agent that
+// documents why it needs host access, including the privilege escalation that
+// privileged mode implies
+func toleratePrivilegedContainers(securityErrors []ValidationError) []ValidationError {
+	tolerated := securityErrors[:0]
+	for _, securityError := range securityErrors {
+		switch {
+		case securityError.ValidationType == "container_privileged_mode":
+			continue
+		case securityError.ValidationType == "container_allows_privilege_escalation" && securityError.ErrorCode == GetSecurityErrorCode("container_allows_privilege_escalation", map[string]interface{}{"is_privileged": true}):
+			continue
+		}
+		tolerated = append(tolerated, securityError)
 	}
+	return tolerated
+}
 
-	for _, pod := range pods.Items {
+func (v *SecurityValidator) validatePodSecurity(ctx context.Context) ([]ValidationError, error) {
+	var errors []ValidationError
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
 		// Skip system namespaces
 		if v.sharedConfig.IsSecurityExcludedNamespace(pod.Namespace) {
-			continue
+			return nil
 		}
 
 		// Skip pods managed by controllers (they're validated via their controllers)
-		if utils.HasOwnerReferences(pod) {
-			continue
+		if utils.HasOwnerReferences(*pod) {
+			return nil
 		}
 
-		podTemplate := corev1.PodTemplateSpec{
-			Spec: pod.Spec,
-		}
-		securityErrors := v.validatePodTemplateSecurity(podTemplate, "Pod", pod.Name, pod.Namespace)
+		securityErrors := v.cache.findings(ctx, "Pod", pod, func() []ValidationError {
+			podTemplate := corev1.PodTemplateSpec{
+				Spec: pod.Spec,
+			}
+			return v.validatePodTemplateSecurity(podTemplate, "Pod", pod.Name, pod.Namespace)
+		})
 		errors = append(errors, securityErrors...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
 	return errors, nil
@@ -243,7 +309,9 @@ func (v *SecurityValidator) validatePodTemplateSecurity(template corev1.PodTempl
 				WithRemediationHint(fmt.Sprintf("Add a SecurityContext with runAsNonRoot: true, runAsUser: %d, runAsGroup: %d, and fsGroup: %d", v.sharedConfig.DefaultSecurityContext.RecommendedUserID, v.sharedConfig.DefaultSecurityContext.RecommendedGroupID, v.sharedConfig.DefaultSecurityContext.RecommendedFSGroup)).
 				WithRelatedResources("SecurityContext/pod-security-context").
 				WithDetail("resource_type", resourceType).
-				WithDetail("recommended_user_id", fmt.Sprintf("%d", v.sharedConfig.DefaultSecurityContext.RecommendedUserID)))
+				WithDetail("recommended_user_id", fmt.Sprintf("%d", v.sharedConfig.DefaultSecurityContext.RecommendedUserID)).
+				WithDetail("recommended_group_id", fmt.Sprintf("%d", v.sharedConfig.DefaultSecurityContext.RecommendedGroupID)).
+				WithDetail("recommended_fs_group", fmt.Sprintf("%d", v.sharedConfig.DefaultSecurityContext.RecommendedFSGroup)))
 		} else {
 			// Check for Pod-level security settings
 			podSecurityErrors := v.validatePodSecurityContext(template.Spec.SecurityContext, resourceType, resourceName, namespace)
@@ -290,6 +358,23 @@ func (v *SecurityValidator) validatePodSecurityContext(securityContext *corev1.P
 		}
 	}
 
+	if v.config.EnableSecurityContextValidation {
+		// Check for sysctls that are not namespaced and affect the whole node
+		for _, sysctl := range securityContext.Sysctls {
+			if safeSysctls[sysctl.Name] {
+				continue
+			}
+			errorCode := GetSecurityErrorCode("pod_unsafe_sysctl", nil)
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "pod_unsafe_sysctl", errorCode, fmt.Sprintf("Pod SecurityContext sets unsafe sysctl %s=%s", sysctl.Name, sysctl.Value)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Remove the sysctl, or move the workload to dedicated nodes whose kubelet allows it with --allowed-unsafe-sysctls; unsafe sysctls can affect other pods on the node").
+				WithRelatedResources("SecurityContext/pod-security-context").
+				WithDetail("sysctl", sysctl.Name).
+				WithDetail("value", sysctl.Value).
+				WithDetail("security_risk", "node_wide_kernel_settings"))
+		}
+	}
+
 	return errors
 }
 
@@ -399,11 +484,67 @@ func (v *SecurityValidator) validateContainerSecurityContext(securityContext *co
 					WithDetail("recommended_action", "drop_all_capabilities"))
 			}
 		}
+
+		// Check that all capabilities are dropped
+		if !dropsAllCapabilities(securityContext.Capabilities) {
+			errorCode := GetSecurityErrorCode("container_capabilities_not_dropped", nil)
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "container_capabilities_not_dropped", errorCode, fmt.Sprintf("Container '%s' (%s) SecurityContext does not drop all capabilities", containerName, containerType)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint("Set capabilities.drop: ['ALL'] in the container SecurityContext and add back only the capabilities the container needs").
+				WithRelatedResources(fmt.Sprintf("Container/%s", containerName)).
+				WithDetail("container_name", containerName).
+				WithDetail("container_type", containerType).
+				WithDetail("security_risk", "default_capabilities").
+				WithDetail("recommended_setting", "capabilities.drop: [ALL]"))
+		}
+
+		// Check for unmasked /proc
+		if securityContext.ProcMount != nil && *securityContext.ProcMount != corev1.DefaultProcMount {
+			errorCode := GetSecurityErrorCode("container_unmasked_proc_mount", nil)
+			errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "container_unmasked_proc_mount", errorCode, fmt.Sprintf("Container '%s' (%s) SecurityContext specifies procMount: %s", containerName, containerType, *securityContext.ProcMount)).
+				WithSeverity(SeverityError).
+				WithRemediationHint("Remove procMount or set it to Default so that the container runtime masks sensitive paths under /proc").
+				WithRelatedResources(fmt.Sprintf("Container/%s", containerName)).
+				WithDetail("container_name", containerName).
+				WithDetail("container_type", containerType).
+				WithDetail("current_setting", fmt.Sprintf("procMount: %s", *securityContext.ProcMount)).
+				WithDetail("security_risk", "host_information_exposure"))
+		}
 	}
 
 	return errors
 }
 
+// safeSysctls are the sysctls Kubernetes considers safe: they are namespaced, isolated
+// between pods on the same node and allowed by every kubelet
+var safeSysctls = map[string]bool{
+	"kernel.shm_rmid_forced":              true,
+	"net.ipv4.ip_local_port_range":        true,
+	"net.ipv4.ip_local_reserved_ports":    true,
+	"net.ipv4.ip_unprivileged_port_start": true,
+	"net.ipv4.ping_group_range":           true,
+	"net.ipv4.tcp_fin_timeout":            true,
+	"net.ipv4.tcp_keepalive_intvl":        true,
+	"net.ipv4.tcp_keepalive_probes":       true,
+	"net.ipv4.tcp_keepalive_time":         true,
+	"net.ipv4.tcp_rmem":                   true,
+	"net.ipv4.tcp_syncookies":             true,
+	"net.ipv4.tcp_wmem":                   true,
+}
+
+// dropsAllCapabilities reports whether capabilities drop ALL
+func dropsAllCapabilities(capabilities *corev1.Capabilities) bool {
+	if capabilities == nil {
+		return false
+	}
+	for _, capability := range capabilities.Drop {
+		if strings.EqualFold(string(capability), "ALL") {
+			return true
+		}
+	}
+	return false
+}
+
 func (v *SecurityValidator) validateServiceAccountPermissions(ctx context.Context) ([]ValidationError, error) {
 	var errors []ValidationError
 
@@ -424,6 +565,11 @@ func (v *SecurityValidator) validateServiceAccountPermissions(ctx context.Contex
 		return nil, fmt.Errorf("failed to list clusterrolebindings: %w", err)
 	}
 
+	rules, err := v.listRoleRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check for ServiceAccounts with potentially excessive permissions
 	for _, sa := range serviceAccounts.Items {
 		// Skip system namespaces
@@ -449,6 +595,7 @@ func (v *SecurityValidator) validateServiceAccountPermissions(ctx context.Contex
 						WithDetail("cluster_role", crb.RoleRef.Name).
 						WithDetail("security_risk", "cluster_wide_permissions").
 						WithDetail("recommended_scope", "namespace_scoped"))
+					errors = append(errors, v.validateBoundRules(sa, "ClusterRoleBinding", crb.Name, crb.RoleRef, rules)...)
 				}
 			}
 		}
@@ -473,6 +620,7 @@ func (v *SecurityValidator) validateServiceAccountPermissions(ctx context.Contex
 							WithDetail("security_risk", "excessive_permissions").
 							WithDetail("principle", "least_privilege"))
 					}
+					errors = append(errors, v.validateBoundRules(sa, "RoleBinding", rb.Name, rb.RoleRef, rules)...)
 				}
 			}
 		}
@@ -485,63 +633,26 @@ func (v *SecurityValidator) isDangerousRole(roleName string) bool {
 	return v.sharedConfig.IsDangerousRole(roleName)
 }
 
+// validateNetworkPolicyCoverage reports the security-sensitive and production-like
+// namespaces without NetworkPolicies
 func (v *SecurityValidator) validateNetworkPolicyCoverage(ctx context.Context) ([]ValidationError, error) {
-	var errors []ValidationError
-
-	// Get all NetworkPolicies
 	var networkPolicies networkingv1.NetworkPolicyList
 	if err := v.client.List(ctx, &networkPolicies); err != nil {
 		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
 	}
 
-	// Create a map of namespaces that have NetworkPolicies
-	namespacesWithPolicies := make(map[string]bool)
-	for _, np := range networkPolicies.Items {
-		namespacesWithPolicies[np.Namespace] = true
-	}
-
-	// Check if security-sensitive namespaces have NetworkPolicies
-	for _, sensitiveNamespace := range v.config.SecuritySensitiveNamespaces {
-		if !namespacesWithPolicies[sensitiveNamespace] {
-			errors = append(errors, NewValidationError("Namespace", sensitiveNamespace, sensitiveNamespace, "missing_network_policy_security_sensitive", fmt.Sprintf("Security-sensitive namespace '%s' has no NetworkPolicies defined", sensitiveNamespace)).
-				WithSeverity(SeverityError).
-				WithRemediationHint("Create NetworkPolicies to implement default-deny ingress/egress rules and explicitly allow required traffic").
-				WithRelatedResources("NetworkPolicy/default-deny-all").
-				WithDetail("namespace_type", "security_sensitive").
-				WithDetail("security_risk", "unrestricted_network_access").
-				WithDetail("recommended_policy", "default_deny_with_explicit_allow"))
-		}
-	}
-
-	// Get all namespaces and check for production-like namespaces without policies
+	// Get all namespaces to resolve patterns and label selectors and to find
+	// production-like namespaces without policies
 	var namespaces corev1.NamespaceList
 	if err := v.client.List(ctx, &namespaces); err != nil {
 		return nil, fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
-	for _, ns := range namespaces.Items {
-		// Skip system namespaces
-		if v.sharedConfig.IsSecurityExcludedNamespace(ns.Name) {
-			continue
-		}
-
-		// Check if this looks like a production namespace without NetworkPolicies
-		if v.isProductionLikeNamespace(ns.Name) && !namespacesWithPolicies[ns.Name] {
-			errors = append(errors, NewValidationError("Namespace", ns.Name, ns.Name, "missing_network_policy_production", fmt.Sprintf("Production-like namespace '%s' has no NetworkPolicies defined", ns.Name)).
-				WithSeverity(SeverityError).
-				WithRemediationHint("Implement NetworkPolicies for production workloads with default-deny rules and specific ingress/egress allowlists").
-				WithRelatedResources("NetworkPolicy/production-default-deny").
-				WithDetail("namespace_type", "production_like").
-				WithDetail("security_risk", "production_traffic_exposure").
-				WithDetail("compliance_requirement", "network_segmentation"))
-		}
-	}
-
-	return errors, nil
-}
-
-func (v *SecurityValidator) isProductionLikeNamespace(namespace string) bool {
-	return v.sharedConfig.IsProductionLikeNamespace(namespace)
+	analyzer := NewNetworkPolicyAnalyzer(NetworkPolicyCoverage{
+		RequiredNamespaces: v.config.SecuritySensitiveNamespaces,
+		ProductionLike:     true,
+	}, v.sharedConfig)
+	return analyzer.Analyze(namespaces.Items, networkPolicies.Items)
 }
 
 // getSecurityErrorCode returns the appropriate error code for security validations
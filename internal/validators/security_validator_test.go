@@ -7,6 +7,8 @@ package validators
 
 import (
 	"context"
+	"sort"
+	"strings"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -699,3 +701,290 @@ func TestSecurityValidator_DaemonSetValidation(t *testing.T) {
 	}
 }
 
+
+func TestSecurityValidator_DaemonSetHostAccessReason(t *testing.T) {
+	daemonSet := func(name string, annotations map[string]string) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "test-ns",
+				Annotations: annotations,
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "agent",
+								Image: "agent:1.0",
+								SecurityContext: &corev1.SecurityContext{
+									Privileged: boolPtr(true),
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			daemonSet("undocumented-agent", nil),
+			daemonSet("node-agent", map[string]string{HostAccessReasonAnnotation: "loads eBPF programs on the node"}),
+		).
+		Build()
+
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{
+		EnableRootUserValidation:        true,
+		EnableSecurityContextValidation: true,
+	})
+	validator.SetLogReceiver(&MockLogReceiver{})
+
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	privilegedFindings := map[string]int{}
+	for _, validationError := range validator.GetLastValidationErrors() {
+		if validationError.ValidationType == "container_privileged_mode" ||
+			(validationError.ValidationType == "container_allows_privilege_escalation" && validationError.ErrorCode == "KOGARO-SEC-005") {
+			privilegedFindings[validationError.ResourceName]++
+		}
+	}
+
+	if privilegedFindings["undocumented-agent"] != 2 {
+		t.Errorf("got %d privileged findings for undocumented-agent, want 2", privilegedFindings["undocumented-agent"])
+	}
+	if privilegedFindings["node-agent"] != 0 {
+		t.Errorf("got %d privileged findings for annotated node-agent, want 0", privilegedFindings["node-agent"])
+	}
+}
+
+func TestSecurityValidator_ValidateBoundRoleRules(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	serviceAccount := func(name string) *corev1.ServiceAccount {
+		return &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"}}
+	}
+	role := func(name string, rules ...rbacv1.PolicyRule) *rbacv1.Role {
+		return &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"}, Rules: rules}
+	}
+	roleBinding := func(name, roleKind, roleName string) *rbacv1.RoleBinding {
+		return &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: name}},
+			RoleRef:    rbacv1.RoleRef{Kind: roleKind, Name: roleName},
+		}
+	}
+	rule := func(group, resource string, verbs ...string) rbacv1.PolicyRule {
+		return rbacv1.PolicyRule{APIGroups: []string{group}, Resources: []string{resource}, Verbs: verbs}
+	}
+
+	objects := []client.Object{
+		serviceAccount("deployer"),
+		role("deployer", rule("apps", "deployments", "*")),
+		roleBinding("deployer", "Role", "deployer"),
+
+		serviceAccount("rbac-manager"),
+		role("rbac-manager", rule("rbac.authorization.k8s.io", "roles", "get", "bind", "escalate")),
+		roleBinding("rbac-manager", "Role", "rbac-manager"),
+
+		serviceAccount("debugger"),
+		role("debugger", rule("", "pods", "get", "create"), rule("", "pods/exec", "create")),
+		roleBinding("debugger", "Role", "debugger"),
+
+		serviceAccount("secret-reader"),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "secret-reader"}, Rules: []rbacv1.PolicyRule{rule("", "secrets", "get", "list")}},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "secret-reader"},
+			Subjects:   []rbacv1.Subject{{Kind: "ServiceAccount", Name: "secret-reader", Namespace: "shop"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "secret-reader"},
+		},
+
+		// Reading Secrets in its own namespace only
+		serviceAccount("config-loader"),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "config-loader"}, Rules: []rbacv1.PolicyRule{rule("", "secrets", "get")}},
+		roleBinding("config-loader", "ClusterRole", "config-loader"),
+
+		// Reported by role name rather than by its rules
+		serviceAccount("admin"),
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: "admin"}, Rules: []rbacv1.PolicyRule{rule("*", "*", "*")}},
+		roleBinding("admin", "ClusterRole", "admin"),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{EnableServiceAccountValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	messages := make(map[string]string)
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		key := finding.ValidationType + " " + finding.ResourceName
+		messages[key] = finding.Message
+		got = append(got, key)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"serviceaccount_cluster_role_binding secret-reader",
+		"serviceaccount_cluster_secrets_access secret-reader",
+		"serviceaccount_excessive_permissions admin",
+		"serviceaccount_pod_exec_permissions debugger",
+		"serviceaccount_privilege_escalation_verbs rbac-manager",
+		"serviceaccount_wildcard_permissions deployer",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	wantMessages := map[string]string{
+		"serviceaccount_wildcard_permissions deployer":           "ServiceAccount is granted wildcard permissions in namespace shop by Role 'deployer' bound by RoleBinding 'deployer': * on deployments.apps",
+		"serviceaccount_cluster_secrets_access secret-reader":    "ServiceAccount can get, list Secrets in every namespace through ClusterRole 'secret-reader' bound by ClusterRoleBinding 'secret-reader'",
+		"serviceaccount_privilege_escalation_verbs rbac-manager": "ServiceAccount is granted the bind, escalate verbs in namespace shop by Role 'rbac-manager' bound by RoleBinding 'rbac-manager', which let it gain permissions beyond its own",
+		"serviceaccount_pod_exec_permissions debugger":           "ServiceAccount can create pods and exec into pods in namespace shop through Role 'debugger' bound by RoleBinding 'debugger'",
+	}
+	for key, want := range wantMessages {
+		if messages[key] != want {
+			t.Errorf("%s message = %q, want %q", key, messages[key], want)
+		}
+	}
+}
+
+func TestSecurityValidator_CapabilitiesSysctlsAndProcMount(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	unmasked := corev1.UnmaskedProcMount
+	deployment := func(name string, podSecurityContext *corev1.PodSecurityContext, securityContext *corev1.SecurityContext) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				SecurityContext: podSecurityContext,
+				Containers:      []corev1.Container{{Name: "app", SecurityContext: securityContext}},
+			}}},
+		}
+	}
+	dropAll := &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}}
+
+	objects := []client.Object{
+		deployment("hardened", &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{{Name: "net.ipv4.tcp_syncookies", Value: "1"}}},
+			&corev1.SecurityContext{Capabilities: dropAll}),
+		deployment("default-capabilities", &corev1.PodSecurityContext{},
+			&corev1.SecurityContext{Capabilities: &corev1.Capabilities{Drop: []corev1.Capability{"NET_RAW"}}}),
+		deployment("tuned", &corev1.PodSecurityContext{Sysctls: []corev1.Sysctl{{Name: "kernel.msgmax", Value: "65536"}}},
+			&corev1.SecurityContext{Capabilities: dropAll}),
+		deployment("unmasked", &corev1.PodSecurityContext{},
+			&corev1.SecurityContext{Capabilities: dropAll, ProcMount: &unmasked}),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewSecurityValidator(fakeClient, logr.Discard(), SecurityConfig{EnableSecurityContextValidation: true})
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		switch finding.ValidationType {
+		case "container_capabilities_not_dropped", "pod_unsafe_sysctl", "container_unmasked_proc_mount":
+			got = append(got, finding.ValidationType+" "+finding.ResourceName)
+		}
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"container_capabilities_not_dropped default-capabilities",
+		"container_unmasked_proc_mount unmasked",
+		"pod_unsafe_sysctl tuned",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+}
+
+func TestSecurityValidator_FindingsReachValidationResult(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	clusterClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}).
+		Build()
+
+	registry := NewValidatorRegistry(logr.Discard(), clusterClient)
+	registry.Register(NewSecurityValidator(nil, logr.Discard(), SecurityConfig{
+		EnableRootUserValidation:        true,
+		EnableSecurityContextValidation: true,
+		EnableNetworkPolicyValidation:   true,
+		SecuritySensitiveNamespaces:     []string{"payments"},
+	}))
+
+	config := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+  namespace: payments
+spec:
+  selector:
+    matchLabels:
+      app: api
+  template:
+    metadata:
+      labels:
+        app: api
+    spec:
+      securityContext:
+        runAsUser: 0
+        runAsNonRoot: true
+      containers:
+      - name: api
+        image: api:1.0
+`)
+
+	result, err := registry.ValidateNewConfigWithScopeAndData(context.Background(), "-", "all", config)
+	if err != nil {
+		t.Fatalf("ValidateNewConfigWithScopeAndData() error = %v", err)
+	}
+
+	codes := make(map[string]string)
+	for _, finding := range result.Errors {
+		if !strings.HasPrefix(finding.ErrorCode, "KOGARO-SEC-") || strings.HasSuffix(finding.ErrorCode, "UNKNOWN") {
+			t.Errorf("%s finding has error code %q, want a KOGARO-SEC code", finding.ValidationType, finding.ErrorCode)
+		}
+		codes[finding.ValidationType] = finding.ErrorCode
+	}
+	if codes["pod_running_as_root"] != "KOGARO-SEC-001" {
+		t.Errorf("pod_running_as_root code = %q, want KOGARO-SEC-001", codes["pod_running_as_root"])
+	}
+	if codes["missing_network_policy_security_sensitive"] != "KOGARO-SEC-027" {
+		t.Errorf("missing_network_policy_security_sensitive code = %q, want KOGARO-SEC-027", codes["missing_network_policy_security_sensitive"])
+	}
+	if result.ExitCode != 1 {
+		t.Errorf("exit code = %d, want 1", result.ExitCode)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Shard assigns namespaces to one of several replicas that split the cluster between
+// them. Each namespace belongs to the shard given by the FNV-1a hash of its name modulo
+// the shard count, so every replica derives the same assignment without coordination.
+// Cluster-scoped resources belong to shard 0. The zero value is unsharded and owns
+// every namespace.
+type Shard struct {
+	Index int
+	Count int
+}
+
+// ParseShardIndex derives a shard index from the ordinal suffix of a StatefulSet pod
+// name, such as "kogaro-2"
+func ParseShardIndex(podName string) (int, error) {
+	dash := strings.LastIndex(podName, "-")
+	if dash < 0 {
+		return 0, fmt.Errorf("pod name %q has no ordinal suffix", podName)
+	}
+	index, err := strconv.Atoi(podName[dash+1:])
+	if err != nil {
+		return 0, fmt.Errorf("pod name %q has no ordinal suffix", podName)
+	}
+	return index, nil
+}
+
+// Validate checks that the index lies within the shard count
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got %d", s.Count)
+	}
+	if s.Enabled() && (s.Index < 0 || s.Index >= s.Count) {
+		return fmt.Errorf("shard index %d is out of range for %d shards", s.Index, s.Count)
+	}
+	return nil
+}
+
+// Enabled reports whether namespaces are split between several shards
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Label returns the shard as "index/count", empty when unsharded
+func (s Shard) Label() string {
+	if !s.Enabled() {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", s.Index, s.Count)
+}
+
+// Owns reports whether a namespace belongs to the shard. The empty namespace stands
+// for cluster-scoped resources.
+func (s Shard) Owns(namespace string) bool {
+	if !s.Enabled() {
+		return true
+	}
+	if namespace == "" {
+		return s.Index == 0
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(namespace))
+	return int(hash.Sum32()%uint32(s.Count)) == s.Index // nolint:gosec // Count is validated positive
+}
+
+// ownedNamespaces returns the names of the cluster's namespaces the shard owns,
+// leaving out the system namespaces validators skip
+func (s Shard) ownedNamespaces(ctx context.Context, reader client.Reader) ([]string, error) {
+	var namespaces corev1.NamespaceList
+	if err := reader.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	sharedConfig := ActiveSharedConfig()
+	var owned []string
+	for _, namespace := range namespaces.Items {
+		if s.Owns(namespace.Name) && !sharedConfig.IsSystemNamespace(namespace.Name) {
+			owned = append(owned, namespace.Name)
+		}
+	}
+	return owned, nil
+}
+
+// NewShardClient returns a client whose List results only hold the namespaced objects
+// of namespaces the shard owns. Cluster-scoped objects are always listed, and Get is
+// unrestricted so that references across namespaces still resolve. Checks that compare
+// objects across namespaces list through allShards instead.
+func NewShardClient(c client.Client, shard Shard) client.Client {
+	if !shard.Enabled() {
+		return c
+	}
+	return &shardClient{Client: c, shard: shard}
+}
+
+// shardClient restricts the objects listed through a client to a shard's namespaces
+type shardClient struct {
+	client.Client
+	shard Shard
+}
+
+// List retrieves a list of objects and drops those in namespaces of other shards,
+// unless listed through allShards
+func (c *shardClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	for _, opt := range opts {
+		if _, ok := opt.(listAllShards); ok {
+			return nil
+		}
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("failed to extract list items: %w", err)
+	}
+	owned := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return fmt.Errorf("failed to read list item metadata: %w", err)
+		}
+		// Cluster-scoped objects are needed by every shard to resolve references
+		if accessor.GetNamespace() == "" || c.shard.Owns(accessor.GetNamespace()) {
+			owned = append(owned, item)
+		}
+	}
+	return meta.SetList(list, owned)
+}
+
+// listAllShards is a list option that keeps the objects of every shard's namespaces
+type listAllShards struct{}
+
+// ApplyToList leaves the list options unchanged
+func (listAllShards) ApplyToList(*client.ListOptions) {}
+
+// allShardsReader lists the objects of every shard's namespaces
+type allShardsReader struct {
+	client.Reader
+}
+
+// allShards returns a reader that lists the objects of every namespace, including those
+// other shards own, for checks that compare objects across namespaces such as Ingress
+// host collisions. Their findings are still only reported for the shard's namespaces.
+func allShards(reader client.Reader) client.Reader {
+	return allShardsReader{Reader: reader}
+}
+
+// List retrieves a list of objects from every shard's namespaces
+func (r allShardsReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	return r.Reader.List(ctx, list, append(opts, listAllShards{})...)
+}
+
+// shardLogReceiver forwards the findings of namespaces the shard owns, so that
+// findings on cluster-scoped resources are only reported once
+type shardLogReceiver struct {
+	LogReceiver
+	shard Shard
+}
+
+// wrapShard returns a log receiver that drops findings of other shards
+func wrapShard(receiver LogReceiver, shard Shard) LogReceiver {
+	if !shard.Enabled() {
+		return receiver
+	}
+	return &shardLogReceiver{LogReceiver: receiver, shard: shard}
+}
+
+// LogValidationError forwards a finding if the shard owns its namespace
+func (s *shardLogReceiver) LogValidationError(validatorType string, validationError ValidationError) {
+	if s.shard.Owns(validationError.Namespace) {
+		s.LogReceiver.LogValidationError(validatorType, validationError)
+	}
+}
+
+// Reports returns whether the shard owns the finding's namespace
+func (s *shardLogReceiver) Reports(validationError ValidationError) bool {
+	return s.shard.Owns(validationError.Namespace)
+}
+
+// Cluster returns the cluster of the wrapped receiver
+func (s *shardLogReceiver) Cluster() string {
+	if scoped, ok := s.LogReceiver.(clusterScoped); ok {
+		return scoped.Cluster()
+	}
+	return ""
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestShard_Owns(t *testing.T) {
+	shards := []Shard{{Index: 0, Count: 3}, {Index: 1, Count: 3}, {Index: 2, Count: 3}}
+	for i := 0; i < 100; i++ {
+		namespace := fmt.Sprintf("team-%d", i)
+		owners := 0
+		for _, shard := range shards {
+			if shard.Owns(namespace) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("namespace %s is owned by %d shards, want 1", namespace, owners)
+		}
+	}
+
+	if !shards[0].Owns("") || shards[1].Owns("") {
+		t.Error("cluster-scoped resources should belong to shard 0 only")
+	}
+	if !(Shard{}).Owns("team-1") {
+		t.Error("an unsharded registry should own every namespace")
+	}
+}
+
+func TestParseShardIndex(t *testing.T) {
+	tests := []struct {
+		podName string
+		want    int
+		wantErr bool
+	}{
+		{podName: "kogaro-0", want: 0},
+		{podName: "kogaro-controller-12", want: 12},
+		{podName: "kogaro-7d9f8c6b5-x2k4p", wantErr: true},
+		{podName: "kogaro", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseShardIndex(tt.podName)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseShardIndex(%q) error = %v, wantErr %v", tt.podName, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseShardIndex(%q) = %d, want %d", tt.podName, got, tt.want)
+		}
+	}
+}
+
+func TestShard_Validate(t *testing.T) {
+	for _, shard := range []Shard{{Index: 3, Count: 3}, {Index: -1, Count: 2}, {Count: -1}} {
+		if err := shard.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected an error", shard)
+		}
+	}
+	if err := (Shard{Index: 2, Count: 3}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestShardClient_List(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	var objects []client.Object
+	for i := 0; i < 10; i++ {
+		objects = append(objects, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: fmt.Sprintf("team-%d", i)}})
+	}
+	objects = append(objects, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-0"}})
+	shard := Shard{Index: 1, Count: 2}
+	shardClient := NewShardClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(), shard)
+
+	var configMaps corev1.ConfigMapList
+	if err := shardClient.List(context.Background(), &configMaps); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(configMaps.Items) == 0 || len(configMaps.Items) == 10 {
+		t.Errorf("listed %d ConfigMaps, want a share of 10", len(configMaps.Items))
+	}
+	for _, configMap := range configMaps.Items {
+		if !shard.Owns(configMap.Namespace) {
+			t.Errorf("listed ConfigMap in namespace %s of another shard", configMap.Namespace)
+		}
+	}
+
+	unstructuredList := &unstructured.UnstructuredList{}
+	unstructuredList.SetAPIVersion("v1")
+	unstructuredList.SetKind("ConfigMapList")
+	if err := shardClient.List(context.Background(), unstructuredList); err != nil {
+		t.Fatalf("List() unstructured error = %v", err)
+	}
+	if len(unstructuredList.Items) != len(configMaps.Items) {
+		t.Errorf("listed %d unstructured ConfigMaps, want %d", len(unstructuredList.Items), len(configMaps.Items))
+	}
+
+	var namespaces corev1.NamespaceList
+	if err := shardClient.List(context.Background(), &namespaces); err != nil {
+		t.Fatalf("List() namespaces error = %v", err)
+	}
+	if len(namespaces.Items) != 1 {
+		t.Errorf("listed %d Namespaces, want cluster-scoped objects to be listed by every shard", len(namespaces.Items))
+	}
+
+	var allConfigMaps corev1.ConfigMapList
+	if err := allShards(shardClient).List(context.Background(), &allConfigMaps); err != nil {
+		t.Fatalf("List() across shards error = %v", err)
+	}
+	if len(allConfigMaps.Items) != 10 {
+		t.Errorf("listed %d ConfigMaps across shards, want 10", len(allConfigMaps.Items))
+	}
+}
+
+func TestValidatorRegistry_ShardsSplitFindings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	var objects []client.Object
+	for i := 0; i < 8; i++ {
+		objects = append(objects, &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: fmt.Sprintf("team-%d", i)},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "app", Image: "app:1"}},
+			}}},
+		})
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	scan := func(shard Shard) []string {
+		registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+		registry.SetShard(shard)
+		registry.Register(NewResourceLimitsValidator(fakeClient, logr.Discard(), ResourceLimitsConfig{
+			EnableMissingLimitsValidation: true,
+		}))
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+
+		var namespaces []string
+		for _, finding := range registry.LastValidationResult().Errors {
+			if finding.Shard != shard.Label() {
+				t.Errorf("finding shard = %q, want %q", finding.Shard, shard.Label())
+			}
+			namespaces = append(namespaces, finding.Namespace)
+		}
+		return namespaces
+	}
+
+	all := scan(Shard{})
+	sharded := append(scan(Shard{Index: 0, Count: 2}), scan(Shard{Index: 1, Count: 2})...)
+	sort.Strings(all)
+	sort.Strings(sharded)
+	if len(all) != 8 || strings.Join(sharded, ",") != strings.Join(all, ",") {
+		t.Errorf("sharded findings = %v, want each of %v exactly once", sharded, all)
+	}
+}
+
+func TestValidatorRegistry_ShardsReportCrossNamespaceCollisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+
+	// Every namespace routes the same host to its own backend, so that most collisions
+	// are between Ingresses of different shards
+	var objects []client.Object
+	for i := 0; i < 8; i++ {
+		objects = append(objects, &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "shop", Namespace: fmt.Sprintf("team-%d", i)},
+			Spec: networkingv1.IngressSpec{Rules: []networkingv1.IngressRule{{
+				Host: "shop.example.com",
+				IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{{
+						Path:    "/",
+						Backend: networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: fmt.Sprintf("shop-%d", i)}},
+					}},
+				}},
+			}}},
+		})
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	scan := func(shard Shard) []string {
+		registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+		registry.SetShard(shard)
+		registry.Register(NewNetworkingValidator(fakeClient, logr.Discard(), NetworkingConfig{EnableIngressCollisionValidation: true}))
+		if err := registry.ValidateCluster(context.Background()); err != nil {
+			t.Fatalf("ValidateCluster() error = %v", err)
+		}
+
+		var findings []string
+		for _, finding := range registry.LastValidationResult().Errors {
+			findings = append(findings, finding.ValidationType+" "+finding.Namespace)
+		}
+		return findings
+	}
+
+	all := scan(Shard{})
+	sharded := append(scan(Shard{Index: 0, Count: 2}), scan(Shard{Index: 1, Count: 2})...)
+	sort.Strings(all)
+	sort.Strings(sharded)
+	// Each of the 28 pairs of Ingresses collides
+	if len(all) != 28 || strings.Join(sharded, ",") != strings.Join(all, ",") {
+		t.Errorf("sharded findings = %v, want each of %v exactly once", sharded, all)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SnoozeUntilAnnotation snoozes the findings of a resource until a date, as
+	// 2025-09-01 or an RFC 3339 time, after which they are reported again
+	SnoozeUntilAnnotation = "kogaro.io/snooze-until"
+	// SnoozeCodesAnnotation limits SnoozeUntilAnnotation to a comma-separated list of
+	// error codes, or prefixes ending in "*"
+	SnoozeCodesAnnotation = "kogaro.io/snooze-codes"
+)
+
+// Snooze suppresses the findings of an error code until a date, so that a known issue
+// scheduled for a fix stops being reported without being ignored for good
+type Snooze struct {
+	// ErrorCode is the error code of the snoozed findings, or a prefix ending in "*"
+	ErrorCode string `json:"errorCode"`
+	// ResourceType, Namespace and Name limit the snooze to matching resources; unset
+	// fields match any resource
+	ResourceType string `json:"resourceType,omitempty"`
+	Namespace    string `json:"namespace,omitempty"`
+	Name         string `json:"name,omitempty"`
+	// Until is the date the findings are reported again, as 2025-09-01 or an RFC 3339 time
+	Until string `json:"until"`
+	// Reason records why the findings are snoozed
+	Reason string `json:"reason,omitempty"`
+}
+
+// snoozeEntry is a Snooze with its expiry parsed
+type snoozeEntry struct {
+	Snooze
+	until time.Time
+}
+
+// matches reports whether a finding is one the snooze applies to
+func (s snoozeEntry) matches(finding ValidationError) bool {
+	return errorCodeMatches(s.ErrorCode, finding.ErrorCode) &&
+		(s.ResourceType == "" || s.ResourceType == finding.ResourceType) &&
+		(s.Namespace == "" || s.Namespace == finding.Namespace) &&
+		(s.Name == "" || s.Name == finding.ResourceName)
+}
+
+// SnoozePolicy snoozes findings until a date, by the snoozes of ValidationPolicies and
+// by the SnoozeUntilAnnotation of the resources findings are reported on. Expired
+// snoozes are kept and simply stop applying, so their findings reappear.
+type SnoozePolicy struct {
+	snoozes []snoozeEntry
+}
+
+// NewSnoozePolicy builds a SnoozePolicy from the snoozes of one or more policies.
+// Without policies, findings are snoozed by annotation only.
+func NewSnoozePolicy(policies ...ValidationPolicy) (*SnoozePolicy, error) {
+	snoozePolicy := &SnoozePolicy{}
+	var problems []string
+
+	for _, policy := range policies {
+		for i, snooze := range policy.Spec.Snoozes {
+			if !severityOverrideKeyPattern.MatchString(snooze.ErrorCode) {
+				problems = append(problems, fmt.Sprintf("policy %q: snooze %d has invalid error code %q", policy.Name, i, snooze.ErrorCode))
+				continue
+			}
+			until, err := parseSnoozeUntil(snooze.Until)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("policy %q: snooze %d: %v", policy.Name, i, err))
+				continue
+			}
+			snoozePolicy.snoozes = append(snoozePolicy.snoozes, snoozeEntry{Snooze: snooze, until: until})
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid snoozes: %s", strings.Join(problems, "; "))
+	}
+	return snoozePolicy, nil
+}
+
+// Len returns the number of snoozes of the policy, expired or not
+func (p *SnoozePolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.snoozes)
+}
+
+// parseSnoozeUntil parses the end of a snooze. A date ends the snooze at the start of
+// that day in UTC.
+func parseSnoozeUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(time.DateOnly, value); err == nil {
+		return until, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid until %q, expected a date such as 2025-09-01 or an RFC 3339 time", value)
+	}
+	return until, nil
+}
+
+// errorCodeMatches reports whether an error code is pattern, or has its prefix when
+// pattern ends in "*"
+func errorCodeMatches(pattern, errorCode string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(errorCode, prefix)
+	}
+	return pattern == errorCode
+}
+
+// snoozeAnnotatedKinds are the kinds whose SnoozeUntilAnnotation is read, by the
+// resource type of their findings
+var snoozeAnnotatedKinds = map[string]schema.GroupVersion{
+	"Pod":                     {Version: "v1"},
+	"Service":                 {Version: "v1"},
+	"ConfigMap":               {Version: "v1"},
+	"Secret":                  {Version: "v1"},
+	"ServiceAccount":          {Version: "v1"},
+	"PersistentVolumeClaim":   {Version: "v1"},
+	"Namespace":               {Version: "v1"},
+	"Deployment":              {Group: "apps", Version: "v1"},
+	"StatefulSet":             {Group: "apps", Version: "v1"},
+	"DaemonSet":               {Group: "apps", Version: "v1"},
+	"ReplicaSet":              {Group: "apps", Version: "v1"},
+	"Job":                     {Group: "batch", Version: "v1"},
+	"CronJob":                 {Group: "batch", Version: "v1"},
+	"Ingress":                 {Group: "networking.k8s.io", Version: "v1"},
+	"NetworkPolicy":           {Group: "networking.k8s.io", Version: "v1"},
+	"PodDisruptionBudget":     {Group: "policy", Version: "v1"},
+	"HorizontalPodAutoscaler": {Group: "autoscaling", Version: "v2"},
+	"Role":                    {Group: "rbac.authorization.k8s.io", Version: "v1"},
+	"RoleBinding":             {Group: "rbac.authorization.k8s.io", Version: "v1"},
+	"ClusterRole":             {Group: "rbac.authorization.k8s.io", Version: "v1"},
+	"ClusterRoleBinding":      {Group: "rbac.authorization.k8s.io", Version: "v1"},
+}
+
+// resolve returns the snoozes of one validation run, which read the annotations of
+// the resources findings are reported on through reader. A nil policy snoozes nothing.
+func (p *SnoozePolicy) resolve(reader client.Reader) *snoozeResolver {
+	if p == nil {
+		return nil
+	}
+	return &snoozeResolver{
+		snoozes:     p.snoozes,
+		reader:      reader,
+		now:         time.Now,
+		annotations: make(map[string]map[string]string),
+		unreadable:  make(map[string]bool),
+	}
+}
+
+// snoozeResolver decides which findings are snoozed for one validation run. Whether a
+// snooze has expired is decided when a finding is checked, so findings reappear
+// during a run that outlives a snooze.
+type snoozeResolver struct {
+	snoozes []snoozeEntry
+	reader  client.Reader
+	now     func() time.Time
+
+	mu sync.Mutex
+	// annotations caches the annotations of resources by kind/namespace/name
+	annotations map[string]map[string]string
+	// unreadable records the kinds whose lookups time out
+	unreadable map[string]bool
+}
+
+// snoozed reports whether a finding is snoozed, by policy or by the annotations of its
+// resource. An annotation that does not parse snoozes nothing.
+func (r *snoozeResolver) snoozed(finding ValidationError) bool {
+	if r == nil {
+		return false
+	}
+	now := r.now()
+	for _, snooze := range r.snoozes {
+		if now.Before(snooze.until) && snooze.matches(finding) {
+			return true
+		}
+	}
+
+	annotations := r.annotationsOf(finding)
+	until, err := parseSnoozeUntil(annotations[SnoozeUntilAnnotation])
+	if err != nil || !now.Before(until) {
+		return false
+	}
+	codes, limited := annotations[SnoozeCodesAnnotation]
+	if !limited {
+		return true
+	}
+	for _, code := range strings.Split(codes, ",") {
+		if code = strings.TrimSpace(code); code != "" && errorCodeMatches(code, finding.ErrorCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// annotationsOf returns the annotations of the resource of a finding, or nil when
+// its kind is not read or it cannot be found
+func (r *snoozeResolver) annotationsOf(finding ValidationError) map[string]string {
+	groupVersion, known := snoozeAnnotatedKinds[finding.ResourceType]
+	if !known || r.reader == nil || finding.ResourceName == "" {
+		return nil
+	}
+	key := finding.ResourceType + "/" + finding.Namespace + "/" + finding.ResourceName
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if annotations, cached := r.annotations[key]; cached {
+		return annotations
+	}
+	if r.unreadable[finding.ResourceType] {
+		return nil
+	}
+
+	// Only the metadata is read, so that snoozes don't need to read Secret data
+	metadata := &metav1.PartialObjectMetadata{}
+	metadata.SetGroupVersionKind(groupVersion.WithKind(finding.ResourceType))
+	lookupCtx, cancel := context.WithTimeout(context.Background(), ownerLookupTimeout)
+	defer cancel()
+	var annotations map[string]string
+	if err := r.reader.Get(lookupCtx, client.ObjectKey{Namespace: finding.Namespace, Name: finding.ResourceName}, metadata); err == nil {
+		annotations = metadata.GetAnnotations()
+	} else if lookupCtx.Err() != nil {
+		r.unreadable[finding.ResourceType] = true
+	}
+	r.annotations[key] = annotations
+	return annotations
+}
+
+// filter returns the findings that are not snoozed
+func (r *snoozeResolver) filter(errors []ValidationError) []ValidationError {
+	if r == nil {
+		return errors
+	}
+	var reported []ValidationError
+	for _, validationError := range errors {
+		if !r.snoozed(validationError) {
+			reported = append(reported, validationError)
+		}
+	}
+	return reported
+}
+
+// wrap returns a log receiver that drops snoozed findings
+func (r *snoozeResolver) wrap(receiver LogReceiver) LogReceiver {
+	if r == nil {
+		return receiver
+	}
+	return &snoozeLogReceiver{LogReceiver: receiver, resolver: r}
+}
+
+// snoozeLogReceiver forwards the findings that are not snoozed
+type snoozeLogReceiver struct {
+	LogReceiver
+	resolver *snoozeResolver
+}
+
+// LogValidationError forwards a finding unless it is snoozed
+func (s *snoozeLogReceiver) LogValidationError(validatorType string, validationError ValidationError) {
+	if !s.resolver.snoozed(validationError) {
+		s.LogReceiver.LogValidationError(validatorType, validationError)
+	}
+}
+
+// Reports returns whether a finding is not snoozed and is reported by the wrapped
+// receiver
+func (s *snoozeLogReceiver) Reports(validationError ValidationError) bool {
+	if s.resolver.snoozed(validationError) {
+		return false
+	}
+	if filter, ok := s.LogReceiver.(findingFilter); ok {
+		return filter.Reports(validationError)
+	}
+	return true
+}
+
+// Cluster returns the cluster of the wrapped receiver
+func (s *snoozeLogReceiver) Cluster() string {
+	if scoped, ok := s.LogReceiver.(clusterScoped); ok {
+		return scoped.Cluster()
+	}
+	return ""
+}
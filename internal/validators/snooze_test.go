@@ -0,0 +1,137 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestNewSnoozePolicy(t *testing.T) {
+	policy := func(snoozes ...Snooze) ValidationPolicy {
+		return ValidationPolicy{ObjectMeta: metav1.ObjectMeta{Name: "team"}, Spec: ValidationPolicySpec{Snoozes: snoozes}}
+	}
+
+	snoozePolicy, err := NewSnoozePolicy(policy(
+		Snooze{ErrorCode: "KOGARO-REF-006", Until: "2025-09-01"},
+		Snooze{ErrorCode: "KOGARO-SEC-*", Namespace: "shop", Until: "2025-09-01T12:00:00Z"},
+	))
+	if err != nil {
+		t.Fatalf("NewSnoozePolicy() error = %v", err)
+	}
+	if snoozePolicy.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", snoozePolicy.Len())
+	}
+
+	_, err = NewSnoozePolicy(policy(
+		Snooze{ErrorCode: "ref-006", Until: "2025-09-01"},
+		Snooze{ErrorCode: "KOGARO-REF-006", Until: "next sprint"},
+	))
+	if err == nil {
+		t.Fatal("NewSnoozePolicy() error = nil, want invalid snoozes")
+	}
+	for _, want := range []string{`invalid error code "ref-006"`, `invalid until "next sprint"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %v, want it to contain %q", err, want)
+		}
+	}
+}
+
+func TestSnoozeResolver_Snoozed(t *testing.T) {
+	snoozePolicy, err := NewSnoozePolicy(ValidationPolicy{Spec: ValidationPolicySpec{Snoozes: []Snooze{
+		{ErrorCode: "KOGARO-REF-*", Namespace: "shop", Until: "2025-09-01"},
+	}}})
+	if err != nil {
+		t.Fatalf("NewSnoozePolicy() error = %v", err)
+	}
+
+	deployment := func(name string, annotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "web", Annotations: annotations}}
+	}
+	cluster := fake.NewClientBuilder().WithObjects(
+		deployment("snoozed", map[string]string{SnoozeUntilAnnotation: "2025-09-01"}),
+		deployment("snoozed-codes", map[string]string{SnoozeUntilAnnotation: "2025-09-01", SnoozeCodesAnnotation: "KOGARO-SEC-001, KOGARO-RES-*"}),
+		deployment("unparsable", map[string]string{SnoozeUntilAnnotation: "soon"}),
+	).Build()
+
+	finding := func(kind, namespace, name, code string) ValidationError {
+		return NewValidationErrorWithCode(kind, name, namespace, "test", code, "finding")
+	}
+	tests := []struct {
+		name    string
+		finding ValidationError
+		now     string
+		want    bool
+	}{
+		{"policy snooze", finding("Deployment", "shop", "api", "KOGARO-REF-006"), "2025-08-31T23:59:59Z", true},
+		{"policy snooze expired", finding("Deployment", "shop", "api", "KOGARO-REF-006"), "2025-09-01T00:00:00Z", false},
+		{"policy snooze other namespace", finding("Deployment", "web", "api", "KOGARO-REF-006"), "2025-08-01T00:00:00Z", false},
+		{"policy snooze other code", finding("Deployment", "shop", "api", "KOGARO-SEC-001"), "2025-08-01T00:00:00Z", false},
+		{"annotation", finding("Deployment", "web", "snoozed", "KOGARO-SEC-001"), "2025-08-01T00:00:00Z", true},
+		{"annotation expired", finding("Deployment", "web", "snoozed", "KOGARO-SEC-001"), "2025-09-02T00:00:00Z", false},
+		{"annotation code", finding("Deployment", "web", "snoozed-codes", "KOGARO-SEC-001"), "2025-08-01T00:00:00Z", true},
+		{"annotation code prefix", finding("Deployment", "web", "snoozed-codes", "KOGARO-RES-002"), "2025-08-01T00:00:00Z", true},
+		{"annotation other code", finding("Deployment", "web", "snoozed-codes", "KOGARO-SEC-002"), "2025-08-01T00:00:00Z", false},
+		{"unparsable annotation", finding("Deployment", "web", "unparsable", "KOGARO-SEC-001"), "2025-08-01T00:00:00Z", false},
+		{"missing resource", finding("Deployment", "web", "gone", "KOGARO-SEC-001"), "2025-08-01T00:00:00Z", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := snoozePolicy.resolve(cluster)
+			now, err := time.Parse(time.RFC3339, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resolver.now = func() time.Time { return now }
+
+			if got := resolver.snoozed(tt.finding); got != tt.want {
+				t.Errorf("snoozed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilPolicy *SnoozePolicy
+	if resolver := nilPolicy.resolve(cluster); resolver.snoozed(finding("Deployment", "web", "snoozed", "KOGARO-SEC-001")) {
+		t.Error("a nil policy snoozed a finding")
+	}
+}
+
+func TestValidateFileOnly_DropsSnoozedFindings(t *testing.T) {
+	registry := NewValidatorRegistry(logr.Discard(), nil)
+	registry.Register(NewResourceLimitsValidator(nil, logr.Discard(), ResourceLimitsConfig{EnableMissingRequestsValidation: true}))
+	snoozePolicy, err := NewSnoozePolicy()
+	if err != nil {
+		t.Fatalf("NewSnoozePolicy() error = %v", err)
+	}
+	registry.SetSnoozePolicy(snoozePolicy)
+
+	until := time.Now().AddDate(0, 0, 7).Format(time.DateOnly)
+	expired := time.Now().AddDate(0, 0, -7).Format(time.DateOnly)
+	snoozedUntil := func(date string) string {
+		return "  annotations:\n    kogaro.io/snooze-until: \"" + date + "\"\n"
+	}
+	config := gitopsDeployment("web", snoozedUntil(until)) + "---\n" + gitopsDeployment("api", "") + "---\n" + gitopsDeployment("worker", snoozedUntil(expired))
+
+	result, err := registry.ValidateFileOnlyData(context.Background(), "app.yaml", []byte(config))
+	if err != nil {
+		t.Fatalf("ValidateFileOnlyData() error = %v", err)
+	}
+
+	reported := make(map[string]bool)
+	for _, finding := range result.Errors {
+		reported[finding.ResourceName] = true
+	}
+	if reported["web"] || !reported["api"] || !reported["worker"] {
+		t.Errorf("findings reported on %v, want api and worker but not the snoozed web", reported)
+	}
+}
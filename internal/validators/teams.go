@@ -0,0 +1,176 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// NamespaceTeamLabel assigns a namespace to the team that owns it by name
+const NamespaceTeamLabel = "kogaro.io/team"
+
+// TeamMapping describes a team that owns namespaces and the notification channels
+// its findings are routed to
+type TeamMapping struct {
+	// Namespaces selects the namespaces the team owns by exact name, glob, regular
+	// expression or label selector, as in --networking-required-namespaces
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Channels names the notification channels the team's findings are routed to
+	Channels []string `json:"channels,omitempty"`
+}
+
+// TeamPolicy assigns namespaces, and so the findings reported in them, to teams. A
+// namespace belongs to the first team, by name, whose namespaces select it, else to
+// the team named by its NamespaceTeamLabel. Findings on cluster-scoped resources and
+// in namespaces no team owns are not assigned to a team.
+type TeamPolicy struct {
+	teams     map[string]TeamMapping
+	selectors map[string]*NamespaceSelector
+}
+
+// NewTeamPolicy builds a TeamPolicy from the team mappings of one or more policies.
+// Later policies replace the mapping of a team defined by an earlier one. Without
+// policies, namespaces are assigned to teams by label only.
+func NewTeamPolicy(policies ...ValidationPolicy) (*TeamPolicy, error) {
+	teamPolicy := &TeamPolicy{
+		teams:     make(map[string]TeamMapping),
+		selectors: make(map[string]*NamespaceSelector),
+	}
+	var problems []string
+
+	for _, policy := range policies {
+		for name, team := range policy.Spec.Teams {
+			selector, err := ParseNamespaceSelector(team.Namespaces)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("policy %q: team %q: %v", policy.Name, name, err))
+				continue
+			}
+			teamPolicy.teams[name] = team
+			teamPolicy.selectors[name] = selector
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("invalid teams: %s", strings.Join(problems, "; "))
+	}
+
+	return teamPolicy, nil
+}
+
+// Len returns the number of teams defined by the policy
+func (p *TeamPolicy) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.teams)
+}
+
+// resolve reads the namespaces of the cluster and returns the team owning each of
+// them. A nil policy resolves to nil, which assigns no finding to a team.
+func (p *TeamPolicy) resolve(ctx context.Context, reader client.Reader) (*teamResolver, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	var namespaces []corev1.Namespace
+	if reader != nil {
+		var list corev1.NamespaceList
+		if err := reader.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		}
+		namespaces = list.Items
+	}
+
+	resolver := &teamResolver{
+		byNamespace: make(map[string]string),
+		channels:    make(map[string][]string, len(p.teams)),
+	}
+	names := make([]string, 0, len(p.teams))
+	for name, team := range p.teams {
+		names = append(names, name)
+		resolver.channels[name] = team.Channels
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, namespace := range p.selectors[name].Select(namespaces) {
+			if _, assigned := resolver.byNamespace[namespace]; !assigned {
+				resolver.byNamespace[namespace] = name
+			}
+		}
+	}
+	for _, namespace := range namespaces {
+		if _, assigned := resolver.byNamespace[namespace.Name]; assigned {
+			continue
+		}
+		if team := namespace.Labels[NamespaceTeamLabel]; team != "" {
+			resolver.byNamespace[namespace.Name] = team
+		}
+	}
+	return resolver, nil
+}
+
+// teamResolver holds the team owning each namespace, and the notification channels of
+// each team, for one validation run
+type teamResolver struct {
+	byNamespace map[string]string
+	channels    map[string][]string
+}
+
+// assign sets the team of each finding from the namespace it is reported in
+func (r *teamResolver) assign(findings []ValidationError) {
+	if r == nil {
+		return
+	}
+	for i := range findings {
+		if findings[i].Namespace != "" {
+			findings[i].Team = r.byNamespace[findings[i].Namespace]
+		}
+	}
+}
+
+// channelsOf returns the notification channels of a team
+func (r *teamResolver) channelsOf(team string) []string {
+	if r == nil {
+		return nil
+	}
+	return r.channels[team]
+}
+
+// GroupByTeam groups findings by the team they are assigned to. Findings without a
+// team are grouped under the empty team name.
+func GroupByTeam(findings []ValidationError) map[string][]ValidationError {
+	grouped := make(map[string][]ValidationError)
+	for _, finding := range findings {
+		grouped[finding.Team] = append(grouped[finding.Team], finding)
+	}
+	return grouped
+}
+
+// recordTeamFindings records the number of findings of each team by severity in the
+// kogaro_team_findings metric
+func recordTeamFindings(cluster string, findings []ValidationError) {
+	counts := make(map[string]map[string]int)
+	for team, teamFindings := range GroupByTeam(findings) {
+		counts[team] = make(map[string]int)
+		for _, finding := range teamFindings {
+			severity := finding.Severity
+			if severity == "" {
+				severity = SeverityError
+			}
+			counts[team][string(severity)]++
+		}
+	}
+	metrics.RecordTeamFindings(cluster, counts)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+func TestNewTeamPolicy_Invalid(t *testing.T) {
+	_, err := NewTeamPolicy(ValidationPolicy{Spec: ValidationPolicySpec{Teams: map[string]TeamMapping{
+		"payments": {Namespaces: []string{"team in (payments"}},
+	}}})
+	if err == nil || !strings.Contains(err.Error(), `team "payments"`) {
+		t.Errorf("NewTeamPolicy() error = %v, want an error naming the team", err)
+	}
+}
+
+func TestValidatorRegistry_TeamsPerNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "billing-eu"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "checkout", Labels: map[string]string{NamespaceTeamLabel: "payments"}}},
+		// The policy mapping takes precedence over the label
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ingress", Labels: map[string]string{"team": "platform", NamespaceTeamLabel: "payments"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "sandbox"}},
+	).Build()
+
+	teamPolicy, err := NewTeamPolicy(
+		ValidationPolicy{Spec: ValidationPolicySpec{Teams: map[string]TeamMapping{
+			"payments": {Namespaces: []string{"payments"}},
+		}}},
+		// A later policy replaces the mapping of the same team
+		ValidationPolicy{Spec: ValidationPolicySpec{Teams: map[string]TeamMapping{
+			"payments": {Namespaces: []string{"billing-*"}, Channels: []string{"payments-oncall"}},
+			"platform": {Namespaces: []string{"team=platform"}, Channels: []string{"platform-alerts"}},
+		}}},
+	)
+	if err != nil {
+		t.Fatalf("NewTeamPolicy() error = %v", err)
+	}
+
+	findings := []ValidationError{
+		NewValidationErrorWithCode("Deployment", "api", "billing-eu", "missing_resource_requests", "KOGARO-RES-001", "no requests").WithSeverity(SeverityWarning),
+		NewValidationErrorWithCode("Deployment", "web", "checkout", "dangling_configmap_volume", "KOGARO-REF-003", "missing"),
+		NewValidationErrorWithCode("Ingress", "edge", "ingress", "dangling_ingress_class", "KOGARO-REF-001", "missing"),
+		NewValidationErrorWithCode("Pod", "debug", "sandbox", "missing_resource_requests", "KOGARO-RES-001", "no requests"),
+		NewValidationErrorWithCode("StorageClass", "fast", "", "invalid_storage_class", "KOGARO-REF-010", "missing"),
+	}
+	registry := NewValidatorRegistry(logr.Discard(), fakeClient)
+	registry.SetCluster("teams-test")
+	registry.SetTeamPolicy(teamPolicy)
+	registry.Register(&MockValidator{validationType: "first", lastValidationErrors: findings})
+	if err := registry.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+	result := registry.LastValidationResult()
+
+	var teams []string
+	for _, finding := range result.Errors {
+		teams = append(teams, finding.Team)
+	}
+	if want := []string{"payments", "payments", "platform", "", ""}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("teams = %q, want %q", teams, want)
+	}
+	if channels := registry.TeamChannels("platform"); !reflect.DeepEqual(channels, []string{"platform-alerts"}) {
+		t.Errorf("TeamChannels(platform) = %q", channels)
+	}
+
+	counts := map[[2]string]float64{
+		{"payments", "error"}:   1,
+		{"payments", "warning"}: 1,
+		{"platform", "error"}:   1,
+		{"", "error"}:           2,
+	}
+	for labels, want := range counts {
+		if got := testutil.ToFloat64(metrics.TeamFindings.WithLabelValues(labels[0], labels[1], "teams-test")); got != want {
+			t.Errorf("kogaro_team_findings{team=%q,severity=%q} = %v, want %v", labels[0], labels[1], got, want)
+		}
+	}
+
+	output, err := registry.FormatMarkdownOutput(result, nil)
+	if err != nil {
+		t.Fatalf("FormatMarkdownOutput() error = %v", err)
+	}
+	want := "### Findings by Team\n\n| Team | Errors | Warnings | Info |\n|---|---|---|---|\n" +
+		"| `payments` | 1 | 1 | 0 |\n| `platform` | 1 | 0 | 0 |\n| _unassigned_ | 2 | 0 | 0 |\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("FormatMarkdownOutput() is missing the team table:\n%s", output)
+	}
+}
@@ -17,6 +17,11 @@ func stringPtr(s string) *string {
 	return &s
 }
 
+// int32Ptr returns a pointer to an int32 value
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
 // int64Ptr returns a pointer to an int64 value
 func int64Ptr(i int64) *int64 {
 	return &i
@@ -0,0 +1,334 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Resources that Kubernetes or common tooling create and consume without a
+// reference Kogaro can see. They are never reported as unused.
+const (
+	rootCAConfigMapName   = "kube-root-ca.crt"
+	helmReleaseSecretType = corev1.SecretType("helm.sh/release.v1")
+)
+
+// resourceReferences records which ConfigMaps, Secrets, PVCs and ServiceAccounts are
+// referenced, keyed by namespace/name
+type resourceReferences struct {
+	configMaps      map[string]bool
+	secrets         map[string]bool
+	pvcs            map[string]bool
+	serviceAccounts map[string]bool
+	// pvcPrefixes are the name prefixes of PVCs created from StatefulSet volumeClaimTemplates
+	pvcPrefixes []string
+}
+
+func newResourceReferences() *resourceReferences {
+	return &resourceReferences{
+		configMaps:      make(map[string]bool),
+		secrets:         make(map[string]bool),
+		pvcs:            make(map[string]bool),
+		serviceAccounts: make(map[string]bool),
+	}
+}
+
+// addPodSpec records every ConfigMap, Secret, PVC and ServiceAccount a pod spec references
+func (r *resourceReferences) addPodSpec(namespace string, spec corev1.PodSpec, defaultServiceAccount string) {
+	key := func(name string) string { return namespace + "/" + name }
+
+	serviceAccount := spec.ServiceAccountName
+	if serviceAccount == "" {
+		serviceAccount = defaultServiceAccount
+	}
+	r.serviceAccounts[key(serviceAccount)] = true
+
+	for _, pullSecret := range spec.ImagePullSecrets {
+		r.secrets[key(pullSecret.Name)] = true
+	}
+
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			r.configMaps[key(volume.ConfigMap.Name)] = true
+		case volume.Secret != nil:
+			r.secrets[key(volume.Secret.SecretName)] = true
+		case volume.PersistentVolumeClaim != nil:
+			r.pvcs[key(volume.PersistentVolumeClaim.ClaimName)] = true
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					r.configMaps[key(source.ConfigMap.Name)] = true
+				}
+				if source.Secret != nil {
+					r.secrets[key(source.Secret.Name)] = true
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.ConfigMapRef != nil {
+				r.configMaps[key(envFrom.ConfigMapRef.Name)] = true
+			}
+			if envFrom.SecretRef != nil {
+				r.secrets[key(envFrom.SecretRef.Name)] = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				r.configMaps[key(env.ValueFrom.ConfigMapKeyRef.Name)] = true
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				r.secrets[key(env.ValueFrom.SecretKeyRef.Name)] = true
+			}
+		}
+	}
+}
+
+// pvcReferenced reports whether a PVC is mounted or was created from a StatefulSet volumeClaimTemplate
+func (r *resourceReferences) pvcReferenced(namespace, name string) bool {
+	key := namespace + "/" + name
+	if r.pvcs[key] {
+		return true
+	}
+	for _, prefix := range r.pvcPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectResourceReferences gathers the references made by workloads, Ingresses,
+// ServiceAccounts and RBAC bindings across the cluster
+func (v *ReferenceValidator) collectResourceReferences(ctx context.Context) (*resourceReferences, error) {
+	refs := newResourceReferences()
+	defaultServiceAccount := v.sharedConfig.DefaultSecurityContext.DefaultServiceAccountName
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		refs.addPodSpec(pod.Namespace, pod.Spec, defaultServiceAccount)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Workload templates are included so that resources of workloads scaled to zero
+	// or of suspended CronJobs are not reported
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		refs.addPodSpec(deployment.Namespace, deployment.Spec.Template.Spec, defaultServiceAccount)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		refs.addPodSpec(statefulSet.Namespace, statefulSet.Spec.Template.Spec, defaultServiceAccount)
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			refs.pvcPrefixes = append(refs.pvcPrefixes, fmt.Sprintf("%s/%s-%s-", statefulSet.Namespace, template.Name, statefulSet.Name))
+		}
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		refs.addPodSpec(daemonSet.Namespace, daemonSet.Spec.Template.Spec, defaultServiceAccount)
+	}
+
+	var jobs batchv1.JobList
+	if err := v.client.List(ctx, &jobs); err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs.Items {
+		refs.addPodSpec(job.Namespace, job.Spec.Template.Spec, defaultServiceAccount)
+	}
+
+	var cronJobs batchv1.CronJobList
+	if err := v.client.List(ctx, &cronJobs); err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cronJob := range cronJobs.Items {
+		refs.addPodSpec(cronJob.Namespace, cronJob.Spec.JobTemplate.Spec.Template.Spec, defaultServiceAccount)
+	}
+
+	var ingresses networkingv1.IngressList
+	if err := v.client.List(ctx, &ingresses); err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			if tls.SecretName != "" {
+				refs.secrets[ingress.Namespace+"/"+tls.SecretName] = true
+			}
+		}
+	}
+
+	var serviceAccounts corev1.ServiceAccountList
+	if err := v.client.List(ctx, &serviceAccounts); err != nil {
+		return nil, fmt.Errorf("failed to list serviceaccounts: %w", err)
+	}
+	for _, serviceAccount := range serviceAccounts.Items {
+		for _, secret := range serviceAccount.Secrets {
+			refs.secrets[serviceAccount.Namespace+"/"+secret.Name] = true
+		}
+		for _, pullSecret := range serviceAccount.ImagePullSecrets {
+			refs.secrets[serviceAccount.Namespace+"/"+pullSecret.Name] = true
+		}
+	}
+
+	addSubjects := func(bindingNamespace string, subjects []rbacv1.Subject) {
+		for _, subject := range subjects {
+			if subject.Kind != rbacv1.ServiceAccountKind {
+				continue
+			}
+			namespace := subject.Namespace
+			if namespace == "" {
+				namespace = bindingNamespace
+			}
+			refs.serviceAccounts[namespace+"/"+subject.Name] = true
+		}
+	}
+
+	// RoleBindings may bind ServiceAccounts of namespaces other shards own
+	var roleBindings rbacv1.RoleBindingList
+	if err := allShards(v.client).List(ctx, &roleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list rolebindings: %w", err)
+	}
+	for _, binding := range roleBindings.Items {
+		addSubjects(binding.Namespace, binding.Subjects)
+	}
+
+	var clusterRoleBindings rbacv1.ClusterRoleBindingList
+	if err := v.client.List(ctx, &clusterRoleBindings); err != nil {
+		return nil, fmt.Errorf("failed to list clusterrolebindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		addSubjects("", binding.Subjects)
+	}
+
+	return refs, nil
+}
+
+// validateUnusedResources reports ConfigMaps, Secrets, PVCs and ServiceAccounts that
+// nothing references. Resources younger than UnusedResourceMinAge, resources owned by
+// another object and resources created by Kubernetes or Helm are skipped.
+func (v *ReferenceValidator) validateUnusedResources(ctx context.Context) ([]ValidationError, error) {
+	refs, err := v.collectResourceReferences(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var errors []ValidationError
+	now := time.Now()
+
+	var configMaps corev1.ConfigMapList
+	if err := v.client.List(ctx, &configMaps); err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+	for _, configMap := range configMaps.Items {
+		if configMap.Name == rootCAConfigMapName || !v.unusedCandidate(&configMap, now) {
+			continue
+		}
+		if refs.configMaps[configMap.Namespace+"/"+configMap.Name] {
+			continue
+		}
+		errors = append(errors, v.newUnusedResourceError("ConfigMap", "unused_configmap", &configMap, now,
+			"no workload references it in a volume, envFrom or env entry"))
+	}
+
+	secrets, err := listSecrets(ctx, v.client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for _, secret := range secrets {
+		if secretType := secretType(&secret); secretType == corev1.SecretTypeServiceAccountToken || secretType == helmReleaseSecretType || !v.unusedCandidate(&secret, now) {
+			continue
+		}
+		if refs.secrets[secret.Namespace+"/"+secret.Name] {
+			continue
+		}
+		errors = append(errors, v.newUnusedResourceError("Secret", "unused_secret", &secret, now,
+			"no workload, Ingress or ServiceAccount references it"))
+	}
+
+	var pvcs corev1.PersistentVolumeClaimList
+	if err := v.client.List(ctx, &pvcs); err != nil {
+		return nil, fmt.Errorf("failed to list persistentvolumeclaims: %w", err)
+	}
+	for _, pvc := range pvcs.Items {
+		if !v.unusedCandidate(&pvc, now) || refs.pvcReferenced(pvc.Namespace, pvc.Name) {
+			continue
+		}
+		errors = append(errors, v.newUnusedResourceError("PersistentVolumeClaim", "unused_pvc", &pvc, now,
+			"no workload mounts it").
+			WithDetail("storage_class", stringValue(pvc.Spec.StorageClassName)))
+	}
+
+	var serviceAccounts corev1.ServiceAccountList
+	if err := v.client.List(ctx, &serviceAccounts); err != nil {
+		return nil, fmt.Errorf("failed to list serviceaccounts: %w", err)
+	}
+	for _, serviceAccount := range serviceAccounts.Items {
+		if serviceAccount.Name == v.sharedConfig.DefaultSecurityContext.DefaultServiceAccountName || !v.unusedCandidate(&serviceAccount, now) {
+			continue
+		}
+		if refs.serviceAccounts[serviceAccount.Namespace+"/"+serviceAccount.Name] {
+			continue
+		}
+		errors = append(errors, v.newUnusedResourceError("ServiceAccount", "unused_serviceaccount", &serviceAccount, now,
+			"no workload runs as it and no RoleBinding or ClusterRoleBinding grants it permissions"))
+	}
+
+	return errors, nil
+}
+
+// unusedCandidate reports whether a resource is old enough, and unmanaged, to be
+// reported as unused
+func (v *ReferenceValidator) unusedCandidate(obj metav1.Object, now time.Time) bool {
+	if v.sharedConfig.IsSystemNamespace(obj.GetNamespace()) || len(obj.GetOwnerReferences()) > 0 {
+		return false
+	}
+	return now.Sub(obj.GetCreationTimestamp().Time) >= v.config.UnusedResourceMinAge
+}
+
+func (v *ReferenceValidator) newUnusedResourceError(resourceType, validationType string, obj metav1.Object, now time.Time, reason string) ValidationError {
+	age := now.Sub(obj.GetCreationTimestamp().Time).Truncate(time.Hour)
+	return NewValidationErrorWithCode(resourceType, obj.GetName(), obj.GetNamespace(), validationType, GetReferenceErrorCode(validationType),
+		fmt.Sprintf("%s '%s' is possibly unused: %s", resourceType, obj.GetName(), reason)).
+		WithSeverity(SeverityInfo).
+		WithRemediationHint(fmt.Sprintf("Delete %s '%s' if it is no longer needed; resources read through the API or by tools outside the cluster cannot be detected", resourceType, obj.GetName())).
+		WithDetail("age", age.String())
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
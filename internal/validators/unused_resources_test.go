@@ -0,0 +1,145 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestReferenceValidator_ValidateUnusedResources(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = networkingv1.AddToScheme(scheme)
+	_ = rbacv1.AddToScheme(scheme)
+
+	old := metav1.NewTime(time.Now().Add(-48 * time.Hour))
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: name, Namespace: "test-ns", CreationTimestamp: old}
+	}
+
+	tests := []struct {
+		name       string
+		objects    []client.Object
+		errorTypes []string
+	}{
+		{
+			name: "resources referenced by workloads, ingresses and bindings",
+			objects: []client.Object{
+				&appsv1.Deployment{
+					ObjectMeta: meta("web"),
+					Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+						ServiceAccountName: "web",
+						Volumes: []corev1.Volume{
+							{Name: "config", VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}},
+							{Name: "data", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}}},
+						},
+						Containers: []corev1.Container{{
+							Name:    "app",
+							EnvFrom: []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-env"}}}},
+						}},
+					}}},
+				},
+				&appsv1.StatefulSet{
+					ObjectMeta: meta("db"),
+					Spec: appsv1.StatefulSetSpec{
+						VolumeClaimTemplates: []corev1.PersistentVolumeClaim{{ObjectMeta: metav1.ObjectMeta{Name: "data"}}},
+					},
+				},
+				&networkingv1.Ingress{
+					ObjectMeta: meta("web"),
+					Spec:       networkingv1.IngressSpec{TLS: []networkingv1.IngressTLS{{SecretName: "web-tls"}}},
+				},
+				&rbacv1.RoleBinding{
+					ObjectMeta: meta("operator"),
+					Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: "operator"}},
+				},
+				&corev1.ConfigMap{ObjectMeta: meta("web-config")},
+				&corev1.ConfigMap{ObjectMeta: meta("kube-root-ca.crt")},
+				&corev1.Secret{ObjectMeta: meta("web-env")},
+				&corev1.Secret{ObjectMeta: meta("web-tls")},
+				&corev1.Secret{ObjectMeta: meta("sh.helm.release.v1.web.v1"), Type: "helm.sh/release.v1"},
+				&corev1.PersistentVolumeClaim{ObjectMeta: meta("web-data")},
+				&corev1.PersistentVolumeClaim{ObjectMeta: meta("data-db-0")},
+				&corev1.ServiceAccount{ObjectMeta: meta("default")},
+				&corev1.ServiceAccount{ObjectMeta: meta("web")},
+				&corev1.ServiceAccount{ObjectMeta: meta("operator")},
+			},
+			errorTypes: []string{},
+		},
+		{
+			name: "unreferenced resources",
+			objects: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: meta("legacy-config")},
+				&corev1.Secret{ObjectMeta: meta("legacy-token")},
+				&corev1.PersistentVolumeClaim{ObjectMeta: meta("scratch")},
+				&corev1.ServiceAccount{ObjectMeta: meta("ci")},
+			},
+			errorTypes: []string{"unused_configmap", "unused_secret", "unused_pvc", "unused_serviceaccount"},
+		},
+		{
+			name: "young, owned and system namespace resources ignored",
+			objects: []client.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "new-config", Namespace: "test-ns", CreationTimestamp: metav1.Now()}},
+				&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+					Name:              "cert",
+					Namespace:         "test-ns",
+					CreationTimestamp: old,
+					OwnerReferences:   []metav1.OwnerReference{{APIVersion: "cert-manager.io/v1", Kind: "Certificate", Name: "cert", UID: "cert-uid"}},
+				}},
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system", CreationTimestamp: old}},
+			},
+			errorTypes: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			config := ValidationConfig{EnableUnusedResourceValidation: true, UnusedResourceMinAge: 24 * time.Hour}
+			validator := NewReferenceValidator(fakeClient, logr.Discard(), config)
+
+			errors, err := validator.validateUnusedResources(context.TODO())
+			if err != nil {
+				t.Fatalf("validateUnusedResources() error = %v", err)
+			}
+
+			if len(errors) != len(tt.errorTypes) {
+				t.Fatalf("validateUnusedResources() got %d errors, want %d: %+v", len(errors), len(tt.errorTypes), errors)
+			}
+
+			for i, expectedType := range tt.errorTypes {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("Expected error type %s, got %s", expectedType, errors[i].ValidationType)
+				}
+				if errors[i].Severity != SeverityInfo {
+					t.Errorf("error[%d] severity = %s, want info", i, errors[i].Severity)
+				}
+				if errors[i].ErrorCode == "KOGARO-REF-UNKNOWN" {
+					t.Errorf("error[%d] has no error code", i)
+				}
+			}
+		})
+	}
+}
@@ -11,18 +11,59 @@
 package validators
 
 import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/topiaruss/kogaro/internal/metrics"
 )
 
 // LogAndRecordErrors logs and records metrics for all validation errors.
 // This consolidates the common error handling pattern used across all validators.
+// errors must hold every finding of the validator's run, since the findings it
+// reported in earlier runs but not in errors are recorded as resolved.
 func LogAndRecordErrors(logReceiver LogReceiver, validatorType string, errors []ValidationError) {
+	cluster := ""
+	if scoped, ok := logReceiver.(clusterScoped); ok {
+		cluster = scoped.Cluster()
+	}
+	filter, _ := logReceiver.(findingFilter)
+	if resolver, ok := logReceiver.(severityResolver); ok {
+		errors = resolver.ResolveSeverities(errors)
+	}
+
+	// Skip findings that the namespace's validation profile does not report, and
+	// findings held back because they flap
+	if filter != nil {
+		var kept []ValidationError
+		for _, validationErr := range errors {
+			if filter.Reports(validationErr) {
+				kept = append(kept, validationErr)
+			}
+		}
+		errors = kept
+	}
+	if damper, ok := logReceiver.(findingDamper); ok {
+		errors = damper.Damp(errors)
+	}
+
+	reported := make(map[string]bool, len(errors))
 	for _, validationErr := range errors {
 		// Log the error
 		logReceiver.LogValidationError(validatorType, validationErr)
 
 		// Record metrics with temporal awareness
-		metrics.RecordValidationErrorWithState(
+		key := metrics.RecordValidatorFinding(
+			cluster,
+			validatorType,
 			validationErr.ResourceType,
 			validationErr.ResourceName,
 			validationErr.Namespace,
@@ -31,5 +72,54 @@ func LogAndRecordErrors(logReceiver LogReceiver, validatorType string, errors []
 			validationErr.ErrorCode,
 			false, // expectedPattern - false for actual errors
 		)
+		reported[key] = true
 	}
+
+	// Findings the validator reported in earlier runs but not in this one have been fixed
+	metrics.ResolveValidatorFindings(cluster, validatorType, reported)
+}
+
+// listUnstructuredByKind lists each kind once as unstructured objects outside system
+// namespaces, sorted by namespace and name. Kinds the cluster does not serve are
+// logged and skipped, so validators driven by user configuration tolerate missing CRDs.
+func listUnstructuredByKind(ctx context.Context, c client.Client, log logr.Logger, sharedConfig SharedConfig, kinds []schema.GroupVersionKind) (map[schema.GroupVersionKind][]unstructured.Unstructured, error) {
+	objects := make(map[schema.GroupVersionKind][]unstructured.Unstructured)
+
+	for _, gvk := range kinds {
+		if _, listed := objects[gvk]; listed {
+			continue
+		}
+		if gvk.GroupKind() == corev1.SchemeGroupVersion.WithKind("Secret").GroupKind() && !secretDataReadable() {
+			log.Info("skipping Secrets, only their metadata may be read", "api_version", gvk.GroupVersion().String())
+			objects[gvk] = nil
+			continue
+		}
+
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := c.List(ctx, list); err != nil {
+			if meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+				log.Info("skipping resource kind unknown to the cluster", "api_version", gvk.GroupVersion().String(), "kind", gvk.Kind)
+				objects[gvk] = nil
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", gvk.Kind, err)
+		}
+
+		items := make([]unstructured.Unstructured, 0, len(list.Items))
+		for _, item := range list.Items {
+			if !sharedConfig.IsSystemNamespace(item.GetNamespace()) {
+				items = append(items, item)
+			}
+		}
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].GetNamespace() != items[j].GetNamespace() {
+				return items[i].GetNamespace() < items[j].GetNamespace()
+			}
+			return items[i].GetName() < items[j].GetName()
+		})
+		objects[gvk] = items
+	}
+
+	return objects, nil
 }
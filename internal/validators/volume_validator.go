@@ -0,0 +1,387 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides volume and volume mount validation functionality.
+//
+// This package implements validation of how workloads declare and mount their
+// volumes, detecting conflicts that Kubernetes either rejects at admission time
+// or accepts silently but which break the application at runtime: duplicate
+// volume names, overlapping mount paths, subPaths pointing at missing items,
+// and read-only mounts of volumes the application needs to write to.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/utils"
+)
+
+// WritableVolumesAnnotation lists (comma-separated) the volume names a workload
+// expects to write to. Mounting any of them read-only is reported.
+const WritableVolumesAnnotation = "kogaro.io/writable-volumes"
+
+// VolumeConfig defines which volume validation checks to perform
+type VolumeConfig struct {
+	EnableDuplicateVolumeValidation bool
+	EnableMountPathValidation       bool
+	EnableSubPathValidation         bool
+	EnableReadOnlyMountValidation   bool
+}
+
+// VolumeValidator validates volume declarations and mounts across workloads
+type VolumeValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               VolumeConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+}
+
+// NewVolumeValidator creates a new VolumeValidator with the given client, logger and config
+func NewVolumeValidator(client client.Client, log logr.Logger, config VolumeConfig) *VolumeValidator {
+	return &VolumeValidator{
+		client:       client,
+		log:          log.WithName("volume-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *VolumeValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *VolumeValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *VolumeValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for volume validation
+func (v *VolumeValidator) GetValidationType() string {
+	return "volume_validation"
+}
+
+// ValidateCluster performs volume and mount validation on all workloads in the cluster
+func (v *VolumeValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	var allErrors []ValidationError
+
+	var deployments appsv1.DeploymentList
+	if err := v.client.List(ctx, &deployments); err != nil {
+		return fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, deployment := range deployments.Items {
+		if v.sharedConfig.IsSystemNamespace(deployment.Namespace) {
+			continue
+		}
+		allErrors = append(allErrors, v.validatePodTemplateVolumes(ctx, deployment.Spec.Template, "Deployment", deployment.Name, deployment.Namespace)...)
+	}
+
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if v.sharedConfig.IsSystemNamespace(statefulSet.Namespace) {
+			continue
+		}
+		allErrors = append(allErrors, v.validatePodTemplateVolumes(ctx, statefulSet.Spec.Template, "StatefulSet", statefulSet.Name, statefulSet.Namespace)...)
+	}
+
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, daemonSet := range daemonSets.Items {
+		if v.sharedConfig.IsSystemNamespace(daemonSet.Namespace) {
+			continue
+		}
+		allErrors = append(allErrors, v.validatePodTemplateVolumes(ctx, daemonSet.Spec.Template, "DaemonSet", daemonSet.Name, daemonSet.Namespace)...)
+	}
+
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		// Pods managed by controllers are validated via their controllers
+		if v.sharedConfig.IsSystemNamespace(pod.Namespace) || utils.HasOwnerReferences(*pod) {
+			return nil
+		}
+		template := corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}
+		allErrors = append(allErrors, v.validatePodTemplateVolumes(ctx, template, "Pod", pod.Name, pod.Namespace)...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "volume", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "volume", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+func (v *VolumeValidator) validatePodTemplateVolumes(ctx context.Context, template corev1.PodTemplateSpec, resourceType, resourceName, namespace string) []ValidationError {
+	var errors []ValidationError
+
+	volumes := make(map[string]corev1.Volume, len(template.Spec.Volumes))
+	reportedDuplicates := make(map[string]bool)
+	for _, volume := range template.Spec.Volumes {
+		if _, exists := volumes[volume.Name]; exists {
+			if v.config.EnableDuplicateVolumeValidation && !reportedDuplicates[volume.Name] {
+				reportedDuplicates[volume.Name] = true
+				errorCode := GetVolumeErrorCode("duplicate_volume_name")
+				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "duplicate_volume_name", errorCode, fmt.Sprintf("Volume name '%s' is declared more than once", volume.Name)).
+					WithSeverity(SeverityError).
+					WithRemediationHint(fmt.Sprintf("Rename or remove the duplicate volume '%s' so each volume in the pod spec has a unique name", volume.Name)).
+					WithDetail("volume_name", volume.Name))
+			}
+			continue
+		}
+		volumes[volume.Name] = volume
+	}
+
+	writable := writableVolumes(template.Annotations)
+
+	containers := make([]corev1.Container, 0, len(template.Spec.InitContainers)+len(template.Spec.Containers))
+	containers = append(containers, template.Spec.InitContainers...)
+	containers = append(containers, template.Spec.Containers...)
+
+	// An emptyDir one container writes to may be mounted read-only by the others, such
+	// as sidecars that ship what it wrote
+	mountedWritable := make(map[string]bool)
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if !mount.ReadOnly {
+				mountedWritable[mount.Name] = true
+			}
+		}
+	}
+
+	for _, container := range containers {
+		if v.config.EnableMountPathValidation {
+			errors = append(errors, v.validateMountPaths(container, resourceType, resourceName, namespace)...)
+		}
+
+		for _, mount := range container.VolumeMounts {
+			volume, exists := volumes[mount.Name]
+			if !exists {
+				continue
+			}
+
+			if v.config.EnableSubPathValidation && mount.SubPath != "" {
+				errors = append(errors, v.validateSubPath(ctx, volume, mount, container.Name, resourceType, resourceName, namespace)...)
+			}
+
+			if v.config.EnableReadOnlyMountValidation && mount.ReadOnly && (writable[mount.Name] || (volume.EmptyDir != nil && !mountedWritable[mount.Name])) {
+				reason := "volume is an emptyDir no container mounts writable"
+				hint := fmt.Sprintf("Remove readOnly: true from the '%s' mount in container '%s', or in the container that should fill the volume, since an emptyDir no container writes to stays empty", mount.Name, container.Name)
+				if writable[mount.Name] {
+					reason = fmt.Sprintf("volume is listed in the %s annotation", WritableVolumesAnnotation)
+					hint = fmt.Sprintf("Remove readOnly: true from the '%s' mount in container '%s', or drop the volume from the %s annotation if writes are not needed", mount.Name, container.Name, WritableVolumesAnnotation)
+				}
+				errorCode := GetVolumeErrorCode("readonly_mount_expected_writable")
+				errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "readonly_mount_expected_writable", errorCode, fmt.Sprintf("Container '%s' mounts volume '%s' read-only at '%s' but the application expects to write to it", container.Name, mount.Name, mount.MountPath)).
+					WithSeverity(SeverityWarning).
+					WithRemediationHint(hint).
+					WithDetail("container_name", container.Name).
+					WithDetail("volume_name", mount.Name).
+					WithDetail("mount_path", mount.MountPath).
+					WithDetail("reason", reason))
+			}
+		}
+	}
+
+	return errors
+}
+
+func (v *VolumeValidator) validateMountPaths(container corev1.Container, resourceType, resourceName, namespace string) []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[string]string)
+	for _, mount := range container.VolumeMounts {
+		mountPath := path.Clean(mount.MountPath)
+		previous, exists := seen[mountPath]
+		if !exists {
+			seen[mountPath] = mount.Name
+			continue
+		}
+
+		errorCode := GetVolumeErrorCode("duplicate_mount_path")
+		errors = append(errors, NewValidationErrorWithCode(resourceType, resourceName, namespace, "duplicate_mount_path", errorCode, fmt.Sprintf("Container '%s' mounts volumes '%s' and '%s' at the same path '%s'", container.Name, previous, mount.Name, mountPath)).
+			WithSeverity(SeverityError).
+			WithRemediationHint(fmt.Sprintf("Give each volume mount in container '%s' a distinct mountPath, or use subPath to place files from both volumes under '%s'", container.Name, mountPath)).
+			WithDetail("container_name", container.Name).
+			WithDetail("mount_path", mountPath).
+			WithDetail("conflicting_volumes", fmt.Sprintf("%s,%s", previous, mount.Name)))
+	}
+
+	return errors
+}
+
+func (v *VolumeValidator) validateSubPath(ctx context.Context, volume corev1.Volume, mount corev1.VolumeMount, containerName, resourceType, resourceName, namespace string) []ValidationError {
+	available, sourceRef, known := v.subPathEntries(ctx, volume, namespace)
+	if !known {
+		return nil
+	}
+
+	// A subPath may point at an item itself or at a directory containing items
+	subPath := path.Clean(mount.SubPath)
+	for _, entry := range available {
+		if entry == subPath || strings.HasPrefix(entry, subPath+"/") {
+			return nil
+		}
+	}
+
+	errorCode := GetVolumeErrorCode("subpath_item_missing")
+	validationError := NewValidationErrorWithCode(resourceType, resourceName, namespace, "subpath_item_missing", errorCode, fmt.Sprintf("Container '%s' mounts subPath '%s' which is not provided by volume '%s'", containerName, mount.SubPath, volume.Name)).
+		WithSeverity(SeverityError).
+		WithRemediationHint(fmt.Sprintf("Set subPath to one of the items provided by volume '%s' (%s), or add the missing item to its source", volume.Name, strings.Join(available, ", "))).
+		WithDetail("container_name", containerName).
+		WithDetail("volume_name", volume.Name).
+		WithDetail("sub_path", mount.SubPath)
+	if sourceRef != "" {
+		return []ValidationError{*validationError.WithRelatedResources(sourceRef)}
+	}
+	return []ValidationError{validationError}
+}
+
+// subPathEntries returns the file paths a ConfigMap, Secret or projected volume
+// exposes. known is false when the volume contents cannot be determined, in which
+// case subPath validation is skipped rather than guessed.
+func (v *VolumeValidator) subPathEntries(ctx context.Context, volume corev1.Volume, namespace string) ([]string, string, bool) {
+	switch {
+	case volume.ConfigMap != nil:
+		if len(volume.ConfigMap.Items) > 0 {
+			return keyToPathEntries(volume.ConfigMap.Items), fmt.Sprintf("ConfigMap/%s", volume.ConfigMap.Name), true
+		}
+		keys, found := v.configMapKeys(ctx, volume.ConfigMap.Name, namespace)
+		return keys, fmt.Sprintf("ConfigMap/%s", volume.ConfigMap.Name), found
+
+	case volume.Secret != nil:
+		if len(volume.Secret.Items) > 0 {
+			return keyToPathEntries(volume.Secret.Items), fmt.Sprintf("Secret/%s", volume.Secret.SecretName), true
+		}
+		keys, found := v.secretKeys(ctx, volume.Secret.SecretName, namespace)
+		return keys, fmt.Sprintf("Secret/%s", volume.Secret.SecretName), found
+
+	case volume.Projected != nil:
+		var entries []string
+		for _, source := range volume.Projected.Sources {
+			switch {
+			case source.ConfigMap != nil:
+				if len(source.ConfigMap.Items) > 0 {
+					entries = append(entries, keyToPathEntries(source.ConfigMap.Items)...)
+					continue
+				}
+				keys, found := v.configMapKeys(ctx, source.ConfigMap.Name, namespace)
+				if !found {
+					return nil, "", false
+				}
+				entries = append(entries, keys...)
+			case source.Secret != nil:
+				if len(source.Secret.Items) > 0 {
+					entries = append(entries, keyToPathEntries(source.Secret.Items)...)
+					continue
+				}
+				keys, found := v.secretKeys(ctx, source.Secret.Name, namespace)
+				if !found {
+					return nil, "", false
+				}
+				entries = append(entries, keys...)
+			case source.ServiceAccountToken != nil:
+				entries = append(entries, path.Clean(source.ServiceAccountToken.Path))
+			case source.DownwardAPI != nil:
+				for _, item := range source.DownwardAPI.Items {
+					entries = append(entries, path.Clean(item.Path))
+				}
+			default:
+				// Sources such as clusterTrustBundle have contents we cannot enumerate
+				return nil, "", false
+			}
+		}
+		return entries, "", true
+	}
+
+	return nil, "", false
+}
+
+func (v *VolumeValidator) configMapKeys(ctx context.Context, name, namespace string) ([]string, bool) {
+	var configMap corev1.ConfigMap
+	if err := v.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &configMap); err != nil {
+		// Missing ConfigMaps are reported by the reference validator
+		return nil, false
+	}
+	keys := make([]string, 0, len(configMap.Data)+len(configMap.BinaryData))
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+	for key := range configMap.BinaryData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, true
+}
+
+func (v *VolumeValidator) secretKeys(ctx context.Context, name, namespace string) ([]string, bool) {
+	// Without Secret data the keys are unknown, so subPaths are not checked
+	if !secretDataReadable() {
+		return nil, false
+	}
+	var secret corev1.Secret
+	if err := v.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret); err != nil {
+		// Missing Secrets are reported by the reference validator
+		return nil, false
+	}
+	keys := make([]string, 0, len(secret.Data)+len(secret.StringData))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	for key := range secret.StringData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, true
+}
+
+// keyToPathEntries returns the file paths projected by explicit volume items
+func keyToPathEntries(items []corev1.KeyToPath) []string {
+	entries := make([]string, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, path.Clean(item.Path))
+	}
+	return entries
+}
+
+// writableVolumes parses the writable-volumes annotation into a set of volume names
+func writableVolumes(annotations map[string]string) map[string]bool {
+	writable := make(map[string]bool)
+	for _, name := range strings.Split(annotations[WritableVolumesAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			writable[name] = true
+		}
+	}
+	return writable
+}
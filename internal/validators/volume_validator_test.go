@@ -0,0 +1,244 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestVolumeValidator_GetValidationType(t *testing.T) {
+	validator := NewVolumeValidator(nil, logr.Discard(), VolumeConfig{})
+	if got := validator.GetValidationType(); got != "volume_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "volume_validation")
+	}
+}
+
+func TestVolumeValidator_ValidateCluster(t *testing.T) {
+	configMapVolume := func(name, configMap string, items ...corev1.KeyToPath) corev1.Volume {
+		return corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
+					Items:                items,
+				},
+			},
+		}
+	}
+	emptyDirVolume := func(name string) corev1.Volume {
+		return corev1.Volume{Name: name, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}
+	}
+	deployment := func(annotations map[string]string, volumes []corev1.Volume, mounts ...corev1.VolumeMount) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "test-ns"},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+					Spec: corev1.PodSpec{
+						Volumes:    volumes,
+						Containers: []corev1.Container{{Name: "app", Image: "nginx", VolumeMounts: mounts}},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErrors []string
+	}{
+		{
+			name: "well-formed volumes",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "test-ns"},
+					Data:       map[string]string{"app.yaml": "key: value"},
+				},
+				deployment(nil,
+					[]corev1.Volume{configMapVolume("config", "app-config"), emptyDirVolume("cache")},
+					corev1.VolumeMount{Name: "config", MountPath: "/etc/app/app.yaml", SubPath: "app.yaml", ReadOnly: true},
+					corev1.VolumeMount{Name: "cache", MountPath: "/var/cache"},
+				),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "duplicate volume name",
+			objects: []client.Object{
+				deployment(nil, []corev1.Volume{emptyDirVolume("data"), emptyDirVolume("data")}),
+			},
+			expectedErrors: []string{"duplicate_volume_name"},
+		},
+		{
+			name: "two volumes mounted at the same path",
+			objects: []client.Object{
+				deployment(nil,
+					[]corev1.Volume{emptyDirVolume("data"), emptyDirVolume("scratch")},
+					corev1.VolumeMount{Name: "data", MountPath: "/data"},
+					corev1.VolumeMount{Name: "scratch", MountPath: "/data/"},
+				),
+			},
+			expectedErrors: []string{"duplicate_mount_path"},
+		},
+		{
+			name: "subPath not in configmap items",
+			objects: []client.Object{
+				deployment(nil,
+					[]corev1.Volume{configMapVolume("config", "app-config", corev1.KeyToPath{Key: "app.yaml", Path: "conf/app.yaml"})},
+					corev1.VolumeMount{Name: "config", MountPath: "/etc/app/conf", SubPath: "conf"},
+					corev1.VolumeMount{Name: "config", MountPath: "/etc/app/settings.yaml", SubPath: "settings.yaml"},
+				),
+			},
+			expectedErrors: []string{"subpath_item_missing"},
+		},
+		{
+			name: "subPath not in configmap keys",
+			objects: []client.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "test-ns"},
+					Data:       map[string]string{"app.yaml": "key: value"},
+				},
+				deployment(nil,
+					[]corev1.Volume{configMapVolume("config", "app-config")},
+					corev1.VolumeMount{Name: "config", MountPath: "/etc/app/app.yml", SubPath: "app.yml"},
+				),
+			},
+			expectedErrors: []string{"subpath_item_missing"},
+		},
+		{
+			name: "subPath skipped when configmap is missing",
+			objects: []client.Object{
+				deployment(nil,
+					[]corev1.Volume{configMapVolume("config", "missing-config")},
+					corev1.VolumeMount{Name: "config", MountPath: "/etc/app/app.yaml", SubPath: "app.yaml"},
+				),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "subPath not in projected sources",
+			objects: []client.Object{
+				deployment(nil,
+					[]corev1.Volume{{
+						Name: "bundle",
+						VolumeSource: corev1.VolumeSource{
+							Projected: &corev1.ProjectedVolumeSource{
+								Sources: []corev1.VolumeProjection{
+									{ServiceAccountToken: &corev1.ServiceAccountTokenProjection{Path: "token"}},
+									{Secret: &corev1.SecretProjection{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "ca"},
+										Items:                []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+									}},
+								},
+							},
+						},
+					}},
+					corev1.VolumeMount{Name: "bundle", MountPath: "/var/run/token", SubPath: "token"},
+					corev1.VolumeMount{Name: "bundle", MountPath: "/var/run/tls.crt", SubPath: "tls.crt"},
+				),
+			},
+			expectedErrors: []string{"subpath_item_missing"},
+		},
+		{
+			name: "read-only emptyDir and annotated writable volume",
+			objects: []client.Object{
+				&corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "uploads", Namespace: "test-ns"},
+				},
+				deployment(map[string]string{WritableVolumesAnnotation: "uploads"},
+					[]corev1.Volume{
+						emptyDirVolume("tmp"),
+						{Name: "uploads", VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "uploads"}}},
+					},
+					corev1.VolumeMount{Name: "tmp", MountPath: "/tmp", ReadOnly: true},
+					corev1.VolumeMount{Name: "uploads", MountPath: "/uploads", ReadOnly: true},
+				),
+			},
+			expectedErrors: []string{"readonly_mount_expected_writable", "readonly_mount_expected_writable"},
+		},
+		{
+			name: "emptyDir written by one container and read by a sidecar",
+			objects: []client.Object{
+				func() client.Object {
+					app := deployment(nil, []corev1.Volume{emptyDirVolume("logs")}, corev1.VolumeMount{Name: "logs", MountPath: "/var/log/app"})
+					app.Spec.Template.Spec.Containers = append(app.Spec.Template.Spec.Containers, corev1.Container{
+						Name:         "shipper",
+						Image:        "fluent-bit",
+						VolumeMounts: []corev1.VolumeMount{{Name: "logs", MountPath: "/logs", ReadOnly: true}},
+					})
+					return app
+				}(),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name: "controller-owned pod is skipped",
+			objects: []client.Object{
+				&corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "app-abc123",
+						Namespace:       "test-ns",
+						OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "app-abc", APIVersion: "apps/v1", UID: "uid"}},
+					},
+					Spec: corev1.PodSpec{
+						Volumes: []corev1.Volume{emptyDirVolume("data"), emptyDirVolume("data")},
+					},
+				},
+			},
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewVolumeValidator(fakeClient, logr.Discard(), VolumeConfig{
+				EnableDuplicateVolumeValidation: true,
+				EnableMountPathValidation:       true,
+				EnableSubPathValidation:         true,
+				EnableReadOnlyMountValidation:   true,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-VOL-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
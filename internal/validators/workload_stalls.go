@@ -0,0 +1,229 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultPodStallTimeout is the default time after which a pending pod or an unset
+// readiness gate is reported
+const DefaultPodStallTimeout = 10 * time.Minute
+
+const (
+	// crashLoopBackOffReason is the waiting reason of containers restarting with back-off
+	crashLoopBackOffReason = "CrashLoopBackOff"
+	// failedSchedulingReason is the reason of the events the scheduler records for pods it cannot place
+	failedSchedulingReason = "FailedScheduling"
+)
+
+// podStall is one kind of stall affecting some of the pods of a workload
+type podStall struct {
+	owner      workloadRef
+	namespace  string
+	stallType  string
+	subject    string
+	reason     string
+	pods       []string
+	ownerTotal int
+}
+
+// validatePodStalls reports workloads whose pods are stuck: readiness gates whose
+// conditions are never set, pods Pending because the scheduler cannot place them, and
+// containers in CrashLoopBackOff. Pods are summarized per owning workload.
+func (v *WorkloadValidator) validatePodStalls(ctx context.Context) ([]ValidationError, error) {
+	resolver := newPodControllerResolver(v.client)
+
+	timeout := v.config.PodStallTimeout
+	if timeout <= 0 {
+		timeout = DefaultPodStallTimeout
+	}
+	stalledBefore := v.now().Add(-timeout)
+
+	podsPerOwner := make(map[string]int)
+	stalls := make(map[string]*podStall)
+	var keys []string
+	record := func(pod corev1.Pod, owner workloadRef, stallType, subject, reason string) {
+		key := strings.Join([]string{pod.Namespace, owner.kind, owner.name, stallType, subject}, "/")
+		stall, ok := stalls[key]
+		if !ok {
+			stall = &podStall{owner: owner, namespace: pod.Namespace, stallType: stallType, subject: subject, reason: reason}
+			stalls[key] = stall
+			keys = append(keys, key)
+		}
+		stall.pods = append(stall.pods, pod.Name)
+	}
+
+	var unscheduled []corev1.Pod
+	err := forEachPod(ctx, v.client, func(pod *corev1.Pod) error {
+		if v.sharedConfig.IsSystemNamespace(pod.Namespace) || pod.DeletionTimestamp != nil ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			return nil
+		}
+		owner := resolver.resolve(ctx, *pod)
+		podsPerOwner[pod.Namespace+"/"+owner.kind+"/"+owner.name]++
+		stalled := pod.CreationTimestamp.Time.Before(stalledBefore)
+
+		for _, gate := range pod.Spec.ReadinessGates {
+			if stalled && pod.Spec.NodeName != "" && podCondition(*pod, gate.ConditionType) == nil {
+				record(*pod, owner, "readiness_gate_condition_missing", string(gate.ConditionType), "")
+			}
+		}
+		if stalled && pod.Status.Phase == corev1.PodPending && pod.Spec.NodeName == "" {
+			unscheduled = append(unscheduled, *pod)
+		}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+				record(*pod, owner, "pod_crash_loop_backoff", status.Name, crashLoopReason(status))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	if len(unscheduled) > 0 {
+		failures, err := v.schedulingFailures(ctx, unscheduled)
+		if err != nil {
+			return nil, err
+		}
+		for _, pod := range unscheduled {
+			if message, ok := failures[pod.Namespace+"/"+pod.Name]; ok {
+				record(pod, resolver.resolve(ctx, pod), "pod_unschedulable", "", message)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+	var errors []ValidationError
+	for _, key := range keys {
+		stall := stalls[key]
+		stall.ownerTotal = podsPerOwner[stall.namespace+"/"+stall.owner.kind+"/"+stall.owner.name]
+		errors = append(errors, v.podStallError(stall, timeout))
+	}
+	return errors, nil
+}
+
+// schedulingFailures returns the latest scheduling failure of each unscheduled pod, from
+// FailedScheduling events or, when the events have expired, the PodScheduled condition
+func (v *WorkloadValidator) schedulingFailures(ctx context.Context, pods []corev1.Pod) (map[string]string, error) {
+	failures := make(map[string]string)
+	latest := make(map[string]time.Time)
+
+	namespaces := make(map[string]bool)
+	for _, pod := range pods {
+		namespaces[pod.Namespace] = true
+	}
+	for namespace := range namespaces {
+		var events corev1.EventList
+		if err := v.client.List(ctx, &events, client.InNamespace(namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		for _, event := range events.Items {
+			if event.Reason != failedSchedulingReason || event.InvolvedObject.Kind != "Pod" {
+				continue
+			}
+			key := event.Namespace + "/" + event.InvolvedObject.Name
+			seen := event.LastTimestamp.Time
+			if seen.IsZero() {
+				seen = event.EventTime.Time
+			}
+			if _, ok := failures[key]; !ok || seen.After(latest[key]) {
+				failures[key] = event.Message
+				latest[key] = seen
+			}
+		}
+	}
+
+	for _, pod := range pods {
+		key := pod.Namespace + "/" + pod.Name
+		if _, ok := failures[key]; ok {
+			continue
+		}
+		if condition := podCondition(pod, corev1.PodScheduled); condition != nil &&
+			condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+			failures[key] = condition.Message
+		}
+	}
+	return failures, nil
+}
+
+// podStallError builds the finding summarizing one kind of stall of a workload's pods
+func (v *WorkloadValidator) podStallError(stall *podStall, timeout time.Duration) ValidationError {
+	affected := fmt.Sprintf("%d of %s", len(stall.pods), countOf(stall.ownerTotal, "pod"))
+	if stall.owner.kind == "Pod" {
+		affected = "the pod"
+	}
+
+	var message, hint string
+	severity := SeverityError
+	switch stall.stallType {
+	case "readiness_gate_condition_missing":
+		message = fmt.Sprintf("Readiness gate '%s' has not been set on %s after %s, so the pods never become ready", stall.subject, affected, formatWindow(timeout))
+		hint = fmt.Sprintf("Check that the controller setting the '%s' condition, such as a load balancer controller, is installed and running, or remove the readiness gate", stall.subject)
+		severity = SeverityWarning
+	case "pod_unschedulable":
+		message = fmt.Sprintf("The scheduler cannot place %s, Pending for more than %s: %s", affected, formatWindow(timeout), stall.reason)
+		hint = "Relax the pod's node selector, affinity, tolerations or requests, or add node capacity matching them; the scheduling failure names the constraints no node meets"
+	case "pod_crash_loop_backoff":
+		message = fmt.Sprintf("Container '%s' is in CrashLoopBackOff in %s", stall.subject, affected)
+		if stall.reason != "" {
+			message += "; " + stall.reason
+		}
+		hint = fmt.Sprintf("Check the logs of container '%s' (kubectl logs --previous); crash loops are often caused by missing configuration, failing probes or memory limits below the container's needs", stall.subject)
+	}
+
+	relatedResources := make([]string, 0, len(stall.pods))
+	for _, pod := range stall.pods {
+		relatedResources = append(relatedResources, "Pod/"+pod)
+	}
+	errorCode := GetWorkloadErrorCode(stall.stallType)
+	finding := NewValidationErrorWithCode(stall.owner.kind, stall.owner.name, stall.namespace, stall.stallType, errorCode, message).
+		WithSeverity(severity).
+		WithRemediationHint(hint).
+		WithRelatedResources(relatedResources...).
+		WithDetail("affected_pods", fmt.Sprintf("%d", len(stall.pods)))
+	switch stall.stallType {
+	case "readiness_gate_condition_missing":
+		finding = finding.WithDetail("condition_type", stall.subject)
+	case "pod_unschedulable":
+		finding = finding.WithDetail("scheduling_failure", stall.reason)
+	case "pod_crash_loop_backoff":
+		finding = finding.WithDetail("container_name", stall.subject)
+	}
+	return finding
+}
+
+// podCondition returns the pod's condition of the given type, or nil when it is not set
+func podCondition(pod corev1.Pod, conditionType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == conditionType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// crashLoopReason describes the last termination of a crash-looping container
+func crashLoopReason(status corev1.ContainerStatus) string {
+	terminated := status.LastTerminationState.Terminated
+	if terminated == nil {
+		return ""
+	}
+	if terminated.Reason != "" && terminated.Reason != "Error" {
+		return fmt.Sprintf("last terminated with %s (exit code %d)", terminated.Reason, terminated.ExitCode)
+	}
+	return fmt.Sprintf("last terminated with exit code %d", terminated.ExitCode)
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkloadValidator_ValidatePodStalls(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	controller := true
+	pod := func(name, owner string, age time.Duration) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", CreationTimestamp: metav1.NewTime(now.Add(-age))},
+			Spec:       corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "app", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}}},
+			},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		return pod
+	}
+	gated := func(pod *corev1.Pod) *corev1.Pod {
+		pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: "target-health.elbv2.k8s.aws/api"}}
+		return pod
+	}
+	pending := func(pod *corev1.Pod) *corev1.Pod {
+		pod.Spec.NodeName = ""
+		pod.Status = corev1.PodStatus{Phase: corev1.PodPending}
+		return pod
+	}
+	crashLooping := func(pod *corev1.Pod) *corev1.Pod {
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:                 "app",
+			State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+			LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+		}}
+		return pod
+	}
+	replicaSet := func(name, deployment string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "shop",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment, Controller: &controller}},
+		}}
+	}
+
+	objects := []client.Object{
+		replicaSet("api-7c9d", "api"),
+		replicaSet("worker-5b8f", "worker"),
+		// Readiness gate never set by the load balancer controller
+		gated(pod("api-7c9d-a", "api-7c9d", time.Hour)),
+		gated(pod("api-7c9d-b", "api-7c9d", time.Hour)),
+		// One of two replicas crash-looping
+		crashLooping(pod("worker-5b8f-a", "worker-5b8f", time.Hour)),
+		pod("worker-5b8f-b", "worker-5b8f", time.Hour),
+		// Unschedulable for longer than the timeout
+		pending(pod("batch", "", time.Hour)),
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "batch.1", Namespace: "shop"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "batch", Namespace: "shop"},
+			Reason:         "FailedScheduling",
+			Message:        "0/3 nodes are available: 3 Insufficient memory.",
+			LastTimestamp:  metav1.NewTime(now.Add(-time.Minute)),
+		},
+		// Too young to be reported
+		pending(pod("fresh", "", time.Minute)),
+		gated(pod("rollout", "", time.Minute)),
+		// Gate condition set
+		func() *corev1.Pod {
+			pod := gated(pod("ready", "", time.Hour))
+			pod.Status.Conditions = []corev1.PodCondition{{Type: "target-health.elbv2.k8s.aws/api", Status: corev1.ConditionTrue}}
+			return pod
+		}(),
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	validator := NewWorkloadValidator(fakeClient, logr.Discard(), WorkloadConfig{
+		EnablePodStallValidation: true,
+		PodStallTimeout:          DefaultPodStallTimeout,
+	})
+	validator.now = func() time.Time { return now }
+	validator.SetLogReceiver(&MockLogReceiver{})
+	if err := validator.ValidateCluster(context.Background()); err != nil {
+		t.Fatalf("ValidateCluster() error = %v", err)
+	}
+
+	findings := make(map[string]ValidationError)
+	var got []string
+	for _, finding := range validator.GetLastValidationErrors() {
+		key := finding.ValidationType + " " + finding.ResourceType + "/" + finding.ResourceName
+		findings[key] = finding
+		got = append(got, key)
+	}
+	sort.Strings(got)
+
+	expected := []string{
+		"pod_crash_loop_backoff Deployment/worker",
+		"pod_unschedulable Pod/batch",
+		"readiness_gate_condition_missing Deployment/api",
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("findings = %v, want %v", got, expected)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("finding %d = %q, want %q", i, got[i], expected[i])
+		}
+	}
+
+	wantMessages := map[string]string{
+		"pod_crash_loop_backoff Deployment/worker":        "Container 'app' is in CrashLoopBackOff in 1 of 2 pods; last terminated with exit code 1",
+		"pod_unschedulable Pod/batch":                     "The scheduler cannot place the pod, Pending for more than 10m: 0/3 nodes are available: 3 Insufficient memory.",
+		"readiness_gate_condition_missing Deployment/api": "Readiness gate 'target-health.elbv2.k8s.aws/api' has not been set on 2 of 2 pods after 10m, so the pods never become ready",
+	}
+	for key, want := range wantMessages {
+		if findings[key].Message != want {
+			t.Errorf("%s message = %q, want %q", key, findings[key].Message, want)
+		}
+	}
+	if related := findings["readiness_gate_condition_missing Deployment/api"].RelatedResources; len(related) != 2 {
+		t.Errorf("related resources = %v, want both gated pods", related)
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+// Package validators provides workload-kind specific validation functionality.
+//
+// This package implements checks that only make sense for a particular workload
+// controller, beyond the container checks all workloads share: StatefulSets whose
+// governing Service is missing or not headless, volumeClaimTemplates requesting
+// StorageClasses that do not exist, OnDelete update strategies nobody explained,
+// DaemonSets using the host network without saying why, and DaemonSet rolling
+// updates that cannot progress or take every node's pod down at once. It also
+// reports workloads whose pods are stuck at runtime because of their configuration.
+package validators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/topiaruss/kogaro/internal/metrics"
+)
+
+// OnDeleteReasonAnnotation documents why a workload uses the OnDelete update strategy.
+// OnDelete workloads without it are reported.
+const OnDeleteReasonAnnotation = "kogaro.io/on-delete-reason"
+
+// HostAccessReasonAnnotation documents why a DaemonSet node agent needs host access.
+// Annotated DaemonSets may use hostNetwork and privileged containers without findings.
+const HostAccessReasonAnnotation = "kogaro.io/host-access-reason"
+
+// WorkloadConfig defines which workload validation checks to perform
+type WorkloadConfig struct {
+	EnableStatefulSetValidation bool
+	EnableDaemonSetValidation   bool
+	// Enable detection of pods stuck on readiness gates, scheduling or CrashLoopBackOff
+	EnablePodStallValidation bool
+	// Time after which pending pods and unset readiness gates are reported
+	PodStallTimeout time.Duration
+}
+
+// WorkloadValidator validates settings specific to workload controller kinds
+type WorkloadValidator struct {
+	client               client.Client
+	log                  logr.Logger
+	config               WorkloadConfig
+	sharedConfig         SharedConfig
+	lastValidationErrors []ValidationError
+	logReceiver          LogReceiver
+
+	// For testing
+	now func() time.Time
+}
+
+// NewWorkloadValidator creates a new WorkloadValidator with the given client, logger and config
+func NewWorkloadValidator(client client.Client, log logr.Logger, config WorkloadConfig) *WorkloadValidator {
+	return &WorkloadValidator{
+		client:       client,
+		log:          log.WithName("workload-validator"),
+		config:       config,
+		sharedConfig: ActiveSharedConfig(),
+		now:          time.Now,
+	}
+}
+
+// SetClient updates the client used by the validator
+func (v *WorkloadValidator) SetClient(c client.Client) {
+	v.client = c
+}
+
+// SetLogReceiver updates the log receiver used by the validator
+func (v *WorkloadValidator) SetLogReceiver(lr LogReceiver) {
+	v.logReceiver = lr
+}
+
+// GetLastValidationErrors returns the errors from the last validation run
+func (v *WorkloadValidator) GetLastValidationErrors() []ValidationError {
+	return v.lastValidationErrors
+}
+
+// GetValidationType returns the validation type identifier for workload validation
+func (v *WorkloadValidator) GetValidationType() string {
+	return "workload_validation"
+}
+
+// ValidateCluster performs workload-kind specific validation across the cluster
+func (v *WorkloadValidator) ValidateCluster(ctx context.Context) error {
+	metrics.ValidationRuns.Inc()
+
+	var allErrors []ValidationError
+
+	if v.config.EnableStatefulSetValidation {
+		statefulSetErrors, err := v.validateStatefulSets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate statefulsets: %w", err)
+		}
+		allErrors = append(allErrors, statefulSetErrors...)
+	}
+
+	if v.config.EnableDaemonSetValidation {
+		daemonSetErrors, err := v.validateDaemonSets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate daemonsets: %w", err)
+		}
+		allErrors = append(allErrors, daemonSetErrors...)
+	}
+
+	if v.config.EnablePodStallValidation {
+		stallErrors, err := v.validatePodStalls(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to validate pod stalls: %w", err)
+		}
+		allErrors = append(allErrors, stallErrors...)
+	}
+
+	// Log all validation errors and update metrics
+	LogAndRecordErrors(v.logReceiver, "workload", allErrors)
+
+	v.log.Info("validation completed", "validator_type", "workload", "total_errors", len(allErrors))
+
+	// Store errors for CLI reporting
+	v.lastValidationErrors = allErrors
+	return nil
+}
+
+// validateStatefulSets checks the governing Service, volumeClaimTemplates and update
+// strategy of every StatefulSet
+func (v *WorkloadValidator) validateStatefulSets(ctx context.Context) ([]ValidationError, error) {
+	var statefulSets appsv1.StatefulSetList
+	if err := v.client.List(ctx, &statefulSets); err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+
+	var services corev1.ServiceList
+	if err := v.client.List(ctx, &services); err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+	servicesByKey := make(map[string]corev1.Service, len(services.Items))
+	for _, service := range services.Items {
+		servicesByKey[service.Namespace+"/"+service.Name] = service
+	}
+
+	var storageClasses storagev1.StorageClassList
+	if err := v.client.List(ctx, &storageClasses); err != nil {
+		return nil, fmt.Errorf("failed to list storage classes: %w", err)
+	}
+	existingClasses := make(map[string]bool, len(storageClasses.Items))
+	for _, storageClass := range storageClasses.Items {
+		existingClasses[storageClass.Name] = true
+	}
+
+	var errors []ValidationError
+	for _, statefulSet := range statefulSets.Items {
+		if v.sharedConfig.IsSystemNamespace(statefulSet.Namespace) {
+			continue
+		}
+
+		errors = append(errors, v.validateGoverningService(statefulSet, servicesByKey)...)
+
+		for _, template := range statefulSet.Spec.VolumeClaimTemplates {
+			if template.Spec.StorageClassName == nil || *template.Spec.StorageClassName == "" {
+				continue
+			}
+			className := *template.Spec.StorageClassName
+			if existingClasses[className] {
+				continue
+			}
+			errors = append(errors, NewValidationErrorWithCode("StatefulSet", statefulSet.Name, statefulSet.Namespace, "statefulset_dangling_storage_class", GetWorkloadErrorCode("statefulset_dangling_storage_class"),
+				fmt.Sprintf("StorageClass '%s' requested by volumeClaimTemplate '%s' does not exist", className, template.Name)).
+				WithSeverity(SeverityError).
+				WithRemediationHint(fmt.Sprintf("Create StorageClass '%s' or recreate the StatefulSet with a volumeClaimTemplate that uses an existing StorageClass; new replicas stay Pending until their claims bind", className)).
+				WithRelatedResources(fmt.Sprintf("StorageClass/%s", className)).
+				WithDetail("missing_storage_class", className).
+				WithDetail("volume_claim_template", template.Name))
+		}
+
+		if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType && statefulSet.Annotations[OnDeleteReasonAnnotation] == "" {
+			errors = append(errors, NewValidationErrorWithCode("StatefulSet", statefulSet.Name, statefulSet.Namespace, "statefulset_ondelete_without_reason", GetWorkloadErrorCode("statefulset_ondelete_without_reason"),
+				fmt.Sprintf("StatefulSet '%s' uses the OnDelete update strategy without a documented reason", statefulSet.Name)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(fmt.Sprintf("Switch to the RollingUpdate strategy, or explain why pods must be replaced by hand in the %s annotation; template changes are not rolled out until each pod is deleted", OnDeleteReasonAnnotation)))
+		}
+	}
+
+	return errors, nil
+}
+
+// validateGoverningService checks that the Service named by serviceName exists and is
+// headless, since it provides the stable network identity of the StatefulSet's pods
+func (v *WorkloadValidator) validateGoverningService(statefulSet appsv1.StatefulSet, servicesByKey map[string]corev1.Service) []ValidationError {
+	serviceName := statefulSet.Spec.ServiceName
+	if serviceName == "" {
+		return []ValidationError{NewValidationErrorWithCode("StatefulSet", statefulSet.Name, statefulSet.Namespace, "statefulset_missing_service_name", GetWorkloadErrorCode("statefulset_missing_service_name"),
+			fmt.Sprintf("StatefulSet '%s' does not set serviceName, so its pods get no stable DNS names", statefulSet.Name)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint("Create a headless Service (clusterIP: None) selecting the StatefulSet's pods and set spec.serviceName to its name")}
+	}
+
+	service, exists := servicesByKey[statefulSet.Namespace+"/"+serviceName]
+	if !exists {
+		return []ValidationError{NewValidationErrorWithCode("StatefulSet", statefulSet.Name, statefulSet.Namespace, "statefulset_dangling_service", GetWorkloadErrorCode("statefulset_dangling_service"),
+			fmt.Sprintf("Governing Service '%s' of StatefulSet '%s' does not exist", serviceName, statefulSet.Name)).
+			WithSeverity(SeverityError).
+			WithRemediationHint(fmt.Sprintf("Create headless Service '%s' (clusterIP: None) in namespace '%s' selecting the StatefulSet's pods", serviceName, statefulSet.Namespace)).
+			WithRelatedResources(fmt.Sprintf("Service/%s", serviceName)).
+			WithDetail("missing_service", serviceName)}
+	}
+
+	if service.Spec.ClusterIP != corev1.ClusterIPNone {
+		return []ValidationError{NewValidationErrorWithCode("StatefulSet", statefulSet.Name, statefulSet.Namespace, "statefulset_service_not_headless", GetWorkloadErrorCode("statefulset_service_not_headless"),
+			fmt.Sprintf("Governing Service '%s' of StatefulSet '%s' is not headless", serviceName, statefulSet.Name)).
+			WithSeverity(SeverityWarning).
+			WithRemediationHint(fmt.Sprintf("Recreate Service '%s' with clusterIP: None, or point serviceName at a headless Service; per-pod DNS records are only published for headless Services", serviceName)).
+			WithRelatedResources(fmt.Sprintf("Service/%s", serviceName)).
+			WithDetail("service_type", string(service.Spec.Type)).
+			WithDetail("cluster_ip", service.Spec.ClusterIP)}
+	}
+
+	return nil
+}
+
+// validateDaemonSets checks the host network use and rolling update settings of every DaemonSet
+func (v *WorkloadValidator) validateDaemonSets(ctx context.Context) ([]ValidationError, error) {
+	var daemonSets appsv1.DaemonSetList
+	if err := v.client.List(ctx, &daemonSets); err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+
+	var errors []ValidationError
+	for _, daemonSet := range daemonSets.Items {
+		if v.sharedConfig.IsSystemNamespace(daemonSet.Namespace) {
+			continue
+		}
+
+		if daemonSet.Spec.Template.Spec.HostNetwork && !HasHostAccessReason(daemonSet.ObjectMeta) {
+			errors = append(errors, NewValidationErrorWithCode("DaemonSet", daemonSet.Name, daemonSet.Namespace, "daemonset_host_network_without_reason", GetWorkloadErrorCode("daemonset_host_network_without_reason"),
+				fmt.Sprintf("DaemonSet '%s' uses the host network without a documented reason", daemonSet.Name)).
+				WithSeverity(SeverityWarning).
+				WithRemediationHint(fmt.Sprintf("Remove hostNetwork: true if the agent does not need the node's network namespace, or explain why it does in the %s annotation", HostAccessReasonAnnotation)))
+		}
+
+		errors = append(errors, v.validateDaemonSetRollingUpdate(daemonSet)...)
+	}
+
+	return errors, nil
+}
+
+// validateDaemonSetRollingUpdate checks that a RollingUpdate strategy can make progress
+// and does not replace the pods of every node at the same time
+func (v *WorkloadValidator) validateDaemonSetRollingUpdate(daemonSet appsv1.DaemonSet) []ValidationError {
+	strategy := daemonSet.Spec.UpdateStrategy
+	if strategy.Type == appsv1.OnDeleteDaemonSetStrategyType || strategy.RollingUpdate == nil || strategy.RollingUpdate.MaxUnavailable == nil {
+		return nil
+	}
+	maxUnavailable := strategy.RollingUpdate.MaxUnavailable
+	maxSurge := strategy.RollingUpdate.MaxSurge
+
+	// Percentages are scaled against the number of nodes the DaemonSet runs on, rounding
+	// maxUnavailable up as the DaemonSet controller does
+	desired := int(daemonSet.Status.DesiredNumberScheduled)
+	scale := desired
+	if scale == 0 {
+		scale = 100
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, scale, true)
+	if err != nil {
+		return nil
+	}
+	surge := 0
+	if maxSurge != nil {
+		if surge, err = intstr.GetScaledValueFromIntOrPercent(maxSurge, scale, true); err != nil {
+			return nil
+		}
+	}
+
+	newError := func(severity Severity, message, hint string) []ValidationError {
+		return []ValidationError{NewValidationErrorWithCode("DaemonSet", daemonSet.Name, daemonSet.Namespace, "daemonset_invalid_max_unavailable", GetWorkloadErrorCode("daemonset_invalid_max_unavailable"), message).
+			WithSeverity(severity).
+			WithRemediationHint(hint).
+			WithDetail("max_unavailable", maxUnavailable.String()).
+			WithDetail("desired_number_scheduled", fmt.Sprintf("%d", desired))}
+	}
+
+	switch {
+	case unavailable == 0 && surge == 0:
+		return newError(SeverityError, fmt.Sprintf("DaemonSet '%s' rolling update allows neither unavailable nor surge pods, so it cannot progress", daemonSet.Name),
+			"Set maxUnavailable to at least 1, or set maxSurge when pods must stay available during updates")
+	case isWholePercentage(maxUnavailable), desired > 1 && unavailable >= desired:
+		return newError(SeverityWarning, fmt.Sprintf("DaemonSet '%s' rolling update maxUnavailable %s takes the pods of every node down at once", daemonSet.Name, maxUnavailable.String()),
+			"Lower maxUnavailable so that updates roll through the nodes in batches; a bad release otherwise reaches every node before it can be stopped")
+	}
+	return nil
+}
+
+// isWholePercentage reports whether a percentage covers every pod of the workload
+func isWholePercentage(value *intstr.IntOrString) bool {
+	if value.Type != intstr.String {
+		return false
+	}
+	percent, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	return err == nil && percent >= 100
+}
+
+// HasHostAccessReason reports whether a workload documents why it needs host access
+func HasHostAccessReason(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[HostAccessReasonAnnotation] != ""
+}
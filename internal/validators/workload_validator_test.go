@@ -0,0 +1,252 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package validators
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestWorkloadValidator_GetValidationType(t *testing.T) {
+	validator := NewWorkloadValidator(nil, logr.Discard(), WorkloadConfig{})
+	if got := validator.GetValidationType(); got != "workload_validation" {
+		t.Errorf("GetValidationType() = %v, want %v", got, "workload_validation")
+	}
+}
+
+func TestWorkloadValidator_ValidateStatefulSets(t *testing.T) {
+	service := func(name, clusterIP string) *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       corev1.ServiceSpec{ClusterIP: clusterIP},
+		}
+	}
+	statefulSet := func(name, serviceName string, mutate ...func(*appsv1.StatefulSet)) *appsv1.StatefulSet {
+		statefulSet := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec: appsv1.StatefulSetSpec{
+				ServiceName:    serviceName,
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			},
+		}
+		for _, m := range mutate {
+			m(statefulSet)
+		}
+		return statefulSet
+	}
+	claimTemplate := func(storageClass string) func(*appsv1.StatefulSet) {
+		return func(s *appsv1.StatefulSet) {
+			s.Spec.VolumeClaimTemplates = append(s.Spec.VolumeClaimTemplates, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: "data"},
+				Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &storageClass},
+			})
+		}
+	}
+	onDelete := func(reason string) func(*appsv1.StatefulSet) {
+		return func(s *appsv1.StatefulSet) {
+			s.Spec.UpdateStrategy.Type = appsv1.OnDeleteStatefulSetStrategyType
+			if reason != "" {
+				s.Annotations = map[string]string{OnDeleteReasonAnnotation: reason}
+			}
+		}
+	}
+
+	tests := []struct {
+		name           string
+		objects        []client.Object
+		expectedErrors []string
+	}{
+		{
+			name: "statefulset with headless service and existing storage class is valid",
+			objects: []client.Object{
+				service("db", corev1.ClusterIPNone),
+				&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: "fast-ssd"}},
+				statefulSet("db", "db", claimTemplate("fast-ssd"), onDelete("database pods are failed over by the operator")),
+			},
+			expectedErrors: []string{},
+		},
+		{
+			name:           "statefulset without service name",
+			objects:        []client.Object{statefulSet("db", "")},
+			expectedErrors: []string{"statefulset_missing_service_name"},
+		},
+		{
+			name:           "statefulset with missing service",
+			objects:        []client.Object{statefulSet("db", "db-headless")},
+			expectedErrors: []string{"statefulset_dangling_service"},
+		},
+		{
+			name:           "statefulset with cluster ip service",
+			objects:        []client.Object{service("db", "10.0.0.12"), statefulSet("db", "db")},
+			expectedErrors: []string{"statefulset_service_not_headless"},
+		},
+		{
+			name:           "statefulset with missing storage class and unexplained ondelete",
+			objects:        []client.Object{service("db", corev1.ClusterIPNone), statefulSet("db", "db", claimTemplate("fast-ssd"), onDelete(""))},
+			expectedErrors: []string{"statefulset_dangling_storage_class", "statefulset_ondelete_without_reason"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = storagev1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.objects...).
+				Build()
+
+			validator := NewWorkloadValidator(fakeClient, logr.Discard(), WorkloadConfig{
+				EnableStatefulSetValidation: true,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-WKL-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkloadValidator_ValidateDaemonSets(t *testing.T) {
+	daemonSet := func(mutate ...func(*appsv1.DaemonSet)) *appsv1.DaemonSet {
+		daemonSet := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-agent", Namespace: "test-ns"},
+			Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType},
+			},
+			Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 5},
+		}
+		for _, m := range mutate {
+			m(daemonSet)
+		}
+		return daemonSet
+	}
+	hostNetwork := func(reason string) func(*appsv1.DaemonSet) {
+		return func(d *appsv1.DaemonSet) {
+			d.Spec.Template.Spec.HostNetwork = true
+			if reason != "" {
+				d.Annotations = map[string]string{HostAccessReasonAnnotation: reason}
+			}
+		}
+	}
+	rollingUpdate := func(maxUnavailable, maxSurge intstr.IntOrString) func(*appsv1.DaemonSet) {
+		return func(d *appsv1.DaemonSet) {
+			d.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable, MaxSurge: &maxSurge}
+		}
+	}
+
+	tests := []struct {
+		name             string
+		daemonSet        *appsv1.DaemonSet
+		expectedErrors   []string
+		expectedSeverity Severity
+	}{
+		{
+			name:           "daemonset with documented host network and batched updates is valid",
+			daemonSet:      daemonSet(hostNetwork("serves node metrics on the host port"), rollingUpdate(intstr.FromString("20%"), intstr.FromInt32(0))),
+			expectedErrors: []string{},
+		},
+		{
+			name:             "daemonset with undocumented host network",
+			daemonSet:        daemonSet(hostNetwork("")),
+			expectedErrors:   []string{"daemonset_host_network_without_reason"},
+			expectedSeverity: SeverityWarning,
+		},
+		{
+			name:             "daemonset rolling update that cannot progress",
+			daemonSet:        daemonSet(rollingUpdate(intstr.FromInt32(0), intstr.FromInt32(0))),
+			expectedErrors:   []string{"daemonset_invalid_max_unavailable"},
+			expectedSeverity: SeverityError,
+		},
+		{
+			name:             "daemonset rolling update replacing every pod at once",
+			daemonSet:        daemonSet(rollingUpdate(intstr.FromString("100%"), intstr.FromInt32(0))),
+			expectedErrors:   []string{"daemonset_invalid_max_unavailable"},
+			expectedSeverity: SeverityWarning,
+		},
+		{
+			name:             "daemonset maxUnavailable covering every scheduled node",
+			daemonSet:        daemonSet(rollingUpdate(intstr.FromInt32(5), intstr.FromInt32(0))),
+			expectedErrors:   []string{"daemonset_invalid_max_unavailable"},
+			expectedSeverity: SeverityWarning,
+		},
+		{
+			name: "ondelete daemonset is not checked for maxUnavailable",
+			daemonSet: daemonSet(rollingUpdate(intstr.FromInt32(0), intstr.FromInt32(0)), func(d *appsv1.DaemonSet) {
+				d.Spec.UpdateStrategy.Type = appsv1.OnDeleteDaemonSetStrategyType
+			}),
+			expectedErrors: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tt.daemonSet).
+				Build()
+
+			validator := NewWorkloadValidator(fakeClient, logr.Discard(), WorkloadConfig{
+				EnableDaemonSetValidation: true,
+			})
+			validator.SetLogReceiver(&MockLogReceiver{})
+
+			if err := validator.ValidateCluster(context.Background()); err != nil {
+				t.Fatalf("ValidateCluster() error = %v", err)
+			}
+
+			errors := validator.GetLastValidationErrors()
+			if len(errors) != len(tt.expectedErrors) {
+				t.Fatalf("got %d errors, want %d: %+v", len(errors), len(tt.expectedErrors), errors)
+			}
+
+			for i, expectedType := range tt.expectedErrors {
+				if errors[i].ValidationType != expectedType {
+					t.Errorf("error[%d] type = %s, want %s", i, errors[i].ValidationType, expectedType)
+				}
+				if errors[i].Severity != tt.expectedSeverity {
+					t.Errorf("error[%d] severity = %s, want %s", i, errors[i].Severity, tt.expectedSeverity)
+				}
+				if !strings.HasPrefix(errors[i].ErrorCode, "KOGARO-WKL-") || strings.HasSuffix(errors[i].ErrorCode, "UNKNOWN") {
+					t.Errorf("error[%d] has unexpected error code %s", i, errors[i].ErrorCode)
+				}
+			}
+		})
+	}
+}
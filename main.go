@@ -15,41 +15,129 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
 	"strings"
+	"syscall"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
+	"github.com/topiaruss/kogaro/internal/api"
 	"github.com/topiaruss/kogaro/internal/controllers"
+	"github.com/topiaruss/kogaro/internal/grpcapi"
+	"github.com/topiaruss/kogaro/internal/handoff"
 	"github.com/topiaruss/kogaro/internal/metrics"
+	"github.com/topiaruss/kogaro/internal/notify"
+	"github.com/topiaruss/kogaro/internal/remediation"
+	"github.com/topiaruss/kogaro/internal/reporting"
+	"github.com/topiaruss/kogaro/internal/schedule"
 	"github.com/topiaruss/kogaro/internal/validators"
 )
 
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// Destination of the logs, standard error when nil
+	logDestination io.Writer
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 }
 
+// shutdownFlushTimeout is the time allowed after the drain timeout to publish the
+// findings of the last scan and flush notifications
+const shutdownFlushTimeout = 30 * time.Second
+
 // FlagConfig holds all CLI flag values
 type FlagConfig struct {
 	// Manager settings
 	MetricsAddr          string
 	EnableLeaderElection bool
+	HandoffConfigMap     string
 	ProbeAddr            string
 	ScanInterval         string
+	KubeContext          string
+	KubeconfigContexts   string
+	ClustersFile         string
+	ParallelClusters     bool
+	APIAddr              string
+	ComponentLabel       string
+	GRPCAddr             string
+	ShardCount           int
+	ShardIndex           int
+
+	// API rate limiting flags
+	KubeAPIQPS            float64
+	KubeAPIBurst          int
+	ScanAPIBudget         int
+	PodPageSize           int64
+	CacheNamespaces       string
+	CacheLabelSelectors   string
+	CacheFieldSelectors   string
+	SecretMetadataOnly    bool
+	LowPriorityValidators string
+	ScanIntervalJitter    float64
+	ScanSchedule          string
+	QuietHours            string
+	ScheduleTimeZone      string
+	StaleScanIntervals    int
+	ValidatorTimeout      time.Duration
+	DrainTimeout          time.Duration
+	ReportAfterScans      int
+	ResolveAfterScans     int
+
+	// Cluster reporting flags
+	EnableWorkloadAnnotations bool
+	EnableValidationReports   bool
+	EnablePolicyReports       bool
+
+	// Auto-remediation flags
+	EnableAutoRemediation bool
+	AutoRemediationDryRun bool
+
+	// Notification flags
+	PagerDutyRoutingKey  string
+	OpsgenieAPIKey       string
+	OpsgenieAPIURL       string
+	IncidentFilter       string
+	SMTPAddress          string
+	SMTPUsername         string
+	SMTPPassword         string
+	EmailFrom            string
+	DigestRecipients     string
+	DigestSchedule       string
+	WebhookConfig        string
+	JiraURL              string
+	JiraUser             string
+	JiraAPIToken         string
+	JiraProject          string
+	JiraIssueType        string
+	JiraFingerprintField string
+	JiraCloseTransition  string
+	JiraMinAge           time.Duration
+	JiraFilter           string
+
+	// Permission self-check flags
+	EnablePermissionSelfCheck bool
 
 	// Reference validation flags
 	EnableIngressValidation        bool
@@ -57,14 +145,30 @@ type FlagConfig struct {
 	EnableSecretValidation         bool
 	EnablePVCValidation            bool
 	EnableServiceAccountValidation bool
+	EnableUnusedResourceValidation bool
+	UnusedResourceMinAge           time.Duration
+	EnableDownwardAPIValidation    bool
 
 	// Resource limits validation flags
-	EnableResourceLimitsValidation  bool
-	EnableMissingRequestsValidation bool
-	EnableMissingLimitsValidation   bool
-	EnableQoSValidation             bool
-	MinCPURequest                   string
-	MinMemoryRequest                string
+	EnableResourceLimitsValidation    bool
+	EnableMissingRequestsValidation   bool
+	EnableMissingLimitsValidation     bool
+	EnableQoSValidation               bool
+	EnableNodeCapacityValidation      bool
+	EnableOvercommitValidation        bool
+	MaxCPULimitRequestRatio           float64
+	MaxMemoryLimitRequestRatio        float64
+	ForbidCPULimits                   bool
+	EnableVPARecommendationValidation bool
+	VPADeviationPercent               float64
+	EnableUsageValidation             bool
+	UsageLimitThreshold               float64
+	UsageWasteRatio                   float64
+	EnableRestartValidation           bool
+	RestartThreshold                  int
+	OOMKillWindow                     time.Duration
+	MinCPURequest                     string
+	MinMemoryRequest                  string
 
 	// Security validation flags
 	EnableSecurityValidation               bool
@@ -72,21 +176,78 @@ type FlagConfig struct {
 	EnableSecurityContextValidation        bool
 	EnableSecurityServiceAccountValidation bool
 	EnableNetworkPolicyValidation          bool
+	EnableRBACHygieneValidation            bool
+	EnableHostPathValidation               bool
+	EnableHostNamespaceValidation          bool
+	EnableHostPortValidation               bool
+	HostNamespaceAllowedNamespaces         string
 	SecuritySensitiveNamespaces            string
 
 	// Networking validation flags
-	EnableNetworkingValidation         bool
-	EnableNetworkingServiceValidation  bool
-	EnableNetworkingIngressValidation  bool
-	EnableNetworkingPolicyValidation   bool
-	NetworkingPolicyRequiredNamespaces string
-	WarnUnexposedPods                  bool
+	EnableNetworkingValidation          bool
+	EnableNetworkingServiceValidation   bool
+	EnableServiceTopologyValidation     bool
+	EnableNetworkingIngressValidation   bool
+	EnableNetworkingPolicyValidation    bool
+	EnableNetworkingDuplicateValidation bool
+	EnableIngressCollisionValidation    bool
+	IngressCollisionCrossNamespaceOnly  bool
+	EnableIngressTLSValidation          bool
+	RequireIngressTLS                   bool
+	EnableNetworkPolicySimulation       bool
+	EnableNetworkingEgressValidation    bool
+	ClusterCIDRs                        string
+	EnableNetworkingDNSValidation       bool
+	EnableExternalNameResolution        bool
+	DNSLookupTimeout                    time.Duration
+	NetworkingPolicyRequiredNamespaces  string
+	WarnUnexposedPods                   bool
 
 	// Image validation flags
 	EnableImageValidation     bool
 	AllowMissingImages        bool
 	AllowArchitectureMismatch bool
 
+	// Secret hygiene validation flags
+	EnableSecretHygieneValidation bool
+	CertExpiryWarningDays         int
+
+	// Volume validation flags
+	EnableVolumeValidation         bool
+	EnableVolumeSubPathValidation  bool
+	EnableVolumeReadOnlyValidation bool
+
+	// Quota validation flags
+	EnableQuotaValidation bool
+
+	// Lifecycle validation flags
+	EnableLifecycleValidation bool
+
+	// Workload validation flags
+	EnableWorkloadValidation    bool
+	EnableStatefulSetValidation bool
+	EnableDaemonSetValidation   bool
+	EnablePodStallValidation    bool
+	PodStallTimeout             time.Duration
+
+	// Availability validation flags
+	EnableAvailabilityValidation bool
+
+	// PolicyReport validation flags
+	EnablePolicyReportValidation bool
+
+	// Custom rule flags
+	CustomRulesFile      string
+	CustomRulesConfigMap string
+
+	// Plugin flags
+	PluginDir     string
+	PluginTimeout time.Duration
+
+	// Validation policy flags
+	PolicyFile               string
+	EnableValidationPolicies bool
+
 	// Validate command flags
 	ValidateMode     string
 	ValidateConfig   string
@@ -94,6 +255,18 @@ type FlagConfig struct {
 	ValidateInterval string
 	ValidateOutput   string
 	ValidateScope    string
+	OutputFile       string
+	BaselineFile     string
+	GitOps           bool
+	SuggestPatches   string
+	Strict           bool
+	Watch            string
+	ChangedFiles     string
+	Offline          bool
+
+	// ComplianceFramework is set by kogaro report to the framework whose controls the
+	// findings are summarised by
+	ComplianceFramework string
 }
 
 // registerFlags defines and parses all CLI flags
@@ -105,7 +278,61 @@ func registerFlags() *FlagConfig {
 	flag.BoolVar(&config.EnableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&config.HandoffConfigMap, "handoff-configmap", "", "ConfigMap, as name in Kogaro's namespace or namespace/name, in which the leader saves its findings and open incidents after each scan, so that the next leader resumes them instead of starting from scratch")
 	flag.StringVar(&config.ScanInterval, "scan-interval", "5m", "Interval between cluster scans for reference validation")
+	flag.Float64Var(&config.ScanIntervalJitter, "scan-interval-jitter", 0, "Delay each scan by a random fraction of the scan interval up to this value (e.g. 0.1), so that installations don't scan simultaneously")
+	flag.StringVar(&config.ScanSchedule, "scan-schedule", "", "Cron expression for periodic scans (e.g. '0 */4 * * *'); replaces --scan-interval when set")
+	flag.StringVar(&config.QuietHours, "quiet-hours", "", "Comma-separated windows during which notifications are held back while scans still run (e.g. 'Mon-Fri 18:00-09:00,Sat-Sun')")
+	flag.StringVar(&config.ScheduleTimeZone, "schedule-timezone", "UTC", "IANA time zone --scan-schedule and --quiet-hours are evaluated in (e.g. 'Europe/Berlin')")
+	flag.IntVar(&config.StaleScanIntervals, "readiness-stale-scan-intervals", 3, "Report the controller not ready once this many scan intervals pass without a successful scan (0 to disable)")
+	flag.IntVar(&config.ReportAfterScans, "report-after-scans", 1, "Consecutive cluster scans that must find a finding before it is reported and notified, to suppress flapping findings; per-code values are set by ValidationPolicy flapSuppression")
+	flag.IntVar(&config.ResolveAfterScans, "resolve-after-scans", 1, "Consecutive cluster scans that must no longer find a reported finding before it is resolved")
+	flag.DurationVar(&config.ValidatorTimeout, "validator-timeout", 0, "Maximum time each validator may run during a scan before it is abandoned and reported with a KOGARO-SYS-001 finding; 0 is unlimited")
+	flag.DurationVar(&config.DrainTimeout, "shutdown-drain-timeout", 20*time.Second, "Time the scan in flight at shutdown may take to finish its running validator before it is cut off; the scan's findings are published and failed notifications retried either way")
+	flag.StringVar(&config.KubeContext, "context", "", "Kubeconfig context of the cluster to validate against (defaults to the current context)")
+	flag.StringVar(&config.KubeconfigContexts, "kubeconfig-contexts", "", "Comma-separated kubeconfig contexts of several clusters to validate; findings and metrics are labeled with the context")
+	flag.StringVar(&config.ClustersFile, "clusters-file", "", "Path to a YAML file listing clusters to validate, each with a name, context and optional kubeconfig")
+	flag.BoolVar(&config.ParallelClusters, "parallel-clusters", false, "Validate several clusters in parallel instead of one after another in one-off and monitor modes")
+	flag.IntVar(&config.ShardCount, "shard-count", 1, "Number of replicas that split the cluster's namespaces between them; each replica validates the namespaces that hash to its shard")
+	flag.IntVar(&config.ShardIndex, "shard-index", -1, "Shard validated by this replica, from 0; when negative it is taken from the ordinal suffix of the pod hostname, as in a StatefulSet")
+	flag.Float64Var(&config.KubeAPIQPS, "kube-api-qps", 20, "Maximum sustained queries per second to the Kubernetes API server")
+	flag.IntVar(&config.KubeAPIBurst, "kube-api-burst", 30, "Maximum burst of queries to the Kubernetes API server")
+	flag.IntVar(&config.ScanAPIBudget, "scan-api-budget", 0, "Maximum requests a scan sends to the API server before low-priority validators are skipped; cache reads do not count; 0 is unlimited")
+	flag.Int64Var(&config.PodPageSize, "pod-page-size", 0, "Read Pods from the API server this many at a time instead of holding every Pod in the informer cache, to bound memory on large clusters; 0 caches all Pods. Ignored by CLI validation")
+	flag.StringVar(&config.CacheNamespaces, "cache-namespaces", "", "Comma-separated namespaces whose objects the informer cache holds and validators see; all namespaces when empty")
+	flag.StringVar(&config.CacheLabelSelectors, "cache-label-selectors", "", "Semicolon-separated kind=label-selector entries restricting the objects of a kind the informer cache holds (e.g. 'Secret=kogaro.io/validate=true'); kinds may be qualified by group, as Ingress.networking.k8s.io")
+	flag.StringVar(&config.CacheFieldSelectors, "cache-field-selectors", "", "Semicolon-separated kind=field-selector entries restricting the objects of a kind the informer cache holds (e.g. 'Secret=type!=helm.sh/release.v1')")
+	flag.BoolVar(&config.SecretMetadataOnly, "secret-metadata-only", false, "Read only the metadata of Secrets, so that Kogaro never holds Secret data; references to missing Secrets are still reported, but Secret keys and certificates are not checked. Cannot be combined with --enable-secret-hygiene-validation")
+	flag.StringVar(&config.LowPriorityValidators, "low-priority-validators", "image_validation,quota_validation,lifecycle_validation,plugin:*", "Comma-separated validation types, or prefixes ending in '*', that run last and are skipped once a scan exhausts --scan-api-budget")
+	flag.StringVar(&config.APIAddr, "api-bind-address", "", "The address the findings REST API binds to (e.g. ':8082'). Disabled when empty.")
+	flag.StringVar(&config.ComponentLabel, "component-label", validators.DefaultComponentLabel, "Label naming the component, such as a Backstage component, of a resource or its owners. Findings record it in their details and the findings API serves them by component under /api/v1/components; empty disables")
+	flag.StringVar(&config.GRPCAddr, "grpc-bind-address", "", "The address the findings gRPC streaming API binds to (e.g. ':8083'). Disabled when empty.")
+	flag.BoolVar(&config.EnableWorkloadAnnotations, "enable-workload-annotations", false, "Annotate workloads with a summary of their findings (kogaro.io/validation-summary, kogaro.io/worst-error-code)")
+	flag.BoolVar(&config.EnableValidationReports, "enable-validation-reports", false, "Maintain the status of ValidationReport resources for GitOps health checks")
+	flag.BoolVar(&config.EnablePolicyReports, "enable-policy-reports", false, "Publish findings as wgpolicyk8s.io PolicyReports and a ClusterPolicyReport named kogaro for the Policy Reporter UI and other PolicyReport tooling")
+	flag.BoolVar(&config.EnableAutoRemediation, "enable-auto-remediation", false, "Apply safe defaults to workloads annotated with kogaro.io/auto-remediate after each scan")
+	flag.BoolVar(&config.AutoRemediationDryRun, "auto-remediation-dry-run", false, "Validate auto-remediation changes with a server-side dry run and record Events without persisting them")
+	flag.StringVar(&config.PagerDutyRoutingKey, "pagerduty-routing-key", "", "Routing key of a PagerDuty Events API v2 integration to open incidents for error-severity findings in (disabled when empty)")
+	flag.StringVar(&config.OpsgenieAPIKey, "opsgenie-api-key", "", "Key of an Opsgenie API integration to open alerts for error-severity findings in (disabled when empty)")
+	flag.StringVar(&config.OpsgenieAPIURL, "opsgenie-api-url", notify.OpsgenieAPIURL, "Opsgenie API endpoint (https://api.eu.opsgenie.com for EU accounts)")
+	flag.StringVar(&config.IncidentFilter, "incident-filter", "", "Comma-separated error codes, code prefixes ending in '*' or validation types, each optionally followed by @namespace-glob, of the error-severity findings that open incidents (e.g. 'KOGARO-SEC-*,ingress_no_backend_pods@prod-*'); all when empty")
+	flag.StringVar(&config.SMTPAddress, "smtp-address", "", "Mail server the email digest is sent through, as host:port")
+	flag.StringVar(&config.SMTPUsername, "smtp-username", "", "Username authenticating with the mail server (no authentication when empty)")
+	flag.StringVar(&config.SMTPPassword, "smtp-password", "", "Password authenticating with the mail server")
+	flag.StringVar(&config.EmailFrom, "email-from", "", "Sender address of the email digest")
+	flag.StringVar(&config.DigestRecipients, "email-digest-recipients", "", "Semicolon-separated addresses to email a digest of active findings to, each optionally followed by = and comma-separated namespace globs (e.g. 'sre@example.com;payments@example.com=payments,billing-*'); disabled when empty")
+	flag.StringVar(&config.DigestSchedule, "email-digest-schedule", "0 8 * * *", "Cron expression of when the email digest is sent, in --schedule-timezone (e.g. '0 8 * * 1' or '@weekly')")
+	flag.StringVar(&config.WebhookConfig, "webhook-config", "", "Path to a YAML file of webhooks to post new and resolved findings to")
+	flag.StringVar(&config.JiraURL, "jira-url", "", "URL of a Jira site to create issues for persistent error-severity findings in (disabled when empty)")
+	flag.StringVar(&config.JiraUser, "jira-user", "", "Email of the Jira Cloud account the API token belongs to; when empty, the token is sent as a Jira Data Center personal access token")
+	flag.StringVar(&config.JiraAPIToken, "jira-api-token", "", "Jira API token or personal access token")
+	flag.StringVar(&config.JiraProject, "jira-project", "", "Key of the Jira project issues are created in")
+	flag.StringVar(&config.JiraIssueType, "jira-issue-type", "Bug", "Name of the type of the Jira issues created")
+	flag.StringVar(&config.JiraFingerprintField, "jira-fingerprint-field", "", "ID of the Jira text custom field holding the finding fingerprint, such as customfield_10050")
+	flag.StringVar(&config.JiraCloseTransition, "jira-close-transition", "Done", "Name of the Jira workflow transition that closes the issue of a fixed finding")
+	flag.DurationVar(&config.JiraMinAge, "jira-min-age", 24*time.Hour, "Time scans must report a finding before a Jira issue is created for it")
+	flag.StringVar(&config.JiraFilter, "jira-filter", "", "Comma-separated error codes, code prefixes ending in '*' or validation types, each optionally followed by @namespace-glob, of the error-severity findings that create Jira issues; all when empty")
+	flag.BoolVar(&config.EnablePermissionSelfCheck, "enable-permission-self-check", true, "Audit Kogaro's own ServiceAccount at startup and report permissions beyond those the enabled validators and features need")
 
 	// Reference validation configuration flags
 	flag.BoolVar(&config.EnableIngressValidation, "enable-ingress-validation", true, "Enable validation of Ingress references (IngressClass, Services)")
@@ -113,12 +340,28 @@ func registerFlags() *FlagConfig {
 	flag.BoolVar(&config.EnableSecretValidation, "enable-secret-validation", true, "Enable validation of Secret references (volumes, env, TLS)")
 	flag.BoolVar(&config.EnablePVCValidation, "enable-pvc-validation", true, "Enable validation of PVC and StorageClass references")
 	flag.BoolVar(&config.EnableServiceAccountValidation, "enable-reference-serviceaccount-validation", false, "Enable validation of ServiceAccount references (may be noisy)")
+	flag.BoolVar(&config.EnableUnusedResourceValidation, "enable-unused-resource-validation", false, "Report ConfigMaps, Secrets, PVCs and ServiceAccounts that nothing references as possibly unused (info)")
+	flag.DurationVar(&config.UnusedResourceMinAge, "unused-resource-min-age", 24*time.Hour, "Minimum age before a resource may be reported as unused")
+	flag.BoolVar(&config.EnableDownwardAPIValidation, "enable-downward-api-validation", true, "Enable validation of Downward API fieldRef and resourceFieldRef selectors in env vars and volumes")
 
 	// Resource limits validation configuration flags
 	flag.BoolVar(&config.EnableResourceLimitsValidation, "enable-resource-limits-validation", true, "Enable validation of resource requests and limits")
 	flag.BoolVar(&config.EnableMissingRequestsValidation, "enable-missing-requests-validation", true, "Enable validation for missing resource requests")
 	flag.BoolVar(&config.EnableMissingLimitsValidation, "enable-missing-limits-validation", true, "Enable validation for missing resource limits")
 	flag.BoolVar(&config.EnableQoSValidation, "enable-qos-validation", true, "Enable QoS class analysis and validation")
+	flag.BoolVar(&config.EnableNodeCapacityValidation, "enable-node-capacity-validation", true, "Enable validation of requests and limits against the allocatable capacity of nodes")
+	flag.BoolVar(&config.EnableOvercommitValidation, "enable-overcommit-validation", true, "Enable validation of limit-to-request ratios and memory limits without memory requests")
+	flag.Float64Var(&config.MaxCPULimitRequestRatio, "max-cpu-limit-request-ratio", validators.DefaultMaxCPULimitRequestRatio, "Largest allowed ratio of CPU limit to CPU request (0 disables the check)")
+	flag.Float64Var(&config.MaxMemoryLimitRequestRatio, "max-memory-limit-request-ratio", validators.DefaultMaxMemoryLimitRequestRatio, "Largest allowed ratio of memory limit to memory request (0 disables the check)")
+	flag.BoolVar(&config.ForbidCPULimits, "forbid-cpu-limits", false, "Report CPU limits instead of requiring them, for clusters whose policy is not to set CPU limits")
+	flag.BoolVar(&config.EnableVPARecommendationValidation, "enable-vpa-recommendation-validation", true, "Enable comparison of requests with VerticalPodAutoscaler recommendations when the VPA CRD is installed")
+	flag.Float64Var(&config.VPADeviationPercent, "vpa-deviation-percent", validators.DefaultVPADeviationPercent, "Largest allowed deviation of a request from the VPA recommendation, in percent of the recommendation")
+	flag.BoolVar(&config.EnableUsageValidation, "enable-usage-validation", false, "Enable comparison of requests and limits with pod usage reported by metrics-server")
+	flag.Float64Var(&config.UsageLimitThreshold, "usage-limit-threshold", validators.DefaultUsageLimitThreshold, "Fraction of a limit at which container usage is reported (0 disables the check)")
+	flag.Float64Var(&config.UsageWasteRatio, "usage-waste-ratio", validators.DefaultUsageWasteRatio, "Request-to-usage ratio above which a request is reported as wasted (0 disables the check)")
+	flag.BoolVar(&config.EnableRestartValidation, "enable-restart-validation", true, "Enable OOM kill and restart checks on pod container statuses, correlated with resource findings")
+	flag.IntVar(&config.RestartThreshold, "restart-threshold", validators.DefaultRestartThreshold, "Container restarts across a workload's pods at which the container is reported (0 disables the check)")
+	flag.DurationVar(&config.OOMKillWindow, "oom-kill-window", validators.DefaultOOMKillWindow, "Age up to which OOM kills are reported")
 	flag.StringVar(&config.MinCPURequest, "min-cpu-request", "", "Minimum CPU request threshold (e.g., '10m')")
 	flag.StringVar(&config.MinMemoryRequest, "min-memory-request", "", "Minimum memory request threshold (e.g., '16Mi')")
 
@@ -128,14 +371,31 @@ func registerFlags() *FlagConfig {
 	flag.BoolVar(&config.EnableSecurityContextValidation, "enable-security-context-validation", true, "Enable validation for missing SecurityContext configurations")
 	flag.BoolVar(&config.EnableSecurityServiceAccountValidation, "enable-security-serviceaccount-validation", true, "Enable validation for ServiceAccount excessive permissions")
 	flag.BoolVar(&config.EnableNetworkPolicyValidation, "enable-network-policy-validation", true, "Enable validation for missing NetworkPolicies in sensitive namespaces")
-	flag.StringVar(&config.SecuritySensitiveNamespaces, "security-required-namespaces", "", "Comma-separated list of namespaces that require NetworkPolicies for security validation")
+	flag.BoolVar(&config.EnableHostPathValidation, "enable-host-path-validation", true, "Enable validation for hostPath volumes mounting sensitive host paths such as /, /etc or the container runtime socket")
+	flag.BoolVar(&config.EnableHostNamespaceValidation, "enable-host-namespace-validation", true, "Enable validation for hostNetwork, hostPID and hostIPC outside the allowed namespaces")
+	flag.BoolVar(&config.EnableHostPortValidation, "enable-host-port-validation", true, "Enable validation for DaemonSets claiming the same host port")
+	flag.StringVar(&config.HostNamespaceAllowedNamespaces, "host-namespace-allowed-namespaces", "", "Comma-separated list of namespaces whose workloads may use hostNetwork, hostPID and hostIPC; entries may be names, globs, regular expressions or label selectors")
+	flag.BoolVar(&config.EnableRBACHygieneValidation, "enable-rbac-hygiene-validation", false, "Enable detection of unused Roles, aggregated ClusterRoles matching nothing or themselves, and bindings to unverifiable users and groups")
+	flag.StringVar(&config.SecuritySensitiveNamespaces, "security-required-namespaces", "", "Comma-separated list of namespaces that require NetworkPolicies for security validation; entries may be names, globs (prod-*), regular expressions (team-.*-live) or label selectors (environment=production)")
 
 	// Networking validation configuration flags
 	flag.BoolVar(&config.EnableNetworkingValidation, "enable-networking-validation", true, "Enable networking connectivity validation")
 	flag.BoolVar(&config.EnableNetworkingServiceValidation, "enable-networking-service-validation", true, "Enable validation for Service selector mismatches")
+	flag.BoolVar(&config.EnableServiceTopologyValidation, "enable-service-topology-validation", true, "Enable validation of externalTrafficPolicy Local on sparse nodes, sessionAffinity on headless Services and LoadBalancer Services stuck pending")
 	flag.BoolVar(&config.EnableNetworkingIngressValidation, "enable-networking-ingress-validation", true, "Enable validation for Ingress connectivity issues")
 	flag.BoolVar(&config.EnableNetworkingPolicyValidation, "enable-networking-policy-validation", true, "Enable validation for NetworkPolicy coverage")
-	flag.StringVar(&config.NetworkingPolicyRequiredNamespaces, "networking-required-namespaces", "", "Comma-separated list of namespaces that require NetworkPolicies for networking validation")
+	flag.BoolVar(&config.EnableNetworkingDuplicateValidation, "enable-networking-duplicate-validation", true, "Enable detection of duplicate Services, Ingress rules and NetworkPolicies within a namespace")
+	flag.BoolVar(&config.EnableIngressCollisionValidation, "enable-ingress-collision-validation", true, "Enable detection of Ingresses routing the same host and path, or overlapping wildcard hosts, to different backends")
+	flag.BoolVar(&config.IngressCollisionCrossNamespaceOnly, "ingress-collision-cross-namespace-only", false, "Only report Ingress host collisions between Ingresses in different namespaces")
+	flag.BoolVar(&config.EnableIngressTLSValidation, "enable-ingress-tls-validation", true, "Enable validation of Ingress TLS hosts, TLS Secret types and certificate subject alternative names")
+	flag.BoolVar(&config.RequireIngressTLS, "require-ingress-tls", false, "Warn about Ingress hosts that are served without TLS")
+	flag.BoolVar(&config.EnableNetworkPolicySimulation, "enable-network-policy-simulation", true, "Enable simulation of NetworkPolicies to find workloads with no allowed path to the Services they depend on, and egress rules open to any address")
+	flag.BoolVar(&config.EnableNetworkingEgressValidation, "enable-networking-egress-validation", true, "Enable detection of NetworkPolicies blocking DNS, namespaceSelectors matching no namespace and ipBlocks overlapping pod or Service addresses")
+	flag.StringVar(&config.ClusterCIDRs, "cluster-cidrs", "", "Comma-separated pod and Service CIDRs of the cluster, checked against NetworkPolicy ipBlocks in addition to node pod CIDRs and Service ClusterIPs")
+	flag.BoolVar(&config.EnableNetworkingDNSValidation, "enable-networking-dns-validation", true, "Enable validation of pod dnsPolicy None without dnsConfig and hostAliases that shadow Service names")
+	flag.BoolVar(&config.EnableExternalNameResolution, "enable-external-name-resolution", false, "Resolve the targets of ExternalName Services and report names that do not resolve")
+	flag.DurationVar(&config.DNSLookupTimeout, "dns-lookup-timeout", 5*time.Second, "Timeout for each ExternalName DNS lookup")
+	flag.StringVar(&config.NetworkingPolicyRequiredNamespaces, "networking-required-namespaces", "", "Comma-separated list of namespaces that require NetworkPolicies for networking validation; entries may be names, globs (prod-*), regular expressions (team-.*-live) or label selectors (environment=production)")
 	flag.BoolVar(&config.WarnUnexposedPods, "warn-unexposed-pods", false, "Enable warnings for pods not exposed by any Service")
 
 	// Image validation configuration flags
@@ -143,13 +403,61 @@ func registerFlags() *FlagConfig {
 	flag.BoolVar(&config.AllowMissingImages, "allow-missing-images", false, "Allow deployment even if images are not found in registry")
 	flag.BoolVar(&config.AllowArchitectureMismatch, "allow-architecture-mismatch", false, "Allow deployment even if image architecture doesn't match nodes")
 
+	// Secret hygiene validation configuration flags
+	flag.BoolVar(&config.EnableSecretHygieneValidation, "enable-secret-hygiene-validation", false, "Enable validation of Secret contents (empty referenced Secrets, TLS expiry, dockerconfigjson, basic-auth keys)")
+	flag.IntVar(&config.CertExpiryWarningDays, "cert-expiry-warning-days", validators.DefaultCertificateExpiryWarningDays, "Warn when TLS Secret certificates expire within this many days")
+
+	// Volume validation configuration flags
+	flag.BoolVar(&config.EnableVolumeValidation, "enable-volume-validation", true, "Enable validation of volume declarations and mounts (duplicate names, mount path conflicts)")
+	flag.BoolVar(&config.EnableVolumeSubPathValidation, "enable-volume-subpath-validation", true, "Enable validation that subPath mounts reference items provided by the volume")
+	flag.BoolVar(&config.EnableVolumeReadOnlyValidation, "enable-volume-readonly-validation", true, "Enable warnings for read-only mounts of emptyDir or kogaro.io/writable-volumes volumes")
+
+	// Quota validation configuration flags
+	flag.BoolVar(&config.EnableQuotaValidation, "enable-quota-validation", false, "Enable validation of workloads against ResourceQuotas and LimitRanges")
+
+	// Lifecycle validation configuration flags
+	flag.BoolVar(&config.EnableLifecycleValidation, "enable-lifecycle-validation", false, "Enable validation of ownerReferences and orphaned ReplicaSets")
+
+	// Workload validation configuration flags
+	flag.BoolVar(&config.EnableWorkloadValidation, "enable-workload-validation", true, "Enable validation of settings specific to workload kinds")
+	flag.BoolVar(&config.EnableStatefulSetValidation, "enable-statefulset-validation", true, "Enable validation of StatefulSet governing Services, volumeClaimTemplate StorageClasses and OnDelete update strategies")
+	flag.BoolVar(&config.EnableDaemonSetValidation, "enable-daemonset-validation", true, "Enable validation of DaemonSet host network use and rolling update maxUnavailable")
+	flag.BoolVar(&config.EnablePodStallValidation, "enable-pod-stall-validation", true, "Enable detection of pods stuck on unset readiness gates, scheduling failures or CrashLoopBackOff")
+	flag.DurationVar(&config.PodStallTimeout, "pod-stall-timeout", validators.DefaultPodStallTimeout, "Time after which pending pods and unset readiness gates are reported")
+
+	// Availability validation configuration flags
+	flag.BoolVar(&config.EnableAvailabilityValidation, "enable-availability-validation", false, "Enable validation of replica spread, single-replica production workloads and single-zone pinning")
+
+	// PolicyReport validation configuration flags
+	flag.BoolVar(&config.EnablePolicyReportValidation, "enable-policy-report-validation", false, "Report the failed rules, warnings and evaluation errors recorded in the PolicyReports of policy engines such as Kyverno")
+
+	// Custom rule configuration flags
+	flag.StringVar(&config.CustomRulesFile, "custom-rules-file", "", "Path to a YAML file of custom CEL validation rules")
+	flag.StringVar(&config.CustomRulesConfigMap, "custom-rules-configmap", "", "ConfigMap holding custom CEL validation rules under the rules.yaml key, as namespace/name")
+
+	// Plugin configuration flags
+	flag.StringVar(&config.PluginDir, "plugin-dir", "", "Directory of external validator plugin executables (see the plugin protocol in the README)")
+	flag.DurationVar(&config.PluginTimeout, "plugin-timeout", 30*time.Second, "Maximum time a validator plugin may run per invocation")
+
+	// Validation policy configuration flags
+	flag.StringVar(&config.PolicyFile, "policy-file", "", "Path to a ValidationPolicy manifest of severity overrides and shared configuration; takes precedence over cluster policies")
+	flag.BoolVar(&config.EnableValidationPolicies, "enable-validation-policies", false, "Read severity overrides and shared configuration from ValidationPolicy resources in the cluster at startup")
+
 	// Add validate command flags
 	flag.StringVar(&config.ValidateMode, "mode", "", "Validation mode: one-off or monitor")
-	flag.StringVar(&config.ValidateConfig, "config", "", "Path to configuration file to validate")
+	flag.StringVar(&config.ValidateConfig, "config", "", "Path to configuration file to validate, or a remote config given as oci://registry/repository:tag or an http(s) URL")
 	flag.StringVar(&config.ValidateDuration, "duration", "", "Duration for monitor mode (e.g., 10m)")
 	flag.StringVar(&config.ValidateInterval, "interval", "1m", "Interval between validations in monitor mode")
-	flag.StringVar(&config.ValidateOutput, "output", "text", "Output format: text, json, or yaml")
-	flag.StringVar(&config.ValidateScope, "scope", "all", "Validation scope: all (show all errors) or file-only (show only errors for config file resources)")
+	flag.StringVar(&config.ValidateOutput, "output", "text", "Output format: text, ci, json, yaml, markdown, github, or html")
+	flag.StringVar(&config.ValidateScope, "scope", "all", "Validation scope: all (show all errors), file-only (show only errors for config file resources) or flux-managed (show only errors for config file resources reconciled by Flux)")
+	flag.StringVar(&config.OutputFile, "output-file", "", "Write formatted validation output to this file instead of stdout/stderr")
+	flag.StringVar(&config.SuggestPatches, "suggest-patches", "", "Write ready-to-apply patches and manifests for fixable findings to this directory (one-off mode)")
+	flag.StringVar(&config.BaselineFile, "baseline", "", "Path to a previous --output=json or yaml results file to compare against in markdown output")
+	flag.BoolVar(&config.Strict, "strict", false, "Also fail validation when it finds warnings but no errors, exiting with status 2")
+	flag.StringVar(&config.Watch, "watch", "", "Directory of manifests to watch recursively, re-validating each file against the cluster when it changes; implies --mode=monitor and defaults to --scope=file-only")
+	flag.StringVar(&config.ChangedFiles, "changed-files", "", "File listing changed files one per line, as printed by git diff --name-only, or - for stdin; only the manifests among them are validated, restricted to the --config directory when set; implies --mode=one-off and defaults to --scope=file-only")
+	flag.BoolVar(&config.GitOps, "gitops", false, "Validate a directory of rendered GitOps (Flux) manifests given by --config; implies --mode=one-off and defaults to --scope=flux-managed and --output=json")
+	flag.BoolVar(&config.Offline, "offline", false, "Validate --config without a kubeconfig or any cluster access, running only the checks that need nothing but the config; skipped checks are reported as info findings; implies --mode=one-off")
 
 	opts := zap.Options{
 		Development: true,
@@ -157,15 +465,169 @@ func registerFlags() *FlagConfig {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	if logDestination != nil {
+		opts.DestWriter = logDestination
+	}
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	if config.GitOps {
+		applyGitOpsDefaults(config)
+	}
+	if config.Watch != "" {
+		applyWatchDefaults(config)
+	}
+	if config.ChangedFiles != "" {
+		applyChangedFilesDefaults(config)
+	}
+	if config.Offline {
+		applyOfflineDefaults(config)
+	}
+
 	return config
 }
 
+// explicitFlags returns the names of the flags set on the command line
+func explicitFlags() map[string]bool {
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) {
+		explicit[f.Name] = true
+	})
+	return explicit
+}
+
+// applyGitOpsDefaults configures one-off validation of rendered GitOps manifests,
+// keeping any mode, scope or output format set explicitly on the command line
+func applyGitOpsDefaults(config *FlagConfig) {
+	explicit := explicitFlags()
+
+	if !explicit["mode"] {
+		config.ValidateMode = "one-off"
+	}
+	if !explicit["scope"] {
+		config.ValidateScope = "flux-managed"
+	}
+	if !explicit["output"] {
+		config.ValidateOutput = "json"
+	}
+}
+
+// applyWatchDefaults configures watching a directory of manifests, keeping any mode or
+// scope set explicitly on the command line
+func applyWatchDefaults(config *FlagConfig) {
+	explicit := explicitFlags()
+
+	if !explicit["mode"] {
+		config.ValidateMode = "monitor"
+	}
+	if !explicit["scope"] {
+		config.ValidateScope = "file-only"
+	}
+}
+
+// applyChangedFilesDefaults configures one-off validation of the manifests changed in
+// a commit range, keeping any mode or scope set explicitly on the command line or by
+// --gitops
+func applyChangedFilesDefaults(config *FlagConfig) {
+	explicit := explicitFlags()
+
+	if !explicit["mode"] {
+		config.ValidateMode = "one-off"
+	}
+	if !explicit["scope"] && !config.GitOps {
+		config.ValidateScope = "file-only"
+	}
+}
+
 // setupValidators initializes and registers all validators based on configuration
 func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.ValidatorRegistry {
 	registry := validators.NewValidatorRegistry(setupLog, mgr.GetClient())
 
+	// Install shared configuration before any validator is created, and report the
+	// registry's findings with the policy's severity overrides
+	var policies []validators.ValidationPolicy
+	if config.PolicyFile != "" || config.EnableValidationPolicies {
+		var err error
+		policies, err = loadValidationPolicies(context.Background(), mgr.GetAPIReader(), config)
+		if err != nil {
+			setupLog.Error(err, "failed to load validation policy")
+			os.Exit(failureExitCode(config))
+		}
+		policy, err := validators.NewSeverityPolicy(policies...)
+		if err != nil {
+			setupLog.Error(err, "failed to load validation policy")
+			os.Exit(failureExitCode(config))
+		}
+		registry.SetSeverityPolicy(policy)
+		sharedConfig := validators.NewSharedConfig(policies...)
+		validators.SetSharedConfig(&sharedConfig)
+		setupLog.Info("loaded validation policy", "severity_overrides", policy.Len(),
+			"system_namespaces", len(sharedConfig.SystemNamespaces), "dangerous_roles", len(sharedConfig.RBACConfig.DangerousRoles))
+	}
+
+	// Bind validation profiles to namespaces by policy and by namespace label
+	profilePolicy, err := validators.NewProfilePolicy(policies...)
+	if err != nil {
+		setupLog.Error(err, "failed to load validation policy")
+		os.Exit(failureExitCode(config))
+	}
+	registry.SetProfilePolicy(profilePolicy)
+
+	// Assign namespaces, and so their findings, to teams by policy and by namespace label
+	teamPolicy, err := validators.NewTeamPolicy(policies...)
+	if err != nil {
+		setupLog.Error(err, "failed to load validation policy")
+		os.Exit(failureExitCode(config))
+	}
+	registry.SetTeamPolicy(teamPolicy)
+
+	// Snooze findings until a date by policy and by resource annotation
+	snoozePolicy, err := validators.NewSnoozePolicy(policies...)
+	if err != nil {
+		setupLog.Error(err, "failed to load validation policy")
+		os.Exit(failureExitCode(config))
+	}
+	registry.SetSnoozePolicy(snoozePolicy)
+
+	// Hold back findings that appear and resolve repeatedly over cluster scans
+	flapPolicy, err := validators.NewFlapPolicy(validators.FlapSuppression{
+		ReportAfter:  config.ReportAfterScans,
+		ResolveAfter: config.ResolveAfterScans,
+	}, policies...)
+	if err != nil {
+		setupLog.Error(err, "invalid flap suppression")
+		os.Exit(failureExitCode(config))
+	}
+	registry.SetFlapPolicy(flapPolicy)
+
+	// Weigh the findings of error codes in hygiene scores by policy
+	scorePolicy, err := validators.NewScorePolicy(policies...)
+	if err != nil {
+		setupLog.Error(err, "failed to load validation policy")
+		os.Exit(failureExitCode(config))
+	}
+	registry.SetScorePolicy(scorePolicy)
+
+	// Abandon validators that hang, so that they can't stall the scan
+	registry.SetValidatorTimeout(config.ValidatorTimeout)
+
+	// Only fail CLI validation on warnings when asked to
+	registry.SetExitCodePolicy(validators.ExitCodePolicy{Strict: config.Strict})
+
+	// Record the component of each finding's resource for the components API
+	registry.SetComponentLabel(config.ComponentLabel)
+
+	// Skip low-priority validators once a scan has issued its budget of API requests
+	if config.ScanAPIBudget > 0 {
+		var lowPriority []string
+		for _, validatorType := range strings.Split(config.LowPriorityValidators, ",") {
+			if validatorType = strings.TrimSpace(validatorType); validatorType != "" {
+				lowPriority = append(lowPriority, validatorType)
+			}
+		}
+		registry.SetAPIBudget(config.ScanAPIBudget, lowPriority)
+		setupLog.Info("scan API request budget enabled", "budget", config.ScanAPIBudget, "low_priority_validators", lowPriority)
+	}
+
 	// Initialize the reference validator with configuration
 	validationConfig := validators.ValidationConfig{
 		EnableIngressValidation:        config.EnableIngressValidation,
@@ -173,6 +635,9 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 		EnableSecretValidation:         config.EnableSecretValidation,
 		EnablePVCValidation:            config.EnablePVCValidation,
 		EnableServiceAccountValidation: config.EnableServiceAccountValidation,
+		EnableUnusedResourceValidation: config.EnableUnusedResourceValidation,
+		UnusedResourceMinAge:           config.UnusedResourceMinAge,
+		EnableDownwardAPIValidation:    config.EnableDownwardAPIValidation,
 	}
 	referenceValidator := validators.NewReferenceValidator(mgr.GetClient(), setupLog, validationConfig)
 	registry.Register(referenceValidator)
@@ -180,9 +645,22 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 	// Initialize and register the resource limits validator if enabled
 	if config.EnableResourceLimitsValidation {
 		resourceLimitsConfig := validators.ResourceLimitsConfig{
-			EnableMissingRequestsValidation: config.EnableMissingRequestsValidation,
-			EnableMissingLimitsValidation:   config.EnableMissingLimitsValidation,
-			EnableQoSValidation:             config.EnableQoSValidation,
+			EnableMissingRequestsValidation:   config.EnableMissingRequestsValidation,
+			EnableMissingLimitsValidation:     config.EnableMissingLimitsValidation,
+			EnableQoSValidation:               config.EnableQoSValidation,
+			EnableNodeCapacityValidation:      config.EnableNodeCapacityValidation,
+			EnableOvercommitValidation:        config.EnableOvercommitValidation,
+			MaxCPULimitRequestRatio:           config.MaxCPULimitRequestRatio,
+			MaxMemoryLimitRequestRatio:        config.MaxMemoryLimitRequestRatio,
+			ForbidCPULimits:                   config.ForbidCPULimits,
+			EnableVPARecommendationValidation: config.EnableVPARecommendationValidation,
+			VPADeviationPercent:               config.VPADeviationPercent,
+			EnableUsageValidation:             config.EnableUsageValidation,
+			UsageLimitThreshold:               config.UsageLimitThreshold,
+			UsageWasteRatio:                   config.UsageWasteRatio,
+			EnableRestartValidation:           config.EnableRestartValidation,
+			RestartThreshold:                  int32(config.RestartThreshold),
+			OOMKillWindow:                     config.OOMKillWindow,
 		}
 
 		// Parse minimum resource thresholds if provided
@@ -207,16 +685,30 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 		}
 
 		resourceLimitsValidator := validators.NewResourceLimitsValidator(mgr.GetClient(), setupLog, resourceLimitsConfig)
+		if config.EnableUsageValidation {
+			// Pod usage is read directly from the metrics API
+			metricsClient, err := dynamic.NewForConfig(mgr.GetConfig())
+			if err != nil {
+				setupLog.Error(err, "failed to create metrics client for usage validation")
+				os.Exit(failureExitCode(config))
+			}
+			resourceLimitsValidator.SetMetricsClient(metricsClient)
+		}
 		registry.Register(resourceLimitsValidator)
 	}
 
-	// Initialize and register the security validator if enabled
+	// Configure the security and networking validators if enabled
+	var securityConfig *validators.SecurityConfig
 	if config.EnableSecurityValidation {
-		securityConfig := validators.SecurityConfig{
+		securityConfig = &validators.SecurityConfig{
 			EnableRootUserValidation:        config.EnableRootUserValidation,
 			EnableSecurityContextValidation: config.EnableSecurityContextValidation,
 			EnableServiceAccountValidation:  config.EnableSecurityServiceAccountValidation,
 			EnableNetworkPolicyValidation:   config.EnableNetworkPolicyValidation,
+			EnableRBACHygieneValidation:     config.EnableRBACHygieneValidation,
+			EnableHostPathValidation:        config.EnableHostPathValidation,
+			EnableHostNamespaceValidation:   config.EnableHostNamespaceValidation,
+			EnableHostPortValidation:        config.EnableHostPortValidation,
 		}
 
 		// Parse security-sensitive namespaces if provided
@@ -225,20 +717,44 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 			for i, ns := range namespaces {
 				namespaces[i] = strings.TrimSpace(ns)
 			}
+			if _, err := validators.ParseNamespaceSelector(namespaces); err != nil {
+				setupLog.Error(err, "invalid --security-required-namespaces")
+				os.Exit(failureExitCode(config))
+			}
 			securityConfig.SecuritySensitiveNamespaces = namespaces
 		}
 
-		securityValidator := validators.NewSecurityValidator(mgr.GetClient(), setupLog, securityConfig)
-		registry.Register(securityValidator)
+		if config.HostNamespaceAllowedNamespaces != "" {
+			namespaces := strings.Split(config.HostNamespaceAllowedNamespaces, ",")
+			for i, ns := range namespaces {
+				namespaces[i] = strings.TrimSpace(ns)
+			}
+			if _, err := validators.ParseNamespaceSelector(namespaces); err != nil {
+				setupLog.Error(err, "invalid --host-namespace-allowed-namespaces")
+				os.Exit(failureExitCode(config))
+			}
+			securityConfig.HostNamespaceAllowedNamespaces = namespaces
+		}
 	}
 
-	// Initialize and register the networking validator if enabled
+	var networkingConfig *validators.NetworkingConfig
 	if config.EnableNetworkingValidation {
-		networkingConfig := validators.NetworkingConfig{
-			EnableServiceValidation:       config.EnableNetworkingServiceValidation,
-			EnableNetworkPolicyValidation: config.EnableNetworkingPolicyValidation,
-			EnableIngressValidation:       config.EnableNetworkingIngressValidation,
-			WarnUnexposedPods:             config.WarnUnexposedPods,
+		networkingConfig = &validators.NetworkingConfig{
+			EnableServiceValidation:            config.EnableNetworkingServiceValidation,
+			EnableNetworkPolicyValidation:      config.EnableNetworkingPolicyValidation,
+			EnableServiceTopologyValidation:    config.EnableServiceTopologyValidation,
+			EnableIngressValidation:            config.EnableNetworkingIngressValidation,
+			EnableDuplicateValidation:          config.EnableNetworkingDuplicateValidation,
+			EnableIngressCollisionValidation:   config.EnableIngressCollisionValidation,
+			IngressCollisionCrossNamespaceOnly: config.IngressCollisionCrossNamespaceOnly,
+			EnableIngressTLSValidation:         config.EnableIngressTLSValidation,
+			RequireIngressTLS:                  config.RequireIngressTLS,
+			EnableNetworkPolicySimulation:      config.EnableNetworkPolicySimulation,
+			EnableEgressValidation:             config.EnableNetworkingEgressValidation,
+			EnableDNSValidation:                config.EnableNetworkingDNSValidation,
+			EnableExternalNameResolution:       config.EnableExternalNameResolution,
+			DNSLookupTimeout:                   config.DNSLookupTimeout,
+			WarnUnexposedPods:                  config.WarnUnexposedPods,
 		}
 
 		// Parse networking policy required namespaces if provided
@@ -247,10 +763,35 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 			for i, ns := range namespaces {
 				namespaces[i] = strings.TrimSpace(ns)
 			}
+			if _, err := validators.ParseNamespaceSelector(namespaces); err != nil {
+				setupLog.Error(err, "invalid --networking-required-namespaces")
+				os.Exit(failureExitCode(config))
+			}
 			networkingConfig.PolicyRequiredNamespaces = namespaces
 		}
 
-		networkingValidator := validators.NewNetworkingValidator(mgr.GetClient(), setupLog, networkingConfig)
+		// Parse the cluster CIDRs checked against NetworkPolicy ipBlocks if provided
+		if config.ClusterCIDRs != "" {
+			for _, cidr := range strings.Split(config.ClusterCIDRs, ",") {
+				cidr = strings.TrimSpace(cidr)
+				if _, _, err := net.ParseCIDR(cidr); err != nil {
+					setupLog.Error(err, "invalid --cluster-cidrs", "cidr", cidr)
+					os.Exit(failureExitCode(config))
+				}
+				networkingConfig.ClusterCIDRs = append(networkingConfig.ClusterCIDRs, cidr)
+			}
+		}
+	}
+
+	// Check NetworkPolicy coverage once when both validators check it
+	validators.ShareNetworkPolicyCoverage(securityConfig, networkingConfig)
+
+	if securityConfig != nil {
+		securityValidator := validators.NewSecurityValidator(mgr.GetClient(), setupLog, *securityConfig)
+		registry.Register(securityValidator)
+	}
+	if networkingConfig != nil {
+		networkingValidator := validators.NewNetworkingValidator(mgr.GetClient(), setupLog, *networkingConfig)
 		registry.Register(networkingValidator)
 	}
 
@@ -260,7 +801,7 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 		k8sClient, err := kubernetes.NewForConfig(mgr.GetConfig())
 		if err != nil {
 			setupLog.Error(err, "failed to get Kubernetes clientset for image validation")
-			os.Exit(1)
+			os.Exit(failureExitCode(config))
 		}
 
 		imageConfig := validators.ImageValidatorConfig{
@@ -273,11 +814,187 @@ func setupValidators(mgr ctrl.Manager, config *FlagConfig) *validators.Validator
 		registry.Register(imageValidator)
 	}
 
+	// Initialize and register the secret hygiene validator if enabled
+	if config.EnableSecretHygieneValidation {
+		secretConfig := validators.SecretConfig{
+			EnableEmptySecretValidation:      true,
+			EnableTLSSecretValidation:        true,
+			EnableDockerConfigJSONValidation: true,
+			EnableBasicAuthValidation:        true,
+			CertificateExpiryWarningDays:     config.CertExpiryWarningDays,
+		}
+
+		secretValidator := validators.NewSecretValidator(mgr.GetClient(), setupLog, secretConfig)
+		registry.Register(secretValidator)
+	}
+
+	// Initialize and register the volume validator if enabled
+	if config.EnableVolumeValidation {
+		volumeConfig := validators.VolumeConfig{
+			EnableDuplicateVolumeValidation: true,
+			EnableMountPathValidation:       true,
+			EnableSubPathValidation:         config.EnableVolumeSubPathValidation,
+			EnableReadOnlyMountValidation:   config.EnableVolumeReadOnlyValidation,
+		}
+
+		volumeValidator := validators.NewVolumeValidator(mgr.GetClient(), setupLog, volumeConfig)
+		registry.Register(volumeValidator)
+	}
+
+	// Initialize and register the quota validator if enabled
+	if config.EnableQuotaValidation {
+		quotaConfig := validators.QuotaConfig{
+			EnableQuotaCapacityValidation:     true,
+			EnableLimitRangeValidation:        true,
+			EnableMissingLimitRangeValidation: true,
+		}
+
+		quotaValidator := validators.NewQuotaValidator(mgr.GetClient(), setupLog, quotaConfig)
+		registry.Register(quotaValidator)
+	}
+
+	// Initialize and register the lifecycle validator if enabled
+	if config.EnableLifecycleValidation {
+		lifecycleConfig := validators.LifecycleConfig{
+			EnableOrphanedReplicaSetValidation: true,
+			EnableOwnerReferenceValidation:     true,
+		}
+
+		lifecycleValidator := validators.NewLifecycleValidator(mgr.GetClient(), setupLog, lifecycleConfig)
+		registry.Register(lifecycleValidator)
+	}
+
+	// Initialize and register the workload validator if enabled
+	if config.EnableWorkloadValidation {
+		workloadConfig := validators.WorkloadConfig{
+			EnableStatefulSetValidation: config.EnableStatefulSetValidation,
+			EnableDaemonSetValidation:   config.EnableDaemonSetValidation,
+			EnablePodStallValidation:    config.EnablePodStallValidation,
+			PodStallTimeout:             config.PodStallTimeout,
+		}
+
+		workloadValidator := validators.NewWorkloadValidator(mgr.GetClient(), setupLog, workloadConfig)
+		registry.Register(workloadValidator)
+	}
+
+	// Initialize and register the availability validator if enabled
+	if config.EnableAvailabilityValidation {
+		availabilityConfig := validators.AvailabilityConfig{
+			EnableSpreadValidation:        true,
+			EnableSingleReplicaValidation: true,
+			EnableZonePinningValidation:   true,
+		}
+
+		availabilityValidator := validators.NewAvailabilityValidator(mgr.GetClient(), setupLog, availabilityConfig)
+		registry.Register(availabilityValidator)
+	}
+
+	// Initialize and register the PolicyReport validator if enabled
+	if config.EnablePolicyReportValidation {
+		policyReportValidator := validators.NewPolicyReportValidator(mgr.GetClient(), setupLog)
+		registry.Register(policyReportValidator)
+	}
+
+	// Initialize and register the custom rule validator if rules are configured
+	if config.CustomRulesFile != "" || config.CustomRulesConfigMap != "" {
+		rules, err := loadCustomRules(context.Background(), mgr.GetAPIReader(), config)
+		if err != nil {
+			setupLog.Error(err, "failed to load custom rules")
+			os.Exit(failureExitCode(config))
+		}
+		setupLog.Info("loaded custom rules", "rules", rules.Len())
+
+		customRuleValidator := validators.NewCustomRuleValidator(mgr.GetClient(), setupLog, validators.CustomRuleConfig{Rules: rules})
+		registry.Register(customRuleValidator)
+	}
+
+	// Discover and register external validator plugins if a plugin directory is configured
+	if config.PluginDir != "" {
+		count, err := registry.RegisterPlugins(context.Background(), config.PluginDir, config.PluginTimeout)
+		if err != nil {
+			setupLog.Error(err, "failed to load validator plugins", "dir", config.PluginDir)
+			os.Exit(failureExitCode(config))
+		}
+		setupLog.Info("loaded validator plugins", "dir", config.PluginDir, "plugins", count)
+	}
+
 	return registry
 }
 
+// customRulesConfigMapKey is the ConfigMap key holding custom rules
+const customRulesConfigMapKey = "rules.yaml"
+
+// loadCustomRules reads custom rules from the configured file and ConfigMap and
+// compiles them together, so rule names must be unique across both sources
+func loadCustomRules(ctx context.Context, reader client.Reader, config *FlagConfig) (*validators.CustomRuleSet, error) {
+	var rules []validators.CustomRule
+
+	if config.CustomRulesFile != "" {
+		data, err := os.ReadFile(config.CustomRulesFile) // nolint:gosec // Rules file path is user-provided
+		if err != nil {
+			return nil, fmt.Errorf("failed to read custom rules file: %w", err)
+		}
+		fileRules, err := validators.ParseCustomRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", config.CustomRulesFile, err)
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	if config.CustomRulesConfigMap != "" {
+		namespace, name, found := strings.Cut(config.CustomRulesConfigMap, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("invalid --custom-rules-configmap %q, expected namespace/name", config.CustomRulesConfigMap)
+		}
+
+		var configMap corev1.ConfigMap
+		if err := reader.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &configMap); err != nil {
+			return nil, fmt.Errorf("failed to get custom rules ConfigMap: %w", err)
+		}
+		data, ok := configMap.Data[customRulesConfigMapKey]
+		if !ok {
+			return nil, fmt.Errorf("ConfigMap %s has no %s key", config.CustomRulesConfigMap, customRulesConfigMapKey)
+		}
+		configMapRules, err := validators.ParseCustomRules([]byte(data))
+		if err != nil {
+			return nil, fmt.Errorf("ConfigMap %s: %w", config.CustomRulesConfigMap, err)
+		}
+		rules = append(rules, configMapRules...)
+	}
+
+	return validators.CompileCustomRules(rules)
+}
+
+// loadValidationPolicies reads the ValidationPolicy resources in the cluster, if enabled,
+// and the policy file. The policy file comes last, so its settings take precedence.
+func loadValidationPolicies(ctx context.Context, reader client.Reader, config *FlagConfig) ([]validators.ValidationPolicy, error) {
+	var policies []validators.ValidationPolicy
+
+	if config.EnableValidationPolicies {
+		clusterPolicies, err := validators.ListValidationPolicies(ctx, reader)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, clusterPolicies...)
+	}
+
+	if config.PolicyFile != "" {
+		data, err := os.ReadFile(config.PolicyFile) // nolint:gosec // Policy file path is user-provided
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy file: %w", err)
+		}
+		policy, err := validators.ParseValidationPolicy(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", config.PolicyFile, err)
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
 // runValidationMode handles one-off and monitor validation modes
-func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry, config *FlagConfig, configData []byte) {
+func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry, config *FlagConfig, configData []byte, manifests []validators.ManifestFile) {
 	// Parse duration if provided
 	var duration time.Duration
 	if config.ValidateDuration != "" {
@@ -285,7 +1002,7 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 		duration, err = time.ParseDuration(config.ValidateDuration)
 		if err != nil {
 			setupLog.Error(err, "invalid duration format")
-			os.Exit(1)
+			os.Exit(validators.ExitCodeInternalFailure)
 		}
 	}
 
@@ -293,7 +1010,7 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 	interval, err := time.ParseDuration(config.ValidateInterval)
 	if err != nil {
 		setupLog.Error(err, "invalid interval format")
-		os.Exit(1)
+		os.Exit(validators.ExitCodeInternalFailure)
 	}
 
 	// Start the manager cache briefly to allow cluster object retrieval
@@ -310,7 +1027,7 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 	// Wait for cache to sync
 	if !mgr.GetCache().WaitForCacheSync(cacheCtx) {
 		setupLog.Error(nil, "failed to sync cache")
-		os.Exit(1)
+		os.Exit(validators.ExitCodeInternalFailure)
 	}
 	setupLog.Info("cache synced successfully")
 
@@ -322,14 +1039,27 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 		defer cancel()
 	}
 
+	if !slices.Contains(validOutputFormats, config.ValidateOutput) {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", strings.Join(validOutputFormats, ", "))
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+	if !slices.Contains(validScopes, config.ValidateScope) {
+		setupLog.Error(nil, "invalid validation scope", "scope", config.ValidateScope, "valid", strings.Join(validScopes, ", "))
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+
 	// Run validation based on mode
 	switch config.ValidateMode {
 	case "one-off":
-		if config.ValidateConfig != "" {
+		if config.ValidateConfig != "" || config.ChangedFiles != "" {
 			// Validate new configuration against cluster with scope filtering
 			var result *validators.ValidationResult
 			var err error
-			if configData != nil {
+			if config.GitOps || config.ChangedFiles != "" || validators.IsRemoteConfig(config.ValidateConfig) {
+				// Validate rendered GitOps manifests read from a directory, the changed manifests
+				// or the fetched remote manifests
+				result, err = registry.ValidateGitOpsManifests(ctx, manifests, config.ValidateScope)
+			} else if configData != nil {
 				// Use pre-read data for stdin
 				result, err = registry.ValidateNewConfigWithScopeAndData(ctx, config.ValidateConfig, config.ValidateScope, configData)
 			} else {
@@ -337,36 +1067,58 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 			}
 			if err != nil {
 				setupLog.Error(err, "validation failed")
-				os.Exit(1)
+				os.Exit(validators.ExitCodeInternalFailure)
 			}
 
-			// Format output based on mode
-			if config.ValidateOutput == "ci" {
-				output, err := registry.FormatCIOutput(*result)
-				if err != nil {
-					setupLog.Error(err, "failed to format CI output")
-					os.Exit(1)
-				}
-				// Output to stderr for CI consumption
-				fmt.Fprintf(os.Stderr, "%s\n", output)
-				os.Exit(result.ExitCode)
-			}
 			// Regular output
-			if result.ExitCode > 0 {
+			if config.ValidateOutput == "text" && result.ExitCode != validators.ExitCodeOK {
 				setupLog.Error(nil, "validation failed",
 					"total_errors", result.Summary.TotalErrors,
 					"missing_refs", result.Summary.MissingRefs,
 					"suggested_refs", result.Summary.SuggestedRefs)
-				os.Exit(result.ExitCode)
 			}
+			// Format output based on mode
+			emitValidationResult(registry, config, *result)
 		} else {
 			// Validate existing cluster
 			if err := registry.ValidateCluster(ctx); err != nil {
 				setupLog.Error(err, "validation failed")
-				os.Exit(1)
+				os.Exit(validators.ExitCodeInternalFailure)
 			}
+			emitValidationResult(registry, config, registry.LastValidationResult())
 		}
 	case "monitor":
+		if config.Watch != "" {
+			// Re-validate each manifest in the directory when it changes
+			validate := func(ctx context.Context, path string) (*validators.ValidationResult, error) {
+				return registry.ValidateNewConfigWithScope(ctx, path, config.ValidateScope)
+			}
+			if err := watchManifestDirectory(ctx, config.Watch, validate, os.Stdout); err != nil {
+				setupLog.Error(err, "failed to watch manifest directory", "dir", config.Watch)
+				os.Exit(validators.ExitCodeInternalFailure)
+			}
+			return
+		}
+		if config.ValidateConfig != "" {
+			// Re-validate the config file on every change as well as every interval, fetching
+			// a remote config again for each run
+			if config.ValidateConfig == "-" && configData == nil {
+				configData, err = io.ReadAll(os.Stdin)
+				if err != nil {
+					setupLog.Error(err, "failed to read from stdin")
+					os.Exit(validators.ExitCodeInternalFailure)
+				}
+			}
+			validate := func(ctx context.Context) (*validators.ValidationResult, error) {
+				return registry.ValidateNewConfigWithScopeAndData(ctx, config.ValidateConfig, config.ValidateScope, configData)
+			}
+			if err := monitorConfigFile(ctx, config.ValidateConfig, interval, validate, os.Stdout); err != nil {
+				setupLog.Error(err, "failed to monitor config file", "config", config.ValidateConfig)
+				os.Exit(validators.ExitCodeInternalFailure)
+			}
+			return
+		}
+
 		ticker := time.NewTicker(interval)
 		defer ticker.Stop()
 
@@ -382,96 +1134,656 @@ func runValidationMode(mgr ctrl.Manager, registry *validators.ValidatorRegistry,
 		}
 	default:
 		setupLog.Error(nil, "invalid validation mode", "mode", config.ValidateMode)
-		os.Exit(1)
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+}
+
+// validOutputFormats lists the values accepted by --output
+var validOutputFormats = []string{"text", "ci", "json", "yaml", "markdown", "github", "html"}
+
+// validScopes lists the values accepted by --scope
+var validScopes = []string{"all", "file-only", "flux-managed"}
+
+// emitValidationResult writes a validation result in a structured output format and
+// exits with the result's exit code. The text format is reported through the logger
+// by the caller, so it exits without writing anything.
+func emitValidationResult(registry *validators.ValidatorRegistry, config *FlagConfig, result validators.ValidationResult) {
+	if config.SuggestPatches != "" {
+		writeSuggestedPatches(config.SuggestPatches, result.Errors)
+	}
+	if config.ComplianceFramework != "" {
+		emitComplianceReport(config, result)
+	}
+
+	var output string
+	var err error
+
+	switch config.ValidateOutput {
+	case "ci":
+		output, err = registry.FormatCIOutput(result)
+	case "json":
+		output, err = registry.FormatJSONOutput(result)
+	case "yaml":
+		output, err = registry.FormatYAMLOutput(result)
+	case "markdown":
+		var baseline *validators.ValidationResult
+		if config.BaselineFile != "" {
+			baseline, err = validators.LoadBaselineResult(config.BaselineFile)
+			if err != nil {
+				setupLog.Error(err, "failed to load baseline", "baseline", config.BaselineFile)
+				os.Exit(validators.ExitCodeInternalFailure)
+			}
+		}
+		output, err = registry.FormatMarkdownOutput(result, baseline)
+	case "github":
+		output, err = registry.FormatGitHubOutput(result)
+	case "html":
+		output, err = registry.FormatHTMLOutput(result)
+	default:
+		os.Exit(result.ExitCode)
+	}
+	if err != nil {
+		setupLog.Error(err, "failed to format output", "output", config.ValidateOutput)
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+
+	switch {
+	case config.OutputFile != "":
+		if err := os.WriteFile(config.OutputFile, []byte(strings.TrimRight(output, "\n")+"\n"), 0o600); err != nil {
+			setupLog.Error(err, "failed to write output file", "path", config.OutputFile)
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+		setupLog.Info("validation output written", "path", config.OutputFile, "output", config.ValidateOutput)
+	case config.ValidateOutput == "ci" || config.ValidateOutput == "markdown":
+		// Output to stderr for CI consumption
+		fmt.Fprintf(os.Stderr, "%s\n", output)
+	default:
+		// Structured output goes to stdout so it stays separate from logs
+		fmt.Fprintf(os.Stdout, "%s\n", strings.TrimRight(output, "\n"))
 	}
+	os.Exit(result.ExitCode)
 }
 
-// setupController configures and registers the validation controller with health checks
-func setupController(mgr ctrl.Manager, registry *validators.ValidatorRegistry, scanInterval string) error {
+// writeSuggestedPatches writes patches for the fixable findings to a directory
+func writeSuggestedPatches(dir string, findings []validators.ValidationError) {
+	patches, err := remediation.SuggestPatches(findings)
+	if err != nil {
+		setupLog.Error(err, "failed to suggest patches")
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+	if err := remediation.WritePatches(dir, patches); err != nil {
+		setupLog.Error(err, "failed to write suggested patches", "dir", dir)
+		os.Exit(validators.ExitCodeInternalFailure)
+	}
+	setupLog.Info("suggested patches written", "dir", dir, "patches", len(patches))
+}
+
+// setupController configures and registers the validation controller with health checks.
+// The controller runs an immediate scan whenever the process receives SIGUSR1.
+func setupController(mgr ctrl.Manager, registry *validators.ValidatorRegistry, config *FlagConfig) (*controllers.ValidationController, error) {
 	// Parse scan interval
-	scanIntervalDuration, err := time.ParseDuration(scanInterval)
+	scanIntervalDuration, err := time.ParseDuration(config.ScanInterval)
 	if err != nil {
-		return fmt.Errorf("invalid scan interval format: %w", err)
+		return nil, fmt.Errorf("invalid scan interval format: %w", err)
 	}
 
+	scanSchedule, quietHours, err := parseSchedules(config)
+	if err != nil {
+		return nil, err
+	}
+	registry.SetQuietHours(quietHours)
+
+	resyncSignals := make(chan os.Signal, 1)
+	signal.Notify(resyncSignals, syscall.SIGUSR1)
+
 	// Setup the validation controller
 	validationController := &controllers.ValidationController{
-		Client:       mgr.GetClient(),
-		Scheme:       mgr.GetScheme(),
-		Log:          setupLog,
-		Registry:     registry,
-		ScanInterval: scanIntervalDuration,
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		Log:                setupLog,
+		Registry:           registry,
+		ScanInterval:       scanIntervalDuration,
+		ScanJitter:         config.ScanIntervalJitter,
+		Schedule:           scanSchedule,
+		ResyncSignals:      resyncSignals,
+		StaleScanIntervals: config.StaleScanIntervals,
+		DrainTimeout:       config.DrainTimeout,
 	}
 
 	if err = validationController.SetupWithManager(mgr); err != nil {
-		return fmt.Errorf("unable to create controller: %w", err)
+		return nil, fmt.Errorf("unable to create controller: %w", err)
 	}
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		return fmt.Errorf("unable to set up health check: %w", err)
+		return nil, fmt.Errorf("unable to set up health check: %w", err)
 	}
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		return fmt.Errorf("unable to set up ready check: %w", err)
+		return nil, fmt.Errorf("unable to set up ready check: %w", err)
+	}
+
+	return validationController, nil
+}
+
+// parseSchedules parses the optional cron schedule of periodic scans and the quiet hours
+// of notifications, both in the configured time zone
+func parseSchedules(config *FlagConfig) (*schedule.Cron, *schedule.QuietHours, error) {
+	location, err := time.LoadLocation(config.ScheduleTimeZone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid schedule time zone: %w", err)
+	}
+
+	var scanSchedule *schedule.Cron
+	if config.ScanSchedule != "" {
+		if scanSchedule, err = schedule.ParseCron(config.ScanSchedule, location); err != nil {
+			return nil, nil, err
+		}
+	}
+	var quietHours *schedule.QuietHours
+	if config.QuietHours != "" {
+		if quietHours, err = schedule.ParseQuietHours(config.QuietHours, location); err != nil {
+			return nil, nil, err
+		}
 	}
+	return scanSchedule, quietHours, nil
+}
 
+// setupScanStatus reports the scan status of the controllers on the manager's probe and
+// metrics servers: each controller adds a readiness check that fails when its scans
+// stall, and /validationz on the metrics server summarises their last scans
+func setupScanStatus(mgr ctrl.Manager, validationControllers ...*controllers.ValidationController) error {
+	for _, validationController := range validationControllers {
+		name := "validation"
+		if cluster := validationController.Registry.Cluster(); cluster != "" {
+			name += "-" + cluster
+		}
+		if err := mgr.AddReadyzCheck(name, validationController.ReadyCheck); err != nil {
+			return fmt.Errorf("unable to set up %s ready check: %w", name, err)
+		}
+	}
+	if err := mgr.AddMetricsServerExtraHandler("/validationz", controllers.NewValidationzHandler(validationControllers...)); err != nil {
+		return fmt.Errorf("unable to set up /validationz: %w", err)
+	}
+	return nil
+}
+
+// configureAPIClient sets the client-side rate limits of the Kubernetes API client
+// and counts the requests of each scan towards its API request budget
+func configureAPIClient(restConfig *rest.Config, config *FlagConfig) {
+	if config.KubeAPIQPS > 0 {
+		restConfig.QPS = float32(config.KubeAPIQPS)
+	}
+	if config.KubeAPIBurst > 0 {
+		restConfig.Burst = config.KubeAPIBurst
+	}
+	restConfig.Wrap(validators.CountAPIRequests)
+}
+
+// resolveShard returns the share of the cluster's namespaces this replica validates
+func resolveShard(config *FlagConfig) (validators.Shard, error) {
+	if config.ShardCount < 1 {
+		return validators.Shard{}, fmt.Errorf("--shard-count must be at least 1, got %d", config.ShardCount)
+	}
+	if config.ShardCount == 1 {
+		return validators.Shard{}, nil
+	}
+
+	switch {
+	case config.EnableLeaderElection:
+		return validators.Shard{}, fmt.Errorf("--shard-count cannot be combined with --leader-elect; every shard replica validates its own namespaces")
+	case config.ValidateMode != "":
+		return validators.Shard{}, fmt.Errorf("--shard-count only applies to continuous validation, not --mode=%s", config.ValidateMode)
+	case config.KubeconfigContexts != "" || config.ClustersFile != "":
+		return validators.Shard{}, fmt.Errorf("--shard-count cannot be combined with --kubeconfig-contexts or --clusters-file")
+	}
+
+	shard := validators.Shard{Index: config.ShardIndex, Count: config.ShardCount}
+	if shard.Index < 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return validators.Shard{}, fmt.Errorf("failed to read hostname for the shard index: %w", err)
+		}
+		if shard.Index, err = validators.ParseShardIndex(hostname); err != nil {
+			return validators.Shard{}, fmt.Errorf("set --shard-index or run as a StatefulSet: %w", err)
+		}
+	}
+	if err := shard.Validate(); err != nil {
+		return validators.Shard{}, err
+	}
+	return shard, nil
+}
+
+// setupAPIServers adds the optional findings APIs to the manager. The REST API starts
+// on-demand scans through the trigger.
+func setupAPIServers(mgr ctrl.Manager, registry *validators.ValidatorRegistry, trigger api.ScanTrigger, config *FlagConfig) error {
+	// Setup the optional findings API
+	if config.APIAddr != "" {
+		apiServer := api.NewServer(config.APIAddr, registry, ctrl.Log)
+		apiServer.Trigger = trigger
+		if err := mgr.Add(apiServer); err != nil {
+			return fmt.Errorf("failed to setup API server: %w", err)
+		}
+	}
+
+	// Setup the optional findings streaming API
+	if config.GRPCAddr != "" {
+		if err := mgr.Add(grpcapi.NewServer(config.GRPCAddr, registry, ctrl.Log)); err != nil {
+			return fmt.Errorf("failed to setup gRPC server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// setupScanListeners registers the optional handlers that act on each scan's findings
+// in the cluster of the manager. The store, when not nil, persists the state of the
+// incident notifiers for the next leader.
+func setupScanListeners(mgr ctrl.Manager, registry *validators.ValidatorRegistry, config *FlagConfig, store *handoff.Store) error {
+	// Listeners only touch the namespaces of the registry's shard, so that replicas
+	// don't clear each other's annotations and reports
+	shardClient := validators.NewShardClient(mgr.GetClient(), registry.Shard())
+
+	// Setup optional publishing of results into the cluster
+	if config.EnableWorkloadAnnotations {
+		registry.AddScanListener(reporting.NewWorkloadAnnotator(shardClient, ctrl.Log).HandleScan)
+	}
+	if config.EnableValidationReports {
+		registry.AddScanListener(reporting.NewValidationReportWriter(shardClient, ctrl.Log).HandleScan)
+	}
+	if config.EnablePolicyReports {
+		registry.AddScanListener(reporting.NewPolicyReportWriter(shardClient, registry.Shard(), ctrl.Log).HandleScan)
+	}
+
+	// Setup optional remediation of workloads that opt in by annotation
+	if config.EnableAutoRemediation {
+		remediator := remediation.NewRemediator(shardClient, mgr.GetEventRecorderFor(remediation.FieldManager), ctrl.Log, config.AutoRemediationDryRun)
+		registry.AddScanListener(remediator.HandleScan)
+		setupLog.Info("auto-remediation enabled", "dry_run", config.AutoRemediationDryRun)
+	}
+
+	// Incident notifiers resume the incidents of the previous leader
+	addIncidentNotifier := func(notifier *notify.IncidentNotifier) {
+		registry.AddNotifier(notifier.HandleScan)
+		if store != nil {
+			store.AddNotifier(registry.Cluster(), notifier)
+		}
+	}
+
+	// Setup optional incidents for error-severity findings, routed by team
+	httpClient := &http.Client{}
+	if config.PagerDutyRoutingKey != "" || config.OpsgenieAPIKey != "" {
+		filter, err := notify.ParseFilter(config.IncidentFilter)
+		if err != nil {
+			return fmt.Errorf("invalid --incident-filter: %w", err)
+		}
+		if config.PagerDutyRoutingKey != "" {
+			backend := notify.NewPagerDuty(config.PagerDutyRoutingKey, httpClient)
+			addIncidentNotifier(notify.NewIncidentNotifier(backend, filter, registry.TeamChannels, ctrl.Log))
+			setupLog.Info("PagerDuty incidents enabled", "filter", config.IncidentFilter)
+		}
+		if config.OpsgenieAPIKey != "" {
+			backend := notify.NewOpsgenie(config.OpsgenieAPIKey, config.OpsgenieAPIURL, httpClient)
+			addIncidentNotifier(notify.NewIncidentNotifier(backend, filter, registry.TeamChannels, ctrl.Log))
+			setupLog.Info("Opsgenie alerts enabled", "filter", config.IncidentFilter)
+		}
+	}
+
+	// Setup optional webhooks of new and resolved findings, routed by team
+	if config.WebhookConfig != "" {
+		data, err := os.ReadFile(config.WebhookConfig) // nolint:gosec // Webhook config path is user-provided
+		if err != nil {
+			return fmt.Errorf("failed to read webhook config: %w", err)
+		}
+		webhooks, err := notify.ParseWebhookConfig(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", config.WebhookConfig, err)
+		}
+		for _, webhook := range webhooks {
+			notifier, err := notify.NewWebhookNotifier(webhook, httpClient, registry.TeamChannels, ctrl.Log)
+			if err != nil {
+				return fmt.Errorf("%s: %w", config.WebhookConfig, err)
+			}
+			addIncidentNotifier(notifier)
+			setupLog.Info("webhook enabled", "webhook", webhook.Name, "filter", webhook.Filter)
+		}
+	}
+
+	// Setup optional Jira issues for persistent error-severity findings, routed by team
+	if config.JiraURL != "" {
+		filter, err := notify.ParseFilter(config.JiraFilter)
+		if err != nil {
+			return fmt.Errorf("invalid --jira-filter: %w", err)
+		}
+		jiraConfig := notify.JiraConfig{
+			URL:              config.JiraURL,
+			User:             config.JiraUser,
+			Token:            config.JiraAPIToken,
+			Project:          config.JiraProject,
+			IssueType:        config.JiraIssueType,
+			FingerprintField: config.JiraFingerprintField,
+			CloseTransition:  config.JiraCloseTransition,
+		}
+		manifests := notify.NewResourceManifests(mgr.GetAPIReader(), mgr.GetScheme())
+		backend, err := notify.NewJira(jiraConfig, manifests.YAML, httpClient)
+		if err != nil {
+			return err
+		}
+		notifier := notify.NewIncidentNotifier(backend, filter, registry.TeamChannels, ctrl.Log).WithMinAge(config.JiraMinAge)
+		addIncidentNotifier(notifier)
+		setupLog.Info("Jira issues enabled", "project", config.JiraProject, "min_age", config.JiraMinAge, "filter", config.JiraFilter)
+	}
+
+	// Setup the optional email digest of active findings
+	if config.DigestRecipients != "" {
+		recipients, err := notify.ParseDigestRecipients(config.DigestRecipients)
+		if err != nil {
+			return fmt.Errorf("invalid --email-digest-recipients: %w", err)
+		}
+		if config.SMTPAddress == "" || config.EmailFrom == "" {
+			return fmt.Errorf("--email-digest-recipients requires --smtp-address and --email-from")
+		}
+		location, err := time.LoadLocation(config.ScheduleTimeZone)
+		if err != nil {
+			return fmt.Errorf("invalid schedule time zone: %w", err)
+		}
+		digestSchedule, err := schedule.ParseCron(config.DigestSchedule, location)
+		if err != nil {
+			return fmt.Errorf("invalid --email-digest-schedule: %w", err)
+		}
+		smtpConfig := notify.SMTPConfig{
+			Address:  config.SMTPAddress,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+			From:     config.EmailFrom,
+		}
+		digest := notify.NewEmailDigest(smtpConfig, recipients, digestSchedule, time.Now(), ctrl.Log)
+		registry.AddNotifier(digest.HandleScan)
+		setupLog.Info("email digest enabled", "recipients", len(recipients), "schedule", digestSchedule.String())
+	}
+
+	// Save the findings and incidents once each scan has been handled
+	if store != nil {
+		registry.AddCompletionListener(store.HandleScan)
+	}
 	return nil
 }
 
 func main() {
+	// Subcommands parse their own flags
+	if len(os.Args) > 1 && os.Args[1] == diffCommand {
+		os.Exit(runDiff(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == exportDashboardsCommand {
+		os.Exit(runExportDashboards(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == explainCommand {
+		os.Exit(runExplain(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == simulateTrafficCommand {
+		os.Exit(runSimulateTraffic(os.Args[2:]))
+	}
+	if len(os.Args) > 1 && os.Args[1] == rbacManifestCommand {
+		// rbac-manifest takes the controller's flags, so they select the validators
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(runRBACManifest())
+	}
+	if len(os.Args) > 1 && os.Args[1] == tuiCommand {
+		// tui takes the controller's flags, so they select the validators
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(runTUI())
+	}
+	if len(os.Args) > 1 && os.Args[1] == rulesCommand {
+		// rules takes the controller's flags, so they select the enabled checks
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(runRules())
+	}
+	if len(os.Args) > 1 && os.Args[1] == doctorCommand {
+		// doctor takes the controller's flags, so they select the checked features
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(runDoctor())
+	}
+	if len(os.Args) > 1 && os.Args[1] == helmLintCommand {
+		// helm-lint takes the controller's flags, so they select the validators
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+		os.Exit(runHelmLint())
+	}
+
+	// validate takes the controller's flags and defaults to one-off validation; report
+	// validates the same way and summarises the findings by compliance control
+	validate := len(os.Args) > 1 && os.Args[1] == validateCommand
+	report := len(os.Args) > 1 && os.Args[1] == reportCommand
+	if validate || report {
+		os.Args = append(os.Args[:1], os.Args[2:]...)
+	}
+	var framework string
+	if report {
+		flag.StringVar(&framework, "framework", "", "Compliance framework to report on: "+strings.Join(complianceFrameworkNames(), ", "))
+	}
+
 	config := registerFlags()
+	if (validate || report) && config.ValidateMode == "" {
+		config.ValidateMode = "one-off"
+	}
+	if report {
+		if err := checkReportFlags(config, framework); err != nil {
+			setupLog.Error(err, "invalid kogaro report configuration")
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+		config.ComplianceFramework = framework
+	}
+	if config.Watch != "" {
+		if config.ValidateMode != "monitor" || config.ValidateConfig != "" || config.GitOps {
+			setupLog.Error(nil, "--watch runs in monitor mode and cannot be combined with --config or --gitops")
+			os.Exit(failureExitCode(config))
+		}
+		if info, err := os.Stat(config.Watch); err != nil || !info.IsDir() {
+			setupLog.Error(err, "--watch must name a directory of manifests", "dir", config.Watch)
+			os.Exit(failureExitCode(config))
+		}
+	}
+	if config.Offline {
+		if err := checkOfflineFlags(config); err != nil {
+			setupLog.Error(err, "invalid --offline configuration")
+			os.Exit(failureExitCode(config))
+		}
+	}
 
 	// Handle one-off validation mode - read config once if using stdin
 	var configData []byte
-	if config.ValidateMode == "one-off" && config.ValidateConfig != "" {
+	var manifests []validators.ManifestFile
+	if config.ChangedFiles != "" {
+		if config.ValidateMode != "one-off" || config.ValidateConfig == "-" {
+			setupLog.Error(nil, "--changed-files requires --mode=one-off, and --config may only name a directory to restrict the changes to")
+			os.Exit(failureExitCode(config))
+		}
+		if config.ValidateConfig != "" {
+			if info, err := os.Stat(config.ValidateConfig); err != nil || !info.IsDir() {
+				setupLog.Error(err, "--config must name a directory when combined with --changed-files", "config", config.ValidateConfig)
+				os.Exit(failureExitCode(config))
+			}
+		}
+
+		changed, err := readChangedFiles(config.ChangedFiles)
+		if err != nil {
+			setupLog.Error(err, "failed to read changed files")
+			os.Exit(failureExitCode(config))
+		}
+		manifests, err = validators.LoadChangedManifests(changed, config.ValidateConfig)
+		if err != nil {
+			setupLog.Error(err, "failed to load changed manifests")
+			os.Exit(failureExitCode(config))
+		}
+		if len(manifests) == 0 {
+			// Nothing to validate, so the cluster isn't contacted
+			setupLog.Info("no changed manifests to validate", "changed_files", len(changed))
+			emitValidationResult(validators.NewValidatorRegistry(setupLog, nil), config, validators.ValidationResult{})
+		}
+		for _, manifest := range manifests {
+			if err := validateConfigFileSyntax(manifest.Path, manifest.Data); err != nil {
+				setupLog.Error(err, "validation failed", "file", manifest.Path)
+				os.Exit(failureExitCode(config))
+			}
+		}
+		setupLog.Info("changed manifest syntax validation passed", "changed_files", len(changed), "manifests", len(manifests))
+	} else if config.GitOps {
+		if config.ValidateMode != "one-off" || config.ValidateConfig == "" {
+			setupLog.Error(nil, "--gitops requires --mode=one-off and --config pointing to a directory of rendered manifests")
+			os.Exit(failureExitCode(config))
+		}
+
+		var err error
+		if validators.IsRemoteConfig(config.ValidateConfig) {
+			manifests, err = validators.FetchRemoteManifests(context.Background(), config.ValidateConfig)
+		} else {
+			manifests, err = validators.LoadManifestDirectory(config.ValidateConfig)
+		}
+		if err != nil {
+			setupLog.Error(err, "failed to load GitOps manifests")
+			os.Exit(failureExitCode(config))
+		}
+		if err := validateConfigFileSyntax(config.ValidateConfig, validators.JoinManifests(manifests)); err != nil {
+			setupLog.Error(err, "validation failed")
+			os.Exit(failureExitCode(config))
+		}
+		setupLog.Info("GitOps manifest syntax validation passed", "files", len(manifests))
+	} else if config.ValidateMode == "one-off" && config.ValidateConfig != "" {
 		var err error
 		if config.ValidateConfig == "-" {
 			// Read stdin once and store for both syntax and full validation
 			configData, err = io.ReadAll(os.Stdin)
 			if err != nil {
 				setupLog.Error(err, "failed to read from stdin")
-				os.Exit(1)
+				os.Exit(failureExitCode(config))
+			}
+		} else if validators.IsRemoteConfig(config.ValidateConfig) {
+			// Fetch the remote manifests once, keeping their files to attribute findings to
+			manifests, err = validators.FetchRemoteManifests(context.Background(), config.ValidateConfig)
+			if err != nil {
+				setupLog.Error(err, "failed to fetch config", "config", config.ValidateConfig)
+				os.Exit(failureExitCode(config))
 			}
+			configData = validators.JoinManifests(manifests)
 		}
 
 		if err := validateConfigFileSyntax(config.ValidateConfig, configData); err != nil {
 			setupLog.Error(err, "validation failed")
-			os.Exit(1)
+			os.Exit(failureExitCode(config))
 		}
 		setupLog.Info("config file syntax validation passed")
 		// Continue to cluster validation - don't return here
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// Offline validation never loads a kubeconfig or contacts a cluster
+	if config.Offline {
+		os.Exit(runOffline(config, configData, manifests))
+	}
+
+	// Split the cluster's namespaces with other replicas when sharding is configured
+	shard, err := resolveShard(config)
+	if err != nil {
+		setupLog.Error(err, "invalid shard configuration")
+		os.Exit(failureExitCode(config))
+	}
+
+	// Validate several clusters when they are configured
+	targets, err := loadClusterTargets(config)
+	if err != nil {
+		setupLog.Error(err, "invalid cluster configuration")
+		os.Exit(failureExitCode(config))
+	}
+	if len(targets) > 0 {
+		if config.ValidateConfig != "" {
+			setupLog.Error(nil, "--config cannot be combined with --kubeconfig-contexts or --clusters-file")
+			os.Exit(failureExitCode(config))
+		}
+		runMultiCluster(targets, config)
+		return
+	}
+
+	restConfig, err := ctrlconfig.GetConfigWithContext(config.KubeContext)
+	if err != nil {
+		setupLog.Error(err, "unable to load kubeconfig", "context", config.KubeContext)
+		os.Exit(failureExitCode(config))
+	}
+	configureAPIClient(restConfig, config)
+
+	// Restrict the informer cache to the namespaces and objects Kogaro validates
+	cacheOptions, clientOptions, err := managerCacheOptions(config)
+	if err != nil {
+		setupLog.Error(err, "invalid cache configuration")
+		os.Exit(failureExitCode(config))
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
+		Client: clientOptions,
 		Metrics: server.Options{
 			BindAddress: config.MetricsAddr,
 		},
-		HealthProbeBindAddress: config.ProbeAddr,
-		LeaderElection:         config.EnableLeaderElection,
-		LeaderElectionID:       "kogaro.io",
+		HealthProbeBindAddress:  config.ProbeAddr,
+		LeaderElection:          config.EnableLeaderElection,
+		LeaderElectionID:        "kogaro.io",
+		GracefulShutdownTimeout: gracefulShutdownTimeout(config),
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		os.Exit(failureExitCode(config))
 	}
 
-	// Register metrics
-	metrics.RegisterMetrics()
+	// Index the cache so validators look up EndpointSlices by Service
+	if err := validators.RegisterFieldIndexes(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to register cache field indexes")
+		os.Exit(failureExitCode(config))
+	}
+
+	// Register metrics, labeled with the shard when replicas split the cluster
+	if shard.Enabled() {
+		metrics.RegisterShardedMetrics(shard.Label())
+	} else {
+		metrics.RegisterMetrics()
+	}
 
 	// Initialize validators
 	registry := setupValidators(mgr, config)
+	if shard.Enabled() {
+		registry.SetShard(shard)
+		setupLog.Info("validating a shard of the cluster's namespaces", "shard", shard.Label())
+	}
 
 	// Handle validate command
 	if config.ValidateMode != "" {
-		runValidationMode(mgr, registry, config, configData)
+		runValidationMode(mgr, registry, config, configData, manifests)
 		return
 	}
+	setupPermissionCheck(mgr, registry, config)
 
-	// Setup the controller
-	if err := setupController(mgr, registry, config.ScanInterval); err != nil {
+	// Setup the controller, which resumes the previous leader's findings
+	store, err := setupHandoff(mgr, config)
+	if err != nil {
+		setupLog.Error(err, "failed to setup finding state hand-off")
+		os.Exit(1)
+	}
+	validationController, err := setupController(mgr, registry, config)
+	if err != nil {
 		setupLog.Error(err, "failed to setup controller")
 		os.Exit(1)
 	}
+	if store != nil {
+		validationController.State = store
+	}
+	if err := setupScanStatus(mgr, validationController); err != nil {
+		setupLog.Error(err, "failed to setup scan status")
+		os.Exit(1)
+	}
+
+	if err := setupAPIServers(mgr, registry, validationController, config); err != nil {
+		setupLog.Error(err, "failed to setup API servers")
+		os.Exit(1)
+	}
+	if err := setupScanListeners(mgr, registry, config, store); err != nil {
+		setupLog.Error(err, "failed to setup scan listeners")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -480,6 +1792,47 @@ func main() {
 	}
 }
 
+// gracefulShutdownTimeout returns the time the manager waits for its runnables to stop:
+// the drain timeout of the scan in flight, and the time to publish its findings and
+// flush notifications
+func gracefulShutdownTimeout(config *FlagConfig) *time.Duration {
+	timeout := config.DrainTimeout + shutdownFlushTimeout
+	return &timeout
+}
+
+// failureExitCode returns the exit code for a failure that stops the process. CLI
+// validation exits with validators.ExitCodeInternalFailure, so that CI can tell a
+// failed run from one that reported findings.
+func failureExitCode(config *FlagConfig) int {
+	if config.ValidateMode != "" {
+		return validators.ExitCodeInternalFailure
+	}
+	return 1
+}
+
+// readChangedFiles reads a list of changed files, one per line, from a file or from
+// stdin when the path is -
+func readChangedFiles(path string) ([]string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path) // nolint:gosec // Changed file list path is user-provided
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 // validateConfigFileSyntax performs early validation with optional pre-read data
 func validateConfigFileSyntax(configPath string, preReadData []byte) error {
 	var configData []byte
@@ -90,4 +90,33 @@ spec:
 		
 		t.Logf("Helm template error output:\n%s", outputStr)
 	})
-}
\ No newline at end of file
+}
+
+func TestResolveShard(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  FlagConfig
+		want    string
+		wantErr bool
+	}{
+		{name: "unsharded", config: FlagConfig{ShardCount: 1, ShardIndex: -1}},
+		{name: "explicit index", config: FlagConfig{ShardCount: 3, ShardIndex: 2}, want: "2/3"},
+		{name: "index out of range", config: FlagConfig{ShardCount: 3, ShardIndex: 3}, wantErr: true},
+		{name: "zero shards", config: FlagConfig{ShardCount: 0}, wantErr: true},
+		{name: "leader election", config: FlagConfig{ShardCount: 2, ShardIndex: 0, EnableLeaderElection: true}, wantErr: true},
+		{name: "one-off mode", config: FlagConfig{ShardCount: 2, ShardIndex: 0, ValidateMode: "one-off"}, wantErr: true},
+		{name: "multiple clusters", config: FlagConfig{ShardCount: 2, ShardIndex: 0, KubeconfigContexts: "a,b"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shard, err := resolveShard(&tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveShard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := shard.Label(); got != tt.want {
+				t.Errorf("resolveShard() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
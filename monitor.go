@@ -0,0 +1,114 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// configReloadDelay lets the burst of events an editor emits while saving a file settle
+// before the file is validated again
+const configReloadDelay = 200 * time.Millisecond
+
+// monitorConfigFile validates the config file under validation every interval, and
+// again as soon as the file changes, until the context is done. After each run it
+// writes how the findings changed since the previous run. Stdin and remote configs
+// can't be watched, so they are only validated every interval. Failed runs are reported and the previous
+// findings are kept, so a half-edited file doesn't reset the comparison.
+func monitorConfigFile(ctx context.Context, path string, interval time.Duration, validate func(context.Context) (*validators.ValidationResult, error), out io.Writer) error {
+	var changes <-chan fsnotify.Event
+	var watchErrors <-chan error
+	if path != "-" && !validators.IsRemoteConfig(path) {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create file watcher: %w", err)
+		}
+		defer func() { _ = watcher.Close() }()
+		// Watch the directory, since editors often save by replacing the file
+		if err := watcher.Add(filepath.Dir(path)); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", path, err)
+		}
+		changes = watcher.Events
+		watchErrors = watcher.Errors
+	}
+	target := filepath.Clean(path)
+
+	var previous *validators.ValidationResult
+	run := func(reason string) {
+		result, err := validate(ctx)
+		if err != nil {
+			setupLog.Error(err, "validation failed", "config", path, "trigger", reason)
+			return
+		}
+		writeFindingsDelta(out, time.Now(), "validated on "+reason, previous, *result)
+		previous = result
+	}
+	run("start")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	reload := time.NewTimer(configReloadDelay)
+	reload.Stop()
+	defer reload.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			run("interval")
+		case event, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			if filepath.Clean(event.Name) == target && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				reload.Reset(configReloadDelay)
+			}
+		case err, ok := <-watchErrors:
+			if !ok {
+				watchErrors = nil
+				continue
+			}
+			setupLog.Error(err, "error watching config file", "config", path)
+		case <-reload.C:
+			run("change")
+		}
+	}
+}
+
+// writeFindingsDelta writes the findings of a monitor run under a label as changes from
+// the previous run: new findings are prefixed with + and resolved ones with -. The
+// first run has nothing to compare against, so all of its findings are new.
+func writeFindingsDelta(w io.Writer, at time.Time, label string, previous *validators.ValidationResult, current validators.ValidationResult) {
+	var baseline []validators.ValidationError
+	if previous != nil {
+		baseline = previous.Errors
+	}
+	newFindings, resolvedFindings, unchanged := validators.DiffFindings(baseline, current.Errors)
+
+	_, _ = fmt.Fprintf(w, "[%s] %s: %d findings (%d new, %d resolved, %d unchanged)\n",
+		at.Format(time.TimeOnly), label, len(current.Errors), len(newFindings), len(resolvedFindings), unchanged)
+	for _, finding := range newFindings {
+		writeFindingLine(w, "+", finding)
+	}
+	for _, finding := range resolvedFindings {
+		writeFindingLine(w, "-", finding)
+	}
+}
+
+// writeFindingLine writes a finding on one line, prefixed with a change marker
+func writeFindingLine(w io.Writer, marker string, finding validators.ValidationError) {
+	_, _ = fmt.Fprintf(w, "%s [%s] %s %s/%s: %s\n", marker, finding.Severity, finding.ErrorCode, finding.ResourceType, finding.GetResourceKey(), finding.Message)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestMonitorConfigFile_RevalidatesOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	if err := os.WriteFile(path, []byte("kind: ConfigMap\n"), 0o600); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	missing := validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'web-config' does not exist")
+	runs := make(chan int, 10)
+	count := 0
+	validate := func(context.Context) (*validators.ValidationResult, error) {
+		count++
+		result := &validators.ValidationResult{}
+		if count == 1 {
+			result.Errors = []validators.ValidationError{missing}
+		}
+		runs <- count
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var out bytes.Buffer
+	done := make(chan error)
+	go func() {
+		done <- monitorConfigFile(ctx, path, time.Hour, validate, &out)
+	}()
+
+	waitForRun := func(want int) {
+		t.Helper()
+		select {
+		case got := <-runs:
+			if got != want {
+				t.Fatalf("run %d, want run %d", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for run %d", want)
+		}
+	}
+	waitForRun(1)
+	if err := os.WriteFile(path, []byte("kind: ConfigMap\nmetadata:\n  name: web-config\n"), 0o600); err != nil {
+		t.Fatalf("failed to update config file: %v", err)
+	}
+	waitForRun(2)
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("monitorConfigFile() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("output = %q, want two summaries and two findings", out.String())
+	}
+	if !strings.HasSuffix(lines[0], "validated on start: 1 findings (1 new, 0 resolved, 0 unchanged)") {
+		t.Errorf("first summary = %q", lines[0])
+	}
+	if lines[1] != "+ [error] KOGARO-REF-003 Deployment/shop/web: ConfigMap 'web-config' does not exist" {
+		t.Errorf("new finding = %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], "validated on change: 0 findings (0 new, 1 resolved, 0 unchanged)") {
+		t.Errorf("second summary = %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "- [error] KOGARO-REF-003") {
+		t.Errorf("resolved finding = %q", lines[3])
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// clusterContextCheck is a check that reads the live state of a cluster, such as its
+// nodes, pods or metrics, rather than the resources it validates, so --offline skips it
+type clusterContextCheck struct {
+	// validator and feature name the check's ruleFeature
+	validator string
+	feature   string
+	// needs says what the check reads from the cluster
+	needs string
+	// disable switches the check off
+	disable func(config *FlagConfig)
+}
+
+// clusterContextChecks lists the checks --offline skips. Keep it in step with
+// ruleFeatures.
+var clusterContextChecks = []clusterContextCheck{
+	{"reference_validation", "Unused Resources", "the cluster's resources outside the config, which may use the ones it defines",
+		func(c *FlagConfig) { c.EnableUnusedResourceValidation = false }},
+	{"resource_limits_validation", "Node Capacity", "the cluster's nodes",
+		func(c *FlagConfig) { c.EnableNodeCapacityValidation = false }},
+	{"resource_limits_validation", "Right-Sizing", "VerticalPodAutoscaler recommendations",
+		func(c *FlagConfig) { c.EnableVPARecommendationValidation = false }},
+	{"resource_limits_validation", "Usage", "container metrics",
+		func(c *FlagConfig) { c.EnableUsageValidation = false }},
+	{"resource_limits_validation", "Restarts", "the status of running pods",
+		func(c *FlagConfig) { c.EnableRestartValidation = false }},
+	{"networking_validation", "Service Connectivity", "running pods and EndpointSlices",
+		func(c *FlagConfig) { c.EnableNetworkingServiceValidation = false }},
+	{"networking_validation", "Unexposed Pods", "running pods",
+		func(c *FlagConfig) { c.WarnUnexposedPods = false }},
+	{"networking_validation", "Service Topology", "the cluster's nodes and load balancer status",
+		func(c *FlagConfig) { c.EnableServiceTopologyValidation = false }},
+	{"networking_validation", "Ingress Connectivity", "running pods",
+		func(c *FlagConfig) { c.EnableNetworkingIngressValidation = false }},
+	{"networking_validation", "ExternalName Resolution", "DNS",
+		func(c *FlagConfig) { c.EnableExternalNameResolution = false }},
+	{"image_validation", "Image Registry & Architecture", "image registries and the cluster's node architectures",
+		func(c *FlagConfig) { c.EnableImageValidation = false }},
+	{"lifecycle_validation", "Ownership & Garbage Collection", "the cluster's owners and ReplicaSets",
+		func(c *FlagConfig) { c.EnableLifecycleValidation = false }},
+	{"workload_validation", "Pod Stalls", "the status of running pods",
+		func(c *FlagConfig) { c.EnablePodStallValidation = false }},
+	{"policy_report_validation", "Policy Reports", "the PolicyReports of the cluster's policy engines",
+		func(c *FlagConfig) { c.EnablePolicyReportValidation = false }},
+}
+
+// applyOfflineDefaults configures one-off validation without a cluster, keeping any
+// mode set explicitly on the command line
+func applyOfflineDefaults(config *FlagConfig) {
+	if !explicitFlags()["mode"] {
+		config.ValidateMode = "one-off"
+	}
+}
+
+// checkOfflineFlags rejects flags that need a cluster, or a mode --offline doesn't support
+func checkOfflineFlags(config *FlagConfig) error {
+	switch {
+	case config.ValidateConfig == "":
+		return fmt.Errorf("--offline validates the config given by --config")
+	case config.ValidateMode != "one-off":
+		return fmt.Errorf("--offline runs in one-off mode")
+	case config.GitOps || config.ChangedFiles != "" || config.Watch != "":
+		return fmt.Errorf("--offline cannot be combined with --gitops, --changed-files or --watch")
+	case config.KubeconfigContexts != "" || config.ClustersFile != "":
+		return fmt.Errorf("--offline cannot be combined with --kubeconfig-contexts or --clusters-file")
+	case config.EnableValidationPolicies:
+		return fmt.Errorf("--offline can't read ValidationPolicy resources from a cluster; use --policy-file")
+	case config.CustomRulesConfigMap != "":
+		return fmt.Errorf("--offline can't read custom rules from a ConfigMap; use --custom-rules-file")
+	}
+	return nil
+}
+
+// skipClusterContextChecks switches off the enabled checks that need cluster context and
+// returns an info finding for each of them, so that reports show what was not checked
+func skipClusterContextChecks(config *FlagConfig) []validators.ValidationError {
+	features := make(map[string]ruleFeature, len(ruleFeatures))
+	for _, feature := range ruleFeatures {
+		features[feature.validator+"/"+feature.name] = feature
+	}
+
+	// Find every enabled check before switching any off, since checks share flags
+	var skipped []validators.ValidationError
+	for _, check := range clusterContextChecks {
+		feature, ok := features[check.validator+"/"+check.feature]
+		if !ok || !feature.enabled(config) {
+			continue
+		}
+		finding := validators.NewValidationErrorWithCode("Validator", check.validator, "", "check_skipped_offline",
+			validators.GetRegistryErrorCode("check_skipped_offline"),
+			fmt.Sprintf("Check '%s' of %s was skipped: it needs %s, which --offline does not read", check.feature, check.validator, check.needs)).
+			WithSeverity(validators.SeverityInfo).
+			WithRemediationHint("Validate the config against a cluster to run the check").
+			WithDetail("check", check.feature).
+			WithDetail("validation_types", strings.Join(feature.validationTypes, ","))
+		skipped = append(skipped, finding)
+	}
+	for _, check := range clusterContextChecks {
+		check.disable(config)
+	}
+	return skipped
+}
+
+// runOffline validates the config on its own, with the validators built around a manager
+// that is never started, and returns the exit code
+func runOffline(config *FlagConfig, configData []byte, manifests []validators.ManifestFile) int {
+	if !slices.Contains(validOutputFormats, config.ValidateOutput) {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", strings.Join(validOutputFormats, ", "))
+		return validators.ExitCodeInternalFailure
+	}
+
+	skipped := skipClusterContextChecks(config)
+	for _, finding := range skipped {
+		setupLog.Info("check skipped offline", "validator", finding.ResourceName, "check", finding.Details["check"])
+	}
+
+	mgr, err := ctrl.NewManager(&rest.Config{Host: offlineClusterHost}, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                server.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create manager")
+		return validators.ExitCodeInternalFailure
+	}
+	registry := setupValidators(mgr, config)
+
+	ctx := context.Background()
+	var result *validators.ValidationResult
+	switch {
+	case manifests != nil:
+		// Attribute findings to the files of the fetched remote manifests
+		result, err = registry.ValidateFileOnlyManifests(ctx, manifests)
+	case configData != nil:
+		// Use pre-read data for stdin
+		result, err = registry.ValidateFileOnlyData(ctx, "", configData)
+	default:
+		result, err = registry.ValidateFileOnly(ctx, config.ValidateConfig)
+	}
+	if err != nil {
+		setupLog.Error(err, "validation failed")
+		return validators.ExitCodeInternalFailure
+	}
+
+	merged := validators.MergeResults(*result, registry.NewResult(skipped))
+	if config.ValidateOutput == "text" && merged.ExitCode != validators.ExitCodeOK {
+		setupLog.Error(nil, "validation failed", "total_errors", merged.Summary.TotalErrors)
+	}
+	emitValidationResult(registry, config, merged)
+	return merged.ExitCode
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestClusterContextChecks_NameRuleFeatures(t *testing.T) {
+	features := make(map[string]bool)
+	for _, feature := range ruleFeatures {
+		features[feature.validator+"/"+feature.name] = true
+	}
+	for _, check := range clusterContextChecks {
+		if !features[check.validator+"/"+check.feature] {
+			t.Errorf("%s of %s is not a rule feature", check.feature, check.validator)
+		}
+	}
+}
+
+func TestSkipClusterContextChecks(t *testing.T) {
+	config := &FlagConfig{
+		Offline:                           true,
+		EnableResourceLimitsValidation:    true,
+		EnableMissingRequestsValidation:   true,
+		EnableNodeCapacityValidation:      true,
+		EnableNetworkingValidation:        true,
+		EnableNetworkingServiceValidation: true,
+		WarnUnexposedPods:                 true,
+		EnableImageValidation:             true,
+		EnableUnusedResourceValidation:    false,
+	}
+
+	skipped := skipClusterContextChecks(config)
+
+	var checks []string
+	for _, finding := range skipped {
+		if finding.ErrorCode != "KOGARO-SYS-008" || finding.Severity != validators.SeverityInfo {
+			t.Errorf("finding = %+v, want a KOGARO-SYS-008 info finding", finding)
+		}
+		checks = append(checks, finding.ResourceName+"/"+finding.Details["check"])
+	}
+	want := []string{
+		"resource_limits_validation/Node Capacity",
+		"networking_validation/Service Connectivity",
+		"networking_validation/Unexposed Pods",
+		"image_validation/Image Registry & Architecture",
+	}
+	if strings.Join(checks, ",") != strings.Join(want, ",") {
+		t.Errorf("skipped checks = %v, want %v", checks, want)
+	}
+	if !strings.Contains(skipped[0].Message, "the cluster's nodes") {
+		t.Errorf("message = %q, want it to say what the check needs", skipped[0].Message)
+	}
+
+	if config.EnableNodeCapacityValidation || config.EnableNetworkingServiceValidation || config.WarnUnexposedPods || config.EnableImageValidation {
+		t.Errorf("config = %+v, want the skipped checks switched off", config)
+	}
+	if !config.EnableResourceLimitsValidation || !config.EnableMissingRequestsValidation || !config.EnableNetworkingValidation {
+		t.Errorf("config = %+v, want the file-local checks kept", config)
+	}
+}
+
+func TestCheckOfflineFlags(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  FlagConfig
+		wantErr string
+	}{
+		{name: "config file", config: FlagConfig{ValidateConfig: "app.yaml", ValidateMode: "one-off"}},
+		{name: "stdin", config: FlagConfig{ValidateConfig: "-", ValidateMode: "one-off", PolicyFile: "policy.yaml"}},
+		{name: "no config", config: FlagConfig{ValidateMode: "one-off"}, wantErr: "--config"},
+		{name: "monitor mode", config: FlagConfig{ValidateConfig: "app.yaml", ValidateMode: "monitor"}, wantErr: "one-off"},
+		{name: "gitops", config: FlagConfig{ValidateConfig: "rendered", ValidateMode: "one-off", GitOps: true}, wantErr: "--gitops"},
+		{name: "several clusters", config: FlagConfig{ValidateConfig: "app.yaml", ValidateMode: "one-off", KubeconfigContexts: "a,b"}, wantErr: "--kubeconfig-contexts"},
+		{name: "cluster policies", config: FlagConfig{ValidateConfig: "app.yaml", ValidateMode: "one-off", EnableValidationPolicies: true}, wantErr: "--policy-file"},
+		{name: "rules ConfigMap", config: FlagConfig{ValidateConfig: "app.yaml", ValidateMode: "one-off", CustomRulesConfigMap: "kogaro/rules"}, wantErr: "--custom-rules-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkOfflineFlags(&tt.config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkOfflineFlags() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkOfflineFlags() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
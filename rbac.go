@@ -0,0 +1,227 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// rbacManifestCommand is the subcommand that prints the minimal RBAC manifest for the
+// validators and features selected by the same flags as the controller
+const rbacManifestCommand = "rbac-manifest"
+
+// serviceAccountDir holds the credentials Kubernetes mounts for the pod's ServiceAccount
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// runRBACManifest writes the ClusterRole, and the Role for leader election and the
+// finding state hand-off, that the validators and features registered with the given
+// flags need. It returns the exit code.
+func runRBACManifest() int {
+	var name, namespace, serviceAccount string
+	flag.StringVar(&name, "rbac-name", "kogaro", "Name of the generated roles and bindings")
+	flag.StringVar(&namespace, "rbac-namespace", "kogaro-system", "Namespace Kogaro runs in")
+	flag.StringVar(&serviceAccount, "rbac-service-account", "kogaro", "ServiceAccount Kogaro runs as")
+	config := registerFlags()
+
+	rules, unknown := validators.RequiredPermissions(permissionOptions(config, enabledValidationTypes(config)))
+	for _, validationType := range unknown {
+		setupLog.Info("permissions of validator depend on its configuration; add read access to the resources it inspects", "validator", validationType)
+	}
+	if config.PluginDir != "" {
+		setupLog.Info("permissions of validator plugins depend on the plugins; add read access to the resources they inspect", "dir", config.PluginDir)
+	}
+
+	subject := rbacv1.Subject{Kind: rbacv1.ServiceAccountKind, Name: serviceAccount, Namespace: namespace}
+	objects := []interface{}{
+		&rbacv1.ClusterRole{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRole"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Rules:      rules,
+		},
+		&rbacv1.ClusterRoleBinding{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "ClusterRoleBinding"},
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+			Subjects:   []rbacv1.Subject{subject},
+		},
+	}
+	if config.EnableLeaderElection || config.HandoffConfigMap != "" {
+		var rules []rbacv1.PolicyRule
+		if config.EnableLeaderElection {
+			rules = append(rules, validators.LeaderElectionPermissions()...)
+		}
+		if config.HandoffConfigMap != "" {
+			rules = append(rules, validators.HandoffPermissions()...)
+		}
+		objects = append(objects,
+			&rbacv1.Role{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "Role"},
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-leader-election", Namespace: namespace},
+				Rules:      rules,
+			},
+			&rbacv1.RoleBinding{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "rbac.authorization.k8s.io/v1", Kind: "RoleBinding"},
+				ObjectMeta: metav1.ObjectMeta{Name: name + "-leader-election", Namespace: namespace},
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name + "-leader-election"},
+				Subjects:   []rbacv1.Subject{subject},
+			})
+	}
+
+	var manifest bytes.Buffer
+	for i, object := range objects {
+		data, err := yaml.Marshal(object)
+		if err != nil {
+			setupLog.Error(err, "failed to render RBAC manifest")
+			return 1
+		}
+		if i > 0 {
+			manifest.WriteString("---\n")
+		}
+		// Drop the empty creationTimestamp that metav1.ObjectMeta always marshals
+		manifest.Write(bytes.ReplaceAll(data, []byte("  creationTimestamp: null\n"), nil))
+	}
+
+	if config.OutputFile == "" {
+		fmt.Print(manifest.String())
+		return 0
+	}
+	if err := os.WriteFile(config.OutputFile, manifest.Bytes(), 0o644); err != nil {
+		setupLog.Error(err, "unable to write file", "path", config.OutputFile)
+		return 1
+	}
+	setupLog.Info("wrote file", "path", config.OutputFile)
+	return 0
+}
+
+// enabledValidationTypes returns the types of the validators setupValidators registers
+// with config, without connecting to a cluster
+func enabledValidationTypes(config *FlagConfig) []string {
+	types := []string{"reference_validation"}
+	for _, validator := range []struct {
+		enabled        bool
+		validationType string
+	}{
+		{config.EnableResourceLimitsValidation, "resource_limits_validation"},
+		{config.EnableSecurityValidation, "security_validation"},
+		{config.EnableNetworkingValidation, "networking_validation"},
+		{config.EnableImageValidation, "image_validation"},
+		{config.EnableSecretHygieneValidation, "secret_validation"},
+		{config.EnableVolumeValidation, "volume_validation"},
+		{config.EnableQuotaValidation, "quota_validation"},
+		{config.EnableLifecycleValidation, "lifecycle_validation"},
+		{config.EnableWorkloadValidation, "workload_validation"},
+		{config.EnableAvailabilityValidation, "availability_validation"},
+		{config.EnablePolicyReportValidation, "policy_report_validation"},
+		{config.CustomRulesFile != "" || config.CustomRulesConfigMap != "", "custom_rule_validation"},
+	} {
+		if validator.enabled {
+			types = append(types, validator.validationType)
+		}
+	}
+	return types
+}
+
+// permissionOptions selects the permissions needed by the given validators and the
+// features enabled in config
+func permissionOptions(config *FlagConfig, validationTypes []string) validators.PermissionOptions {
+	return validators.PermissionOptions{
+		ValidationTypes:     validationTypes,
+		ValidationPolicies:  config.EnableValidationPolicies,
+		WorkloadAnnotations: config.EnableWorkloadAnnotations,
+		ValidationReports:   config.EnableValidationReports,
+		PolicyReports:       config.EnablePolicyReports,
+		AutoRemediation:     config.EnableAutoRemediation,
+		UsageMetrics:        config.EnableResourceLimitsValidation && config.EnableUsageValidation,
+	}
+}
+
+// setupPermissionCheck audits the permissions of Kogaro's own ServiceAccount at startup
+// and registers the audit as a validator, so that permissions beyond those the
+// registered validators and enabled features need are reported as findings
+func setupPermissionCheck(mgr ctrl.Manager, registry *validators.ValidatorRegistry, config *FlagConfig) {
+	if !config.EnablePermissionSelfCheck {
+		return
+	}
+	namespace, serviceAccount, err := podServiceAccount()
+	if err != nil {
+		setupLog.Info("skipping permission self-check, Kogaro is not running as a ServiceAccount", "reason", err.Error())
+		return
+	}
+
+	var validationTypes []string
+	for _, validator := range registry.GetValidators() {
+		validationTypes = append(validationTypes, validator.GetValidationType())
+	}
+	required, unknown := validators.RequiredPermissions(permissionOptions(config, validationTypes))
+	if len(unknown) > 0 {
+		setupLog.Info("permission self-check does not know the resources these validators need and may report them as excess", "validators", unknown)
+	}
+
+	permissionValidator := validators.NewPermissionValidator(mgr.GetClient(), setupLog, validators.PermissionConfig{
+		Namespace:      namespace,
+		ServiceAccount: serviceAccount,
+		Required:       required,
+		LeaderElection: config.EnableLeaderElection,
+		Handoff:        config.HandoffConfigMap != "",
+	})
+	writes, reads, err := permissionValidator.Audit(context.Background())
+	switch {
+	case err != nil:
+		setupLog.Error(err, "permission self-check failed")
+	case len(writes) > 0:
+		setupLog.Info("WARNING: Kogaro's ServiceAccount holds write permissions no enabled feature needs", "service_account", serviceAccount, "write_permissions", writes, "read_permissions", reads)
+	case len(reads) > 0:
+		setupLog.Info("Kogaro's ServiceAccount can read resources no registered validator needs", "service_account", serviceAccount, "read_permissions", reads)
+	default:
+		setupLog.Info("permission self-check passed", "service_account", serviceAccount)
+	}
+	registry.Register(permissionValidator)
+}
+
+// podServiceAccount returns the namespace and name of the ServiceAccount whose token
+// Kubernetes mounted into the pod
+func podServiceAccount() (namespace, name string, err error) {
+	token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return "", "", err
+	}
+
+	// The token is a JWT whose subject is system:serviceaccount:<namespace>:<name>
+	parts := strings.Split(strings.TrimSpace(string(token)), ".")
+	if len(parts) != 3 {
+		return "", "", fmt.Errorf("service account token is not a JWT")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode service account token: %w", err)
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", "", fmt.Errorf("failed to decode service account token: %w", err)
+	}
+	fields := strings.Split(claims.Subject, ":")
+	if len(fields) != 4 || fields[0] != "system" || fields[1] != "serviceaccount" {
+		return "", "", fmt.Errorf("token subject %q is not a ServiceAccount", claims.Subject)
+	}
+	return fields[2], fields[3], nil
+}
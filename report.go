@@ -0,0 +1,215 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// reportCommand is the subcommand that validates like validate and summarises the
+// findings by the controls of a compliance framework
+const reportCommand = "report"
+
+// validReportOutputFormats lists the values kogaro report accepts for --output
+var validReportOutputFormats = []string{"text", "json", "markdown"}
+
+// Statuses of a control in a compliance report
+const (
+	controlPassed     = "pass"
+	controlFailed     = "fail"
+	controlNotChecked = "not checked"
+)
+
+// controlReport is the outcome of one control in a compliance report
+type controlReport struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Status     string   `json:"status"`
+	ErrorCodes []string `json:"errorCodes"`
+	// Flags enable the checks of a control that is not checked
+	Flags    []string                     `json:"flags,omitempty"`
+	Findings []validators.ValidationError `json:"findings,omitempty"`
+}
+
+// complianceReport summarises the findings of a validation by the controls of a
+// compliance framework
+type complianceReport struct {
+	Framework string `json:"framework"`
+	Title     string `json:"title"`
+	Summary   struct {
+		Passed     int `json:"passed"`
+		Failed     int `json:"failed"`
+		NotChecked int `json:"notChecked"`
+		// HygieneScore is the hygiene score of the validated resources, from 0 to 100
+		HygieneScore int `json:"hygieneScore"`
+	} `json:"summary"`
+	Controls []controlReport `json:"controls"`
+}
+
+// checkReportFlags rejects the flags kogaro report can't run with
+func checkReportFlags(config *FlagConfig, framework string) error {
+	if _, ok := validators.LookupComplianceFramework(framework); !ok {
+		return fmt.Errorf("--framework must be one of %s", strings.Join(complianceFrameworkNames(), ", "))
+	}
+	if config.ValidateMode != "one-off" {
+		return fmt.Errorf("kogaro report runs with --mode=one-off")
+	}
+	if !slices.Contains(validReportOutputFormats, config.ValidateOutput) {
+		return fmt.Errorf("--output must be one of %s", strings.Join(validReportOutputFormats, ", "))
+	}
+	return nil
+}
+
+// complianceFrameworkNames returns the names --framework accepts
+func complianceFrameworkNames() []string {
+	var names []string
+	for _, framework := range validators.ComplianceFrameworks() {
+		names = append(names, framework.Name)
+	}
+	return names
+}
+
+// buildComplianceReport reports each control of a framework as failed when the result
+// has findings of its error codes, as passed when one of its checks ran, and as not
+// checked when the flags enable none of them
+func buildComplianceReport(framework validators.ComplianceFramework, config *FlagConfig, result validators.ValidationResult) complianceReport {
+	features := make(map[string]ruleFeature)
+	for _, feature := range ruleFeatures {
+		for _, validationType := range feature.validationTypes {
+			features[validationType] = feature
+		}
+	}
+	findings := make(map[string][]validators.ValidationError)
+	for _, finding := range result.Errors {
+		findings[finding.ErrorCode] = append(findings[finding.ErrorCode], finding)
+	}
+
+	report := complianceReport{Framework: framework.Name, Title: framework.Title}
+	report.Summary.HygieneScore = result.Summary.HygieneScore
+	for _, control := range framework.Controls {
+		outcome := controlReport{ID: control.ID, Title: control.Title, Status: controlNotChecked, ErrorCodes: control.ErrorCodes}
+		for _, code := range control.ErrorCodes {
+			outcome.Findings = append(outcome.Findings, findings[code]...)
+
+			info, _ := validators.LookupErrorCode(code)
+			feature, ok := features[info.ValidationType]
+			switch {
+			case ok && feature.enabled(config):
+				outcome.Status = controlPassed
+			case ok:
+				for _, name := range feature.flags {
+					if !slices.Contains(outcome.Flags, "--"+name) {
+						outcome.Flags = append(outcome.Flags, "--"+name)
+					}
+				}
+			}
+		}
+		if len(outcome.Findings) > 0 {
+			outcome.Status = controlFailed
+		}
+		if outcome.Status != controlNotChecked {
+			outcome.Flags = nil
+		}
+
+		switch outcome.Status {
+		case controlPassed:
+			report.Summary.Passed++
+		case controlFailed:
+			report.Summary.Failed++
+		default:
+			report.Summary.NotChecked++
+		}
+		report.Controls = append(report.Controls, outcome)
+	}
+	return report
+}
+
+// emitComplianceReport writes the compliance report of a result in the --output format
+// and exits with the result's exit code
+func emitComplianceReport(config *FlagConfig, result validators.ValidationResult) {
+	framework, _ := validators.LookupComplianceFramework(config.ComplianceFramework)
+	report := buildComplianceReport(framework, config, result)
+
+	var output strings.Builder
+	switch config.ValidateOutput {
+	case "json":
+		encoder := json.NewEncoder(&output)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			setupLog.Error(err, "failed to encode compliance report")
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+	case "markdown":
+		writeComplianceMarkdown(&output, report)
+	default:
+		writeComplianceText(&output, report)
+	}
+
+	if config.OutputFile != "" {
+		if err := os.WriteFile(config.OutputFile, []byte(output.String()), 0o600); err != nil {
+			setupLog.Error(err, "failed to write output file", "path", config.OutputFile)
+			os.Exit(validators.ExitCodeInternalFailure)
+		}
+		setupLog.Info("compliance report written", "path", config.OutputFile, "framework", report.Framework)
+	} else {
+		fmt.Print(output.String())
+	}
+	os.Exit(result.ExitCode)
+}
+
+// writeComplianceText writes a compliance report as text, with the findings under each
+// failed control
+func writeComplianceText(w io.Writer, report complianceReport) {
+	_, _ = fmt.Fprintf(w, "%s\n", report.Title)
+	_, _ = fmt.Fprintf(w, "%d passed, %d failed, %d not checked\n", report.Summary.Passed, report.Summary.Failed, report.Summary.NotChecked)
+	_, _ = fmt.Fprintf(w, "Hygiene score: %d/%d\n\n", report.Summary.HygieneScore, validators.MaxHygieneScore)
+	for _, control := range report.Controls {
+		_, _ = fmt.Fprintf(w, "[%s] %s %s\n", strings.ToUpper(control.Status), control.ID, control.Title)
+		for _, finding := range control.Findings {
+			_, _ = fmt.Fprintf(w, "    %s %s: %s\n", finding.ErrorCode, findingResource(finding), finding.Message)
+		}
+		if len(control.Flags) > 0 {
+			_, _ = fmt.Fprintf(w, "    enable with %s\n", strings.Join(control.Flags, " "))
+		}
+	}
+}
+
+// writeComplianceMarkdown writes a compliance report as a Markdown table of controls
+// followed by the findings of the failed ones
+func writeComplianceMarkdown(w io.Writer, report complianceReport) {
+	_, _ = fmt.Fprintf(w, "## %s\n\n", report.Title)
+	_, _ = fmt.Fprintf(w, "**%d passed, %d failed, %d not checked**\n\n", report.Summary.Passed, report.Summary.Failed, report.Summary.NotChecked)
+	_, _ = fmt.Fprintf(w, "Hygiene score: %d/%d\n\n", report.Summary.HygieneScore, validators.MaxHygieneScore)
+	_, _ = fmt.Fprintln(w, "| Control | Title | Status | Findings |")
+	_, _ = fmt.Fprintln(w, "|---------|-------|--------|----------|")
+	for _, control := range report.Controls {
+		_, _ = fmt.Fprintf(w, "| %s | %s | %s | %d |\n", control.ID, control.Title, control.Status, len(control.Findings))
+	}
+	for _, control := range report.Controls {
+		if control.Status != controlFailed {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "\n### %s %s\n\n", control.ID, control.Title)
+		for _, finding := range control.Findings {
+			_, _ = fmt.Fprintf(w, "- `%s` %s: %s\n", finding.ErrorCode, findingResource(finding), finding.Message)
+		}
+	}
+}
+
+// findingResource names the resource of a finding as Kind namespace/name
+func findingResource(finding validators.ValidationError) string {
+	if finding.Namespace == "" {
+		return finding.ResourceType + " " + finding.ResourceName
+	}
+	return finding.ResourceType + " " + finding.Namespace + "/" + finding.ResourceName
+}
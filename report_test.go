@@ -0,0 +1,80 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestBuildComplianceReport(t *testing.T) {
+	framework, _ := validators.LookupComplianceFramework("cis")
+	config := &FlagConfig{EnableSecurityValidation: true, EnableSecurityContextValidation: true, EnableRootUserValidation: true}
+	result := validators.ValidationResult{Errors: []validators.ValidationError{
+		validators.NewValidationErrorWithCode("Deployment", "web", "shop", "container_privileged_mode", "KOGARO-SEC-006", "Container 'web' runs in privileged mode"),
+		validators.NewValidationErrorWithCode("Service", "web", "shop", "service_selector_mismatch", "KOGARO-NET-001", "Service selector does not match any pods"),
+	}}
+
+	report := buildComplianceReport(framework, config, result)
+	controls := make(map[string]controlReport)
+	for _, control := range report.Controls {
+		controls[control.ID] = control
+	}
+	if len(report.Controls) != len(framework.Controls) {
+		t.Fatalf("report has %d controls, want %d", len(report.Controls), len(framework.Controls))
+	}
+
+	if privileged := controls["5.2.2"]; privileged.Status != controlFailed || len(privileged.Findings) != 1 {
+		t.Errorf("5.2.2 = %+v, want it failed by the privileged finding", privileged)
+	}
+	if root := controls["5.2.7"]; root.Status != controlPassed || len(root.Findings) != 0 {
+		t.Errorf("5.2.7 = %+v, want it passed", root)
+	}
+	hostPath := controls["5.2.12"]
+	if hostPath.Status != controlNotChecked || strings.Join(hostPath.Flags, " ") != "--enable-security-validation --enable-host-path-validation" {
+		t.Errorf("5.2.12 = %+v, want it not checked with the flags enabling it", hostPath)
+	}
+	if report.Summary.Failed != 1 || report.Summary.Passed+report.Summary.Failed+report.Summary.NotChecked != len(framework.Controls) {
+		t.Errorf("summary = %+v", report.Summary)
+	}
+
+	var text strings.Builder
+	writeComplianceText(&text, report)
+	for _, want := range []string{
+		"CIS Kubernetes Benchmark v1.9",
+		"[FAIL] 5.2.2 Minimize the admission of privileged containers\n    KOGARO-SEC-006 Deployment shop/web: Container 'web' runs in privileged mode",
+		"[PASS] 5.2.7 Minimize the admission of root containers",
+		"[NOT CHECKED] 5.2.12 Minimize the admission of HostPath volumes\n    enable with --enable-security-validation --enable-host-path-validation",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report does not contain %q:\n%s", want, text.String())
+		}
+	}
+
+	var markdown strings.Builder
+	writeComplianceMarkdown(&markdown, report)
+	for _, want := range []string{"| 5.2.2 | Minimize the admission of privileged containers | fail | 1 |", "### 5.2.2 Minimize the admission of privileged containers"} {
+		if !strings.Contains(markdown.String(), want) {
+			t.Errorf("markdown report does not contain %q:\n%s", want, markdown.String())
+		}
+	}
+}
+
+func TestCheckReportFlags(t *testing.T) {
+	config := &FlagConfig{ValidateMode: "one-off", ValidateOutput: "text"}
+	if err := checkReportFlags(config, "nsa"); err != nil {
+		t.Errorf("checkReportFlags() error = %v", err)
+	}
+	if err := checkReportFlags(config, "sox"); err == nil || !strings.Contains(err.Error(), "cis, nsa, pci") {
+		t.Errorf("checkReportFlags(sox) error = %v, want the supported frameworks", err)
+	}
+	config.ValidateOutput = "html"
+	if err := checkReportFlags(config, "cis"); err == nil {
+		t.Error("checkReportFlags() accepted --output=html")
+	}
+}
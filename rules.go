@@ -0,0 +1,366 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// rulesCommand is the subcommand that lists every check, its error code and the flags
+// controlling it, for the validators and features selected by the controller's flags
+const rulesCommand = "rules"
+
+// ruleFeature is a group of checks of one validator switched on by the same flags
+type ruleFeature struct {
+	validator string
+	name      string
+	// flags switch the checks on
+	flags []string
+	// settings tune the checks
+	settings []string
+	enabled  func(config *FlagConfig) bool
+	// validationTypes are the checks of the feature
+	validationTypes []string
+}
+
+// ruleFeatures lists the checks of every validator by the flags that control them.
+// Keep it in step with registerFlags and setupValidators.
+var ruleFeatures = []ruleFeature{
+	// Reference validation is always registered
+	{"reference_validation", "Ingress References", []string{"enable-ingress-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableIngressValidation },
+		[]string{"dangling_ingress_class", "dangling_service_reference", "dangling_tls_secret"}},
+	{"reference_validation", "ConfigMap References", []string{"enable-configmap-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableConfigMapValidation },
+		[]string{"dangling_configmap_volume", "dangling_configmap_envfrom", "dangling_configmap_env", "missing_configmap_key", "dangling_projected_configmap"}},
+	{"reference_validation", "Secret References", []string{"enable-secret-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableSecretValidation },
+		[]string{"dangling_secret_volume", "dangling_secret_envfrom", "dangling_secret_env", "missing_secret_key", "dangling_projected_secret",
+			"dangling_projected_token_service_account", "dangling_secret_provider_class", "dangling_image_pull_secret"}},
+	{"reference_validation", "Storage References", []string{"enable-pvc-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnablePVCValidation },
+		[]string{"dangling_pvc_reference", "dangling_storage_class"}},
+	{"reference_validation", "ServiceAccount References", []string{"enable-reference-serviceaccount-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableServiceAccountValidation },
+		[]string{"dangling_service_account"}},
+	{"reference_validation", "Downward API References", []string{"enable-downward-api-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableDownwardAPIValidation },
+		[]string{"dangling_field_ref", "dangling_resource_field_ref"}},
+	{"reference_validation", "Unused Resources", []string{"enable-unused-resource-validation"}, []string{"unused-resource-min-age"},
+		func(c *FlagConfig) bool { return c.EnableUnusedResourceValidation },
+		[]string{"unused_configmap", "unused_secret", "unused_pvc", "unused_serviceaccount"}},
+
+	{"resource_limits_validation", "Missing Requests", []string{"enable-resource-limits-validation", "enable-missing-requests-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableMissingRequestsValidation },
+		[]string{"missing_resource_requests"}},
+	{"resource_limits_validation", "Minimum CPU Request", []string{"enable-resource-limits-validation", "enable-missing-requests-validation", "min-cpu-request"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableResourceLimitsValidation && c.EnableMissingRequestsValidation && c.MinCPURequest != ""
+		},
+		[]string{"insufficient_cpu_request"}},
+	{"resource_limits_validation", "Minimum Memory Request", []string{"enable-resource-limits-validation", "enable-missing-requests-validation", "min-memory-request"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableResourceLimitsValidation && c.EnableMissingRequestsValidation && c.MinMemoryRequest != ""
+		},
+		[]string{"insufficient_memory_request"}},
+	{"resource_limits_validation", "Missing Limits", []string{"enable-resource-limits-validation", "enable-missing-limits-validation"}, []string{"forbid-cpu-limits"},
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableMissingLimitsValidation },
+		[]string{"missing_resource_limits"}},
+	{"resource_limits_validation", "QoS", []string{"enable-resource-limits-validation", "enable-qos-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableQoSValidation },
+		[]string{"qos_class_issue"}},
+	{"resource_limits_validation", "Node Capacity", []string{"enable-resource-limits-validation", "enable-node-capacity-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableNodeCapacityValidation },
+		[]string{"request_exceeds_node_capacity", "limit_exceeds_node_capacity", "namespace_requests_exceed_capacity"}},
+	{"resource_limits_validation", "Overcommit", []string{"enable-resource-limits-validation", "enable-overcommit-validation"}, []string{"max-cpu-limit-request-ratio", "max-memory-limit-request-ratio"},
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableOvercommitValidation },
+		[]string{"limit_request_ratio_exceeded", "memory_limit_without_request"}},
+	{"resource_limits_validation", "Forbidden CPU Limits", []string{"enable-resource-limits-validation", "forbid-cpu-limits"}, nil,
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.ForbidCPULimits },
+		[]string{"cpu_limit_forbidden"}},
+	{"resource_limits_validation", "Right-Sizing", []string{"enable-resource-limits-validation", "enable-vpa-recommendation-validation"}, []string{"vpa-deviation-percent"},
+		func(c *FlagConfig) bool {
+			return c.EnableResourceLimitsValidation && c.EnableVPARecommendationValidation
+		},
+		[]string{"request_deviates_from_vpa_recommendation"}},
+	{"resource_limits_validation", "Usage", []string{"enable-resource-limits-validation", "enable-usage-validation"}, []string{"usage-limit-threshold", "usage-waste-ratio"},
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableUsageValidation },
+		[]string{"usage_near_limit", "request_far_above_usage"}},
+	{"resource_limits_validation", "Restarts", []string{"enable-resource-limits-validation", "enable-restart-validation"}, []string{"restart-threshold", "oom-kill-window"},
+		func(c *FlagConfig) bool { return c.EnableResourceLimitsValidation && c.EnableRestartValidation },
+		[]string{"container_oom_killed", "container_restart_loop"}},
+
+	{"security_validation", "Root User & Privileges", []string{"enable-security-validation", "enable-security-context-validation", "enable-root-user-validation"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableSecurityValidation && c.EnableSecurityContextValidation && c.EnableRootUserValidation
+		},
+		[]string{"pod_running_as_root", "pod_allows_root_user", "container_running_as_root", "container_allows_privilege_escalation",
+			"container_privileged_mode", "container_writable_root_filesystem"}},
+	{"security_validation", "Security Contexts", []string{"enable-security-validation", "enable-security-context-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableSecurityContextValidation },
+		[]string{"missing_pod_security_context", "missing_container_security_context", "container_additional_capabilities",
+			"container_capabilities_not_dropped", "container_unmasked_proc_mount", "pod_unsafe_sysctl"}},
+	{"security_validation", "ServiceAccount & RBAC Security", []string{"enable-security-validation", "enable-security-serviceaccount-validation"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableSecurityValidation && c.EnableSecurityServiceAccountValidation
+		},
+		[]string{"serviceaccount_cluster_role_binding", "serviceaccount_excessive_permissions", "serviceaccount_wildcard_permissions",
+			"serviceaccount_cluster_secrets_access", "serviceaccount_privilege_escalation_verbs", "serviceaccount_pod_exec_permissions"}},
+	{"security_validation", "RBAC Hygiene", []string{"enable-security-validation", "enable-rbac-hygiene-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableRBACHygieneValidation },
+		[]string{"clusterrole_aggregation_matches_nothing", "clusterrole_aggregation_loop", "unused_role", "binding_unverifiable_subject"}},
+	{"security_validation", "Host Paths", []string{"enable-security-validation", "enable-host-path-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableHostPathValidation },
+		[]string{"host_path_sensitive_mount"}},
+	{"security_validation", "Host Namespaces", []string{"enable-security-validation", "enable-host-namespace-validation"}, []string{"host-namespace-allowed-namespaces"},
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableHostNamespaceValidation },
+		[]string{"host_namespace_sharing"}},
+	{"security_validation", "Host Ports", []string{"enable-security-validation", "enable-host-port-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableHostPortValidation },
+		[]string{"host_port_collision"}},
+	{"security_validation", "NetworkPolicy Coverage", []string{"enable-security-validation", "enable-network-policy-validation"}, []string{"security-required-namespaces"},
+		func(c *FlagConfig) bool { return c.EnableSecurityValidation && c.EnableNetworkPolicyValidation },
+		[]string{"missing_network_policy_security_sensitive", "missing_network_policy_production"}},
+
+	{"networking_validation", "Service Connectivity", []string{"enable-networking-validation", "enable-networking-service-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingServiceValidation },
+		[]string{"service_selector_mismatch", "service_no_endpoints", "service_port_mismatch"}},
+	{"networking_validation", "Unexposed Pods", []string{"enable-networking-validation", "enable-networking-service-validation", "warn-unexposed-pods"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableNetworkingValidation && c.EnableNetworkingServiceValidation && c.WarnUnexposedPods
+		},
+		[]string{"pod_no_service"}},
+	{"networking_validation", "Service Topology", []string{"enable-networking-validation", "enable-service-topology-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableServiceTopologyValidation },
+		[]string{"service_local_traffic_policy_sparse", "service_session_affinity_headless", "service_load_balancer_pending"}},
+	{"networking_validation", "NetworkPolicy Coverage", []string{"enable-networking-validation", "enable-networking-policy-validation"}, []string{"networking-required-namespaces"},
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingPolicyValidation },
+		[]string{"network_policy_orphaned", "missing_network_policy_default_deny"}},
+	{"networking_validation", "Ingress Connectivity", []string{"enable-networking-validation", "enable-networking-ingress-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingIngressValidation },
+		[]string{"ingress_service_missing", "ingress_service_port_mismatch", "ingress_no_backend_pods"}},
+	{"networking_validation", "Duplicates", []string{"enable-networking-validation", "enable-networking-duplicate-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingDuplicateValidation },
+		[]string{"duplicate_service", "duplicate_ingress_rule", "duplicate_network_policy"}},
+	{"networking_validation", "Ingress Host Collisions", []string{"enable-networking-validation", "enable-ingress-collision-validation"}, []string{"ingress-collision-cross-namespace-only"},
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableIngressCollisionValidation },
+		[]string{"ingress_host_collision", "ingress_wildcard_host_overlap", "ingress_path_overlap"}},
+	{"networking_validation", "Ingress TLS", []string{"enable-networking-validation", "enable-ingress-tls-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableIngressTLSValidation },
+		[]string{"ingress_tls_host_without_rule", "ingress_tls_secret_wrong_type", "ingress_tls_certificate_host_mismatch"}},
+	{"networking_validation", "Required Ingress TLS", []string{"enable-networking-validation", "enable-ingress-tls-validation", "require-ingress-tls"}, nil,
+		func(c *FlagConfig) bool {
+			return c.EnableNetworkingValidation && c.EnableIngressTLSValidation && c.RequireIngressTLS
+		},
+		[]string{"ingress_host_without_tls"}},
+	{"networking_validation", "NetworkPolicy Simulation", []string{"enable-networking-validation", "enable-network-policy-simulation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkPolicySimulation },
+		[]string{"network_policy_blocks_dependency", "network_policy_egress_allow_all"}},
+	{"networking_validation", "Egress Policies", []string{"enable-networking-validation", "enable-networking-egress-validation"}, []string{"cluster-cidrs"},
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingEgressValidation },
+		[]string{"network_policy_blocks_dns", "network_policy_namespace_selector_unmatched", "network_policy_ipblock_overlaps_cluster"}},
+	{"networking_validation", "DNS", []string{"enable-networking-validation", "enable-networking-dns-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableNetworkingDNSValidation },
+		[]string{"dns_policy_none_without_config", "host_alias_shadows_service"}},
+	{"networking_validation", "ExternalName Resolution", []string{"enable-networking-validation", "enable-external-name-resolution"}, []string{"dns-lookup-timeout"},
+		func(c *FlagConfig) bool { return c.EnableNetworkingValidation && c.EnableExternalNameResolution },
+		[]string{"external_name_unresolvable"}},
+
+	{"image_validation", "Image Registry & Architecture", []string{"enable-image-validation"}, []string{"allow-missing-images", "allow-architecture-mismatch"},
+		func(c *FlagConfig) bool { return c.EnableImageValidation },
+		[]string{"invalid_image_reference", "missing_image", "missing_image_warning", "architecture_mismatch", "architecture_mismatch_warning"}},
+
+	{"secret_validation", "Secret Hygiene", []string{"enable-secret-hygiene-validation"}, []string{"cert-expiry-warning-days"},
+		func(c *FlagConfig) bool { return c.EnableSecretHygieneValidation },
+		[]string{"empty_secret_referenced", "tls_secret_missing_keys", "tls_secret_invalid_certificate", "tls_certificate_expired",
+			"tls_certificate_expiring", "dockerconfigjson_missing_key", "dockerconfigjson_malformed", "basic_auth_missing_keys"}},
+
+	{"volume_validation", "Volume Declarations & Mounts", []string{"enable-volume-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableVolumeValidation },
+		[]string{"duplicate_volume_name", "duplicate_mount_path"}},
+	{"volume_validation", "SubPath Items", []string{"enable-volume-validation", "enable-volume-subpath-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableVolumeValidation && c.EnableVolumeSubPathValidation },
+		[]string{"subpath_item_missing"}},
+	{"volume_validation", "Read-Only Mounts", []string{"enable-volume-validation", "enable-volume-readonly-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableVolumeValidation && c.EnableVolumeReadOnlyValidation },
+		[]string{"readonly_mount_expected_writable"}},
+
+	{"quota_validation", "ResourceQuota & LimitRange", []string{"enable-quota-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableQuotaValidation },
+		[]string{"resource_quota_exceeded", "limitrange_below_min", "limitrange_above_max", "quota_without_limitrange", "quota_requires_explicit_resources"}},
+
+	{"lifecycle_validation", "Ownership & Garbage Collection", []string{"enable-lifecycle-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableLifecycleValidation },
+		[]string{"orphaned_replicaset", "dangling_owner_reference", "cross_namespace_owner_reference"}},
+
+	{"workload_validation", "StatefulSets", []string{"enable-workload-validation", "enable-statefulset-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableWorkloadValidation && c.EnableStatefulSetValidation },
+		[]string{"statefulset_missing_service_name", "statefulset_dangling_service", "statefulset_service_not_headless",
+			"statefulset_dangling_storage_class", "statefulset_ondelete_without_reason"}},
+	{"workload_validation", "DaemonSets", []string{"enable-workload-validation", "enable-daemonset-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableWorkloadValidation && c.EnableDaemonSetValidation },
+		[]string{"daemonset_host_network_without_reason", "daemonset_invalid_max_unavailable"}},
+	{"workload_validation", "Pod Stalls", []string{"enable-workload-validation", "enable-pod-stall-validation"}, []string{"pod-stall-timeout"},
+		func(c *FlagConfig) bool { return c.EnableWorkloadValidation && c.EnablePodStallValidation },
+		[]string{"readiness_gate_condition_missing", "pod_unschedulable", "pod_crash_loop_backoff"}},
+
+	{"availability_validation", "High Availability", []string{"enable-availability-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnableAvailabilityValidation },
+		[]string{"replicas_not_spread", "single_replica_production", "workload_pinned_to_single_zone"}},
+
+	{"policy_report_validation", "Policy Reports", []string{"enable-policy-report-validation"}, nil,
+		func(c *FlagConfig) bool { return c.EnablePolicyReportValidation },
+		[]string{"policy_violation", "policy_warning", "policy_evaluation_error"}},
+
+	{"custom_rule_validation", "Custom Rules", []string{"custom-rules-file", "custom-rules-configmap"}, nil,
+		func(c *FlagConfig) bool { return c.CustomRulesFile != "" || c.CustomRulesConfigMap != "" },
+		[]string{"custom_rule_evaluation_failed"}},
+
+	{"plugin:*", "Validator Plugins", []string{"plugin-dir"}, []string{"plugin-timeout"},
+		func(c *FlagConfig) bool { return c.PluginDir != "" },
+		[]string{"plugin_failed"}},
+
+	{"validator_registry", "Validator Timeouts", []string{"validator-timeout"}, nil,
+		func(c *FlagConfig) bool { return c.ValidatorTimeout > 0 },
+		[]string{"validator_timeout"}},
+	{"validator_registry", "Validator Panics", nil, nil,
+		func(c *FlagConfig) bool { return true },
+		[]string{"validator_panic"}},
+	{"validator_registry", "Config Decoding", []string{"config"}, nil,
+		func(c *FlagConfig) bool { return c.ValidateConfig != "" },
+		[]string{"invalid_config_object", "unknown_config_field", "invalid_custom_resource"}},
+	{"validator_registry", "Offline Skip Markers", []string{"offline"}, nil,
+		func(c *FlagConfig) bool { return c.Offline },
+		[]string{"check_skipped_offline"}},
+
+	{"permission_validation", "Permission Self-Check", []string{"enable-permission-self-check"}, nil,
+		func(c *FlagConfig) bool { return c.EnablePermissionSelfCheck },
+		[]string{"agent_write_permissions", "agent_excess_read_permissions"}},
+
+	// Drift is only reported by kogaro diff
+	{diffCommand, "Cluster Drift", nil, nil,
+		func(c *FlagConfig) bool { return false },
+		[]string{"image_drift", "resource_settings_drift", "security_context_drift", "replica_count_drift", "env_drift", "resource_not_in_git"}},
+}
+
+// ruleFlag is a flag controlling a rule, with its value under the given flags
+type ruleFlag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ruleOutput is one check in the rules listing
+type ruleOutput struct {
+	Validator      string              `json:"validator"`
+	Feature        string              `json:"feature"`
+	ValidationType string              `json:"validationType"`
+	ErrorCode      string              `json:"errorCode"`
+	Severity       validators.Severity `json:"severity"`
+	Title          string              `json:"title"`
+	ResourceType   string              `json:"resourceType"`
+	Enabled        bool                `json:"enabled"`
+	Flags          []ruleFlag          `json:"flags"`
+	Settings       []ruleFlag          `json:"settings,omitempty"`
+	DocURL         string              `json:"docURL"`
+}
+
+// runRules lists every check with its error code, default severity and the flags that
+// control it. It takes the controller's flags, so the listing shows which checks those
+// flags enable. It returns the exit code.
+func runRules() int {
+	config := registerFlags()
+	if config.ValidateOutput != "text" && config.ValidateOutput != "json" {
+		setupLog.Error(nil, "invalid output format", "output", config.ValidateOutput, "valid", "text, json")
+		return 1
+	}
+
+	rules := listRules(config, flag.Lookup)
+	if config.ValidateOutput == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rules); err != nil {
+			setupLog.Error(err, "failed to encode rules")
+			return 1
+		}
+		return 0
+	}
+	writeRules(os.Stdout, rules)
+	return 0
+}
+
+// listRules lists a rule for every registered error code, in code order, looking up
+// the values of the flags controlling it with lookup
+func listRules(config *FlagConfig, lookup func(name string) *flag.Flag) []ruleOutput {
+	features := make(map[string]ruleFeature)
+	for _, feature := range ruleFeatures {
+		for _, validationType := range feature.validationTypes {
+			features[validationType] = feature
+		}
+	}
+	flagValues := func(names []string) []ruleFlag {
+		values := []ruleFlag{}
+		for _, name := range names {
+			value := ""
+			if f := lookup(name); f != nil {
+				value = f.Value.String()
+			}
+			values = append(values, ruleFlag{Name: "--" + name, Value: value})
+		}
+		return values
+	}
+
+	var rules []ruleOutput
+	for _, info := range validators.ErrorCodes() {
+		feature, ok := features[info.ValidationType]
+		if !ok {
+			continue
+		}
+		rules = append(rules, ruleOutput{
+			Validator:      feature.validator,
+			Feature:        feature.name,
+			ValidationType: info.ValidationType,
+			ErrorCode:      info.Code,
+			Severity:       info.Severity,
+			Title:          info.Title,
+			ResourceType:   info.ResourceType,
+			Enabled:        feature.enabled(config),
+			Flags:          flagValues(feature.flags),
+			Settings:       flagValues(feature.settings),
+			DocURL:         info.DocURL,
+		})
+	}
+	return rules
+}
+
+// writeRules writes the rules as a table
+func writeRules(w io.Writer, rules []ruleOutput) {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "CODE\tVALIDATION TYPE\tSEVERITY\tENABLED\tFLAGS")
+	enabled := 0
+	for _, rule := range rules {
+		var flags []string
+		for _, f := range rule.Flags {
+			flags = append(flags, f.Name)
+		}
+		if rule.Validator == diffCommand {
+			flags = append(flags, "(kogaro diff)")
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%s\t%t\t%s\n", rule.ErrorCode, rule.ValidationType, rule.Severity, rule.Enabled, strings.Join(flags, " "))
+		if rule.Enabled {
+			enabled++
+		}
+	}
+	_ = table.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d of %d rules enabled\n", enabled, len(rules))
+}
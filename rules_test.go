@@ -0,0 +1,70 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestRuleFeatures_CoverErrorCodes(t *testing.T) {
+	registered := make(map[string]bool)
+	for _, info := range validators.ErrorCodes() {
+		registered[info.ValidationType] = true
+	}
+
+	features := make(map[string]string)
+	for _, feature := range ruleFeatures {
+		for _, validationType := range feature.validationTypes {
+			if other, ok := features[validationType]; ok {
+				t.Errorf("%s is listed by both %s and %s", validationType, other, feature.name)
+			}
+			features[validationType] = feature.name
+			if !registered[validationType] {
+				t.Errorf("%s of %s has no registered error code", validationType, feature.name)
+			}
+		}
+	}
+	for validationType := range registered {
+		if _, ok := features[validationType]; !ok {
+			t.Errorf("%s is not listed by any rule feature", validationType)
+		}
+	}
+}
+
+func TestListRules(t *testing.T) {
+	flags := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags.Bool("enable-networking-validation", true, "")
+	flags.Bool("enable-networking-service-validation", true, "")
+	flags.Bool("warn-unexposed-pods", false, "")
+	config := &FlagConfig{EnableNetworkingValidation: true, EnableNetworkingServiceValidation: true}
+
+	rules := listRules(config, flags.Lookup)
+	if len(rules) != len(validators.ErrorCodes()) {
+		t.Fatalf("listRules() returned %d rules, want one per error code (%d)", len(rules), len(validators.ErrorCodes()))
+	}
+
+	byCode := make(map[string]ruleOutput)
+	for _, rule := range rules {
+		byCode[rule.ErrorCode] = rule
+	}
+	selector := byCode["KOGARO-NET-001"]
+	if !selector.Enabled || selector.Validator != "networking_validation" || selector.Feature != "Service Connectivity" {
+		t.Errorf("KOGARO-NET-001 = %+v, want an enabled Service Connectivity rule", selector)
+	}
+	unexposed := byCode["KOGARO-NET-004"]
+	if unexposed.Enabled {
+		t.Error("KOGARO-NET-004 is enabled without --warn-unexposed-pods")
+	}
+	if len(unexposed.Flags) != 3 || unexposed.Flags[2] != (ruleFlag{Name: "--warn-unexposed-pods", Value: "false"}) {
+		t.Errorf("KOGARO-NET-004 flags = %+v", unexposed.Flags)
+	}
+	if drift := byCode["KOGARO-DRF-001"]; drift.Enabled || drift.Validator != diffCommand {
+		t.Errorf("KOGARO-DRF-001 = %+v, want a disabled diff rule", drift)
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// simulateTrafficCommand is the subcommand that evaluates the NetworkPolicies of a
+// cluster for traffic between selected pods
+const simulateTrafficCommand = "simulate-traffic"
+
+// simulatedPathOutput is one source and destination pair in JSON output
+type simulatedPathOutput struct {
+	Source      string   `json:"source"`
+	Destination string   `json:"destination"`
+	Allowed     bool     `json:"allowed"`
+	BlockedBy   string   `json:"blocked_by,omitempty"`
+	Policies    []string `json:"policies,omitempty"`
+}
+
+// runSimulateTraffic evaluates the NetworkPolicies of a cluster for traffic from the
+// pods selected by --from to the port of the pods selected by --to. It returns 1 on
+// errors, or when --fail-on-blocked is set and any pair is blocked.
+func runSimulateTraffic(args []string) int {
+	var target clusterTarget
+	var from, to, port, protocol, output string
+	var timeout time.Duration
+	var failOnBlocked bool
+	flags := flag.NewFlagSet(simulateTrafficCommand, flag.ExitOnError)
+	flags.StringVar(&target.Context, "context", "", "Kubeconfig context of the cluster (defaults to the current context)")
+	flags.StringVar(&target.Kubeconfig, "kubeconfig", "", "Kubeconfig file of the cluster (defaults to the standard locations)")
+	flags.StringVar(&from, "from", "", "Source pods as namespace/selector, such as shop/app=web; * selects all namespaces and an omitted selector all pods")
+	flags.StringVar(&to, "to", "", "Destination pods as namespace/selector, such as shop/app=db")
+	flags.StringVar(&port, "port", "", "Destination port, as a number or a container port name")
+	flags.StringVar(&protocol, "protocol", "TCP", "Protocol: TCP, UDP or SCTP")
+	flags.StringVar(&output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&timeout, "timeout", time.Minute, "Maximum time to read the cluster")
+	flags.BoolVar(&failOnBlocked, "fail-on-blocked", false, "Exit with status 1 when traffic between any selected pods is blocked")
+
+	opts := zap.Options{Development: true}
+	opts.BindFlags(flags)
+	_ = flags.Parse(args)
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if from == "" || to == "" || port == "" {
+		setupLog.Error(nil, "kogaro simulate-traffic requires --from, --to and --port")
+		return 1
+	}
+	if output != "text" && output != "json" {
+		setupLog.Error(nil, "invalid output format", "output", output, "valid", "text, json")
+		return 1
+	}
+	query := validators.SimulationQuery{Port: intstr.Parse(port), Protocol: corev1.Protocol(strings.ToUpper(protocol))}
+	var err error
+	if query.SourceNamespace, query.SourceSelector, err = parsePodSelection(from); err != nil {
+		setupLog.Error(err, "invalid --from")
+		return 1
+	}
+	if query.DestinationNamespace, query.DestinationSelector, err = parsePodSelection(to); err != nil {
+		setupLog.Error(err, "invalid --to")
+		return 1
+	}
+
+	c, err := newDirectClient(target)
+	if err != nil {
+		setupLog.Error(err, "unable to connect to cluster", "context", target.Context)
+		return 1
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	paths, err := simulateTraffic(ctx, c, query)
+	if err != nil {
+		setupLog.Error(err, "traffic simulation failed")
+		return 1
+	}
+
+	if output == "json" {
+		results := make([]simulatedPathOutput, 0, len(paths))
+		for _, path := range paths {
+			results = append(results, simulatedPathOutput{
+				Source:      path.Source.Namespace + "/" + path.Source.Name,
+				Destination: path.Destination.Namespace + "/" + path.Destination.Name,
+				Allowed:     path.Allowed,
+				BlockedBy:   path.BlockedBy,
+				Policies:    qualifiedPolicies(path.TrafficVerdict),
+			})
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			setupLog.Error(err, "failed to format output")
+			return 1
+		}
+		fmt.Println(string(data))
+	} else {
+		writeSimulatedPaths(os.Stdout, paths)
+	}
+
+	if failOnBlocked {
+		for _, path := range paths {
+			if !path.Allowed {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// simulateTraffic reads the pods, namespaces and NetworkPolicies of a cluster and
+// evaluates query against them. Pods that are not running are left out.
+func simulateTraffic(ctx context.Context, reader client.Reader, query validators.SimulationQuery) ([]validators.SimulatedPath, error) {
+	var policies networkingv1.NetworkPolicyList
+	if err := reader.List(ctx, &policies); err != nil {
+		return nil, fmt.Errorf("failed to list networkpolicies: %w", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := reader.List(ctx, &namespaces); err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods); err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	running := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			running = append(running, pod)
+		}
+	}
+	return validators.NewNetworkPolicySimulator(policies.Items, namespaces.Items).Simulate(query, running), nil
+}
+
+// parsePodSelection parses namespace/selector, where namespace * selects all namespaces
+func parsePodSelection(value string) (string, labels.Selector, error) {
+	namespace, selector, _ := strings.Cut(value, "/")
+	if namespace == "" {
+		return "", nil, fmt.Errorf("%q names no namespace", value)
+	}
+	if namespace == "*" {
+		namespace = ""
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid selector in %q: %w", value, err)
+	}
+	return namespace, parsed, nil
+}
+
+// qualifiedPolicies returns the blocking policies of a verdict as namespace/name
+func qualifiedPolicies(verdict validators.TrafficVerdict) []string {
+	policies := make([]string, 0, len(verdict.Policies))
+	for _, policy := range verdict.Policies {
+		policies = append(policies, verdict.Namespace+"/"+policy)
+	}
+	return policies
+}
+
+// writeSimulatedPaths writes the verdicts as an aligned table followed by a summary
+func writeSimulatedPaths(w io.Writer, paths []validators.SimulatedPath) {
+	if len(paths) == 0 {
+		_, _ = fmt.Fprintln(w, "No running pods match both selectors.")
+		return
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "SOURCE\tDESTINATION\tVERDICT\tPOLICIES")
+	blocked := 0
+	for _, path := range paths {
+		verdict := "allowed"
+		if !path.Allowed {
+			blocked++
+			verdict = "blocked by " + path.BlockedBy
+		}
+		_, _ = fmt.Fprintf(table, "%s/%s\t%s/%s\t%s\t%s\n", path.Source.Namespace, path.Source.Name,
+			path.Destination.Namespace, path.Destination.Name, verdict, strings.Join(qualifiedPolicies(path.TrafficVerdict), ", "))
+	}
+	_ = table.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d allowed, %d blocked\n", len(paths)-blocked, blocked)
+}
@@ -0,0 +1,563 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/term"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlconfig "sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// tuiCommand is the subcommand that browses the findings of a validation run in an
+// interactive terminal UI
+const tuiCommand = "tui"
+
+// tuiSeverities is the order the severity filter cycles through; empty shows all
+var tuiSeverities = []validators.Severity{"", validators.SeverityError, validators.SeverityWarning, validators.SeverityInfo}
+
+// tuiKeyHelp lists the keys of the finding list
+const tuiKeyHelp = "↑/↓ move  enter expand  n namespace  s severity  / error code  x clear  r re-run  q quit"
+
+// runTUI validates the cluster, or the config file given by --config, and browses
+// the findings in a terminal UI that can re-run the validation on demand. It takes
+// the controller's flags, so they select the enabled validators.
+func runTUI() int {
+	// Logs would overwrite the UI, so they are only shown until it starts
+	logs := &muteWriter{w: os.Stderr}
+	logDestination = logs
+	config := registerFlags()
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		setupLog.Error(nil, "kogaro tui needs an interactive terminal; use --mode=one-off for scripts")
+		return validators.ExitCodeInternalFailure
+	}
+	if config.ValidateConfig == "-" {
+		setupLog.Error(nil, "kogaro tui reads keys from stdin, so --config must name a file")
+		return validators.ExitCodeInternalFailure
+	}
+	if !slices.Contains(validScopes, config.ValidateScope) {
+		setupLog.Error(nil, "invalid validation scope", "scope", config.ValidateScope, "valid", strings.Join(validScopes, ", "))
+		return validators.ExitCodeInternalFailure
+	}
+
+	restConfig, err := ctrlconfig.GetConfigWithContext(config.KubeContext)
+	if err != nil {
+		setupLog.Error(err, "unable to load kubeconfig", "context", config.KubeContext)
+		return validators.ExitCodeInternalFailure
+	}
+	configureAPIClient(restConfig, config)
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                server.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to create manager")
+		return validators.ExitCodeInternalFailure
+	}
+	registry := setupValidators(mgr, config)
+
+	// The manager's cache stays up while the UI runs, so re-runs see the current state
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			setupLog.Error(err, "failed to start manager")
+		}
+	}()
+	syncCtx, syncCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer syncCancel()
+	setupLog.Info("waiting for the cluster cache to sync")
+	if !mgr.GetCache().WaitForCacheSync(syncCtx) {
+		setupLog.Error(nil, "failed to sync cache")
+		return validators.ExitCodeInternalFailure
+	}
+
+	source := "cluster"
+	if restConfig.Host != "" {
+		source = "cluster " + restConfig.Host
+	}
+	scan := func(ctx context.Context) (validators.ValidationResult, error) {
+		if err := registry.ValidateCluster(ctx); err != nil {
+			return validators.ValidationResult{}, err
+		}
+		return registry.LastValidationResult(), nil
+	}
+	if config.ValidateConfig != "" {
+		source = fmt.Sprintf("%s (scope %s)", config.ValidateConfig, config.ValidateScope)
+		scan = func(ctx context.Context) (validators.ValidationResult, error) {
+			result, err := registry.ValidateNewConfigWithScope(ctx, config.ValidateConfig, config.ValidateScope)
+			if err != nil {
+				return validators.ValidationResult{}, err
+			}
+			return *result, nil
+		}
+	}
+
+	logs.Mute(true)
+	err = runTerminalUI(ctx, os.Stdin, os.Stdout, newTUIModel(source), scan)
+	logs.Mute(false)
+	if err != nil {
+		setupLog.Error(err, "terminal UI failed")
+		return validators.ExitCodeInternalFailure
+	}
+	return validators.ExitCodeOK
+}
+
+// muteWriter passes writes through to w unless it is muted
+type muteWriter struct {
+	mu    sync.Mutex
+	w     io.Writer
+	muted bool
+}
+
+// Write writes p to the underlying writer, or drops it while muted
+func (m *muteWriter) Write(p []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.muted {
+		return len(p), nil
+	}
+	return m.w.Write(p)
+}
+
+// Mute starts or stops dropping writes
+func (m *muteWriter) Mute(muted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.muted = muted
+}
+
+// scanOutcome is the result of a validation run started by the UI
+type scanOutcome struct {
+	result   validators.ValidationResult
+	err      error
+	finished time.Time
+	duration time.Duration
+}
+
+// runTerminalUI switches the terminal to raw mode on the alternate screen and runs the
+// UI until the user quits. It scans once at start, and again whenever the user asks.
+func runTerminalUI(ctx context.Context, in *os.File, out io.Writer, model *tuiModel, scan func(context.Context) (validators.ValidationResult, error)) error {
+	fd := int(in.Fd())
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to switch the terminal to raw mode: %w", err)
+	}
+	defer func() { _ = term.Restore(fd, state) }()
+	_, _ = fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer func() { _, _ = fmt.Fprint(out, "\x1b[?25h\x1b[?1049l") }()
+
+	keys := make(chan string)
+	go readKeys(in, keys)
+
+	outcomes := make(chan scanOutcome, 1)
+	startScan := func() {
+		model.scanning = true
+		go func() {
+			started := time.Now()
+			result, err := scan(ctx)
+			outcomes <- scanOutcome{result: result, err: err, finished: time.Now(), duration: time.Since(started)}
+		}()
+	}
+	startScan()
+
+	// The size is checked on every redraw, and the ticker redraws after a resize
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+	width, height := 0, 0
+	for {
+		if w, h, err := term.GetSize(fd); err == nil && (w != width || h != height || model.dirty) {
+			width, height = w, h
+			model.dirty = false
+			_, _ = fmt.Fprint(out, "\x1b[H\x1b[2J"+strings.Join(model.Render(width, height), "\r\n"))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case outcome := <-outcomes:
+			model.SetResult(outcome)
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch model.HandleKey(key) {
+			case tuiQuit:
+				return nil
+			case tuiRescan:
+				if !model.scanning {
+					startScan()
+				}
+			}
+		}
+	}
+}
+
+// readKeys reads key presses from the terminal and sends them as key names, such as
+// "up" or "enter", or as the typed character. It closes keys when input ends.
+func readKeys(in io.Reader, keys chan<- string) {
+	defer close(keys)
+	buf := make([]byte, 64)
+	for {
+		n, err := in.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range parseKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// tuiEscapeKeys maps the escape sequences of special keys to key names
+var tuiEscapeKeys = map[string]string{
+	"\x1b[A": "up", "\x1b[B": "down", "\x1b[5~": "pgup", "\x1b[6~": "pgdown",
+	"\x1b[H": "home", "\x1b[F": "end", "\x1bOA": "up", "\x1bOB": "down",
+}
+
+// parseKeys splits terminal input into key names and typed characters
+func parseKeys(input []byte) []string {
+	var keys []string
+	text := string(input)
+	for len(text) > 0 {
+		if text[0] == 0x1b {
+			matched := false
+			for sequence, key := range tuiEscapeKeys {
+				if strings.HasPrefix(text, sequence) {
+					keys = append(keys, key)
+					text = text[len(sequence):]
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				keys = append(keys, "esc")
+				text = text[1:]
+			}
+			continue
+		}
+		size := 1
+		switch text[0] {
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		case 0x7f, 0x08:
+			keys = append(keys, "backspace")
+		case 0x03:
+			keys = append(keys, "ctrl+c")
+		default:
+			_, size = utf8.DecodeRuneInString(text)
+			keys = append(keys, text[:size])
+		}
+		text = text[size:]
+	}
+	return keys
+}
+
+// tuiAction is what the UI does after a key press
+type tuiAction int
+
+const (
+	tuiNone tuiAction = iota
+	tuiQuit
+	tuiRescan
+)
+
+// tuiModel is the state of the terminal UI: the findings of the last run, the filters
+// applied to them and the position in the list. It does no I/O, so it can be tested.
+type tuiModel struct {
+	source   string
+	result   validators.ValidationResult
+	lastScan scanOutcome
+	scanning bool
+	dirty    bool
+
+	namespace   string
+	severity    validators.Severity
+	code        string
+	editingCode bool
+
+	cursor   int
+	offset   int
+	expanded map[int]bool
+}
+
+// newTUIModel creates the UI state for validating the given source
+func newTUIModel(source string) *tuiModel {
+	return &tuiModel{source: source, expanded: make(map[int]bool), dirty: true}
+}
+
+// SetResult replaces the findings with those of a finished run. A failed run keeps
+// the previous findings and reports the error.
+func (m *tuiModel) SetResult(outcome scanOutcome) {
+	m.scanning = false
+	m.dirty = true
+	m.lastScan = outcome
+	if outcome.err != nil {
+		return
+	}
+	m.result = outcome.result
+	m.expanded = make(map[int]bool)
+	m.cursor, m.offset = 0, 0
+}
+
+// visible returns the indexes of the findings that pass the filters
+func (m *tuiModel) visible() []int {
+	var indexes []int
+	code := strings.ToUpper(m.code)
+	for i, finding := range m.result.Errors {
+		if m.namespace != "" && finding.Namespace != m.namespace {
+			continue
+		}
+		if m.severity != "" && finding.Severity != m.severity {
+			continue
+		}
+		if code != "" && !strings.Contains(finding.ErrorCode, code) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// namespaces returns the namespaces of the findings in order; cluster-scoped findings
+// have none and are only shown without a namespace filter
+func (m *tuiModel) namespaces() []string {
+	seen := make(map[string]bool)
+	var namespaces []string
+	for _, finding := range m.result.Errors {
+		if finding.Namespace != "" && !seen[finding.Namespace] {
+			seen[finding.Namespace] = true
+			namespaces = append(namespaces, finding.Namespace)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces
+}
+
+// HandleKey applies a key press to the state and returns what the UI should do next
+func (m *tuiModel) HandleKey(key string) tuiAction {
+	m.dirty = true
+	if m.editingCode {
+		switch key {
+		case "enter", "esc":
+			m.editingCode = false
+		case "backspace":
+			if m.code != "" {
+				m.code = m.code[:len(m.code)-1]
+			}
+		case "ctrl+c":
+			return tuiQuit
+		default:
+			if len(key) == 1 {
+				m.code += key
+			}
+		}
+		m.cursor, m.offset = 0, 0
+		return tuiNone
+	}
+
+	count := len(m.visible())
+	switch key {
+	case "q", "ctrl+c":
+		return tuiQuit
+	case "r":
+		return tuiRescan
+	case "up", "k":
+		m.cursor--
+	case "down", "j":
+		m.cursor++
+	case "pgup":
+		m.cursor -= 10
+	case "pgdown":
+		m.cursor += 10
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = count - 1
+	case "enter", " ":
+		if visible := m.visible(); m.cursor < len(visible) {
+			index := visible[m.cursor]
+			m.expanded[index] = !m.expanded[index]
+		}
+	case "n":
+		m.namespace = nextValue(m.namespaces(), m.namespace)
+		m.cursor, m.offset = 0, 0
+	case "s":
+		m.severity = nextValue(tuiSeverities, m.severity)
+		m.cursor, m.offset = 0, 0
+	case "/":
+		m.editingCode = true
+	case "x":
+		m.namespace, m.severity, m.code = "", "", ""
+		m.cursor, m.offset = 0, 0
+	}
+	m.cursor = max(0, min(m.cursor, len(m.visible())-1))
+	return tuiNone
+}
+
+// nextValue returns the value after current in a cycle through "" and values
+func nextValue[T comparable](values []T, current T) T {
+	var all T
+	if current == all && len(values) > 0 && values[0] != all {
+		return values[0]
+	}
+	for i, value := range values {
+		if value == current && i+1 < len(values) {
+			return values[i+1]
+		}
+	}
+	return all
+}
+
+// Render draws the UI as lines that fit a terminal of the given size: a header with
+// the run and the filters, the findings, and a line of key help
+func (m *tuiModel) Render(width, height int) []string {
+	var lines []string
+	status := "scanned " + m.lastScan.finished.Format(time.TimeOnly) + fmt.Sprintf(" in %s", m.lastScan.duration.Round(time.Millisecond))
+	switch {
+	case m.scanning:
+		status = "scanning..."
+	case m.lastScan.err != nil:
+		status = "last run failed: " + m.lastScan.err.Error()
+	}
+	lines = append(lines, truncate(fmt.Sprintf("Kogaro: %s, %s", m.source, status), width))
+
+	visible := m.visible()
+	counts := make(map[validators.Severity]int)
+	for _, index := range visible {
+		counts[m.result.Errors[index].Severity]++
+	}
+	lines = append(lines, truncate(fmt.Sprintf("Showing %d of %d findings (%d errors, %d warnings, %d info)  namespace: %s  severity: %s  error code: %s",
+		len(visible), len(m.result.Errors), counts[validators.SeverityError], counts[validators.SeverityWarning], counts[validators.SeverityInfo],
+		orAll(m.namespace), orAll(string(m.severity)), orAll(m.code)), width), "")
+
+	footer := tuiKeyHelp
+	if m.editingCode {
+		footer = "Error code filter: " + m.code + "_  (enter or esc to finish)"
+	}
+	listHeight := max(1, height-len(lines)-1)
+
+	// Scroll so the selected finding and its expanded lines are on screen
+	blocks := make([][]string, len(visible))
+	for i, index := range visible {
+		blocks[i] = m.renderFinding(index, i == m.cursor, width)
+	}
+	m.offset = min(m.offset, m.cursor)
+	for m.offset < m.cursor && blockHeight(blocks[m.offset:m.cursor+1]) > listHeight {
+		m.offset++
+	}
+
+	var list []string
+	if len(visible) == 0 {
+		list = append(list, "  No findings match the filters.")
+	}
+	for _, block := range blocks[m.offset:] {
+		if len(list)+len(block) > listHeight && len(list) > 0 {
+			break
+		}
+		list = append(list, block...)
+	}
+	if len(list) > listHeight {
+		list = list[:listHeight]
+	}
+	for len(list) < listHeight {
+		list = append(list, "")
+	}
+	lines = append(lines, list...)
+	return append(lines, truncate(footer, width))
+}
+
+// renderFinding draws a finding as a line, followed by its remediation hint, details,
+// related resources and documentation link when it is expanded
+func (m *tuiModel) renderFinding(index int, selected bool, width int) []string {
+	finding := m.result.Errors[index]
+	marker := "+"
+	if m.expanded[index] {
+		marker = "-"
+	}
+	line := truncate(fmt.Sprintf("%s %-7s %-15s %s/%s: %s", marker, finding.Severity, finding.ErrorCode,
+		finding.ResourceType, finding.GetResourceKey(), finding.Message), width)
+	switch {
+	case selected:
+		line = "\x1b[7m" + line + "\x1b[0m"
+	case finding.Severity == validators.SeverityError:
+		line = "\x1b[31m" + line + "\x1b[0m"
+	case finding.Severity == validators.SeverityWarning:
+		line = "\x1b[33m" + line + "\x1b[0m"
+	}
+	block := []string{line}
+	if !m.expanded[index] {
+		return block
+	}
+
+	detail := func(label, value string) {
+		if value != "" {
+			block = append(block, truncate("    "+label+": "+value, width))
+		}
+	}
+	detail("Hint", finding.RemediationHint)
+	keys := make([]string, 0, len(finding.Details))
+	for key := range finding.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		detail(key, finding.Details[key])
+	}
+	detail("Related", strings.Join(finding.RelatedResources, ", "))
+	if info, ok := validators.LookupErrorCode(finding.ErrorCode); ok {
+		detail("Docs", info.DocURL)
+	}
+	return block
+}
+
+// blockHeight returns the number of lines of a run of findings
+func blockHeight(blocks [][]string) int {
+	height := 0
+	for _, block := range blocks {
+		height += len(block)
+	}
+	return height
+}
+
+// truncate shortens a line to width characters
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	if width == 1 {
+		return string(runes[:1])
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// orAll returns the value of a filter, or "all" when it is not set
+func orAll(value string) string {
+	if value == "" {
+		return "all"
+	}
+	return value
+}
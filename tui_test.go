@@ -0,0 +1,123 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+func TestParseKeys(t *testing.T) {
+	got := parseKeys([]byte("j\x1b[A\x1b[6~\r\x7f\x1bq/é"))
+	want := []string{"j", "up", "pgdown", "enter", "backspace", "esc", "q", "/", "é"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeys() = %q, want %q", got, want)
+	}
+}
+
+func TestTUIModel_FiltersAndExpands(t *testing.T) {
+	findings := []validators.ValidationError{
+		validators.NewValidationErrorWithCode("Deployment", "web", "shop", "dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'web-config' does not exist").
+			WithSeverity(validators.SeverityError).
+			WithRemediationHint("Create the ConfigMap").
+			WithDetail("configmap", "web-config"),
+		validators.NewValidationErrorWithCode("Deployment", "web", "shop", "missing_resource_requests", "KOGARO-RES-001", "No resource requests").
+			WithSeverity(validators.SeverityWarning),
+		validators.NewValidationErrorWithCode("Deployment", "api", "billing", "missing_resource_requests", "KOGARO-RES-001", "No resource requests").
+			WithSeverity(validators.SeverityWarning),
+	}
+
+	model := newTUIModel("manifests.yaml")
+	model.SetResult(scanOutcome{result: validators.ValidationResult{Errors: findings}})
+	if got := len(model.visible()); got != 3 {
+		t.Fatalf("visible findings = %d, want 3", got)
+	}
+
+	// Namespaces cycle in order, then back to all
+	model.HandleKey("n")
+	if model.namespace != "billing" || len(model.visible()) != 1 {
+		t.Errorf("namespace filter = %q showing %d findings, want billing showing 1", model.namespace, len(model.visible()))
+	}
+	model.HandleKey("n")
+	model.HandleKey("n")
+	if model.namespace != "" {
+		t.Errorf("namespace filter = %q, want all", model.namespace)
+	}
+
+	model.HandleKey("s")
+	if model.severity != validators.SeverityError || len(model.visible()) != 1 {
+		t.Errorf("severity filter = %q showing %d findings, want error showing 1", model.severity, len(model.visible()))
+	}
+	model.HandleKey("x")
+
+	for _, key := range []string{"/", "r", "e", "s", "enter"} {
+		model.HandleKey(key)
+	}
+	if model.code != "res" || len(model.visible()) != 2 {
+		t.Errorf("error code filter = %q showing %d findings, want res showing 2", model.code, len(model.visible()))
+	}
+	model.HandleKey("x")
+
+	model.HandleKey("enter")
+	lines := model.Render(120, 12)
+	if len(lines) != 12 {
+		t.Fatalf("Render() returned %d lines, want 12", len(lines))
+	}
+	screen := strings.Join(lines, "\n")
+	for _, want := range []string{
+		"Showing 3 of 3 findings (1 errors, 2 warnings, 0 info)",
+		"KOGARO-REF-003  Deployment/shop/web: ConfigMap 'web-config' does not exist",
+		"    Hint: Create the ConfigMap",
+		"    configmap: web-config",
+		"    Docs: " + validators.ErrorCodesDocURL + "#reference-validation-ref",
+	} {
+		if !strings.Contains(screen, want) {
+			t.Errorf("Render() is missing %q:\n%s", want, screen)
+		}
+	}
+
+	if model.HandleKey("r") != tuiRescan || model.HandleKey("q") != tuiQuit {
+		t.Error("r and q do not re-run and quit")
+	}
+}
+
+func TestTUIModel_FailedRunKeepsFindings(t *testing.T) {
+	model := newTUIModel("cluster")
+	model.SetResult(scanOutcome{result: validators.ValidationResult{Errors: []validators.ValidationError{
+		validators.NewValidationErrorWithCode("Pod", "web", "shop", "missing_resource_requests", "KOGARO-RES-001", "No resource requests"),
+	}}})
+	model.SetResult(scanOutcome{err: errors.New("connection refused")})
+
+	lines := model.Render(80, 6)
+	if !strings.Contains(lines[0], "last run failed: connection refused") {
+		t.Errorf("header = %q", lines[0])
+	}
+	if len(model.visible()) != 1 {
+		t.Errorf("visible findings = %d, want the previous run's finding", len(model.visible()))
+	}
+}
+
+func TestTUIModel_ScrollsToCursor(t *testing.T) {
+	var findings []validators.ValidationError
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		findings = append(findings, validators.NewValidationErrorWithCode("Pod", name, "shop", "missing_resource_requests", "KOGARO-RES-001", "No resource requests"))
+	}
+	model := newTUIModel("cluster")
+	model.SetResult(scanOutcome{result: validators.ValidationResult{Errors: findings}})
+
+	model.HandleKey("end")
+	lines := model.Render(80, 7)
+	if !strings.Contains(strings.Join(lines, "\n"), "Pod/shop/f") {
+		t.Errorf("Render() does not show the selected finding:\n%s", strings.Join(lines, "\n"))
+	}
+	if strings.Contains(strings.Join(lines, "\n"), "Pod/shop/a") {
+		t.Errorf("Render() did not scroll:\n%s", strings.Join(lines, "\n"))
+	}
+}
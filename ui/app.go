@@ -132,6 +132,13 @@ func (a *App) setupRegistry(c client.Client) *validators.ValidatorRegistry {
 		EnableIngressValidation:       true,
 	}))
 
+	registry.Register(validators.NewVolumeValidator(c, logger, validators.VolumeConfig{
+		EnableDuplicateVolumeValidation: true,
+		EnableMountPathValidation:       true,
+		EnableSubPathValidation:         true,
+		EnableReadOnlyMountValidation:   true,
+	}))
+
 	return registry
 }
 
@@ -252,7 +259,12 @@ func (a *App) Scan() (*graph.FaultGraph, error) {
 	// Save to history database
 	if a.history != nil {
 		ctxName, _ := a.kubeMgr.GetCurrentContext()
-		if _, err := a.history.SaveScan(ctxName, fg); err != nil {
+		// Clean namespaces count towards the recorded cluster score
+		namespaces, err := validators.ScoredNamespaces(a.ctx, a.k8sClient)
+		if err != nil {
+			log.Printf("Warning: could not list namespaces for hygiene scores: %v", err)
+		}
+		if _, err := a.history.SaveScan(ctxName, fg, namespaces); err != nil {
 			log.Printf("Warning: could not save scan to history: %v", err)
 		}
 	}
@@ -330,6 +342,16 @@ func (a *App) GetScanHistory(limit int) ([]history.ScanRecord, error) {
 	return a.history.GetScanHistory(ctxName, limit)
 }
 
+// GetScoreHistory returns the hygiene scores of recent scans for the current context,
+// oldest first.
+func (a *App) GetScoreHistory(limit int) ([]history.ScorePoint, error) {
+	if a.history == nil {
+		return nil, fmt.Errorf("history database not available")
+	}
+	ctxName, _ := a.kubeMgr.GetCurrentContext()
+	return a.history.GetScoreHistory(ctxName, limit)
+}
+
 // GetScanDiff compares two scans and returns new/fixed errors.
 func (a *App) GetScanDiff(olderScanID, newerScanID uint) (*history.ScanDiff, error) {
 	if a.history == nil {
@@ -224,6 +224,12 @@ func categoryFromCode(code string) string {
 		return "resource_limits"
 	case strings.HasPrefix(code, "KOGARO-IMG"):
 		return "image"
+	case strings.HasPrefix(code, "KOGARO-SCR"):
+		return "secret"
+	case strings.HasPrefix(code, "KOGARO-VOL"):
+		return "volume"
+	case strings.HasPrefix(code, "KOGARO-QTA"):
+		return "quota"
 	default:
 		return "unknown"
 	}
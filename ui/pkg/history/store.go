@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/topiaruss/kogaro/internal/validators"
 	"github.com/topiaruss/kogaro/ui/pkg/graph"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -15,20 +16,23 @@ import (
 
 // ScanRecord represents a single scan run.
 type ScanRecord struct {
-	ID            uint         `json:"id" gorm:"primaryKey"`
-	Context       string       `json:"context" gorm:"index;not null"`
-	ScanTime      time.Time    `json:"scanTime" gorm:"not null"`
-	NodeCount     int          `json:"nodeCount"`
-	EdgeCount     int          `json:"edgeCount"`
-	IncidentCount int          `json:"incidentCount"`
-	ErrorCount    int          `json:"errorCount"`
-	Errors []ErrorRecord `json:"errors,omitempty"`
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	Context       string    `json:"context" gorm:"index;not null"`
+	ScanTime      time.Time `json:"scanTime" gorm:"not null"`
+	NodeCount     int       `json:"nodeCount"`
+	EdgeCount     int       `json:"edgeCount"`
+	IncidentCount int       `json:"incidentCount"`
+	ErrorCount    int       `json:"errorCount"`
+	// HygieneScore is nil for scans saved before hygiene scores were recorded.
+	HygieneScore    *int                   `json:"hygieneScore,omitempty"`
+	Errors          []ErrorRecord          `json:"errors,omitempty"`
+	NamespaceScores []NamespaceScoreRecord `json:"namespaceScores,omitempty"`
 }
 
 // ErrorRecord represents a single validation error within a scan.
 type ErrorRecord struct {
-	ID             uint   `json:"id" gorm:"primaryKey"`
-	ScanRecordID   uint   `json:"scanId" gorm:"index;not null"`
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	ScanRecordID uint   `json:"scanId" gorm:"index;not null"`
 	ErrorCode    string `json:"errorCode" gorm:"not null"`
 	ResourceType string `json:"resourceType" gorm:"not null"`
 	ResourceName string `json:"resourceName" gorm:"not null"`
@@ -37,6 +41,22 @@ type ErrorRecord struct {
 	Severity     string `json:"severity" gorm:"default:'error'"`
 }
 
+// NamespaceScoreRecord is the hygiene score of one namespace within a scan.
+type NamespaceScoreRecord struct {
+	ID           uint   `json:"id" gorm:"primaryKey"`
+	ScanRecordID uint   `json:"scanId" gorm:"index;not null"`
+	Namespace    string `json:"namespace" gorm:"not null"`
+	Score        int    `json:"score"`
+}
+
+// ScorePoint is the hygiene score of one scan, for trend charts.
+type ScorePoint struct {
+	ScanID          uint           `json:"scanId"`
+	ScanTime        time.Time      `json:"scanTime"`
+	HygieneScore    int            `json:"hygieneScore"`
+	NamespaceScores map[string]int `json:"namespaceScores"`
+}
+
 // DiagnosticRecord persists a diagnostic run result.
 type DiagnosticRecord struct {
 	ID           uint      `json:"id" gorm:"primaryKey"`
@@ -101,15 +121,18 @@ func Open(path string) (*Store, error) {
 	if err != nil {
 		return nil, fmt.Errorf("opening database: %w", err)
 	}
-	if err := db.AutoMigrate(&ScanRecord{}, &ErrorRecord{}, &DiagnosticRecord{}, &FixAttempt{}); err != nil {
+	if err := db.AutoMigrate(&ScanRecord{}, &ErrorRecord{}, &NamespaceScoreRecord{}, &DiagnosticRecord{}, &FixAttempt{}); err != nil {
 		return nil, fmt.Errorf("migrating database: %w", err)
 	}
 	return &Store{db: db}, nil
 }
 
-// SaveScan persists a FaultGraph scan result.
-func (s *Store) SaveScan(kubeContext string, fg *graph.FaultGraph) (uint, error) {
+// SaveScan persists a FaultGraph scan result with the hygiene scores of the cluster
+// and of each namespace. Namespaces lists the namespaces scanned, which score 100
+// without errors; when it is empty only the namespaces with errors are rated.
+func (s *Store) SaveScan(kubeContext string, fg *graph.FaultGraph, namespaces []string) (uint, error) {
 	var errors []ErrorRecord
+	var findings []validators.ValidationError
 	for _, inc := range fg.Incidents {
 		for _, e := range inc.Errors {
 			errors = append(errors, ErrorRecord{
@@ -120,17 +143,30 @@ func (s *Store) SaveScan(kubeContext string, fg *graph.FaultGraph) (uint, error)
 				Message:      e.Message,
 				Severity:     e.Severity,
 			})
+			findings = append(findings, validators.ValidationError{
+				ErrorCode: e.ErrorCode,
+				Namespace: e.Namespace,
+				Severity:  validators.Severity(e.Severity),
+			})
 		}
 	}
 
+	scores := validators.ScoreFindings(findings, namespaces)
+	var namespaceScores []NamespaceScoreRecord
+	for namespace, score := range scores.Namespaces {
+		namespaceScores = append(namespaceScores, NamespaceScoreRecord{Namespace: namespace, Score: score})
+	}
+
 	record := ScanRecord{
-		Context:       kubeContext,
-		ScanTime:      fg.ScanTime,
-		NodeCount:     len(fg.Nodes),
-		EdgeCount:     len(fg.Edges),
-		IncidentCount: len(fg.Incidents),
-		ErrorCount:    len(errors),
-		Errors:        errors,
+		Context:         kubeContext,
+		ScanTime:        fg.ScanTime,
+		NodeCount:       len(fg.Nodes),
+		EdgeCount:       len(fg.Edges),
+		IncidentCount:   len(fg.Incidents),
+		ErrorCount:      len(errors),
+		HygieneScore:    &scores.Cluster,
+		Errors:          errors,
+		NamespaceScores: namespaceScores,
 	}
 
 	if err := s.db.Create(&record).Error; err != nil {
@@ -152,6 +188,38 @@ func (s *Store) GetScanHistory(kubeContext string, limit int) ([]ScanRecord, err
 	return records, err
 }
 
+// GetScoreHistory returns the hygiene scores of recent scans, oldest first, for
+// trend charts. Scans saved before hygiene scores were recorded are skipped.
+func (s *Store) GetScoreHistory(kubeContext string, limit int) ([]ScorePoint, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var records []ScanRecord
+	err := s.db.Preload("NamespaceScores").
+		Where("context = ? AND hygiene_score IS NOT NULL", kubeContext).
+		Order("scan_time desc").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]ScorePoint, 0, len(records))
+	for i := len(records) - 1; i >= 0; i-- {
+		point := ScorePoint{
+			ScanID:          records[i].ID,
+			ScanTime:        records[i].ScanTime,
+			HygieneScore:    *records[i].HygieneScore,
+			NamespaceScores: make(map[string]int, len(records[i].NamespaceScores)),
+		}
+		for _, ns := range records[i].NamespaceScores {
+			point.NamespaceScores[ns.Namespace] = ns.Score
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
 // GetScanErrors returns errors for a specific scan.
 func (s *Store) GetScanErrors(scanID uint) ([]ErrorRecord, error) {
 	var records []ErrorRecord
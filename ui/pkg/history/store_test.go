@@ -39,7 +39,7 @@ func TestSaveScanAndHistory(t *testing.T) {
 		},
 	}
 
-	scanID, err := s.SaveScan("docker-desktop", fg)
+	scanID, err := s.SaveScan("docker-desktop", fg, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -76,7 +76,7 @@ func TestGetScanErrors(t *testing.T) {
 			},
 		}},
 	}
-	scanID, _ := s.SaveScan("ctx", fg)
+	scanID, _ := s.SaveScan("ctx", fg, nil)
 
 	errors, err := s.GetScanErrors(scanID)
 	if err != nil {
@@ -100,7 +100,7 @@ func TestDiffScans(t *testing.T) {
 			},
 		}},
 	}
-	id1, _ := s.SaveScan("ctx", fg1)
+	id1, _ := s.SaveScan("ctx", fg1, nil)
 
 	fg2 := &graph.FaultGraph{
 		ScanTime: time.Now(),
@@ -112,7 +112,7 @@ func TestDiffScans(t *testing.T) {
 			},
 		}},
 	}
-	id2, _ := s.SaveScan("ctx", fg2)
+	id2, _ := s.SaveScan("ctx", fg2, nil)
 
 	diff, err := s.DiffScans(id1, id2)
 	if err != nil {
@@ -155,9 +155,9 @@ func TestHistoryFiltersByContext(t *testing.T) {
 	s := testStore(t)
 
 	fg := &graph.FaultGraph{ScanTime: time.Now(), Nodes: []graph.Node{{ID: "Pod/default/x"}}}
-	s.SaveScan("ctx-a", fg)
-	s.SaveScan("ctx-b", fg)
-	s.SaveScan("ctx-a", fg)
+	s.SaveScan("ctx-a", fg, nil)
+	s.SaveScan("ctx-b", fg, nil)
+	s.SaveScan("ctx-a", fg, nil)
 
 	histA, _ := s.GetScanHistory("ctx-a", 10)
 	histB, _ := s.GetScanHistory("ctx-b", 10)
@@ -169,3 +169,45 @@ func TestHistoryFiltersByContext(t *testing.T) {
 		t.Errorf("expected 1 scan for ctx-b, got %d", len(histB))
 	}
 }
+
+func TestGetScoreHistory(t *testing.T) {
+	s := testStore(t)
+
+	start := time.Now().Add(-time.Hour)
+	broken := &graph.FaultGraph{
+		ScanTime: start,
+		Incidents: []graph.Incident{{
+			ID: "INC-001",
+			Errors: []graph.ErrorDetail{
+				{ErrorCode: "KOGARO-SEC-006", ResourceType: "Pod", ResourceName: "foo", Namespace: "shop", Message: "privileged", Severity: "error"},
+				{ErrorCode: "KOGARO-RES-002", ResourceType: "Pod", ResourceName: "bar", Namespace: "billing", Message: "no requests", Severity: "warning"},
+			},
+		}},
+	}
+	fixed := &graph.FaultGraph{ScanTime: start.Add(30 * time.Minute)}
+	namespaces := []string{"shop", "billing", "web", "docs"}
+	s.SaveScan("ctx", broken, namespaces)
+	s.SaveScan("ctx", fixed, namespaces)
+	s.SaveScan("other", broken, nil)
+
+	points, err := s.GetScoreHistory("ctx", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	// Oldest first, for charting; clean namespaces count towards the cluster score
+	if points[0].HygieneScore != 94 || points[0].NamespaceScores["shop"] != 80 || points[0].NamespaceScores["billing"] != 97 ||
+		points[0].NamespaceScores["web"] != 100 {
+		t.Errorf("unexpected first point: %+v", points[0])
+	}
+	if points[1].HygieneScore != 100 || len(points[1].NamespaceScores) != 4 || points[1].NamespaceScores["docs"] != 100 {
+		t.Errorf("unexpected second point: %+v", points[1])
+	}
+
+	history, _ := s.GetScanHistory("ctx", 1)
+	if len(history) != 1 || history[0].HygieneScore == nil || *history[0].HygieneScore != 100 {
+		t.Errorf("expected the latest scan to record its score, got %+v", history)
+	}
+}
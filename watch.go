@@ -0,0 +1,161 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// validateCommand is the subcommand for CLI validation. It takes the controller's
+// flags and defaults to --mode=one-off.
+const validateCommand = "validate"
+
+// watchManifestDirectory validates every manifest under a directory against the
+// cluster, then watches the directory tree and re-validates each manifest as soon as
+// it changes, until the context is done. After each run it writes how the findings of
+// the file changed since it was last validated; deleted files have all their findings
+// resolved. Hidden directories and kustomization files are skipped, as in --gitops.
+func watchManifestDirectory(ctx context.Context, dir string, validate func(ctx context.Context, path string) (*validators.ValidationResult, error), out io.Writer) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	files, err := watchTree(watcher, dir)
+	if err != nil {
+		return err
+	}
+
+	previous := make(map[string]*validators.ValidationResult)
+	run := func(path string) {
+		label := path
+		if rel, err := filepath.Rel(dir, path); err == nil {
+			label = rel
+		}
+
+		if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			if last, ok := previous[path]; ok {
+				writeFindingsDelta(out, time.Now(), label+" (deleted)", last, validators.ValidationResult{})
+				delete(previous, path)
+			}
+			return
+		}
+		result, err := validate(ctx, path)
+		if err != nil {
+			setupLog.Error(err, "validation failed", "file", path)
+			return
+		}
+		writeFindingsDelta(out, time.Now(), label, previous[path], *result)
+		previous[path] = result
+	}
+	for _, path := range files {
+		run(path)
+	}
+
+	// Editors emit several events while saving, so a file is validated once they settle
+	pending := make(map[string]bool)
+	reload := time.NewTimer(configReloadDelay)
+	reload.Stop()
+	defer reload.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					// Manifests in a new directory are validated as they are found
+					added, err := watchTree(watcher, event.Name)
+					if err != nil {
+						setupLog.Error(err, "failed to watch directory", "dir", event.Name)
+					}
+					for _, path := range added {
+						pending[path] = true
+					}
+					reload.Reset(configReloadDelay)
+					continue
+				}
+			}
+			if validators.IsManifestFile(filepath.Base(event.Name)) && !hiddenPath(dir, event.Name) {
+				pending[filepath.Clean(event.Name)] = true
+				reload.Reset(configReloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			setupLog.Error(err, "error watching manifest directory", "dir", dir)
+		case <-reload.C:
+			paths := make([]string, 0, len(pending))
+			for path := range pending {
+				paths = append(paths, path)
+			}
+			sort.Strings(paths)
+			clear(pending)
+			for _, path := range paths {
+				run(path)
+			}
+		}
+	}
+}
+
+// watchTree adds a directory and its subdirectories to the watcher, skipping hidden
+// directories, and returns the manifests it found in them
+func watchTree(watcher *fsnotify.Watcher, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return watcher.Add(path)
+		}
+		if validators.IsManifestFile(entry.Name()) {
+			files = append(files, filepath.Clean(path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	return files, nil
+}
+
+// hiddenPath reports whether a path below dir is inside a hidden directory or is a
+// hidden file, such as an editor's swap file
+func hiddenPath(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		if strings.HasPrefix(part, ".") && part != "." && part != ".." {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 Russell Ferriday
+// Licensed under the Apache License, Version 2.0
+//
+// Kogaro - Kubernetes Configuration Hygiene Agent
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/topiaruss/kogaro/internal/validators"
+)
+
+// chanWriter sends each write to a channel, so tests can wait for output
+type chanWriter chan string
+
+func (c chanWriter) Write(p []byte) (int, error) {
+	c <- string(p)
+	return len(p), nil
+}
+
+func TestWatchManifestDirectory(t *testing.T) {
+	dir := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		path = filepath.Join(dir, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	write("web.yaml", "kind: Deployment\n")
+	write("db/statefulset.yml", "kind: StatefulSet\n")
+	write("kustomization.yaml", "resources: []\n")
+	write(".git/config.yaml", "kind: ConfigMap\n")
+
+	// Each file has one finding until it mentions a ConfigMap
+	validate := func(_ context.Context, path string) (*validators.ValidationResult, error) {
+		data, err := os.ReadFile(path) // nolint:gosec // Test file
+		if err != nil {
+			return nil, err
+		}
+		result := &validators.ValidationResult{}
+		if !strings.Contains(string(data), "ConfigMap") {
+			result.Errors = append(result.Errors, validators.NewValidationErrorWithCode("Deployment", filepath.Base(path), "shop",
+				"dangling_configmap_volume", "KOGARO-REF-003", "ConfigMap 'settings' does not exist"))
+		}
+		return result, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := make(chanWriter, 20)
+	done := make(chan error)
+	go func() {
+		done <- watchManifestDirectory(ctx, dir, validate, out)
+	}()
+
+	expect := func(prefix string) {
+		t.Helper()
+		for {
+			select {
+			case line := <-out:
+				if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+					continue
+				}
+				if !strings.Contains(line, "] "+prefix) {
+					t.Fatalf("output = %q, want %q", line, prefix)
+				}
+				return
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out waiting for %q", prefix)
+			}
+		}
+	}
+
+	// Every manifest is validated at start, skipping hidden directories and kustomizations
+	expect(filepath.Join("db", "statefulset.yml") + ": 1 findings (1 new, 0 resolved, 0 unchanged)")
+	expect("web.yaml: 1 findings (1 new, 0 resolved, 0 unchanged)")
+
+	write("web.yaml", "kind: Deployment\n---\nkind: ConfigMap\n")
+	expect("web.yaml: 0 findings (0 new, 1 resolved, 0 unchanged)")
+
+	write("cache/redis.yaml", "kind: Deployment\n")
+	expect(filepath.Join("cache", "redis.yaml") + ": 1 findings (1 new, 0 resolved, 0 unchanged)")
+
+	if err := os.Remove(filepath.Join(dir, "db", "statefulset.yml")); err != nil {
+		t.Fatalf("failed to remove manifest: %v", err)
+	}
+	expect(filepath.Join("db", "statefulset.yml") + " (deleted): 0 findings (0 new, 1 resolved, 0 unchanged)")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("watchManifestDirectory() error = %v", err)
+	}
+}